@@ -0,0 +1,122 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/payloads"
+)
+
+type Differ struct {
+	DiffStub        func(int, payloads.ManifestApplication, manifest.AppState) ([]manifest.DiffEntry, error)
+	diffMutex       sync.RWMutex
+	diffArgsForCall []struct {
+		arg1 int
+		arg2 payloads.ManifestApplication
+		arg3 manifest.AppState
+	}
+	diffReturns struct {
+		result1 []manifest.DiffEntry
+		result2 error
+	}
+	diffReturnsOnCall map[int]struct {
+		result1 []manifest.DiffEntry
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Differ) Diff(arg1 int, arg2 payloads.ManifestApplication, arg3 manifest.AppState) ([]manifest.DiffEntry, error) {
+	fake.diffMutex.Lock()
+	ret, specificReturn := fake.diffReturnsOnCall[len(fake.diffArgsForCall)]
+	fake.diffArgsForCall = append(fake.diffArgsForCall, struct {
+		arg1 int
+		arg2 payloads.ManifestApplication
+		arg3 manifest.AppState
+	}{arg1, arg2, arg3})
+	stub := fake.DiffStub
+	fakeReturns := fake.diffReturns
+	fake.recordInvocation("Diff", []interface{}{arg1, arg2, arg3})
+	fake.diffMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *Differ) DiffCallCount() int {
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	return len(fake.diffArgsForCall)
+}
+
+func (fake *Differ) DiffCalls(stub func(int, payloads.ManifestApplication, manifest.AppState) ([]manifest.DiffEntry, error)) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = stub
+}
+
+func (fake *Differ) DiffArgsForCall(i int) (int, payloads.ManifestApplication, manifest.AppState) {
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	argsForCall := fake.diffArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Differ) DiffReturns(result1 []manifest.DiffEntry, result2 error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = nil
+	fake.diffReturns = struct {
+		result1 []manifest.DiffEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Differ) DiffReturnsOnCall(i int, result1 []manifest.DiffEntry, result2 error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = nil
+	if fake.diffReturnsOnCall == nil {
+		fake.diffReturnsOnCall = make(map[int]struct {
+			result1 []manifest.DiffEntry
+			result2 error
+		})
+	}
+	fake.diffReturnsOnCall[i] = struct {
+		result1 []manifest.DiffEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Differ) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Differ) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ actions.Differ = new(Differ)
@@ -2,6 +2,7 @@ package manifest
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"maps"
 	"slices"
@@ -23,6 +24,7 @@ type Applier struct {
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
+	stackRepo           shared.CFStackRepository
 }
 
 func NewApplier(
@@ -32,6 +34,7 @@ func NewApplier(
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
+	stackRepo shared.CFStackRepository,
 ) *Applier {
 	return &Applier{
 		appRepo:             appRepo,
@@ -40,6 +43,7 @@ func NewApplier(
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
+		stackRepo:           stackRepo,
 	}
 }
 
@@ -68,7 +72,12 @@ func (a *Applier) applyApp(
 	appState AppState,
 ) (AppState, error) {
 	if appState.App.GUID == "" {
-		appRecord, err := a.appRepo.CreateApp(ctx, authInfo, appInfo.ToAppCreateMessage(spaceGUID))
+		createMessage := appInfo.ToAppCreateMessage(spaceGUID)
+		if err := a.applyDefaultStack(ctx, authInfo, &createMessage.Lifecycle); err != nil {
+			return AppState{}, err
+		}
+
+		appRecord, err := a.appRepo.CreateApp(ctx, authInfo, createMessage)
 		return AppState{App: appRecord}, err
 	} else {
 		_, err := a.appRepo.PatchApp(ctx, authInfo, appInfo.ToAppPatchMessage(appState.App.GUID, spaceGUID))
@@ -76,6 +85,23 @@ func (a *Applier) applyApp(
 	}
 }
 
+func (a *Applier) applyDefaultStack(ctx context.Context, authInfo authorization.Info, lifecycle *repositories.Lifecycle) error {
+	if lifecycle.Type != string(korifiv1alpha1.BuildpackLifecycle) || lifecycle.Data.Stack != "" {
+		return nil
+	}
+
+	stack, err := a.stackRepo.GetDefaultStack(ctx, authInfo)
+	if err != nil {
+		if errors.As(err, &apierrors.NotFoundError{}) {
+			return nil
+		}
+		return fmt.Errorf("failed to get the default stack: %w", err)
+	}
+
+	lifecycle.Data.Stack = stack.Name
+	return nil
+}
+
 func (a *Applier) applyProcesses(
 	ctx context.Context,
 	authInfo authorization.Info,
@@ -109,7 +135,7 @@ func (a *Applier) applyRoutes(ctx context.Context, authInfo authorization.Info,
 
 func (a *Applier) createOrUpdateRoutes(ctx context.Context, authInfo authorization.Info, appInfo payloads.ManifestApplication, appState AppState) error {
 	for _, route := range appInfo.Routes {
-		err := a.createOrUpdateRoute(ctx, authInfo, *route.Route, appState)
+		err := a.createOrUpdateRoute(ctx, authInfo, route, appState)
 		if err != nil {
 			return fmt.Errorf("createOrUpdateRoutes: %w", err)
 		}
@@ -118,9 +144,10 @@ func (a *Applier) createOrUpdateRoutes(ctx context.Context, authInfo authorizati
 	return nil
 }
 
-func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorization.Info, routeString string, appState AppState) error {
-	if _, routeExists := appState.Routes[routeString]; routeExists {
-		return nil
+func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorization.Info, route payloads.ManifestRoute, appState AppState) error {
+	routeString := *route.Route
+	if existingRoute, routeExists := appState.Routes[routeString]; routeExists {
+		return a.updateDestinationProtocols(ctx, authInfo, existingRoute, route.Protocol, appState.App.GUID)
 	}
 
 	hostName, domainName, path := splitRoute(routeString)
@@ -159,6 +186,7 @@ func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorizatio
 		NewDestinations: []repositories.DesiredDestination{{
 			AppGUID:     appState.App.GUID,
 			ProcessType: korifiv1alpha1.ProcessTypeWeb,
+			Protocol:    route.Protocol,
 		}},
 	})
 	if err != nil {
@@ -168,6 +196,36 @@ func (a *Applier) createOrUpdateRoute(ctx context.Context, authInfo authorizatio
 	return nil
 }
 
+// updateDestinationProtocols updates the protocol of the app web process
+// destinations of an already mapped route if the manifest specifies another one
+func (a *Applier) updateDestinationProtocols(ctx context.Context, authInfo authorization.Info, route repositories.RouteRecord, protocol *string, appGUID string) error {
+	if protocol == nil {
+		return nil
+	}
+
+	for _, destination := range route.Destinations {
+		if destination.AppGUID != appGUID || destination.ProcessType != korifiv1alpha1.ProcessTypeWeb {
+			continue
+		}
+
+		if destination.Protocol != nil && *destination.Protocol == *protocol {
+			continue
+		}
+
+		_, err := a.routeRepo.UpdateDestination(ctx, authInfo, repositories.UpdateDestinationMessage{
+			RouteGUID: route.GUID,
+			SpaceGUID: route.SpaceGUID,
+			GUID:      destination.GUID,
+			Protocol:  protocol,
+		})
+		if err != nil {
+			return fmt.Errorf("updateDestination: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (a *Applier) deleteAppDestinations(
 	ctx context.Context,
 	authInfo authorization.Info,
@@ -27,6 +27,7 @@ var _ = Describe("Applier", func() {
 		routeRepo           *fake.CFRouteRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
+		stackRepo           *fake.CFStackRepository
 		applier             *manifest.Applier
 		applierErr          error
 		ctx                 context.Context
@@ -42,7 +43,9 @@ var _ = Describe("Applier", func() {
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
-		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo)
+		stackRepo = new(fake.CFStackRepository)
+		stackRepo.GetDefaultStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, stackRepo)
 		ctx = context.Background()
 		authInfo = authorization.Info{Token: "a-token"}
 		appInfo = payloads.ManifestApplication{
@@ -95,6 +98,47 @@ var _ = Describe("Applier", func() {
 			Expect(createAppMsg.Annotations).To(Equal(map[string]string{"bar": "BAR", "novalue2": ""}))
 		})
 
+		When("there is a default stack", func() {
+			BeforeEach(func() {
+				stackRepo.GetDefaultStackReturns(repositories.StackRecord{Name: "default-stack"}, nil)
+			})
+
+			It("creates the app with the default stack", func() {
+				Expect(applierErr).NotTo(HaveOccurred())
+				Expect(stackRepo.GetDefaultStackCallCount()).To(Equal(1))
+				_, actualAuthInfo := stackRepo.GetDefaultStackArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+
+				_, _, createAppMsg := appRepo.CreateAppArgsForCall(0)
+				Expect(createAppMsg.Lifecycle.Data.Stack).To(Equal("default-stack"))
+			})
+
+			When("the app uses the docker lifecycle", func() {
+				BeforeEach(func() {
+					appInfo.Buildpacks = nil
+					appInfo.Docker = map[string]any{"image": "some/image"}
+				})
+
+				It("does not set a stack", func() {
+					Expect(applierErr).NotTo(HaveOccurred())
+					Expect(stackRepo.GetDefaultStackCallCount()).To(BeZero())
+					_, _, createAppMsg := appRepo.CreateAppArgsForCall(0)
+					Expect(createAppMsg.Lifecycle.Data.Stack).To(BeEmpty())
+				})
+			})
+		})
+
+		When("getting the default stack fails", func() {
+			BeforeEach(func() {
+				stackRepo.GetDefaultStackReturns(repositories.StackRecord{}, errors.New("get-default-stack-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(applierErr).To(MatchError(ContainSubstring("get-default-stack-failed")))
+				Expect(appRepo.CreateAppCallCount()).To(BeZero())
+			})
+		})
+
 		When("creating the app fails", func() {
 			BeforeEach(func() {
 				appRepo.CreateAppReturns(repositories.AppRecord{}, errors.New("create-app-failed"))
@@ -321,6 +365,22 @@ var _ = Describe("Applier", func() {
 			}))
 		})
 
+		When("the route specifies a protocol", func() {
+			BeforeEach(func() {
+				appInfo.Routes[0].Protocol = tools.PtrTo("http2")
+			})
+
+			It("adds a destination with that protocol", func() {
+				Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(1))
+				_, _, addDestinationMessage := routeRepo.AddDestinationsToRouteArgsForCall(0)
+				Expect(addDestinationMessage.NewDestinations).To(ConsistOf(repositories.DesiredDestination{
+					AppGUID:     "app-guid",
+					ProcessType: "web",
+					Protocol:    tools.PtrTo("http2"),
+				}))
+			})
+		})
+
 		When("adding the destination to the route fails", func() {
 			BeforeEach(func() {
 				routeRepo.AddDestinationsToRouteReturns(repositories.RouteRecord{}, errors.New("add-route-to-dest-error"))
@@ -367,6 +427,47 @@ var _ = Describe("Applier", func() {
 
 			It("doesn't do any route creation", func() {
 				Expect(routeRepo.GetOrCreateRouteCallCount()).To(BeZero())
+				Expect(routeRepo.UpdateDestinationCallCount()).To(BeZero())
+			})
+
+			When("the route specifies another destination protocol", func() {
+				BeforeEach(func() {
+					appInfo.Routes[0].Protocol = tools.PtrTo("http2")
+					appState.Routes = map[string]repositories.RouteRecord{
+						"r1.my.domain/my-path": {
+							GUID:      "route-guid",
+							SpaceGUID: "space-guid",
+							Destinations: []repositories.DestinationRecord{
+								{GUID: "web-destination-guid", AppGUID: "app-guid", ProcessType: "web", Protocol: tools.PtrTo("http1")},
+								{GUID: "worker-destination-guid", AppGUID: "app-guid", ProcessType: "worker", Protocol: tools.PtrTo("http1")},
+								{GUID: "other-app-destination-guid", AppGUID: "other-app-guid", ProcessType: "web", Protocol: tools.PtrTo("http1")},
+							},
+						},
+					}
+				})
+
+				It("updates the protocol of the app web destination", func() {
+					Expect(routeRepo.GetOrCreateRouteCallCount()).To(BeZero())
+
+					Expect(routeRepo.UpdateDestinationCallCount()).To(Equal(1))
+					_, _, updateDestinationMessage := routeRepo.UpdateDestinationArgsForCall(0)
+					Expect(updateDestinationMessage).To(Equal(repositories.UpdateDestinationMessage{
+						RouteGUID: "route-guid",
+						SpaceGUID: "space-guid",
+						GUID:      "web-destination-guid",
+						Protocol:  tools.PtrTo("http2"),
+					}))
+				})
+
+				When("updating the destination fails", func() {
+					BeforeEach(func() {
+						routeRepo.UpdateDestinationReturns(repositories.RouteRecord{}, errors.New("update-destination-error"))
+					})
+
+					It("returns the error", func() {
+						Expect(applierErr).To(MatchError(ContainSubstring("update-destination-error")))
+					})
+				})
 			})
 		})
 
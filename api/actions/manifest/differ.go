@@ -0,0 +1,271 @@
+package manifest
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"code.cloudfoundry.org/bytefmt"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/tools"
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	DiffOpAdd     = "add"
+	DiffOpRemove  = "remove"
+	DiffOpReplace = "replace"
+)
+
+// DiffEntry is a single change in the CF manifest diff format, i.e. a JSON
+// patch operation that also carries the previous value
+type DiffEntry struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Was   any    `json:"was,omitempty"`
+	Value any    `json:"value,omitempty"`
+}
+
+// collectionKeys identifies the elements of the manifest collections that
+// applying a manifest only ever adds to, so that they are compared by identity
+// rather than by position
+var collectionKeys = map[string]string{
+	"processes": "type",
+	"routes":    "route",
+	"services":  "name",
+}
+
+// ignoredKeys are either folded into other fields by the normalizer or cannot
+// be derived from the current state of an app
+var ignoredKeys = []string{"buildpack", "default-route", "disk-quota", "docker", "no-route", "random-route"}
+
+type Differ struct{}
+
+func NewDiffer() Differ {
+	return Differ{}
+}
+
+// Diff compares a normalized manifest application with the manifest
+// generated from the current state of the app. Only the fields set in the
+// manifest are compared, as applying it leaves the other ones unchanged.
+func (d Differ) Diff(appIndex int, appInfo payloads.ManifestApplication, appState AppState) ([]DiffEntry, error) {
+	appPath := fmt.Sprintf("/applications/%d", appIndex)
+
+	desired, err := toDiffable(normalizeForDiff(appInfo))
+	if err != nil {
+		return nil, err
+	}
+
+	if appState.App.GUID == "" {
+		return []DiffEntry{{Op: DiffOpAdd, Path: appPath, Value: desired}}, nil
+	}
+
+	currentManifest := GenerateManifest(appState)
+	current, err := toDiffable(currentManifest)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, identityKey := range collectionKeys {
+		current[key] = alignCollection(current[key], desired[key], identityKey)
+	}
+	ignoreBindingParameters(current["services"], desired["services"])
+
+	entries := diffValues(appPath, current, desired)
+
+	if appInfo.NoRoute {
+		for i, route := range currentManifest.Routes {
+			entries = append(entries, DiffEntry{
+				Op:   DiffOpRemove,
+				Path: fmt.Sprintf("%s/routes/%d", appPath, i),
+				Was:  map[string]any{"route": *route.Route},
+			})
+		}
+	}
+
+	return entries, nil
+}
+
+func normalizeForDiff(appInfo payloads.ManifestApplication) payloads.ManifestApplication {
+	appInfo.Processes = slices.Clone(appInfo.Processes)
+	for i := range appInfo.Processes {
+		appInfo.Processes[i].Memory = normalizeMegabytes(appInfo.Processes[i].Memory)
+		appInfo.Processes[i].DiskQuota = normalizeMegabytes(appInfo.Processes[i].DiskQuota)
+		appInfo.Processes[i].LogRateLimit = normalizeLogRateLimit(appInfo.Processes[i].LogRateLimit)
+		if tools.ZeroIfNil(appInfo.Processes[i].HealthCheckType) == "none" {
+			appInfo.Processes[i].HealthCheckType = tools.PtrTo("process")
+		}
+	}
+
+	return appInfo
+}
+
+func normalizeMegabytes(amount *string) *string {
+	if amount == nil {
+		return nil
+	}
+
+	megabytes, err := bytefmt.ToMegabytes(*amount)
+	if err != nil {
+		return amount
+	}
+
+	return tools.PtrTo(formatMegabytes(int64(megabytes)))
+}
+
+func normalizeLogRateLimit(limit *string) *string {
+	if limit == nil {
+		return nil
+	}
+
+	return tools.PtrTo(formatLogRateLimit(payloads.ParseLogRateLimit(*limit)))
+}
+
+// toDiffable converts the manifest application into its generic YAML
+// representation, without null and ignored fields
+func toDiffable(appInfo payloads.ManifestApplication) (map[string]any, error) {
+	appYAML, err := yaml.Marshal(appInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest application %q: %w", appInfo.Name, err)
+	}
+
+	diffable := map[string]any{}
+	if err = yaml.Unmarshal(appYAML, &diffable); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal manifest application %q: %w", appInfo.Name, err)
+	}
+
+	for _, key := range ignoredKeys {
+		delete(diffable, key)
+	}
+
+	pruned, _ := prune(diffable).(map[string]any)
+	if pruned == nil {
+		pruned = map[string]any{}
+	}
+
+	return pruned, nil
+}
+
+func prune(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, element := range v {
+			if pruned := prune(element); pruned != nil {
+				v[key] = pruned
+			} else {
+				delete(v, key)
+			}
+		}
+		if len(v) == 0 {
+			return nil
+		}
+		return v
+	case []any:
+		if len(v) == 0 {
+			return nil
+		}
+		for i := range v {
+			v[i] = prune(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// alignCollection reorders the current collection elements to match the
+// positions of the desired elements with the same identity. Current elements
+// that are not in the desired collection are dropped and desired elements
+// that do not exist yet are matched with nil.
+func alignCollection(current, desired any, identityKey string) any {
+	desiredElements, ok := desired.([]any)
+	if !ok {
+		return current
+	}
+	currentElements, _ := current.([]any)
+
+	aligned := make([]any, len(desiredElements))
+	for i, desiredElement := range desiredElements {
+		identity := identityOf(desiredElement, identityKey)
+		idx := slices.IndexFunc(currentElements, func(currentElement any) bool {
+			return identityOf(currentElement, identityKey) == identity
+		})
+		if idx >= 0 {
+			aligned[i] = currentElements[idx]
+		}
+	}
+
+	return aligned
+}
+
+// ignoreBindingParameters removes the parameters of the services that are
+// already bound, as the parameters of existing bindings cannot be read back
+func ignoreBindingParameters(current, desired any) {
+	currentServices, _ := current.([]any)
+	desiredServices, _ := desired.([]any)
+	for i, desiredService := range desiredServices {
+		if currentServices[i] == nil {
+			continue
+		}
+		if service, ok := desiredService.(map[string]any); ok {
+			delete(service, "parameters")
+		}
+	}
+}
+
+func identityOf(element any, identityKey string) any {
+	if m, ok := element.(map[string]any); ok {
+		return m[identityKey]
+	}
+	return nil
+}
+
+func diffValues(path string, was, value any) []DiffEntry {
+	if was == nil {
+		return []DiffEntry{{Op: DiffOpAdd, Path: path, Value: value}}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		w, ok := was.(map[string]any)
+		if !ok {
+			break
+		}
+
+		entries := []DiffEntry{}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			entries = append(entries, diffValues(path+"/"+escapePointerToken(key), w[key], v[key])...)
+		}
+		return entries
+	case []any:
+		w, ok := was.([]any)
+		if !ok {
+			break
+		}
+
+		entries := []DiffEntry{}
+		for i := range v {
+			var wasElement any
+			if i < len(w) {
+				wasElement = w[i]
+			}
+			entries = append(entries, diffValues(fmt.Sprintf("%s/%d", path, i), wasElement, v[i])...)
+		}
+		for i := len(v); i < len(w); i++ {
+			entries = append(entries, DiffEntry{Op: DiffOpRemove, Path: fmt.Sprintf("%s/%d", path, i), Was: w[i]})
+		}
+		return entries
+	}
+
+	if reflect.DeepEqual(was, value) {
+		return nil
+	}
+
+	return []DiffEntry{{Op: DiffOpReplace, Path: path, Was: was, Value: value}}
+}
+
+func escapePointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,200 @@
+package manifest_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Differ", func() {
+	var (
+		differ   manifest.Differ
+		appInfo  payloads.ManifestApplication
+		appState manifest.AppState
+		diff     []manifest.DiffEntry
+		diffErr  error
+	)
+
+	BeforeEach(func() {
+		differ = manifest.NewDiffer()
+
+		appInfo = payloads.ManifestApplication{
+			Name: "my-app",
+			Env:  map[string]string{"FOO": "bar"},
+			Processes: []payloads.ManifestApplicationProcess{{
+				Type:      "web",
+				Instances: tools.PtrTo[int32](1),
+				Memory:    tools.PtrTo("1G"),
+			}},
+			Routes: []payloads.ManifestRoute{{Route: tools.PtrTo("my-app.my.domain")}},
+		}
+
+		appState = manifest.AppState{
+			App: repositories.AppRecord{
+				GUID: "app-guid",
+				Name: "my-app",
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+			EnvironmentVariables: map[string]string{"FOO": "bar", "OTHER": "value"},
+			Processes: map[string]repositories.ProcessRecord{
+				"web": {
+					Type:             "web",
+					DesiredInstances: 1,
+					MemoryMB:         1024,
+					DiskQuotaMB:      1024,
+					HealthCheck:      repositories.HealthCheck{Type: "port"},
+				},
+				"worker": {
+					Type:             "worker",
+					DesiredInstances: 1,
+					MemoryMB:         1024,
+					DiskQuotaMB:      1024,
+					HealthCheck:      repositories.HealthCheck{Type: "process"},
+				},
+			},
+			Routes: map[string]repositories.RouteRecord{
+				"another.my.domain": {},
+				"my-app.my.domain":  {},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		diff, diffErr = differ.Diff(2, appInfo, appState)
+	})
+
+	It("returns an empty diff when the manifest matches the app", func() {
+		Expect(diffErr).NotTo(HaveOccurred())
+		Expect(diff).To(BeEmpty())
+	})
+
+	When("the app does not exist", func() {
+		BeforeEach(func() {
+			appState = manifest.AppState{}
+		})
+
+		It("adds the whole application", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			Expect(diff).To(Equal([]manifest.DiffEntry{{
+				Op:   "add",
+				Path: "/applications/2",
+				Value: map[string]any{
+					"name": "my-app",
+					"env":  map[string]any{"FOO": "bar"},
+					"processes": []any{map[string]any{
+						"type":      "web",
+						"instances": 1,
+						"memory":    "1024M",
+					}},
+					"routes": []any{map[string]any{"route": "my-app.my.domain"}},
+				},
+			}}))
+		})
+	})
+
+	When("fields change", func() {
+		BeforeEach(func() {
+			appInfo.Env = map[string]string{"FOO": "baz", "NEW": "value"}
+			appInfo.Processes[0].Instances = tools.PtrTo[int32](3)
+			appInfo.Processes[0].HealthCheckType = tools.PtrTo("http")
+		})
+
+		It("replaces changed values and adds new ones", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			Expect(diff).To(Equal([]manifest.DiffEntry{
+				{Op: "replace", Path: "/applications/2/env/FOO", Was: "bar", Value: "baz"},
+				{Op: "add", Path: "/applications/2/env/NEW", Value: "value"},
+				{Op: "replace", Path: "/applications/2/processes/0/health-check-type", Was: "port", Value: "http"},
+				{Op: "replace", Path: "/applications/2/processes/0/instances", Was: 1, Value: 3},
+			}))
+		})
+	})
+
+	When("new processes, routes and services are added", func() {
+		BeforeEach(func() {
+			appInfo.Processes = append(appInfo.Processes, payloads.ManifestApplicationProcess{
+				Type:    "scheduler",
+				Command: tools.PtrTo("schedule.sh"),
+			})
+			appInfo.Routes = append([]payloads.ManifestRoute{{Route: tools.PtrTo("new.my.domain")}}, appInfo.Routes...)
+			appInfo.Services = []payloads.ManifestApplicationService{{
+				Name:       "my-db",
+				Parameters: map[string]any{"foo": "bar"},
+			}}
+		})
+
+		It("adds them", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			Expect(diff).To(Equal([]manifest.DiffEntry{
+				{Op: "add", Path: "/applications/2/processes/1", Value: map[string]any{"type": "scheduler", "command": "schedule.sh"}},
+				{Op: "add", Path: "/applications/2/routes/0", Value: map[string]any{"route": "new.my.domain"}},
+				{Op: "add", Path: "/applications/2/services/0", Value: map[string]any{"name": "my-db", "parameters": map[string]any{"foo": "bar"}}},
+			}))
+		})
+	})
+
+	When("the service is already bound", func() {
+		BeforeEach(func() {
+			appInfo.Services = []payloads.ManifestApplicationService{{
+				Name:       "my-db",
+				Parameters: map[string]any{"foo": "bar"},
+			}}
+			appState.ServiceBindings = map[string]repositories.ServiceBindingRecord{
+				"my-db": {},
+			}
+		})
+
+		It("ignores the binding parameters", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			Expect(diff).To(BeEmpty())
+		})
+	})
+
+	When("buildpacks change", func() {
+		BeforeEach(func() {
+			appState.App.Lifecycle.Data.Buildpacks = []string{"java_buildpack", "go_buildpack"}
+			appInfo.Buildpacks = []string{"go_buildpack"}
+		})
+
+		It("replaces and removes them by position", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			Expect(diff).To(Equal([]manifest.DiffEntry{
+				{Op: "replace", Path: "/applications/2/buildpacks/0", Was: "java_buildpack", Value: "go_buildpack"},
+				{Op: "remove", Path: "/applications/2/buildpacks/1", Was: "go_buildpack"},
+			}))
+		})
+	})
+
+	When("metadata keys contain slashes", func() {
+		BeforeEach(func() {
+			appInfo.Metadata.Labels = map[string]*string{"example.org/team": tools.PtrTo("a-team")}
+		})
+
+		It("escapes them in the path", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			Expect(diff).To(Equal([]manifest.DiffEntry{
+				{Op: "add", Path: "/applications/2/metadata", Value: map[string]any{"labels": map[string]any{"example.org/team": "a-team"}}},
+			}))
+		})
+	})
+
+	When("no-route is set", func() {
+		BeforeEach(func() {
+			appInfo.NoRoute = true
+			appInfo.Routes = nil
+		})
+
+		It("removes all current routes", func() {
+			Expect(diffErr).NotTo(HaveOccurred())
+			Expect(diff).To(Equal([]manifest.DiffEntry{
+				{Op: "remove", Path: "/applications/2/routes/0", Was: map[string]any{"route": "another.my.domain"}},
+				{Op: "remove", Path: "/applications/2/routes/1", Was: map[string]any{"route": "my-app.my.domain"}},
+			}))
+		})
+	})
+})
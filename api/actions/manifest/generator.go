@@ -0,0 +1,115 @@
+package manifest
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+)
+
+// GenerateManifest describes the current state of an app as a manifest
+// application. Processes, routes and services are sorted so that the output
+// is stable.
+func GenerateManifest(appState AppState) payloads.ManifestApplication {
+	appManifest := payloads.ManifestApplication{
+		Name: appState.App.Name,
+		Env:  appState.EnvironmentVariables,
+		Metadata: payloads.MetadataPatch{
+			Labels:      toMetadataPatch(appState.App.Labels),
+			Annotations: toMetadataPatch(appState.App.Annotations),
+		},
+	}
+
+	if appState.App.Lifecycle.Type == string(korifiv1alpha1.BuildpackLifecycle) {
+		appManifest.Buildpacks = appState.App.Lifecycle.Data.Buildpacks
+	}
+
+	if appState.DockerImage != "" {
+		appManifest.Docker = map[string]any{"image": appState.DockerImage}
+	}
+
+	for _, processType := range slices.Sorted(maps.Keys(appState.Processes)) {
+		appManifest.Processes = append(appManifest.Processes, generateProcess(appState.Processes[processType]))
+	}
+
+	for _, routeURL := range slices.Sorted(maps.Keys(appState.Routes)) {
+		appManifest.Routes = append(appManifest.Routes, payloads.ManifestRoute{Route: tools.PtrTo(routeURL)})
+	}
+
+	for _, serviceName := range slices.Sorted(maps.Keys(appState.ServiceBindings)) {
+		appManifest.Services = append(appManifest.Services, payloads.ManifestApplicationService{
+			Name:        serviceName,
+			BindingName: appState.ServiceBindings[serviceName].Name,
+		})
+	}
+
+	return appManifest
+}
+
+func generateProcess(process repositories.ProcessRecord) payloads.ManifestApplicationProcess {
+	manifestProcess := payloads.ManifestApplicationProcess{
+		Type:            process.Type,
+		Instances:       tools.PtrTo(process.DesiredInstances),
+		Memory:          tools.PtrTo(formatMegabytes(process.MemoryMB)),
+		DiskQuota:       tools.PtrTo(formatMegabytes(process.DiskQuotaMB)),
+		LogRateLimit:    tools.PtrTo(formatLogRateLimit(process.LogRateLimitBytesPerSecond)),
+		HealthCheckType: tools.PtrTo(process.HealthCheck.Type),
+	}
+
+	if process.Command != "" {
+		manifestProcess.Command = tools.PtrTo(process.Command)
+	}
+	if process.HealthCheck.Data.HTTPEndpoint != "" {
+		manifestProcess.HealthCheckHTTPEndpoint = tools.PtrTo(process.HealthCheck.Data.HTTPEndpoint)
+	}
+	if process.HealthCheck.Data.InvocationTimeoutSeconds != 0 {
+		manifestProcess.HealthCheckInvocationTimeout = tools.PtrTo(process.HealthCheck.Data.InvocationTimeoutSeconds)
+	}
+	if process.HealthCheck.Data.TimeoutSeconds != 0 {
+		manifestProcess.Timeout = tools.PtrTo(process.HealthCheck.Data.TimeoutSeconds)
+	}
+	if process.HealthCheck.Data.IntervalSeconds != 0 {
+		manifestProcess.HealthCheckInterval = tools.PtrTo(process.HealthCheck.Data.IntervalSeconds)
+	}
+	if process.ReadinessHealthCheck.Type != "" {
+		manifestProcess.ReadinessHealthCheckType = tools.PtrTo(process.ReadinessHealthCheck.Type)
+	}
+	if process.ReadinessHealthCheck.Data.HTTPEndpoint != "" {
+		manifestProcess.ReadinessHealthCheckHTTPEndpoint = tools.PtrTo(process.ReadinessHealthCheck.Data.HTTPEndpoint)
+	}
+	if process.ReadinessHealthCheck.Data.InvocationTimeoutSeconds != 0 {
+		manifestProcess.ReadinessHealthCheckInvocationTimeout = tools.PtrTo(process.ReadinessHealthCheck.Data.InvocationTimeoutSeconds)
+	}
+	if process.ReadinessHealthCheck.Data.IntervalSeconds != 0 {
+		manifestProcess.ReadinessHealthCheckInterval = tools.PtrTo(process.ReadinessHealthCheck.Data.IntervalSeconds)
+	}
+
+	return manifestProcess
+}
+
+func formatMegabytes(megabytes int64) string {
+	return fmt.Sprintf("%dM", megabytes)
+}
+
+func formatLogRateLimit(bytesPerSecond int64) string {
+	if bytesPerSecond < 0 {
+		return "-1"
+	}
+
+	return fmt.Sprintf("%dB", bytesPerSecond)
+}
+
+func toMetadataPatch(metadata map[string]string) map[string]*string {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	return maps.Collect(it.Map2(maps.All(metadata), func(key, value string) (string, *string) {
+		return key, tools.PtrTo(value)
+	}))
+}
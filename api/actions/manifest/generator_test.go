@@ -0,0 +1,158 @@
+package manifest_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.yaml.in/yaml/v3"
+)
+
+var _ = Describe("GenerateManifest", func() {
+	var (
+		appState    manifest.AppState
+		appManifest payloads.ManifestApplication
+	)
+
+	BeforeEach(func() {
+		appState = manifest.AppState{
+			App: repositories.AppRecord{
+				GUID:        "app-guid",
+				Name:        "my-app",
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"baz": "qux"},
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Buildpacks: []string{"go_buildpack"}},
+				},
+			},
+			EnvironmentVariables: map[string]string{"FOO": "bar"},
+			Processes: map[string]repositories.ProcessRecord{
+				"worker": {
+					Type:                       "worker",
+					Command:                    "work.sh",
+					DesiredInstances:           2,
+					MemoryMB:                   512,
+					DiskQuotaMB:                1024,
+					LogRateLimitBytesPerSecond: -1,
+					HealthCheck:                repositories.HealthCheck{Type: "process"},
+				},
+				"web": {
+					Type:                       "web",
+					DesiredInstances:           1,
+					MemoryMB:                   256,
+					DiskQuotaMB:                1024,
+					LogRateLimitBytesPerSecond: 16384,
+					HealthCheck: repositories.HealthCheck{
+						Type: "http",
+						Data: repositories.HealthCheckData{
+							HTTPEndpoint:             "/health",
+							InvocationTimeoutSeconds: 5,
+							TimeoutSeconds:           60,
+							IntervalSeconds:          20,
+						},
+					},
+					ReadinessHealthCheck: repositories.ReadinessHealthCheck{
+						Type: "http",
+						Data: repositories.ReadinessHealthCheckData{
+							HTTPEndpoint:             "/ready",
+							InvocationTimeoutSeconds: 2,
+							IntervalSeconds:          10,
+						},
+					},
+				},
+			},
+			Routes: map[string]repositories.RouteRecord{
+				"my-app.my.domain/path": {},
+				"another.my.domain":     {},
+			},
+			ServiceBindings: map[string]repositories.ServiceBindingRecord{
+				"my-db":    {Name: tools.PtrTo("db-binding")},
+				"my-cache": {},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		appManifest = manifest.GenerateManifest(appState)
+	})
+
+	It("describes the current state of the app", func() {
+		Expect(appManifest).To(Equal(payloads.ManifestApplication{
+			Name: "my-app",
+			Env:  map[string]string{"FOO": "bar"},
+			Metadata: payloads.MetadataPatch{
+				Labels:      map[string]*string{"foo": tools.PtrTo("bar")},
+				Annotations: map[string]*string{"baz": tools.PtrTo("qux")},
+			},
+			Buildpacks: []string{"go_buildpack"},
+			Processes: []payloads.ManifestApplicationProcess{
+				{
+					Type:                                  "web",
+					Instances:                             tools.PtrTo[int32](1),
+					Memory:                                tools.PtrTo("256M"),
+					DiskQuota:                             tools.PtrTo("1024M"),
+					LogRateLimit:                          tools.PtrTo("16384B"),
+					HealthCheckType:                       tools.PtrTo("http"),
+					HealthCheckHTTPEndpoint:               tools.PtrTo("/health"),
+					HealthCheckInvocationTimeout:          tools.PtrTo[int32](5),
+					HealthCheckInterval:                   tools.PtrTo[int32](20),
+					ReadinessHealthCheckType:              tools.PtrTo("http"),
+					ReadinessHealthCheckHTTPEndpoint:      tools.PtrTo("/ready"),
+					ReadinessHealthCheckInvocationTimeout: tools.PtrTo[int32](2),
+					ReadinessHealthCheckInterval:          tools.PtrTo[int32](10),
+					Timeout:                               tools.PtrTo[int32](60),
+				},
+				{
+					Type:            "worker",
+					Command:         tools.PtrTo("work.sh"),
+					Instances:       tools.PtrTo[int32](2),
+					Memory:          tools.PtrTo("512M"),
+					DiskQuota:       tools.PtrTo("1024M"),
+					LogRateLimit:    tools.PtrTo("-1"),
+					HealthCheckType: tools.PtrTo("process"),
+				},
+			},
+			Routes: []payloads.ManifestRoute{
+				{Route: tools.PtrTo("another.my.domain")},
+				{Route: tools.PtrTo("my-app.my.domain/path")},
+			},
+			Services: []payloads.ManifestApplicationService{
+				{Name: "my-cache"},
+				{Name: "my-db", BindingName: tools.PtrTo("db-binding")},
+			},
+		}))
+	})
+
+	When("the app uses the docker lifecycle", func() {
+		BeforeEach(func() {
+			appState.App.Lifecycle = repositories.Lifecycle{Type: "docker"}
+			appState.DockerImage = "my/image"
+		})
+
+		It("sets the docker image instead of buildpacks", func() {
+			Expect(appManifest.Buildpacks).To(BeEmpty())
+			Expect(appManifest.Docker).To(Equal(map[string]any{"image": "my/image"}))
+		})
+	})
+
+	It("round-trips through yaml without changes", func() {
+		manifestYAML, err := yaml.Marshal(payloads.Manifest{
+			Version:      1,
+			Applications: []payloads.ManifestApplication{appManifest},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		var decoded payloads.Manifest
+		Expect(yaml.Unmarshal(manifestYAML, &decoded)).To(Succeed())
+		Expect(decoded.Applications).To(HaveLen(1))
+		Expect(decoded.Applications[0].Validate()).To(Succeed())
+
+		normalized := manifest.NewNormalizer("my.domain").Normalize(decoded.Applications[0], appState)
+		diff, err := manifest.NewDiffer().Diff(0, normalized, appState)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(diff).To(BeEmpty())
+	})
+})
@@ -11,14 +11,18 @@ import (
 )
 
 type prcParams struct {
-	Command                      *string
-	Memory                       *string
-	DiskQuota                    *string
-	Instances                    *int32
-	HealthCheckHTTPEndpoint      *string
-	HealthCheckInvocationTimeout *int32
-	HealthCheckType              *string
-	Timeout                      *int32
+	Command                          *string
+	Memory                           *string
+	DiskQuota                        *string
+	Instances                        *int32
+	HealthCheckHTTPEndpoint          *string
+	HealthCheckInvocationTimeout     *int32
+	HealthCheckType                  *string
+	HealthCheckInterval              *int32
+	ReadinessHealthCheckHTTPEndpoint *string
+	ReadinessHealthCheckType         *string
+	Timeout                          *int32
+	LogRateLimit                     *string
 }
 
 type (
@@ -166,19 +170,27 @@ var _ = Describe("Normalizer", func() {
 				appInfo.HealthCheckHTTPEndpoint = app.HealthCheckHTTPEndpoint
 				appInfo.HealthCheckType = app.HealthCheckType
 				appInfo.HealthCheckInvocationTimeout = app.HealthCheckInvocationTimeout
+				appInfo.HealthCheckInterval = app.HealthCheckInterval
+				appInfo.ReadinessHealthCheckHTTPEndpoint = app.ReadinessHealthCheckHTTPEndpoint
+				appInfo.ReadinessHealthCheckType = app.ReadinessHealthCheckType
 				appInfo.Timeout = app.Timeout
+				appInfo.LogRateLimit = app.LogRateLimit
 
 				if (process != prcParams{}) {
 					appInfo.Processes = append(appInfo.Processes, payloads.ManifestApplicationProcess{
-						Type:                         "web",
-						Memory:                       process.Memory,
-						DiskQuota:                    process.DiskQuota,
-						Instances:                    process.Instances,
-						Command:                      process.Command,
-						HealthCheckHTTPEndpoint:      process.HealthCheckHTTPEndpoint,
-						HealthCheckType:              process.HealthCheckType,
-						HealthCheckInvocationTimeout: process.HealthCheckInvocationTimeout,
-						Timeout:                      process.Timeout,
+						Type:                             "web",
+						Memory:                           process.Memory,
+						DiskQuota:                        process.DiskQuota,
+						Instances:                        process.Instances,
+						Command:                          process.Command,
+						HealthCheckHTTPEndpoint:          process.HealthCheckHTTPEndpoint,
+						HealthCheckType:                  process.HealthCheckType,
+						HealthCheckInvocationTimeout:     process.HealthCheckInvocationTimeout,
+						HealthCheckInterval:              process.HealthCheckInterval,
+						ReadinessHealthCheckHTTPEndpoint: process.ReadinessHealthCheckHTTPEndpoint,
+						ReadinessHealthCheckType:         process.ReadinessHealthCheckType,
+						Timeout:                          process.Timeout,
+						LogRateLimit:                     process.LogRateLimit,
 					})
 				}
 
@@ -192,7 +204,11 @@ var _ = Describe("Normalizer", func() {
 				Expect(webProc.HealthCheckHTTPEndpoint).To(Equal(effective.HealthCheckHTTPEndpoint))
 				Expect(webProc.HealthCheckType).To(Equal(effective.HealthCheckType))
 				Expect(webProc.HealthCheckInvocationTimeout).To(Equal(effective.HealthCheckInvocationTimeout))
+				Expect(webProc.HealthCheckInterval).To(Equal(effective.HealthCheckInterval))
+				Expect(webProc.ReadinessHealthCheckHTTPEndpoint).To(Equal(effective.ReadinessHealthCheckHTTPEndpoint))
+				Expect(webProc.ReadinessHealthCheckType).To(Equal(effective.ReadinessHealthCheckType))
 				Expect(webProc.Timeout).To(Equal(effective.Timeout))
+				Expect(webProc.LogRateLimit).To(Equal(effective.LogRateLimit))
 			},
 
 			// without an explicit web process in the manifest
@@ -220,6 +236,12 @@ var _ = Describe("Normalizer", func() {
 			Entry("app-level timeout only",
 				appParams{Timeout: tools.PtrTo(int32(12))}, prcParams{},
 				expParams{Timeout: tools.PtrTo(int32(12))}),
+			Entry("app-level healthcheck interval only",
+				appParams{HealthCheckInterval: tools.PtrTo(int32(15))}, prcParams{},
+				expParams{HealthCheckInterval: tools.PtrTo(int32(15))}),
+			Entry("app-level readiness healthcheck only",
+				appParams{ReadinessHealthCheckType: tools.PtrTo("http"), ReadinessHealthCheckHTTPEndpoint: tools.PtrTo("/ready")}, prcParams{},
+				expParams{ReadinessHealthCheckType: tools.PtrTo("http"), ReadinessHealthCheckHTTPEndpoint: tools.PtrTo("/ready")}),
 			Entry("a combination of fields",
 				appParams{Memory: tools.PtrTo("512M"), DiskQuota: tools.PtrTo("2G")}, prcParams{},
 				expParams{Memory: tools.PtrTo("512M"), DiskQuota: tools.PtrTo("2G")}),
@@ -259,6 +281,9 @@ var _ = Describe("Normalizer", func() {
 				expParams{Timeout: tools.PtrTo(int32(32)), Instances: tools.PtrTo[int32](3)}),
 
 			// with an existing web process with the given value set
+			Entry("app-level log rate limit only",
+				appParams{LogRateLimit: tools.PtrTo("1K")}, prcParams{},
+				expParams{LogRateLimit: tools.PtrTo("1K")}),
 			Entry("value from proc memory used",
 				appParams{Memory: tools.PtrTo("256M")},
 				prcParams{Memory: tools.PtrTo("512M")},
@@ -279,6 +304,10 @@ var _ = Describe("Normalizer", func() {
 				appParams{HealthCheckHTTPEndpoint: tools.PtrTo("/apphealth")},
 				prcParams{HealthCheckHTTPEndpoint: tools.PtrTo("/prchealth")},
 				expParams{HealthCheckHTTPEndpoint: tools.PtrTo("/prchealth")}),
+			Entry("value from proc log rate limit used",
+				appParams{LogRateLimit: tools.PtrTo("1K")},
+				prcParams{LogRateLimit: tools.PtrTo("2K")},
+				expParams{LogRateLimit: tools.PtrTo("2K")}),
 			Entry("value from proc healthcheck type used",
 				appParams{HealthCheckType: tools.PtrTo("apptype")},
 				prcParams{HealthCheckType: tools.PtrTo("proctype")},
@@ -13,6 +13,7 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/tools/singleton"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"github.com/BooleanCat/go-functional/v2/it"
 )
 
@@ -20,22 +21,26 @@ type StateCollector struct {
 	appRepo             shared.CFAppRepository
 	domainRepo          shared.CFDomainRepository
 	processRepo         shared.CFProcessRepository
+	packageRepo         shared.CFPackageRepository
 	routeRepo           shared.CFRouteRepository
 	serviceInstanceRepo shared.CFServiceInstanceRepository
 	serviceBindingRepo  shared.CFServiceBindingRepository
 }
 
 type AppState struct {
-	App             repositories.AppRecord
-	Processes       map[string]repositories.ProcessRecord
-	Routes          map[string]repositories.RouteRecord
-	ServiceBindings map[string]repositories.ServiceBindingRecord
+	App                  repositories.AppRecord
+	Processes            map[string]repositories.ProcessRecord
+	Routes               map[string]repositories.RouteRecord
+	ServiceBindings      map[string]repositories.ServiceBindingRecord
+	EnvironmentVariables map[string]string
+	DockerImage          string
 }
 
 func NewStateCollector(
 	appRepo shared.CFAppRepository,
 	domainRepo shared.CFDomainRepository,
 	processRepo shared.CFProcessRepository,
+	packageRepo shared.CFPackageRepository,
 	routeRepo shared.CFRouteRepository,
 	serviceInstanceRepo shared.CFServiceInstanceRepository,
 	serviceBindingRepo shared.CFServiceBindingRepository,
@@ -44,6 +49,7 @@ func NewStateCollector(
 		appRepo:             appRepo,
 		domainRepo:          domainRepo,
 		processRepo:         processRepo,
+		packageRepo:         packageRepo,
 		routeRepo:           routeRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
@@ -82,14 +88,52 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 		return AppState{}, err
 	}
 
+	appEnv, err := s.appRepo.GetAppEnv(ctx, authInfo, appRecord.GUID)
+	if err != nil {
+		return AppState{}, err
+	}
+
+	dockerImage, err := s.getDockerImage(ctx, authInfo, appRecord)
+	if err != nil {
+		return AppState{}, err
+	}
+
 	return AppState{
-		App:             appRecord,
-		Processes:       processesByType,
-		Routes:          routesByURL,
-		ServiceBindings: bindingsByServiceName,
+		App:                  appRecord,
+		Processes:            processesByType,
+		Routes:               routesByURL,
+		ServiceBindings:      bindingsByServiceName,
+		EnvironmentVariables: appEnv.EnvironmentVariables,
+		DockerImage:          dockerImage,
 	}, nil
 }
 
+func (s StateCollector) getDockerImage(ctx context.Context, authInfo authorization.Info, appRecord repositories.AppRecord) (string, error) {
+	if appRecord.Lifecycle.Type != string(korifiv1alpha1.DockerPackage) {
+		return "", nil
+	}
+
+	packages, err := s.packageRepo.ListPackages(ctx, authInfo, repositories.ListPackagesMessage{
+		AppGUIDs: []string{appRecord.GUID},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	dockerPackages := slices.Collect(it.Filter(slices.Values(packages.Records), func(p repositories.PackageRecord) bool {
+		return p.Type == string(korifiv1alpha1.DockerPackage)
+	}))
+	if len(dockerPackages) == 0 {
+		return "", nil
+	}
+
+	latestPackage := slices.MaxFunc(dockerPackages, func(a, b repositories.PackageRecord) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+
+	return latestPackage.ImageRef, nil
+}
+
 func (s StateCollector) indexProcessesByType(ctx context.Context, authInfo authorization.Info, appGUID, spaceGUID string) (map[string]repositories.ProcessRecord, error) {
 	procs, err := s.processRepo.ListProcesses(ctx, authInfo, repositories.ListProcessesMessage{
 		AppGUIDs:   []string{appGUID},
@@ -3,6 +3,7 @@ package manifest_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -19,6 +20,7 @@ var _ = Describe("StateCollector", func() {
 		appRepo             *fake.CFAppRepository
 		domainRepo          *fake.CFDomainRepository
 		processRepo         *fake.CFProcessRepository
+		packageRepo         *fake.CFPackageRepository
 		routeRepo           *fake.CFRouteRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		serviceBindingRepo  *fake.CFServiceBindingRepository
@@ -31,6 +33,7 @@ var _ = Describe("StateCollector", func() {
 		appRepo = new(fake.CFAppRepository)
 		domainRepo = new(fake.CFDomainRepository)
 		processRepo = new(fake.CFProcessRepository)
+		packageRepo = new(fake.CFPackageRepository)
 		routeRepo = new(fake.CFRouteRepository)
 		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
 		serviceBindingRepo = new(fake.CFServiceBindingRepository)
@@ -38,6 +41,7 @@ var _ = Describe("StateCollector", func() {
 			appRepo,
 			domainRepo,
 			processRepo,
+			packageRepo,
 			routeRepo,
 			serviceInstanceRepo,
 			serviceBindingRepo,
@@ -273,4 +277,82 @@ var _ = Describe("StateCollector", func() {
 			}))
 		})
 	})
+
+	Describe("environment variables", func() {
+		BeforeEach(func() {
+			appRepo.ListAppsReturns(repositories.ListResult[repositories.AppRecord]{Records: []repositories.AppRecord{{GUID: "app-guid"}}}, nil)
+			appRepo.GetAppEnvReturns(repositories.AppEnvRecord{
+				EnvironmentVariables: map[string]string{"FOO": "bar"},
+			}, nil)
+		})
+
+		It("gets the app env", func() {
+			Expect(appRepo.GetAppEnvCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppEnvArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+		})
+
+		It("populates the environment variables", func() {
+			Expect(collectStateErr).NotTo(HaveOccurred())
+			Expect(appState.EnvironmentVariables).To(Equal(map[string]string{"FOO": "bar"}))
+		})
+
+		When("getting the app env fails", func() {
+			BeforeEach(func() {
+				appRepo.GetAppEnvReturns(repositories.AppEnvRecord{}, errors.New("get-env-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(collectStateErr).To(MatchError("get-env-err"))
+			})
+		})
+	})
+
+	Describe("docker image", func() {
+		BeforeEach(func() {
+			appRepo.ListAppsReturns(repositories.ListResult[repositories.AppRecord]{Records: []repositories.AppRecord{{
+				GUID:      "app-guid",
+				Lifecycle: repositories.Lifecycle{Type: "docker"},
+			}}}, nil)
+			packageRepo.ListPackagesReturns(repositories.ListResult[repositories.PackageRecord]{Records: []repositories.PackageRecord{
+				{Type: "docker", ImageRef: "old/image", CreatedAt: time.UnixMilli(1000)},
+				{Type: "docker", ImageRef: "new/image", CreatedAt: time.UnixMilli(2000)},
+			}}, nil)
+		})
+
+		It("lists the app packages", func() {
+			Expect(packageRepo.ListPackagesCallCount()).To(Equal(1))
+			_, _, listMsg := packageRepo.ListPackagesArgsForCall(0)
+			Expect(listMsg.AppGUIDs).To(ConsistOf("app-guid"))
+		})
+
+		It("sets the image of the latest docker package", func() {
+			Expect(collectStateErr).NotTo(HaveOccurred())
+			Expect(appState.DockerImage).To(Equal("new/image"))
+		})
+
+		When("listing the packages fails", func() {
+			BeforeEach(func() {
+				packageRepo.ListPackagesReturns(repositories.ListResult[repositories.PackageRecord]{}, errors.New("list-packages-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(collectStateErr).To(MatchError("list-packages-err"))
+			})
+		})
+
+		When("the app uses the buildpack lifecycle", func() {
+			BeforeEach(func() {
+				appRepo.ListAppsReturns(repositories.ListResult[repositories.AppRecord]{Records: []repositories.AppRecord{{
+					GUID:      "app-guid",
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}}}, nil)
+			})
+
+			It("does not look up the docker image", func() {
+				Expect(packageRepo.ListPackagesCallCount()).To(BeZero())
+				Expect(appState.DockerImage).To(BeEmpty())
+			})
+		})
+	})
 })
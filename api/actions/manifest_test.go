@@ -66,7 +66,7 @@ var _ = Describe("ApplyManifest", func() {
 			}},
 		}
 
-		manifestAction = actions.NewManifest(domainRepository, "my.domain", stateCollector, normalizer, applier)
+		manifestAction = actions.NewManifest(domainRepository, "my.domain", stateCollector, normalizer, applier, new(fake.Differ))
 	})
 
 	JustBeforeEach(func() {
@@ -147,3 +147,152 @@ var _ = Describe("ApplyManifest", func() {
 		})
 	})
 })
+
+var _ = Describe("DiffManifest", func() {
+	var (
+		manifestAction *actions.Manifest
+		diff           []manifest.DiffEntry
+		diffErr        error
+
+		stateCollector *fake.StateCollector
+		normalizer     *fake.Normalizer
+		applier        *fake.Applier
+		differ         *fake.Differ
+
+		appManifest payloads.Manifest
+	)
+
+	BeforeEach(func() {
+		stateCollector = new(fake.StateCollector)
+		normalizer = new(fake.Normalizer)
+		applier = new(fake.Applier)
+		differ = new(fake.Differ)
+
+		stateCollector.CollectStateReturnsOnCall(0, manifest.AppState{
+			App: repositories.AppRecord{GUID: "app1-guid"},
+		}, nil)
+		stateCollector.CollectStateReturnsOnCall(1, manifest.AppState{}, nil)
+
+		normalizer.NormalizeReturnsOnCall(0, payloads.ManifestApplication{Name: "normalized-app1"})
+		normalizer.NormalizeReturnsOnCall(1, payloads.ManifestApplication{Name: "normalized-app2"})
+
+		differ.DiffReturnsOnCall(0, []manifest.DiffEntry{{Op: "replace", Path: "/applications/0/env/FOO", Was: "foo", Value: "bar"}}, nil)
+		differ.DiffReturnsOnCall(1, []manifest.DiffEntry{{Op: "add", Path: "/applications/1", Value: map[string]any{"name": "app2"}}}, nil)
+
+		appManifest = payloads.Manifest{
+			Applications: []payloads.ManifestApplication{{
+				Name: "app1",
+			}, {
+				Name: "app2",
+			}},
+		}
+
+		manifestAction = actions.NewManifest(new(reposfake.CFDomainRepository), "my.domain", stateCollector, normalizer, applier, differ)
+	})
+
+	JustBeforeEach(func() {
+		diff, diffErr = manifestAction.Diff(context.Background(), authorization.Info{}, "space-guid", appManifest)
+	})
+
+	It("diffs each normalized app against its current state", func() {
+		Expect(diffErr).NotTo(HaveOccurred())
+
+		Expect(stateCollector.CollectStateCallCount()).To(Equal(2))
+		_, _, actualAppName, actualSpaceGUID := stateCollector.CollectStateArgsForCall(1)
+		Expect(actualAppName).To(Equal("app2"))
+		Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+		Expect(differ.DiffCallCount()).To(Equal(2))
+		actualIndex, actualAppInfo, actualState := differ.DiffArgsForCall(0)
+		Expect(actualIndex).To(Equal(0))
+		Expect(actualAppInfo.Name).To(Equal("normalized-app1"))
+		Expect(actualState.App.GUID).To(Equal("app1-guid"))
+		actualIndex, actualAppInfo, _ = differ.DiffArgsForCall(1)
+		Expect(actualIndex).To(Equal(1))
+		Expect(actualAppInfo.Name).To(Equal("normalized-app2"))
+
+		Expect(diff).To(Equal([]manifest.DiffEntry{
+			{Op: "replace", Path: "/applications/0/env/FOO", Was: "foo", Value: "bar"},
+			{Op: "add", Path: "/applications/1", Value: map[string]any{"name": "app2"}},
+		}))
+	})
+
+	It("does not apply anything", func() {
+		Expect(applier.ApplyCallCount()).To(BeZero())
+	})
+
+	When("collecting the app state fails", func() {
+		BeforeEach(func() {
+			stateCollector.CollectStateReturnsOnCall(0, manifest.AppState{}, errors.New("collect-state-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(diffErr).To(MatchError("collect-state-err"))
+		})
+	})
+
+	When("diffing an app fails", func() {
+		BeforeEach(func() {
+			differ.DiffReturnsOnCall(1, nil, errors.New("diff-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(diffErr).To(MatchError("diff-err"))
+		})
+	})
+})
+
+var _ = Describe("GenerateManifest", func() {
+	var (
+		manifestAction *actions.Manifest
+		stateCollector *fake.StateCollector
+		generated      payloads.Manifest
+		generateErr    error
+	)
+
+	BeforeEach(func() {
+		stateCollector = new(fake.StateCollector)
+		stateCollector.CollectStateReturns(manifest.AppState{
+			App: repositories.AppRecord{GUID: "app-guid", Name: "my-app"},
+		}, nil)
+
+		manifestAction = actions.NewManifest(new(reposfake.CFDomainRepository), "my.domain", stateCollector, new(fake.Normalizer), new(fake.Applier), new(fake.Differ))
+	})
+
+	JustBeforeEach(func() {
+		generated, generateErr = manifestAction.Generate(context.Background(), authorization.Info{}, "my-app", "space-guid")
+	})
+
+	It("generates the manifest from the app state", func() {
+		Expect(generateErr).NotTo(HaveOccurred())
+
+		Expect(stateCollector.CollectStateCallCount()).To(Equal(1))
+		_, _, actualAppName, actualSpaceGUID := stateCollector.CollectStateArgsForCall(0)
+		Expect(actualAppName).To(Equal("my-app"))
+		Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+		Expect(generated.Version).To(Equal(1))
+		Expect(generated.Applications).To(HaveLen(1))
+		Expect(generated.Applications[0].Name).To(Equal("my-app"))
+	})
+
+	When("the app does not exist", func() {
+		BeforeEach(func() {
+			stateCollector.CollectStateReturns(manifest.AppState{}, nil)
+		})
+
+		It("returns a not found error", func() {
+			Expect(generateErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+	})
+
+	When("collecting the app state fails", func() {
+		BeforeEach(func() {
+			stateCollector.CollectStateReturns(manifest.AppState{}, errors.New("collect-state-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(generateErr).To(MatchError("collect-state-err"))
+		})
+	})
+})
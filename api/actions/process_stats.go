@@ -38,13 +38,14 @@ type Usage struct {
 }
 
 type PodStatsRecord struct {
-	ProcessGUID string
-	ProcessType string
-	Index       int
-	State       string `default:"DOWN"`
-	Usage       Usage
-	MemQuota    *int64
-	DiskQuota   *int64
+	ProcessGUID  string
+	ProcessType  string
+	Index        int
+	State        string `default:"DOWN"`
+	Usage        Usage
+	MemQuota     *int64
+	DiskQuota    *int64
+	LogRateLimit *int64
 }
 
 type ProcessStats struct {
@@ -142,6 +143,7 @@ func (a *ProcessStats) FetchStats(ctx context.Context, authInfo authorization.In
 		records[index].Usage.Timestamp = tools.PtrTo(m.Metrics.Timestamp.Time)
 		records[index].MemQuota = tools.PtrTo(megabytesToBytes(processRecord.MemoryMB))
 		records[index].DiskQuota = tools.PtrTo(megabytesToBytes(processRecord.DiskQuotaMB))
+		records[index].LogRateLimit = tools.PtrTo(processRecord.LogRateLimitBytesPerSecond)
 	}
 	return records, nil
 }
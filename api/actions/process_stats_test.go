@@ -51,11 +51,12 @@ var _ = Describe("ProcessStats", func() {
 		authInfo = authorization.Info{Token: "a-token"}
 
 		processRepo.GetProcessReturns(repositories.ProcessRecord{
-			AppGUID:          "the-app-guid",
-			DesiredInstances: 2,
-			Type:             "web",
-			MemoryMB:         1024,
-			DiskQuotaMB:      2048,
+			AppGUID:                    "the-app-guid",
+			DesiredInstances:           2,
+			Type:                       "web",
+			MemoryMB:                   1024,
+			DiskQuotaMB:                2048,
+			LogRateLimitBytesPerSecond: 4096,
 		}, nil)
 
 		appRepo.GetAppReturns(repositories.AppRecord{
@@ -120,6 +121,7 @@ var _ = Describe("ProcessStats", func() {
 			Expect(responseRecords[0].Usage.Disk).To(Equal(tools.PtrTo(int64(890))))
 			Expect(responseRecords[0].MemQuota).To(Equal(tools.PtrTo(int64(1024 * 1024 * 1024))))
 			Expect(responseRecords[0].DiskQuota).To(Equal(tools.PtrTo(int64(2048 * 1024 * 1024))))
+			Expect(responseRecords[0].LogRateLimit).To(Equal(tools.PtrTo(int64(4096))))
 
 			Expect(responseRecords[1].Index).To(Equal(1))
 			Expect(responseRecords[1].ProcessType).To(Equal("web"))
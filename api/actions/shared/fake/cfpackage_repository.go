@@ -0,0 +1,123 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFPackageRepository struct {
+	ListPackagesStub        func(context.Context, authorization.Info, repositories.ListPackagesMessage) (repositories.ListResult[repositories.PackageRecord], error)
+	listPackagesMutex       sync.RWMutex
+	listPackagesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListPackagesMessage
+	}
+	listPackagesReturns struct {
+		result1 repositories.ListResult[repositories.PackageRecord]
+		result2 error
+	}
+	listPackagesReturnsOnCall map[int]struct {
+		result1 repositories.ListResult[repositories.PackageRecord]
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFPackageRepository) ListPackages(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListPackagesMessage) (repositories.ListResult[repositories.PackageRecord], error) {
+	fake.listPackagesMutex.Lock()
+	ret, specificReturn := fake.listPackagesReturnsOnCall[len(fake.listPackagesArgsForCall)]
+	fake.listPackagesArgsForCall = append(fake.listPackagesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListPackagesMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListPackagesStub
+	fakeReturns := fake.listPackagesReturns
+	fake.recordInvocation("ListPackages", []interface{}{arg1, arg2, arg3})
+	fake.listPackagesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFPackageRepository) ListPackagesCallCount() int {
+	fake.listPackagesMutex.RLock()
+	defer fake.listPackagesMutex.RUnlock()
+	return len(fake.listPackagesArgsForCall)
+}
+
+func (fake *CFPackageRepository) ListPackagesCalls(stub func(context.Context, authorization.Info, repositories.ListPackagesMessage) (repositories.ListResult[repositories.PackageRecord], error)) {
+	fake.listPackagesMutex.Lock()
+	defer fake.listPackagesMutex.Unlock()
+	fake.ListPackagesStub = stub
+}
+
+func (fake *CFPackageRepository) ListPackagesArgsForCall(i int) (context.Context, authorization.Info, repositories.ListPackagesMessage) {
+	fake.listPackagesMutex.RLock()
+	defer fake.listPackagesMutex.RUnlock()
+	argsForCall := fake.listPackagesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFPackageRepository) ListPackagesReturns(result1 repositories.ListResult[repositories.PackageRecord], result2 error) {
+	fake.listPackagesMutex.Lock()
+	defer fake.listPackagesMutex.Unlock()
+	fake.ListPackagesStub = nil
+	fake.listPackagesReturns = struct {
+		result1 repositories.ListResult[repositories.PackageRecord]
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFPackageRepository) ListPackagesReturnsOnCall(i int, result1 repositories.ListResult[repositories.PackageRecord], result2 error) {
+	fake.listPackagesMutex.Lock()
+	defer fake.listPackagesMutex.Unlock()
+	fake.ListPackagesStub = nil
+	if fake.listPackagesReturnsOnCall == nil {
+		fake.listPackagesReturnsOnCall = make(map[int]struct {
+			result1 repositories.ListResult[repositories.PackageRecord]
+			result2 error
+		})
+	}
+	fake.listPackagesReturnsOnCall[i] = struct {
+		result1 repositories.ListResult[repositories.PackageRecord]
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFPackageRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listPackagesMutex.RLock()
+	defer fake.listPackagesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFPackageRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ shared.CFPackageRepository = new(CFPackageRepository)
@@ -71,6 +71,21 @@ type CFRouteRepository struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}
+	UpdateDestinationStub        func(context.Context, authorization.Info, repositories.UpdateDestinationMessage) (repositories.RouteRecord, error)
+	updateDestinationMutex       sync.RWMutex
+	updateDestinationArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateDestinationMessage
+	}
+	updateDestinationReturns struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	updateDestinationReturnsOnCall map[int]struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -339,6 +354,72 @@ func (fake *CFRouteRepository) RemoveDestinationFromRouteReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *CFRouteRepository) UpdateDestination(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateDestinationMessage) (repositories.RouteRecord, error) {
+	fake.updateDestinationMutex.Lock()
+	ret, specificReturn := fake.updateDestinationReturnsOnCall[len(fake.updateDestinationArgsForCall)]
+	fake.updateDestinationArgsForCall = append(fake.updateDestinationArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateDestinationMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateDestinationStub
+	fakeReturns := fake.updateDestinationReturns
+	fake.recordInvocation("UpdateDestination", []interface{}{arg1, arg2, arg3})
+	fake.updateDestinationMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) UpdateDestinationCallCount() int {
+	fake.updateDestinationMutex.RLock()
+	defer fake.updateDestinationMutex.RUnlock()
+	return len(fake.updateDestinationArgsForCall)
+}
+
+func (fake *CFRouteRepository) UpdateDestinationCalls(stub func(context.Context, authorization.Info, repositories.UpdateDestinationMessage) (repositories.RouteRecord, error)) {
+	fake.updateDestinationMutex.Lock()
+	defer fake.updateDestinationMutex.Unlock()
+	fake.UpdateDestinationStub = stub
+}
+
+func (fake *CFRouteRepository) UpdateDestinationArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateDestinationMessage) {
+	fake.updateDestinationMutex.RLock()
+	defer fake.updateDestinationMutex.RUnlock()
+	argsForCall := fake.updateDestinationArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) UpdateDestinationReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.updateDestinationMutex.Lock()
+	defer fake.updateDestinationMutex.Unlock()
+	fake.UpdateDestinationStub = nil
+	fake.updateDestinationReturns = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) UpdateDestinationReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.updateDestinationMutex.Lock()
+	defer fake.updateDestinationMutex.Unlock()
+	fake.UpdateDestinationStub = nil
+	if fake.updateDestinationReturnsOnCall == nil {
+		fake.updateDestinationReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.updateDestinationReturnsOnCall[i] = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -350,6 +431,8 @@ func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	defer fake.listRoutesMutex.RUnlock()
 	fake.removeDestinationFromRouteMutex.RLock()
 	defer fake.removeDestinationFromRouteMutex.RUnlock()
+	fake.updateDestinationMutex.RLock()
+	defer fake.updateDestinationMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFStackRepository struct {
+	GetDefaultStackStub        func(context.Context, authorization.Info) (repositories.StackRecord, error)
+	getDefaultStackMutex       sync.RWMutex
+	getDefaultStackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	getDefaultStackReturns struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
+	getDefaultStackReturnsOnCall map[int]struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFStackRepository) GetDefaultStack(arg1 context.Context, arg2 authorization.Info) (repositories.StackRecord, error) {
+	fake.getDefaultStackMutex.Lock()
+	ret, specificReturn := fake.getDefaultStackReturnsOnCall[len(fake.getDefaultStackArgsForCall)]
+	fake.getDefaultStackArgsForCall = append(fake.getDefaultStackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.GetDefaultStackStub
+	fakeReturns := fake.getDefaultStackReturns
+	fake.recordInvocation("GetDefaultStack", []interface{}{arg1, arg2})
+	fake.getDefaultStackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFStackRepository) GetDefaultStackCallCount() int {
+	fake.getDefaultStackMutex.RLock()
+	defer fake.getDefaultStackMutex.RUnlock()
+	return len(fake.getDefaultStackArgsForCall)
+}
+
+func (fake *CFStackRepository) GetDefaultStackCalls(stub func(context.Context, authorization.Info) (repositories.StackRecord, error)) {
+	fake.getDefaultStackMutex.Lock()
+	defer fake.getDefaultStackMutex.Unlock()
+	fake.GetDefaultStackStub = stub
+}
+
+func (fake *CFStackRepository) GetDefaultStackArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.getDefaultStackMutex.RLock()
+	defer fake.getDefaultStackMutex.RUnlock()
+	argsForCall := fake.getDefaultStackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *CFStackRepository) GetDefaultStackReturns(result1 repositories.StackRecord, result2 error) {
+	fake.getDefaultStackMutex.Lock()
+	defer fake.getDefaultStackMutex.Unlock()
+	fake.GetDefaultStackStub = nil
+	fake.getDefaultStackReturns = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFStackRepository) GetDefaultStackReturnsOnCall(i int, result1 repositories.StackRecord, result2 error) {
+	fake.getDefaultStackMutex.Lock()
+	defer fake.getDefaultStackMutex.Unlock()
+	fake.GetDefaultStackStub = nil
+	if fake.getDefaultStackReturnsOnCall == nil {
+		fake.getDefaultStackReturnsOnCall = make(map[int]struct {
+			result1 repositories.StackRecord
+			result2 error
+		})
+	}
+	fake.getDefaultStackReturnsOnCall[i] = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFStackRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getDefaultStackMutex.RLock()
+	defer fake.getDefaultStackMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFStackRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ shared.CFStackRepository = new(CFStackRepository)
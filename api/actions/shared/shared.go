@@ -24,6 +24,13 @@ type CFAppRepository interface {
 	ListApps(context.Context, authorization.Info, repositories.ListAppsMessage) (repositories.ListResult[repositories.AppRecord], error)
 	CreateApp(context.Context, authorization.Info, repositories.CreateAppMessage) (repositories.AppRecord, error)
 	PatchApp(context.Context, authorization.Info, repositories.PatchAppMessage) (repositories.AppRecord, error)
+	GetAppEnv(context.Context, authorization.Info, string) (repositories.AppEnvRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name CFPackageRepository . CFPackageRepository
+
+type CFPackageRepository interface {
+	ListPackages(context.Context, authorization.Info, repositories.ListPackagesMessage) (repositories.ListResult[repositories.PackageRecord], error)
 }
 
 //counterfeiter:generate -o fake -fake-name CFDomainRepository . CFDomainRepository
@@ -39,6 +46,7 @@ type CFRouteRepository interface {
 	ListRoutes(context.Context, authorization.Info, repositories.ListRoutesMessage) (repositories.ListResult[repositories.RouteRecord], error)
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsMessage) (repositories.RouteRecord, error)
 	RemoveDestinationFromRoute(ctx context.Context, authInfo authorization.Info, message repositories.RemoveDestinationMessage) (repositories.RouteRecord, error)
+	UpdateDestination(ctx context.Context, authInfo authorization.Info, message repositories.UpdateDestinationMessage) (repositories.RouteRecord, error)
 }
 
 //counterfeiter:generate -o fake -fake-name CFServiceBindingRepository . CFServiceBindingRepository
@@ -53,3 +61,8 @@ type CFServiceBindingRepository interface {
 type CFServiceInstanceRepository interface {
 	ListServiceInstances(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) (repositories.ListResult[repositories.ServiceInstanceRecord], error)
 }
+
+//counterfeiter:generate -o fake -fake-name CFStackRepository . CFStackRepository
+type CFStackRepository interface {
+	GetDefaultStack(context.Context, authorization.Info) (repositories.StackRecord, error)
+}
@@ -17,8 +17,14 @@ const (
 //counterfeiter:generate -o fake -fake-name CertIdentityInspector . CertIdentityInspector
 
 type Identity struct {
-	Name string
-	Kind string
+	Name   string
+	Kind   string
+	Groups []string
+
+	// Impersonate is set for identities that the Kubernetes API server cannot
+	// authenticate by itself (e.g. users of the API OIDC provider). Requests on
+	// their behalf are made by impersonating them.
+	Impersonate bool
 }
 
 func (i *Identity) Hash() string {
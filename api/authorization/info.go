@@ -10,6 +10,14 @@ type Info struct {
 	Token         string
 	CertData      []byte
 	RawAuthHeader string
+
+	// Impersonation is the identity that user clients impersonate instead of
+	// presenting the token to the Kubernetes API server
+	Impersonation *Identity
+
+	// UserName is the name of the authenticated identity, as resolved by the
+	// authentication middleware
+	UserName string
 }
 
 type key int
@@ -3,6 +3,7 @@ package authorization
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -105,6 +106,10 @@ func (o *NamespacePermissions) AuthorizedIn(ctx context.Context, identity Identi
 }
 
 func SameSubject(subject rbacv1.Subject, identity Identity) (bool, error) {
+	if subject.Kind == rbacv1.GroupKind && identity.Kind != rbacv1.GroupKind {
+		return slices.Contains(identity.Groups, subject.Name), nil
+	}
+
 	if identity.Kind != subject.Kind {
 		return false, nil
 	}
@@ -188,6 +188,23 @@ var _ = Describe("Namespace Permissions", func() {
 			})
 		})
 
+		When("a user is a member of a group with a rolebinding", func() {
+			BeforeEach(func() {
+				groupName := generateGUID("devs")
+				identityProvider.GetIdentityReturns(authorization.Identity{
+					Name:   generateGUID("bob"),
+					Kind:   "User",
+					Groups: []string{"some-other-group", groupName},
+				}, nil)
+				createRoleBindingForSubject(rbacv1.Subject{Name: groupName, Kind: "Group"}, roleName1, org2NS)
+			})
+
+			It("lists the namespaces with bindings for the user groups", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(namespaces).To(Equal(map[string]bool{org2NS: true}))
+			})
+		})
+
 		When("a service account is authenticated", func() {
 			BeforeEach(func() {
 				identityProvider.GetIdentityReturns(serviceAccountIdentity, nil)
@@ -371,6 +388,27 @@ var _ = Describe("Namespace Permissions", func() {
 			})
 		})
 
+		When("a group is bound in the namespace", func() {
+			var groupName string
+
+			BeforeEach(func() {
+				groupName = generateGUID("devs")
+				createRoleBindingForSubject(rbacv1.Subject{Name: groupName, Kind: "Group"}, roleName1, org1NS)
+			})
+
+			It("authorizes members of the group", func() {
+				authorized, err := nsPerms.AuthorizedIn(ctx, authorization.Identity{Name: userName, Kind: "User", Groups: []string{groupName}}, org1NS)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeTrue())
+			})
+
+			It("authorizes the group itself", func() {
+				authorized, err := nsPerms.AuthorizedIn(ctx, authorization.Identity{Name: groupName, Kind: "Group"}, org1NS)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(authorized).To(BeTrue())
+			})
+		})
+
 		When("a service account is authenticated", func() {
 			BeforeEach(func() {
 				createRoleBindingForServiceAccount(serviceAccountName, serviceAccountNS, roleName1, org1NS)
@@ -0,0 +1,240 @@
+package authorization
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"github.com/go-jose/go-jose/v4"
+	"github.com/golang-jwt/jwt/v5"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+
+	// reservedIdentityPrefix is the prefix of the users and groups
+	// reserved to Kubernetes, which OIDC identities must never impersonate
+	reservedIdentityPrefix = "system:"
+)
+
+type OIDCConfig struct {
+	IssuerURL      string
+	ClientID       string
+	UsernameClaim  string
+	UsernamePrefix string
+	GroupsClaim    string
+	GroupsPrefix   string
+}
+
+// OIDCTokenInspector verifies tokens issued by the configured OpenID Connect
+// provider offline, using the provider JWKS. Tokens issued by anyone else
+// are delegated to the fallback inspector.
+type OIDCTokenInspector struct {
+	config     OIDCConfig
+	httpClient *http.Client
+	fallback   TokenIdentityInspector
+
+	keysMutex sync.RWMutex
+	jwksURI   string
+	keys      jose.JSONWebKeySet
+}
+
+func NewOIDCTokenInspector(config OIDCConfig, httpClient *http.Client, fallback TokenIdentityInspector) *OIDCTokenInspector {
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = "sub"
+	}
+
+	return &OIDCTokenInspector{
+		config:     config,
+		httpClient: httpClient,
+		fallback:   fallback,
+	}
+}
+
+func (i *OIDCTokenInspector) WhoAmI(ctx context.Context, token string) (Identity, error) {
+	if !i.issuedByProvider(token) {
+		return i.fallback.WhoAmI(ctx, token)
+	}
+
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(
+		token,
+		claims,
+		func(t *jwt.Token) (any, error) { return i.verificationKeys(ctx, t) },
+		jwt.WithIssuer(i.config.IssuerURL),
+		jwt.WithAudience(i.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"}),
+	)
+	if err != nil {
+		return Identity{}, apierrors.NewInvalidAuthError(fmt.Errorf("failed to verify OIDC token: %w", err))
+	}
+
+	username, ok := claims[i.config.UsernameClaim].(string)
+	if !ok || username == "" {
+		return Identity{}, apierrors.NewInvalidAuthError(fmt.Errorf("OIDC token has no %q claim", i.config.UsernameClaim))
+	}
+
+	if isReservedIdentity(username) {
+		return Identity{}, apierrors.NewInvalidAuthError(fmt.Errorf("OIDC user %q is reserved", username))
+	}
+
+	groups, err := i.groups(claims)
+	if err != nil {
+		return Identity{}, apierrors.NewInvalidAuthError(err)
+	}
+
+	return Identity{
+		Name:        i.config.UsernamePrefix + username,
+		Kind:        rbacv1.UserKind,
+		Groups:      groups,
+		Impersonate: true,
+	}, nil
+}
+
+func (i *OIDCTokenInspector) issuedByProvider(token string) bool {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		return false
+	}
+
+	issuer, err := claims.GetIssuer()
+	if err != nil {
+		return false
+	}
+
+	return issuer == i.config.IssuerURL
+}
+
+func (i *OIDCTokenInspector) groups(claims jwt.MapClaims) ([]string, error) {
+	if i.config.GroupsClaim == "" {
+		return nil, nil
+	}
+
+	var groups []string
+	switch claim := claims[i.config.GroupsClaim].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		groups = []string{claim}
+	case []any:
+		for _, g := range claim {
+			group, ok := g.(string)
+			if !ok {
+				return nil, fmt.Errorf("OIDC token %q claim contains a non-string value", i.config.GroupsClaim)
+			}
+			groups = append(groups, group)
+		}
+	default:
+		return nil, fmt.Errorf("OIDC token %q claim is neither a string nor a list of strings", i.config.GroupsClaim)
+	}
+
+	for idx := range groups {
+		if isReservedIdentity(groups[idx]) {
+			return nil, fmt.Errorf("OIDC group %q is reserved", groups[idx])
+		}
+		groups[idx] = i.config.GroupsPrefix + groups[idx]
+	}
+
+	return groups, nil
+}
+
+func isReservedIdentity(name string) bool {
+	return strings.HasPrefix(name, reservedIdentityPrefix)
+}
+
+// verificationKeys returns the provider keys matching the token key id. The
+// JWKS is refetched when no key matches, so that key rotations at the
+// provider are picked up.
+func (i *OIDCTokenInspector) verificationKeys(ctx context.Context, token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	keys := i.cachedKeys(kid)
+	if len(keys.Keys) > 0 {
+		return keys, nil
+	}
+
+	if err := i.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	keys = i.cachedKeys(kid)
+	if len(keys.Keys) == 0 {
+		return nil, fmt.Errorf("no OIDC provider key found for key id %q", kid)
+	}
+
+	return keys, nil
+}
+
+func (i *OIDCTokenInspector) cachedKeys(kid string) jwt.VerificationKeySet {
+	i.keysMutex.RLock()
+	defer i.keysMutex.RUnlock()
+
+	keySet := jwt.VerificationKeySet{}
+	for _, key := range i.keys.Keys {
+		if key.Use == "enc" || (kid != "" && key.KeyID != kid) {
+			continue
+		}
+		keySet.Keys = append(keySet.Keys, key.Key)
+	}
+
+	return keySet
+}
+
+func (i *OIDCTokenInspector) refreshKeys(ctx context.Context) error {
+	i.keysMutex.Lock()
+	defer i.keysMutex.Unlock()
+
+	if i.jwksURI == "" {
+		var discovery struct {
+			Issuer  string `json:"issuer"`
+			JWKSURI string `json:"jwks_uri"`
+		}
+		if err := i.getJSON(ctx, strings.TrimSuffix(i.config.IssuerURL, "/")+oidcDiscoveryPath, &discovery); err != nil {
+			return fmt.Errorf("failed to discover OIDC provider metadata: %w", err)
+		}
+
+		if discovery.Issuer != i.config.IssuerURL {
+			return fmt.Errorf("OIDC provider issuer %q does not match the configured issuer %q", discovery.Issuer, i.config.IssuerURL)
+		}
+
+		if discovery.JWKSURI == "" {
+			return errors.New("OIDC provider metadata has no jwks_uri")
+		}
+
+		i.jwksURI = discovery.JWKSURI
+	}
+
+	keys := jose.JSONWebKeySet{}
+	if err := i.getJSON(ctx, i.jwksURI, &keys); err != nil {
+		return fmt.Errorf("failed to fetch OIDC provider keys: %w", err)
+	}
+	i.keys = keys
+
+	return nil
+}
+
+func (i *OIDCTokenInspector) getJSON(ctx context.Context, url string, target any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := i.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned status %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
@@ -0,0 +1,141 @@
+package authorization_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/authorization/fake"
+	"code.cloudfoundry.org/korifi/api/authorization/testhelpers"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var _ = Describe("OIDCTokenInspector", func() {
+	var (
+		ctx              context.Context
+		oidcConfig       authorization.OIDCConfig
+		fallback         *fake.TokenIdentityInspector
+		token            string
+		identity         authorization.Identity
+		whoAmIErr        error
+		otherProvider    *testhelpers.AuthProvider
+		inspectorFactory func() *authorization.OIDCTokenInspector
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fallback = new(fake.TokenIdentityInspector)
+		fallback.WhoAmIReturns(authorization.Identity{Name: "fallback-user", Kind: rbacv1.UserKind}, nil)
+
+		oidcConfig = authorization.OIDCConfig{
+			IssuerURL:      authProvider.IssuerURL(),
+			ClientID:       authProvider.Audience(),
+			UsernamePrefix: "corp:",
+			GroupsClaim:    "groups",
+			GroupsPrefix:   "corp:",
+		}
+		inspectorFactory = func() *authorization.OIDCTokenInspector {
+			return authorization.NewOIDCTokenInspector(oidcConfig, authProvider.HTTPClient(), fallback)
+		}
+
+		token = authProvider.GenerateJWTToken("alice", "devs", "admins")
+	})
+
+	JustBeforeEach(func() {
+		identity, whoAmIErr = inspectorFactory().WhoAmI(ctx, token)
+	})
+
+	It("verifies the token and maps its claims to the identity", func() {
+		Expect(whoAmIErr).NotTo(HaveOccurred())
+		Expect(identity).To(Equal(authorization.Identity{
+			Name:        "corp:alice",
+			Kind:        rbacv1.UserKind,
+			Groups:      []string{"corp:devs", "corp:admins"},
+			Impersonate: true,
+		}))
+		Expect(fallback.WhoAmICallCount()).To(BeZero())
+	})
+
+	When("the token is issued by another issuer", func() {
+		BeforeEach(func() {
+			otherProvider = testhelpers.NewAuthProvider()
+			DeferCleanup(otherProvider.Stop)
+			token = otherProvider.GenerateJWTToken("bob")
+		})
+
+		It("delegates to the fallback inspector", func() {
+			Expect(whoAmIErr).NotTo(HaveOccurred())
+			Expect(identity.Name).To(Equal("fallback-user"))
+			Expect(fallback.WhoAmICallCount()).To(Equal(1))
+			_, actualToken := fallback.WhoAmIArgsForCall(0)
+			Expect(actualToken).To(Equal(token))
+		})
+	})
+
+	When("the token is not a JWT", func() {
+		BeforeEach(func() {
+			token = "not-a-jwt"
+			fallback.WhoAmIReturns(authorization.Identity{}, errors.New("fallback-err"))
+		})
+
+		It("delegates to the fallback inspector", func() {
+			Expect(whoAmIErr).To(MatchError("fallback-err"))
+		})
+	})
+
+	When("the token is issued for another client", func() {
+		BeforeEach(func() {
+			oidcConfig.ClientID = "another-client"
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(whoAmIErr).To(BeAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token does not have the username claim", func() {
+		BeforeEach(func() {
+			oidcConfig.UsernameClaim = "email"
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(whoAmIErr).To(BeAssignableToTypeOf(apierrors.InvalidAuthError{}))
+		})
+	})
+
+	When("the token user is reserved to Kubernetes", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTToken("system:admin", "devs")
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(whoAmIErr).To(BeAssignableToTypeOf(apierrors.InvalidAuthError{}))
+			Expect(identity).To(BeZero())
+		})
+	})
+
+	When("a token group is reserved to Kubernetes", func() {
+		BeforeEach(func() {
+			token = authProvider.GenerateJWTToken("alice", "devs", "system:masters")
+		})
+
+		It("returns an invalid auth error", func() {
+			Expect(whoAmIErr).To(BeAssignableToTypeOf(apierrors.InvalidAuthError{}))
+			Expect(identity).To(BeZero())
+		})
+	})
+
+	When("no groups claim is configured", func() {
+		BeforeEach(func() {
+			oidcConfig.GroupsClaim = ""
+		})
+
+		It("does not map groups", func() {
+			Expect(whoAmIErr).NotTo(HaveOccurred())
+			Expect(identity.Groups).To(BeEmpty())
+		})
+	})
+})
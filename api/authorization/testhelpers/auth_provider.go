@@ -161,3 +161,15 @@ func (p *AuthProvider) APIServerExtraArgs(oidcPrefix string) map[string]string {
 		"oidc-groups-claim":    "groups",
 	}
 }
+
+func (p *AuthProvider) IssuerURL() string {
+	return p.server.URL()
+}
+
+func (p *AuthProvider) Audience() string {
+	return audience
+}
+
+func (p *AuthProvider) HTTPClient() *http.Client {
+	return p.server.HTTPTestServer.Client()
+}
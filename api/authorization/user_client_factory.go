@@ -21,18 +21,20 @@ type UserClientFactory interface {
 }
 
 type UnprivilegedClientFactory struct {
-	config   *rest.Config
-	mapper   meta.RESTMapper
-	wrappers []ClientWrappingFunc
-	scheme   *runtime.Scheme
+	config              *rest.Config
+	impersonatingConfig *rest.Config
+	mapper              meta.RESTMapper
+	wrappers            []ClientWrappingFunc
+	scheme              *runtime.Scheme
 }
 
 func NewUnprivilegedClientFactory(config *rest.Config, mapper meta.RESTMapper, scheme *runtime.Scheme) UnprivilegedClientFactory {
 	return UnprivilegedClientFactory{
-		config:   rest.AnonymousClientConfig(rest.CopyConfig(config)),
-		mapper:   mapper,
-		wrappers: []ClientWrappingFunc{},
-		scheme:   scheme,
+		config:              rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		impersonatingConfig: rest.CopyConfig(config),
+		mapper:              mapper,
+		wrappers:            []ClientWrappingFunc{},
+		scheme:              scheme,
 	}
 }
 
@@ -46,6 +48,10 @@ func (f UnprivilegedClientFactory) BuildClient(authInfo Info) (client.WithWatch,
 
 	switch strings.ToLower(authInfo.Scheme()) {
 	case BearerScheme:
+		if authInfo.Impersonation != nil {
+			config = impersonatingConfig(f.impersonatingConfig, *authInfo.Impersonation)
+			break
+		}
 		config.BearerToken = authInfo.Token
 
 	case CertScheme:
@@ -80,3 +86,15 @@ func (f UnprivilegedClientFactory) BuildClient(authInfo Info) (client.WithWatch,
 
 	return userClient, nil
 }
+
+// impersonatingConfig returns a copy of the API's own config that acts on
+// behalf of the given identity
+func impersonatingConfig(config *rest.Config, identity Identity) *rest.Config {
+	impersonating := rest.CopyConfig(config)
+	impersonating.Impersonate = rest.ImpersonationConfig{
+		UserName: identity.Name,
+		Groups:   identity.Groups,
+	}
+
+	return impersonating
+}
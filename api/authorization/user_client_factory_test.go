@@ -136,6 +136,33 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 				})
 			})
 		})
+
+		Context("impersonated identities", func() {
+			BeforeEach(func() {
+				authInfo.Token = "a-token-the-api-server-does-not-trust"
+				authInfo.Impersonation = &authorization.Identity{
+					Name:        "corp:" + userName,
+					Kind:        rbacv1.UserKind,
+					Impersonate: true,
+				}
+			})
+
+			It("succeeds and forbids access to the user", func() {
+				Expect(buildClientErr).NotTo(HaveOccurred())
+				Expect(k8serrors.IsForbidden(podListErr)).To(BeTrue())
+			})
+
+			When("a role binding exists", func() {
+				BeforeEach(func() {
+					allowListingPods("corp:" + userName)
+				})
+
+				It("allows listing pods", func() {
+					Expect(buildClientErr).NotTo(HaveOccurred())
+					Expect(podListErr).NotTo(HaveOccurred())
+				})
+			})
+		})
 	})
 
 	Context("isolation", func() {
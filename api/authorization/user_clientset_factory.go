@@ -17,12 +17,14 @@ type UserClientsetFactory interface {
 }
 
 type UnprivilegedClientsetFactory struct {
-	config *rest.Config
+	config              *rest.Config
+	impersonatingConfig *rest.Config
 }
 
 func NewUnprivilegedClientsetFactory(config *rest.Config) UnprivilegedClientsetFactory {
 	return UnprivilegedClientsetFactory{
-		config: rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		config:              rest.AnonymousClientConfig(rest.CopyConfig(config)),
+		impersonatingConfig: rest.CopyConfig(config),
 	}
 }
 
@@ -31,6 +33,10 @@ func (f UnprivilegedClientsetFactory) BuildClientset(authInfo Info) (k8sclient.I
 
 	switch strings.ToLower(authInfo.Scheme()) {
 	case BearerScheme:
+		if authInfo.Impersonation != nil {
+			config = impersonatingConfig(f.impersonatingConfig, *authInfo.Impersonation)
+			break
+		}
 		config.BearerToken = authInfo.Token
 
 	case CertScheme:
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/tools"
@@ -14,6 +15,8 @@ import (
 const (
 	OrgRole   RoleLevel = "org"
 	SpaceRole RoleLevel = "space"
+
+	reservedIdentityPrefix = "system:"
 )
 
 type (
@@ -56,6 +59,7 @@ type (
 	Experimental struct {
 		ManagedServices  ManagedServices `yaml:"managedServices"`
 		UAA              UAA             `yaml:"uaa"`
+		OIDC             OIDC            `yaml:"oidc"`
 		ExternalLogCache ExtenalLogCache `yaml:"externalLogCache"`
 		K8SClient        K8SClientConfig `yaml:"k8sClient"`
 		SecurityGroups   SecurityGroups  `yaml:"securityGroups"`
@@ -70,6 +74,19 @@ type (
 		URL     string `yaml:"url"`
 	}
 
+	// OIDC configures an OpenID Connect provider whose tokens are verified by
+	// the API itself, rather than by the Kubernetes API server
+	OIDC struct {
+		Enabled        bool   `yaml:"enabled"`
+		IssuerURL      string `yaml:"issuerURL"`
+		ClientID       string `yaml:"clientID"`
+		UsernameClaim  string `yaml:"usernameClaim"`
+		UsernamePrefix string `yaml:"usernamePrefix"`
+		GroupsClaim    string `yaml:"groupsClaim"`
+		GroupsPrefix   string `yaml:"groupsPrefix"`
+		CACert         string `yaml:"caCert"`
+	}
+
 	ExtenalLogCache struct {
 		Enabled               bool   `yaml:"enabled"`
 		URL                   string `yaml:"url"`
@@ -163,6 +180,31 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	if c.Experimental.OIDC.Enabled {
+		if err := c.Experimental.OIDC.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validate ensures that OIDC identities can never clash with Kubernetes
+// users and groups, as the API impersonates them and RBAC cannot restrict
+// impersonation to a name prefix
+func (o OIDC) validate() error {
+	if o.IssuerURL == "" || o.ClientID == "" {
+		return errors.New("OIDC requires values for issuerURL and clientID")
+	}
+
+	if o.UsernamePrefix == "" || o.GroupsPrefix == "" {
+		return errors.New("OIDC requires values for usernamePrefix and groupsPrefix")
+	}
+
+	if strings.HasPrefix(o.UsernamePrefix, reservedIdentityPrefix) || strings.HasPrefix(o.GroupsPrefix, reservedIdentityPrefix) {
+		return fmt.Errorf("OIDC usernamePrefix and groupsPrefix must not start with %q", reservedIdentityPrefix)
+	}
+
 	return nil
 }
 
@@ -221,6 +221,74 @@ var _ = Describe("Config", func() {
 		})
 	})
 
+	When("OIDC is enabled without an issuer", func() {
+		BeforeEach(func() {
+			configMap["experimental"] = map[string]any{
+				"oidc": map[string]any{
+					"enabled":  true,
+					"clientID": "korifi",
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(loadErr).To(MatchError("OIDC requires values for issuerURL and clientID"))
+		})
+	})
+
+	When("OIDC is enabled without identity prefixes", func() {
+		BeforeEach(func() {
+			configMap["experimental"] = map[string]any{
+				"oidc": map[string]any{
+					"enabled":   true,
+					"issuerURL": "https://oidc.example.com",
+					"clientID":  "korifi",
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(loadErr).To(MatchError("OIDC requires values for usernamePrefix and groupsPrefix"))
+		})
+	})
+
+	When("OIDC identity prefixes are reserved to Kubernetes", func() {
+		BeforeEach(func() {
+			configMap["experimental"] = map[string]any{
+				"oidc": map[string]any{
+					"enabled":        true,
+					"issuerURL":      "https://oidc.example.com",
+					"clientID":       "korifi",
+					"usernamePrefix": "system:",
+					"groupsPrefix":   "oidc:",
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(loadErr).To(MatchError(`OIDC usernamePrefix and groupsPrefix must not start with "system:"`))
+		})
+	})
+
+	When("OIDC is fully configured", func() {
+		BeforeEach(func() {
+			configMap["experimental"] = map[string]any{
+				"oidc": map[string]any{
+					"enabled":        true,
+					"issuerURL":      "https://oidc.example.com",
+					"clientID":       "korifi",
+					"usernamePrefix": "oidc:",
+					"groupsPrefix":   "oidc:",
+				},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.Experimental.OIDC.UsernamePrefix).To(Equal("oidc:"))
+		})
+	})
+
 	When("external port is specified", func() {
 		BeforeEach(func() {
 			configMap["externalPort"] = 1234
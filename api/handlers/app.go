@@ -78,6 +78,7 @@ type App struct {
 	podRepo                 PodRepository
 	gaugesCollector         GaugesCollector
 	instancesStateCollector InstancesStateCollector
+	stackRepo               LifecycleStackRepository
 }
 
 func NewApp(
@@ -93,6 +94,7 @@ func NewApp(
 	podRepo PodRepository,
 	gaugesCollector GaugesCollector,
 	instancesStateCollector InstancesStateCollector,
+	stackRepo LifecycleStackRepository,
 ) *App {
 	return &App{
 		serverURL:               serverURL,
@@ -107,6 +109,7 @@ func NewApp(
 		podRepo:                 podRepo,
 		gaugesCollector:         gaugesCollector,
 		instancesStateCollector: instancesStateCollector,
+		stackRepo:               stackRepo,
 	}
 }
 
@@ -141,12 +144,26 @@ func (h *App) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
-	appRecord, err := h.appRepo.CreateApp(r.Context(), authInfo, payload.ToAppCreateMessage())
+	createMessage := payload.ToAppCreateMessage()
+	requestedStack := ""
+	if payload.Lifecycle != nil {
+		requestedStack = payload.Lifecycle.Data.Stack
+	}
+	if err = applyDefaultStack(r.Context(), h.stackRepo, authInfo, &createMessage.Lifecycle, requestedStack); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to resolve the default stack", "App Name", payload.Name)
+	}
+
+	appRecord, err := h.appRepo.CreateApp(r.Context(), authInfo, createMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create app", "App Name", payload.Name)
 	}
 
-	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForApp(appRecord, h.serverURL)), nil
+	resp := routing.NewResponse(http.StatusCreated).WithBody(presenter.ForApp(appRecord, h.serverURL))
+	if warning := stackDeprecationWarning(r.Context(), logger, h.stackRepo, authInfo, appRecord.Lifecycle); warning != "" {
+		resp = resp.WithHeader("X-Cf-Warnings", warning)
+	}
+
+	return resp, nil
 }
 
 func (h *App) list(r *http.Request) (*routing.Response, error) { //nolint:dupl
@@ -673,7 +690,13 @@ func (h *App) update(r *http.Request) (*routing.Response, error) {
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app", "AppGUID", appGUID)
 	}
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
+
+	resp := routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL))
+	if warning := stackDeprecationWarning(r.Context(), logger, h.stackRepo, authInfo, app.Lifecycle); warning != "" {
+		resp = resp.WithHeader("X-Cf-Warnings", warning)
+	}
+
+	return resp, nil
 }
 
 func (h *App) getSSHEnabled(r *http.Request) (*routing.Response, error) {
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/routing"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppManifestPath = "/v3/apps/{guid}/manifest"
+)
+
+//counterfeiter:generate -o fake -fake-name ManifestGenerator . ManifestGenerator
+type ManifestGenerator interface {
+	Generate(ctx context.Context, authInfo authorization.Info, appName, spaceGUID string) (payloads.Manifest, error)
+}
+
+type AppManifest struct {
+	serverURL         url.URL
+	appRepo           CFAppRepository
+	manifestGenerator ManifestGenerator
+}
+
+func NewAppManifest(
+	serverURL url.URL,
+	appRepo CFAppRepository,
+	manifestGenerator ManifestGenerator,
+) *AppManifest {
+	return &AppManifest{
+		serverURL:         serverURL,
+		appRepo:           appRepo,
+		manifestGenerator: manifestGenerator,
+	}
+}
+
+func (h *AppManifest) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AppManifest) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AppManifestPath, Handler: h.get},
+	}
+}
+
+func (h *AppManifest) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-manifest.get")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	manifest, err := h.manifestGenerator.Generate(r.Context(), authInfo, app.Name, app.SpaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to generate manifest", "guid", appGUID)
+	}
+
+	manifestYAML, err := yaml.Marshal(manifest)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to marshal manifest", "guid", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "application/x-yaml").
+		WithContent(bytes.NewReader(manifestYAML)), nil
+}
@@ -0,0 +1,116 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppManifest", func() {
+	var (
+		appRepo           *fake.CFAppRepository
+		manifestGenerator *fake.ManifestGenerator
+	)
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		manifestGenerator = new(fake.ManifestGenerator)
+
+		apiHandler := NewAppManifest(
+			*serverURL,
+			appRepo,
+			manifestGenerator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:      "app-guid",
+			Name:      "my-app",
+			SpaceGUID: "space-guid",
+		}, nil)
+		manifestGenerator.GenerateReturns(payloads.Manifest{
+			Version: 1,
+			Applications: []payloads.ManifestApplication{{
+				Name:       "my-app",
+				Env:        map[string]string{"FOO": "bar"},
+				Buildpacks: []string{"go_buildpack"},
+				Processes: []payloads.ManifestApplicationProcess{{
+					Type:      "web",
+					Instances: tools.PtrTo[int32](2),
+					Memory:    tools.PtrTo("256M"),
+				}},
+				Routes: []payloads.ManifestRoute{{Route: tools.PtrTo("my-app.my.domain")}},
+			}},
+		}, nil)
+	})
+
+	Describe("GET /v3/apps/{guid}/manifest", func() {
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/manifest", nil)
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("returns the manifest of the app as yaml", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/x-yaml"))
+			Expect(rr).To(HaveHTTPBody(MatchYAML(`
+version: 1
+applications:
+- name: my-app
+  env:
+    FOO: bar
+  buildpacks:
+  - go_buildpack
+  processes:
+  - type: web
+    instances: 2
+    memory: 256M
+  routes:
+  - route: my-app.my.domain
+`)))
+		})
+
+		It("generates the manifest for the app", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(manifestGenerator.GenerateCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppName, actualSpaceGUID := manifestGenerator.GenerateArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppName).To(Equal("my-app"))
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("generating the manifest fails", func() {
+			BeforeEach(func() {
+				manifestGenerator.GenerateReturns(payloads.Manifest{}, errors.New("generate-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -43,6 +43,7 @@ var _ = Describe("App", func() {
 		requestValidator        *fake.RequestValidator
 		gaugesCollector         *fake.GaugesCollector
 		instancesStateCollector *fake.InstancesStateCollector
+		stackRepo               *fake.LifecycleStackRepository
 		req                     *http.Request
 
 		appRecord repositories.AppRecord
@@ -60,6 +61,9 @@ var _ = Describe("App", func() {
 		podRepo = new(fake.PodRepository)
 		gaugesCollector = new(fake.GaugesCollector)
 		instancesStateCollector = new(fake.InstancesStateCollector)
+		stackRepo = new(fake.LifecycleStackRepository)
+		stackRepo.GetDefaultStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+		stackRepo.GetStackByNameReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
 
 		apiHandler := NewApp(
 			*serverURL,
@@ -74,6 +78,7 @@ var _ = Describe("App", func() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			stackRepo,
 		)
 
 		appRecord = repositories.AppRecord{
@@ -193,6 +198,53 @@ var _ = Describe("App", func() {
 			}))
 		})
 
+		When("there is a default stack", func() {
+			BeforeEach(func() {
+				stackRepo.GetDefaultStackReturns(repositories.StackRecord{Name: "default-stack"}, nil)
+			})
+
+			It("creates the app with the default stack", func() {
+				Expect(stackRepo.GetDefaultStackCallCount()).To(Equal(1))
+				_, actualAuthInfo := stackRepo.GetDefaultStackArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+
+				Expect(appRepo.CreateAppCallCount()).To(Equal(1))
+				_, _, actualCreateMessage := appRepo.CreateAppArgsForCall(0)
+				Expect(actualCreateMessage.Lifecycle.Data.Stack).To(Equal("default-stack"))
+			})
+		})
+
+		When("getting the default stack fails", func() {
+			BeforeEach(func() {
+				stackRepo.GetDefaultStackReturns(repositories.StackRecord{}, errors.New("get-default-stack-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(appRepo.CreateAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app stack is deprecated", func() {
+			BeforeEach(func() {
+				appRecord.Lifecycle.Data.Stack = "old-stack"
+				appRepo.CreateAppReturns(appRecord, nil)
+				stackRepo.GetStackByNameReturns(repositories.StackRecord{
+					Name:  "old-stack",
+					State: repositories.StackStateDeprecated,
+				}, nil)
+			})
+
+			It("returns a deprecation warning", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPHeaderWithValue("X-Cf-Warnings", "Stack 'old-stack' is deprecated and will be removed in the future."))
+
+				Expect(stackRepo.GetStackByNameCallCount()).To(Equal(1))
+				_, _, actualStackName := stackRepo.GetStackByNameArgsForCall(0)
+				Expect(actualStackName).To(Equal("old-stack"))
+			})
+		})
+
 		When("the app has buildpack lifecycle", func() {
 			BeforeEach(func() {
 				payload.Lifecycle = &payloads.Lifecycle{
@@ -204,6 +256,10 @@ var _ = Describe("App", func() {
 				}
 			})
 
+			It("does not look up the default stack", func() {
+				Expect(stackRepo.GetDefaultStackCallCount()).To(BeZero())
+			})
+
 			It("sends an AppCreate message with buildpack lifecycle", func() {
 				Expect(appRepo.CreateAppCallCount()).To(Equal(1))
 				_, _, actualCreateMessage := appRepo.CreateAppArgsForCall(0)
@@ -466,6 +522,22 @@ var _ = Describe("App", func() {
 			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
 		})
 
+		When("the app stack is deprecated", func() {
+			BeforeEach(func() {
+				appRecord.Lifecycle.Data.Stack = "cflinuxfs3"
+				appRepo.PatchAppReturns(appRecord, nil)
+				stackRepo.GetStackByNameReturns(repositories.StackRecord{
+					Name:  "cflinuxfs3",
+					State: repositories.StackStateDeprecated,
+				}, nil)
+			})
+
+			It("returns a deprecation warning", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPHeaderWithValue("X-Cf-Warnings", "Stack 'cflinuxfs3' is deprecated and will be removed in the future."))
+			})
+		})
+
 		It("returns the App in the response", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppUsageEventsPath      = "/v3/app_usage_events"
+	AppUsageEventPath       = "/v3/app_usage_events/{guid}"
+	AppUsageEventsPurgePath = "/v3/app_usage_events/actions/destructively_purge_all_and_reseed"
+)
+
+//counterfeiter:generate -o fake -fake-name AppUsageEventRepository . AppUsageEventRepository
+type AppUsageEventRepository interface {
+	ListAppUsageEvents(context.Context, authorization.Info, repositories.ListUsageEventsMessage) (repositories.ListResult[repositories.AppUsageEventRecord], error)
+	GetAppUsageEvent(context.Context, authorization.Info, string) (repositories.AppUsageEventRecord, error)
+	PurgeAndReseedAppUsageEvents(context.Context, authorization.Info) error
+}
+
+type AppUsageEvent struct {
+	serverURL        url.URL
+	usageEventRepo   AppUsageEventRepository
+	requestValidator RequestValidator
+}
+
+func NewAppUsageEvent(
+	serverURL url.URL,
+	usageEventRepo AppUsageEventRepository,
+	requestValidator RequestValidator,
+) *AppUsageEvent {
+	return &AppUsageEvent{
+		serverURL:        serverURL,
+		usageEventRepo:   usageEventRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *AppUsageEvent) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.list")
+
+	payload := new(payloads.UsageEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	events, err := h.usageEventRepo.ListAppUsageEvents(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list app usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAppUsageEvent, events, h.serverURL, *r.URL)), nil
+}
+
+func (h *AppUsageEvent) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.get")
+	eventGUID := routing.URLParam(r, "guid")
+
+	event, err := h.usageEventRepo.GetAppUsageEvent(r.Context(), authInfo, eventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to get app usage event", "guid", eventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppUsageEvent(event, h.serverURL)), nil
+}
+
+func (h *AppUsageEvent) purge(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-usage-event.purge")
+
+	if err := h.usageEventRepo.PurgeAndReseedAppUsageEvents(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to purge and reseed app usage events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]any{}), nil
+}
+
+func (h *AppUsageEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AppUsageEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AppUsageEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: AppUsageEventPath, Handler: h.get},
+		{Method: "POST", Pattern: AppUsageEventsPurgePath, Handler: h.purge},
+	}
+}
@@ -0,0 +1,174 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppUsageEvent", func() {
+	var (
+		usageEventRepo   *fake.AppUsageEventRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		usageEventRepo = new(fake.AppUsageEventRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewAppUsageEvent(*serverURL, usageEventRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/app_usage_events", func() {
+		BeforeEach(func() {
+			usageEventRepo.ListAppUsageEventsReturns(repositories.ListResult[repositories.AppUsageEventRecord]{
+				Records: []repositories.AppUsageEventRecord{{
+					GUID:      "event-guid",
+					CreatedAt: time.UnixMilli(1000),
+					State:     "STARTED",
+				}},
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 1,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     50,
+				},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.UsageEventList{
+				AfterGUID: "previous-event-guid",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/app_usage_events?after_guid=previous-event-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the app usage events after the cursor", func() {
+			Expect(usageEventRepo.ListAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := usageEventRepo.ListAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.AfterGUID).To(Equal("previous-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "event-guid"),
+				MatchJSONPath("$.resources[0].state.current", "STARTED"),
+			)))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the cursor is not a valid event", func() {
+			BeforeEach(func() {
+				usageEventRepo.ListAppUsageEventsReturns(repositories.ListResult[repositories.AppUsageEventRecord]{},
+					apierrors.NewUnprocessableEntityError(nil, "After guid filter must be a valid app usage event guid."))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("After guid filter must be a valid app usage event guid.")
+			})
+		})
+	})
+
+	Describe("GET /v3/app_usage_events/{guid}", func() {
+		BeforeEach(func() {
+			usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{
+				GUID:  "event-guid",
+				State: "STOPPED",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/app_usage_events/event-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the app usage event", func() {
+			Expect(usageEventRepo.GetAppUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := usageEventRepo.GetAppUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.state.current", "STOPPED"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/app_usage_events/event-guid"),
+			)))
+		})
+
+		When("the event does not exist", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, apierrors.NewNotFoundError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppUsageEventResourceType)
+			})
+		})
+
+		When("the user is not allowed to see the event", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AppUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppUsageEventResourceType)
+			})
+		})
+	})
+
+	Describe("POST /v3/app_usage_events/actions/destructively_purge_all_and_reseed", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/app_usage_events/actions/destructively_purge_all_and_reseed", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("purges and reseeds the app usage events", func() {
+			Expect(usageEventRepo.PurgeAndReseedAppUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo := usageEventRepo.PurgeAndReseedAppUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+		})
+
+		When("purging fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.PurgeAndReseedAppUsageEventsReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+// The autoscaling endpoints follow the App Autoscaler API so that existing
+// clients such as the autoscaler cf CLI plugin work against korifi
+const (
+	AutoscalingPolicyPath      = "/v1/apps/{guid}/policy"
+	AutoscalingHistoryPath     = "/v1/apps/{guid}/scaling_histories"
+	autoscalingPolicyDeleteMsg = "autoscaling policy deleted"
+)
+
+//counterfeiter:generate -o fake -fake-name AutoscalingPolicyRepository . AutoscalingPolicyRepository
+type AutoscalingPolicyRepository interface {
+	GetAutoscalingPolicy(context.Context, authorization.Info, string, string) (repositories.AutoscalingPolicyRecord, error)
+	SetAutoscalingPolicy(context.Context, authorization.Info, repositories.SetAutoscalingPolicyMessage) (repositories.AutoscalingPolicyRecord, error)
+	DeleteAutoscalingPolicy(context.Context, authorization.Info, repositories.DeleteAutoscalingPolicyMessage) error
+	ListScalingHistory(context.Context, authorization.Info, repositories.ListScalingHistoryMessage) (repositories.ListResult[repositories.ScalingEventRecord], error)
+}
+
+type AutoscalingPolicy struct {
+	serverURL        url.URL
+	appRepo          CFAppRepository
+	policyRepo       AutoscalingPolicyRepository
+	requestValidator RequestValidator
+}
+
+func NewAutoscalingPolicy(
+	serverURL url.URL,
+	appRepo CFAppRepository,
+	policyRepo AutoscalingPolicyRepository,
+	requestValidator RequestValidator,
+) *AutoscalingPolicy {
+	return &AutoscalingPolicy{
+		serverURL:        serverURL,
+		appRepo:          appRepo,
+		policyRepo:       policyRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *AutoscalingPolicy) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.autoscaling-policy.get")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	policy, err := h.policyRepo.GetAutoscalingPolicy(r.Context(), authInfo, app.SpaceGUID, app.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get autoscaling policy", "guid", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAutoscalingPolicy(policy)), nil
+}
+
+func (h *AutoscalingPolicy) set(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.autoscaling-policy.set")
+	appGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.AutoscalingPolicy
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	policy, err := h.policyRepo.SetAutoscalingPolicy(r.Context(), authInfo, payload.ToMessage(app.GUID, app.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to set autoscaling policy", "guid", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAutoscalingPolicy(policy)), nil
+}
+
+func (h *AutoscalingPolicy) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.autoscaling-policy.delete")
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	err = h.policyRepo.DeleteAutoscalingPolicy(r.Context(), authInfo, repositories.DeleteAutoscalingPolicyMessage{
+		AppGUID:   app.GUID,
+		SpaceGUID: app.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to delete autoscaling policy", "guid", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]string{"message": autoscalingPolicyDeleteMsg}), nil
+}
+
+func (h *AutoscalingPolicy) listHistory(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.autoscaling-policy.list-history")
+	appGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.AutoscalingHistoryList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	history, err := h.policyRepo.ListScalingHistory(r.Context(), authInfo, payload.ToMessage(app.GUID, app.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list scaling history", "guid", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForScalingHistory(history)), nil
+}
+
+func (h *AutoscalingPolicy) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AutoscalingPolicy) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AutoscalingPolicyPath, Handler: h.get},
+		{Method: "PUT", Pattern: AutoscalingPolicyPath, Handler: h.set},
+		{Method: "DELETE", Pattern: AutoscalingPolicyPath, Handler: h.delete},
+		{Method: "GET", Pattern: AutoscalingHistoryPath, Handler: h.listHistory},
+	}
+}
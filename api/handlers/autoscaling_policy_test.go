@@ -0,0 +1,322 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("AutoscalingPolicy", func() {
+	var (
+		appRepo          *fake.CFAppRepository
+		policyRepo       *fake.AutoscalingPolicyRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		policyRepo = new(fake.AutoscalingPolicyRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid"}, nil)
+		policyRepo.GetAutoscalingPolicyReturns(repositories.AutoscalingPolicyRecord{
+			AppGUID:          "app-guid",
+			SpaceGUID:        "space-guid",
+			InstanceMinCount: 1,
+			InstanceMaxCount: 3,
+			ScalingRules: []korifiv1alpha1.ScalingRule{{
+				MetricType:            korifiv1alpha1.AutoscalingMetricCPU,
+				Threshold:             80,
+				Operator:              ">=",
+				BreachDurationSeconds: 120,
+				CoolDownSeconds:       300,
+				Adjustment:            "+1",
+			}},
+		}, nil)
+
+		apiHandler := NewAutoscalingPolicy(*serverURL, appRepo, policyRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v1/apps/{guid}/policy", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v1/apps/app-guid/policy", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the policy", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(policyRepo.GetAutoscalingPolicyCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID, actualPolicyAppGUID := policyRepo.GetAutoscalingPolicyArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+			Expect(actualPolicyAppGUID).To(Equal("app-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+				"instance_min_count": 1,
+				"instance_max_count": 3,
+				"scaling_rules": [{
+					"metric_type": "cpu",
+					"breach_duration_secs": 120,
+					"threshold": 80,
+					"operator": ">=",
+					"cool_down_secs": 300,
+					"adjustment": "+1"
+				}]
+			}`)))
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
+
+		When("the app has no policy", func() {
+			BeforeEach(func() {
+				policyRepo.GetAutoscalingPolicyReturns(repositories.AutoscalingPolicyRecord{}, apierrors.NewNotFoundError(nil, repositories.AutoscalingPolicyResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AutoscalingPolicyResourceType)
+			})
+		})
+	})
+
+	Describe("PUT /v1/apps/{guid}/policy", func() {
+		BeforeEach(func() {
+			policyRepo.SetAutoscalingPolicyReturns(repositories.AutoscalingPolicyRecord{
+				AppGUID:          "app-guid",
+				SpaceGUID:        "space-guid",
+				InstanceMinCount: 2,
+				InstanceMaxCount: 5,
+				ScalingRules: []korifiv1alpha1.ScalingRule{{
+					MetricType:            korifiv1alpha1.AutoscalingMetricMemoryUsed,
+					Threshold:             512,
+					Operator:              ">",
+					BreachDurationSeconds: 120,
+					CoolDownSeconds:       300,
+					Adjustment:            "+50%",
+				}},
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.AutoscalingPolicy{
+				InstanceMinCount: 2,
+				InstanceMaxCount: 5,
+				ScalingRules: []payloads.AutoscalingRule{{
+					MetricType: "memoryused",
+					Threshold:  512,
+					Operator:   ">",
+					Adjustment: "+50%",
+				}},
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PUT", "/v1/apps/app-guid/policy", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("validates the payload", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+		})
+
+		It("sets the policy", func() {
+			Expect(policyRepo.SetAutoscalingPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := policyRepo.SetAutoscalingPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.AppGUID).To(Equal("app-guid"))
+			Expect(message.SpaceGUID).To(Equal("space-guid"))
+			Expect(message.InstanceMinCount).To(BeEquivalentTo(2))
+			Expect(message.InstanceMaxCount).To(BeEquivalentTo(5))
+			Expect(message.ScalingRules).To(ConsistOf(korifiv1alpha1.ScalingRule{
+				MetricType:            korifiv1alpha1.AutoscalingMetricMemoryUsed,
+				Threshold:             512,
+				Operator:              ">",
+				BreachDurationSeconds: 120,
+				CoolDownSeconds:       300,
+				Adjustment:            "+50%",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.instance_min_count", BeEquivalentTo(2)),
+				MatchJSONPath("$.instance_max_count", BeEquivalentTo(5)),
+				MatchJSONPath("$.scaling_rules[0].adjustment", "+50%"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(policyRepo.SetAutoscalingPolicyCallCount()).To(BeZero())
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+				Expect(policyRepo.SetAutoscalingPolicyCallCount()).To(BeZero())
+			})
+		})
+
+		When("setting the policy fails", func() {
+			BeforeEach(func() {
+				policyRepo.SetAutoscalingPolicyReturns(repositories.AutoscalingPolicyRecord{}, errors.New("set-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v1/apps/{guid}/policy", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v1/apps/app-guid/policy", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the policy", func() {
+			Expect(policyRepo.DeleteAutoscalingPolicyCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := policyRepo.DeleteAutoscalingPolicyArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.DeleteAutoscalingPolicyMessage{
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+		})
+
+		When("the app has no policy", func() {
+			BeforeEach(func() {
+				policyRepo.DeleteAutoscalingPolicyReturns(apierrors.NewNotFoundError(nil, repositories.AutoscalingPolicyResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AutoscalingPolicyResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v1/apps/{guid}/scaling_histories", func() {
+		var timestamp time.Time
+
+		BeforeEach(func() {
+			timestamp = time.Unix(0, 1700000000000000000)
+			policyRepo.ListScalingHistoryReturns(repositories.ListResult[repositories.ScalingEventRecord]{
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 1,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     50,
+				},
+				Records: []repositories.ScalingEventRecord{{
+					AppGUID:      "app-guid",
+					Timestamp:    timestamp,
+					ScalingType:  string(korifiv1alpha1.ScalingTypeDynamic),
+					Status:       string(korifiv1alpha1.ScalingStatusFailed),
+					OldInstances: 1,
+					NewInstances: 2,
+					Reason:       "+1 instance(s) because cpu >= 80 for 120 seconds",
+					Error:        "boom",
+				}},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AutoscalingHistoryList{
+				StartTime: tools.PtrTo[int64](10),
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v1/apps/app-guid/scaling_histories?start-time=10", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the scaling history", func() {
+			Expect(policyRepo.ListScalingHistoryCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := policyRepo.ListScalingHistoryArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.AppGUID).To(Equal("app-guid"))
+			Expect(message.SpaceGUID).To(Equal("space-guid"))
+			Expect(message.StartTime).To(PointTo(BeTemporally("==", time.Unix(0, 10))))
+			Expect(message.Descending).To(BeTrue())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
+				"total_results": 1,
+				"total_pages": 1,
+				"page": 1,
+				"resources": [{
+					"app_id": "app-guid",
+					"timestamp": 1700000000000000000,
+					"scaling_type": 0,
+					"status": 1,
+					"old_instances": 1,
+					"new_instances": 2,
+					"reason": "+1 instance(s) because cpu >= 80 for 120 seconds",
+					"error": "boom"
+				}]
+			}`)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "bad-query"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("bad-query")
+			})
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("App")
+			})
+		})
+	})
+})
@@ -34,6 +34,7 @@ type Build struct {
 	buildRepo        CFBuildRepository
 	packageRepo      CFPackageRepository
 	appRepo          CFAppRepository
+	stackRepo        LifecycleStackRepository
 	requestValidator RequestValidator
 }
 
@@ -42,6 +43,7 @@ func NewBuild(
 	buildRepo CFBuildRepository,
 	packageRepo CFPackageRepository,
 	appRepo CFAppRepository,
+	stackRepo LifecycleStackRepository,
 	requestValidator RequestValidator,
 ) *Build {
 	return &Build{
@@ -49,6 +51,7 @@ func NewBuild(
 		buildRepo:        buildRepo,
 		packageRepo:      packageRepo,
 		appRepo:          appRepo,
+		stackRepo:        stackRepo,
 		requestValidator: requestValidator,
 	}
 }
@@ -102,13 +105,21 @@ func (h *Build) create(r *http.Request) (*routing.Response, error) {
 	}
 
 	buildCreateMessage := payload.ToMessage(appRecord)
+	if err = applyDefaultStack(r.Context(), h.stackRepo, authInfo, &buildCreateMessage.Lifecycle, buildCreateMessage.Lifecycle.Data.Stack); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error resolving the default stack")
+	}
 
 	record, err := h.buildRepo.CreateBuild(r.Context(), authInfo, buildCreateMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating build with repository")
 	}
 
-	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForBuild(record, h.serverURL)), nil
+	resp := routing.NewResponse(http.StatusCreated).WithBody(presenter.ForBuild(record, h.serverURL))
+	if warning := stackDeprecationWarning(r.Context(), logger, h.stackRepo, authInfo, buildCreateMessage.Lifecycle); warning != "" {
+		resp = resp.WithHeader("X-Cf-Warnings", warning)
+	}
+
+	return resp, nil
 }
 
 func (h *Build) update(r *http.Request) (*routing.Response, error) { //nolint:dupl
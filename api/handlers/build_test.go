@@ -32,12 +32,16 @@ var _ = Describe("Build", func() {
 		appRepo          *fake.CFAppRepository
 		buildRepo        *fake.CFBuildRepository
 		packageRepo      *fake.CFPackageRepository
+		stackRepo        *fake.LifecycleStackRepository
 	)
 
 	BeforeEach(func() {
 		requestValidator = new(fake.RequestValidator)
 		appRepo = new(fake.CFAppRepository)
 		buildRepo = new(fake.CFBuildRepository)
+		stackRepo = new(fake.LifecycleStackRepository)
+		stackRepo.GetStackByNameReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+		stackRepo.GetDefaultStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
 		packageRepo = new(fake.CFPackageRepository)
 
 		apiHandler = handlers.NewBuild(
@@ -45,6 +49,7 @@ var _ = Describe("Build", func() {
 			buildRepo,
 			packageRepo,
 			appRepo,
+			stackRepo,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -298,6 +303,79 @@ var _ = Describe("Build", func() {
 				MatchJSONPath("$.state", "STAGING"),
 				MatchJSONPath("$.links.self.href", HavePrefix("https://api.example.org")),
 			)))
+			Expect(rr.Header().Get("X-Cf-Warnings")).To(BeEmpty())
+		})
+
+		It("looks up the build stack", func() {
+			Expect(stackRepo.GetStackByNameCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualStackName := stackRepo.GetStackByNameArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualStackName).To(Equal(expectedLifecycleStack))
+		})
+
+		When("the build stack is deprecated", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackByNameReturns(repositories.StackRecord{
+					Name:  expectedLifecycleStack,
+					State: repositories.StackStateDeprecated,
+				}, nil)
+			})
+
+			It("warns about the deprecated stack", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPHeaderWithValue("X-Cf-Warnings", "Stack 'cflinuxfs3d' is deprecated and will be removed in the future."))
+			})
+		})
+
+		It("does not look up the default stack", func() {
+			Expect(stackRepo.GetDefaultStackCallCount()).To(BeZero())
+		})
+
+		When("the app does not specify a stack", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{
+					GUID:      appGUID,
+					SpaceGUID: spaceGUID,
+					Lifecycle: repositories.Lifecycle{
+						Type: expectedLifecycleType,
+						Data: repositories.LifecycleData{
+							Buildpacks: expectedLifecycleBuildpacks,
+						},
+					},
+				}, nil)
+				stackRepo.GetDefaultStackReturns(repositories.StackRecord{Name: "default-stack"}, nil)
+			})
+
+			It("creates the build with the default stack", func() {
+				Expect(stackRepo.GetDefaultStackCallCount()).To(Equal(1))
+				_, actualAuthInfo := stackRepo.GetDefaultStackArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+
+				Expect(buildRepo.CreateBuildCallCount()).To(Equal(1))
+				_, _, actualCreate := buildRepo.CreateBuildArgsForCall(0)
+				Expect(actualCreate.Lifecycle.Data.Stack).To(Equal("default-stack"))
+			})
+
+			When("getting the default stack fails", func() {
+				BeforeEach(func() {
+					stackRepo.GetDefaultStackReturns(repositories.StackRecord{}, errors.New("default-stack-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+					Expect(buildRepo.CreateBuildCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("looking up the build stack fails", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackByNameReturns(repositories.StackRecord{}, errors.New("stack-err"))
+			})
+
+			It("still creates the build", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
 		})
 
 		When("the package doesn't exist", func() {
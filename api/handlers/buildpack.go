@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -16,28 +17,43 @@ import (
 )
 
 const (
-	BuildpacksPath = "/v3/buildpacks"
+	BuildpacksPath      = "/v3/buildpacks"
+	BuildpackPath       = "/v3/buildpacks/{guid}"
+	BuildpackUploadPath = "/v3/buildpacks/{guid}/upload"
 )
 
 //counterfeiter:generate -o fake -fake-name BuildpackRepository . BuildpackRepository
 type BuildpackRepository interface {
 	ListBuildpacks(ctx context.Context, authInfo authorization.Info, message repositories.ListBuildpacksMessage) (repositories.ListResult[repositories.BuildpackRecord], error)
+	GetBuildpack(ctx context.Context, authInfo authorization.Info, guid string) (repositories.BuildpackRecord, error)
+	CreateBuildpack(ctx context.Context, authInfo authorization.Info, message repositories.CreateBuildpackMessage) (repositories.BuildpackRecord, error)
+	UpdateBuildpack(ctx context.Context, authInfo authorization.Info, message repositories.UpdateBuildpackMessage) (repositories.BuildpackRecord, error)
+	UpdateBuildpackSource(ctx context.Context, authInfo authorization.Info, message repositories.UpdateBuildpackSourceMessage) (repositories.BuildpackRecord, error)
+	DeleteBuildpack(ctx context.Context, authInfo authorization.Info, guid string) error
+}
+
+//counterfeiter:generate -o fake -fake-name BuildpackImageRepository . BuildpackImageRepository
+type BuildpackImageRepository interface {
+	UploadBuildpackImage(ctx context.Context, authInfo authorization.Info, imageRef string, tarReader io.Reader, tags ...string) (imageRefWithDigest string, err error)
 }
 
 type Buildpack struct {
 	serverURL        url.URL
 	buildpackRepo    BuildpackRepository
+	imageRepo        BuildpackImageRepository
 	requestValidator RequestValidator
 }
 
 func NewBuildpack(
 	serverURL url.URL,
 	buildpackRepo BuildpackRepository,
+	imageRepo BuildpackImageRepository,
 	requestValidator RequestValidator,
 ) *Buildpack {
 	return &Buildpack{
 		serverURL:        serverURL,
 		buildpackRepo:    buildpackRepo,
+		imageRepo:        imageRepo,
 		requestValidator: requestValidator,
 	}
 }
@@ -59,6 +75,126 @@ func (h *Buildpack) list(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForBuildpack, buildpacks, h.serverURL, *r.URL)), nil
 }
 
+func (h *Buildpack) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.get")
+
+	buildpackGUID := routing.URLParam(r, "guid")
+
+	buildpack, err := h.buildpackRepo.GetBuildpack(r.Context(), authInfo, buildpackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting buildpack in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForBuildpack(buildpack, h.serverURL)), nil
+}
+
+func (h *Buildpack) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.create")
+
+	var payload payloads.BuildpackCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	buildpack, err := h.buildpackRepo.CreateBuildpack(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating buildpack in repository")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForBuildpack(buildpack, h.serverURL)), nil
+}
+
+func (h *Buildpack) update(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.update")
+
+	buildpackGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.BuildpackUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.buildpackRepo.GetBuildpack(r.Context(), authInfo, buildpackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting buildpack in repository")
+	}
+
+	buildpack, err := h.buildpackRepo.UpdateBuildpack(r.Context(), authInfo, payload.ToMessage(buildpackGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error updating buildpack in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForBuildpack(buildpack, h.serverURL)), nil
+}
+
+func (h *Buildpack) upload(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.upload")
+
+	buildpackGUID := routing.URLParam(r, "guid")
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
+	}
+
+	buildpack, err := h.buildpackRepo.GetBuildpack(r.Context(), authInfo, buildpackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching buildpack with repository")
+	}
+
+	if buildpack.Locked {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Buildpack is locked"),
+			"Error, cannot upload bits to a locked buildpack", "buildpackGUID", buildpackGUID,
+		)
+	}
+
+	sourceMessage := repositories.UpdateBuildpackSourceMessage{
+		GUID:     buildpackGUID,
+		ImageRef: r.FormValue("image"),
+	}
+
+	if sourceMessage.ImageRef == "" {
+		bitsFile, _, err := r.FormFile("bits")
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include either bits or an image"), "Error reading form file \"bits\"")
+		}
+		defer bitsFile.Close()
+
+		sourceMessage.ImageRef, err = h.imageRepo.UploadBuildpackImage(r.Context(), authInfo, buildpack.RepositoryRef, bitsFile, buildpackGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Error calling UploadBuildpackImage")
+		}
+	}
+
+	buildpack, err = h.buildpackRepo.UpdateBuildpackSource(r.Context(), authInfo, sourceMessage)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateBuildpackSource")
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithBody(presenter.ForBuildpack(buildpack, h.serverURL)), nil
+}
+
+func (h *Buildpack) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.buildpack.delete")
+
+	buildpackGUID := routing.URLParam(r, "guid")
+
+	err := h.buildpackRepo.DeleteBuildpack(r.Context(), authInfo, buildpackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to delete buildpack from Kubernetes", "buildpackGUID", buildpackGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(buildpackGUID, presenter.BuildpackDeleteOperation, h.serverURL),
+	), nil
+}
+
 func (h *Buildpack) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -66,5 +202,10 @@ func (h *Buildpack) UnauthenticatedRoutes() []routing.Route {
 func (h *Buildpack) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: BuildpacksPath, Handler: h.list},
+		{Method: "POST", Pattern: BuildpacksPath, Handler: h.create},
+		{Method: "GET", Pattern: BuildpackPath, Handler: h.get},
+		{Method: "PATCH", Pattern: BuildpackPath, Handler: h.update},
+		{Method: "DELETE", Pattern: BuildpackPath, Handler: h.delete},
+		{Method: "POST", Pattern: BuildpackUploadPath, Handler: h.upload},
 	}
 }
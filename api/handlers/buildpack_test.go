@@ -1,10 +1,15 @@
 package handlers_test
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"strings"
 	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
 	"code.cloudfoundry.org/korifi/api/payloads"
@@ -20,15 +25,17 @@ import (
 var _ = Describe("Buildpack", func() {
 	var (
 		buildpackRepo    *fake.BuildpackRepository
+		imageRepo        *fake.BuildpackImageRepository
 		req              *http.Request
 		requestValidator *fake.RequestValidator
 	)
 
 	BeforeEach(func() {
 		buildpackRepo = new(fake.BuildpackRepository)
+		imageRepo = new(fake.BuildpackImageRepository)
 
 		requestValidator = new(fake.RequestValidator)
-		apiHandler := NewBuildpack(*serverURL, buildpackRepo, requestValidator)
+		apiHandler := NewBuildpack(*serverURL, buildpackRepo, imageRepo, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -42,6 +49,7 @@ var _ = Describe("Buildpack", func() {
 				repositories.ListResult[repositories.BuildpackRecord]{
 					Records: []repositories.BuildpackRecord{{
 						Name:      "paketo-foopacks/bar",
+						Filename:  "paketo-foopacks/bar@1.0.0",
 						Position:  1,
 						Stack:     "waffle-house",
 						Version:   "1.0.0",
@@ -109,4 +117,302 @@ var _ = Describe("Buildpack", func() {
 			})
 		})
 	})
+
+	Describe("the POST /v3/buildpacks endpoint", func() {
+		var payload *payloads.BuildpackCreate
+
+		BeforeEach(func() {
+			payload = &payloads.BuildpackCreate{
+				Name:     "my-buildpack",
+				Position: tools.PtrTo(2),
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
+
+			buildpackRepo.CreateBuildpackReturns(repositories.BuildpackRecord{
+				GUID:  "bp-guid",
+				Name:  "my-buildpack",
+				State: repositories.BuildpackStateAwaitingUpload,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/buildpacks", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the buildpack", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(buildpackRepo.CreateBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := buildpackRepo.CreateBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateBuildpackMessage{
+				Name:     "my-buildpack",
+				Position: 2,
+				Enabled:  true,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "bp-guid"),
+				MatchJSONPath("$.state", "AWAITING_UPLOAD"),
+				MatchJSONPath("$.links.upload.href", "https://api.example.org/v3/buildpacks/bp-guid/upload"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the buildpack fails", func() {
+			BeforeEach(func() {
+				buildpackRepo.CreateBuildpackReturns(repositories.BuildpackRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/buildpacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{
+				GUID: "bp-guid",
+				Name: "my-buildpack",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/buildpacks/bp-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the buildpack", func() {
+			Expect(buildpackRepo.GetBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := buildpackRepo.GetBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("bp-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "bp-guid"),
+				MatchJSONPath("$.name", "my-buildpack"),
+			)))
+		})
+
+		When("the buildpack is not accessible", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{}, apierrors.NewForbiddenError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildpackResourceType)
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/buildpacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.BuildpackUpdate{
+				Enabled: tools.PtrTo(false),
+				Metadata: payloads.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			})
+
+			buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{GUID: "bp-guid"}, nil)
+			buildpackRepo.UpdateBuildpackReturns(repositories.BuildpackRecord{
+				GUID:    "bp-guid",
+				Enabled: false,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/buildpacks/bp-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the buildpack", func() {
+			Expect(buildpackRepo.UpdateBuildpackCallCount()).To(Equal(1))
+			_, actualAuthInfo, updateMessage := buildpackRepo.UpdateBuildpackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(updateMessage).To(Equal(repositories.UpdateBuildpackMessage{
+				GUID:    "bp-guid",
+				Enabled: tools.PtrTo(false),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "bp-guid"),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the buildpack does not exist", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{}, apierrors.NewNotFoundError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildpackResourceType)
+				Expect(buildpackRepo.UpdateBuildpackCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the buildpack fails", func() {
+			BeforeEach(func() {
+				buildpackRepo.UpdateBuildpackReturns(repositories.BuildpackRecord{}, errors.New("update-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the POST /v3/buildpacks/:guid/upload endpoint", func() {
+		newUploadRequest := func(writeForm func(*multipart.Writer)) *http.Request {
+			var b bytes.Buffer
+			writer := multipart.NewWriter(&b)
+			writeForm(writer)
+			Expect(writer.Close()).To(Succeed())
+
+			uploadReq, err := http.NewRequestWithContext(ctx, "POST", "/v3/buildpacks/bp-guid/upload", &b)
+			Expect(err).NotTo(HaveOccurred())
+			uploadReq.Header.Add("Content-Type", writer.FormDataContentType())
+			return uploadReq
+		}
+
+		BeforeEach(func() {
+			buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{
+				GUID:          "bp-guid",
+				State:         repositories.BuildpackStateAwaitingUpload,
+				RepositoryRef: "registry.repo/bp-guid-buildpack",
+			}, nil)
+			buildpackRepo.UpdateBuildpackSourceReturns(repositories.BuildpackRecord{
+				GUID:  "bp-guid",
+				State: repositories.BuildpackStateProcessingUpload,
+			}, nil)
+			imageRepo.UploadBuildpackImageReturns("registry.repo/bp-guid-buildpack@sha256:some-sha", nil)
+
+			req = newUploadRequest(func(writer *multipart.Writer) {
+				part, err := writer.CreateFormFile("bits", "buildpack.cnb")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = io.Copy(part, strings.NewReader("the-buildpack-contents"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		It("uploads the buildpackage", func() {
+			Expect(imageRepo.UploadBuildpackImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, repoRef, bitsFile, actualTags := imageRepo.UploadBuildpackImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(repoRef).To(Equal("registry.repo/bp-guid-buildpack"))
+			Expect(io.ReadAll(bitsFile)).To(BeEquivalentTo("the-buildpack-contents"))
+			Expect(actualTags).To(ConsistOf("bp-guid"))
+
+			Expect(buildpackRepo.UpdateBuildpackSourceCallCount()).To(Equal(1))
+			_, _, message := buildpackRepo.UpdateBuildpackSourceArgsForCall(0)
+			Expect(message).To(Equal(repositories.UpdateBuildpackSourceMessage{
+				GUID:     "bp-guid",
+				ImageRef: "registry.repo/bp-guid-buildpack@sha256:some-sha",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "bp-guid"),
+				MatchJSONPath("$.state", "PROCESSING_UPLOAD"),
+			)))
+		})
+
+		When("an image is given instead of bits", func() {
+			BeforeEach(func() {
+				req = newUploadRequest(func(writer *multipart.Writer) {
+					Expect(writer.WriteField("image", "paketo-buildpacks/go:1.0")).To(Succeed())
+				})
+			})
+
+			It("uses the image as the buildpack source", func() {
+				Expect(imageRepo.UploadBuildpackImageCallCount()).To(BeZero())
+
+				Expect(buildpackRepo.UpdateBuildpackSourceCallCount()).To(Equal(1))
+				_, _, message := buildpackRepo.UpdateBuildpackSourceArgsForCall(0)
+				Expect(message).To(Equal(repositories.UpdateBuildpackSourceMessage{
+					GUID:     "bp-guid",
+					ImageRef: "paketo-buildpacks/go:1.0",
+				}))
+			})
+		})
+
+		When("neither bits nor an image are given", func() {
+			BeforeEach(func() {
+				req = newUploadRequest(func(*multipart.Writer) {})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Upload must include either bits or an image")
+			})
+		})
+
+		When("the buildpack is locked", func() {
+			BeforeEach(func() {
+				buildpackRepo.GetBuildpackReturns(repositories.BuildpackRecord{
+					GUID:   "bp-guid",
+					Locked: true,
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Buildpack is locked")
+				Expect(imageRepo.UploadBuildpackImageCallCount()).To(BeZero())
+			})
+		})
+
+		When("uploading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.UploadBuildpackImageReturns("", errors.New("upload-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/buildpacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/buildpacks/bp-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the buildpack", func() {
+			Expect(buildpackRepo.DeleteBuildpackCallCount()).To(Equal(1))
+			_, _, deletedGUID := buildpackRepo.DeleteBuildpackArgsForCall(0)
+			Expect(deletedGUID).To(Equal("bp-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/buildpack.delete~bp-guid"))
+		})
+
+		When("the user is not authorized to delete buildpacks", func() {
+			BeforeEach(func() {
+				buildpackRepo.DeleteBuildpackReturns(apierrors.NewForbiddenError(nil, repositories.BuildpackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.BuildpackResourceType)
+			})
+		})
+	})
 })
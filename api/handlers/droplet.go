@@ -2,7 +2,9 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -17,8 +19,10 @@ import (
 )
 
 const (
-	DropletsPath = "/v3/droplets"
-	DropletPath  = "/v3/droplets/{guid}"
+	DropletsPath        = "/v3/droplets"
+	DropletPath         = "/v3/droplets/{guid}"
+	DropletUploadPath   = "/v3/droplets/{guid}/upload"
+	DropletDownloadPath = "/v3/droplets/{guid}/download"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDropletRepository . CFDropletRepository
@@ -26,23 +30,44 @@ type CFDropletRepository interface {
 	GetDroplet(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
 	ListDroplets(context.Context, authorization.Info, repositories.ListDropletsMessage) (repositories.ListResult[repositories.DropletRecord], error)
 	UpdateDroplet(context.Context, authorization.Info, repositories.UpdateDropletMessage) (repositories.DropletRecord, error)
+	CreateDroplet(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)
+	CopyDroplet(context.Context, authorization.Info, repositories.CopyDropletMessage) (repositories.DropletRecord, error)
+	UpdateDropletSource(context.Context, authorization.Info, repositories.UpdateDropletSourceMessage) (repositories.DropletRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name DropletImageRepository . DropletImageRepository
+type DropletImageRepository interface {
+	UploadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, baseImageRef string, dropletReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string) (io.ReadCloser, error)
 }
 
 type Droplet struct {
-	serverURL        url.URL
-	dropletRepo      CFDropletRepository
-	requestValidator RequestValidator
+	serverURL           url.URL
+	dropletRepo         CFDropletRepository
+	appRepo             CFAppRepository
+	imageRepo           DropletImageRepository
+	stackRepo           LifecycleStackRepository
+	requestValidator    RequestValidator
+	registrySecretNames []string
 }
 
 func NewDroplet(
 	serverURL url.URL,
 	dropletRepo CFDropletRepository,
+	appRepo CFAppRepository,
+	imageRepo DropletImageRepository,
+	stackRepo LifecycleStackRepository,
 	requestValidator RequestValidator,
+	registrySecretNames []string,
 ) *Droplet {
 	return &Droplet{
-		serverURL:        serverURL,
-		dropletRepo:      dropletRepo,
-		requestValidator: requestValidator,
+		serverURL:           serverURL,
+		dropletRepo:         dropletRepo,
+		appRepo:             appRepo,
+		imageRepo:           imageRepo,
+		stackRepo:           stackRepo,
+		requestValidator:    requestValidator,
+		registrySecretNames: registrySecretNames,
 	}
 }
 
@@ -112,6 +137,182 @@ func (h *Droplet) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
 }
 
+func (h *Droplet) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.create")
+
+	var payload payloads.DropletCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appGUID := payload.Relationships.App.Data.GUID
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"App is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding App",
+			"App GUID", appGUID,
+		)
+	}
+
+	sourceGUID := r.URL.Query().Get("source_guid")
+	if sourceGUID == "" {
+		droplet, err := h.dropletRepo.CreateDroplet(r.Context(), authInfo, payload.ToMessage(appRecord))
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Error creating droplet with repository")
+		}
+
+		return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+	}
+
+	sourceDroplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Unable to use droplet. Ensure that the droplet exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding source droplet",
+			"Droplet GUID", sourceGUID,
+		)
+	}
+
+	if sourceDroplet.Lifecycle.Type != appRecord.Lifecycle.Type {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Cannot copy a %s droplet to a %s app.", sourceDroplet.Lifecycle.Type, appRecord.Lifecycle.Type)),
+			"Droplet and app lifecycle types do not match",
+			"Droplet GUID", sourceGUID, "App GUID", appGUID,
+		)
+	}
+
+	droplet, err := h.dropletRepo.CopyDroplet(r.Context(), authInfo, payload.ToCopyMessage(sourceGUID, appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error copying droplet with repository", "Droplet GUID", sourceGUID)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) upload(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.upload")
+
+	dropletGUID := routing.URLParam(r, "guid")
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
+	}
+
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository")
+	}
+
+	if droplet.State != repositories.DropletStateAwaitingUpload {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Droplet may be uploaded only once. Create a new droplet to upload bits."),
+			"Error, cannot upload a droplet that is not in AWAITING_UPLOAD state", "dropletGUID", dropletGUID,
+		)
+	}
+
+	sourceMessage := repositories.UpdateDropletSourceMessage{
+		GUID:     dropletGUID,
+		ImageRef: r.FormValue("image"),
+	}
+
+	if sourceMessage.ImageRef == "" {
+		bitsFile, _, err := r.FormFile("bits")
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include either bits or an image"), "Error reading form file \"bits\"")
+		}
+		defer bitsFile.Close()
+
+		baseImageRef, err := h.stackRunImage(r.Context(), authInfo, droplet.Lifecycle.Data.Stack)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Error resolving the droplet stack run image", "stack", droplet.Lifecycle.Data.Stack)
+		}
+
+		sourceMessage.ImageRef, err = h.imageRepo.UploadDropletImage(r.Context(), authInfo, droplet.RepositoryRef, baseImageRef, bitsFile, droplet.SpaceGUID, dropletGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "Error calling UploadDropletImage")
+		}
+		sourceMessage.RegistrySecretNames = h.registrySecretNames
+	}
+
+	droplet, err = h.dropletRepo.UpdateDropletSource(r.Context(), authInfo, sourceMessage)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateDropletSource")
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+// stackRunImage returns the run image the droplet is layered on top of. Stacks
+// that are not managed as CFStacks have no known run image.
+func (h *Droplet) stackRunImage(ctx context.Context, authInfo authorization.Info, stackName string) (string, error) {
+	if stackName == "" {
+		return "", nil
+	}
+
+	stack, err := h.stackRepo.GetStackByName(ctx, authInfo, stackName)
+	if err != nil {
+		if errors.As(err, &apierrors.NotFoundError{}) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return stack.RunRootfsImage, nil
+}
+
+func (h *Droplet) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.download")
+
+	dropletGUID := routing.URLParam(r, "guid")
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository")
+	}
+
+	if droplet.Lifecycle.Type == "docker" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Cannot download droplets with 'docker' lifecycle."),
+			"docker droplets cannot be downloaded", "dropletGUID", dropletGUID,
+		)
+	}
+
+	if droplet.State != repositories.DropletStateStaged {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Only staged droplets can be downloaded."),
+			"droplet is not staged", "dropletGUID", dropletGUID,
+		)
+	}
+
+	imageReader, err := h.imageRepo.DownloadDropletImage(r.Context(), authInfo, droplet.ImageRef)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling DownloadDropletImage")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "application/x-tar").
+		WithHeader("Content-Disposition", fmt.Sprintf("attachment; filename=%q", dropletGUID+".tar")).
+		WithContent(imageReader), nil
+}
+
 func (h *Droplet) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -121,5 +322,8 @@ func (h *Droplet) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: DropletPath, Handler: h.get},
 		{Method: "GET", Pattern: DropletsPath, Handler: h.list},
 		{Method: "PATCH", Pattern: DropletPath, Handler: h.update},
+		{Method: "POST", Pattern: DropletsPath, Handler: h.create},
+		{Method: "POST", Pattern: DropletUploadPath, Handler: h.upload},
+		{Method: "GET", Pattern: DropletDownloadPath, Handler: h.download},
 	}
 }
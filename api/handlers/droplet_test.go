@@ -1,7 +1,10 @@
 package handlers_test
 
 import (
+	"bytes"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"time"
@@ -31,32 +34,50 @@ var _ = Describe("Droplet", func() {
 
 		requestValidator *fake.RequestValidator
 		dropletRepo      *fake.CFDropletRepository
+		appRepo          *fake.CFAppRepository
+		imageRepo        *fake.DropletImageRepository
+		stackRepo        *fake.LifecycleStackRepository
 		req              *http.Request
 		err              error
 		reqPath          string
 		reqMethod        string
+		reqBody          io.Reader
+		reqContentType   string
 	)
 
 	BeforeEach(func() {
 		dropletRepo = new(fake.CFDropletRepository)
+		appRepo = new(fake.CFAppRepository)
+		imageRepo = new(fake.DropletImageRepository)
+		stackRepo = new(fake.LifecycleStackRepository)
+		stackRepo.GetStackByNameReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
 		requestValidator = new(fake.RequestValidator)
 
 		appGUID = "test-app-guid"
 		packageGUID = "test-package-guid"
 		dropletGUID = "test-build-guid" // same as build guid
 		dropletGUID2 = "test-build-guid-2"
+		reqBody = strings.NewReader("the-json-body")
+		reqContentType = ""
 
 		apiHandler := NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
+			stackRepo,
 			requestValidator,
+			[]string{"registry-secret"},
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
 	JustBeforeEach(func() {
-		req, err = http.NewRequestWithContext(ctx, reqMethod, reqPath, strings.NewReader("the-json-body"))
+		req, err = http.NewRequestWithContext(ctx, reqMethod, reqPath, reqBody)
 		Expect(err).NotTo(HaveOccurred())
+		if reqContentType != "" {
+			req.Header.Add("Content-Type", reqContentType)
+		}
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
@@ -331,4 +352,376 @@ var _ = Describe("Droplet", func() {
 			})
 		})
 	})
+
+	Describe("the POST /v3/droplets endpoint", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: "test-space-guid",
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			}, nil)
+
+			dropletRepo.CreateDropletReturns(repositories.DropletRecord{
+				GUID:    dropletGUID,
+				State:   repositories.DropletStateAwaitingUpload,
+				AppGUID: appGUID,
+			}, nil)
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DropletCreate{
+				Relationships: &payloads.DropletRelationships{
+					App: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: appGUID}},
+				},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			})
+
+			reqMethod = http.MethodPost
+			reqPath = "/v3/droplets"
+		})
+
+		It("creates the droplet", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(dropletRepo.CreateDropletCallCount()).To(Equal(1))
+			_, _, message := dropletRepo.CreateDropletArgsForCall(0)
+			Expect(message.AppGUID).To(Equal(appGUID))
+			Expect(message.SpaceGUID).To(Equal("test-space-guid"))
+			Expect(message.ProcessTypes).To(Equal(map[string]string{"web": "bundle exec rackup"}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "AWAITING_UPLOAD"),
+			)))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("validation error")
+			})
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
+		When("creating the droplet fails", func() {
+			BeforeEach(func() {
+				dropletRepo.CreateDropletReturns(repositories.DropletRecord{}, errors.New("create-droplet-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("a source droplet is specified", func() {
+			BeforeEach(func() {
+				reqPath = "/v3/droplets?source_guid=" + dropletGUID2
+
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID2,
+					State:     repositories.DropletStateStaged,
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+
+				dropletRepo.CopyDropletReturns(repositories.DropletRecord{
+					GUID:    dropletGUID,
+					State:   repositories.DropletStateStaged,
+					AppGUID: appGUID,
+				}, nil)
+			})
+
+			It("copies the droplet", func() {
+				Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+				_, _, actualSourceGUID := dropletRepo.GetDropletArgsForCall(0)
+				Expect(actualSourceGUID).To(Equal(dropletGUID2))
+
+				Expect(dropletRepo.CreateDropletCallCount()).To(BeZero())
+				Expect(dropletRepo.CopyDropletCallCount()).To(Equal(1))
+				_, _, message := dropletRepo.CopyDropletArgsForCall(0)
+				Expect(message).To(Equal(repositories.CopyDropletMessage{
+					SourceGUID: dropletGUID2,
+					AppGUID:    appGUID,
+					SpaceGUID:  "test-space-guid",
+				}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.guid", dropletGUID),
+					MatchJSONPath("$.state", "STAGED"),
+				)))
+			})
+
+			When("the source droplet does not exist", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewNotFoundError(nil, repositories.DropletResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Unable to use droplet. Ensure that the droplet exists and you have access to it.")
+				})
+			})
+
+			When("the source droplet lifecycle does not match the app", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{
+						GUID:      dropletGUID2,
+						Lifecycle: repositories.Lifecycle{Type: "docker"},
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Cannot copy a docker droplet to a buildpack app.")
+				})
+			})
+
+			When("copying the droplet fails", func() {
+				BeforeEach(func() {
+					dropletRepo.CopyDropletReturns(repositories.DropletRecord{}, errors.New("copy-droplet-error"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+	})
+
+	Describe("the POST /v3/droplets/:guid/upload endpoint", func() {
+		BeforeEach(func() {
+			reqMethod = http.MethodPost
+			reqPath = "/v3/droplets/" + dropletGUID + "/upload"
+
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:          dropletGUID,
+				SpaceGUID:     "test-space-guid",
+				State:         repositories.DropletStateAwaitingUpload,
+				RepositoryRef: "registry.repo/app-droplets",
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Stack: "cflinuxfs4"},
+				},
+			}, nil)
+
+			dropletRepo.UpdateDropletSourceReturns(repositories.DropletRecord{
+				GUID:  dropletGUID,
+				State: repositories.DropletStateProcessingUpload,
+			}, nil)
+
+			imageRepo.UploadDropletImageReturns("registry.repo/app-droplets@sha256:some-sha", nil)
+
+			var b bytes.Buffer
+			writer := multipart.NewWriter(&b)
+			part, err := writer.CreateFormFile("bits", "droplet.tar")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = io.Copy(part, strings.NewReader("the-droplet-contents"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(writer.Close()).To(Succeed())
+			reqContentType = writer.FormDataContentType()
+
+			reqBody = &b
+		})
+
+		It("uploads the droplet", func() {
+			Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(1))
+			_, _, repoRef, baseImageRef, dropletFile, actualSpaceGUID, actualTags := imageRepo.UploadDropletImageArgsForCall(0)
+			Expect(repoRef).To(Equal("registry.repo/app-droplets"))
+			Expect(baseImageRef).To(BeEmpty())
+			Expect(io.ReadAll(dropletFile)).To(BeEquivalentTo("the-droplet-contents"))
+			Expect(actualSpaceGUID).To(Equal("test-space-guid"))
+			Expect(actualTags).To(ConsistOf(dropletGUID))
+
+			Expect(dropletRepo.UpdateDropletSourceCallCount()).To(Equal(1))
+			_, _, message := dropletRepo.UpdateDropletSourceArgsForCall(0)
+			Expect(message).To(Equal(repositories.UpdateDropletSourceMessage{
+				GUID:                dropletGUID,
+				ImageRef:            "registry.repo/app-droplets@sha256:some-sha",
+				RegistrySecretNames: []string{"registry-secret"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "PROCESSING_UPLOAD"),
+			)))
+		})
+
+		When("the droplet stack is managed", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackByNameReturns(repositories.StackRecord{
+					Name:           "cflinuxfs4",
+					RunRootfsImage: "stacks/cflinuxfs4-run",
+				}, nil)
+			})
+
+			It("uploads the droplet on top of the stack run image", func() {
+				Expect(stackRepo.GetStackByNameCallCount()).To(Equal(1))
+				_, _, actualStackName := stackRepo.GetStackByNameArgsForCall(0)
+				Expect(actualStackName).To(Equal("cflinuxfs4"))
+
+				Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(1))
+				_, _, _, baseImageRef, _, _, _ := imageRepo.UploadDropletImageArgsForCall(0)
+				Expect(baseImageRef).To(Equal("stacks/cflinuxfs4-run"))
+			})
+		})
+
+		When("getting the droplet stack fails", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackByNameReturns(repositories.StackRecord{}, errors.New("stack-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("an image is given instead of bits", func() {
+			BeforeEach(func() {
+				var b bytes.Buffer
+				writer := multipart.NewWriter(&b)
+				Expect(writer.WriteField("image", "some/image:tag")).To(Succeed())
+				Expect(writer.Close()).To(Succeed())
+				reqContentType = writer.FormDataContentType()
+
+				reqBody = &b
+			})
+
+			It("uses the image as the droplet", func() {
+				Expect(imageRepo.UploadDropletImageCallCount()).To(BeZero())
+
+				Expect(dropletRepo.UpdateDropletSourceCallCount()).To(Equal(1))
+				_, _, message := dropletRepo.UpdateDropletSourceArgsForCall(0)
+				Expect(message).To(Equal(repositories.UpdateDropletSourceMessage{
+					GUID:     dropletGUID,
+					ImageRef: "some/image:tag",
+				}))
+			})
+		})
+
+		When("neither bits nor an image are given", func() {
+			BeforeEach(func() {
+				var b bytes.Buffer
+				writer := multipart.NewWriter(&b)
+				Expect(writer.Close()).To(Succeed())
+				reqContentType = writer.FormDataContentType()
+
+				reqBody = &b
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Upload must include either bits or an image")
+			})
+		})
+
+		When("the droplet has already been uploaded", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:  dropletGUID,
+					State: repositories.DropletStateStaged,
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Droplet may be uploaded only once. Create a new droplet to upload bits.")
+			})
+		})
+
+		When("the user is not authorized to get the droplet", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.DropletResourceType)
+			})
+		})
+
+		When("uploading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.UploadDropletImageReturns("", errors.New("upload-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/droplets/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:      dropletGUID,
+				State:     repositories.DropletStateStaged,
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				ImageRef:  "registry.repo/app-droplets@sha256:some-sha",
+			}, nil)
+
+			imageRepo.DownloadDropletImageReturns(io.NopCloser(strings.NewReader("the-droplet-contents")), nil)
+
+			reqMethod = http.MethodGet
+			reqPath = "/v3/droplets/" + dropletGUID + "/download"
+		})
+
+		It("streams the droplet image", func() {
+			Expect(imageRepo.DownloadDropletImageCallCount()).To(Equal(1))
+			_, _, actualImageRef := imageRepo.DownloadDropletImageArgsForCall(0)
+			Expect(actualImageRef).To(Equal("registry.repo/app-droplets@sha256:some-sha"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/x-tar"))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Disposition", `attachment; filename="test-build-guid.tar"`))
+			Expect(rr).To(HaveHTTPBody("the-droplet-contents"))
+		})
+
+		When("the droplet has a docker lifecycle", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     repositories.DropletStateStaged,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot download droplets with 'docker' lifecycle.")
+			})
+		})
+
+		When("the droplet is not staged", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					State:     repositories.DropletStateAwaitingUpload,
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Only staged droplets can be downloaded.")
+			})
+		})
+
+		When("exporting the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadDropletImageReturns(nil, errors.New("export-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -0,0 +1,369 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type AutoscalingPolicyRepository struct {
+	DeleteAutoscalingPolicyStub        func(context.Context, authorization.Info, repositories.DeleteAutoscalingPolicyMessage) error
+	deleteAutoscalingPolicyMutex       sync.RWMutex
+	deleteAutoscalingPolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteAutoscalingPolicyMessage
+	}
+	deleteAutoscalingPolicyReturns struct {
+		result1 error
+	}
+	deleteAutoscalingPolicyReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetAutoscalingPolicyStub        func(context.Context, authorization.Info, string, string) (repositories.AutoscalingPolicyRecord, error)
+	getAutoscalingPolicyMutex       sync.RWMutex
+	getAutoscalingPolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}
+	getAutoscalingPolicyReturns struct {
+		result1 repositories.AutoscalingPolicyRecord
+		result2 error
+	}
+	getAutoscalingPolicyReturnsOnCall map[int]struct {
+		result1 repositories.AutoscalingPolicyRecord
+		result2 error
+	}
+	ListScalingHistoryStub        func(context.Context, authorization.Info, repositories.ListScalingHistoryMessage) (repositories.ListResult[repositories.ScalingEventRecord], error)
+	listScalingHistoryMutex       sync.RWMutex
+	listScalingHistoryArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListScalingHistoryMessage
+	}
+	listScalingHistoryReturns struct {
+		result1 repositories.ListResult[repositories.ScalingEventRecord]
+		result2 error
+	}
+	listScalingHistoryReturnsOnCall map[int]struct {
+		result1 repositories.ListResult[repositories.ScalingEventRecord]
+		result2 error
+	}
+	SetAutoscalingPolicyStub        func(context.Context, authorization.Info, repositories.SetAutoscalingPolicyMessage) (repositories.AutoscalingPolicyRecord, error)
+	setAutoscalingPolicyMutex       sync.RWMutex
+	setAutoscalingPolicyArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.SetAutoscalingPolicyMessage
+	}
+	setAutoscalingPolicyReturns struct {
+		result1 repositories.AutoscalingPolicyRecord
+		result2 error
+	}
+	setAutoscalingPolicyReturnsOnCall map[int]struct {
+		result1 repositories.AutoscalingPolicyRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *AutoscalingPolicyRepository) DeleteAutoscalingPolicy(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeleteAutoscalingPolicyMessage) error {
+	fake.deleteAutoscalingPolicyMutex.Lock()
+	ret, specificReturn := fake.deleteAutoscalingPolicyReturnsOnCall[len(fake.deleteAutoscalingPolicyArgsForCall)]
+	fake.deleteAutoscalingPolicyArgsForCall = append(fake.deleteAutoscalingPolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteAutoscalingPolicyMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteAutoscalingPolicyStub
+	fakeReturns := fake.deleteAutoscalingPolicyReturns
+	fake.recordInvocation("DeleteAutoscalingPolicy", []interface{}{arg1, arg2, arg3})
+	fake.deleteAutoscalingPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *AutoscalingPolicyRepository) DeleteAutoscalingPolicyCallCount() int {
+	fake.deleteAutoscalingPolicyMutex.RLock()
+	defer fake.deleteAutoscalingPolicyMutex.RUnlock()
+	return len(fake.deleteAutoscalingPolicyArgsForCall)
+}
+
+func (fake *AutoscalingPolicyRepository) DeleteAutoscalingPolicyCalls(stub func(context.Context, authorization.Info, repositories.DeleteAutoscalingPolicyMessage) error) {
+	fake.deleteAutoscalingPolicyMutex.Lock()
+	defer fake.deleteAutoscalingPolicyMutex.Unlock()
+	fake.DeleteAutoscalingPolicyStub = stub
+}
+
+func (fake *AutoscalingPolicyRepository) DeleteAutoscalingPolicyArgsForCall(i int) (context.Context, authorization.Info, repositories.DeleteAutoscalingPolicyMessage) {
+	fake.deleteAutoscalingPolicyMutex.RLock()
+	defer fake.deleteAutoscalingPolicyMutex.RUnlock()
+	argsForCall := fake.deleteAutoscalingPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *AutoscalingPolicyRepository) DeleteAutoscalingPolicyReturns(result1 error) {
+	fake.deleteAutoscalingPolicyMutex.Lock()
+	defer fake.deleteAutoscalingPolicyMutex.Unlock()
+	fake.DeleteAutoscalingPolicyStub = nil
+	fake.deleteAutoscalingPolicyReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *AutoscalingPolicyRepository) DeleteAutoscalingPolicyReturnsOnCall(i int, result1 error) {
+	fake.deleteAutoscalingPolicyMutex.Lock()
+	defer fake.deleteAutoscalingPolicyMutex.Unlock()
+	fake.DeleteAutoscalingPolicyStub = nil
+	if fake.deleteAutoscalingPolicyReturnsOnCall == nil {
+		fake.deleteAutoscalingPolicyReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteAutoscalingPolicyReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *AutoscalingPolicyRepository) GetAutoscalingPolicy(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) (repositories.AutoscalingPolicyRecord, error) {
+	fake.getAutoscalingPolicyMutex.Lock()
+	ret, specificReturn := fake.getAutoscalingPolicyReturnsOnCall[len(fake.getAutoscalingPolicyArgsForCall)]
+	fake.getAutoscalingPolicyArgsForCall = append(fake.getAutoscalingPolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.GetAutoscalingPolicyStub
+	fakeReturns := fake.getAutoscalingPolicyReturns
+	fake.recordInvocation("GetAutoscalingPolicy", []interface{}{arg1, arg2, arg3, arg4})
+	fake.getAutoscalingPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *AutoscalingPolicyRepository) GetAutoscalingPolicyCallCount() int {
+	fake.getAutoscalingPolicyMutex.RLock()
+	defer fake.getAutoscalingPolicyMutex.RUnlock()
+	return len(fake.getAutoscalingPolicyArgsForCall)
+}
+
+func (fake *AutoscalingPolicyRepository) GetAutoscalingPolicyCalls(stub func(context.Context, authorization.Info, string, string) (repositories.AutoscalingPolicyRecord, error)) {
+	fake.getAutoscalingPolicyMutex.Lock()
+	defer fake.getAutoscalingPolicyMutex.Unlock()
+	fake.GetAutoscalingPolicyStub = stub
+}
+
+func (fake *AutoscalingPolicyRepository) GetAutoscalingPolicyArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+	fake.getAutoscalingPolicyMutex.RLock()
+	defer fake.getAutoscalingPolicyMutex.RUnlock()
+	argsForCall := fake.getAutoscalingPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *AutoscalingPolicyRepository) GetAutoscalingPolicyReturns(result1 repositories.AutoscalingPolicyRecord, result2 error) {
+	fake.getAutoscalingPolicyMutex.Lock()
+	defer fake.getAutoscalingPolicyMutex.Unlock()
+	fake.GetAutoscalingPolicyStub = nil
+	fake.getAutoscalingPolicyReturns = struct {
+		result1 repositories.AutoscalingPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AutoscalingPolicyRepository) GetAutoscalingPolicyReturnsOnCall(i int, result1 repositories.AutoscalingPolicyRecord, result2 error) {
+	fake.getAutoscalingPolicyMutex.Lock()
+	defer fake.getAutoscalingPolicyMutex.Unlock()
+	fake.GetAutoscalingPolicyStub = nil
+	if fake.getAutoscalingPolicyReturnsOnCall == nil {
+		fake.getAutoscalingPolicyReturnsOnCall = make(map[int]struct {
+			result1 repositories.AutoscalingPolicyRecord
+			result2 error
+		})
+	}
+	fake.getAutoscalingPolicyReturnsOnCall[i] = struct {
+		result1 repositories.AutoscalingPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AutoscalingPolicyRepository) ListScalingHistory(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListScalingHistoryMessage) (repositories.ListResult[repositories.ScalingEventRecord], error) {
+	fake.listScalingHistoryMutex.Lock()
+	ret, specificReturn := fake.listScalingHistoryReturnsOnCall[len(fake.listScalingHistoryArgsForCall)]
+	fake.listScalingHistoryArgsForCall = append(fake.listScalingHistoryArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListScalingHistoryMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListScalingHistoryStub
+	fakeReturns := fake.listScalingHistoryReturns
+	fake.recordInvocation("ListScalingHistory", []interface{}{arg1, arg2, arg3})
+	fake.listScalingHistoryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *AutoscalingPolicyRepository) ListScalingHistoryCallCount() int {
+	fake.listScalingHistoryMutex.RLock()
+	defer fake.listScalingHistoryMutex.RUnlock()
+	return len(fake.listScalingHistoryArgsForCall)
+}
+
+func (fake *AutoscalingPolicyRepository) ListScalingHistoryCalls(stub func(context.Context, authorization.Info, repositories.ListScalingHistoryMessage) (repositories.ListResult[repositories.ScalingEventRecord], error)) {
+	fake.listScalingHistoryMutex.Lock()
+	defer fake.listScalingHistoryMutex.Unlock()
+	fake.ListScalingHistoryStub = stub
+}
+
+func (fake *AutoscalingPolicyRepository) ListScalingHistoryArgsForCall(i int) (context.Context, authorization.Info, repositories.ListScalingHistoryMessage) {
+	fake.listScalingHistoryMutex.RLock()
+	defer fake.listScalingHistoryMutex.RUnlock()
+	argsForCall := fake.listScalingHistoryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *AutoscalingPolicyRepository) ListScalingHistoryReturns(result1 repositories.ListResult[repositories.ScalingEventRecord], result2 error) {
+	fake.listScalingHistoryMutex.Lock()
+	defer fake.listScalingHistoryMutex.Unlock()
+	fake.ListScalingHistoryStub = nil
+	fake.listScalingHistoryReturns = struct {
+		result1 repositories.ListResult[repositories.ScalingEventRecord]
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AutoscalingPolicyRepository) ListScalingHistoryReturnsOnCall(i int, result1 repositories.ListResult[repositories.ScalingEventRecord], result2 error) {
+	fake.listScalingHistoryMutex.Lock()
+	defer fake.listScalingHistoryMutex.Unlock()
+	fake.ListScalingHistoryStub = nil
+	if fake.listScalingHistoryReturnsOnCall == nil {
+		fake.listScalingHistoryReturnsOnCall = make(map[int]struct {
+			result1 repositories.ListResult[repositories.ScalingEventRecord]
+			result2 error
+		})
+	}
+	fake.listScalingHistoryReturnsOnCall[i] = struct {
+		result1 repositories.ListResult[repositories.ScalingEventRecord]
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AutoscalingPolicyRepository) SetAutoscalingPolicy(arg1 context.Context, arg2 authorization.Info, arg3 repositories.SetAutoscalingPolicyMessage) (repositories.AutoscalingPolicyRecord, error) {
+	fake.setAutoscalingPolicyMutex.Lock()
+	ret, specificReturn := fake.setAutoscalingPolicyReturnsOnCall[len(fake.setAutoscalingPolicyArgsForCall)]
+	fake.setAutoscalingPolicyArgsForCall = append(fake.setAutoscalingPolicyArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.SetAutoscalingPolicyMessage
+	}{arg1, arg2, arg3})
+	stub := fake.SetAutoscalingPolicyStub
+	fakeReturns := fake.setAutoscalingPolicyReturns
+	fake.recordInvocation("SetAutoscalingPolicy", []interface{}{arg1, arg2, arg3})
+	fake.setAutoscalingPolicyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *AutoscalingPolicyRepository) SetAutoscalingPolicyCallCount() int {
+	fake.setAutoscalingPolicyMutex.RLock()
+	defer fake.setAutoscalingPolicyMutex.RUnlock()
+	return len(fake.setAutoscalingPolicyArgsForCall)
+}
+
+func (fake *AutoscalingPolicyRepository) SetAutoscalingPolicyCalls(stub func(context.Context, authorization.Info, repositories.SetAutoscalingPolicyMessage) (repositories.AutoscalingPolicyRecord, error)) {
+	fake.setAutoscalingPolicyMutex.Lock()
+	defer fake.setAutoscalingPolicyMutex.Unlock()
+	fake.SetAutoscalingPolicyStub = stub
+}
+
+func (fake *AutoscalingPolicyRepository) SetAutoscalingPolicyArgsForCall(i int) (context.Context, authorization.Info, repositories.SetAutoscalingPolicyMessage) {
+	fake.setAutoscalingPolicyMutex.RLock()
+	defer fake.setAutoscalingPolicyMutex.RUnlock()
+	argsForCall := fake.setAutoscalingPolicyArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *AutoscalingPolicyRepository) SetAutoscalingPolicyReturns(result1 repositories.AutoscalingPolicyRecord, result2 error) {
+	fake.setAutoscalingPolicyMutex.Lock()
+	defer fake.setAutoscalingPolicyMutex.Unlock()
+	fake.SetAutoscalingPolicyStub = nil
+	fake.setAutoscalingPolicyReturns = struct {
+		result1 repositories.AutoscalingPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AutoscalingPolicyRepository) SetAutoscalingPolicyReturnsOnCall(i int, result1 repositories.AutoscalingPolicyRecord, result2 error) {
+	fake.setAutoscalingPolicyMutex.Lock()
+	defer fake.setAutoscalingPolicyMutex.Unlock()
+	fake.SetAutoscalingPolicyStub = nil
+	if fake.setAutoscalingPolicyReturnsOnCall == nil {
+		fake.setAutoscalingPolicyReturnsOnCall = make(map[int]struct {
+			result1 repositories.AutoscalingPolicyRecord
+			result2 error
+		})
+	}
+	fake.setAutoscalingPolicyReturnsOnCall[i] = struct {
+		result1 repositories.AutoscalingPolicyRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *AutoscalingPolicyRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteAutoscalingPolicyMutex.RLock()
+	defer fake.deleteAutoscalingPolicyMutex.RUnlock()
+	fake.getAutoscalingPolicyMutex.RLock()
+	defer fake.getAutoscalingPolicyMutex.RUnlock()
+	fake.listScalingHistoryMutex.RLock()
+	defer fake.listScalingHistoryMutex.RUnlock()
+	fake.setAutoscalingPolicyMutex.RLock()
+	defer fake.setAutoscalingPolicyMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *AutoscalingPolicyRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.AutoscalingPolicyRepository = new(AutoscalingPolicyRepository)
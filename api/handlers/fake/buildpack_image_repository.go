@@ -0,0 +1,127 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+)
+
+type BuildpackImageRepository struct {
+	UploadBuildpackImageStub        func(context.Context, authorization.Info, string, io.Reader, ...string) (string, error)
+	uploadBuildpackImageMutex       sync.RWMutex
+	uploadBuildpackImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}
+	uploadBuildpackImageReturns struct {
+		result1 string
+		result2 error
+	}
+	uploadBuildpackImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *BuildpackImageRepository) UploadBuildpackImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
+	fake.uploadBuildpackImageMutex.Lock()
+	ret, specificReturn := fake.uploadBuildpackImageReturnsOnCall[len(fake.uploadBuildpackImageArgsForCall)]
+	fake.uploadBuildpackImageArgsForCall = append(fake.uploadBuildpackImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.UploadBuildpackImageStub
+	fakeReturns := fake.uploadBuildpackImageReturns
+	fake.recordInvocation("UploadBuildpackImage", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.uploadBuildpackImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BuildpackImageRepository) UploadBuildpackImageCallCount() int {
+	fake.uploadBuildpackImageMutex.RLock()
+	defer fake.uploadBuildpackImageMutex.RUnlock()
+	return len(fake.uploadBuildpackImageArgsForCall)
+}
+
+func (fake *BuildpackImageRepository) UploadBuildpackImageCalls(stub func(context.Context, authorization.Info, string, io.Reader, ...string) (string, error)) {
+	fake.uploadBuildpackImageMutex.Lock()
+	defer fake.uploadBuildpackImageMutex.Unlock()
+	fake.UploadBuildpackImageStub = stub
+}
+
+func (fake *BuildpackImageRepository) UploadBuildpackImageArgsForCall(i int) (context.Context, authorization.Info, string, io.Reader, []string) {
+	fake.uploadBuildpackImageMutex.RLock()
+	defer fake.uploadBuildpackImageMutex.RUnlock()
+	argsForCall := fake.uploadBuildpackImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *BuildpackImageRepository) UploadBuildpackImageReturns(result1 string, result2 error) {
+	fake.uploadBuildpackImageMutex.Lock()
+	defer fake.uploadBuildpackImageMutex.Unlock()
+	fake.UploadBuildpackImageStub = nil
+	fake.uploadBuildpackImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackImageRepository) UploadBuildpackImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.uploadBuildpackImageMutex.Lock()
+	defer fake.uploadBuildpackImageMutex.Unlock()
+	fake.UploadBuildpackImageStub = nil
+	if fake.uploadBuildpackImageReturnsOnCall == nil {
+		fake.uploadBuildpackImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.uploadBuildpackImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BuildpackImageRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.uploadBuildpackImageMutex.RLock()
+	defer fake.uploadBuildpackImageMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *BuildpackImageRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.BuildpackImageRepository = new(BuildpackImageRepository)
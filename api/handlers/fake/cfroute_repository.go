@@ -82,6 +82,21 @@ type CFRouteRepository struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}
+	IsRouteReservedStub        func(context.Context, authorization.Info, repositories.RouteReservationMessage) (bool, error)
+	isRouteReservedMutex       sync.RWMutex
+	isRouteReservedArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RouteReservationMessage
+	}
+	isRouteReservedReturns struct {
+		result1 bool
+		result2 error
+	}
+	isRouteReservedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	ListRoutesStub        func(context.Context, authorization.Info, repositories.ListRoutesMessage) (repositories.ListResult[repositories.RouteRecord], error)
 	listRoutesMutex       sync.RWMutex
 	listRoutesArgsForCall []struct {
@@ -97,18 +112,18 @@ type CFRouteRepository struct {
 		result1 repositories.ListResult[repositories.RouteRecord]
 		result2 error
 	}
-	PatchRouteMetadataStub        func(context.Context, authorization.Info, repositories.PatchRouteMetadataMessage) (repositories.RouteRecord, error)
-	patchRouteMetadataMutex       sync.RWMutex
-	patchRouteMetadataArgsForCall []struct {
+	PatchRouteStub        func(context.Context, authorization.Info, repositories.PatchRouteMessage) (repositories.RouteRecord, error)
+	patchRouteMutex       sync.RWMutex
+	patchRouteArgsForCall []struct {
 		arg1 context.Context
 		arg2 authorization.Info
-		arg3 repositories.PatchRouteMetadataMessage
+		arg3 repositories.PatchRouteMessage
 	}
-	patchRouteMetadataReturns struct {
+	patchRouteReturns struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}
-	patchRouteMetadataReturnsOnCall map[int]struct {
+	patchRouteReturnsOnCall map[int]struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}
@@ -127,6 +142,49 @@ type CFRouteRepository struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}
+	ShareRouteStub        func(context.Context, authorization.Info, repositories.ShareRouteMessage) (repositories.RouteRecord, error)
+	shareRouteMutex       sync.RWMutex
+	shareRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareRouteMessage
+	}
+	shareRouteReturns struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	shareRouteReturnsOnCall map[int]struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	TransferRouteOwnershipStub        func(context.Context, authorization.Info, repositories.TransferRouteOwnershipMessage) (repositories.RouteRecord, error)
+	transferRouteOwnershipMutex       sync.RWMutex
+	transferRouteOwnershipArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.TransferRouteOwnershipMessage
+	}
+	transferRouteOwnershipReturns struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	transferRouteOwnershipReturnsOnCall map[int]struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}
+	UnshareRouteStub        func(context.Context, authorization.Info, repositories.UnshareRouteMessage) error
+	unshareRouteMutex       sync.RWMutex
+	unshareRouteArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareRouteMessage
+	}
+	unshareRouteReturns struct {
+		result1 error
+	}
+	unshareRouteReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -455,6 +513,72 @@ func (fake *CFRouteRepository) GetRouteReturnsOnCall(i int, result1 repositories
 	}{result1, result2}
 }
 
+func (fake *CFRouteRepository) IsRouteReserved(arg1 context.Context, arg2 authorization.Info, arg3 repositories.RouteReservationMessage) (bool, error) {
+	fake.isRouteReservedMutex.Lock()
+	ret, specificReturn := fake.isRouteReservedReturnsOnCall[len(fake.isRouteReservedArgsForCall)]
+	fake.isRouteReservedArgsForCall = append(fake.isRouteReservedArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RouteReservationMessage
+	}{arg1, arg2, arg3})
+	stub := fake.IsRouteReservedStub
+	fakeReturns := fake.isRouteReservedReturns
+	fake.recordInvocation("IsRouteReserved", []interface{}{arg1, arg2, arg3})
+	fake.isRouteReservedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) IsRouteReservedCallCount() int {
+	fake.isRouteReservedMutex.RLock()
+	defer fake.isRouteReservedMutex.RUnlock()
+	return len(fake.isRouteReservedArgsForCall)
+}
+
+func (fake *CFRouteRepository) IsRouteReservedCalls(stub func(context.Context, authorization.Info, repositories.RouteReservationMessage) (bool, error)) {
+	fake.isRouteReservedMutex.Lock()
+	defer fake.isRouteReservedMutex.Unlock()
+	fake.IsRouteReservedStub = stub
+}
+
+func (fake *CFRouteRepository) IsRouteReservedArgsForCall(i int) (context.Context, authorization.Info, repositories.RouteReservationMessage) {
+	fake.isRouteReservedMutex.RLock()
+	defer fake.isRouteReservedMutex.RUnlock()
+	argsForCall := fake.isRouteReservedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) IsRouteReservedReturns(result1 bool, result2 error) {
+	fake.isRouteReservedMutex.Lock()
+	defer fake.isRouteReservedMutex.Unlock()
+	fake.IsRouteReservedStub = nil
+	fake.isRouteReservedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) IsRouteReservedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.isRouteReservedMutex.Lock()
+	defer fake.isRouteReservedMutex.Unlock()
+	fake.IsRouteReservedStub = nil
+	if fake.isRouteReservedReturnsOnCall == nil {
+		fake.isRouteReservedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.isRouteReservedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFRouteRepository) ListRoutes(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListRoutesMessage) (repositories.ListResult[repositories.RouteRecord], error) {
 	fake.listRoutesMutex.Lock()
 	ret, specificReturn := fake.listRoutesReturnsOnCall[len(fake.listRoutesArgsForCall)]
@@ -521,18 +645,18 @@ func (fake *CFRouteRepository) ListRoutesReturnsOnCall(i int, result1 repositori
 	}{result1, result2}
 }
 
-func (fake *CFRouteRepository) PatchRouteMetadata(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchRouteMetadataMessage) (repositories.RouteRecord, error) {
-	fake.patchRouteMetadataMutex.Lock()
-	ret, specificReturn := fake.patchRouteMetadataReturnsOnCall[len(fake.patchRouteMetadataArgsForCall)]
-	fake.patchRouteMetadataArgsForCall = append(fake.patchRouteMetadataArgsForCall, struct {
+func (fake *CFRouteRepository) PatchRoute(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchRouteMessage) (repositories.RouteRecord, error) {
+	fake.patchRouteMutex.Lock()
+	ret, specificReturn := fake.patchRouteReturnsOnCall[len(fake.patchRouteArgsForCall)]
+	fake.patchRouteArgsForCall = append(fake.patchRouteArgsForCall, struct {
 		arg1 context.Context
 		arg2 authorization.Info
-		arg3 repositories.PatchRouteMetadataMessage
+		arg3 repositories.PatchRouteMessage
 	}{arg1, arg2, arg3})
-	stub := fake.PatchRouteMetadataStub
-	fakeReturns := fake.patchRouteMetadataReturns
-	fake.recordInvocation("PatchRouteMetadata", []interface{}{arg1, arg2, arg3})
-	fake.patchRouteMetadataMutex.Unlock()
+	stub := fake.PatchRouteStub
+	fakeReturns := fake.patchRouteReturns
+	fake.recordInvocation("PatchRoute", []interface{}{arg1, arg2, arg3})
+	fake.patchRouteMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2, arg3)
 	}
@@ -542,46 +666,46 @@ func (fake *CFRouteRepository) PatchRouteMetadata(arg1 context.Context, arg2 aut
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *CFRouteRepository) PatchRouteMetadataCallCount() int {
-	fake.patchRouteMetadataMutex.RLock()
-	defer fake.patchRouteMetadataMutex.RUnlock()
-	return len(fake.patchRouteMetadataArgsForCall)
+func (fake *CFRouteRepository) PatchRouteCallCount() int {
+	fake.patchRouteMutex.RLock()
+	defer fake.patchRouteMutex.RUnlock()
+	return len(fake.patchRouteArgsForCall)
 }
 
-func (fake *CFRouteRepository) PatchRouteMetadataCalls(stub func(context.Context, authorization.Info, repositories.PatchRouteMetadataMessage) (repositories.RouteRecord, error)) {
-	fake.patchRouteMetadataMutex.Lock()
-	defer fake.patchRouteMetadataMutex.Unlock()
-	fake.PatchRouteMetadataStub = stub
+func (fake *CFRouteRepository) PatchRouteCalls(stub func(context.Context, authorization.Info, repositories.PatchRouteMessage) (repositories.RouteRecord, error)) {
+	fake.patchRouteMutex.Lock()
+	defer fake.patchRouteMutex.Unlock()
+	fake.PatchRouteStub = stub
 }
 
-func (fake *CFRouteRepository) PatchRouteMetadataArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchRouteMetadataMessage) {
-	fake.patchRouteMetadataMutex.RLock()
-	defer fake.patchRouteMetadataMutex.RUnlock()
-	argsForCall := fake.patchRouteMetadataArgsForCall[i]
+func (fake *CFRouteRepository) PatchRouteArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchRouteMessage) {
+	fake.patchRouteMutex.RLock()
+	defer fake.patchRouteMutex.RUnlock()
+	argsForCall := fake.patchRouteArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *CFRouteRepository) PatchRouteMetadataReturns(result1 repositories.RouteRecord, result2 error) {
-	fake.patchRouteMetadataMutex.Lock()
-	defer fake.patchRouteMetadataMutex.Unlock()
-	fake.PatchRouteMetadataStub = nil
-	fake.patchRouteMetadataReturns = struct {
+func (fake *CFRouteRepository) PatchRouteReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.patchRouteMutex.Lock()
+	defer fake.patchRouteMutex.Unlock()
+	fake.PatchRouteStub = nil
+	fake.patchRouteReturns = struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *CFRouteRepository) PatchRouteMetadataReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
-	fake.patchRouteMetadataMutex.Lock()
-	defer fake.patchRouteMetadataMutex.Unlock()
-	fake.PatchRouteMetadataStub = nil
-	if fake.patchRouteMetadataReturnsOnCall == nil {
-		fake.patchRouteMetadataReturnsOnCall = make(map[int]struct {
+func (fake *CFRouteRepository) PatchRouteReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.patchRouteMutex.Lock()
+	defer fake.patchRouteMutex.Unlock()
+	fake.PatchRouteStub = nil
+	if fake.patchRouteReturnsOnCall == nil {
+		fake.patchRouteReturnsOnCall = make(map[int]struct {
 			result1 repositories.RouteRecord
 			result2 error
 		})
 	}
-	fake.patchRouteMetadataReturnsOnCall[i] = struct {
+	fake.patchRouteReturnsOnCall[i] = struct {
 		result1 repositories.RouteRecord
 		result2 error
 	}{result1, result2}
@@ -653,6 +777,201 @@ func (fake *CFRouteRepository) RemoveDestinationFromRouteReturnsOnCall(i int, re
 	}{result1, result2}
 }
 
+func (fake *CFRouteRepository) ShareRoute(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ShareRouteMessage) (repositories.RouteRecord, error) {
+	fake.shareRouteMutex.Lock()
+	ret, specificReturn := fake.shareRouteReturnsOnCall[len(fake.shareRouteArgsForCall)]
+	fake.shareRouteArgsForCall = append(fake.shareRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ShareRouteMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ShareRouteStub
+	fakeReturns := fake.shareRouteReturns
+	fake.recordInvocation("ShareRoute", []interface{}{arg1, arg2, arg3})
+	fake.shareRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) ShareRouteCallCount() int {
+	fake.shareRouteMutex.RLock()
+	defer fake.shareRouteMutex.RUnlock()
+	return len(fake.shareRouteArgsForCall)
+}
+
+func (fake *CFRouteRepository) ShareRouteCalls(stub func(context.Context, authorization.Info, repositories.ShareRouteMessage) (repositories.RouteRecord, error)) {
+	fake.shareRouteMutex.Lock()
+	defer fake.shareRouteMutex.Unlock()
+	fake.ShareRouteStub = stub
+}
+
+func (fake *CFRouteRepository) ShareRouteArgsForCall(i int) (context.Context, authorization.Info, repositories.ShareRouteMessage) {
+	fake.shareRouteMutex.RLock()
+	defer fake.shareRouteMutex.RUnlock()
+	argsForCall := fake.shareRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) ShareRouteReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.shareRouteMutex.Lock()
+	defer fake.shareRouteMutex.Unlock()
+	fake.ShareRouteStub = nil
+	fake.shareRouteReturns = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) ShareRouteReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.shareRouteMutex.Lock()
+	defer fake.shareRouteMutex.Unlock()
+	fake.ShareRouteStub = nil
+	if fake.shareRouteReturnsOnCall == nil {
+		fake.shareRouteReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.shareRouteReturnsOnCall[i] = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) TransferRouteOwnership(arg1 context.Context, arg2 authorization.Info, arg3 repositories.TransferRouteOwnershipMessage) (repositories.RouteRecord, error) {
+	fake.transferRouteOwnershipMutex.Lock()
+	ret, specificReturn := fake.transferRouteOwnershipReturnsOnCall[len(fake.transferRouteOwnershipArgsForCall)]
+	fake.transferRouteOwnershipArgsForCall = append(fake.transferRouteOwnershipArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.TransferRouteOwnershipMessage
+	}{arg1, arg2, arg3})
+	stub := fake.TransferRouteOwnershipStub
+	fakeReturns := fake.transferRouteOwnershipReturns
+	fake.recordInvocation("TransferRouteOwnership", []interface{}{arg1, arg2, arg3})
+	fake.transferRouteOwnershipMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFRouteRepository) TransferRouteOwnershipCallCount() int {
+	fake.transferRouteOwnershipMutex.RLock()
+	defer fake.transferRouteOwnershipMutex.RUnlock()
+	return len(fake.transferRouteOwnershipArgsForCall)
+}
+
+func (fake *CFRouteRepository) TransferRouteOwnershipCalls(stub func(context.Context, authorization.Info, repositories.TransferRouteOwnershipMessage) (repositories.RouteRecord, error)) {
+	fake.transferRouteOwnershipMutex.Lock()
+	defer fake.transferRouteOwnershipMutex.Unlock()
+	fake.TransferRouteOwnershipStub = stub
+}
+
+func (fake *CFRouteRepository) TransferRouteOwnershipArgsForCall(i int) (context.Context, authorization.Info, repositories.TransferRouteOwnershipMessage) {
+	fake.transferRouteOwnershipMutex.RLock()
+	defer fake.transferRouteOwnershipMutex.RUnlock()
+	argsForCall := fake.transferRouteOwnershipArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) TransferRouteOwnershipReturns(result1 repositories.RouteRecord, result2 error) {
+	fake.transferRouteOwnershipMutex.Lock()
+	defer fake.transferRouteOwnershipMutex.Unlock()
+	fake.TransferRouteOwnershipStub = nil
+	fake.transferRouteOwnershipReturns = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) TransferRouteOwnershipReturnsOnCall(i int, result1 repositories.RouteRecord, result2 error) {
+	fake.transferRouteOwnershipMutex.Lock()
+	defer fake.transferRouteOwnershipMutex.Unlock()
+	fake.TransferRouteOwnershipStub = nil
+	if fake.transferRouteOwnershipReturnsOnCall == nil {
+		fake.transferRouteOwnershipReturnsOnCall = make(map[int]struct {
+			result1 repositories.RouteRecord
+			result2 error
+		})
+	}
+	fake.transferRouteOwnershipReturnsOnCall[i] = struct {
+		result1 repositories.RouteRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFRouteRepository) UnshareRoute(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UnshareRouteMessage) error {
+	fake.unshareRouteMutex.Lock()
+	ret, specificReturn := fake.unshareRouteReturnsOnCall[len(fake.unshareRouteArgsForCall)]
+	fake.unshareRouteArgsForCall = append(fake.unshareRouteArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UnshareRouteMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UnshareRouteStub
+	fakeReturns := fake.unshareRouteReturns
+	fake.recordInvocation("UnshareRoute", []interface{}{arg1, arg2, arg3})
+	fake.unshareRouteMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFRouteRepository) UnshareRouteCallCount() int {
+	fake.unshareRouteMutex.RLock()
+	defer fake.unshareRouteMutex.RUnlock()
+	return len(fake.unshareRouteArgsForCall)
+}
+
+func (fake *CFRouteRepository) UnshareRouteCalls(stub func(context.Context, authorization.Info, repositories.UnshareRouteMessage) error) {
+	fake.unshareRouteMutex.Lock()
+	defer fake.unshareRouteMutex.Unlock()
+	fake.UnshareRouteStub = stub
+}
+
+func (fake *CFRouteRepository) UnshareRouteArgsForCall(i int) (context.Context, authorization.Info, repositories.UnshareRouteMessage) {
+	fake.unshareRouteMutex.RLock()
+	defer fake.unshareRouteMutex.RUnlock()
+	argsForCall := fake.unshareRouteArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFRouteRepository) UnshareRouteReturns(result1 error) {
+	fake.unshareRouteMutex.Lock()
+	defer fake.unshareRouteMutex.Unlock()
+	fake.UnshareRouteStub = nil
+	fake.unshareRouteReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFRouteRepository) UnshareRouteReturnsOnCall(i int, result1 error) {
+	fake.unshareRouteMutex.Lock()
+	defer fake.unshareRouteMutex.Unlock()
+	fake.UnshareRouteStub = nil
+	if fake.unshareRouteReturnsOnCall == nil {
+		fake.unshareRouteReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.unshareRouteReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -666,12 +985,20 @@ func (fake *CFRouteRepository) Invocations() map[string][][]interface{} {
 	defer fake.deleteUnmappedRoutesMutex.RUnlock()
 	fake.getRouteMutex.RLock()
 	defer fake.getRouteMutex.RUnlock()
+	fake.isRouteReservedMutex.RLock()
+	defer fake.isRouteReservedMutex.RUnlock()
 	fake.listRoutesMutex.RLock()
 	defer fake.listRoutesMutex.RUnlock()
-	fake.patchRouteMetadataMutex.RLock()
-	defer fake.patchRouteMetadataMutex.RUnlock()
+	fake.patchRouteMutex.RLock()
+	defer fake.patchRouteMutex.RUnlock()
 	fake.removeDestinationFromRouteMutex.RLock()
 	defer fake.removeDestinationFromRouteMutex.RUnlock()
+	fake.shareRouteMutex.RLock()
+	defer fake.shareRouteMutex.RUnlock()
+	fake.transferRouteOwnershipMutex.RLock()
+	defer fake.transferRouteOwnershipMutex.RUnlock()
+	fake.unshareRouteMutex.RLock()
+	defer fake.unshareRouteMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,946 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type IsolationSegmentRepository struct {
+	CreateIsolationSegmentStub        func(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	createIsolationSegmentMutex       sync.RWMutex
+	createIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateIsolationSegmentMessage
+	}
+	createIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	createIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	DeleteIsolationSegmentStub        func(context.Context, authorization.Info, string) error
+	deleteIsolationSegmentMutex       sync.RWMutex
+	deleteIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	deleteIsolationSegmentReturns struct {
+		result1 error
+	}
+	deleteIsolationSegmentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EntitleOrgsStub        func(context.Context, authorization.Info, string, []string) (repositories.IsolationSegmentRecord, error)
+	entitleOrgsMutex       sync.RWMutex
+	entitleOrgsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 []string
+	}
+	entitleOrgsReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	entitleOrgsReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	GetIsolationSegmentStub        func(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)
+	getIsolationSegmentMutex       sync.RWMutex
+	getIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	getIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	GetOrgDefaultIsolationSegmentStub        func(context.Context, authorization.Info, string) (string, error)
+	getOrgDefaultIsolationSegmentMutex       sync.RWMutex
+	getOrgDefaultIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getOrgDefaultIsolationSegmentReturns struct {
+		result1 string
+		result2 error
+	}
+	getOrgDefaultIsolationSegmentReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetSpaceIsolationSegmentStub        func(context.Context, authorization.Info, string) (string, error)
+	getSpaceIsolationSegmentMutex       sync.RWMutex
+	getSpaceIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getSpaceIsolationSegmentReturns struct {
+		result1 string
+		result2 error
+	}
+	getSpaceIsolationSegmentReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ListIsolationSegmentsStub        func(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)
+	listIsolationSegmentsMutex       sync.RWMutex
+	listIsolationSegmentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListIsolationSegmentsMessage
+	}
+	listIsolationSegmentsReturns struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}
+	listIsolationSegmentsReturnsOnCall map[int]struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}
+	RevokeOrgStub        func(context.Context, authorization.Info, string, string) error
+	revokeOrgMutex       sync.RWMutex
+	revokeOrgArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}
+	revokeOrgReturns struct {
+		result1 error
+	}
+	revokeOrgReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetOrgDefaultIsolationSegmentStub        func(context.Context, authorization.Info, string, string) error
+	setOrgDefaultIsolationSegmentMutex       sync.RWMutex
+	setOrgDefaultIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}
+	setOrgDefaultIsolationSegmentReturns struct {
+		result1 error
+	}
+	setOrgDefaultIsolationSegmentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetSpaceIsolationSegmentStub        func(context.Context, authorization.Info, string, string) error
+	setSpaceIsolationSegmentMutex       sync.RWMutex
+	setSpaceIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}
+	setSpaceIsolationSegmentReturns struct {
+		result1 error
+	}
+	setSpaceIsolationSegmentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateIsolationSegmentStub        func(context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	updateIsolationSegmentMutex       sync.RWMutex
+	updateIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateIsolationSegmentMessage
+	}
+	updateIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	updateIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *IsolationSegmentRepository) CreateIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error) {
+	fake.createIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.createIsolationSegmentReturnsOnCall[len(fake.createIsolationSegmentArgsForCall)]
+	fake.createIsolationSegmentArgsForCall = append(fake.createIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateIsolationSegmentStub
+	fakeReturns := fake.createIsolationSegmentReturns
+	fake.recordInvocation("CreateIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.createIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IsolationSegmentRepository) CreateIsolationSegmentCallCount() int {
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	return len(fake.createIsolationSegmentArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) CreateIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = stub
+}
+
+func (fake *IsolationSegmentRepository) CreateIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) {
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.createIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *IsolationSegmentRepository) CreateIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = nil
+	fake.createIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) CreateIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = nil
+	if fake.createIsolationSegmentReturnsOnCall == nil {
+		fake.createIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.createIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) DeleteIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.deleteIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.deleteIsolationSegmentReturnsOnCall[len(fake.deleteIsolationSegmentArgsForCall)]
+	fake.deleteIsolationSegmentArgsForCall = append(fake.deleteIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteIsolationSegmentStub
+	fakeReturns := fake.deleteIsolationSegmentReturns
+	fake.recordInvocation("DeleteIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.deleteIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *IsolationSegmentRepository) DeleteIsolationSegmentCallCount() int {
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	return len(fake.deleteIsolationSegmentArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) DeleteIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = stub
+}
+
+func (fake *IsolationSegmentRepository) DeleteIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.deleteIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *IsolationSegmentRepository) DeleteIsolationSegmentReturns(result1 error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = nil
+	fake.deleteIsolationSegmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *IsolationSegmentRepository) DeleteIsolationSegmentReturnsOnCall(i int, result1 error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = nil
+	if fake.deleteIsolationSegmentReturnsOnCall == nil {
+		fake.deleteIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteIsolationSegmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *IsolationSegmentRepository) EntitleOrgs(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 []string) (repositories.IsolationSegmentRecord, error) {
+	var arg4Copy []string
+	if arg4 != nil {
+		arg4Copy = make([]string, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.entitleOrgsMutex.Lock()
+	ret, specificReturn := fake.entitleOrgsReturnsOnCall[len(fake.entitleOrgsArgsForCall)]
+	fake.entitleOrgsArgsForCall = append(fake.entitleOrgsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 []string
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.EntitleOrgsStub
+	fakeReturns := fake.entitleOrgsReturns
+	fake.recordInvocation("EntitleOrgs", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.entitleOrgsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IsolationSegmentRepository) EntitleOrgsCallCount() int {
+	fake.entitleOrgsMutex.RLock()
+	defer fake.entitleOrgsMutex.RUnlock()
+	return len(fake.entitleOrgsArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) EntitleOrgsCalls(stub func(context.Context, authorization.Info, string, []string) (repositories.IsolationSegmentRecord, error)) {
+	fake.entitleOrgsMutex.Lock()
+	defer fake.entitleOrgsMutex.Unlock()
+	fake.EntitleOrgsStub = stub
+}
+
+func (fake *IsolationSegmentRepository) EntitleOrgsArgsForCall(i int) (context.Context, authorization.Info, string, []string) {
+	fake.entitleOrgsMutex.RLock()
+	defer fake.entitleOrgsMutex.RUnlock()
+	argsForCall := fake.entitleOrgsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *IsolationSegmentRepository) EntitleOrgsReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.entitleOrgsMutex.Lock()
+	defer fake.entitleOrgsMutex.Unlock()
+	fake.EntitleOrgsStub = nil
+	fake.entitleOrgsReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) EntitleOrgsReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.entitleOrgsMutex.Lock()
+	defer fake.entitleOrgsMutex.Unlock()
+	fake.EntitleOrgsStub = nil
+	if fake.entitleOrgsReturnsOnCall == nil {
+		fake.entitleOrgsReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.entitleOrgsReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) GetIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.IsolationSegmentRecord, error) {
+	fake.getIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.getIsolationSegmentReturnsOnCall[len(fake.getIsolationSegmentArgsForCall)]
+	fake.getIsolationSegmentArgsForCall = append(fake.getIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetIsolationSegmentStub
+	fakeReturns := fake.getIsolationSegmentReturns
+	fake.recordInvocation("GetIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.getIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IsolationSegmentRepository) GetIsolationSegmentCallCount() int {
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	return len(fake.getIsolationSegmentArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) GetIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = stub
+}
+
+func (fake *IsolationSegmentRepository) GetIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.getIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *IsolationSegmentRepository) GetIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = nil
+	fake.getIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) GetIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = nil
+	if fake.getIsolationSegmentReturnsOnCall == nil {
+		fake.getIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.getIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) GetOrgDefaultIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) (string, error) {
+	fake.getOrgDefaultIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.getOrgDefaultIsolationSegmentReturnsOnCall[len(fake.getOrgDefaultIsolationSegmentArgsForCall)]
+	fake.getOrgDefaultIsolationSegmentArgsForCall = append(fake.getOrgDefaultIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetOrgDefaultIsolationSegmentStub
+	fakeReturns := fake.getOrgDefaultIsolationSegmentReturns
+	fake.recordInvocation("GetOrgDefaultIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.getOrgDefaultIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IsolationSegmentRepository) GetOrgDefaultIsolationSegmentCallCount() int {
+	fake.getOrgDefaultIsolationSegmentMutex.RLock()
+	defer fake.getOrgDefaultIsolationSegmentMutex.RUnlock()
+	return len(fake.getOrgDefaultIsolationSegmentArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) GetOrgDefaultIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) (string, error)) {
+	fake.getOrgDefaultIsolationSegmentMutex.Lock()
+	defer fake.getOrgDefaultIsolationSegmentMutex.Unlock()
+	fake.GetOrgDefaultIsolationSegmentStub = stub
+}
+
+func (fake *IsolationSegmentRepository) GetOrgDefaultIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getOrgDefaultIsolationSegmentMutex.RLock()
+	defer fake.getOrgDefaultIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.getOrgDefaultIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *IsolationSegmentRepository) GetOrgDefaultIsolationSegmentReturns(result1 string, result2 error) {
+	fake.getOrgDefaultIsolationSegmentMutex.Lock()
+	defer fake.getOrgDefaultIsolationSegmentMutex.Unlock()
+	fake.GetOrgDefaultIsolationSegmentStub = nil
+	fake.getOrgDefaultIsolationSegmentReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) GetOrgDefaultIsolationSegmentReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getOrgDefaultIsolationSegmentMutex.Lock()
+	defer fake.getOrgDefaultIsolationSegmentMutex.Unlock()
+	fake.GetOrgDefaultIsolationSegmentStub = nil
+	if fake.getOrgDefaultIsolationSegmentReturnsOnCall == nil {
+		fake.getOrgDefaultIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getOrgDefaultIsolationSegmentReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) GetSpaceIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) (string, error) {
+	fake.getSpaceIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.getSpaceIsolationSegmentReturnsOnCall[len(fake.getSpaceIsolationSegmentArgsForCall)]
+	fake.getSpaceIsolationSegmentArgsForCall = append(fake.getSpaceIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSpaceIsolationSegmentStub
+	fakeReturns := fake.getSpaceIsolationSegmentReturns
+	fake.recordInvocation("GetSpaceIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.getSpaceIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IsolationSegmentRepository) GetSpaceIsolationSegmentCallCount() int {
+	fake.getSpaceIsolationSegmentMutex.RLock()
+	defer fake.getSpaceIsolationSegmentMutex.RUnlock()
+	return len(fake.getSpaceIsolationSegmentArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) GetSpaceIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) (string, error)) {
+	fake.getSpaceIsolationSegmentMutex.Lock()
+	defer fake.getSpaceIsolationSegmentMutex.Unlock()
+	fake.GetSpaceIsolationSegmentStub = stub
+}
+
+func (fake *IsolationSegmentRepository) GetSpaceIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getSpaceIsolationSegmentMutex.RLock()
+	defer fake.getSpaceIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.getSpaceIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *IsolationSegmentRepository) GetSpaceIsolationSegmentReturns(result1 string, result2 error) {
+	fake.getSpaceIsolationSegmentMutex.Lock()
+	defer fake.getSpaceIsolationSegmentMutex.Unlock()
+	fake.GetSpaceIsolationSegmentStub = nil
+	fake.getSpaceIsolationSegmentReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) GetSpaceIsolationSegmentReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getSpaceIsolationSegmentMutex.Lock()
+	defer fake.getSpaceIsolationSegmentMutex.Unlock()
+	fake.GetSpaceIsolationSegmentStub = nil
+	if fake.getSpaceIsolationSegmentReturnsOnCall == nil {
+		fake.getSpaceIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getSpaceIsolationSegmentReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) ListIsolationSegments(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	ret, specificReturn := fake.listIsolationSegmentsReturnsOnCall[len(fake.listIsolationSegmentsArgsForCall)]
+	fake.listIsolationSegmentsArgsForCall = append(fake.listIsolationSegmentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListIsolationSegmentsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListIsolationSegmentsStub
+	fakeReturns := fake.listIsolationSegmentsReturns
+	fake.recordInvocation("ListIsolationSegments", []interface{}{arg1, arg2, arg3})
+	fake.listIsolationSegmentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IsolationSegmentRepository) ListIsolationSegmentsCallCount() int {
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	return len(fake.listIsolationSegmentsArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) ListIsolationSegmentsCalls(stub func(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = stub
+}
+
+func (fake *IsolationSegmentRepository) ListIsolationSegmentsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) {
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	argsForCall := fake.listIsolationSegmentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *IsolationSegmentRepository) ListIsolationSegmentsReturns(result1 []repositories.IsolationSegmentRecord, result2 error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = nil
+	fake.listIsolationSegmentsReturns = struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) ListIsolationSegmentsReturnsOnCall(i int, result1 []repositories.IsolationSegmentRecord, result2 error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = nil
+	if fake.listIsolationSegmentsReturnsOnCall == nil {
+		fake.listIsolationSegmentsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.listIsolationSegmentsReturnsOnCall[i] = struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) RevokeOrg(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) error {
+	fake.revokeOrgMutex.Lock()
+	ret, specificReturn := fake.revokeOrgReturnsOnCall[len(fake.revokeOrgArgsForCall)]
+	fake.revokeOrgArgsForCall = append(fake.revokeOrgArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.RevokeOrgStub
+	fakeReturns := fake.revokeOrgReturns
+	fake.recordInvocation("RevokeOrg", []interface{}{arg1, arg2, arg3, arg4})
+	fake.revokeOrgMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *IsolationSegmentRepository) RevokeOrgCallCount() int {
+	fake.revokeOrgMutex.RLock()
+	defer fake.revokeOrgMutex.RUnlock()
+	return len(fake.revokeOrgArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) RevokeOrgCalls(stub func(context.Context, authorization.Info, string, string) error) {
+	fake.revokeOrgMutex.Lock()
+	defer fake.revokeOrgMutex.Unlock()
+	fake.RevokeOrgStub = stub
+}
+
+func (fake *IsolationSegmentRepository) RevokeOrgArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+	fake.revokeOrgMutex.RLock()
+	defer fake.revokeOrgMutex.RUnlock()
+	argsForCall := fake.revokeOrgArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *IsolationSegmentRepository) RevokeOrgReturns(result1 error) {
+	fake.revokeOrgMutex.Lock()
+	defer fake.revokeOrgMutex.Unlock()
+	fake.RevokeOrgStub = nil
+	fake.revokeOrgReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *IsolationSegmentRepository) RevokeOrgReturnsOnCall(i int, result1 error) {
+	fake.revokeOrgMutex.Lock()
+	defer fake.revokeOrgMutex.Unlock()
+	fake.RevokeOrgStub = nil
+	if fake.revokeOrgReturnsOnCall == nil {
+		fake.revokeOrgReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeOrgReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *IsolationSegmentRepository) SetOrgDefaultIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) error {
+	fake.setOrgDefaultIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.setOrgDefaultIsolationSegmentReturnsOnCall[len(fake.setOrgDefaultIsolationSegmentArgsForCall)]
+	fake.setOrgDefaultIsolationSegmentArgsForCall = append(fake.setOrgDefaultIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetOrgDefaultIsolationSegmentStub
+	fakeReturns := fake.setOrgDefaultIsolationSegmentReturns
+	fake.recordInvocation("SetOrgDefaultIsolationSegment", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setOrgDefaultIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *IsolationSegmentRepository) SetOrgDefaultIsolationSegmentCallCount() int {
+	fake.setOrgDefaultIsolationSegmentMutex.RLock()
+	defer fake.setOrgDefaultIsolationSegmentMutex.RUnlock()
+	return len(fake.setOrgDefaultIsolationSegmentArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) SetOrgDefaultIsolationSegmentCalls(stub func(context.Context, authorization.Info, string, string) error) {
+	fake.setOrgDefaultIsolationSegmentMutex.Lock()
+	defer fake.setOrgDefaultIsolationSegmentMutex.Unlock()
+	fake.SetOrgDefaultIsolationSegmentStub = stub
+}
+
+func (fake *IsolationSegmentRepository) SetOrgDefaultIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+	fake.setOrgDefaultIsolationSegmentMutex.RLock()
+	defer fake.setOrgDefaultIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.setOrgDefaultIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *IsolationSegmentRepository) SetOrgDefaultIsolationSegmentReturns(result1 error) {
+	fake.setOrgDefaultIsolationSegmentMutex.Lock()
+	defer fake.setOrgDefaultIsolationSegmentMutex.Unlock()
+	fake.SetOrgDefaultIsolationSegmentStub = nil
+	fake.setOrgDefaultIsolationSegmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *IsolationSegmentRepository) SetOrgDefaultIsolationSegmentReturnsOnCall(i int, result1 error) {
+	fake.setOrgDefaultIsolationSegmentMutex.Lock()
+	defer fake.setOrgDefaultIsolationSegmentMutex.Unlock()
+	fake.SetOrgDefaultIsolationSegmentStub = nil
+	if fake.setOrgDefaultIsolationSegmentReturnsOnCall == nil {
+		fake.setOrgDefaultIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setOrgDefaultIsolationSegmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *IsolationSegmentRepository) SetSpaceIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string) error {
+	fake.setSpaceIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.setSpaceIsolationSegmentReturnsOnCall[len(fake.setSpaceIsolationSegmentArgsForCall)]
+	fake.setSpaceIsolationSegmentArgsForCall = append(fake.setSpaceIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.SetSpaceIsolationSegmentStub
+	fakeReturns := fake.setSpaceIsolationSegmentReturns
+	fake.recordInvocation("SetSpaceIsolationSegment", []interface{}{arg1, arg2, arg3, arg4})
+	fake.setSpaceIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *IsolationSegmentRepository) SetSpaceIsolationSegmentCallCount() int {
+	fake.setSpaceIsolationSegmentMutex.RLock()
+	defer fake.setSpaceIsolationSegmentMutex.RUnlock()
+	return len(fake.setSpaceIsolationSegmentArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) SetSpaceIsolationSegmentCalls(stub func(context.Context, authorization.Info, string, string) error) {
+	fake.setSpaceIsolationSegmentMutex.Lock()
+	defer fake.setSpaceIsolationSegmentMutex.Unlock()
+	fake.SetSpaceIsolationSegmentStub = stub
+}
+
+func (fake *IsolationSegmentRepository) SetSpaceIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string, string) {
+	fake.setSpaceIsolationSegmentMutex.RLock()
+	defer fake.setSpaceIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.setSpaceIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *IsolationSegmentRepository) SetSpaceIsolationSegmentReturns(result1 error) {
+	fake.setSpaceIsolationSegmentMutex.Lock()
+	defer fake.setSpaceIsolationSegmentMutex.Unlock()
+	fake.SetSpaceIsolationSegmentStub = nil
+	fake.setSpaceIsolationSegmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *IsolationSegmentRepository) SetSpaceIsolationSegmentReturnsOnCall(i int, result1 error) {
+	fake.setSpaceIsolationSegmentMutex.Lock()
+	defer fake.setSpaceIsolationSegmentMutex.Unlock()
+	fake.SetSpaceIsolationSegmentStub = nil
+	if fake.setSpaceIsolationSegmentReturnsOnCall == nil {
+		fake.setSpaceIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setSpaceIsolationSegmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *IsolationSegmentRepository) UpdateIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error) {
+	fake.updateIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.updateIsolationSegmentReturnsOnCall[len(fake.updateIsolationSegmentArgsForCall)]
+	fake.updateIsolationSegmentArgsForCall = append(fake.updateIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateIsolationSegmentStub
+	fakeReturns := fake.updateIsolationSegmentReturns
+	fake.recordInvocation("UpdateIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.updateIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *IsolationSegmentRepository) UpdateIsolationSegmentCallCount() int {
+	fake.updateIsolationSegmentMutex.RLock()
+	defer fake.updateIsolationSegmentMutex.RUnlock()
+	return len(fake.updateIsolationSegmentArgsForCall)
+}
+
+func (fake *IsolationSegmentRepository) UpdateIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)) {
+	fake.updateIsolationSegmentMutex.Lock()
+	defer fake.updateIsolationSegmentMutex.Unlock()
+	fake.UpdateIsolationSegmentStub = stub
+}
+
+func (fake *IsolationSegmentRepository) UpdateIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) {
+	fake.updateIsolationSegmentMutex.RLock()
+	defer fake.updateIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.updateIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *IsolationSegmentRepository) UpdateIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.updateIsolationSegmentMutex.Lock()
+	defer fake.updateIsolationSegmentMutex.Unlock()
+	fake.UpdateIsolationSegmentStub = nil
+	fake.updateIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) UpdateIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.updateIsolationSegmentMutex.Lock()
+	defer fake.updateIsolationSegmentMutex.Unlock()
+	fake.UpdateIsolationSegmentStub = nil
+	if fake.updateIsolationSegmentReturnsOnCall == nil {
+		fake.updateIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.updateIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *IsolationSegmentRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	fake.entitleOrgsMutex.RLock()
+	defer fake.entitleOrgsMutex.RUnlock()
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	fake.getOrgDefaultIsolationSegmentMutex.RLock()
+	defer fake.getOrgDefaultIsolationSegmentMutex.RUnlock()
+	fake.getSpaceIsolationSegmentMutex.RLock()
+	defer fake.getSpaceIsolationSegmentMutex.RUnlock()
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	fake.revokeOrgMutex.RLock()
+	defer fake.revokeOrgMutex.RUnlock()
+	fake.setOrgDefaultIsolationSegmentMutex.RLock()
+	defer fake.setOrgDefaultIsolationSegmentMutex.RUnlock()
+	fake.setSpaceIsolationSegmentMutex.RLock()
+	defer fake.setSpaceIsolationSegmentMutex.RUnlock()
+	fake.updateIsolationSegmentMutex.RLock()
+	defer fake.updateIsolationSegmentMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *IsolationSegmentRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.IsolationSegmentRepository = new(IsolationSegmentRepository)
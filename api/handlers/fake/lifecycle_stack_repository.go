@@ -0,0 +1,204 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type LifecycleStackRepository struct {
+	GetDefaultStackStub        func(context.Context, authorization.Info) (repositories.StackRecord, error)
+	getDefaultStackMutex       sync.RWMutex
+	getDefaultStackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}
+	getDefaultStackReturns struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
+	getDefaultStackReturnsOnCall map[int]struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
+	GetStackByNameStub        func(context.Context, authorization.Info, string) (repositories.StackRecord, error)
+	getStackByNameMutex       sync.RWMutex
+	getStackByNameArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getStackByNameReturns struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
+	getStackByNameReturnsOnCall map[int]struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *LifecycleStackRepository) GetDefaultStack(arg1 context.Context, arg2 authorization.Info) (repositories.StackRecord, error) {
+	fake.getDefaultStackMutex.Lock()
+	ret, specificReturn := fake.getDefaultStackReturnsOnCall[len(fake.getDefaultStackArgsForCall)]
+	fake.getDefaultStackArgsForCall = append(fake.getDefaultStackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+	}{arg1, arg2})
+	stub := fake.GetDefaultStackStub
+	fakeReturns := fake.getDefaultStackReturns
+	fake.recordInvocation("GetDefaultStack", []interface{}{arg1, arg2})
+	fake.getDefaultStackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *LifecycleStackRepository) GetDefaultStackCallCount() int {
+	fake.getDefaultStackMutex.RLock()
+	defer fake.getDefaultStackMutex.RUnlock()
+	return len(fake.getDefaultStackArgsForCall)
+}
+
+func (fake *LifecycleStackRepository) GetDefaultStackCalls(stub func(context.Context, authorization.Info) (repositories.StackRecord, error)) {
+	fake.getDefaultStackMutex.Lock()
+	defer fake.getDefaultStackMutex.Unlock()
+	fake.GetDefaultStackStub = stub
+}
+
+func (fake *LifecycleStackRepository) GetDefaultStackArgsForCall(i int) (context.Context, authorization.Info) {
+	fake.getDefaultStackMutex.RLock()
+	defer fake.getDefaultStackMutex.RUnlock()
+	argsForCall := fake.getDefaultStackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *LifecycleStackRepository) GetDefaultStackReturns(result1 repositories.StackRecord, result2 error) {
+	fake.getDefaultStackMutex.Lock()
+	defer fake.getDefaultStackMutex.Unlock()
+	fake.GetDefaultStackStub = nil
+	fake.getDefaultStackReturns = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LifecycleStackRepository) GetDefaultStackReturnsOnCall(i int, result1 repositories.StackRecord, result2 error) {
+	fake.getDefaultStackMutex.Lock()
+	defer fake.getDefaultStackMutex.Unlock()
+	fake.GetDefaultStackStub = nil
+	if fake.getDefaultStackReturnsOnCall == nil {
+		fake.getDefaultStackReturnsOnCall = make(map[int]struct {
+			result1 repositories.StackRecord
+			result2 error
+		})
+	}
+	fake.getDefaultStackReturnsOnCall[i] = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LifecycleStackRepository) GetStackByName(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.StackRecord, error) {
+	fake.getStackByNameMutex.Lock()
+	ret, specificReturn := fake.getStackByNameReturnsOnCall[len(fake.getStackByNameArgsForCall)]
+	fake.getStackByNameArgsForCall = append(fake.getStackByNameArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetStackByNameStub
+	fakeReturns := fake.getStackByNameReturns
+	fake.recordInvocation("GetStackByName", []interface{}{arg1, arg2, arg3})
+	fake.getStackByNameMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *LifecycleStackRepository) GetStackByNameCallCount() int {
+	fake.getStackByNameMutex.RLock()
+	defer fake.getStackByNameMutex.RUnlock()
+	return len(fake.getStackByNameArgsForCall)
+}
+
+func (fake *LifecycleStackRepository) GetStackByNameCalls(stub func(context.Context, authorization.Info, string) (repositories.StackRecord, error)) {
+	fake.getStackByNameMutex.Lock()
+	defer fake.getStackByNameMutex.Unlock()
+	fake.GetStackByNameStub = stub
+}
+
+func (fake *LifecycleStackRepository) GetStackByNameArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getStackByNameMutex.RLock()
+	defer fake.getStackByNameMutex.RUnlock()
+	argsForCall := fake.getStackByNameArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *LifecycleStackRepository) GetStackByNameReturns(result1 repositories.StackRecord, result2 error) {
+	fake.getStackByNameMutex.Lock()
+	defer fake.getStackByNameMutex.Unlock()
+	fake.GetStackByNameStub = nil
+	fake.getStackByNameReturns = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LifecycleStackRepository) GetStackByNameReturnsOnCall(i int, result1 repositories.StackRecord, result2 error) {
+	fake.getStackByNameMutex.Lock()
+	defer fake.getStackByNameMutex.Unlock()
+	fake.GetStackByNameStub = nil
+	if fake.getStackByNameReturnsOnCall == nil {
+		fake.getStackByNameReturnsOnCall = make(map[int]struct {
+			result1 repositories.StackRecord
+			result2 error
+		})
+	}
+	fake.getStackByNameReturnsOnCall[i] = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *LifecycleStackRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getDefaultStackMutex.RLock()
+	defer fake.getDefaultStackMutex.RUnlock()
+	fake.getStackByNameMutex.RLock()
+	defer fake.getStackByNameMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *LifecycleStackRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.LifecycleStackRepository = new(LifecycleStackRepository)
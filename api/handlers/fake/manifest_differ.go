@@ -0,0 +1,126 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/payloads"
+)
+
+type ManifestDiffer struct {
+	DiffStub        func(context.Context, authorization.Info, string, payloads.Manifest) ([]manifest.DiffEntry, error)
+	diffMutex       sync.RWMutex
+	diffArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 payloads.Manifest
+	}
+	diffReturns struct {
+		result1 []manifest.DiffEntry
+		result2 error
+	}
+	diffReturnsOnCall map[int]struct {
+		result1 []manifest.DiffEntry
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ManifestDiffer) Diff(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 payloads.Manifest) ([]manifest.DiffEntry, error) {
+	fake.diffMutex.Lock()
+	ret, specificReturn := fake.diffReturnsOnCall[len(fake.diffArgsForCall)]
+	fake.diffArgsForCall = append(fake.diffArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 payloads.Manifest
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DiffStub
+	fakeReturns := fake.diffReturns
+	fake.recordInvocation("Diff", []interface{}{arg1, arg2, arg3, arg4})
+	fake.diffMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ManifestDiffer) DiffCallCount() int {
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	return len(fake.diffArgsForCall)
+}
+
+func (fake *ManifestDiffer) DiffCalls(stub func(context.Context, authorization.Info, string, payloads.Manifest) ([]manifest.DiffEntry, error)) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = stub
+}
+
+func (fake *ManifestDiffer) DiffArgsForCall(i int) (context.Context, authorization.Info, string, payloads.Manifest) {
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	argsForCall := fake.diffArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ManifestDiffer) DiffReturns(result1 []manifest.DiffEntry, result2 error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = nil
+	fake.diffReturns = struct {
+		result1 []manifest.DiffEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ManifestDiffer) DiffReturnsOnCall(i int, result1 []manifest.DiffEntry, result2 error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = nil
+	if fake.diffReturnsOnCall == nil {
+		fake.diffReturnsOnCall = make(map[int]struct {
+			result1 []manifest.DiffEntry
+			result2 error
+		})
+	}
+	fake.diffReturnsOnCall[i] = struct {
+		result1 []manifest.DiffEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ManifestDiffer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ManifestDiffer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.ManifestDiffer = new(ManifestDiffer)
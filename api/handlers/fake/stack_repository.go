@@ -1,3 +1,4 @@
+// Code generated by counterfeiter. DO NOT EDIT.
 package fake
 
 import (
@@ -10,6 +11,49 @@ import (
 )
 
 type StackRepository struct {
+	CreateStackStub        func(context.Context, authorization.Info, repositories.CreateStackMessage) (repositories.StackRecord, error)
+	createStackMutex       sync.RWMutex
+	createStackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateStackMessage
+	}
+	createStackReturns struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
+	createStackReturnsOnCall map[int]struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
+	DeleteStackStub        func(context.Context, authorization.Info, string) error
+	deleteStackMutex       sync.RWMutex
+	deleteStackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	deleteStackReturns struct {
+		result1 error
+	}
+	deleteStackReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetStackStub        func(context.Context, authorization.Info, string) (repositories.StackRecord, error)
+	getStackMutex       sync.RWMutex
+	getStackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getStackReturns struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
+	getStackReturnsOnCall map[int]struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
 	ListStacksStub        func(context.Context, authorization.Info) ([]repositories.StackRecord, error)
 	listStacksMutex       sync.RWMutex
 	listStacksArgsForCall []struct {
@@ -24,10 +68,220 @@ type StackRepository struct {
 		result1 []repositories.StackRecord
 		result2 error
 	}
+	UpdateStackStub        func(context.Context, authorization.Info, repositories.UpdateStackMessage) (repositories.StackRecord, error)
+	updateStackMutex       sync.RWMutex
+	updateStackArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateStackMessage
+	}
+	updateStackReturns struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
+	updateStackReturnsOnCall map[int]struct {
+		result1 repositories.StackRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *StackRepository) CreateStack(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateStackMessage) (repositories.StackRecord, error) {
+	fake.createStackMutex.Lock()
+	ret, specificReturn := fake.createStackReturnsOnCall[len(fake.createStackArgsForCall)]
+	fake.createStackArgsForCall = append(fake.createStackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateStackMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateStackStub
+	fakeReturns := fake.createStackReturns
+	fake.recordInvocation("CreateStack", []interface{}{arg1, arg2, arg3})
+	fake.createStackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *StackRepository) CreateStackCallCount() int {
+	fake.createStackMutex.RLock()
+	defer fake.createStackMutex.RUnlock()
+	return len(fake.createStackArgsForCall)
+}
+
+func (fake *StackRepository) CreateStackCalls(stub func(context.Context, authorization.Info, repositories.CreateStackMessage) (repositories.StackRecord, error)) {
+	fake.createStackMutex.Lock()
+	defer fake.createStackMutex.Unlock()
+	fake.CreateStackStub = stub
+}
+
+func (fake *StackRepository) CreateStackArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateStackMessage) {
+	fake.createStackMutex.RLock()
+	defer fake.createStackMutex.RUnlock()
+	argsForCall := fake.createStackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *StackRepository) CreateStackReturns(result1 repositories.StackRecord, result2 error) {
+	fake.createStackMutex.Lock()
+	defer fake.createStackMutex.Unlock()
+	fake.CreateStackStub = nil
+	fake.createStackReturns = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *StackRepository) CreateStackReturnsOnCall(i int, result1 repositories.StackRecord, result2 error) {
+	fake.createStackMutex.Lock()
+	defer fake.createStackMutex.Unlock()
+	fake.CreateStackStub = nil
+	if fake.createStackReturnsOnCall == nil {
+		fake.createStackReturnsOnCall = make(map[int]struct {
+			result1 repositories.StackRecord
+			result2 error
+		})
+	}
+	fake.createStackReturnsOnCall[i] = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *StackRepository) DeleteStack(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.deleteStackMutex.Lock()
+	ret, specificReturn := fake.deleteStackReturnsOnCall[len(fake.deleteStackArgsForCall)]
+	fake.deleteStackArgsForCall = append(fake.deleteStackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteStackStub
+	fakeReturns := fake.deleteStackReturns
+	fake.recordInvocation("DeleteStack", []interface{}{arg1, arg2, arg3})
+	fake.deleteStackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *StackRepository) DeleteStackCallCount() int {
+	fake.deleteStackMutex.RLock()
+	defer fake.deleteStackMutex.RUnlock()
+	return len(fake.deleteStackArgsForCall)
+}
+
+func (fake *StackRepository) DeleteStackCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.deleteStackMutex.Lock()
+	defer fake.deleteStackMutex.Unlock()
+	fake.DeleteStackStub = stub
+}
+
+func (fake *StackRepository) DeleteStackArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.deleteStackMutex.RLock()
+	defer fake.deleteStackMutex.RUnlock()
+	argsForCall := fake.deleteStackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *StackRepository) DeleteStackReturns(result1 error) {
+	fake.deleteStackMutex.Lock()
+	defer fake.deleteStackMutex.Unlock()
+	fake.DeleteStackStub = nil
+	fake.deleteStackReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *StackRepository) DeleteStackReturnsOnCall(i int, result1 error) {
+	fake.deleteStackMutex.Lock()
+	defer fake.deleteStackMutex.Unlock()
+	fake.DeleteStackStub = nil
+	if fake.deleteStackReturnsOnCall == nil {
+		fake.deleteStackReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteStackReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *StackRepository) GetStack(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.StackRecord, error) {
+	fake.getStackMutex.Lock()
+	ret, specificReturn := fake.getStackReturnsOnCall[len(fake.getStackArgsForCall)]
+	fake.getStackArgsForCall = append(fake.getStackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetStackStub
+	fakeReturns := fake.getStackReturns
+	fake.recordInvocation("GetStack", []interface{}{arg1, arg2, arg3})
+	fake.getStackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *StackRepository) GetStackCallCount() int {
+	fake.getStackMutex.RLock()
+	defer fake.getStackMutex.RUnlock()
+	return len(fake.getStackArgsForCall)
+}
+
+func (fake *StackRepository) GetStackCalls(stub func(context.Context, authorization.Info, string) (repositories.StackRecord, error)) {
+	fake.getStackMutex.Lock()
+	defer fake.getStackMutex.Unlock()
+	fake.GetStackStub = stub
+}
+
+func (fake *StackRepository) GetStackArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getStackMutex.RLock()
+	defer fake.getStackMutex.RUnlock()
+	argsForCall := fake.getStackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *StackRepository) GetStackReturns(result1 repositories.StackRecord, result2 error) {
+	fake.getStackMutex.Lock()
+	defer fake.getStackMutex.Unlock()
+	fake.GetStackStub = nil
+	fake.getStackReturns = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *StackRepository) GetStackReturnsOnCall(i int, result1 repositories.StackRecord, result2 error) {
+	fake.getStackMutex.Lock()
+	defer fake.getStackMutex.Unlock()
+	fake.GetStackStub = nil
+	if fake.getStackReturnsOnCall == nil {
+		fake.getStackReturnsOnCall = make(map[int]struct {
+			result1 repositories.StackRecord
+			result2 error
+		})
+	}
+	fake.getStackReturnsOnCall[i] = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *StackRepository) ListStacks(arg1 context.Context, arg2 authorization.Info) ([]repositories.StackRecord, error) {
 	fake.listStacksMutex.Lock()
 	ret, specificReturn := fake.listStacksReturnsOnCall[len(fake.listStacksArgsForCall)]
@@ -93,11 +347,85 @@ func (fake *StackRepository) ListStacksReturnsOnCall(i int, result1 []repositori
 	}{result1, result2}
 }
 
+func (fake *StackRepository) UpdateStack(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateStackMessage) (repositories.StackRecord, error) {
+	fake.updateStackMutex.Lock()
+	ret, specificReturn := fake.updateStackReturnsOnCall[len(fake.updateStackArgsForCall)]
+	fake.updateStackArgsForCall = append(fake.updateStackArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateStackMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateStackStub
+	fakeReturns := fake.updateStackReturns
+	fake.recordInvocation("UpdateStack", []interface{}{arg1, arg2, arg3})
+	fake.updateStackMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *StackRepository) UpdateStackCallCount() int {
+	fake.updateStackMutex.RLock()
+	defer fake.updateStackMutex.RUnlock()
+	return len(fake.updateStackArgsForCall)
+}
+
+func (fake *StackRepository) UpdateStackCalls(stub func(context.Context, authorization.Info, repositories.UpdateStackMessage) (repositories.StackRecord, error)) {
+	fake.updateStackMutex.Lock()
+	defer fake.updateStackMutex.Unlock()
+	fake.UpdateStackStub = stub
+}
+
+func (fake *StackRepository) UpdateStackArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateStackMessage) {
+	fake.updateStackMutex.RLock()
+	defer fake.updateStackMutex.RUnlock()
+	argsForCall := fake.updateStackArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *StackRepository) UpdateStackReturns(result1 repositories.StackRecord, result2 error) {
+	fake.updateStackMutex.Lock()
+	defer fake.updateStackMutex.Unlock()
+	fake.UpdateStackStub = nil
+	fake.updateStackReturns = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *StackRepository) UpdateStackReturnsOnCall(i int, result1 repositories.StackRecord, result2 error) {
+	fake.updateStackMutex.Lock()
+	defer fake.updateStackMutex.Unlock()
+	fake.UpdateStackStub = nil
+	if fake.updateStackReturnsOnCall == nil {
+		fake.updateStackReturnsOnCall = make(map[int]struct {
+			result1 repositories.StackRecord
+			result2 error
+		})
+	}
+	fake.updateStackReturnsOnCall[i] = struct {
+		result1 repositories.StackRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *StackRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createStackMutex.RLock()
+	defer fake.createStackMutex.RUnlock()
+	fake.deleteStackMutex.RLock()
+	defer fake.deleteStackMutex.RUnlock()
+	fake.getStackMutex.RLock()
+	defer fake.getStackMutex.RUnlock()
 	fake.listStacksMutex.RLock()
 	defer fake.listStacksMutex.RUnlock()
+	fake.updateStackMutex.RLock()
+	defer fake.updateStackMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	IsolationSegmentsPath                      = "/v3/isolation_segments"
+	IsolationSegmentPath                       = "/v3/isolation_segments/{guid}"
+	IsolationSegmentOrgsRelationshipPath       = "/v3/isolation_segments/{guid}/relationships/organizations"
+	IsolationSegmentOrgRelationshipPath        = "/v3/isolation_segments/{guid}/relationships/organizations/{org_guid}"
+	OrgDefaultIsolationSegmentRelationshipPath = "/v3/organizations/{guid}/relationships/default_isolation_segment"
+	SpaceIsolationSegmentRelationshipPath      = "/v3/spaces/{guid}/relationships/isolation_segment"
+	invalidIsolationSegmentOrganizationError   = "Organization does not exist, or you do not have access."
+)
+
+//counterfeiter:generate -o fake -fake-name IsolationSegmentRepository . IsolationSegmentRepository
+type IsolationSegmentRepository interface {
+	CreateIsolationSegment(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	GetIsolationSegment(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)
+	ListIsolationSegments(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)
+	UpdateIsolationSegment(context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	DeleteIsolationSegment(context.Context, authorization.Info, string) error
+	EntitleOrgs(context.Context, authorization.Info, string, []string) (repositories.IsolationSegmentRecord, error)
+	RevokeOrg(context.Context, authorization.Info, string, string) error
+	GetOrgDefaultIsolationSegment(context.Context, authorization.Info, string) (string, error)
+	SetOrgDefaultIsolationSegment(context.Context, authorization.Info, string, string) error
+	GetSpaceIsolationSegment(context.Context, authorization.Info, string) (string, error)
+	SetSpaceIsolationSegment(context.Context, authorization.Info, string, string) error
+}
+
+type IsolationSegment struct {
+	serverURL            url.URL
+	isolationSegmentRepo IsolationSegmentRepository
+	orgRepo              CFOrgRepository
+	spaceRepo            CFSpaceRepository
+	requestValidator     RequestValidator
+}
+
+func NewIsolationSegment(
+	serverURL url.URL,
+	isolationSegmentRepo IsolationSegmentRepository,
+	orgRepo CFOrgRepository,
+	spaceRepo CFSpaceRepository,
+	requestValidator RequestValidator,
+) *IsolationSegment {
+	return &IsolationSegment{
+		serverURL:            serverURL,
+		isolationSegmentRepo: isolationSegmentRepo,
+		orgRepo:              orgRepo,
+		spaceRepo:            spaceRepo,
+		requestValidator:     requestValidator,
+	}
+}
+
+func (h *IsolationSegment) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list")
+
+	payload := new(payloads.IsolationSegmentList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	isolationSegments, err := h.isolationSegmentRepo.ListIsolationSegments(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch isolation segments from Kubernetes")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForListDeprecated(presenter.ForIsolationSegment, isolationSegments, h.serverURL, *r.URL)), nil
+}
+
+func (h *IsolationSegment) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get")
+
+	guid := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting isolation segment in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.create")
+
+	var payload payloads.IsolationSegmentCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.CreateIsolationSegment(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating isolation segment in repository")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) update(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.update")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting isolation segment in repository")
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.UpdateIsolationSegment(r.Context(), authInfo, payload.ToMessage(guid))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error updating isolation segment in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.delete")
+
+	guid := routing.URLParam(r, "guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting isolation segment in repository")
+	}
+
+	if err = h.isolationSegmentRepo.DeleteIsolationSegment(r.Context(), authInfo, guid); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error deleting isolation segment in repository")
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) listOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list-orgs")
+
+	guid := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting isolation segment in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrgsRelationship(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) entitleOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.entitle-orgs")
+
+	guid := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentEntitleOrgs
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting isolation segment in repository")
+	}
+
+	for _, orgGUID := range payload.OrgGUIDs() {
+		if _, err = h.orgRepo.GetOrg(r.Context(), authInfo, orgGUID); err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(err, invalidIsolationSegmentOrganizationError, apierrors.ForbiddenError{}, apierrors.NotFoundError{}),
+				"Error getting org in repository",
+				"OrgGUID", orgGUID,
+			)
+		}
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.EntitleOrgs(r.Context(), authInfo, guid, payload.OrgGUIDs())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error entitling orgs to isolation segment")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrgsRelationship(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) revokeOrg(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.revoke-org")
+
+	guid := routing.URLParam(r, "guid")
+	orgGUID := routing.URLParam(r, "org_guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, guid)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting isolation segment in repository")
+	}
+
+	if _, err = h.orgRepo.GetOrg(r.Context(), authInfo, orgGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting org in repository", "OrgGUID", orgGUID)
+	}
+
+	if err = h.isolationSegmentRepo.RevokeOrg(r.Context(), authInfo, guid, orgGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error revoking org from isolation segment", "OrgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) getOrgDefault(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get-org-default")
+
+	orgGUID := routing.URLParam(r, "guid")
+
+	if _, err := h.orgRepo.GetOrg(r.Context(), authInfo, orgGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting org in repository", "OrgGUID", orgGUID)
+	}
+
+	isolationSegmentGUID, err := h.isolationSegmentRepo.GetOrgDefaultIsolationSegment(r.Context(), authInfo, orgGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error getting org default isolation segment", "OrgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgDefaultIsolationSegmentRelationship(orgGUID, isolationSegmentGUID, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) setOrgDefault(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.set-org-default")
+
+	orgGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentAssign
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if _, err := h.orgRepo.GetOrg(r.Context(), authInfo, orgGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting org in repository", "OrgGUID", orgGUID)
+	}
+
+	if err := h.isolationSegmentRepo.SetOrgDefaultIsolationSegment(r.Context(), authInfo, orgGUID, payload.GUID()); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error setting org default isolation segment", "OrgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgDefaultIsolationSegmentRelationship(orgGUID, payload.GUID(), h.serverURL)), nil
+}
+
+func (h *IsolationSegment) getSpaceAssignment(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get-space-assignment")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting space in repository", "SpaceGUID", spaceGUID)
+	}
+
+	isolationSegmentGUID, err := h.isolationSegmentRepo.GetSpaceIsolationSegment(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error getting space isolation segment", "SpaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegmentRelationship(spaceGUID, isolationSegmentGUID, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) setSpaceAssignment(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.set-space-assignment")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentAssign
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting space in repository", "SpaceGUID", spaceGUID)
+	}
+
+	if err := h.isolationSegmentRepo.SetSpaceIsolationSegment(r.Context(), authInfo, spaceGUID, payload.GUID()); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error setting space isolation segment", "SpaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegmentRelationship(spaceGUID, payload.GUID(), h.serverURL)), nil
+}
+
+func (h *IsolationSegment) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *IsolationSegment) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: IsolationSegmentsPath, Handler: h.list},
+		{Method: "POST", Pattern: IsolationSegmentsPath, Handler: h.create},
+		{Method: "GET", Pattern: IsolationSegmentPath, Handler: h.get},
+		{Method: "PATCH", Pattern: IsolationSegmentPath, Handler: h.update},
+		{Method: "DELETE", Pattern: IsolationSegmentPath, Handler: h.delete},
+		{Method: "GET", Pattern: IsolationSegmentOrgsRelationshipPath, Handler: h.listOrgs},
+		{Method: "POST", Pattern: IsolationSegmentOrgsRelationshipPath, Handler: h.entitleOrgs},
+		{Method: "DELETE", Pattern: IsolationSegmentOrgRelationshipPath, Handler: h.revokeOrg},
+		{Method: "GET", Pattern: OrgDefaultIsolationSegmentRelationshipPath, Handler: h.getOrgDefault},
+		{Method: "PATCH", Pattern: OrgDefaultIsolationSegmentRelationshipPath, Handler: h.setOrgDefault},
+		{Method: "GET", Pattern: SpaceIsolationSegmentRelationshipPath, Handler: h.getSpaceAssignment},
+		{Method: "PATCH", Pattern: SpaceIsolationSegmentRelationshipPath, Handler: h.setSpaceAssignment},
+	}
+}
@@ -0,0 +1,396 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("IsolationSegment", func() {
+	var (
+		isolationSegmentRepo *fake.IsolationSegmentRepository
+		orgRepo              *fake.CFOrgRepository
+		spaceRepo            *fake.CFSpaceRepository
+		requestValidator     *fake.RequestValidator
+		req                  *http.Request
+	)
+
+	BeforeEach(func() {
+		isolationSegmentRepo = new(fake.IsolationSegmentRepository)
+		orgRepo = new(fake.CFOrgRepository)
+		spaceRepo = new(fake.CFSpaceRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewIsolationSegment(*serverURL, isolationSegmentRepo, orgRepo, spaceRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("the GET /v3/isolation_segments endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.IsolationSegmentList{
+				Names: "pci",
+			})
+
+			isolationSegmentRepo.ListIsolationSegmentsReturns([]repositories.IsolationSegmentRecord{
+				{GUID: "iso-seg-guid", Name: "pci"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments?names=pci", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the isolation segments", func() {
+			Expect(isolationSegmentRepo.ListIsolationSegmentsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.ListIsolationSegmentsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("pci"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "iso-seg-guid"),
+				MatchJSONPath("$.resources[0].name", "pci"),
+			)))
+		})
+
+		When("listing the isolation segments fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.ListIsolationSegmentsReturns(nil, errors.New("unknown!"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the POST /v3/isolation_segments endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentCreate{
+				Name: "pci",
+				Scheduling: payloads.IsolationSegmentScheduling{
+					NodeSelector:     map[string]string{"pool": "pci"},
+					RuntimeClassName: tools.PtrTo("gvisor"),
+				},
+			})
+
+			isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "iso-seg-guid",
+				Name: "pci",
+				Scheduling: repositories.IsolationSegmentScheduling{
+					NodeSelector: map[string]string{"pool": "pci"},
+				},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/isolation_segments", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the isolation segment", func() {
+			Expect(isolationSegmentRepo.CreateIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := isolationSegmentRepo.CreateIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage.Name).To(Equal("pci"))
+			Expect(createMessage.Scheduling.NodeSelector).To(Equal(map[string]string{"pool": "pci"}))
+			Expect(createMessage.Scheduling.RuntimeClassName).To(PointTo(Equal("gvisor")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "iso-seg-guid"),
+				MatchJSONPath("$.scheduling.node_selector.pool", "pci"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(isolationSegmentRepo.CreateIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user is not authorized to create isolation segments", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewForbiddenError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/isolation_segments/:guid endpoint", func() {
+		BeforeEach(func() {
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{GUID: "iso-seg-guid", Name: "pci"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments/iso-seg-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the isolation segment", func() {
+			Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(Equal(1))
+			_, _, actualGUID := isolationSegmentRepo.GetIsolationSegmentArgsForCall(0)
+			Expect(actualGUID).To(Equal("iso-seg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "pci")))
+		})
+
+		When("the isolation segment is forbidden", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewForbiddenError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/isolation_segments/:guid endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentUpdate{
+				Name: tools.PtrTo("pci-2"),
+			})
+
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{GUID: "iso-seg-guid"}, nil)
+			isolationSegmentRepo.UpdateIsolationSegmentReturns(repositories.IsolationSegmentRecord{GUID: "iso-seg-guid", Name: "pci-2"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/isolation_segments/iso-seg-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the isolation segment", func() {
+			Expect(isolationSegmentRepo.UpdateIsolationSegmentCallCount()).To(Equal(1))
+			_, _, updateMessage := isolationSegmentRepo.UpdateIsolationSegmentArgsForCall(0)
+			Expect(updateMessage.GUID).To(Equal("iso-seg-guid"))
+			Expect(updateMessage.Name).To(PointTo(Equal("pci-2")))
+			Expect(updateMessage.Scheduling).To(BeNil())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "pci-2")))
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+				Expect(isolationSegmentRepo.UpdateIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/isolation_segments/:guid endpoint", func() {
+		BeforeEach(func() {
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{GUID: "iso-seg-guid"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/isolation_segments/iso-seg-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the isolation segment", func() {
+			Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(Equal(1))
+			_, _, deletedGUID := isolationSegmentRepo.DeleteIsolationSegmentArgsForCall(0)
+			Expect(deletedGUID).To(Equal("iso-seg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the isolation segment is still entitled to orgs", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.DeleteIsolationSegmentReturns(apierrors.NewUnprocessableEntityError(nil, "still entitled"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("still entitled")
+			})
+		})
+	})
+
+	Describe("the POST /v3/isolation_segments/:guid/relationships/organizations endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentEntitleOrgs{
+				Data: []payloads.RelationshipData{{GUID: "org-guid"}},
+			})
+
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{GUID: "iso-seg-guid"}, nil)
+			isolationSegmentRepo.EntitleOrgsReturns(repositories.IsolationSegmentRecord{
+				GUID:         "iso-seg-guid",
+				EntitledOrgs: []string{"org-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/isolation_segments/iso-seg-guid/relationships/organizations", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("entitles the orgs", func() {
+			Expect(orgRepo.GetOrgCallCount()).To(Equal(1))
+			_, _, actualOrgGUID := orgRepo.GetOrgArgsForCall(0)
+			Expect(actualOrgGUID).To(Equal("org-guid"))
+
+			Expect(isolationSegmentRepo.EntitleOrgsCallCount()).To(Equal(1))
+			_, _, actualGUID, actualOrgGUIDs := isolationSegmentRepo.EntitleOrgsArgsForCall(0)
+			Expect(actualGUID).To(Equal("iso-seg-guid"))
+			Expect(actualOrgGUIDs).To(ConsistOf("org-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid/relationships/organizations"),
+			)))
+		})
+
+		When("the org does not exist", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Organization does not exist, or you do not have access.")
+				Expect(isolationSegmentRepo.EntitleOrgsCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/isolation_segments/:guid/relationships/organizations/:org_guid endpoint", func() {
+		BeforeEach(func() {
+			isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{GUID: "iso-seg-guid"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/isolation_segments/iso-seg-guid/relationships/organizations/org-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("revokes the org entitlement", func() {
+			Expect(isolationSegmentRepo.RevokeOrgCallCount()).To(Equal(1))
+			_, _, actualGUID, actualOrgGUID := isolationSegmentRepo.RevokeOrgArgsForCall(0)
+			Expect(actualGUID).To(Equal("iso-seg-guid"))
+			Expect(actualOrgGUID).To(Equal("org-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+	})
+
+	Describe("the PATCH /v3/organizations/:guid/relationships/default_isolation_segment endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentAssign{
+				Data: &payloads.RelationshipData{GUID: "iso-seg-guid"},
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/organizations/org-guid/relationships/default_isolation_segment", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("sets the org default isolation segment", func() {
+			Expect(isolationSegmentRepo.SetOrgDefaultIsolationSegmentCallCount()).To(Equal(1))
+			_, _, actualOrgGUID, actualGUID := isolationSegmentRepo.SetOrgDefaultIsolationSegmentArgsForCall(0)
+			Expect(actualOrgGUID).To(Equal("org-guid"))
+			Expect(actualGUID).To(Equal("iso-seg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data.guid", "iso-seg-guid"),
+				MatchJSONPath("$.links.related.href", "https://api.example.org/v3/isolation_segments/iso-seg-guid"),
+			)))
+		})
+
+		When("the org is not visible to the user", func() {
+			BeforeEach(func() {
+				orgRepo.GetOrgReturns(repositories.OrgRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgResourceType)
+				Expect(isolationSegmentRepo.SetOrgDefaultIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the GET /v3/spaces/:guid/relationships/isolation_segment endpoint", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/spaces/space-guid/relationships/isolation_segment", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns a null relationship when no isolation segment is assigned", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", BeNil()),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"),
+			)))
+		})
+
+		When("the space has an isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetSpaceIsolationSegmentReturns("iso-seg-guid", nil)
+			})
+
+			It("returns it", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data.guid", "iso-seg-guid")))
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/spaces/:guid/relationships/isolation_segment endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentAssign{})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/spaces/space-guid/relationships/isolation_segment", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("resets the space isolation segment", func() {
+			Expect(isolationSegmentRepo.SetSpaceIsolationSegmentCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID, actualGUID := isolationSegmentRepo.SetSpaceIsolationSegmentArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+			Expect(actualGUID).To(BeEmpty())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data", BeNil())))
+		})
+
+		When("the isolation segment is not entitled to the org of the space", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.SetSpaceIsolationSegmentReturns(apierrors.NewUnprocessableEntityError(nil, "not entitled"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("not entitled")
+			})
+		})
+	})
+})
@@ -26,6 +26,7 @@ const (
 	RouteDeleteJobType                  = "route.delete"
 	SpaceDeleteJobType                  = "space.delete"
 	DomainDeleteJobType                 = "domain.delete"
+	BuildpackDeleteJobType              = "buildpack.delete"
 	RoleDeleteJobType                   = "role.delete"
 	ServiceBrokerCreateJobType          = "service_broker.create"
 	ServiceBrokerUpdateJobType          = "service_broker.update"
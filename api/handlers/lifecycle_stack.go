@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	"github.com/go-logr/logr"
+)
+
+//counterfeiter:generate -o fake -fake-name LifecycleStackRepository . LifecycleStackRepository
+type LifecycleStackRepository interface {
+	GetStackByName(ctx context.Context, authInfo authorization.Info, name string) (repositories.StackRecord, error)
+	GetDefaultStack(ctx context.Context, authInfo authorization.Info) (repositories.StackRecord, error)
+}
+
+// applyDefaultStack sets the stack of a buildpack lifecycle to the admin
+// managed default stack when no stack has been requested explicitly. The
+// lifecycle is left untouched when there is no default stack.
+func applyDefaultStack(ctx context.Context, stackRepo LifecycleStackRepository, authInfo authorization.Info, lifecycle *repositories.Lifecycle, requestedStack string) error {
+	if lifecycle.Type != "buildpack" || requestedStack != "" {
+		return nil
+	}
+
+	stack, err := stackRepo.GetDefaultStack(ctx, authInfo)
+	if err != nil {
+		if errors.As(err, &apierrors.NotFoundError{}) {
+			return nil
+		}
+		return err
+	}
+
+	lifecycle.Data.Stack = stack.Name
+	return nil
+}
+
+func stackDeprecationWarning(ctx context.Context, logger logr.Logger, stackRepo LifecycleStackRepository, authInfo authorization.Info, lifecycle repositories.Lifecycle) string {
+	if lifecycle.Type != "buildpack" || lifecycle.Data.Stack == "" {
+		return ""
+	}
+
+	stack, err := stackRepo.GetStackByName(ctx, authInfo, lifecycle.Data.Stack)
+	if err != nil {
+		if !errors.As(err, &apierrors.NotFoundError{}) {
+			logger.Info("failed to get the stack, skipping deprecation check", "stack", lifecycle.Data.Stack, "reason", err)
+		}
+		return ""
+	}
+
+	if stack.State != repositories.StackStateDeprecated {
+		return ""
+	}
+
+	return fmt.Sprintf("Stack '%s' is deprecated and will be removed in the future.", stack.Name)
+}
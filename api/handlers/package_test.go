@@ -21,6 +21,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -446,6 +447,24 @@ var _ = Describe("Package", func() {
 			)))
 		})
 
+		When("the payload contains registry credentials", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageUpdate{
+					Data: &payloads.PackageUpdateData{
+						Username: tools.PtrTo("bob"),
+						Password: tools.PtrTo("paSs"),
+					},
+				})
+			})
+
+			It("passes them to the repository", func() {
+				Expect(packageRepo.UpdatePackageCallCount()).To(Equal(1))
+				_, _, actualUpdate := packageRepo.UpdatePackageArgsForCall(0)
+				Expect(actualUpdate.Username).To(gstruct.PointTo(Equal("bob")))
+				Expect(actualUpdate.Password).To(gstruct.PointTo(Equal("paSs")))
+			})
+		})
+
 		When("updating the package fails", func() {
 			BeforeEach(func() {
 				packageRepo.UpdatePackageReturns(repositories.PackageRecord{}, errors.New("boom"))
@@ -16,19 +16,21 @@ const (
 type Root struct {
 	baseURL     url.URL
 	uaaConfig   config.UAA
+	oidcConfig  config.OIDC
 	logCacheURL url.URL
 }
 
-func NewRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) *Root {
+func NewRoot(baseURL url.URL, uaaConfig config.UAA, oidcConfig config.OIDC, logCacheURL url.URL) *Root {
 	return &Root{
 		baseURL:     baseURL,
 		uaaConfig:   uaaConfig,
+		oidcConfig:  oidcConfig,
 		logCacheURL: logCacheURL,
 	}
 }
 
 func (h *Root) get(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL, h.uaaConfig, h.logCacheURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL, h.uaaConfig, h.oidcConfig, h.logCacheURL)), nil
 }
 
 func (h *Root) UnauthenticatedRoutes() []routing.Route {
@@ -24,7 +24,7 @@ var _ = Describe("Root", func() {
 		logCacheURL, err = url.Parse("https://my.logcache.org")
 		Expect(err).NotTo(HaveOccurred())
 
-		apiHandler = handlers.NewRoot(*serverURL, config.UAA{}, *logCacheURL)
+		apiHandler = handlers.NewRoot(*serverURL, config.UAA{}, config.OIDC{}, *logCacheURL)
 	})
 
 	JustBeforeEach(func() {
@@ -51,6 +51,24 @@ var _ = Describe("Root", func() {
 			)))
 		})
 
+		When("OIDC support is enabled", func() {
+			BeforeEach(func() {
+				apiHandler = handlers.NewRoot(
+					*serverURL,
+					config.UAA{},
+					config.OIDC{
+						Enabled:   true,
+						IssuerURL: "https://my.idp",
+					},
+					*logCacheURL)
+			})
+
+			It("advertises the OIDC issuer", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.links.oidc.href", "https://my.idp")))
+			})
+		})
+
 		When("UAA support is enabled", func() {
 			BeforeEach(func() {
 				apiHandler = handlers.NewRoot(
@@ -59,6 +77,7 @@ var _ = Describe("Root", func() {
 						Enabled: true,
 						URL:     "https://my.uaa",
 					},
+					config.OIDC{},
 					*logCacheURL)
 			})
 
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 
@@ -20,6 +21,11 @@ const (
 	RoutesPath            = "/v3/routes"
 	RouteDestinationsPath = "/v3/routes/{guid}/destinations"
 	RouteDestinationPath  = "/v3/routes/{guid}/destinations/{destination_guid}"
+
+	RouteSharedSpacesRelationshipPath = "/v3/routes/{guid}/relationships/shared_spaces"
+	RouteSharedSpaceRelationshipPath  = "/v3/routes/{guid}/relationships/shared_spaces/{space_guid}"
+	RouteTransferOwnerPath            = "/v3/routes/{guid}/transfer_owner"
+	DomainRouteReservationsPath       = "/v3/domains/{guid}/route_reservations"
 )
 
 //counterfeiter:generate -o fake -fake-name CFRouteRepository . CFRouteRepository
@@ -32,7 +38,11 @@ type CFRouteRepository interface {
 	DeleteUnmappedRoutes(context.Context, authorization.Info, string) error
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsMessage) (repositories.RouteRecord, error)
 	RemoveDestinationFromRoute(ctx context.Context, authInfo authorization.Info, message repositories.RemoveDestinationMessage) (repositories.RouteRecord, error)
-	PatchRouteMetadata(context.Context, authorization.Info, repositories.PatchRouteMetadataMessage) (repositories.RouteRecord, error)
+	PatchRoute(context.Context, authorization.Info, repositories.PatchRouteMessage) (repositories.RouteRecord, error)
+	ShareRoute(context.Context, authorization.Info, repositories.ShareRouteMessage) (repositories.RouteRecord, error)
+	UnshareRoute(context.Context, authorization.Info, repositories.UnshareRouteMessage) error
+	TransferRouteOwnership(context.Context, authorization.Info, repositories.TransferRouteOwnershipMessage) (repositories.RouteRecord, error)
+	IsRouteReserved(context.Context, authorization.Info, repositories.RouteReservationMessage) (bool, error)
 }
 
 type Route struct {
@@ -174,6 +184,24 @@ func (h *Route) insertDestinations(r *http.Request) (*routing.Response, error) {
 	}
 
 	destinationListCreateMessage := destinationCreatePayload.ToMessage(routeRecord)
+	for i, destination := range destinationListCreateMessage.NewDestinations {
+		// apps in spaces the route is shared with can be destinations too
+		app, err := h.appRepo.GetApp(r.Context(), authInfo, destination.AppGUID)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(
+					err,
+					"App is invalid. Ensure it exists and you have access to it.",
+					apierrors.NotFoundError{},
+					apierrors.ForbiddenError{},
+				),
+				"Failed to fetch destination app",
+				"AppGUID", destination.AppGUID,
+			)
+		}
+		destinationListCreateMessage.NewDestinations[i].AppSpaceGUID = app.SpaceGUID
+	}
 
 	responseRouteRecord, err := h.routeRepo.AddDestinationsToRoute(r.Context(), authInfo, destinationListCreateMessage)
 	if err != nil {
@@ -289,13 +317,167 @@ func (h *Route) update(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
-	route, err = h.routeRepo.PatchRouteMetadata(r.Context(), authInfo, payload.ToMessage(routeGUID, route.SpaceGUID))
+	route, err = h.routeRepo.PatchRoute(r.Context(), authInfo, payload.ToMessage(route))
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch route metadata", "RouteGUID", routeGUID)
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch route", "RouteGUID", routeGUID)
 	}
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoute(route, h.serverURL)), nil
 }
 
+func (h *Route) listSharedSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.list-shared-spaces")
+
+	routeGUID := routing.URLParam(r, "guid")
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteSharedSpaces(route, h.serverURL)), nil
+}
+
+func (h *Route) shareSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.share-spaces")
+
+	routeGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.RouteShareSpaces
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	for _, spaceGUID := range payload.SpaceGUIDs() {
+		if spaceGUID == route.SpaceGUID {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to share route '%s' with space '%s'. Routes cannot be shared into the space where they were created.", route.GUID, spaceGUID)),
+				"Cannot share route into its own space",
+				"RouteGUID", routeGUID,
+				"SpaceGUID", spaceGUID,
+			)
+		}
+
+		if _, err = h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.AsUnprocessableEntity(
+					err,
+					"Invalid space. Ensure that the space exists and you have access to it.",
+					apierrors.NotFoundError{},
+					apierrors.ForbiddenError{},
+				),
+				"Failed to fetch space from Kubernetes",
+				"SpaceGUID", spaceGUID,
+			)
+		}
+	}
+
+	route, err = h.routeRepo.ShareRoute(r.Context(), authInfo, payload.ToMessage(route))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to share route", "RouteGUID", routeGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteSharedSpaces(route, h.serverURL)), nil
+}
+
+func (h *Route) unshareSpace(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.unshare-space")
+
+	routeGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	err = h.routeRepo.UnshareRoute(r.Context(), authInfo, repositories.UnshareRouteMessage{
+		RouteGUID:       route.GUID,
+		SpaceGUID:       route.SpaceGUID,
+		SharedSpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to unshare route", "RouteGUID", routeGUID, "SpaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *Route) transferOwner(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.transfer-owner")
+
+	routeGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.RouteTransferOwner
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, routeGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch route from Kubernetes", "RouteGUID", routeGUID)
+	}
+
+	if _, err = h.spaceRepo.GetSpace(r.Context(), authInfo, payload.Data.GUID); err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Invalid space. Ensure that the space exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Failed to fetch space from Kubernetes",
+			"SpaceGUID", payload.Data.GUID,
+		)
+	}
+
+	_, err = h.routeRepo.TransferRouteOwnership(r.Context(), authInfo, payload.ToMessage(route))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to transfer route ownership", "RouteGUID", routeGUID, "SpaceGUID", payload.Data.GUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *Route) checkReservation(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.route.check-reservation")
+
+	domainGUID := routing.URLParam(r, "guid")
+
+	payload := new(payloads.RouteReservationsGet)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if _, err := h.domainRepo.GetDomain(r.Context(), authInfo, domainGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch domain from Kubernetes", "DomainGUID", domainGUID)
+	}
+
+	// tcp routes are not supported, hence no route can reserve a port
+	if payload.Port != "" {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteReservation(false)), nil
+	}
+
+	reserved, err := h.routeRepo.IsRouteReserved(r.Context(), authInfo, payload.ToMessage(domainGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to check route reservation", "DomainGUID", domainGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteReservation(reserved)), nil
+}
+
 func (h *Route) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -310,5 +492,10 @@ func (h *Route) AuthenticatedRoutes() []routing.Route {
 		{Method: "POST", Pattern: RouteDestinationsPath, Handler: h.insertDestinations},
 		{Method: "DELETE", Pattern: RouteDestinationPath, Handler: h.deleteDestination},
 		{Method: "PATCH", Pattern: RoutePath, Handler: h.update},
+		{Method: "GET", Pattern: RouteSharedSpacesRelationshipPath, Handler: h.listSharedSpaces},
+		{Method: "POST", Pattern: RouteSharedSpacesRelationshipPath, Handler: h.shareSpaces},
+		{Method: "DELETE", Pattern: RouteSharedSpaceRelationshipPath, Handler: h.unshareSpace},
+		{Method: "PATCH", Pattern: RouteTransferOwnerPath, Handler: h.transferOwner},
+		{Method: "GET", Pattern: DomainRouteReservationsPath, Handler: h.checkReservation},
 	}
 }
@@ -373,7 +373,7 @@ var _ = Describe("Route", func() {
 		})
 
 		BeforeEach(func() {
-			routeRepo.PatchRouteMetadataReturns(repositories.RouteRecord{
+			routeRepo.PatchRouteReturns(repositories.RouteRecord{
 				GUID:      "test-route-guid",
 				SpaceGUID: spaceGUID,
 				Labels: map[string]string{
@@ -402,12 +402,13 @@ var _ = Describe("Route", func() {
 			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
 			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
 
-			Expect(routeRepo.PatchRouteMetadataCallCount()).To(Equal(1))
-			_, _, msg := routeRepo.PatchRouteMetadataArgsForCall(0)
+			Expect(routeRepo.PatchRouteCallCount()).To(Equal(1))
+			_, _, msg := routeRepo.PatchRouteArgsForCall(0)
 			Expect(msg.RouteGUID).To(Equal("test-route-guid"))
 			Expect(msg.SpaceGUID).To(Equal(spaceGUID))
 			Expect(msg.Annotations).To(HaveKeyWithValue("a", PointTo(Equal("av"))))
 			Expect(msg.Labels).To(HaveKeyWithValue("l", PointTo(Equal("lv"))))
+			Expect(msg.Options).To(BeNil())
 
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
@@ -417,13 +418,34 @@ var _ = Describe("Route", func() {
 			)))
 		})
 
+		When("the payload sets route options", func() {
+			BeforeEach(func() {
+				routeRecord.Options = &repositories.RouteOptions{LoadBalancing: "least-connection"}
+				routeRepo.GetRouteReturns(routeRecord, nil)
+
+				payload := payloads.RoutePatch{
+					Options: &payloads.RouteOptions{SessionAffinity: tools.PtrTo(true)},
+				}
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
+			})
+
+			It("patches the route options merged with the existing ones", func() {
+				Expect(routeRepo.PatchRouteCallCount()).To(Equal(1))
+				_, _, msg := routeRepo.PatchRouteArgsForCall(0)
+				Expect(msg.Options).To(PointTo(Equal(repositories.RouteOptions{
+					LoadBalancing:   "least-connection",
+					SessionAffinity: true,
+				})))
+			})
+		})
+
 		When("the user doesn't have permission to get the Route", func() {
 			BeforeEach(func() {
 				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
 			})
 
 			It("returns a not found error and doesn't try patching", func() {
-				Expect(routeRepo.PatchRouteMetadataCallCount()).To(Equal(0))
+				Expect(routeRepo.PatchRouteCallCount()).To(Equal(0))
 				expectNotFoundError("Route")
 			})
 		})
@@ -434,14 +456,14 @@ var _ = Describe("Route", func() {
 			})
 
 			It("returns an error and doesn't try patching", func() {
-				Expect(routeRepo.PatchRouteMetadataCallCount()).To(Equal(0))
+				Expect(routeRepo.PatchRouteCallCount()).To(Equal(0))
 				expectUnknownError()
 			})
 		})
 
 		When("patching the Route errors", func() {
 			BeforeEach(func() {
-				routeRepo.PatchRouteMetadataReturns(repositories.RouteRecord{}, errors.New("boom"))
+				routeRepo.PatchRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
 			})
 
 			It("returns an error", func() {
@@ -552,6 +574,8 @@ var _ = Describe("Route", func() {
 				},
 			}
 			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
+
+			appRepo.GetAppReturns(repositories.AppRecord{SpaceGUID: "test-space-guid"}, nil)
 		})
 
 		It("adds the destinations to the route", func() {
@@ -598,6 +622,49 @@ var _ = Describe("Route", func() {
 			)))
 		})
 
+		It("sets the destination app spaces", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(2))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-1-guid"))
+
+			_, _, message := routeRepo.AddDestinationsToRouteArgsForCall(0)
+			Expect(message.NewDestinations).To(HaveEach(MatchFields(IgnoreExtras, Fields{
+				"AppSpaceGUID": Equal("test-space-guid"),
+			})))
+		})
+
+		When("a destination app is in a space the route is shared with", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturnsOnCall(1, repositories.AppRecord{SpaceGUID: "shared-space-guid"}, nil)
+			})
+
+			It("sets the shared space as the destination app space", func() {
+				_, _, message := routeRepo.AddDestinationsToRouteArgsForCall(0)
+				Expect(message.NewDestinations).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"AppGUID":      Equal("app-1-guid"),
+						"AppSpaceGUID": Equal("test-space-guid"),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"AppGUID":      Equal("app-2-guid"),
+						"AppSpaceGUID": Equal("shared-space-guid"),
+					}),
+				))
+			})
+		})
+
+		When("a destination app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error and doesn't add the destinations", func() {
+				Expect(routeRepo.AddDestinationsToRouteCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
 		When("the route doesn't exist", func() {
 			BeforeEach(func() {
 				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
@@ -762,4 +829,327 @@ var _ = Describe("Route", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/routes/:guid/relationships/shared_spaces endpoint", func() {
+		BeforeEach(func() {
+			routeRecord.SharedSpaceGUIDs = []string{"shared-space-guid"}
+			routeRepo.GetRouteReturns(routeRecord, nil)
+
+			requestMethod = http.MethodGet
+			requestPath = "/v3/routes/test-route-guid/relationships/shared_spaces"
+			requestBody = ""
+		})
+
+		It("returns the spaces the route is shared with", func() {
+			Expect(routeRepo.GetRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualRouteGUID := routeRepo.GetRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualRouteGUID).To(Equal("test-route-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", HaveLen(1)),
+				MatchJSONPath("$.data[0].guid", "shared-space-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/routes/test-route-guid/relationships/shared_spaces"),
+			)))
+		})
+
+		When("the route is not accessible", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Route")
+			})
+		})
+	})
+
+	Describe("the POST /v3/routes/:guid/relationships/shared_spaces endpoint", func() {
+		BeforeEach(func() {
+			sharedRoute := routeRecord
+			sharedRoute.SharedSpaceGUIDs = []string{"shared-space-guid"}
+			routeRepo.ShareRouteReturns(sharedRoute, nil)
+
+			requestMethod = http.MethodPost
+			requestPath = "/v3/routes/test-route-guid/relationships/shared_spaces"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.RouteShareSpaces{
+				Data: []payloads.RelationshipData{{GUID: "shared-space-guid"}},
+			})
+		})
+
+		It("shares the route with the spaces", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("shared-space-guid"))
+
+			Expect(routeRepo.ShareRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.ShareRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ShareRouteMessage{
+				RouteGUID:        "test-route-guid",
+				SpaceGUID:        "test-space-guid",
+				SharedSpaceGUIDs: []string{"shared-space-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data", HaveLen(1)),
+				MatchJSONPath("$.data[0].guid", "shared-space-guid"),
+			)))
+		})
+
+		When("the route is shared into its own space", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.RouteShareSpaces{
+					Data: []payloads.RelationshipData{{GUID: "test-space-guid"}},
+				})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(routeRepo.ShareRouteCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Unable to share route 'test-route-guid' with space 'test-space-guid'. Routes cannot be shared into the space where they were created.")
+			})
+		})
+
+		When("the space is not accessible", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(routeRepo.ShareRouteCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Invalid space. Ensure that the space exists and you have access to it.")
+			})
+		})
+
+		When("the route is not accessible", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Route")
+			})
+		})
+
+		When("sharing the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.ShareRouteReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/routes/:guid/relationships/shared_spaces/:space_guid endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/routes/test-route-guid/relationships/shared_spaces/shared-space-guid"
+			requestBody = ""
+		})
+
+		It("unshares the route from the space", func() {
+			Expect(routeRepo.UnshareRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.UnshareRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnshareRouteMessage{
+				RouteGUID:       "test-route-guid",
+				SpaceGUID:       "test-space-guid",
+				SharedSpaceGUID: "shared-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the route is not accessible", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(routeRepo.UnshareRouteCallCount()).To(Equal(0))
+				expectNotFoundError("Route")
+			})
+		})
+
+		When("unsharing the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.UnshareRouteReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/routes/:guid/transfer_owner endpoint", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/routes/test-route-guid/transfer_owner"
+			requestBody = "the-json-body"
+
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.RouteTransferOwner{
+				Data: &payloads.RelationshipData{GUID: "new-space-guid"},
+			})
+		})
+
+		It("transfers the route to the new space", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("new-space-guid"))
+
+			Expect(routeRepo.TransferRouteOwnershipCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.TransferRouteOwnershipArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.TransferRouteOwnershipMessage{
+				RouteGUID:    "test-route-guid",
+				SpaceGUID:    "test-space-guid",
+				NewSpaceGUID: "new-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the new space is not accessible", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(routeRepo.TransferRouteOwnershipCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Invalid space. Ensure that the space exists and you have access to it.")
+			})
+		})
+
+		When("the route is not accessible", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewForbiddenError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Route")
+			})
+		})
+
+		When("transferring the route fails", func() {
+			BeforeEach(func() {
+				routeRepo.TransferRouteOwnershipReturns(repositories.RouteRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/domains/:guid/route_reservations endpoint", func() {
+		var payload *payloads.RouteReservationsGet
+
+		BeforeEach(func() {
+			routeRepo.IsRouteReservedReturns(true, nil)
+
+			payload = &payloads.RouteReservationsGet{
+				Host: "test-route-host",
+				Path: "/some_path",
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(payload)
+
+			requestMethod = http.MethodGet
+			requestPath = "/v3/domains/test-domain-guid/route_reservations?host=test-route-host&path=/some_path"
+			requestBody = ""
+		})
+
+		It("looks up the matching routes", func() {
+			Expect(domainRepo.GetDomainCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualDomainGUID := domainRepo.GetDomainArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualDomainGUID).To(Equal("test-domain-guid"))
+
+			Expect(routeRepo.IsRouteReservedCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := routeRepo.IsRouteReservedArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.RouteReservationMessage{
+				DomainGUID: "test-domain-guid",
+				Host:       "test-route-host",
+				Path:       "/some_path",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"matching_route": true}`)))
+		})
+
+		When("no route matches", func() {
+			BeforeEach(func() {
+				routeRepo.IsRouteReservedReturns(false, nil)
+			})
+
+			It("returns false", func() {
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"matching_route": false}`)))
+			})
+		})
+
+		When("a port is requested", func() {
+			BeforeEach(func() {
+				payload.Port = "1234"
+			})
+
+			It("returns false without looking up routes", func() {
+				Expect(routeRepo.IsRouteReservedCallCount()).To(Equal(0))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"matching_route": false}`)))
+			})
+		})
+
+		When("the domain is not accessible", func() {
+			BeforeEach(func() {
+				domainRepo.GetDomainReturns(repositories.DomainRecord{}, apierrors.NewForbiddenError(nil, repositories.DomainResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Domain")
+			})
+		})
+
+		When("checking the route reservation fails", func() {
+			BeforeEach(func() {
+				routeRepo.IsRouteReservedReturns(false, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -103,6 +103,14 @@ func (h *ServiceBinding) createUserProvided(ctx context.Context, payload *payloa
 		)
 	}
 
+	if payload.Relationships.PreviousBinding != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Binding rotation is not supported for user-provided service instances."),
+			"",
+		)
+	}
+
 	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(serviceInstance.SpaceGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logr.FromContextOrDiscard(ctx), err, "failed to create ServiceBinding")
@@ -115,6 +123,12 @@ func (h *ServiceBinding) createManaged(ctx context.Context, payload *payloads.Se
 	authInfo, _ := authorization.InfoFromContext(ctx)
 	logger := logr.FromContextOrDiscard(ctx).WithName("handlers.service-binding.create-managed")
 
+	if payload.Relationships.PreviousBinding != nil {
+		if err := h.validatePreviousBinding(ctx, payload); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "invalid previous binding", "guid", payload.Relationships.PreviousBinding.Data.GUID)
+		}
+	}
+
 	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(ctx, authInfo, payload.ToMessage(serviceInstance.SpaceGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to create ServiceBinding")
@@ -123,6 +137,35 @@ func (h *ServiceBinding) createManaged(ctx context.Context, payload *payloads.Se
 		WithHeader("Location", presenter.JobURLForRedirects(serviceBinding.GUID, presenter.ManagedServiceBindingCreateOperation, h.serverURL)), nil
 }
 
+// validatePreviousBinding checks that the binding to rotate exists, has not
+// already been rotated and binds the same service instance (and app) as the
+// new binding
+func (h *ServiceBinding) validatePreviousBinding(ctx context.Context, payload *payloads.ServiceBindingCreate) error {
+	authInfo, _ := authorization.InfoFromContext(ctx)
+
+	previousBinding, err := h.serviceBindingRepo.GetServiceBinding(ctx, authInfo, payload.Relationships.PreviousBinding.Data.GUID)
+	if err != nil {
+		return apierrors.AsUnprocessableEntity(err, "The previous binding could not be found.", apierrors.NotFoundError{}, apierrors.ForbiddenError{})
+	}
+
+	if previousBinding.SupersededByGUID != "" || previousBinding.DeletedAt != nil {
+		return apierrors.NewUnprocessableEntityError(nil, "The previous binding has already been superseded or is being deleted.")
+	}
+
+	var appGUID string
+	if payload.Relationships.App != nil {
+		appGUID = payload.Relationships.App.Data.GUID
+	}
+
+	if previousBinding.ServiceInstanceGUID != payload.Relationships.ServiceInstance.Data.GUID ||
+		previousBinding.AppGUID != appGUID ||
+		previousBinding.Type != payload.Type {
+		return apierrors.NewUnprocessableEntityError(nil, "The previous binding must bind the same service instance and app with the same type.")
+	}
+
+	return nil
+}
+
 func (h *ServiceBinding) delete(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-binding.delete")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
@@ -200,6 +201,21 @@ var _ = Describe("ServiceBinding", func() {
 						expectUnknownError()
 					})
 				})
+
+				When("the binding rotates a previous binding", func() {
+					BeforeEach(func() {
+						payload.Relationships.PreviousBinding = &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: "previous-binding-guid",
+							},
+						}
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
+						expectUnprocessableEntityError("Binding rotation is not supported for user-provided service instances.")
+					})
+				})
 			})
 
 			When("binding to a managed service instance", func() {
@@ -238,6 +254,96 @@ var _ = Describe("ServiceBinding", func() {
 						expectUnknownError()
 					})
 				})
+
+				When("the binding rotates a previous binding", func() {
+					BeforeEach(func() {
+						payload.Relationships.PreviousBinding = &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: "previous-binding-guid",
+							},
+						}
+
+						serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{
+							GUID:                "previous-binding-guid",
+							Type:                korifiv1alpha1.CFServiceBindingTypeApp,
+							AppGUID:             "app-guid",
+							ServiceInstanceGUID: "service-instance-guid",
+						}, nil)
+					})
+
+					It("creates a binding rotating the previous binding", func() {
+						Expect(serviceBindingRepo.GetServiceBindingCallCount()).To(Equal(1))
+						_, actualAuthInfo, actualGUID := serviceBindingRepo.GetServiceBindingArgsForCall(0)
+						Expect(actualAuthInfo).To(Equal(authInfo))
+						Expect(actualGUID).To(Equal("previous-binding-guid"))
+
+						Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+						_, _, createServiceBindingMessage := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+						Expect(createServiceBindingMessage.PreviousBindingGUID).To(Equal("previous-binding-guid"))
+						Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+					})
+
+					When("the previous binding does not exist", func() {
+						BeforeEach(func() {
+							serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceBindingResourceType))
+						})
+
+						It("returns an unprocessable entity error", func() {
+							Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
+							expectUnprocessableEntityError("The previous binding could not be found.")
+						})
+					})
+
+					When("the previous binding has already been superseded", func() {
+						BeforeEach(func() {
+							serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{
+								GUID:                "previous-binding-guid",
+								Type:                korifiv1alpha1.CFServiceBindingTypeApp,
+								AppGUID:             "app-guid",
+								ServiceInstanceGUID: "service-instance-guid",
+								SupersededByGUID:    "another-binding-guid",
+							}, nil)
+						})
+
+						It("returns an unprocessable entity error", func() {
+							Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
+							expectUnprocessableEntityError("The previous binding has already been superseded or is being deleted.")
+						})
+					})
+
+					When("the previous binding is being deleted", func() {
+						BeforeEach(func() {
+							serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{
+								GUID:                "previous-binding-guid",
+								Type:                korifiv1alpha1.CFServiceBindingTypeApp,
+								AppGUID:             "app-guid",
+								ServiceInstanceGUID: "service-instance-guid",
+								DeletedAt:           tools.PtrTo(time.Now()),
+							}, nil)
+						})
+
+						It("returns an unprocessable entity error", func() {
+							Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
+							expectUnprocessableEntityError("The previous binding has already been superseded or is being deleted.")
+						})
+					})
+
+					When("the previous binding binds another service instance", func() {
+						BeforeEach(func() {
+							serviceBindingRepo.GetServiceBindingReturns(repositories.ServiceBindingRecord{
+								GUID:                "previous-binding-guid",
+								Type:                korifiv1alpha1.CFServiceBindingTypeApp,
+								AppGUID:             "app-guid",
+								ServiceInstanceGUID: "another-service-instance-guid",
+							}, nil)
+						})
+
+						It("returns an unprocessable entity error", func() {
+							Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(0))
+							expectUnprocessableEntityError("The previous binding must bind the same service instance and app with the same type.")
+						})
+					})
+				})
 			})
 
 			It("gets the app", func() {
@@ -24,6 +24,7 @@ const (
 	ServiceInstancesPath           = "/v3/service_instances"
 	ServiceInstancePath            = "/v3/service_instances/{guid}"
 	ServiceInstanceCredentialsPath = "/v3/service_instances/{guid}/credentials"
+	ServiceInstanceParametersPath  = "/v3/service_instances/{guid}/parameters"
 )
 
 //counterfeiter:generate -o fake -fake-name CFServiceInstanceRepository . CFServiceInstanceRepository
@@ -34,6 +35,7 @@ type CFServiceInstanceRepository interface {
 	ListServiceInstances(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) (repositories.ListResult[repositories.ServiceInstanceRecord], error)
 	GetServiceInstance(context.Context, authorization.Info, string) (repositories.ServiceInstanceRecord, error)
 	GetServiceInstanceCredentials(context.Context, authorization.Info, string) (map[string]any, error)
+	GetServiceInstanceParameters(context.Context, authorization.Info, string) (map[string]any, error)
 	DeleteServiceInstance(context.Context, authorization.Info, repositories.DeleteServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
 }
 
@@ -112,6 +114,33 @@ func (h *ServiceInstance) getCredentials(r *http.Request) (*routing.Response, er
 	return routing.NewResponse(http.StatusOK).WithBody(credentials), nil
 }
 
+func (h *ServiceInstance) getParameters(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get-parameters")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "GUID", serviceInstanceGUID)
+	}
+
+	if serviceInstance.Type != korifiv1alpha1.ManagedType {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "This service instance does not support fetching parameters."),
+			"cannot get parameters of a user-provided service instance", "GUID", serviceInstanceGUID,
+		)
+	}
+
+	parameters, err := h.serviceInstanceRepo.GetServiceInstanceParameters(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get service instance parameters")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(parameters), nil
+}
+
 //nolint:dupl
 func (h *ServiceInstance) create(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
@@ -251,6 +280,7 @@ func (h *ServiceInstance) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: ServiceInstancesPath, Handler: h.list},
 		{Method: "GET", Pattern: ServiceInstancePath, Handler: h.get},
 		{Method: "GET", Pattern: ServiceInstanceCredentialsPath, Handler: h.getCredentials},
+		{Method: "GET", Pattern: ServiceInstanceParametersPath, Handler: h.getParameters},
 		{Method: "DELETE", Pattern: ServiceInstancePath, Handler: h.delete},
 	}
 }
@@ -396,6 +396,75 @@ var _ = Describe("ServiceInstance", func() {
 		})
 	})
 
+	Describe("GET /v3/service_instances/:guid/parameters", func() {
+		BeforeEach(func() {
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID: "service-instance-guid",
+				Type: korifiv1alpha1.ManagedType,
+			}, nil)
+
+			serviceInstanceRepo.GetServiceInstanceParametersReturns(map[string]any{
+				"foo": "bar",
+			}, nil)
+
+			reqPath += "/service-instance-guid/parameters"
+		})
+
+		It("gets the service instance parameters", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualServiceInstanceGUID := serviceInstanceRepo.GetServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualServiceInstanceGUID).To(Equal("service-instance-guid"))
+
+			Expect(serviceInstanceRepo.GetServiceInstanceParametersCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualInstanceGUID := serviceInstanceRepo.GetServiceInstanceParametersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualInstanceGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).Should(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.foo", "bar"),
+			)))
+		})
+
+		When("the service instance is user-provided", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID: "service-instance-guid",
+					Type: korifiv1alpha1.UserProvidedType,
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("This service instance does not support fetching parameters.")
+			})
+		})
+
+		When("getting the service instance fails with forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(
+					repositories.ServiceInstanceRecord{},
+					apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType),
+				)
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("getting the service instance parameters fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(map[string]any{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("the POST /v3/service_instances endpoint", func() {
 		BeforeEach(func() {
 			reqMethod = http.MethodPost
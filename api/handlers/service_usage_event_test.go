@@ -0,0 +1,174 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceUsageEvent", func() {
+	var (
+		usageEventRepo   *fake.ServiceUsageEventRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		usageEventRepo = new(fake.ServiceUsageEventRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewServiceUsageEvent(*serverURL, usageEventRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/service_usage_events", func() {
+		BeforeEach(func() {
+			usageEventRepo.ListServiceUsageEventsReturns(repositories.ListResult[repositories.ServiceUsageEventRecord]{
+				Records: []repositories.ServiceUsageEventRecord{{
+					GUID:      "event-guid",
+					CreatedAt: time.UnixMilli(1000),
+					State:     "CREATED",
+				}},
+				PageInfo: descriptors.PageInfo{
+					TotalResults: 1,
+					TotalPages:   1,
+					PageNumber:   1,
+					PageSize:     50,
+				},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.UsageEventList{
+				AfterGUID: "previous-event-guid",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_usage_events?after_guid=previous-event-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the service usage events after the cursor", func() {
+			Expect(usageEventRepo.ListServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := usageEventRepo.ListServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.AfterGUID).To(Equal("previous-event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "event-guid"),
+				MatchJSONPath("$.resources[0].state", "CREATED"),
+			)))
+		})
+
+		When("the request is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the cursor is not a valid event", func() {
+			BeforeEach(func() {
+				usageEventRepo.ListServiceUsageEventsReturns(repositories.ListResult[repositories.ServiceUsageEventRecord]{},
+					apierrors.NewUnprocessableEntityError(nil, "After guid filter must be a valid service usage event guid."))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("After guid filter must be a valid service usage event guid.")
+			})
+		})
+	})
+
+	Describe("GET /v3/service_usage_events/{guid}", func() {
+		BeforeEach(func() {
+			usageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{
+				GUID:  "event-guid",
+				State: "DELETED",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_usage_events/event-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service usage event", func() {
+			Expect(usageEventRepo.GetServiceUsageEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := usageEventRepo.GetServiceUsageEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.state", "DELETED"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_usage_events/event-guid"),
+			)))
+		})
+
+		When("the event does not exist", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceUsageEventResourceType)
+			})
+		})
+
+		When("the user is not allowed to see the event", func() {
+			BeforeEach(func() {
+				usageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceUsageEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceUsageEventResourceType)
+			})
+		})
+	})
+
+	Describe("POST /v3/service_usage_events/actions/destructively_purge_all_and_reseed", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/service_usage_events/actions/destructively_purge_all_and_reseed", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("purges and reseeds the service usage events", func() {
+			Expect(usageEventRepo.PurgeAndReseedServiceUsageEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo := usageEventRepo.PurgeAndReseedServiceUsageEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+		})
+
+		When("purging fails", func() {
+			BeforeEach(func() {
+				usageEventRepo.PurgeAndReseedServiceUsageEventsReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
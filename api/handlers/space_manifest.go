@@ -4,11 +4,14 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"slices"
 
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
 
 	"github.com/go-logr/logr"
@@ -22,7 +25,10 @@ const (
 type SpaceManifest struct {
 	serverURL        url.URL
 	manifestApplier  ManifestApplier
+	manifestDiffer   ManifestDiffer
 	spaceRepo        CFSpaceRepository
+	appRepo          CFAppRepository
+	stackRepo        LifecycleStackRepository
 	requestValidator RequestValidator
 }
 
@@ -31,16 +37,27 @@ type ManifestApplier interface {
 	Apply(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) error
 }
 
+//counterfeiter:generate -o fake -fake-name ManifestDiffer . ManifestDiffer
+type ManifestDiffer interface {
+	Diff(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) ([]manifest.DiffEntry, error)
+}
+
 func NewSpaceManifest(
 	serverURL url.URL,
 	manifestApplier ManifestApplier,
+	manifestDiffer ManifestDiffer,
 	spaceRepo CFSpaceRepository,
+	appRepo CFAppRepository,
+	stackRepo LifecycleStackRepository,
 	requestValidator RequestValidator,
 ) *SpaceManifest {
 	return &SpaceManifest{
 		serverURL:        serverURL,
 		manifestApplier:  manifestApplier,
+		manifestDiffer:   manifestDiffer,
 		spaceRepo:        spaceRepo,
+		appRepo:          appRepo,
+		stackRepo:        stackRepo,
 		requestValidator: requestValidator,
 	}
 }
@@ -70,8 +87,38 @@ func (h *SpaceManifest) apply(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Error applying manifest")
 	}
 
-	return routing.NewResponse(http.StatusAccepted).
-		WithHeader("Location", presenter.JobURLForRedirects(spaceGUID, presenter.SpaceApplyManifestOperation, h.serverURL)), nil
+	resp := routing.NewResponse(http.StatusAccepted).
+		WithHeader("Location", presenter.JobURLForRedirects(spaceGUID, presenter.SpaceApplyManifestOperation, h.serverURL))
+	for _, warning := range h.stackDeprecationWarnings(r.Context(), logger, authInfo, spaceGUID, manifest) {
+		resp = resp.WithHeader("X-Cf-Warnings", warning)
+	}
+
+	return resp, nil
+}
+
+func (h *SpaceManifest) stackDeprecationWarnings(ctx context.Context, logger logr.Logger, authInfo authorization.Info, spaceGUID string, manifest payloads.Manifest) []string {
+	appNames := []string{}
+	for _, appInfo := range manifest.Applications {
+		appNames = append(appNames, appInfo.Name)
+	}
+
+	apps, err := h.appRepo.ListApps(ctx, authInfo, repositories.ListAppsMessage{
+		Names:      appNames,
+		SpaceGUIDs: []string{spaceGUID},
+	})
+	if err != nil {
+		logger.Info("failed to list the manifest apps, skipping stack deprecation check", "reason", err)
+		return nil
+	}
+
+	warnings := []string{}
+	for _, app := range apps.Records {
+		if warning := stackDeprecationWarning(ctx, logger, h.stackRepo, authInfo, app.Lifecycle); warning != "" && !slices.Contains(warnings, warning) {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return warnings
 }
 
 func (h *SpaceManifest) diff(r *http.Request) (*routing.Response, error) {
@@ -79,10 +126,19 @@ func (h *SpaceManifest) diff(r *http.Request) (*routing.Response, error) {
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-manifest.diff")
 
 	spaceGUID := routing.URLParam(r, "spaceGUID")
+	var manifest payloads.Manifest
+	if err := h.requestValidator.DecodeAndValidateYAMLPayload(r, &manifest); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
 
 	if _, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID); err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "guid", spaceGUID)
 	}
 
-	return routing.NewResponse(http.StatusAccepted).WithBody(map[string]interface{}{"diff": []string{}}), nil
+	diff, err := h.manifestDiffer.Diff(r.Context(), authInfo, spaceGUID, manifest)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to diff manifest", "guid", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithBody(presenter.ForManifestDiff(diff)), nil
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -20,7 +21,10 @@ import (
 var _ = Describe("SpaceManifest", func() {
 	var (
 		manifestApplier  *fake.ManifestApplier
+		manifestDiffer   *fake.ManifestDiffer
 		spaceRepo        *fake.CFSpaceRepository
+		appRepo          *fake.CFAppRepository
+		stackRepo        *fake.LifecycleStackRepository
 		requestValidator *fake.RequestValidator
 		requestMethod    string
 		requestPath      string
@@ -31,13 +35,20 @@ var _ = Describe("SpaceManifest", func() {
 		requestPath = ""
 
 		manifestApplier = new(fake.ManifestApplier)
+		manifestDiffer = new(fake.ManifestDiffer)
 		spaceRepo = new(fake.CFSpaceRepository)
+		appRepo = new(fake.CFAppRepository)
+		stackRepo = new(fake.LifecycleStackRepository)
+		stackRepo.GetStackByNameReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
 		requestValidator = new(fake.RequestValidator)
 
 		apiHandler := NewSpaceManifest(
 			*serverURL,
 			manifestApplier,
+			manifestDiffer,
 			spaceRepo,
+			appRepo,
+			stackRepo,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -121,6 +132,54 @@ var _ = Describe("SpaceManifest", func() {
 			}))
 		})
 
+		It("does not return warnings", func() {
+			Expect(rr.Header().Get("X-Cf-Warnings")).To(BeEmpty())
+		})
+
+		When("an app in the manifest uses a deprecated stack", func() {
+			BeforeEach(func() {
+				appRepo.ListAppsReturns(repositories.ListResult[repositories.AppRecord]{
+					Records: []repositories.AppRecord{{
+						Name: "app1",
+						Lifecycle: repositories.Lifecycle{
+							Type: "buildpack",
+							Data: repositories.LifecycleData{Stack: "old-stack"},
+						},
+					}},
+				}, nil)
+				stackRepo.GetStackByNameReturns(repositories.StackRecord{
+					Name:  "old-stack",
+					State: repositories.StackStateDeprecated,
+				}, nil)
+			})
+
+			It("returns a deprecation warning", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("X-Cf-Warnings", "Stack 'old-stack' is deprecated and will be removed in the future."))
+
+				Expect(appRepo.ListAppsCallCount()).To(Equal(1))
+				_, actualAuthInfo, listMessage := appRepo.ListAppsArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(listMessage.Names).To(ConsistOf("app1"))
+				Expect(listMessage.SpaceGUIDs).To(ConsistOf(spaceGUID))
+
+				Expect(stackRepo.GetStackByNameCallCount()).To(Equal(1))
+				_, _, actualStackName := stackRepo.GetStackByNameArgsForCall(0)
+				Expect(actualStackName).To(Equal("old-stack"))
+			})
+		})
+
+		When("listing the manifest apps fails", func() {
+			BeforeEach(func() {
+				appRepo.ListAppsReturns(repositories.ListResult[repositories.AppRecord]{}, errors.New("list-err"))
+			})
+
+			It("still applies the manifest", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr.Header().Get("X-Cf-Warnings")).To(BeEmpty())
+			})
+		})
+
 		When("the manifest is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateYAMLPayloadReturns(errors.New("boom"))
@@ -135,16 +194,76 @@ var _ = Describe("SpaceManifest", func() {
 	Describe("POST /v3/spaces/{spaceGUID}/manifest_diff", func() {
 		BeforeEach(func() {
 			requestPath = "/v3/spaces/test-space-guid/manifest_diff"
+			requestValidator.DecodeAndValidateYAMLPayloadStub = decodeAndValidatePayloadStub(&payloads.Manifest{
+				Version: 1,
+				Applications: []payloads.ManifestApplication{{
+					Name: "app1",
+					Env:  map[string]string{"FOO": "bar"},
+				}},
+			})
+			manifestDiffer.DiffReturns([]manifest.DiffEntry{{
+				Op:    "replace",
+				Path:  "/applications/0/env/FOO",
+				Was:   "foo",
+				Value: "bar",
+			}}, nil)
 		})
 
-		It("returns 202 with an empty diff", func() {
+		It("returns 202 with the diff", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
-				"diff": []
+				"diff": [{
+					"op": "replace",
+					"path": "/applications/0/env/FOO",
+					"was": "foo",
+					"value": "bar"
+				}]
 			}`)))
 		})
 
+		It("diffs the manifest", func() {
+			Expect(requestValidator.DecodeAndValidateYAMLPayloadCallCount()).To(Equal(1))
+
+			Expect(manifestDiffer.DiffCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID, payload := manifestDiffer.DiffArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("test-space-guid"))
+			Expect(payload.Applications).To(HaveLen(1))
+			Expect(payload.Applications[0].Env).To(Equal(map[string]string{"FOO": "bar"}))
+		})
+
+		When("there are no changes", func() {
+			BeforeEach(func() {
+				manifestDiffer.DiffReturns(nil, nil)
+			})
+
+			It("returns an empty diff", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"diff": []}`)))
+			})
+		})
+
+		When("the manifest is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateYAMLPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("diffing the manifest fails", func() {
+			BeforeEach(func() {
+				manifestDiffer.DiffReturns(nil, errors.New("diff-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
 		When("getting the space errors", func() {
 			BeforeEach(func() {
 				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("foo"))
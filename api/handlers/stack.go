@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
@@ -16,24 +17,33 @@ import (
 
 const (
 	StacksPath = "/v3/stacks"
+	StackPath  = "/v3/stacks/{guid}"
 )
 
+//counterfeiter:generate -o fake -fake-name StackRepository . StackRepository
 type StackRepository interface {
 	ListStacks(ctx context.Context, authInfo authorization.Info) ([]repositories.StackRecord, error)
+	GetStack(ctx context.Context, authInfo authorization.Info, guid string) (repositories.StackRecord, error)
+	CreateStack(ctx context.Context, authInfo authorization.Info, message repositories.CreateStackMessage) (repositories.StackRecord, error)
+	UpdateStack(ctx context.Context, authInfo authorization.Info, message repositories.UpdateStackMessage) (repositories.StackRecord, error)
+	DeleteStack(ctx context.Context, authInfo authorization.Info, guid string) error
 }
 
 type Stack struct {
-	serverURL url.URL
-	stackRepo StackRepository
+	serverURL        url.URL
+	stackRepo        StackRepository
+	requestValidator RequestValidator
 }
 
 func NewStack(
 	serverURL url.URL,
 	stackRepo StackRepository,
+	requestValidator RequestValidator,
 ) *Stack {
 	return &Stack{
-		serverURL: serverURL,
-		stackRepo: stackRepo,
+		serverURL:        serverURL,
+		stackRepo:        stackRepo,
+		requestValidator: requestValidator,
 	}
 }
 
@@ -49,6 +59,79 @@ func (h *Stack) list(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForListDeprecated(presenter.ForStack, stacks, h.serverURL, *r.URL)), nil
 }
 
+func (h *Stack) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.get")
+
+	stackGUID := routing.URLParam(r, "guid")
+
+	stack, err := h.stackRepo.GetStack(r.Context(), authInfo, stackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting stack in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForStack(stack, h.serverURL)), nil
+}
+
+func (h *Stack) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.create")
+
+	var payload payloads.StackCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	stack, err := h.stackRepo.CreateStack(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating stack in repository")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForStack(stack, h.serverURL)), nil
+}
+
+func (h *Stack) update(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.update")
+
+	stackGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.StackUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.stackRepo.GetStack(r.Context(), authInfo, stackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting stack in repository")
+	}
+
+	stack, err := h.stackRepo.UpdateStack(r.Context(), authInfo, payload.ToMessage(stackGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error updating stack in repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForStack(stack, h.serverURL)), nil
+}
+
+func (h *Stack) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.delete")
+
+	stackGUID := routing.URLParam(r, "guid")
+
+	_, err := h.stackRepo.GetStack(r.Context(), authInfo, stackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting stack in repository")
+	}
+
+	if err = h.stackRepo.DeleteStack(r.Context(), authInfo, stackGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error deleting stack in repository")
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
 func (h *Stack) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -56,5 +139,9 @@ func (h *Stack) UnauthenticatedRoutes() []routing.Route {
 func (h *Stack) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: StacksPath, Handler: h.list},
+		{Method: "POST", Pattern: StacksPath, Handler: h.create},
+		{Method: "GET", Pattern: StackPath, Handler: h.get},
+		{Method: "PATCH", Pattern: StackPath, Handler: h.update},
+		{Method: "DELETE", Pattern: StackPath, Handler: h.delete},
 	}
 }
@@ -3,10 +3,13 @@ package handlers_test
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
@@ -17,14 +20,16 @@ import (
 
 var _ = Describe("Stack", func() {
 	var (
-		stackRepo *fake.StackRepository
-		req       *http.Request
+		stackRepo        *fake.StackRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
 	)
 
 	BeforeEach(func() {
 		stackRepo = new(fake.StackRepository)
+		requestValidator = new(fake.RequestValidator)
 
-		apiHandler := NewStack(*serverURL, stackRepo)
+		apiHandler := NewStack(*serverURL, stackRepo, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -71,4 +76,174 @@ var _ = Describe("Stack", func() {
 			})
 		})
 	})
+
+	Describe("the POST /v3/stacks endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.StackCreate{
+				Name:           "cflinuxfs4",
+				Description:    "Jammy",
+				Default:        tools.PtrTo(true),
+				ClusterBuilder: "jammy-builder",
+			})
+
+			stackRepo.CreateStackReturns(repositories.StackRecord{
+				GUID:    "stack-guid",
+				Name:    "cflinuxfs4",
+				Default: true,
+				State:   repositories.StackStateActive,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/stacks", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the stack", func() {
+			Expect(stackRepo.CreateStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := stackRepo.CreateStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateStackMessage{
+				Name:               "cflinuxfs4",
+				Description:        "Jammy",
+				Default:            true,
+				ClusterBuilderName: "jammy-builder",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "stack-guid"),
+				MatchJSONPath("$.default", BeTrue()),
+				MatchJSONPath("$.state", "ACTIVE"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/stacks/stack-guid"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(stackRepo.CreateStackCallCount()).To(BeZero())
+			})
+		})
+
+		When("the user is not authorized to create stacks", func() {
+			BeforeEach(func() {
+				stackRepo.CreateStackReturns(repositories.StackRecord{}, apierrors.NewForbiddenError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/stacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			stackRepo.GetStackReturns(repositories.StackRecord{GUID: "stack-guid", Name: "cflinuxfs4"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/stacks/stack-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the stack", func() {
+			Expect(stackRepo.GetStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := stackRepo.GetStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("stack-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "cflinuxfs4")))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+			})
+		})
+	})
+
+	Describe("the PATCH /v3/stacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.StackUpdate{
+				State: tools.PtrTo(repositories.StackStateDeprecated),
+				Metadata: payloads.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			})
+
+			stackRepo.GetStackReturns(repositories.StackRecord{GUID: "stack-guid"}, nil)
+			stackRepo.UpdateStackReturns(repositories.StackRecord{
+				GUID:  "stack-guid",
+				State: repositories.StackStateDeprecated,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/stacks/stack-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the stack", func() {
+			Expect(stackRepo.UpdateStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, updateMessage := stackRepo.UpdateStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(updateMessage).To(Equal(repositories.UpdateStackMessage{
+				GUID:  "stack-guid",
+				State: tools.PtrTo(repositories.StackStateDeprecated),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.state", "DEPRECATED")))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+				Expect(stackRepo.UpdateStackCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("the DELETE /v3/stacks/:guid endpoint", func() {
+		BeforeEach(func() {
+			stackRepo.GetStackReturns(repositories.StackRecord{GUID: "stack-guid"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/stacks/stack-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the stack", func() {
+			Expect(stackRepo.DeleteStackCallCount()).To(Equal(1))
+			_, _, deletedGUID := stackRepo.DeleteStackArgsForCall(0)
+			Expect(deletedGUID).To(Equal("stack-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+				Expect(stackRepo.DeleteStackCallCount()).To(BeZero())
+			})
+		})
+	})
 })
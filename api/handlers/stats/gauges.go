@@ -22,12 +22,13 @@ import (
 //counterfeiter:generate -o fake -fake-name HttpHandler net/http.Handler
 
 type ProcessGauges struct {
-	Index     int
-	CPU       *float64
-	Mem       *int64
-	Disk      *int64
-	MemQuota  *int64
-	DiskQuota *int64
+	Index        int
+	CPU          *float64
+	Mem          *int64
+	Disk         *int64
+	MemQuota     *int64
+	DiskQuota    *int64
+	LogRateLimit *int64
 }
 
 type LogCacheGaugesCollector struct {
@@ -79,6 +80,7 @@ func (c *LogCacheGaugesCollector) CollectProcessGauges(ctx context.Context, appG
 			stats.Disk = tools.IfNil(stats.Disk, gaugeValueOrNil[int64](metrics["disk"]))
 			stats.MemQuota = tools.IfNil(stats.MemQuota, gaugeValueOrNil[int64](metrics["memory_quota"]))
 			stats.DiskQuota = tools.IfNil(stats.DiskQuota, gaugeValueOrNil[int64](metrics["disk_quota"]))
+			stats.LogRateLimit = tools.IfNil(stats.LogRateLimit, gaugeValueOrNil[int64](metrics["log_rate_limit"]))
 		})
 	}
 
@@ -59,6 +59,10 @@ var _ = Describe("LogCacheGaugesCollector", func() {
 									"unit":  "bytes",
 									"value": 6666,
 								},
+								"log_rate_limit": map[string]any{
+									"unit":  "bytes",
+									"value": 5555,
+								},
 
 								"memory": map[string]any{
 									"unit":  "bytes",
@@ -152,12 +156,13 @@ var _ = Describe("LogCacheGaugesCollector", func() {
 	It("returns the proces stats", func() {
 		Expect(gaugesErr).NotTo(HaveOccurred())
 		Expect(gauges).To(ConsistOf(stats.ProcessGauges{
-			Index:     3,
-			CPU:       tools.PtrTo(1.23),
-			Mem:       tools.PtrTo[int64](7776),
-			Disk:      tools.PtrTo[int64](6665),
-			MemQuota:  tools.PtrTo[int64](7777),
-			DiskQuota: tools.PtrTo[int64](6666),
+			Index:        3,
+			CPU:          tools.PtrTo(1.23),
+			Mem:          tools.PtrTo[int64](7776),
+			Disk:         tools.PtrTo[int64](6665),
+			MemQuota:     tools.PtrTo[int64](7777),
+			DiskQuota:    tools.PtrTo[int64](6666),
+			LogRateLimit: tools.PtrTo[int64](5555),
 		}))
 	})
 
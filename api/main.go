@@ -126,7 +126,7 @@ func main() {
 		panic(fmt.Sprintf("could not create kubernetes REST mapper: %v", err))
 	}
 
-	identityProvider := wireIdentityProvider(k8sClient, k8sClientConfig)
+	identityProvider := wireIdentityProvider(cfg, k8sClient, k8sClientConfig)
 	cachingIdentityProvider := authorization.NewCachingIdentityProvider(identityProvider, cache.NewExpiring())
 	nsPermissions := authorization.NewNamespacePermissions(k8sClient, cachingIdentityProvider)
 
@@ -186,8 +186,12 @@ func main() {
 		spaceScopedKlient,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](conditionTimeout),
 	)
-	dropletRepo := repositories.NewDropletRepo(spaceScopedKlient)
-	routeRepo := repositories.NewRouteRepo(spaceScopedKlient)
+	dropletRepo := repositories.NewDropletRepo(
+		spaceScopedKlient,
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		cfg.ContainerRepositoryPrefix,
+	)
+	routeRepo := repositories.NewRouteRepo(spaceScopedKlient, k8sClient)
 	domainRepo := repositories.NewDomainRepo(
 		rootNSKlient,
 		cfg.RootNamespace,
@@ -217,6 +221,7 @@ func main() {
 	serviceInstanceRepo := repositories.NewServiceInstanceRepo(
 		spaceScopedKlient,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceInstance, korifiv1alpha1.CFServiceInstanceList](conditionTimeout),
+		paramsClient,
 		cfg.RootNamespace,
 	)
 	serviceBindingRepo := repositories.NewServiceBindingRepo(
@@ -235,6 +240,8 @@ func main() {
 		cfg.BuilderName,
 		cfg.RootNamespace,
 		repositories.NewBuildpackSorter(),
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		cfg.ContainerRepositoryPrefix,
 	)
 	roleRepo := repositories.NewRoleRepo(
 		spaceScopedKlient,
@@ -257,18 +264,22 @@ func main() {
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](conditionTimeout),
 	)
 	metricsRepo := repositories.NewMetricsRepo(userClientFactory)
-	serviceBrokerRepo := repositories.NewServiceBrokerRepo(rootNSKlient, cfg.RootNamespace)
+	serviceBrokerRepo := repositories.NewServiceBrokerRepo(rootNSKlient, spaceScopedKlient, cfg.RootNamespace)
 	serviceOfferingRepo := repositories.NewServiceOfferingRepo(rootNSKlient, spaceScopedKlient, cfg.RootNamespace)
-	servicePlanRepo := repositories.NewServicePlanRepo(rootNSKlient, cfg.RootNamespace, orgRepo)
+	servicePlanRepo := repositories.NewServicePlanRepo(rootNSKlient, spaceScopedKlient, cfg.RootNamespace, orgRepo)
 	securityGroupRepo := repositories.NewSecurityGroupRepo(rootNSKlient, cfg.RootNamespace)
+	isolationSegmentRepo := repositories.NewIsolationSegmentRepo(rootNSKlient, spaceScopedKlient, cfg.RootNamespace)
+	autoscalingPolicyRepo := repositories.NewAutoscalingPolicyRepo(spaceScopedKlient)
+	usageEventRepo := repositories.NewUsageEventRepo(rootNSKlient, spaceScopedKlient, cfg.RootNamespace)
 
 	processStats := actions.NewProcessStats(processRepo, appRepo, metricsRepo)
 	manifest := actions.NewManifest(
 		domainRepo,
 		cfg.DefaultDomainName,
-		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewStateCollector(appRepo, domainRepo, processRepo, packageRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
 		manifest.NewNormalizer(cfg.DefaultDomainName),
-		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo),
+		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, serviceInstanceRepo, serviceBindingRepo, stackRepo),
+		manifest.NewDiffer(),
 	)
 
 	requestValidator := validation.NewDefaultDecoderValidator()
@@ -319,7 +330,7 @@ func main() {
 	instancesStateCollector := stats.NewProcessInstanceStateCollector(processRepo)
 	apiHandlers := []routing.Routable{
 		handlers.NewRootV3(*serverURL),
-		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, *logCacheURL),
+		handlers.NewRoot(*serverURL, cfg.Experimental.UAA, cfg.Experimental.OIDC, *logCacheURL),
 		handlers.NewInfoV3(
 			*serverURL,
 			cfg.InfoConfig,
@@ -338,6 +349,7 @@ func main() {
 			podRepo,
 			gaugesCollector,
 			instancesStateCollector,
+			stackRepo,
 		),
 		handlers.NewRoute(
 			*serverURL,
@@ -364,12 +376,17 @@ func main() {
 			buildRepo,
 			packageRepo,
 			appRepo,
+			stackRepo,
 			requestValidator,
 		),
 		handlers.NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
+			stackRepo,
 			requestValidator,
+			cfg.PackageRegistrySecretNames,
 		),
 		handlers.NewProcess(
 			*serverURL,
@@ -394,6 +411,30 @@ func main() {
 		handlers.NewStack(
 			*serverURL,
 			stackRepo,
+			requestValidator,
+		),
+		handlers.NewIsolationSegment(
+			*serverURL,
+			isolationSegmentRepo,
+			orgRepo,
+			spaceRepo,
+			requestValidator,
+		),
+		handlers.NewAutoscalingPolicy(
+			*serverURL,
+			appRepo,
+			autoscalingPolicyRepo,
+			requestValidator,
+		),
+		handlers.NewAppUsageEvent(
+			*serverURL,
+			usageEventRepo,
+			requestValidator,
+		),
+		handlers.NewServiceUsageEvent(
+			*serverURL,
+			usageEventRepo,
+			requestValidator,
 		),
 		handlers.NewJob(
 			*serverURL,
@@ -403,6 +444,7 @@ func main() {
 				handlers.AppDeleteJobType:                    appRepo,
 				handlers.RouteDeleteJobType:                  routeRepo,
 				handlers.DomainDeleteJobType:                 domainRepo,
+				handlers.BuildpackDeleteJobType:              buildpackRepo,
 				handlers.RoleDeleteJobType:                   roleRepo,
 				handlers.ServiceBrokerDeleteJobType:          serviceBrokerRepo,
 				handlers.ManagedServiceInstanceDeleteJobType: serviceInstanceRepo,
@@ -433,10 +475,18 @@ func main() {
 			requestValidator,
 			relationshipsRepo,
 		),
+		handlers.NewAppManifest(
+			*serverURL,
+			appRepo,
+			manifest,
+		),
 		handlers.NewSpaceManifest(
 			*serverURL,
 			manifest,
+			manifest,
 			spaceRepo,
+			appRepo,
+			stackRepo,
 			requestValidator,
 		),
 		handlers.NewRole(
@@ -449,6 +499,7 @@ func main() {
 		handlers.NewBuildpack(
 			*serverURL,
 			buildpackRepo,
+			imageRepo,
 			requestValidator,
 		),
 		handlers.NewServiceInstance(
@@ -565,10 +616,37 @@ func createCertWatcher(envVar string) *certwatcher.CertWatcher {
 	return certWatcher
 }
 
-func wireIdentityProvider(client client.Client, restConfig *rest.Config) authorization.IdentityProvider {
-	tokenReviewer := authorization.NewTokenReviewer(client)
+func wireIdentityProvider(cfg *config.APIConfig, client client.Client, restConfig *rest.Config) authorization.IdentityProvider {
+	var tokenInspector authorization.TokenIdentityInspector = authorization.NewTokenReviewer(client)
 	certInspector := authorization.NewCertInspector(restConfig)
-	return authorization.NewCertTokenIdentityProvider(tokenReviewer, certInspector)
+
+	if cfg.Experimental.OIDC.Enabled {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.Experimental.OIDC.CACert != "" {
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if ok := tlsConfig.RootCAs.AppendCertsFromPEM([]byte(cfg.Experimental.OIDC.CACert)); !ok {
+				panic("could not append the OIDC provider CA cert to the cert pool")
+			}
+		}
+
+		tokenInspector = authorization.NewOIDCTokenInspector(
+			authorization.OIDCConfig{
+				IssuerURL:      cfg.Experimental.OIDC.IssuerURL,
+				ClientID:       cfg.Experimental.OIDC.ClientID,
+				UsernameClaim:  cfg.Experimental.OIDC.UsernameClaim,
+				UsernamePrefix: cfg.Experimental.OIDC.UsernamePrefix,
+				GroupsClaim:    cfg.Experimental.OIDC.GroupsClaim,
+				GroupsPrefix:   cfg.Experimental.OIDC.GroupsPrefix,
+			},
+			&http.Client{
+				Timeout:   30 * time.Second,
+				Transport: &http.Transport{TLSClientConfig: tlsConfig},
+			},
+			tokenInspector,
+		)
+	}
+
+	return authorization.NewCertTokenIdentityProvider(tokenInspector, certInspector)
 }
 
 func wireGaugeCollector(cfg *config.APIConfig) (*url.URL, handlers.GaugesCollector, error) {
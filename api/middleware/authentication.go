@@ -51,12 +51,17 @@ func (a *authentication) middleware(next http.Handler) http.Handler {
 
 		r = r.WithContext(authorization.NewContext(r.Context(), &authInfo))
 
-		_, err = a.identityProvider.GetIdentity(r.Context(), authInfo)
+		identity, err := a.identityProvider.GetIdentity(r.Context(), authInfo)
 		if err != nil {
 			routing.PresentError(logger, w, apierrors.LogAndReturn(logger, err, "failed to get identity"))
 			return
 		}
 
+		authInfo.UserName = identity.Name
+		if identity.Impersonate {
+			authInfo.Impersonation = &identity
+		}
+
 		next.ServeHTTP(w, r)
 	})
 }
@@ -37,7 +37,7 @@ var _ = Describe("Authentication Middleware", func() {
 		authInfoParser.ParseReturns(authorization.Info{Token: "the-token"}, nil)
 
 		identityProvider = new(fake.IdentityProvider)
-		identityProvider.GetIdentityReturns(authorization.Identity{}, nil)
+		identityProvider.GetIdentityReturns(authorization.Identity{Name: "alice"}, nil)
 
 		authMiddleware = middleware.Authentication(
 			authInfoParser,
@@ -66,7 +66,31 @@ var _ = Describe("Authentication Middleware", func() {
 	It("parses the Authorization header into an authorization.Info and injects it in the request context", func() {
 		actualAuthInfo, ok := authorization.InfoFromContext(actualReq.Context())
 		Expect(ok).To(BeTrue())
-		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "the-token"}))
+		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "the-token", UserName: "alice"}))
+	})
+
+	When("the identity must be impersonated", func() {
+		BeforeEach(func() {
+			identityProvider.GetIdentityReturns(authorization.Identity{
+				Name:        "oidc:alice",
+				Kind:        "User",
+				Groups:      []string{"oidc:devs"},
+				Impersonate: true,
+			}, nil)
+		})
+
+		It("adds the identity to the authorization.Info in the request context", func() {
+			actualAuthInfo, ok := authorization.InfoFromContext(actualReq.Context())
+			Expect(ok).To(BeTrue())
+			Expect(actualAuthInfo.Token).To(Equal("the-token"))
+			Expect(actualAuthInfo.UserName).To(Equal("oidc:alice"))
+			Expect(actualAuthInfo.Impersonation).To(Equal(&authorization.Identity{
+				Name:        "oidc:alice",
+				Kind:        "User",
+				Groups:      []string{"oidc:devs"},
+				Impersonate: true,
+			}))
+		})
 	})
 
 	When("parsing the Authorization header fails", func() {
@@ -0,0 +1,292 @@
+package payloads
+
+import (
+	"errors"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	jellidation "github.com/jellydator/validation"
+)
+
+const (
+	defaultBreachDurationSecs    = 120
+	defaultCoolDownSecs          = 300
+	defaultScalingHistoryPerPage = 50
+)
+
+var (
+	adjustmentRegex      = regexp.MustCompile(`^[-+][1-9][0-9]*%?$`)
+	timeOfDayRegex       = regexp.MustCompile(`^([01][0-9]|2[0-3]):[0-5][0-9]$`)
+	dateRegex            = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+	dateTimeRegex        = regexp.MustCompile(`^[0-9]{4}-[0-9]{2}-[0-9]{2}T([01][0-9]|2[0-3]):[0-5][0-9]$`)
+	scalingDurationRules = []jellidation.Rule{jellidation.Min(int32(60)), jellidation.Max(int32(3600))}
+)
+
+// AutoscalingPolicy is the policy document of the App Autoscaler API
+type AutoscalingPolicy struct {
+	InstanceMinCount int32                 `json:"instance_min_count"`
+	InstanceMaxCount int32                 `json:"instance_max_count"`
+	ScalingRules     []AutoscalingRule     `json:"scaling_rules,omitempty"`
+	Schedules        *AutoscalingSchedules `json:"schedules,omitempty"`
+}
+
+func (p AutoscalingPolicy) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.InstanceMinCount, jellidation.Required, jellidation.Min(int32(1))),
+		jellidation.Field(&p.InstanceMaxCount, jellidation.Required, jellidation.Min(p.InstanceMinCount).Error("must not be less than instance_min_count")),
+		jellidation.Field(&p.ScalingRules, jellidation.When(p.Schedules == nil, jellidation.Required.Error("either scaling_rules or schedules must be set"))),
+		jellidation.Field(&p.Schedules),
+	)
+}
+
+func (p AutoscalingPolicy) ToMessage(appGUID, spaceGUID string) repositories.SetAutoscalingPolicyMessage {
+	message := repositories.SetAutoscalingPolicyMessage{
+		AppGUID:          appGUID,
+		SpaceGUID:        spaceGUID,
+		InstanceMinCount: p.InstanceMinCount,
+		InstanceMaxCount: p.InstanceMaxCount,
+		ScalingRules:     slices.Collect(it.Map(slices.Values(p.ScalingRules), AutoscalingRule.toRule)),
+	}
+
+	if p.Schedules != nil {
+		message.Schedules = tools.PtrTo(p.Schedules.toSchedules())
+	}
+
+	return message
+}
+
+type AutoscalingRule struct {
+	MetricType         string `json:"metric_type"`
+	BreachDurationSecs *int32 `json:"breach_duration_secs,omitempty"`
+	Threshold          int64  `json:"threshold"`
+	Operator           string `json:"operator"`
+	CoolDownSecs       *int32 `json:"cool_down_secs,omitempty"`
+	Adjustment         string `json:"adjustment"`
+}
+
+func (r AutoscalingRule) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.MetricType, jellidation.Required, validation.OneOf(
+			string(korifiv1alpha1.AutoscalingMetricCPU),
+			string(korifiv1alpha1.AutoscalingMetricMemoryUsed),
+			string(korifiv1alpha1.AutoscalingMetricMemoryUtil),
+			string(korifiv1alpha1.AutoscalingMetricThroughput),
+		)),
+		jellidation.Field(&r.BreachDurationSecs, scalingDurationRules...),
+		jellidation.Field(&r.Threshold, jellidation.Min(int64(0))),
+		jellidation.Field(&r.Operator, jellidation.Required, validation.OneOf("<", ">", "<=", ">=")),
+		jellidation.Field(&r.CoolDownSecs, scalingDurationRules...),
+		jellidation.Field(&r.Adjustment, jellidation.Required, jellidation.Match(adjustmentRegex)),
+	)
+}
+
+func (r AutoscalingRule) toRule() korifiv1alpha1.ScalingRule {
+	return korifiv1alpha1.ScalingRule{
+		MetricType:            korifiv1alpha1.AutoscalingMetricType(r.MetricType),
+		Threshold:             r.Threshold,
+		Operator:              r.Operator,
+		BreachDurationSeconds: tools.IfZero(tools.ZeroIfNil(r.BreachDurationSecs), defaultBreachDurationSecs),
+		CoolDownSeconds:       tools.IfZero(tools.ZeroIfNil(r.CoolDownSecs), defaultCoolDownSecs),
+		Adjustment:            r.Adjustment,
+	}
+}
+
+type AutoscalingSchedules struct {
+	Timezone          string                         `json:"timezone"`
+	RecurringSchedule []AutoscalingRecurringSchedule `json:"recurring_schedule,omitempty"`
+	SpecificDate      []AutoscalingSpecificDate      `json:"specific_date,omitempty"`
+}
+
+func (s AutoscalingSchedules) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.Timezone, jellidation.Required, jellidation.By(func(value any) error {
+			if _, err := time.LoadLocation(s.Timezone); err != nil {
+				return errors.New("must be a valid IANA time zone")
+			}
+			return nil
+		})),
+		jellidation.Field(&s.RecurringSchedule),
+		jellidation.Field(&s.SpecificDate),
+	)
+}
+
+func (s AutoscalingSchedules) toSchedules() korifiv1alpha1.AutoscalingSchedules {
+	return korifiv1alpha1.AutoscalingSchedules{
+		Timezone:           s.Timezone,
+		RecurringSchedules: slices.Collect(it.Map(slices.Values(s.RecurringSchedule), AutoscalingRecurringSchedule.toSchedule)),
+		SpecificDates:      slices.Collect(it.Map(slices.Values(s.SpecificDate), AutoscalingSpecificDate.toSchedule)),
+	}
+}
+
+type AutoscalingScheduleLimits struct {
+	InstanceMinCount        int32  `json:"instance_min_count"`
+	InstanceMaxCount        int32  `json:"instance_max_count"`
+	InitialMinInstanceCount *int32 `json:"initial_min_instance_count,omitempty"`
+}
+
+func (l AutoscalingScheduleLimits) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.InstanceMinCount, jellidation.Required, jellidation.Min(int32(1))),
+		jellidation.Field(&l.InstanceMaxCount, jellidation.Required, jellidation.Min(l.InstanceMinCount).Error("must not be less than instance_min_count")),
+		jellidation.Field(&l.InitialMinInstanceCount,
+			jellidation.Min(l.InstanceMinCount).Error("must not be less than instance_min_count"),
+			jellidation.Max(l.InstanceMaxCount).Error("must not be greater than instance_max_count"),
+		),
+	)
+}
+
+func (l AutoscalingScheduleLimits) toLimits() korifiv1alpha1.ScheduleLimits {
+	return korifiv1alpha1.ScheduleLimits{
+		InstanceMinCount:        l.InstanceMinCount,
+		InstanceMaxCount:        l.InstanceMaxCount,
+		InitialMinInstanceCount: l.InitialMinInstanceCount,
+	}
+}
+
+type AutoscalingRecurringSchedule struct {
+	AutoscalingScheduleLimits
+	StartTime   string  `json:"start_time"`
+	EndTime     string  `json:"end_time"`
+	DaysOfWeek  []int32 `json:"days_of_week,omitempty"`
+	DaysOfMonth []int32 `json:"days_of_month,omitempty"`
+	StartDate   string  `json:"start_date,omitempty"`
+	EndDate     string  `json:"end_date,omitempty"`
+}
+
+func (s AutoscalingRecurringSchedule) Validate() error {
+	err := jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.StartTime, jellidation.Required, jellidation.Match(timeOfDayRegex)),
+		jellidation.Field(&s.EndTime, jellidation.Required, jellidation.Match(timeOfDayRegex), jellidation.By(func(value any) error {
+			if s.EndTime <= s.StartTime {
+				return errors.New("must be after start_time")
+			}
+			return nil
+		})),
+		jellidation.Field(&s.DaysOfWeek,
+			jellidation.When(len(s.DaysOfMonth) == 0, jellidation.Required.Error("either days_of_week or days_of_month must be set")),
+			jellidation.When(len(s.DaysOfMonth) > 0, jellidation.Empty.Error("cannot be set together with days_of_month")),
+			jellidation.Each(jellidation.Min(int32(1)), jellidation.Max(int32(7))),
+		),
+		jellidation.Field(&s.DaysOfMonth, jellidation.Each(jellidation.Min(int32(1)), jellidation.Max(int32(31)))),
+		jellidation.Field(&s.StartDate, jellidation.Match(dateRegex)),
+		jellidation.Field(&s.EndDate, jellidation.Match(dateRegex)),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.AutoscalingScheduleLimits.Validate()
+}
+
+func (s AutoscalingRecurringSchedule) toSchedule() korifiv1alpha1.RecurringSchedule {
+	return korifiv1alpha1.RecurringSchedule{
+		ScheduleLimits: s.toLimits(),
+		StartTime:      s.StartTime,
+		EndTime:        s.EndTime,
+		DaysOfWeek:     s.DaysOfWeek,
+		DaysOfMonth:    s.DaysOfMonth,
+		StartDate:      s.StartDate,
+		EndDate:        s.EndDate,
+	}
+}
+
+type AutoscalingSpecificDate struct {
+	AutoscalingScheduleLimits
+	StartDateTime string `json:"start_date_time"`
+	EndDateTime   string `json:"end_date_time"`
+}
+
+func (s AutoscalingSpecificDate) Validate() error {
+	err := jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.StartDateTime, jellidation.Required, jellidation.Match(dateTimeRegex)),
+		jellidation.Field(&s.EndDateTime, jellidation.Required, jellidation.Match(dateTimeRegex), jellidation.By(func(value any) error {
+			if s.EndDateTime <= s.StartDateTime {
+				return errors.New("must be after start_date_time")
+			}
+			return nil
+		})),
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.AutoscalingScheduleLimits.Validate()
+}
+
+func (s AutoscalingSpecificDate) toSchedule() korifiv1alpha1.SpecificDateSchedule {
+	return korifiv1alpha1.SpecificDateSchedule{
+		ScheduleLimits: s.toLimits(),
+		StartDateTime:  s.StartDateTime,
+		EndDateTime:    s.EndDateTime,
+	}
+}
+
+// AutoscalingHistoryList filters the scaling history of an app. Times are
+// expressed in nanoseconds since the epoch.
+type AutoscalingHistoryList struct {
+	StartTime      *int64
+	EndTime        *int64
+	OrderDirection string
+	Page           *int64
+	ResultsPerPage *int64
+}
+
+func (l AutoscalingHistoryList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.OrderDirection, validation.OneOf("asc", "desc")),
+		jellidation.Field(&l.Page, jellidation.NilOrNotEmpty, jellidation.Min(int64(1))),
+		jellidation.Field(&l.ResultsPerPage, jellidation.NilOrNotEmpty, jellidation.Min(int64(1))),
+	)
+}
+
+func (l *AutoscalingHistoryList) SupportedKeys() []string {
+	return []string{"start-time", "end-time", "order-direction", "page", "results-per-page"}
+}
+
+func (l *AutoscalingHistoryList) DecodeFromURLValues(values url.Values) error {
+	var err error
+	if l.StartTime, err = getIntPtr(values, "start-time"); err != nil {
+		return err
+	}
+	if l.EndTime, err = getIntPtr(values, "end-time"); err != nil {
+		return err
+	}
+	l.OrderDirection = strings.ToLower(values.Get("order-direction"))
+	if l.Page, err = getIntPtr(values, "page"); err != nil {
+		return err
+	}
+	if l.ResultsPerPage, err = getIntPtr(values, "results-per-page"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (l AutoscalingHistoryList) ToMessage(appGUID, spaceGUID string) repositories.ListScalingHistoryMessage {
+	return repositories.ListScalingHistoryMessage{
+		AppGUID:    appGUID,
+		SpaceGUID:  spaceGUID,
+		StartTime:  unixNanoTime(l.StartTime),
+		EndTime:    unixNanoTime(l.EndTime),
+		Descending: l.OrderDirection != "asc",
+		Pagination: repositories.Pagination{
+			Page:    int(tools.IfZero(tools.ZeroIfNil(l.Page), 1)),
+			PerPage: int(tools.IfZero(tools.ZeroIfNil(l.ResultsPerPage), defaultScalingHistoryPerPage)),
+		},
+	}
+}
+
+func unixNanoTime(nanos *int64) *time.Time {
+	if nanos == nil {
+		return nil
+	}
+
+	return tools.PtrTo(time.Unix(0, *nanos))
+}
@@ -0,0 +1,268 @@
+package payloads_test
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+var _ = Describe("AutoscalingPolicy", func() {
+	var (
+		policy         payloads.AutoscalingPolicy
+		decodedPayload *payloads.AutoscalingPolicy
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.AutoscalingPolicy)
+		policy = payloads.AutoscalingPolicy{
+			InstanceMinCount: 1,
+			InstanceMaxCount: 4,
+			ScalingRules: []payloads.AutoscalingRule{{
+				MetricType:         "cpu",
+				BreachDurationSecs: tools.PtrTo[int32](60),
+				Threshold:          80,
+				Operator:           ">=",
+				Adjustment:         "+1",
+			}},
+			Schedules: &payloads.AutoscalingSchedules{
+				Timezone: "Europe/London",
+				RecurringSchedule: []payloads.AutoscalingRecurringSchedule{{
+					AutoscalingScheduleLimits: payloads.AutoscalingScheduleLimits{
+						InstanceMinCount:        2,
+						InstanceMaxCount:        6,
+						InitialMinInstanceCount: tools.PtrTo[int32](3),
+					},
+					StartTime:  "08:00",
+					EndTime:    "18:00",
+					DaysOfWeek: []int32{1, 2, 3, 4, 5},
+				}},
+				SpecificDate: []payloads.AutoscalingSpecificDate{{
+					AutoscalingScheduleLimits: payloads.AutoscalingScheduleLimits{
+						InstanceMinCount: 5,
+						InstanceMaxCount: 10,
+					},
+					StartDateTime: "2024-12-24T00:00",
+					EndDateTime:   "2024-12-26T23:59",
+				}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(policy), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(policy)))
+	})
+
+	When("instance_max_count is less than instance_min_count", func() {
+		BeforeEach(func() {
+			policy.InstanceMaxCount = 0
+			policy.InstanceMinCount = 2
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("instance_max_count")))
+		})
+	})
+
+	When("there are neither scaling rules nor schedules", func() {
+		BeforeEach(func() {
+			policy.ScalingRules = nil
+			policy.Schedules = nil
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("either scaling_rules or schedules must be set")))
+		})
+	})
+
+	When("a metric type is not supported", func() {
+		BeforeEach(func() {
+			policy.ScalingRules[0].MetricType = "disk"
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("metric_type")))
+		})
+	})
+
+	When("the adjustment is invalid", func() {
+		BeforeEach(func() {
+			policy.ScalingRules[0].Adjustment = "1"
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("adjustment")))
+		})
+	})
+
+	When("the breach duration is out of range", func() {
+		BeforeEach(func() {
+			policy.ScalingRules[0].BreachDurationSecs = tools.PtrTo[int32](30)
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("breach_duration_secs")))
+		})
+	})
+
+	When("the time zone is invalid", func() {
+		BeforeEach(func() {
+			policy.Schedules.Timezone = "Nowhere/Special"
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("must be a valid IANA time zone")))
+		})
+	})
+
+	When("a recurring schedule sets both days of week and days of month", func() {
+		BeforeEach(func() {
+			policy.Schedules.RecurringSchedule[0].DaysOfMonth = []int32{1}
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("cannot be set together with days_of_month")))
+		})
+	})
+
+	When("a recurring schedule ends before it starts", func() {
+		BeforeEach(func() {
+			policy.Schedules.RecurringSchedule[0].EndTime = "07:00"
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("must be after start_time")))
+		})
+	})
+
+	When("the initial minimum instance count exceeds the schedule maximum", func() {
+		BeforeEach(func() {
+			policy.Schedules.RecurringSchedule[0].InitialMinInstanceCount = tools.PtrTo[int32](7)
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("must not be greater than instance_max_count")))
+		})
+	})
+
+	When("a specific date is malformed", func() {
+		BeforeEach(func() {
+			policy.Schedules.SpecificDate[0].StartDateTime = "24/12/2024"
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("start_date_time")))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the policy and applies the rule defaults", func() {
+			Expect(policy.ToMessage("app-guid", "space-guid")).To(gstruct.MatchAllFields(gstruct.Fields{
+				"AppGUID":          Equal("app-guid"),
+				"SpaceGUID":        Equal("space-guid"),
+				"InstanceMinCount": BeEquivalentTo(1),
+				"InstanceMaxCount": BeEquivalentTo(4),
+				"ScalingRules": Equal([]korifiv1alpha1.ScalingRule{{
+					MetricType:            korifiv1alpha1.AutoscalingMetricCPU,
+					Threshold:             80,
+					Operator:              ">=",
+					BreachDurationSeconds: 60,
+					CoolDownSeconds:       300,
+					Adjustment:            "+1",
+				}}),
+				"Schedules": Equal(&korifiv1alpha1.AutoscalingSchedules{
+					Timezone: "Europe/London",
+					RecurringSchedules: []korifiv1alpha1.RecurringSchedule{{
+						ScheduleLimits: korifiv1alpha1.ScheduleLimits{
+							InstanceMinCount:        2,
+							InstanceMaxCount:        6,
+							InitialMinInstanceCount: tools.PtrTo[int32](3),
+						},
+						StartTime:  "08:00",
+						EndTime:    "18:00",
+						DaysOfWeek: []int32{1, 2, 3, 4, 5},
+					}},
+					SpecificDates: []korifiv1alpha1.SpecificDateSchedule{{
+						ScheduleLimits: korifiv1alpha1.ScheduleLimits{
+							InstanceMinCount: 5,
+							InstanceMaxCount: 10,
+						},
+						StartDateTime: "2024-12-24T00:00",
+						EndDateTime:   "2024-12-26T23:59",
+					}},
+				}),
+			}))
+		})
+	})
+})
+
+var _ = Describe("AutoscalingHistoryList", func() {
+	DescribeTable("valid query",
+		func(query string, expected payloads.AutoscalingHistoryList) {
+			actual, decodeErr := decodeQuery[payloads.AutoscalingHistoryList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actual).To(Equal(expected))
+		},
+		Entry("start-time", "start-time=10", payloads.AutoscalingHistoryList{StartTime: tools.PtrTo[int64](10)}),
+		Entry("end-time", "end-time=20", payloads.AutoscalingHistoryList{EndTime: tools.PtrTo[int64](20)}),
+		Entry("order-direction", "order-direction=ASC", payloads.AutoscalingHistoryList{OrderDirection: "asc"}),
+		Entry("page", "page=2", payloads.AutoscalingHistoryList{Page: tools.PtrTo[int64](2)}),
+		Entry("results-per-page", "results-per-page=5", payloads.AutoscalingHistoryList{ResultsPerPage: tools.PtrTo[int64](5)}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AutoscalingHistoryList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid order-direction", "order-direction=sideways", "value must be one of"),
+		Entry("invalid page", "page=0", "cannot be blank"),
+		Entry("invalid results-per-page", "results-per-page=-1", "must be no less than 1"),
+	)
+
+	It("rejects non numeric times", func() {
+		req, err := http.NewRequest("GET", "http://foo.com/bar?start-time=yesterday", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(validator.DecodeAndValidateURLValues(req, new(payloads.AutoscalingHistoryList))).NotTo(Succeed())
+	})
+
+	Describe("ToMessage", func() {
+		It("defaults to the newest events first", func() {
+			Expect(payloads.AutoscalingHistoryList{}.ToMessage("app-guid", "space-guid")).To(Equal(repositories.ListScalingHistoryMessage{
+				AppGUID:    "app-guid",
+				SpaceGUID:  "space-guid",
+				Descending: true,
+				Pagination: repositories.Pagination{Page: 1, PerPage: 50},
+			}))
+		})
+
+		It("converts the filters", func() {
+			message := payloads.AutoscalingHistoryList{
+				StartTime:      tools.PtrTo[int64](1000),
+				EndTime:        tools.PtrTo[int64](2000),
+				OrderDirection: "asc",
+				Page:           tools.PtrTo[int64](2),
+				ResultsPerPage: tools.PtrTo[int64](10),
+			}.ToMessage("app-guid", "space-guid")
+
+			Expect(message.StartTime).To(gstruct.PointTo(BeTemporally("==", time.Unix(0, 1000))))
+			Expect(message.EndTime).To(gstruct.PointTo(BeTemporally("==", time.Unix(0, 2000))))
+			Expect(message.Descending).To(BeFalse())
+			Expect(message.Pagination).To(Equal(repositories.Pagination{Page: 2, PerPage: 10}))
+		})
+	})
+})
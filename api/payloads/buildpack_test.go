@@ -3,9 +3,11 @@ package payloads_test
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
 
 	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 )
 
 var _ = Describe("BuildpackList", func() {
@@ -69,3 +71,138 @@ var _ = Describe("BuildpackList", func() {
 		})
 	})
 })
+
+var _ = Describe("BuildpackCreate", func() {
+	var (
+		createPayload  payloads.BuildpackCreate
+		decodedPayload *payloads.BuildpackCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.BuildpackCreate)
+		createPayload = payloads.BuildpackCreate{
+			Name:     "my-buildpack",
+			Stack:    "cflinuxfs4",
+			Position: tools.PtrTo(2),
+			Enabled:  tools.PtrTo(false),
+			Locked:   tools.PtrTo(true),
+			Metadata: payloads.Metadata{
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"bar": "baz"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("name: cannot be blank")))
+		})
+	})
+
+	When("position is not positive", func() {
+		BeforeEach(func() {
+			createPayload.Position = tools.PtrTo(0)
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("position: must be greater than 0")))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(decodedPayload.ToMessage()).To(Equal(repositories.CreateBuildpackMessage{
+				Name:     "my-buildpack",
+				Stack:    "cflinuxfs4",
+				Position: 2,
+				Enabled:  false,
+				Locked:   true,
+				Metadata: repositories.Metadata{
+					Labels:      map[string]string{"foo": "bar"},
+					Annotations: map[string]string{"bar": "baz"},
+				},
+			}))
+		})
+
+		When("optional fields are not set", func() {
+			BeforeEach(func() {
+				createPayload = payloads.BuildpackCreate{Name: "my-buildpack"}
+			})
+
+			It("defaults them", func() {
+				Expect(decodedPayload.ToMessage()).To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Position": Equal(1),
+					"Enabled":  BeTrue(),
+					"Locked":   BeFalse(),
+				}))
+			})
+		})
+	})
+})
+
+var _ = Describe("BuildpackUpdate", func() {
+	var (
+		updatePayload  payloads.BuildpackUpdate
+		decodedPayload *payloads.BuildpackUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.BuildpackUpdate)
+		updatePayload = payloads.BuildpackUpdate{
+			Name:     tools.PtrTo("new-name"),
+			Position: tools.PtrTo(3),
+			Enabled:  tools.PtrTo(true),
+			Metadata: payloads.MetadataPatch{
+				Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Name = tools.PtrTo("")
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("name: cannot be blank")))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(decodedPayload.ToMessage("bp-guid")).To(Equal(repositories.UpdateBuildpackMessage{
+				GUID:     "bp-guid",
+				Name:     tools.PtrTo("new-name"),
+				Position: tools.PtrTo(3),
+				Enabled:  tools.PtrTo(true),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+		})
+	})
+})
@@ -9,6 +9,53 @@ import (
 	jellidation "github.com/jellydator/validation"
 )
 
+type DropletCreate struct {
+	Relationships *DropletRelationships `json:"relationships"`
+	ProcessTypes  map[string]string     `json:"process_types"`
+	Metadata      Metadata              `json:"metadata"`
+}
+
+func (c DropletCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+		jellidation.Field(&c.Metadata),
+	)
+}
+
+func (c DropletCreate) ToMessage(record repositories.AppRecord) repositories.CreateDropletMessage {
+	return repositories.CreateDropletMessage{
+		AppGUID:      record.GUID,
+		SpaceGUID:    record.SpaceGUID,
+		Lifecycle:    record.Lifecycle,
+		ProcessTypes: c.ProcessTypes,
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+func (c DropletCreate) ToCopyMessage(sourceGUID string, record repositories.AppRecord) repositories.CopyDropletMessage {
+	return repositories.CopyDropletMessage{
+		SourceGUID: sourceGUID,
+		AppGUID:    record.GUID,
+		SpaceGUID:  record.SpaceGUID,
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type DropletRelationships struct {
+	App *Relationship `json:"app"`
+}
+
+func (r DropletRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.App, jellidation.NotNil))
+}
+
 type DropletUpdate struct {
 	Metadata MetadataPatch `json:"metadata"`
 }
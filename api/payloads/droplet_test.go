@@ -64,6 +64,116 @@ var _ = Describe("DropletList", func() {
 	})
 })
 
+var _ = Describe("DropletCreate", func() {
+	var createPayload payloads.DropletCreate
+
+	BeforeEach(func() {
+		createPayload = payloads.DropletCreate{
+			Relationships: &payloads.DropletRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "app-guid",
+					},
+				},
+			},
+			ProcessTypes: map[string]string{
+				"web": "bundle exec rackup",
+			},
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{
+					"foo": "bar",
+				},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		var (
+			decodedPayload *payloads.DropletCreate
+			validatorErr   error
+		)
+
+		BeforeEach(func() {
+			decodedPayload = new(payloads.DropletCreate)
+		})
+
+		JustBeforeEach(func() {
+			validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+		})
+
+		When("relationships are not specified", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "relationships is required")
+			})
+		})
+
+		When("the app relationship is not specified", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.App = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "app is required")
+			})
+		})
+
+		When("metadata.labels contains an invalid key", func() {
+			BeforeEach(func() {
+				createPayload.Metadata.Labels = map[string]string{
+					"foo.cloudfoundry.org/bar": "jim",
+				}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "cannot use the cloudfoundry.org domain")
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("translates to a create message", func() {
+			Expect(createPayload.ToMessage(repositories.AppRecord{
+				GUID:      "app-guid",
+				SpaceGUID: "space-guid",
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			})).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Lifecycle:    repositories.Lifecycle{Type: "buildpack"},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+	})
+
+	Describe("ToCopyMessage", func() {
+		It("translates to a copy message", func() {
+			Expect(createPayload.ToCopyMessage("source-guid", repositories.AppRecord{
+				GUID:      "app-guid",
+				SpaceGUID: "space-guid",
+			})).To(Equal(repositories.CopyDropletMessage{
+				SourceGUID: "source-guid",
+				AppGUID:    "app-guid",
+				SpaceGUID:  "space-guid",
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+	})
+})
+
 var _ = Describe("DropletUpdate", func() {
 	Describe("Decode", func() {
 		var (
@@ -0,0 +1,167 @@
+package payloads
+
+import (
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/BooleanCat/go-functional/v2/it"
+	jellidation "github.com/jellydator/validation"
+)
+
+type IsolationSegmentToleration struct {
+	Key      string `json:"key"`
+	Operator string `json:"operator"`
+	Value    string `json:"value"`
+	Effect   string `json:"effect"`
+}
+
+func (t IsolationSegmentToleration) Validate() error {
+	return jellidation.ValidateStruct(&t,
+		jellidation.Field(&t.Operator, validation.OneOf("Exists", "Equal")),
+		jellidation.Field(&t.Effect, validation.OneOf("NoSchedule", "PreferNoSchedule", "NoExecute")),
+	)
+}
+
+// IsolationSegmentScheduling is a korifi extension that describes the nodes
+// the workloads of the isolation segment are scheduled on
+type IsolationSegmentScheduling struct {
+	NodeSelector     map[string]string            `json:"node_selector"`
+	Tolerations      []IsolationSegmentToleration `json:"tolerations"`
+	RuntimeClassName *string                      `json:"runtime_class_name"`
+}
+
+func (s IsolationSegmentScheduling) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.Tolerations),
+		jellidation.Field(&s.RuntimeClassName, jellidation.NilOrNotEmpty),
+	)
+}
+
+func (s IsolationSegmentScheduling) toRecord() repositories.IsolationSegmentScheduling {
+	return repositories.IsolationSegmentScheduling{
+		NodeSelector: s.NodeSelector,
+		Tolerations: slices.Collect(it.Map(slices.Values(s.Tolerations), func(t IsolationSegmentToleration) repositories.IsolationSegmentToleration {
+			return repositories.IsolationSegmentToleration(t)
+		})),
+		RuntimeClassName: s.RuntimeClassName,
+	}
+}
+
+type IsolationSegmentCreate struct {
+	Name       string                     `json:"name"`
+	Scheduling IsolationSegmentScheduling `json:"scheduling"`
+	Metadata   Metadata                   `json:"metadata"`
+}
+
+func (c IsolationSegmentCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, validation.StrictlyRequired),
+		jellidation.Field(&c.Scheduling),
+		jellidation.Field(&c.Metadata),
+	)
+}
+
+func (c IsolationSegmentCreate) ToMessage() repositories.CreateIsolationSegmentMessage {
+	return repositories.CreateIsolationSegmentMessage{
+		Name:       c.Name,
+		Scheduling: c.Scheduling.toRecord(),
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type IsolationSegmentUpdate struct {
+	Name       *string                     `json:"name"`
+	Scheduling *IsolationSegmentScheduling `json:"scheduling"`
+	Metadata   MetadataPatch               `json:"metadata"`
+}
+
+func (u IsolationSegmentUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.Scheduling),
+		jellidation.Field(&u.Metadata),
+	)
+}
+
+func (u IsolationSegmentUpdate) ToMessage(guid string) repositories.UpdateIsolationSegmentMessage {
+	message := repositories.UpdateIsolationSegmentMessage{
+		GUID: guid,
+		Name: u.Name,
+		MetadataPatch: repositories.MetadataPatch{
+			Labels:      u.Metadata.Labels,
+			Annotations: u.Metadata.Annotations,
+		},
+	}
+
+	if u.Scheduling != nil {
+		scheduling := u.Scheduling.toRecord()
+		message.Scheduling = &scheduling
+	}
+
+	return message
+}
+
+type IsolationSegmentList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+}
+
+func (l *IsolationSegmentList) ToMessage() repositories.ListIsolationSegmentsMessage {
+	return repositories.ListIsolationSegmentsMessage{
+		GUIDs:             parse.ArrayParam(l.GUIDs),
+		Names:             parse.ArrayParam(l.Names),
+		OrganizationGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+	}
+}
+
+func (l *IsolationSegmentList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "order_by", "per_page", "page"}
+}
+
+func (l *IsolationSegmentList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	return nil
+}
+
+type IsolationSegmentEntitleOrgs struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (e IsolationSegmentEntitleOrgs) Validate() error {
+	return jellidation.ValidateStruct(&e,
+		jellidation.Field(&e.Data, jellidation.Required),
+	)
+}
+
+func (e IsolationSegmentEntitleOrgs) OrgGUIDs() []string {
+	return slices.Collect(it.Map(slices.Values(e.Data), func(d RelationshipData) string { return d.GUID }))
+}
+
+// IsolationSegmentAssign is the payload of the org default and space
+// isolation segment relationships. A null data resets the assignment.
+type IsolationSegmentAssign struct {
+	Data *RelationshipData `json:"data"`
+}
+
+func (a IsolationSegmentAssign) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.Data),
+	)
+}
+
+func (a IsolationSegmentAssign) GUID() string {
+	if a.Data == nil {
+		return ""
+	}
+
+	return a.Data.GUID
+}
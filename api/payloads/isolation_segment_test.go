@@ -0,0 +1,167 @@
+package payloads_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+var _ = Describe("IsolationSegmentCreate", func() {
+	var (
+		createPayload  payloads.IsolationSegmentCreate
+		decodedPayload *payloads.IsolationSegmentCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentCreate)
+		createPayload = payloads.IsolationSegmentCreate{
+			Name: "pci",
+			Scheduling: payloads.IsolationSegmentScheduling{
+				NodeSelector: map[string]string{"pool": "pci"},
+				Tolerations: []payloads.IsolationSegmentToleration{{
+					Key:      "dedicated",
+					Operator: "Equal",
+					Value:    "pci",
+					Effect:   "NoSchedule",
+				}},
+				RuntimeClassName: tools.PtrTo("gvisor"),
+			},
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("name: cannot be blank")))
+		})
+	})
+
+	When("a toleration effect is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Scheduling.Tolerations[0].Effect = "Evict"
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("value must be one of: NoSchedule, PreferNoSchedule, NoExecute")))
+		})
+	})
+
+	When("the runtime class name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Scheduling.RuntimeClassName = tools.PtrTo("")
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("runtime_class_name: cannot be blank")))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(decodedPayload.ToMessage()).To(Equal(repositories.CreateIsolationSegmentMessage{
+				Name: "pci",
+				Scheduling: repositories.IsolationSegmentScheduling{
+					NodeSelector: map[string]string{"pool": "pci"},
+					Tolerations: []repositories.IsolationSegmentToleration{{
+						Key:      "dedicated",
+						Operator: "Equal",
+						Value:    "pci",
+						Effect:   "NoSchedule",
+					}},
+					RuntimeClassName: tools.PtrTo("gvisor"),
+				},
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedList payloads.IsolationSegmentList) {
+			actualList, decodeErr := decodeQuery[payloads.IsolationSegmentList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualList).To(Equal(expectedList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.IsolationSegmentList{GUIDs: "g1,g2"}),
+		Entry("names", "names=n1,n2", payloads.IsolationSegmentList{Names: "n1,n2"}),
+		Entry("organization_guids", "organization_guids=o1,o2", payloads.IsolationSegmentList{OrganizationGUIDs: "o1,o2"}),
+	)
+
+	Describe("ToMessage", func() {
+		It("splits the filters", func() {
+			list := payloads.IsolationSegmentList{OrganizationGUIDs: "o1,o2"}
+			Expect(list.ToMessage()).To(Equal(repositories.ListIsolationSegmentsMessage{
+				OrganizationGUIDs: []string{"o1", "o2"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentAssign", func() {
+	var (
+		assignPayload  payloads.IsolationSegmentAssign
+		decodedPayload *payloads.IsolationSegmentAssign
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentAssign)
+		assignPayload = payloads.IsolationSegmentAssign{
+			Data: &payloads.RelationshipData{GUID: "iso-seg-guid"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(assignPayload), decodedPayload)
+	})
+
+	It("returns the isolation segment guid", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.GUID()).To(Equal("iso-seg-guid"))
+	})
+
+	When("data is null", func() {
+		BeforeEach(func() {
+			assignPayload.Data = nil
+		})
+
+		It("returns an empty guid", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.GUID()).To(BeEmpty())
+		})
+	})
+
+	When("the guid is empty", func() {
+		BeforeEach(func() {
+			assignPayload.Data = &payloads.RelationshipData{}
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("guid: cannot be blank")))
+		})
+	})
+})
@@ -21,30 +21,36 @@ type Manifest struct {
 
 type ManifestApplication struct {
 	Name         string            `json:"name" yaml:"name"`
-	Env          map[string]string `yaml:"env"`
-	DefaultRoute bool              `json:"default-route" yaml:"default-route"`
-	RandomRoute  bool              `yaml:"random-route"`
-	NoRoute      bool              `yaml:"no-route"`
-	Command      *string           `yaml:"command"`
-	Instances    *int32            `json:"instances" yaml:"instances"`
-	Memory       *string           `json:"memory" yaml:"memory"`
-	DiskQuota    *string           `json:"disk_quota" yaml:"disk_quota"`
+	Env          map[string]string `yaml:"env,omitempty"`
+	DefaultRoute bool              `json:"default-route" yaml:"default-route,omitempty"`
+	RandomRoute  bool              `yaml:"random-route,omitempty"`
+	NoRoute      bool              `yaml:"no-route,omitempty"`
+	Command      *string           `yaml:"command,omitempty"`
+	Instances    *int32            `json:"instances" yaml:"instances,omitempty"`
+	Memory       *string           `json:"memory" yaml:"memory,omitempty"`
+	DiskQuota    *string           `json:"disk_quota" yaml:"disk_quota,omitempty"`
 	// AltDiskQuota supports `disk-quota` with a hyphen for backwards compatibility.
 	// Do not set both DiskQuota and AltDiskQuota.
 	//
 	// Deprecated: Use DiskQuota instead
-	AltDiskQuota                 *string                      `json:"disk-quota" yaml:"disk-quota"`
-	HealthCheckHTTPEndpoint      *string                      `yaml:"health-check-http-endpoint"`
-	HealthCheckInvocationTimeout *int32                       `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout"`
-	HealthCheckType              *string                      `json:"health-check-type" yaml:"health-check-type"`
-	Timeout                      *int32                       `json:"timeout" yaml:"timeout"`
-	Processes                    []ManifestApplicationProcess `json:"processes" yaml:"processes"`
-	Routes                       []ManifestRoute              `json:"routes" yaml:"routes"`
-	Buildpacks                   []string                     `yaml:"buildpacks"`
+	AltDiskQuota                          *string                      `json:"disk-quota" yaml:"disk-quota,omitempty"`
+	LogRateLimit                          *string                      `json:"log-rate-limit-per-second" yaml:"log-rate-limit-per-second,omitempty"`
+	HealthCheckHTTPEndpoint               *string                      `yaml:"health-check-http-endpoint,omitempty"`
+	HealthCheckInvocationTimeout          *int32                       `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout,omitempty"`
+	HealthCheckType                       *string                      `json:"health-check-type" yaml:"health-check-type,omitempty"`
+	HealthCheckInterval                   *int32                       `json:"health-check-interval" yaml:"health-check-interval,omitempty"`
+	ReadinessHealthCheckHTTPEndpoint      *string                      `json:"readiness-health-check-http-endpoint" yaml:"readiness-health-check-http-endpoint,omitempty"`
+	ReadinessHealthCheckInvocationTimeout *int32                       `json:"readiness-health-check-invocation-timeout" yaml:"readiness-health-check-invocation-timeout,omitempty"`
+	ReadinessHealthCheckInterval          *int32                       `json:"readiness-health-check-interval" yaml:"readiness-health-check-interval,omitempty"`
+	ReadinessHealthCheckType              *string                      `json:"readiness-health-check-type" yaml:"readiness-health-check-type,omitempty"`
+	Timeout                               *int32                       `json:"timeout" yaml:"timeout,omitempty"`
+	Processes                             []ManifestApplicationProcess `json:"processes" yaml:"processes,omitempty"`
+	Routes                                []ManifestRoute              `json:"routes" yaml:"routes,omitempty"`
+	Buildpacks                            []string                     `yaml:"buildpacks,omitempty"`
 	// Deprecated: Use Buildpacks instead
-	Buildpack *string                      `json:"buildpack" yaml:"buildpack"`
-	Metadata  MetadataPatch                `json:"metadata" yaml:"metadata"`
-	Services  []ManifestApplicationService `json:"services" yaml:"services"`
+	Buildpack *string                      `json:"buildpack" yaml:"buildpack,omitempty"`
+	Metadata  MetadataPatch                `json:"metadata" yaml:"metadata,omitempty"`
+	Services  []ManifestApplicationService `json:"services" yaml:"services,omitempty"`
 	Docker    any                          `json:"docker,omitempty" yaml:"docker,omitempty"`
 }
 
@@ -52,25 +58,31 @@ type ManifestApplication struct {
 // it for backwards compatibility?
 type ManifestApplicationProcess struct {
 	Type      string  `json:"type" yaml:"type"`
-	Command   *string `yaml:"command"`
-	DiskQuota *string `json:"disk_quota" yaml:"disk_quota"`
+	Command   *string `yaml:"command,omitempty"`
+	DiskQuota *string `json:"disk_quota" yaml:"disk_quota,omitempty"`
 	// AltDiskQuota supports `disk-quota` with a hyphen for backwards compatibility.
 	// Do not set both DiskQuota and AltDiskQuota.
 	//
 	// Deprecated: Use DiskQuota instead
-	AltDiskQuota                 *string `json:"disk-quota" yaml:"disk-quota"`
-	HealthCheckHTTPEndpoint      *string `yaml:"health-check-http-endpoint"`
-	HealthCheckInvocationTimeout *int32  `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout"`
-	HealthCheckType              *string `json:"health-check-type" yaml:"health-check-type"`
-	Instances                    *int32  `json:"instances" yaml:"instances"`
-	Memory                       *string `json:"memory" yaml:"memory"`
-	Timeout                      *int32  `json:"timeout" yaml:"timeout"`
+	AltDiskQuota                          *string `json:"disk-quota" yaml:"disk-quota,omitempty"`
+	LogRateLimit                          *string `json:"log-rate-limit-per-second" yaml:"log-rate-limit-per-second,omitempty"`
+	HealthCheckHTTPEndpoint               *string `yaml:"health-check-http-endpoint,omitempty"`
+	HealthCheckInvocationTimeout          *int32  `json:"health-check-invocation-timeout" yaml:"health-check-invocation-timeout,omitempty"`
+	HealthCheckType                       *string `json:"health-check-type" yaml:"health-check-type,omitempty"`
+	HealthCheckInterval                   *int32  `json:"health-check-interval" yaml:"health-check-interval,omitempty"`
+	ReadinessHealthCheckHTTPEndpoint      *string `json:"readiness-health-check-http-endpoint" yaml:"readiness-health-check-http-endpoint,omitempty"`
+	ReadinessHealthCheckInvocationTimeout *int32  `json:"readiness-health-check-invocation-timeout" yaml:"readiness-health-check-invocation-timeout,omitempty"`
+	ReadinessHealthCheckInterval          *int32  `json:"readiness-health-check-interval" yaml:"readiness-health-check-interval,omitempty"`
+	ReadinessHealthCheckType              *string `json:"readiness-health-check-type" yaml:"readiness-health-check-type,omitempty"`
+	Instances                             *int32  `json:"instances" yaml:"instances,omitempty"`
+	Memory                                *string `json:"memory" yaml:"memory,omitempty"`
+	Timeout                               *int32  `json:"timeout" yaml:"timeout,omitempty"`
 }
 
 type ManifestApplicationService struct {
 	Name        string         `json:"name" yaml:"name"`
-	BindingName *string        `json:"binding_name" yaml:"binding_name"`
-	Parameters  map[string]any `json:"parameters" yaml:"parameters"`
+	BindingName *string        `json:"binding_name" yaml:"binding_name,omitempty"`
+	Parameters  map[string]any `json:"parameters" yaml:"parameters,omitempty"`
 }
 
 func (s *ManifestApplicationService) UnmarshalYAML(value *yaml.Node) error {
@@ -89,7 +101,8 @@ func (s *ManifestApplicationService) UnmarshalYAML(value *yaml.Node) error {
 }
 
 type ManifestRoute struct {
-	Route *string `json:"route" yaml:"route"`
+	Route    *string `json:"route" yaml:"route,omitempty"`
+	Protocol *string `json:"protocol" yaml:"protocol,omitempty"`
 }
 
 func (a ManifestApplication) ToAppCreateMessage(spaceGUID string) repositories.CreateAppMessage {
@@ -170,6 +183,21 @@ func (p ManifestApplicationProcess) ToProcessCreateMessage(appGUID, spaceGUID st
 			msg.HealthCheck.Type = "process"
 		}
 	}
+	if p.HealthCheckInterval != nil {
+		msg.HealthCheck.Data.IntervalSeconds = *p.HealthCheckInterval
+	}
+	if p.ReadinessHealthCheckType != nil {
+		msg.ReadinessHealthCheck.Type = *p.ReadinessHealthCheckType
+	}
+	if p.ReadinessHealthCheckHTTPEndpoint != nil {
+		msg.ReadinessHealthCheck.Data.HTTPEndpoint = *p.ReadinessHealthCheckHTTPEndpoint
+	}
+	if p.ReadinessHealthCheckInvocationTimeout != nil {
+		msg.ReadinessHealthCheck.Data.InvocationTimeoutSeconds = *p.ReadinessHealthCheckInvocationTimeout
+	}
+	if p.ReadinessHealthCheckInterval != nil {
+		msg.ReadinessHealthCheck.Data.IntervalSeconds = *p.ReadinessHealthCheckInterval
+	}
 	msg.DesiredInstances = p.Instances
 
 	if p.Memory != nil {
@@ -180,18 +208,27 @@ func (p ManifestApplicationProcess) ToProcessCreateMessage(appGUID, spaceGUID st
 		msg.DiskQuotaMB = parseMegabytes(*p.DiskQuota)
 	}
 
+	if p.LogRateLimit != nil {
+		msg.LogRateLimitBytesPerSecond = tools.PtrTo(ParseLogRateLimit(*p.LogRateLimit))
+	}
+
 	return msg
 }
 
 func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID string) repositories.PatchProcessMessage {
 	message := repositories.PatchProcessMessage{
-		ProcessGUID:                         processGUID,
-		SpaceGUID:                           spaceGUID,
-		Command:                             p.Command,
-		HealthCheckHTTPEndpoint:             p.HealthCheckHTTPEndpoint,
-		HealthCheckInvocationTimeoutSeconds: p.HealthCheckInvocationTimeout,
-		HealthCheckTimeoutSeconds:           p.Timeout,
-		DesiredInstances:                    p.Instances,
+		ProcessGUID:                                  processGUID,
+		SpaceGUID:                                    spaceGUID,
+		Command:                                      p.Command,
+		HealthCheckHTTPEndpoint:                      p.HealthCheckHTTPEndpoint,
+		HealthCheckInvocationTimeoutSeconds:          p.HealthCheckInvocationTimeout,
+		HealthCheckTimeoutSeconds:                    p.Timeout,
+		HealthCheckIntervalSeconds:                   p.HealthCheckInterval,
+		ReadinessHealthCheckType:                     p.ReadinessHealthCheckType,
+		ReadinessHealthCheckHTTPEndpoint:             p.ReadinessHealthCheckHTTPEndpoint,
+		ReadinessHealthCheckInvocationTimeoutSeconds: p.ReadinessHealthCheckInvocationTimeout,
+		ReadinessHealthCheckIntervalSeconds:          p.ReadinessHealthCheckInterval,
+		DesiredInstances:                             p.Instances,
 	}
 	if p.HealthCheckType != nil {
 		message.HealthCheckType = p.HealthCheckType
@@ -205,6 +242,9 @@ func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID
 	if p.Memory != nil {
 		message.MemoryMB = tools.PtrTo(parseMegabytes(*p.Memory))
 	}
+	if p.LogRateLimit != nil {
+		message.LogRateLimitBytesPerSecond = tools.PtrTo(ParseLogRateLimit(*p.LogRateLimit))
+	}
 	return message
 }
 
@@ -219,9 +259,14 @@ func (a ManifestApplication) Validate() error {
 		validation.Field(&a.DefaultRoute, validation.When(a.RandomRoute && a.DefaultRoute, validation.Nil.Error("and random-route may not be used together"))),
 		validation.Field(&a.DiskQuota, validation.By(validateAmountWithUnit), validation.When(a.AltDiskQuota != nil, validation.Nil.Error("and disk-quota may not be used together"))),
 		validation.Field(&a.AltDiskQuota, validation.By(validateAmountWithUnit)),
+		validation.Field(&a.LogRateLimit, validation.By(validateLogRateLimit)),
 		validation.Field(&a.Instances, validation.Min(0)),
 		validation.Field(&a.HealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.HealthCheckType, validation.In("none", "process", "port", "http")),
+		validation.Field(&a.HealthCheckInterval, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&a.ReadinessHealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&a.ReadinessHealthCheckInterval, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&a.ReadinessHealthCheckType, validation.In("process", "port", "http")),
 		validation.Field(&a.Memory, validation.By(validateAmountWithUnit)),
 		validation.Field(&a.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.Processes),
@@ -237,8 +282,13 @@ func (p ManifestApplicationProcess) Validate() error {
 		validation.Field(&p.Type, validation.Required),
 		validation.Field(&p.DiskQuota, validation.By(validateAmountWithUnit), validation.When(p.AltDiskQuota != nil, validation.Nil.Error("and disk-quota may not be used together"))),
 		validation.Field(&p.AltDiskQuota, validation.By(validateAmountWithUnit)),
+		validation.Field(&p.LogRateLimit, validation.By(validateLogRateLimit)),
 		validation.Field(&p.HealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&p.HealthCheckType, validation.In("none", "process", "port", "http")),
+		validation.Field(&p.HealthCheckInterval, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&p.ReadinessHealthCheckInvocationTimeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&p.ReadinessHealthCheckInterval, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
+		validation.Field(&p.ReadinessHealthCheckType, validation.In("process", "port", "http")),
 		validation.Field(&p.Instances, validation.Min(0)),
 		validation.Field(&p.Memory, validation.By(validateAmountWithUnit)),
 		validation.Field(&p.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
@@ -250,7 +300,8 @@ func (m ManifestRoute) Validate() error {
 		`^(?:https?://|tcp://)?(?:(?:[\w-]+\.)|(?:[*]\.))+\w+(?:\:\d+)?(?:/.*)*(?:\.\w+)?$`,
 	)
 	return validation.ValidateStruct(&m,
-		validation.Field(&m.Route, validation.Match(routeRegex).Error("is not a valid route")))
+		validation.Field(&m.Route, validation.Match(routeRegex).Error("is not a valid route")),
+		validation.Field(&m.Protocol, validation.In("http1", "http2")))
 }
 
 func (s ManifestApplicationService) Validate() error {
@@ -281,6 +332,39 @@ func validateAmountWithUnit(value any) error {
 	return nil
 }
 
+// unlimitedLogRate is how manifests express the absence of a log rate limit
+const unlimitedLogRate = "-1"
+
+func validateLogRateLimit(value any) error {
+	v, isNil := validation.Indirect(value)
+	if isNil {
+		return nil
+	}
+
+	if v.(string) == unlimitedLogRate || v.(string) == "0" {
+		return nil
+	}
+
+	if !unitAmount.MatchString(v.(string)) {
+		return errors.New("must be -1 or use a supported unit (B, K, KB, M, m, MB, mb, G, g, GB, gb, T, t, TB or tb)")
+	}
+
+	_, err := bytefmt.ToBytes(v.(string))
+	return err
+}
+
+// ParseLogRateLimit converts a manifest log rate limit, such as "16K", into
+// bytes per second. "-1" means unlimited and is returned as is
+func ParseLogRateLimit(s string) int64 {
+	if s == unlimitedLogRate {
+		return -1
+	}
+
+	// error intentionally ignored as the manifest is validated beforehand
+	bytes, _ := bytefmt.ToBytes(s)
+	return int64(bytes) // #nosec G115
+}
+
 func parseMegabytes(s string) int64 {
 	// error intentinally ignored as the manifesst is validated beforehand
 	mb, _ := bytefmt.ToMegabytes(s)
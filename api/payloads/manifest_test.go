@@ -466,6 +466,26 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("the log rate limit doesn't supply a unit", func() {
+				BeforeEach(func() {
+					testManifestProcess.LogRateLimit = tools.PtrTo("1024")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "log-rate-limit-per-second must be -1 or use a supported unit (B, K, KB, M, m, MB, mb, G, g, GB, gb, T, t, TB or tb)")
+				})
+			})
+
+			When("the log rate limit is unlimited", func() {
+				BeforeEach(func() {
+					testManifestProcess.LogRateLimit = tools.PtrTo("-1")
+				})
+
+				It("does not return a validation error", func() {
+					Expect(validateErr).NotTo(HaveOccurred())
+				})
+			})
+
 			When("the alt disk quota doesn't supply a unit", func() {
 				BeforeEach(func() {
 					testManifestProcess.AltDiskQuota = tools.PtrTo("1024")
@@ -517,6 +537,26 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("ReadinessHealthCheckType is invalid", func() {
+				BeforeEach(func() {
+					testManifestProcess.ReadinessHealthCheckType = tools.PtrTo("none")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "readiness-health-check-type must be a valid value")
+				})
+			})
+
+			When("ReadinessHealthCheckInterval is 0", func() {
+				BeforeEach(func() {
+					testManifestProcess.ReadinessHealthCheckInterval = tools.PtrTo(int32(0))
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "readiness-health-check-interval must be no less than 1")
+				})
+			})
+
 			When("Instances is negative", func() {
 				BeforeEach(func() {
 					testManifestProcess.Instances = tools.PtrTo[int32](-1)
@@ -575,15 +615,21 @@ var _ = Describe("Manifest payload", func() {
 			When("all fields are specified", func() {
 				BeforeEach(func() {
 					processInfo = ManifestApplicationProcess{
-						Type:                         "web",
-						Command:                      tools.PtrTo("start-web.sh"),
-						DiskQuota:                    tools.PtrTo("512M"),
-						HealthCheckHTTPEndpoint:      tools.PtrTo("/stuff"),
-						HealthCheckInvocationTimeout: tools.PtrTo(int32(90)),
-						HealthCheckType:              tools.PtrTo("http"),
-						Instances:                    tools.PtrTo[int32](3),
-						Memory:                       tools.PtrTo("1G"),
-						Timeout:                      tools.PtrTo(int32(60)),
+						Type:                                  "web",
+						Command:                               tools.PtrTo("start-web.sh"),
+						DiskQuota:                             tools.PtrTo("512M"),
+						HealthCheckHTTPEndpoint:               tools.PtrTo("/stuff"),
+						HealthCheckInvocationTimeout:          tools.PtrTo(int32(90)),
+						HealthCheckType:                       tools.PtrTo("http"),
+						HealthCheckInterval:                   tools.PtrTo(int32(20)),
+						ReadinessHealthCheckType:              tools.PtrTo("http"),
+						ReadinessHealthCheckHTTPEndpoint:      tools.PtrTo("/ready"),
+						ReadinessHealthCheckInvocationTimeout: tools.PtrTo(int32(5)),
+						ReadinessHealthCheckInterval:          tools.PtrTo(int32(10)),
+						Instances:                             tools.PtrTo[int32](3),
+						Memory:                                tools.PtrTo("1G"),
+						Timeout:                               tools.PtrTo(int32(60)),
+						LogRateLimit:                          tools.PtrTo("16K"),
 					}
 				})
 
@@ -602,10 +648,20 @@ var _ = Describe("Manifest payload", func() {
 								HTTPEndpoint:             "/stuff",
 								TimeoutSeconds:           60,
 								InvocationTimeoutSeconds: 90,
+								IntervalSeconds:          20,
 							},
 						},
-						DesiredInstances: tools.PtrTo[int32](3),
-						MemoryMB:         1024,
+						ReadinessHealthCheck: repositories.ReadinessHealthCheck{
+							Type: "http",
+							Data: repositories.ReadinessHealthCheckData{
+								HTTPEndpoint:             "/ready",
+								InvocationTimeoutSeconds: 5,
+								IntervalSeconds:          10,
+							},
+						},
+						DesiredInstances:           tools.PtrTo[int32](3),
+						MemoryMB:                   1024,
+						LogRateLimitBytesPerSecond: tools.PtrTo[int64](16384),
 					}))
 				})
 
@@ -712,6 +768,18 @@ var _ = Describe("Manifest payload", func() {
 				})
 			})
 
+			When("LogRateLimit is specified", func() {
+				BeforeEach(func() {
+					processInfo.LogRateLimit = tools.PtrTo("-1")
+				})
+
+				It("returns a message with LogRateLimitBytesPerSecond set to the parsed value", func() {
+					Expect(
+						processInfo.ToProcessPatchMessage(processGUID, spaceGUID).LogRateLimitBytesPerSecond,
+					).To(PointTo(BeEquivalentTo(-1)))
+				})
+			})
+
 			When("Memory is specified", func() {
 				BeforeEach(func() {
 					processInfo.Memory = tools.PtrTo("1G")
@@ -778,6 +846,26 @@ var _ = Describe("Manifest payload", func() {
 				expectUnprocessableEntityError(validateErr, "route is not a valid route")
 			})
 		})
+
+		When("the protocol is http2", func() {
+			BeforeEach(func() {
+				testManifestRoute.Protocol = tools.PtrTo("http2")
+			})
+
+			It("validates the struct", func() {
+				Expect(validateErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the protocol is not supported", func() {
+			BeforeEach(func() {
+				testManifestRoute.Protocol = tools.PtrTo("tcp")
+			})
+
+			It("returns a validation error", func() {
+				expectUnprocessableEntityError(validateErr, "protocol must be a valid value")
+			})
+		})
 	})
 
 	Describe("ManifestApplicationService", func() {
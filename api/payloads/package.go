@@ -70,23 +70,44 @@ func (r PackageRelationships) Validate() error {
 }
 
 type PackageUpdate struct {
-	Metadata MetadataPatch `json:"metadata"`
+	Metadata MetadataPatch      `json:"metadata"`
+	Data     *PackageUpdateData `json:"data"`
 }
 
 func (p PackageUpdate) Validate() error {
 	return jellidation.ValidateStruct(&p,
 		jellidation.Field(&p.Metadata),
+		jellidation.Field(&p.Data),
+	)
+}
+
+type PackageUpdateData struct {
+	Username *string `json:"username"`
+	Password *string `json:"password"`
+}
+
+func (d PackageUpdateData) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.Username, jellidation.NotNil),
+		jellidation.Field(&d.Password, jellidation.NotNil),
 	)
 }
 
 func (u *PackageUpdate) ToMessage(packageGUID string) repositories.UpdatePackageMessage {
-	return repositories.UpdatePackageMessage{
+	message := repositories.UpdatePackageMessage{
 		GUID: packageGUID,
 		MetadataPatch: repositories.MetadataPatch{
 			Annotations: u.Metadata.Annotations,
 			Labels:      u.Metadata.Labels,
 		},
 	}
+
+	if u.Data != nil {
+		message.Username = u.Data.Username
+		message.Password = u.Data.Password
+	}
+
+	return message
 }
 
 type PackageList struct {
@@ -282,6 +282,40 @@ var _ = Describe("PackageUpdate", func() {
 				expectUnprocessableEntityError(validatorErr, "label/annotation key cannot use the cloudfoundry.org domain")
 			})
 		})
+
+		When("registry credentials are set", func() {
+			BeforeEach(func() {
+				payload.Data = &payloads.PackageUpdateData{
+					Username: tools.PtrTo("bob"),
+					Password: tools.PtrTo("paSs"),
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+			})
+
+			When("the password is missing", func() {
+				BeforeEach(func() {
+					payload.Data.Password = nil
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "data.password is required")
+				})
+			})
+
+			When("the username is missing", func() {
+				BeforeEach(func() {
+					payload.Data.Username = nil
+				})
+
+				It("returns an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "data.username is required")
+				})
+			})
+		})
 	})
 
 	Describe("ToMessage", func() {
@@ -291,6 +325,23 @@ var _ = Describe("PackageUpdate", func() {
 				"foo": tools.PtrTo("bar"),
 				"bar": nil,
 			}))
+			Expect(msg.Username).To(BeNil())
+			Expect(msg.Password).To(BeNil())
+		})
+
+		When("registry credentials are set", func() {
+			BeforeEach(func() {
+				payload.Data = &payloads.PackageUpdateData{
+					Username: tools.PtrTo("bob"),
+					Password: tools.PtrTo("paSs"),
+				}
+			})
+
+			It("sets them on the message", func() {
+				msg := payload.ToMessage("foo")
+				Expect(msg.Username).To(gstruct.PointTo(Equal("bob")))
+				Expect(msg.Password).To(gstruct.PointTo(Equal("paSs")))
+			})
 		})
 	})
 })
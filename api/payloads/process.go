@@ -10,9 +10,10 @@ import (
 )
 
 type ProcessScale struct {
-	Instances *int32 `json:"instances"`
-	MemoryMB  *int64 `json:"memory_in_mb"`
-	DiskMB    *int64 `json:"disk_in_mb"`
+	Instances    *int32 `json:"instances"`
+	MemoryMB     *int64 `json:"memory_in_mb"`
+	DiskMB       *int64 `json:"disk_in_mb"`
+	LogRateLimit *int64 `json:"log_rate_limit_in_bytes_per_second"`
 }
 
 func (p ProcessScale) Validate() error {
@@ -20,13 +21,15 @@ func (p ProcessScale) Validate() error {
 		jellidation.Field(&p.Instances, jellidation.Min(0).Error("must be 0 or greater")),
 		jellidation.Field(&p.MemoryMB, jellidation.Min(1).Error("must be greater than 0")),
 		jellidation.Field(&p.DiskMB, jellidation.Min(1).Error("must be greater than 0")),
+		jellidation.Field(&p.LogRateLimit, jellidation.Min(-1).Error("must be -1 or greater")),
 	)
 }
 
 type ProcessPatch struct {
-	Metadata    *MetadataPatch `json:"metadata"`
-	Command     *string        `json:"command"`
-	HealthCheck *HealthCheck   `json:"health_check"`
+	Metadata             *MetadataPatch        `json:"metadata"`
+	Command              *string               `json:"command"`
+	HealthCheck          *HealthCheck          `json:"health_check"`
+	ReadinessHealthCheck *ReadinessHealthCheck `json:"readiness_health_check"`
 }
 
 type HealthCheck struct {
@@ -38,13 +41,26 @@ type Data struct {
 	Timeout           *int32  `json:"timeout"`
 	Endpoint          *string `json:"endpoint"`
 	InvocationTimeout *int32  `json:"invocation_timeout"`
+	Interval          *int32  `json:"interval"`
+}
+
+type ReadinessHealthCheck struct {
+	Type *string                   `json:"type"`
+	Data *ReadinessHealthCheckData `json:"data"`
+}
+
+type ReadinessHealthCheckData struct {
+	Endpoint          *string `json:"endpoint"`
+	InvocationTimeout *int32  `json:"invocation_timeout"`
+	Interval          *int32  `json:"interval"`
 }
 
 func (p ProcessScale) ToRecord() repositories.ProcessScaleValues {
 	return repositories.ProcessScaleValues{
-		Instances: p.Instances,
-		MemoryMB:  p.MemoryMB,
-		DiskMB:    p.DiskMB,
+		Instances:                  p.Instances,
+		MemoryMB:                   p.MemoryMB,
+		DiskMB:                     p.DiskMB,
+		LogRateLimitBytesPerSecond: p.LogRateLimit,
 	}
 }
 
@@ -93,6 +109,17 @@ func (p ProcessPatch) ToProcessPatchMessage(processGUID, spaceGUID string) repos
 			message.HealthCheckHTTPEndpoint = p.HealthCheck.Data.Endpoint
 			message.HealthCheckTimeoutSeconds = p.HealthCheck.Data.Timeout
 			message.HealthCheckInvocationTimeoutSeconds = p.HealthCheck.Data.InvocationTimeout
+			message.HealthCheckIntervalSeconds = p.HealthCheck.Data.Interval
+		}
+	}
+
+	if p.ReadinessHealthCheck != nil {
+		message.ReadinessHealthCheckType = p.ReadinessHealthCheck.Type
+
+		if p.ReadinessHealthCheck.Data != nil {
+			message.ReadinessHealthCheckHTTPEndpoint = p.ReadinessHealthCheck.Data.Endpoint
+			message.ReadinessHealthCheckInvocationTimeoutSeconds = p.ReadinessHealthCheck.Data.InvocationTimeout
+			message.ReadinessHealthCheckIntervalSeconds = p.ReadinessHealthCheck.Data.Interval
 		}
 	}
 
@@ -80,9 +80,10 @@ var _ = Describe("Process payload validation", func() {
 
 		BeforeEach(func() {
 			payload = payloads.ProcessScale{
-				Instances: tools.PtrTo[int32](1),
-				MemoryMB:  tools.PtrTo[int64](2),
-				DiskMB:    tools.PtrTo[int64](3),
+				Instances:    tools.PtrTo[int32](1),
+				MemoryMB:     tools.PtrTo[int64](2),
+				DiskMB:       tools.PtrTo[int64](3),
+				LogRateLimit: tools.PtrTo[int64](1024),
 			}
 
 			decodedPayload = new(payloads.ProcessScale)
@@ -126,5 +127,55 @@ var _ = Describe("Process payload validation", func() {
 				expectUnprocessableEntityError(validatorErr, "disk_in_mb must be greater than 0")
 			})
 		})
+
+		When("log rate limit is less than -1", func() {
+			BeforeEach(func() {
+				payload.LogRateLimit = tools.PtrTo[int64](-2)
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError(validatorErr, "log_rate_limit_in_bytes_per_second must be -1 or greater")
+			})
+		})
+	})
+})
+
+var _ = Describe("ProcessPatch", func() {
+	Describe("ToProcessPatchMessage", func() {
+		It("converts the liveness and readiness health checks", func() {
+			message := payloads.ProcessPatch{
+				HealthCheck: &payloads.HealthCheck{
+					Type: tools.PtrTo("http"),
+					Data: &payloads.Data{
+						Timeout:           tools.PtrTo[int32](60),
+						Endpoint:          tools.PtrTo("/healthz"),
+						InvocationTimeout: tools.PtrTo[int32](2),
+						Interval:          tools.PtrTo[int32](15),
+					},
+				},
+				ReadinessHealthCheck: &payloads.ReadinessHealthCheck{
+					Type: tools.PtrTo("http"),
+					Data: &payloads.ReadinessHealthCheckData{
+						Endpoint:          tools.PtrTo("/ready"),
+						InvocationTimeout: tools.PtrTo[int32](3),
+						Interval:          tools.PtrTo[int32](5),
+					},
+				},
+			}.ToProcessPatchMessage("process-guid", "space-guid")
+
+			Expect(message).To(Equal(repositories.PatchProcessMessage{
+				ProcessGUID:                                  "process-guid",
+				SpaceGUID:                                    "space-guid",
+				HealthCheckType:                              tools.PtrTo("http"),
+				HealthCheckHTTPEndpoint:                      tools.PtrTo("/healthz"),
+				HealthCheckTimeoutSeconds:                    tools.PtrTo[int32](60),
+				HealthCheckInvocationTimeoutSeconds:          tools.PtrTo[int32](2),
+				HealthCheckIntervalSeconds:                   tools.PtrTo[int32](15),
+				ReadinessHealthCheckType:                     tools.PtrTo("http"),
+				ReadinessHealthCheckHTTPEndpoint:             tools.PtrTo("/ready"),
+				ReadinessHealthCheckInvocationTimeoutSeconds: tools.PtrTo[int32](3),
+				ReadinessHealthCheckIntervalSeconds:          tools.PtrTo[int32](5),
+			}))
+		})
 	})
 })
@@ -62,6 +62,18 @@ func (p RoleCreate) ToMessage() repositories.CreateRoleMessage {
 		message.Org = p.Relationships.Organization.Data.GUID
 	}
 
+	if p.Relationships.Group != nil {
+		message.Kind = rbacv1.GroupKind
+		message.User = p.Relationships.Group.Data.Name
+
+		// Group names are prefixed with the origin in the same way as user names
+		if p.Relationships.Group.Data.Origin != "" {
+			message.User = p.Relationships.Group.Data.Origin + ":" + message.User
+		}
+
+		return message
+	}
+
 	message.Kind = rbacv1.UserKind
 	message.User = p.Relationships.User.Data.Username
 
@@ -89,16 +101,21 @@ func (p RoleCreate) ToMessage() repositories.CreateRoleMessage {
 }
 
 type RoleRelationships struct {
-	User         UserRelationship `json:"user"`
-	Space        *Relationship    `json:"space"`
-	Organization *Relationship    `json:"organization"`
+	User         UserRelationship   `json:"user"`
+	Group        *GroupRelationship `json:"group"`
+	Space        *Relationship      `json:"space"`
+	Organization *Relationship      `json:"organization"`
 }
 
 func (r RoleRelationships) ValidateWithContext(ctx context.Context) error {
 	roleType := ctx.Value(typeKey)
 
 	return jellidation.ValidateStruct(&r,
-		jellidation.Field(&r.User, validation.StrictlyRequired),
+		jellidation.Field(&r.User, jellidation.When(r.Group == nil, validation.StrictlyRequired)),
+
+		jellidation.Field(&r.Group,
+			jellidation.When(r.User != UserRelationship{},
+				jellidation.Nil.Error("cannot pass both 'user' and 'group' in a create role request"))),
 
 		jellidation.Field(&r.Space,
 			jellidation.When(r.Organization != nil,
@@ -130,12 +147,34 @@ type UserRelationshipData struct {
 	Origin   string `json:"origin"`
 }
 
+type GroupRelationship struct {
+	Data GroupRelationshipData `json:"data"`
+}
+
+func (r GroupRelationship) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Data, validation.StrictlyRequired),
+	)
+}
+
+type GroupRelationshipData struct {
+	Name   string `json:"name"`
+	Origin string `json:"origin"`
+}
+
+func (d GroupRelationshipData) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.Name, validation.StrictlyRequired),
+	)
+}
+
 type RoleList struct {
 	GUIDs      string
 	Types      string
 	SpaceGUIDs string
 	OrgGUIDs   string
 	UserGUIDs  string
+	GroupNames string
 	OrderBy    string
 	Pagination Pagination
 }
@@ -147,13 +186,14 @@ func (r RoleList) ToMessage() repositories.ListRolesMessage {
 		SpaceGUIDs: parse.ArrayParam(r.SpaceGUIDs),
 		OrgGUIDs:   parse.ArrayParam(r.OrgGUIDs),
 		UserGUIDs:  parse.ArrayParam(r.UserGUIDs),
+		GroupNames: parse.ArrayParam(r.GroupNames),
 		OrderBy:    r.OrderBy,
 		Pagination: r.Pagination.ToMessage(DefaultPageSize),
 	}
 }
 
 func (r RoleList) SupportedKeys() []string {
-	return []string{"guids", "types", "space_guids", "organization_guids", "user_guids", "order_by", "include", "per_page", "page"}
+	return []string{"guids", "types", "space_guids", "organization_guids", "user_guids", "group_names", "order_by", "include", "per_page", "page"}
 }
 
 func (r *RoleList) DecodeFromURLValues(values url.Values) error {
@@ -162,6 +202,7 @@ func (r *RoleList) DecodeFromURLValues(values url.Values) error {
 	r.SpaceGUIDs = values.Get("space_guids")
 	r.OrgGUIDs = values.Get("organization_guids")
 	r.UserGUIDs = values.Get("user_guids")
+	r.GroupNames = values.Get("group_names")
 	r.OrderBy = values.Get("order_by")
 	return r.Pagination.DecodeFromURLValues(values)
 }
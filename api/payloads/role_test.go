@@ -116,6 +116,52 @@ var _ = Describe("RoleCreate", func() {
 		})
 	})
 
+	When("a group is provided instead of a user", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.User = payloads.UserRelationship{}
+			createPayload.Relationships.Group = &payloads.GroupRelationship{
+				Data: payloads.GroupRelationshipData{
+					Name:   "devs",
+					Origin: "corp",
+				},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(roleCreate).To(PointTo(Equal(createPayload)))
+		})
+
+		It("converts to a group role message", func() {
+			msg := roleCreate.ToMessage()
+			Expect(msg.Kind).To(Equal(rbacv1.GroupKind))
+			Expect(msg.User).To(Equal("corp:devs"))
+			Expect(msg.Space).To(Equal("cf-space-guid"))
+		})
+
+		When("the group name is missing", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.Group.Data.Name = ""
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("name cannot be blank"))
+			})
+		})
+
+		When("the user is provided as well", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.User.Data.Username = "bob"
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("cannot pass both 'user' and 'group' in a create role request"))
+			})
+		})
+	})
+
 	When("the service account name is provided", func() {
 		BeforeEach(func() {
 			createPayload.Relationships.User.Data.Username = "system:serviceaccount:cf-space-guid:cf-service-account"
@@ -208,6 +254,7 @@ var _ = Describe("RoleList", func() {
 		Entry("space_guids", "space_guids=s1,s2", payloads.RoleList{SpaceGUIDs: "s1,s2"}),
 		Entry("organization_guids", "organization_guids=o1,o2", payloads.RoleList{OrgGUIDs: "o1,o2"}),
 		Entry("user_guids", "user_guids=u1,u2", payloads.RoleList{UserGUIDs: "u1,u2"}),
+		Entry("group_names", "group_names=g1,g2", payloads.RoleList{GroupNames: "g1,g2"}),
 		Entry("order_by1", "order_by=created_at", payloads.RoleList{OrderBy: "created_at"}),
 		Entry("order_by2", "order_by=-created_at", payloads.RoleList{OrderBy: "-created_at"}),
 		Entry("order_by3", "order_by=updated_at", payloads.RoleList{OrderBy: "updated_at"}),
@@ -238,6 +285,7 @@ var _ = Describe("RoleList", func() {
 				SpaceGUIDs: "space1,space2",
 				OrgGUIDs:   "org1,org2",
 				UserGUIDs:  "user1,user2",
+				GroupNames: "group1,group2",
 				OrderBy:    "created_at",
 				Pagination: payloads.Pagination{
 					PerPage: "10",
@@ -257,6 +305,7 @@ var _ = Describe("RoleList", func() {
 				SpaceGUIDs: []string{"space1", "space2"},
 				OrgGUIDs:   []string{"org1", "org2"},
 				UserGUIDs:  []string{"user1", "user2"},
+				GroupNames: []string{"group1", "group2"},
 				OrderBy:    "created_at",
 				Pagination: repositories.Pagination{
 					PerPage: 10,
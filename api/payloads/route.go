@@ -2,11 +2,14 @@ package payloads
 
 import (
 	"net/url"
+	"slices"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
 	jellidation "github.com/jellydator/validation"
 )
 
@@ -15,6 +18,7 @@ type RouteCreate struct {
 	Path          string              `json:"path"`
 	Relationships *RouteRelationships `json:"relationships"`
 	Metadata      Metadata            `json:"metadata"`
+	Options       *RouteOptions       `json:"options"`
 }
 
 func (p RouteCreate) Validate() error {
@@ -22,9 +26,80 @@ func (p RouteCreate) Validate() error {
 		jellidation.Field(&p.Host, jellidation.Required),
 		jellidation.Field(&p.Relationships, jellidation.NotNil),
 		jellidation.Field(&p.Metadata),
+		jellidation.Field(&p.Options),
 	)
 }
 
+type RouteOptions struct {
+	LoadBalancing   *string `json:"loadbalancing"`
+	HashHeader      *string `json:"hash_header"`
+	RequestTimeout  *int32  `json:"request_timeout"`
+	SessionAffinity *bool   `json:"session_affinity"`
+}
+
+func (o RouteOptions) Validate() error {
+	return jellidation.ValidateStruct(&o,
+		jellidation.Field(&o.LoadBalancing, validation.OneOf(
+			korifiv1alpha1.LoadBalancingRoundRobin,
+			korifiv1alpha1.LoadBalancingLeastConnection,
+			korifiv1alpha1.LoadBalancingHash,
+		)),
+		jellidation.Field(&o.HashHeader, jellidation.When(
+			tools.ZeroIfNil(o.LoadBalancing) == korifiv1alpha1.LoadBalancingHash,
+			jellidation.Required,
+		)),
+		jellidation.Field(&o.RequestTimeout, jellidation.NilOrNotEmpty, jellidation.Min(int32(1))),
+	)
+}
+
+func (o *RouteOptions) toRepoOptions() *repositories.RouteOptions {
+	if o == nil {
+		return nil
+	}
+
+	return &repositories.RouteOptions{
+		LoadBalancing:         tools.ZeroIfNil(o.LoadBalancing),
+		HashHeader:            tools.ZeroIfNil(o.HashHeader),
+		RequestTimeoutSeconds: o.RequestTimeout,
+		SessionAffinity:       tools.ZeroIfNil(o.SessionAffinity),
+	}
+}
+
+// mergeInto returns the existing route options updated with the options set
+// in the payload. The hash header is dropped when the load balancing
+// algorithm is no longer hash, unless set in the payload
+func (o *RouteOptions) mergeInto(existing *repositories.RouteOptions) *repositories.RouteOptions {
+	if o == nil {
+		return nil
+	}
+
+	merged := repositories.RouteOptions{}
+	if existing != nil {
+		merged = *existing
+	}
+
+	if o.LoadBalancing != nil {
+		merged.LoadBalancing = *o.LoadBalancing
+		if merged.LoadBalancing != korifiv1alpha1.LoadBalancingHash {
+			merged.HashHeader = ""
+		}
+	}
+
+	if o.HashHeader != nil {
+		merged.HashHeader = *o.HashHeader
+	}
+
+	if o.RequestTimeout != nil {
+		merged.RequestTimeoutSeconds = o.RequestTimeout
+	}
+
+	if o.SessionAffinity != nil {
+		merged.SessionAffinity = *o.SessionAffinity
+	}
+
+	return &merged
+}
+
 func (p RouteCreate) ToMessage(domainNamespace, domainName string) repositories.CreateRouteMessage {
 	return repositories.CreateRouteMessage{
 		Host:            p.Host,
@@ -35,6 +110,7 @@ func (p RouteCreate) ToMessage(domainNamespace, domainName string) repositories.
 		DomainName:      domainName,
 		Labels:          p.Metadata.Labels,
 		Annotations:     p.Metadata.Annotations,
+		Options:         p.Options.toRepoOptions(),
 	}
 }
 
@@ -95,22 +171,25 @@ func (p RouteList) Validate() error {
 
 type RoutePatch struct {
 	Metadata MetadataPatch `json:"metadata"`
+	Options  *RouteOptions `json:"options"`
 }
 
 func (p RoutePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
 		jellidation.Field(&p.Metadata),
+		jellidation.Field(&p.Options),
 	)
 }
 
-func (p RoutePatch) ToMessage(routeGUID, spaceGUID string) repositories.PatchRouteMetadataMessage {
-	return repositories.PatchRouteMetadataMessage{
-		RouteGUID: routeGUID,
-		SpaceGUID: spaceGUID,
+func (p RoutePatch) ToMessage(route repositories.RouteRecord) repositories.PatchRouteMessage {
+	return repositories.PatchRouteMessage{
+		RouteGUID: route.GUID,
+		SpaceGUID: route.SpaceGUID,
 		MetadataPatch: repositories.MetadataPatch{
 			Annotations: p.Metadata.Annotations,
 			Labels:      p.Metadata.Labels,
 		},
+		Options: p.Options.mergeInto(route.Options),
 	}
 }
 
@@ -133,7 +212,7 @@ type RouteDestination struct {
 func (r RouteDestination) Validate() error {
 	return jellidation.ValidateStruct(&r,
 		jellidation.Field(&r.App),
-		jellidation.Field(&r.Protocol, validation.OneOf("http1")),
+		jellidation.Field(&r.Protocol, validation.OneOf("http1", "http2")),
 	)
 }
 
@@ -181,3 +260,76 @@ func (dc RouteDestinationCreate) ToMessage(routeRecord repositories.RouteRecord)
 		NewDestinations:      addDestinations,
 	}
 }
+
+type RouteReservationsGet struct {
+	Host string
+	Path string
+	Port string
+}
+
+func (p RouteReservationsGet) SupportedKeys() []string {
+	return []string{"host", "path", "port"}
+}
+
+func (p *RouteReservationsGet) DecodeFromURLValues(values url.Values) error {
+	p.Host = values.Get("host")
+	p.Path = values.Get("path")
+	p.Port = values.Get("port")
+	return nil
+}
+
+func (p RouteReservationsGet) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Port, jellidation.When(p.Port != "",
+			jellidation.By(validation.IntegerMatching(jellidation.Min(1), jellidation.Max(65535)))),
+		),
+	)
+}
+
+func (p RouteReservationsGet) ToMessage(domainGUID string) repositories.RouteReservationMessage {
+	return repositories.RouteReservationMessage{
+		DomainGUID: domainGUID,
+		Host:       p.Host,
+		Path:       p.Path,
+	}
+}
+
+type RouteShareSpaces struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (p RouteShareSpaces) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Data, jellidation.Required),
+	)
+}
+
+func (p RouteShareSpaces) SpaceGUIDs() []string {
+	return slices.Collect(it.Map(slices.Values(p.Data), func(d RelationshipData) string { return d.GUID }))
+}
+
+func (p RouteShareSpaces) ToMessage(routeRecord repositories.RouteRecord) repositories.ShareRouteMessage {
+	return repositories.ShareRouteMessage{
+		RouteGUID:        routeRecord.GUID,
+		SpaceGUID:        routeRecord.SpaceGUID,
+		SharedSpaceGUIDs: p.SpaceGUIDs(),
+	}
+}
+
+type RouteTransferOwner struct {
+	Data *RelationshipData `json:"data"`
+}
+
+func (p RouteTransferOwner) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Data, jellidation.NotNil),
+	)
+}
+
+func (p RouteTransferOwner) ToMessage(routeRecord repositories.RouteRecord) repositories.TransferRouteOwnershipMessage {
+	return repositories.TransferRouteOwnershipMessage{
+		RouteGUID:    routeRecord.GUID,
+		SpaceGUID:    routeRecord.SpaceGUID,
+		NewSpaceGUID: p.Data.GUID,
+	}
+}
@@ -180,6 +180,63 @@ var _ = Describe("RouteCreate", func() {
 			Expect(apiError.Detail()).To(ContainSubstring("cannot use the cloudfoundry.org domain"))
 		})
 	})
+
+	When("options are set", func() {
+		BeforeEach(func() {
+			createPayload.Options = &payloads.RouteOptions{
+				LoadBalancing:   tools.PtrTo("hash"),
+				HashHeader:      tools.PtrTo("X-Tenant"),
+				RequestTimeout:  tools.PtrTo[int32](30),
+				SessionAffinity: tools.PtrTo(false),
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(routeCreate).To(gstruct.PointTo(Equal(createPayload)))
+		})
+
+		It("converts the options to the message", func() {
+			Expect(routeCreate.ToMessage("domain-ns", "domain-name").Options).To(gstruct.PointTo(Equal(repositories.RouteOptions{
+				LoadBalancing:         "hash",
+				HashHeader:            "X-Tenant",
+				RequestTimeoutSeconds: tools.PtrTo[int32](30),
+			})))
+		})
+
+		When("the load-balancing algorithm is not supported", func() {
+			BeforeEach(func() {
+				createPayload.Options.LoadBalancing = tools.PtrTo("random")
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("value must be one of: round-robin, least-connection, hash"))
+			})
+		})
+
+		When("the hash header is missing for hash load-balancing", func() {
+			BeforeEach(func() {
+				createPayload.Options.HashHeader = nil
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("hash_header cannot be blank"))
+			})
+		})
+
+		When("the request timeout is not positive", func() {
+			BeforeEach(func() {
+				createPayload.Options.RequestTimeout = tools.PtrTo[int32](-5)
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("request_timeout must be no less than 1"))
+			})
+		})
+	})
 })
 
 var _ = Describe("RoutePatch", func() {
@@ -220,6 +277,88 @@ var _ = Describe("RoutePatch", func() {
 			Expect(apiError.Detail()).To(ContainSubstring("cannot use the cloudfoundry.org domain"))
 		})
 	})
+
+	It("does not change the route options", func() {
+		Expect(routePatch.ToMessage(repositories.RouteRecord{
+			GUID:      "route-guid",
+			SpaceGUID: "space-guid",
+			Options:   &repositories.RouteOptions{LoadBalancing: "least-connection"},
+		}).Options).To(BeNil())
+	})
+
+	When("options are set", func() {
+		BeforeEach(func() {
+			patchPayload.Options = &payloads.RouteOptions{
+				RequestTimeout: tools.PtrTo[int32](30),
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(routePatch).To(gstruct.PointTo(Equal(patchPayload)))
+		})
+
+		It("merges the options into the existing route options", func() {
+			Expect(routePatch.ToMessage(repositories.RouteRecord{
+				GUID:      "route-guid",
+				SpaceGUID: "space-guid",
+				Options: &repositories.RouteOptions{
+					LoadBalancing:   "hash",
+					HashHeader:      "X-Tenant",
+					SessionAffinity: false,
+				},
+			})).To(Equal(repositories.PatchRouteMessage{
+				RouteGUID: "route-guid",
+				SpaceGUID: "space-guid",
+				MetadataPatch: repositories.MetadataPatch{
+					Annotations: map[string]*string{"a": tools.PtrTo("av")},
+					Labels:      map[string]*string{"l": tools.PtrTo("lv")},
+				},
+				Options: &repositories.RouteOptions{
+					LoadBalancing:         "hash",
+					HashHeader:            "X-Tenant",
+					RequestTimeoutSeconds: tools.PtrTo[int32](30),
+				},
+			}))
+		})
+
+		When("the load-balancing algorithm changes from hash", func() {
+			BeforeEach(func() {
+				patchPayload.Options.LoadBalancing = tools.PtrTo("round-robin")
+			})
+
+			It("drops the hash header", func() {
+				Expect(routePatch.ToMessage(repositories.RouteRecord{
+					Options: &repositories.RouteOptions{
+						LoadBalancing: "hash",
+						HashHeader:    "X-Tenant",
+					},
+				}).Options).To(gstruct.PointTo(Equal(repositories.RouteOptions{
+					LoadBalancing:         "round-robin",
+					RequestTimeoutSeconds: tools.PtrTo[int32](30),
+				})))
+			})
+		})
+
+		When("the route has no options yet", func() {
+			It("sets the options", func() {
+				Expect(routePatch.ToMessage(repositories.RouteRecord{}).Options).To(gstruct.PointTo(Equal(repositories.RouteOptions{
+					RequestTimeoutSeconds: tools.PtrTo[int32](30),
+				})))
+			})
+		})
+
+		When("the request timeout is not positive", func() {
+			BeforeEach(func() {
+				patchPayload.Options.RequestTimeout = tools.PtrTo[int32](0)
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("request_timeout"))
+			})
+		})
+	})
 })
 
 var _ = Describe("Add destination", func() {
@@ -285,14 +424,168 @@ var _ = Describe("Add destination", func() {
 		})
 	})
 
-	When("protocol is not http1", func() {
+	When("protocol is http2", func() {
+		BeforeEach(func() {
+			addPayload.Destinations[1].Protocol = tools.PtrTo("http2")
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(destinationAdd.Destinations[1].Protocol).To(gstruct.PointTo(Equal("http2")))
+		})
+	})
+
+	When("protocol is neither http1 nor http2", func() {
 		BeforeEach(func() {
 			addPayload.Destinations[1].Protocol = tools.PtrTo("http")
 		})
 
 		It("fails", func() {
 			Expect(apiError).To(HaveOccurred())
-			Expect(apiError.Detail()).To(ContainSubstring("value must be one of: http1"))
+			Expect(apiError.Detail()).To(ContainSubstring("value must be one of: http1, http2"))
+		})
+	})
+})
+
+var _ = Describe("RouteReservationsGet", func() {
+	DescribeTable("valid query",
+		func(query string, expected payloads.RouteReservationsGet) {
+			actual, decodeErr := decodeQuery[payloads.RouteReservationsGet](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actual).To(Equal(expected))
+		},
+		Entry("host", "host=h1", payloads.RouteReservationsGet{Host: "h1"}),
+		Entry("path", "path=/p1", payloads.RouteReservationsGet{Path: "/p1"}),
+		Entry("port", "port=1234", payloads.RouteReservationsGet{Port: "1234"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.RouteReservationsGet](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("port is not a number", "port=foo", "value must be an integer"),
+		Entry("port is out of range", "port=70000", "must be no greater than 65535"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts to a route reservation message", func() {
+			payload := payloads.RouteReservationsGet{Host: "h1", Path: "/p1"}
+			Expect(payload.ToMessage("domain-guid")).To(Equal(repositories.RouteReservationMessage{
+				DomainGUID: "domain-guid",
+				Host:       "h1",
+				Path:       "/p1",
+			}))
+		})
+	})
+})
+
+var _ = Describe("RouteShareSpaces", func() {
+	var (
+		sharePayload payloads.RouteShareSpaces
+		routeShare   *payloads.RouteShareSpaces
+		validatorErr error
+	)
+
+	BeforeEach(func() {
+		routeShare = new(payloads.RouteShareSpaces)
+		sharePayload = payloads.RouteShareSpaces{
+			Data: []payloads.RelationshipData{{GUID: "space-1"}, {GUID: "space-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(sharePayload), routeShare)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(routeShare).To(gstruct.PointTo(Equal(sharePayload)))
+		Expect(routeShare.SpaceGUIDs()).To(Equal([]string{"space-1", "space-2"}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data = nil
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+
+	When("a space guid is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data = []payloads.RelationshipData{{GUID: ""}}
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a share route message", func() {
+			Expect(sharePayload.ToMessage(repositories.RouteRecord{GUID: "route-guid", SpaceGUID: "space-guid"})).To(Equal(repositories.ShareRouteMessage{
+				RouteGUID:        "route-guid",
+				SpaceGUID:        "space-guid",
+				SharedSpaceGUIDs: []string{"space-1", "space-2"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("RouteTransferOwner", func() {
+	var (
+		transferPayload payloads.RouteTransferOwner
+		routeTransfer   *payloads.RouteTransferOwner
+		validatorErr    error
+	)
+
+	BeforeEach(func() {
+		routeTransfer = new(payloads.RouteTransferOwner)
+		transferPayload = payloads.RouteTransferOwner{
+			Data: &payloads.RelationshipData{GUID: "new-space-guid"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(transferPayload), routeTransfer)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(routeTransfer).To(gstruct.PointTo(Equal(transferPayload)))
+	})
+
+	When("data is missing", func() {
+		BeforeEach(func() {
+			transferPayload.Data = nil
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "data is required")
+		})
+	})
+
+	When("the space guid is empty", func() {
+		BeforeEach(func() {
+			transferPayload.Data.GUID = ""
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a transfer route ownership message", func() {
+			Expect(transferPayload.ToMessage(repositories.RouteRecord{GUID: "route-guid", SpaceGUID: "space-guid"})).To(Equal(repositories.TransferRouteOwnershipMessage{
+				RouteGUID:    "route-guid",
+				SpaceGUID:    "space-guid",
+				NewSpaceGUID: "new-space-guid",
+			}))
 		})
 	})
 })
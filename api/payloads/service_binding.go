@@ -23,6 +23,11 @@ func (p ServiceBindingCreate) ToMessage(spaceGUID string) repositories.CreateSer
 		appGUID = p.Relationships.App.Data.GUID
 	}
 
+	var previousBindingGUID string
+	if p.Relationships.PreviousBinding != nil {
+		previousBindingGUID = p.Relationships.PreviousBinding.Data.GUID
+	}
+
 	return repositories.CreateServiceBindingMessage{
 		Name:                p.Name,
 		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
@@ -30,6 +35,7 @@ func (p ServiceBindingCreate) ToMessage(spaceGUID string) repositories.CreateSer
 		SpaceGUID:           spaceGUID,
 		Parameters:          p.Parameters,
 		Type:                p.Type,
+		PreviousBindingGUID: previousBindingGUID,
 	}
 }
 
@@ -62,11 +68,13 @@ func (p ServiceBindingCreate) Validate() error {
 type ServiceBindingRelationships struct {
 	App             *Relationship `json:"app"`
 	ServiceInstance *Relationship `json:"service_instance"`
+	PreviousBinding *Relationship `json:"previous_binding"`
 }
 
 func (r ServiceBindingRelationships) Validate() error {
 	return jellidation.ValidateStruct(&r,
 		jellidation.Field(&r.ServiceInstance, jellidation.NotNil),
+		jellidation.Field(&r.PreviousBinding),
 	)
 }
 
@@ -225,6 +225,32 @@ var _ = Describe("ServiceBindingCreate", func() {
 				Expect(apiError.Detail()).To(ContainSubstring("relationships.service_instance.data.guid cannot be blank"))
 			})
 		})
+
+		When("the previous binding relationship is set", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.PreviousBinding = &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "previous-binding-guid",
+					},
+				}
+			})
+
+			It("succeeds", func() {
+				Expect(validatorErr).NotTo(HaveOccurred())
+				Expect(serviceBindingCreate).To(PointTo(Equal(createPayload)))
+			})
+
+			When("the previous binding GUID is blank", func() {
+				BeforeEach(func() {
+					createPayload.Relationships.PreviousBinding.Data.GUID = ""
+				})
+
+				It("fails", func() {
+					Expect(apiError).To(HaveOccurred())
+					Expect(apiError.Detail()).To(ContainSubstring("relationships.previous_binding.data.guid cannot be blank"))
+				})
+			})
+		})
 	})
 
 	Describe("ToMessage", func() {
@@ -246,6 +272,20 @@ var _ = Describe("ServiceBindingCreate", func() {
 				},
 			}))
 		})
+
+		When("the previous binding relationship is set", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.PreviousBinding = &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "previous-binding-guid",
+					},
+				}
+			})
+
+			It("sets the previous binding guid", func() {
+				Expect(createMessage.PreviousBindingGUID).To(Equal("previous-binding-guid"))
+			})
+		})
 	})
 })
 
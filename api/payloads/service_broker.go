@@ -39,6 +39,7 @@ type ServiceBrokerCreate struct {
 	Labels         map[string]string     `json:"labels,omitempty"`
 	Annotations    map[string]string     `json:"annotations,omitempty"`
 	Authentication *BrokerAuthentication `json:"authentication"`
+	Relationships  *BrokerRelationships  `json:"relationships,omitempty"`
 }
 
 func (c ServiceBrokerCreate) Validate() error {
@@ -46,11 +47,22 @@ func (c ServiceBrokerCreate) Validate() error {
 		jellidation.Field(&c.Name, jellidation.Required),
 		jellidation.Field(&c.URL, jellidation.Required),
 		jellidation.Field(&c.Authentication, jellidation.Required),
+		jellidation.Field(&c.Relationships),
+	)
+}
+
+type BrokerRelationships struct {
+	Space *Relationship `json:"space"`
+}
+
+func (r BrokerRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Space, jellidation.NotNil),
 	)
 }
 
 func (c ServiceBrokerCreate) ToMessage() repositories.CreateServiceBrokerMessage {
-	return repositories.CreateServiceBrokerMessage{
+	message := repositories.CreateServiceBrokerMessage{
 		Name: c.Name,
 		URL:  c.URL,
 		Metadata: repositories.Metadata{
@@ -62,20 +74,28 @@ func (c ServiceBrokerCreate) ToMessage() repositories.CreateServiceBrokerMessage
 			Password: c.Authentication.Credentials.Password,
 		},
 	}
+
+	if c.Relationships != nil {
+		message.SpaceGUID = c.Relationships.Space.Data.GUID
+	}
+
+	return message
 }
 
 type ServiceBrokerList struct {
 	Names      string
+	SpaceGUIDs string
 	Pagination Pagination
 }
 
 func (b *ServiceBrokerList) DecodeFromURLValues(values url.Values) error {
 	b.Names = values.Get("names")
+	b.SpaceGUIDs = values.Get("space_guids")
 	return b.Pagination.DecodeFromURLValues(values)
 }
 
 func (b *ServiceBrokerList) SupportedKeys() []string {
-	return []string{"names", "page", "per_page"}
+	return []string{"names", "space_guids", "page", "per_page"}
 }
 
 func (l ServiceBrokerList) Validate() error {
@@ -87,6 +107,7 @@ func (l ServiceBrokerList) Validate() error {
 func (b *ServiceBrokerList) ToMessage() repositories.ListServiceBrokerMessage {
 	return repositories.ListServiceBrokerMessage{
 		Names:      parse.ArrayParam(b.Names),
+		SpaceGUIDs: parse.ArrayParam(b.SpaceGUIDs),
 		Pagination: b.Pagination.ToMessage(DefaultPageSize),
 	}
 }
@@ -87,6 +87,35 @@ var _ = Describe("ServiceBrokerCreate", func() {
 		})
 	})
 
+	When("the space relationship is set", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = &payloads.BrokerRelationships{
+				Space: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "space-guid"},
+				},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceBrokerCreate).To(PointTo(Equal(createPayload)))
+		})
+
+		It("sets the space guid on the message", func() {
+			Expect(serviceBrokerCreate.ToMessage().SpaceGUID).To(Equal("space-guid"))
+		})
+	})
+
+	When("the relationships do not contain a space", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = &payloads.BrokerRelationships{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships.space is required")
+		})
+	})
+
 	Describe("ToMessage()", func() {
 		It("converts to repo message correctly", func() {
 			msg := serviceBrokerCreate.ToMessage()
@@ -115,6 +144,7 @@ var _ = Describe("ServiceBrokerList", func() {
 			Expect(*actualServiceBrokerList).To(Equal(expectedServiceBrokerList))
 		},
 		Entry("names", "names=n1,n2", payloads.ServiceBrokerList{Names: "n1,n2"}),
+		Entry("space_guids", "space_guids=s1,s2", payloads.ServiceBrokerList{SpaceGUIDs: "s1,s2"}),
 		Entry("page=3", "page=3", payloads.ServiceBrokerList{Pagination: payloads.Pagination{Page: "3"}}),
 	)
 
@@ -134,7 +164,8 @@ var _ = Describe("ServiceBrokerList", func() {
 
 		BeforeEach(func() {
 			payload = payloads.ServiceBrokerList{
-				Names: "n1,n2",
+				Names:      "n1,n2",
+				SpaceGUIDs: "s1,s2",
 				Pagination: payloads.Pagination{
 					PerPage: "3",
 					Page:    "4",
@@ -148,7 +179,8 @@ var _ = Describe("ServiceBrokerList", func() {
 
 		It("returns a list service bindings message", func() {
 			Expect(message).To(Equal(repositories.ListServiceBrokerMessage{
-				Names: []string{"n1", "n2"},
+				Names:      []string{"n1", "n2"},
+				SpaceGUIDs: []string{"s1", "s2"},
 				Pagination: repositories.Pagination{
 					PerPage: 3,
 					Page:    4,
@@ -11,17 +11,19 @@ import (
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
 	jellidation "github.com/jellydator/validation"
 )
 
 type ServiceInstanceCreate struct {
-	Name          string                        `json:"name"`
-	Type          string                        `json:"type"`
-	Tags          []string                      `json:"tags"`
-	Credentials   map[string]any                `json:"credentials"`
-	Parameters    map[string]any                `json:"parameters"`
-	Relationships *ServiceInstanceRelationships `json:"relationships"`
-	Metadata      Metadata                      `json:"metadata"`
+	Name           string                        `json:"name"`
+	Type           string                        `json:"type"`
+	Tags           []string                      `json:"tags"`
+	Credentials    map[string]any                `json:"credentials"`
+	SyslogDrainURL *string                       `json:"syslog_drain_url"`
+	Parameters     map[string]any                `json:"parameters"`
+	Relationships  *ServiceInstanceRelationships `json:"relationships"`
+	Metadata       Metadata                      `json:"metadata"`
 }
 
 const maxTagsLength = 2048
@@ -43,11 +45,38 @@ func validateTagLength(tags any) error {
 	return nil
 }
 
+func validateSyslogDrainURL(value any) error {
+	drainURL, ok := value.(*string)
+	if !ok {
+		return errors.New("wrong input")
+	}
+
+	if drainURL == nil || *drainURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(*drainURL)
+	if err != nil || u.Host == "" {
+		return errors.New("must be a valid URL")
+	}
+
+	switch u.Scheme {
+	case "syslog", "syslog-tls", "https":
+		return nil
+	}
+
+	return errors.New("must use one of the following schemes: syslog, syslog-tls, https")
+}
+
 func (c ServiceInstanceCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
 		jellidation.Field(&c.Name, jellidation.Required),
 		jellidation.Field(&c.Type, jellidation.Required, validation.OneOf("user-provided", "managed")),
 		jellidation.Field(&c.Tags, jellidation.By(validateTagLength)),
+		jellidation.Field(&c.SyslogDrainURL,
+			jellidation.When(c.Type == "managed", jellidation.Nil.Error("must be blank for managed service instances")),
+			jellidation.By(validateSyslogDrainURL),
+		),
 		jellidation.Field(&c.Relationships, jellidation.NotNil, jellidation.By(func(r any) error {
 			rel := r.(*ServiceInstanceRelationships)
 			if c.Type == "user-provided" {
@@ -62,12 +91,13 @@ func (c ServiceInstanceCreate) Validate() error {
 
 func (p ServiceInstanceCreate) ToUPSICreateMessage() repositories.CreateUPSIMessage {
 	return repositories.CreateUPSIMessage{
-		Name:        p.Name,
-		SpaceGUID:   p.Relationships.Space.Data.GUID,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
-		Labels:      p.Metadata.Labels,
-		Annotations: p.Metadata.Annotations,
+		Name:           p.Name,
+		SpaceGUID:      p.Relationships.Space.Data.GUID,
+		Credentials:    p.Credentials,
+		SyslogDrainURL: p.SyslogDrainURL,
+		Tags:           p.Tags,
+		Labels:         p.Metadata.Labels,
+		Annotations:    p.Metadata.Annotations,
 	}
 }
 
@@ -171,25 +201,28 @@ func (g *ServiceInstanceGet) DecodeFromURLValues(values url.Values) error {
 }
 
 type ServiceInstancePatch struct {
-	Name        *string         `json:"name,omitempty"`
-	Tags        *[]string       `json:"tags,omitempty"`
-	Credentials *map[string]any `json:"credentials,omitempty"`
-	Metadata    MetadataPatch   `json:"metadata"`
+	Name           *string         `json:"name,omitempty"`
+	Tags           *[]string       `json:"tags,omitempty"`
+	Credentials    *map[string]any `json:"credentials,omitempty"`
+	SyslogDrainURL *string         `json:"syslog_drain_url,omitempty"`
+	Metadata       MetadataPatch   `json:"metadata"`
 }
 
 func (p ServiceInstancePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.SyslogDrainURL, jellidation.By(validateSyslogDrainURL)),
 		jellidation.Field(&p.Metadata),
 	)
 }
 
 func (p ServiceInstancePatch) ToServiceInstancePatchMessage(spaceGUID, appGUID string) repositories.PatchServiceInstanceMessage {
 	return repositories.PatchServiceInstanceMessage{
-		SpaceGUID:   spaceGUID,
-		GUID:        appGUID,
-		Name:        p.Name,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
+		SpaceGUID:      spaceGUID,
+		GUID:           appGUID,
+		Name:           p.Name,
+		Credentials:    p.Credentials,
+		SyslogDrainURL: p.SyslogDrainURL,
+		Tags:           p.Tags,
 		MetadataPatch: repositories.MetadataPatch{
 			Labels:      p.Metadata.Labels,
 			Annotations: p.Metadata.Annotations,
@@ -220,6 +253,10 @@ func (p *ServiceInstancePatch) UnmarshalJSON(data []byte) error {
 		patch.Credentials = &map[string]any{}
 	}
 
+	if v, ok := patchMap["syslog_drain_url"]; ok && v == nil {
+		patch.SyslogDrainURL = tools.PtrTo("")
+	}
+
 	*p = ServiceInstancePatch(patch)
 
 	return nil
@@ -206,6 +206,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 						"a": "b",
 					},
 				},
+				SyslogDrainURL: tools.PtrTo("syslog-tls://logs.example.com:6514"),
 				Relationships: &payloads.ServiceInstanceRelationships{
 					Space: &payloads.Relationship{
 						Data: &payloads.RelationshipData{
@@ -280,6 +281,26 @@ var _ = Describe("ServiceInstanceCreate", func() {
 			})
 		})
 
+		When("the syslog drain url is not a url", func() {
+			BeforeEach(func() {
+				createPayload.SyslogDrainURL = tools.PtrTo("not-a-url")
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "syslog_drain_url must be a valid URL")
+			})
+		})
+
+		When("the syslog drain url has an unsupported scheme", func() {
+			BeforeEach(func() {
+				createPayload.SyslogDrainURL = tools.PtrTo("ftp://logs.example.com")
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "syslog_drain_url must use one of the following schemes: syslog, syslog-tls, https")
+			})
+		})
+
 		When("metadata is invalid", func() {
 			BeforeEach(func() {
 				createPayload.Metadata = payloads.Metadata{
@@ -298,6 +319,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 			BeforeEach(func() {
 				createPayload.Type = "managed"
 				createPayload.Credentials = nil
+				createPayload.SyslogDrainURL = nil
 				createPayload.Relationships.ServicePlan = &payloads.Relationship{
 					Data: &payloads.RelationshipData{
 						GUID: "plan_guid",
@@ -310,6 +332,16 @@ var _ = Describe("ServiceInstanceCreate", func() {
 				Expect(serviceInstanceCreate).To(PointTo(Equal(createPayload)))
 			})
 
+			When("the syslog drain url is set", func() {
+				BeforeEach(func() {
+					createPayload.SyslogDrainURL = tools.PtrTo("https://logs.example.com")
+				})
+
+				It("return an appropriate error", func() {
+					expectUnprocessableEntityError(validatorErr, "syslog_drain_url must be blank for managed service instances")
+				})
+			})
+
 			When("plan relationship is not set", func() {
 				BeforeEach(func() {
 					createPayload.Relationships.ServicePlan = nil
@@ -337,6 +369,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 						"a": "b",
 					},
 				},
+				SyslogDrainURL: tools.PtrTo("https://logs.example.com"),
 				Relationships: &payloads.ServiceInstanceRelationships{
 					Space: &payloads.Relationship{
 						Data: &payloads.RelationshipData{
@@ -359,6 +392,7 @@ var _ = Describe("ServiceInstanceCreate", func() {
 			Expect(msg.Name).To(Equal("service-instance-name"))
 			Expect(msg.SpaceGUID).To(Equal("space-guid"))
 			Expect(msg.Tags).To(ConsistOf("foo", "bar"))
+			Expect(msg.SyslogDrainURL).To(PointTo(Equal("https://logs.example.com")))
 			Expect(msg.Annotations).To(HaveLen(1))
 			Expect(msg.Annotations).To(HaveKeyWithValue("ann1", "val_ann1"))
 			Expect(msg.Labels).To(HaveLen(1))
@@ -475,6 +509,16 @@ var _ = Describe("ServiceInstancePatch custom unmarshalling", func() {
 			Expect(patch.Credentials).To(PointTo(HaveLen(0)))
 		})
 	})
+
+	When("syslog_drain_url is present but null", func() {
+		BeforeEach(func() {
+			payload = `{"syslog_drain_url": null}`
+		})
+
+		It("defaults it to an empty string", func() {
+			Expect(patch.SyslogDrainURL).To(PointTo(BeEmpty()))
+		})
+	})
 })
 
 var _ = Describe("ServiceInstancePatch", func() {
@@ -494,6 +538,7 @@ var _ = Describe("ServiceInstancePatch", func() {
 					"a": "b",
 				},
 			},
+			SyslogDrainURL: tools.PtrTo("syslog://logs.example.com:514"),
 			Metadata: payloads.MetadataPatch{
 				Annotations: map[string]*string{"ann1": tools.PtrTo("val_ann1")},
 				Labels:      map[string]*string{"lab1": tools.PtrTo("val_lab1")},
@@ -521,6 +566,16 @@ var _ = Describe("ServiceInstancePatch", func() {
 		})
 	})
 
+	When("the syslog drain url has an unsupported scheme", func() {
+		BeforeEach(func() {
+			patchPayload.SyslogDrainURL = tools.PtrTo("ftp://logs.example.com")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "syslog_drain_url must use one of the following schemes: syslog, syslog-tls, https")
+		})
+	})
+
 	When("metadata is invalid", func() {
 		BeforeEach(func() {
 			patchPayload.Metadata.Labels["foo.cloudfoundry.org/bar"] = tools.PtrTo("baz")
@@ -538,6 +593,7 @@ var _ = Describe("ServiceInstancePatch", func() {
 			Expect(msg.GUID).To(Equal("app-guid"))
 			Expect(msg.Name).To(PointTo(Equal("service-instance-name")))
 			Expect(msg.Tags).To(PointTo(ConsistOf("foo", "bar")))
+			Expect(msg.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.com:514")))
 			Expect(msg.Annotations).To(MatchAllKeys(Keys{
 				"ann1": PointTo(Equal("val_ann1")),
 			}))
@@ -0,0 +1,68 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	jellidation "github.com/jellydator/validation"
+)
+
+type StackCreate struct {
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	Default        *bool    `json:"default"`
+	State          string   `json:"state"`
+	ClusterBuilder string   `json:"cluster_builder"`
+	Metadata       Metadata `json:"metadata"`
+}
+
+func (c StackCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, validation.StrictlyRequired),
+		jellidation.Field(&c.State, validation.OneOf(repositories.StackStateActive, repositories.StackStateDeprecated)),
+		jellidation.Field(&c.Metadata),
+	)
+}
+
+func (c StackCreate) ToMessage() repositories.CreateStackMessage {
+	return repositories.CreateStackMessage{
+		Name:               c.Name,
+		Description:        c.Description,
+		Default:            tools.ZeroIfNil(c.Default),
+		State:              c.State,
+		ClusterBuilderName: c.ClusterBuilder,
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type StackUpdate struct {
+	Description    *string       `json:"description"`
+	Default        *bool         `json:"default"`
+	State          *string       `json:"state"`
+	ClusterBuilder *string       `json:"cluster_builder"`
+	Metadata       MetadataPatch `json:"metadata"`
+}
+
+func (u StackUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.State, jellidation.NilOrNotEmpty, validation.OneOf(repositories.StackStateActive, repositories.StackStateDeprecated)),
+		jellidation.Field(&u.Metadata),
+	)
+}
+
+func (u StackUpdate) ToMessage(stackGUID string) repositories.UpdateStackMessage {
+	return repositories.UpdateStackMessage{
+		GUID:               stackGUID,
+		Description:        u.Description,
+		Default:            u.Default,
+		State:              u.State,
+		ClusterBuilderName: u.ClusterBuilder,
+		MetadataPatch: repositories.MetadataPatch{
+			Labels:      u.Metadata.Labels,
+			Annotations: u.Metadata.Annotations,
+		},
+	}
+}
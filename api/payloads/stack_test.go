@@ -0,0 +1,140 @@
+package payloads_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+var _ = Describe("StackCreate", func() {
+	var (
+		createPayload  payloads.StackCreate
+		decodedPayload *payloads.StackCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.StackCreate)
+		createPayload = payloads.StackCreate{
+			Name:           "cflinuxfs4",
+			Description:    "Jammy",
+			Default:        tools.PtrTo(true),
+			State:          "DEPRECATED",
+			ClusterBuilder: "jammy-builder",
+			Metadata: payloads.Metadata{
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"bar": "baz"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("name is empty", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("name: cannot be blank")))
+		})
+	})
+
+	When("state is invalid", func() {
+		BeforeEach(func() {
+			createPayload.State = "RETIRED"
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("state: value must be one of: ACTIVE, DEPRECATED")))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(decodedPayload.ToMessage()).To(Equal(repositories.CreateStackMessage{
+				Name:               "cflinuxfs4",
+				Description:        "Jammy",
+				Default:            true,
+				State:              "DEPRECATED",
+				ClusterBuilderName: "jammy-builder",
+				Metadata: repositories.Metadata{
+					Labels:      map[string]string{"foo": "bar"},
+					Annotations: map[string]string{"bar": "baz"},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("StackUpdate", func() {
+	var (
+		updatePayload  payloads.StackUpdate
+		decodedPayload *payloads.StackUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.StackUpdate)
+		updatePayload = payloads.StackUpdate{
+			Description: tools.PtrTo("Jammy"),
+			State:       tools.PtrTo("ACTIVE"),
+			Metadata: payloads.MetadataPatch{
+				Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("state is empty", func() {
+		BeforeEach(func() {
+			updatePayload.State = tools.PtrTo("")
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("state: cannot be blank")))
+		})
+	})
+
+	When("state is invalid", func() {
+		BeforeEach(func() {
+			updatePayload.State = tools.PtrTo("RETIRED")
+		})
+
+		It("returns an error", func() {
+			Expect(validatorErr).To(MatchError(ContainSubstring("state: value must be one of")))
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(decodedPayload.ToMessage("stack-guid")).To(Equal(repositories.UpdateStackMessage{
+				GUID:        "stack-guid",
+				Description: tools.PtrTo("Jammy"),
+				State:       tools.PtrTo("ACTIVE"),
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+		})
+	})
+})
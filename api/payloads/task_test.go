@@ -42,7 +42,8 @@ var _ = Describe("TaskCreate", func() {
 
 	BeforeEach(func() {
 		payload = payloads.TaskCreate{
-			Command: "sleep 9000",
+			Command:      "sleep 9000",
+			LogRateLimit: tools.PtrTo[int64](1024),
 			Metadata: payloads.Metadata{
 				Labels: map[string]string{
 					"foo": "bar",
@@ -81,6 +82,16 @@ var _ = Describe("TaskCreate", func() {
 			})
 		})
 
+		When("the log rate limit is less than -1", func() {
+			BeforeEach(func() {
+				payload.LogRateLimit = tools.PtrTo[int64](-2)
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "log_rate_limit_in_bytes_per_second must be -1 or greater")
+			})
+		})
+
 		When("metadata is invalid", func() {
 			BeforeEach(func() {
 				payload.Metadata = payloads.Metadata{
@@ -101,6 +112,7 @@ var _ = Describe("TaskCreate", func() {
 			msg := payload.ToMessage(repositories.AppRecord{GUID: "appGUID", SpaceGUID: "spaceGUID"})
 			Expect(msg.AppGUID).To(Equal("appGUID"))
 			Expect(msg.SpaceGUID).To(Equal("spaceGUID"))
+			Expect(msg.LogRateLimitBytesPerSecond).To(gstruct.PointTo(BeEquivalentTo(1024)))
 			Expect(msg.Metadata.Labels).To(Equal(map[string]string{
 				"foo": "bar",
 				"bar": "baz",
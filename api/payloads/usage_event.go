@@ -0,0 +1,46 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+// UsageEventList is the query of both the app and the service usage events
+// list endpoints
+type UsageEventList struct {
+	GUIDs      string
+	AfterGUID  string
+	OrderBy    string
+	Pagination Pagination
+}
+
+func (l UsageEventList) ToMessage() repositories.ListUsageEventsMessage {
+	return repositories.ListUsageEventsMessage{
+		GUIDs:      parse.ArrayParam(l.GUIDs),
+		AfterGUID:  l.AfterGUID,
+		Descending: l.OrderBy == "-created_at",
+		Pagination: l.Pagination.ToMessage(DefaultPageSize),
+	}
+}
+
+func (l UsageEventList) SupportedKeys() []string {
+	return []string{"guids", "after_guid", "order_by", "per_page", "page"}
+}
+
+func (l *UsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AfterGUID = values.Get("after_guid")
+	l.OrderBy = values.Get("order_by")
+	return l.Pagination.DecodeFromURLValues(values)
+}
+
+func (l UsageEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Pagination),
+		jellidation.Field(&l.OrderBy, validation.OneOfOrderBy("created_at")),
+	)
+}
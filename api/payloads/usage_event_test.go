@@ -0,0 +1,56 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("UsageEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedList payloads.UsageEventList) {
+			actualList, decodeErr := decodeQuery[payloads.UsageEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualList).To(Equal(expectedList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.UsageEventList{GUIDs: "g1,g2"}),
+		Entry("after_guid", "after_guid=g1", payloads.UsageEventList{AfterGUID: "g1"}),
+		Entry("created_at", "order_by=created_at", payloads.UsageEventList{OrderBy: "created_at"}),
+		Entry("-created_at", "order_by=-created_at", payloads.UsageEventList{OrderBy: "-created_at"}),
+		Entry("page=3", "page=3", payloads.UsageEventList{Pagination: payloads.Pagination{Page: "3"}}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.UsageEventList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid order_by", "order_by=foo", "value must be one of"),
+		Entry("per_page is not a number", "per_page=foo", "value must be an integer"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts the query", func() {
+			Expect(payloads.UsageEventList{
+				GUIDs:      "g1,g2",
+				AfterGUID:  "g0",
+				OrderBy:    "-created_at",
+				Pagination: payloads.Pagination{PerPage: "10", Page: "2"},
+			}.ToMessage()).To(Equal(repositories.ListUsageEventsMessage{
+				GUIDs:      []string{"g1", "g2"},
+				AfterGUID:  "g0",
+				Descending: true,
+				Pagination: repositories.Pagination{PerPage: 10, Page: 2},
+			}))
+		})
+
+		It("defaults to the oldest events first", func() {
+			Expect(payloads.UsageEventList{}.ToMessage()).To(Equal(repositories.ListUsageEventsMessage{
+				Pagination: repositories.Pagination{PerPage: payloads.DefaultPageSize, Page: 1},
+			}))
+		})
+	})
+})
@@ -0,0 +1,118 @@
+package presenter
+
+import (
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+)
+
+// The App Autoscaler API represents scaling types and statuses as numbers
+var (
+	scalingTypeCodes = map[string]int{
+		string(korifiv1alpha1.ScalingTypeDynamic):  0,
+		string(korifiv1alpha1.ScalingTypeSchedule): 1,
+	}
+	scalingStatusCodes = map[string]int{
+		string(korifiv1alpha1.ScalingStatusSucceeded): 0,
+		string(korifiv1alpha1.ScalingStatusFailed):    1,
+	}
+)
+
+func ForAutoscalingPolicy(record repositories.AutoscalingPolicyRecord) payloads.AutoscalingPolicy {
+	policy := payloads.AutoscalingPolicy{
+		InstanceMinCount: record.InstanceMinCount,
+		InstanceMaxCount: record.InstanceMaxCount,
+		ScalingRules:     slices.Collect(it.Map(slices.Values(record.ScalingRules), forAutoscalingRule)),
+	}
+
+	if record.Schedules != nil {
+		policy.Schedules = &payloads.AutoscalingSchedules{
+			Timezone:          record.Schedules.Timezone,
+			RecurringSchedule: slices.Collect(it.Map(slices.Values(record.Schedules.RecurringSchedules), forRecurringSchedule)),
+			SpecificDate:      slices.Collect(it.Map(slices.Values(record.Schedules.SpecificDates), forSpecificDate)),
+		}
+	}
+
+	return policy
+}
+
+func forAutoscalingRule(rule korifiv1alpha1.ScalingRule) payloads.AutoscalingRule {
+	return payloads.AutoscalingRule{
+		MetricType:         string(rule.MetricType),
+		BreachDurationSecs: tools.PtrTo(rule.BreachDurationSeconds),
+		Threshold:          rule.Threshold,
+		Operator:           rule.Operator,
+		CoolDownSecs:       tools.PtrTo(rule.CoolDownSeconds),
+		Adjustment:         rule.Adjustment,
+	}
+}
+
+func forScheduleLimits(limits korifiv1alpha1.ScheduleLimits) payloads.AutoscalingScheduleLimits {
+	return payloads.AutoscalingScheduleLimits{
+		InstanceMinCount:        limits.InstanceMinCount,
+		InstanceMaxCount:        limits.InstanceMaxCount,
+		InitialMinInstanceCount: limits.InitialMinInstanceCount,
+	}
+}
+
+func forRecurringSchedule(schedule korifiv1alpha1.RecurringSchedule) payloads.AutoscalingRecurringSchedule {
+	return payloads.AutoscalingRecurringSchedule{
+		AutoscalingScheduleLimits: forScheduleLimits(schedule.ScheduleLimits),
+		StartTime:                 schedule.StartTime,
+		EndTime:                   schedule.EndTime,
+		DaysOfWeek:                schedule.DaysOfWeek,
+		DaysOfMonth:               schedule.DaysOfMonth,
+		StartDate:                 schedule.StartDate,
+		EndDate:                   schedule.EndDate,
+	}
+}
+
+func forSpecificDate(schedule korifiv1alpha1.SpecificDateSchedule) payloads.AutoscalingSpecificDate {
+	return payloads.AutoscalingSpecificDate{
+		AutoscalingScheduleLimits: forScheduleLimits(schedule.ScheduleLimits),
+		StartDateTime:             schedule.StartDateTime,
+		EndDateTime:               schedule.EndDateTime,
+	}
+}
+
+type ScalingHistoryResponse struct {
+	TotalResults int                           `json:"total_results"`
+	TotalPages   int                           `json:"total_pages"`
+	Page         int                           `json:"page"`
+	Resources    []ScalingHistoryEntryResponse `json:"resources"`
+}
+
+type ScalingHistoryEntryResponse struct {
+	AppID        string `json:"app_id"`
+	Timestamp    int64  `json:"timestamp"`
+	ScalingType  int    `json:"scaling_type"`
+	Status       int    `json:"status"`
+	OldInstances int32  `json:"old_instances"`
+	NewInstances int32  `json:"new_instances"`
+	Reason       string `json:"reason"`
+	Error        string `json:"error"`
+}
+
+func ForScalingHistory(result repositories.ListResult[repositories.ScalingEventRecord]) ScalingHistoryResponse {
+	return ScalingHistoryResponse{
+		TotalResults: result.PageInfo.TotalResults,
+		TotalPages:   result.PageInfo.TotalPages,
+		Page:         result.PageInfo.PageNumber,
+		Resources: emptySliceIfNil(slices.Collect(it.Map(slices.Values(result.Records), func(record repositories.ScalingEventRecord) ScalingHistoryEntryResponse {
+			return ScalingHistoryEntryResponse{
+				AppID:        record.AppGUID,
+				Timestamp:    record.Timestamp.UnixNano(),
+				ScalingType:  scalingTypeCodes[record.ScalingType],
+				Status:       scalingStatusCodes[record.Status],
+				OldInstances: record.OldInstances,
+				NewInstances: record.NewInstances,
+				Reason:       record.Reason,
+				Error:        record.Error,
+			}
+		}))),
+	}
+}
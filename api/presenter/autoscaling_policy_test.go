@@ -0,0 +1,158 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Autoscaling Policy", func() {
+	var (
+		record repositories.AutoscalingPolicyRecord
+		output []byte
+	)
+
+	BeforeEach(func() {
+		record = repositories.AutoscalingPolicyRecord{
+			AppGUID:          "app-guid",
+			InstanceMinCount: 1,
+			InstanceMaxCount: 4,
+			ScalingRules: []korifiv1alpha1.ScalingRule{{
+				MetricType:            korifiv1alpha1.AutoscalingMetricCPU,
+				Threshold:             80,
+				Operator:              ">=",
+				BreachDurationSeconds: 120,
+				CoolDownSeconds:       300,
+				Adjustment:            "+1",
+			}},
+			Schedules: &korifiv1alpha1.AutoscalingSchedules{
+				Timezone: "Europe/London",
+				RecurringSchedules: []korifiv1alpha1.RecurringSchedule{{
+					ScheduleLimits: korifiv1alpha1.ScheduleLimits{
+						InstanceMinCount:        2,
+						InstanceMaxCount:        6,
+						InitialMinInstanceCount: tools.PtrTo[int32](3),
+					},
+					StartTime:  "08:00",
+					EndTime:    "18:00",
+					DaysOfWeek: []int32{1, 2},
+				}},
+				SpecificDates: []korifiv1alpha1.SpecificDateSchedule{{
+					ScheduleLimits: korifiv1alpha1.ScheduleLimits{
+						InstanceMinCount: 5,
+						InstanceMaxCount: 10,
+					},
+					StartDateTime: "2024-12-24T00:00",
+					EndDateTime:   "2024-12-26T23:59",
+				}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForAutoscalingPolicy(record))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"instance_min_count": 1,
+			"instance_max_count": 4,
+			"scaling_rules": [{
+				"metric_type": "cpu",
+				"breach_duration_secs": 120,
+				"threshold": 80,
+				"operator": ">=",
+				"cool_down_secs": 300,
+				"adjustment": "+1"
+			}],
+			"schedules": {
+				"timezone": "Europe/London",
+				"recurring_schedule": [{
+					"instance_min_count": 2,
+					"instance_max_count": 6,
+					"initial_min_instance_count": 3,
+					"start_time": "08:00",
+					"end_time": "18:00",
+					"days_of_week": [1, 2]
+				}],
+				"specific_date": [{
+					"instance_min_count": 5,
+					"instance_max_count": 10,
+					"start_date_time": "2024-12-24T00:00",
+					"end_date_time": "2024-12-26T23:59"
+				}]
+			}
+		}`))
+	})
+})
+
+var _ = Describe("Scaling History", func() {
+	var (
+		result repositories.ListResult[repositories.ScalingEventRecord]
+		output []byte
+	)
+
+	BeforeEach(func() {
+		result = repositories.ListResult[repositories.ScalingEventRecord]{
+			PageInfo: descriptors.PageInfo{
+				TotalResults: 3,
+				TotalPages:   2,
+				PageNumber:   2,
+				PageSize:     2,
+			},
+			Records: []repositories.ScalingEventRecord{{
+				AppGUID:      "app-guid",
+				Timestamp:    time.Unix(1700000000, 5),
+				ScalingType:  "schedule",
+				Status:       "failed",
+				OldInstances: 1,
+				NewInstances: 3,
+				Reason:       "schedule started",
+				Error:        "boom",
+			}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForScalingHistory(result))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"total_results": 3,
+			"total_pages": 2,
+			"page": 2,
+			"resources": [{
+				"app_id": "app-guid",
+				"timestamp": 1700000000000000005,
+				"scaling_type": 1,
+				"status": 1,
+				"old_instances": 1,
+				"new_instances": 3,
+				"reason": "schedule started",
+				"error": "boom"
+			}]
+		}`))
+	})
+
+	When("there are no events", func() {
+		BeforeEach(func() {
+			result.Records = nil
+		})
+
+		It("returns an empty list of resources", func() {
+			Expect(output).To(MatchJSON(`{"total_results": 3, "total_pages": 2, "page": 2, "resources": []}`))
+		})
+	})
+})
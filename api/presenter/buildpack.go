@@ -8,6 +8,10 @@ import (
 	"code.cloudfoundry.org/korifi/tools"
 )
 
+const (
+	buildpacksBase = "/v3/buildpacks"
+)
+
 type BuildpackResponse struct {
 	GUID      string          `json:"guid"`
 	CreatedAt string          `json:"created_at"`
@@ -15,6 +19,7 @@ type BuildpackResponse struct {
 	Name      string          `json:"name"`
 	Filename  string          `json:"filename"`
 	Stack     string          `json:"stack"`
+	State     string          `json:"state"`
 	Position  int             `json:"position"`
 	Enabled   bool            `json:"enabled"`
 	Locked    bool            `json:"locked"`
@@ -22,23 +27,34 @@ type BuildpackResponse struct {
 	Links     map[string]Link `json:"links"`
 }
 
-func ForBuildpack(buildpackRecord repositories.BuildpackRecord, _ url.URL, includes ...include.Resource) BuildpackResponse {
+func ForBuildpack(buildpackRecord repositories.BuildpackRecord, baseURL url.URL, includes ...include.Resource) BuildpackResponse {
 	toReturn := BuildpackResponse{
-		GUID:      "",
+		GUID:      buildpackRecord.GUID,
 		CreatedAt: tools.ZeroIfNil(formatTimestamp(&buildpackRecord.CreatedAt)),
 		UpdatedAt: tools.ZeroIfNil(formatTimestamp(buildpackRecord.UpdatedAt)),
 		Name:      buildpackRecord.Name,
-		Filename:  buildpackRecord.Name + "@" + buildpackRecord.Version,
+		Filename:  buildpackRecord.Filename,
 		Stack:     buildpackRecord.Stack,
+		State:     buildpackRecord.State,
 		Position:  buildpackRecord.Position,
-		Enabled:   true,
-		Locked:    false,
+		Enabled:   buildpackRecord.Enabled,
+		Locked:    buildpackRecord.Locked,
 		Metadata: Metadata{
-			Labels:      map[string]string{},
-			Annotations: map[string]string{},
+			Labels:      emptyMapIfNil(buildpackRecord.Labels),
+			Annotations: emptyMapIfNil(buildpackRecord.Annotations),
 		},
 		Links: map[string]Link{},
 	}
 
+	if buildpackRecord.GUID != "" {
+		toReturn.Links["self"] = Link{
+			HRef: buildURL(baseURL).appendPath(buildpacksBase, buildpackRecord.GUID).build(),
+		}
+		toReturn.Links["upload"] = Link{
+			HRef:   buildURL(baseURL).appendPath(buildpacksBase, buildpackRecord.GUID, "upload").build(),
+			Method: "POST",
+		}
+	}
+
 	return toReturn
 }
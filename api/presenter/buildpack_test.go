@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -14,23 +15,31 @@ import (
 
 var _ = Describe("Buildpacks", func() {
 	var (
-		output []byte
-		record repositories.BuildpackRecord
+		baseURL *url.URL
+		output  []byte
+		record  repositories.BuildpackRecord
 	)
 
 	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
 		record = repositories.BuildpackRecord{
 			Name:      "paketo-foopacks/bar",
+			Filename:  "paketo-foopacks/bar@1.0.0",
 			Position:  1,
 			Stack:     "waffle-house",
 			Version:   "1.0.0",
+			State:     "READY",
+			Enabled:   true,
 			CreatedAt: time.UnixMilli(1000),
 			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
 		}
 	})
 
 	JustBeforeEach(func() {
-		response := presenter.ForBuildpack(record, url.URL{})
+		response := presenter.ForBuildpack(record, *baseURL)
 		var err error
 		output, err = json.Marshal(response)
 		Expect(err).NotTo(HaveOccurred())
@@ -44,6 +53,7 @@ var _ = Describe("Buildpacks", func() {
 			"name": "paketo-foopacks/bar",
 			"filename": "paketo-foopacks/bar@1.0.0",
 			"stack": "waffle-house",
+			"state": "READY",
 			"position": 1,
 			"enabled": true,
 			"locked": false,
@@ -54,4 +64,21 @@ var _ = Describe("Buildpacks", func() {
 			"links": {}
 		}`))
 	})
+
+	When("the buildpack is managed by the admin", func() {
+		BeforeEach(func() {
+			record.GUID = "bp-guid"
+			record.Locked = true
+			record.Labels = map[string]string{"foo": "bar"}
+		})
+
+		It("produces the buildpack links", func() {
+			Expect(output).To(MatchJSONPath("$.guid", "bp-guid"))
+			Expect(output).To(MatchJSONPath("$.locked", BeTrue()))
+			Expect(output).To(MatchJSONPath("$.metadata.labels.foo", "bar"))
+			Expect(output).To(MatchJSONPath("$.links.self.href", "https://api.example.org/v3/buildpacks/bp-guid"))
+			Expect(output).To(MatchJSONPath("$.links.upload.href", "https://api.example.org/v3/buildpacks/bp-guid/upload"))
+			Expect(output).To(MatchJSONPath("$.links.upload.method", "POST"))
+		})
+	})
 })
@@ -21,6 +21,7 @@ type DropletResponse struct {
 	ProcessTypes      map[string]string            `json:"process_types"`
 	Stack             string                       `json:"stack"`
 	Image             *string                      `json:"image"`
+	ImageDigest       *string                      `json:"image_digest,omitempty"`
 	Relationships     map[string]ToOneRelationship `json:"relationships"`
 	Metadata          Metadata                     `json:"metadata"`
 	Links             map[string]*Link             `json:"links"`
@@ -77,11 +78,22 @@ func ForDroplet(dropletRecord repositories.DropletRecord, baseURL url.URL, inclu
 			"download": nil,
 		},
 	}
+	if dropletRecord.PackageGUID == "" {
+		toReturn.Links["package"] = nil
+	}
+	if dropletRecord.Lifecycle.Type != "docker" && dropletRecord.State == "STAGED" {
+		toReturn.Links["download"] = &Link{
+			HRef: buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "download").build(),
+		}
+	}
 	if dropletRecord.DropletErrorMsg != "" {
 		toReturn.Error = &dropletRecord.DropletErrorMsg
 	}
 	if dropletRecord.Lifecycle.Type == "docker" {
 		toReturn.Image = &dropletRecord.Image
+		if dropletRecord.ImageDigest != "" {
+			toReturn.ImageDigest = &dropletRecord.ImageDigest
+		}
 	}
 	return toReturn
 }
@@ -95,7 +95,9 @@ var _ = Describe("Droplet", func() {
 					"href": "https://api.example.org/v3/apps/the-app-guid/relationships/current_droplet",
 					"method": "PATCH"
 				},
-				"download": null
+				"download": {
+					"href": "https://api.example.org/v3/droplets/the-droplet-guid/download"
+				}
 			},
 			"metadata": {
 				"labels": {
@@ -108,6 +110,26 @@ var _ = Describe("Droplet", func() {
 		}`))
 	})
 
+	When("the droplet has no package", func() {
+		BeforeEach(func() {
+			record.PackageGUID = ""
+		})
+
+		It("does not link to a package", func() {
+			Expect(output).To(MatchJSONPath("$.links.package", BeNil()))
+		})
+	})
+
+	When("the droplet is not staged", func() {
+		BeforeEach(func() {
+			record.State = "AWAITING_UPLOAD"
+		})
+
+		It("does not link to the download", func() {
+			Expect(output).To(MatchJSONPath("$.links.download", BeNil()))
+		})
+	})
+
 	When("the lifecycle is docker", func() {
 		BeforeEach(func() {
 			record.Lifecycle = repositories.Lifecycle{
@@ -115,6 +137,7 @@ var _ = Describe("Droplet", func() {
 				Data: repositories.LifecycleData{},
 			}
 			record.Image = "some/image"
+			record.ImageDigest = "sha256:abc"
 		})
 
 		It("produces expected droplet json", func() {
@@ -137,6 +160,7 @@ var _ = Describe("Droplet", func() {
 			"buildpacks": [],
 			"stack": "cflinuxfs3",
 			"image": "some/image",
+			"image_digest": "sha256:abc",
 			"created_at": "1970-01-01T00:00:01Z",
 			"updated_at": "1970-01-01T00:00:02Z",
 			"relationships": {
@@ -0,0 +1,126 @@
+package presenter
+
+import (
+	"net/url"
+	"slices"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+)
+
+const (
+	isolationSegmentsBase = "/v3/isolation_segments"
+)
+
+type IsolationSegmentResponse struct {
+	GUID       string                             `json:"guid"`
+	CreatedAt  string                             `json:"created_at"`
+	UpdatedAt  string                             `json:"updated_at"`
+	Name       string                             `json:"name"`
+	Scheduling IsolationSegmentSchedulingResponse `json:"scheduling"`
+	Metadata   Metadata                           `json:"metadata"`
+	Links      IsolationSegmentLinks              `json:"links"`
+}
+
+type IsolationSegmentSchedulingResponse struct {
+	NodeSelector     map[string]string                         `json:"node_selector"`
+	Tolerations      []repositories.IsolationSegmentToleration `json:"tolerations"`
+	RuntimeClassName *string                                   `json:"runtime_class_name"`
+}
+
+type IsolationSegmentLinks struct {
+	Self          Link `json:"self"`
+	Organizations Link `json:"organizations"`
+}
+
+func ForIsolationSegment(record repositories.IsolationSegmentRecord, baseURL url.URL, includes ...include.Resource) IsolationSegmentResponse {
+	return IsolationSegmentResponse{
+		GUID:      record.GUID,
+		CreatedAt: tools.ZeroIfNil(formatTimestamp(&record.CreatedAt)),
+		UpdatedAt: tools.ZeroIfNil(formatTimestamp(record.UpdatedAt)),
+		Name:      record.Name,
+		Scheduling: IsolationSegmentSchedulingResponse{
+			NodeSelector:     emptyMapIfNil(record.Scheduling.NodeSelector),
+			Tolerations:      emptySliceIfNil(record.Scheduling.Tolerations),
+			RuntimeClassName: record.Scheduling.RuntimeClassName,
+		},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		Links: IsolationSegmentLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID).build(),
+			},
+			Organizations: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+type IsolationSegmentOrgsRelationshipResponse struct {
+	Data  []RelationshipData                `json:"data"`
+	Links IsolationSegmentRelationshipLinks `json:"links"`
+}
+
+type IsolationSegmentRelationshipLinks struct {
+	Self    Link  `json:"self"`
+	Related *Link `json:"related,omitempty"`
+}
+
+func ForIsolationSegmentOrgsRelationship(record repositories.IsolationSegmentRecord, baseURL url.URL) IsolationSegmentOrgsRelationshipResponse {
+	return IsolationSegmentOrgsRelationshipResponse{
+		Data: slices.Collect(it.Map(slices.Values(record.EntitledOrgs), func(orgGUID string) RelationshipData {
+			return RelationshipData{GUID: orgGUID}
+		})),
+		Links: IsolationSegmentRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID, "relationships/organizations").build(),
+			},
+			Related: &Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+type IsolationSegmentRelationshipResponse struct {
+	Data  *RelationshipData                 `json:"data"`
+	Links IsolationSegmentRelationshipLinks `json:"links"`
+}
+
+func ForOrgDefaultIsolationSegmentRelationship(orgGUID string, isolationSegmentGUID string, baseURL url.URL) IsolationSegmentRelationshipResponse {
+	return forIsolationSegmentRelationship(
+		buildURL(baseURL).appendPath(orgsBase, orgGUID, "relationships/default_isolation_segment").build(),
+		isolationSegmentGUID,
+		baseURL,
+	)
+}
+
+func ForSpaceIsolationSegmentRelationship(spaceGUID string, isolationSegmentGUID string, baseURL url.URL) IsolationSegmentRelationshipResponse {
+	return forIsolationSegmentRelationship(
+		buildURL(baseURL).appendPath(spacesBase, spaceGUID, "relationships/isolation_segment").build(),
+		isolationSegmentGUID,
+		baseURL,
+	)
+}
+
+func forIsolationSegmentRelationship(selfHRef string, isolationSegmentGUID string, baseURL url.URL) IsolationSegmentRelationshipResponse {
+	response := IsolationSegmentRelationshipResponse{
+		Links: IsolationSegmentRelationshipLinks{
+			Self: Link{HRef: selfHRef},
+		},
+	}
+
+	if isolationSegmentGUID != "" {
+		response.Data = &RelationshipData{GUID: isolationSegmentGUID}
+		response.Links.Related = &Link{
+			HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentGUID).build(),
+		}
+	}
+
+	return response
+}
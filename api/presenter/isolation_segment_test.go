@@ -0,0 +1,118 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Isolation Segments", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.IsolationSegmentRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.IsolationSegmentRecord{
+			GUID:         "iso-seg-guid",
+			Name:         "pci",
+			EntitledOrgs: []string{"org-guid"},
+			Scheduling: repositories.IsolationSegmentScheduling{
+				NodeSelector: map[string]string{"pool": "pci"},
+				Tolerations: []repositories.IsolationSegmentToleration{{
+					Key:      "dedicated",
+					Operator: "Equal",
+					Value:    "pci",
+					Effect:   "NoSchedule",
+				}},
+				RuntimeClassName: tools.PtrTo("gvisor"),
+			},
+			Labels:    map[string]string{"foo": "bar"},
+			CreatedAt: time.UnixMilli(1000),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	Describe("ForIsolationSegment", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForIsolationSegment(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "iso-seg-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "pci",
+				"scheduling": {
+					"node_selector": {"pool": "pci"},
+					"tolerations": [{"key": "dedicated", "operator": "Equal", "value": "pci", "effect": "NoSchedule"}],
+					"runtime_class_name": "gvisor"
+				},
+				"metadata": {
+					"labels": {"foo": "bar"},
+					"annotations": {}
+				},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/isolation_segments/iso-seg-guid"},
+					"organizations": {"href": "https://api.example.org/v3/isolation_segments/iso-seg-guid/organizations"}
+				}
+			}`))
+		})
+
+		When("the isolation segment has no scheduling constraints", func() {
+			BeforeEach(func() {
+				record.Scheduling = repositories.IsolationSegmentScheduling{}
+			})
+
+			It("presents empty collections", func() {
+				Expect(output).To(MatchJSONPath("$.scheduling.node_selector", BeEmpty()))
+				Expect(output).To(MatchJSONPath("$.scheduling.tolerations", BeEmpty()))
+				Expect(output).To(MatchJSONPath("$.scheduling.runtime_class_name", BeNil()))
+			})
+		})
+	})
+
+	Describe("ForIsolationSegmentOrgsRelationship", func() {
+		It("presents the entitled orgs", func() {
+			var err error
+			output, err = json.Marshal(presenter.ForIsolationSegmentOrgsRelationship(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{
+				"data": [{"guid": "org-guid"}],
+				"links": {
+					"self": {"href": "https://api.example.org/v3/isolation_segments/iso-seg-guid/relationships/organizations"},
+					"related": {"href": "https://api.example.org/v3/isolation_segments/iso-seg-guid/organizations"}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForSpaceIsolationSegmentRelationship", func() {
+		It("presents a null relationship when no isolation segment is assigned", func() {
+			var err error
+			output, err = json.Marshal(presenter.ForSpaceIsolationSegmentRelationship("space-guid", "", *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{
+				"data": null,
+				"links": {
+					"self": {"href": "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"}
+				}
+			}`))
+		})
+	})
+})
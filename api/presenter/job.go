@@ -23,6 +23,7 @@ const (
 	SpaceDeleteOperation               = "space.delete"
 	SpaceDeleteUnmappedRoutesOperation = "space.delete_unapped_routes"
 	DomainDeleteOperation              = "domain.delete"
+	BuildpackDeleteOperation           = "buildpack.delete"
 	RoleDeleteOperation                = "role.delete"
 	ServiceBrokerCreateOperation       = "service_broker.create"
 	ServiceBrokerDeleteOperation       = "service_broker.delete"
@@ -136,6 +136,10 @@ func ForStats(appRecord repositories.AppRecord, appPodStats []actions.PodStatsRe
 						Unit:  "bytes",
 						Value: GaugeInt(tools.ZeroIfNil(podStats.DiskQuota)),
 					},
+					"log_rate_limit": {
+						Unit:  "bytes",
+						Value: GaugeInt(tools.ZeroIfNil(podStats.LogRateLimit)),
+					},
 				},
 			},
 		})
@@ -93,8 +93,9 @@ var _ = Describe("ForStats", func() {
 				Mem:       tools.PtrTo[int64](2),
 				Disk:      tools.PtrTo[int64](3),
 			},
-			MemQuota:  tools.PtrTo[int64](4),
-			DiskQuota: tools.PtrTo[int64](5),
+			MemQuota:     tools.PtrTo[int64](4),
+			DiskQuota:    tools.PtrTo[int64](5),
+			LogRateLimit: tools.PtrTo[int64](6),
 		}}
 	})
 
@@ -134,6 +135,10 @@ var _ = Describe("ForStats", func() {
 					  "unit": "bytes",
 					  "value": 5
 					},
+					"log_rate_limit": {
+					  "unit": "bytes",
+					  "value": 6
+					},
 					"memory": {
 					  "unit": "bytes",
 					  "value": 2
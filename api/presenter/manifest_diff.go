@@ -0,0 +1,15 @@
+package presenter
+
+import (
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+)
+
+type ManifestDiffResponse struct {
+	Diff []manifest.DiffEntry `json:"diff"`
+}
+
+func ForManifestDiff(diff []manifest.DiffEntry) ManifestDiffResponse {
+	return ManifestDiffResponse{
+		Diff: emptySliceIfNil(diff),
+	}
+}
@@ -0,0 +1,51 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manifest Diff", func() {
+	var (
+		diff   []manifest.DiffEntry
+		output []byte
+	)
+
+	BeforeEach(func() {
+		diff = []manifest.DiffEntry{
+			{Op: "add", Path: "/applications/0/env/FOO", Value: "bar"},
+			{Op: "remove", Path: "/applications/0/routes/0", Was: map[string]any{"route": "my-app.my.domain"}},
+			{Op: "replace", Path: "/applications/0/processes/0/instances", Was: 1, Value: 2},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForManifestDiff(diff))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"diff": [
+				{"op": "add", "path": "/applications/0/env/FOO", "value": "bar"},
+				{"op": "remove", "path": "/applications/0/routes/0", "was": {"route": "my-app.my.domain"}},
+				{"op": "replace", "path": "/applications/0/processes/0/instances", "was": 1, "value": 2}
+			]
+		}`))
+	})
+
+	When("the diff is empty", func() {
+		BeforeEach(func() {
+			diff = nil
+		})
+
+		It("presents an empty list", func() {
+			Expect(output).To(MatchJSON(`{"diff": []}`))
+		})
+	})
+})
@@ -15,18 +15,20 @@ const (
 )
 
 type ProcessResponse struct {
-	GUID          string                       `json:"guid"`
-	Type          string                       `json:"type"`
-	Command       string                       `json:"command"`
-	Instances     int32                        `json:"instances"`
-	MemoryMB      int64                        `json:"memory_in_mb"`
-	DiskQuotaMB   int64                        `json:"disk_in_mb"`
-	HealthCheck   ProcessResponseHealthCheck   `json:"health_check"`
-	Relationships map[string]ToOneRelationship `json:"relationships"`
-	Metadata      Metadata                     `json:"metadata"`
-	CreatedAt     string                       `json:"created_at"`
-	UpdatedAt     string                       `json:"updated_at"`
-	Links         ProcessLinks                 `json:"links"`
+	GUID                 string                              `json:"guid"`
+	Type                 string                              `json:"type"`
+	Command              string                              `json:"command"`
+	Instances            int32                               `json:"instances"`
+	MemoryMB             int64                               `json:"memory_in_mb"`
+	DiskQuotaMB          int64                               `json:"disk_in_mb"`
+	LogRateLimit         int64                               `json:"log_rate_limit_in_bytes_per_second"`
+	HealthCheck          ProcessResponseHealthCheck          `json:"health_check"`
+	ReadinessHealthCheck ProcessResponseReadinessHealthCheck `json:"readiness_health_check"`
+	Relationships        map[string]ToOneRelationship        `json:"relationships"`
+	Metadata             Metadata                            `json:"metadata"`
+	CreatedAt            string                              `json:"created_at"`
+	UpdatedAt            string                              `json:"updated_at"`
+	Links                ProcessLinks                        `json:"links"`
 }
 
 type ProcessLinks struct {
@@ -46,6 +48,7 @@ type ProcessResponseHealthCheckData struct {
 	Type              string `json:"-"`
 	Timeout           int32  `json:"timeout"`
 	InvocationTimeout int32  `json:"invocation_timeout"`
+	Interval          int32  `json:"interval"`
 	HTTPEndpoint      string `json:"endpoint"`
 }
 
@@ -61,18 +64,24 @@ func (h ProcessResponseHealthCheckData) MarshalJSON() ([]byte, error) {
 	if *invocationTimeout == 0 {
 		invocationTimeout = nil
 	}
+	interval := tools.PtrTo(h.Interval)
+	if *interval == 0 {
+		interval = nil
+	}
 
 	switch h.Type {
 	case "http":
 		return json.Marshal(ProcessResponseHTTPHealthCheckData{
 			Timeout:           timeout,
 			InvocationTimeout: invocationTimeout,
+			Interval:          interval,
 			HTTPEndpoint:      h.HTTPEndpoint,
 		})
 	case "port":
 		return json.Marshal(ProcessResponsePortHealthCheckData{
 			Timeout:           timeout,
 			InvocationTimeout: invocationTimeout,
+			Interval:          interval,
 		})
 	case "process":
 		return json.Marshal(ProcessResponseProcessHealthCheckData{
@@ -86,35 +95,108 @@ func (h ProcessResponseHealthCheckData) MarshalJSON() ([]byte, error) {
 type ProcessResponseHTTPHealthCheckData struct {
 	Timeout           *int32 `json:"timeout"`
 	InvocationTimeout *int32 `json:"invocation_timeout"`
+	Interval          *int32 `json:"interval"`
 	HTTPEndpoint      string `json:"endpoint"`
 }
 
 type ProcessResponsePortHealthCheckData struct {
 	Timeout           *int32 `json:"timeout"`
 	InvocationTimeout *int32 `json:"invocation_timeout"`
+	Interval          *int32 `json:"interval"`
 }
 
 type ProcessResponseProcessHealthCheckData struct {
 	Timeout *int32 `json:"timeout"`
 }
 
+type ProcessResponseReadinessHealthCheck struct {
+	Type string                                  `json:"type"`
+	Data ProcessResponseReadinessHealthCheckData `json:"data"`
+}
+
+type ProcessResponseReadinessHealthCheckData struct {
+	Type              string `json:"-"`
+	InvocationTimeout int32  `json:"invocation_timeout"`
+	Interval          int32  `json:"interval"`
+	HTTPEndpoint      string `json:"endpoint"`
+}
+
+func (h ProcessResponseReadinessHealthCheckData) MarshalJSON() ([]byte, error) {
+	invocationTimeout := tools.PtrTo(h.InvocationTimeout)
+	if *invocationTimeout == 0 {
+		invocationTimeout = nil
+	}
+	interval := tools.PtrTo(h.Interval)
+	if *interval == 0 {
+		interval = nil
+	}
+
+	switch h.Type {
+	case "http":
+		return json.Marshal(ProcessResponseHTTPReadinessHealthCheckData{
+			InvocationTimeout: invocationTimeout,
+			Interval:          interval,
+			HTTPEndpoint:      h.HTTPEndpoint,
+		})
+	case "port":
+		return json.Marshal(ProcessResponsePortReadinessHealthCheckData{
+			InvocationTimeout: invocationTimeout,
+			Interval:          interval,
+		})
+	default:
+		return json.Marshal(struct{}{})
+	}
+}
+
+type ProcessResponseHTTPReadinessHealthCheckData struct {
+	InvocationTimeout *int32 `json:"invocation_timeout"`
+	Interval          *int32 `json:"interval"`
+	HTTPEndpoint      string `json:"endpoint"`
+}
+
+type ProcessResponsePortReadinessHealthCheckData struct {
+	InvocationTimeout *int32 `json:"invocation_timeout"`
+	Interval          *int32 `json:"interval"`
+}
+
+// Processes without a readiness health check are ready as soon as they
+// start, which is what the "process" type means
+func readinessHealthCheckType(healthCheck repositories.ReadinessHealthCheck) string {
+	if healthCheck.Type == "" {
+		return "process"
+	}
+
+	return healthCheck.Type
+}
+
 func ForProcess(responseProcess repositories.ProcessRecord, baseURL url.URL, _ ...include.Resource) ProcessResponse {
 	return ProcessResponse{
-		GUID:        responseProcess.GUID,
-		Type:        responseProcess.Type,
-		Command:     responseProcess.Command,
-		Instances:   responseProcess.DesiredInstances,
-		MemoryMB:    responseProcess.MemoryMB,
-		DiskQuotaMB: responseProcess.DiskQuotaMB,
+		GUID:         responseProcess.GUID,
+		Type:         responseProcess.Type,
+		Command:      responseProcess.Command,
+		Instances:    responseProcess.DesiredInstances,
+		MemoryMB:     responseProcess.MemoryMB,
+		DiskQuotaMB:  responseProcess.DiskQuotaMB,
+		LogRateLimit: responseProcess.LogRateLimitBytesPerSecond,
 		HealthCheck: ProcessResponseHealthCheck{
 			Type: string(responseProcess.HealthCheck.Type),
 			Data: ProcessResponseHealthCheckData{
 				Type:              string(responseProcess.HealthCheck.Type),
 				Timeout:           responseProcess.HealthCheck.Data.TimeoutSeconds,
 				InvocationTimeout: responseProcess.HealthCheck.Data.InvocationTimeoutSeconds,
+				Interval:          responseProcess.HealthCheck.Data.IntervalSeconds,
 				HTTPEndpoint:      responseProcess.HealthCheck.Data.HTTPEndpoint,
 			},
 		},
+		ReadinessHealthCheck: ProcessResponseReadinessHealthCheck{
+			Type: readinessHealthCheckType(responseProcess.ReadinessHealthCheck),
+			Data: ProcessResponseReadinessHealthCheckData{
+				Type:              readinessHealthCheckType(responseProcess.ReadinessHealthCheck),
+				InvocationTimeout: responseProcess.ReadinessHealthCheck.Data.InvocationTimeoutSeconds,
+				Interval:          responseProcess.ReadinessHealthCheck.Data.IntervalSeconds,
+				HTTPEndpoint:      responseProcess.ReadinessHealthCheck.Data.HTTPEndpoint,
+			},
+		},
 		Relationships: ForRelationships(responseProcess.Relationships()),
 		Metadata: Metadata{
 			Labels:      responseProcess.Labels,
@@ -15,13 +15,14 @@ type ProcessStatsResponse struct {
 }
 
 type ProcessStatsResource struct {
-	Type      string        `json:"type"`
-	Index     int           `json:"index"`
-	State     string        `json:"state"`
-	Usage     *ProcessUsage `json:"usage,omitempty"`
-	MemQuota  *int64        `json:"mem_quota,omitempty"`
-	DiskQuota *int64        `json:"disk_quota,omitempty"`
-	Uptime    *int64        `json:"uptime,omitempty"`
+	Type         string        `json:"type"`
+	Index        int           `json:"index"`
+	State        string        `json:"state"`
+	Usage        *ProcessUsage `json:"usage,omitempty"`
+	MemQuota     *int64        `json:"mem_quota,omitempty"`
+	DiskQuota    *int64        `json:"disk_quota,omitempty"`
+	LogRateLimit *int64        `json:"log_rate_limit,omitempty"`
+	Uptime       *int64        `json:"uptime,omitempty"`
 }
 
 type ProcessUsage struct {
@@ -55,6 +56,7 @@ func ForProcessStats(gauges []stats.ProcessGauges, instancesState []stats.Proces
 			})
 			statsResource.MemQuota = gauge.MemQuota
 			statsResource.DiskQuota = gauge.DiskQuota
+			statsResource.LogRateLimit = gauge.LogRateLimit
 		}
 
 		resources = append(resources, statsResource)
@@ -23,12 +23,13 @@ var _ = Describe("Process Stats", func() {
 
 	BeforeEach(func() {
 		gauges = []stats.ProcessGauges{{
-			Index:     0,
-			CPU:       tools.PtrTo(500.0),
-			Mem:       tools.PtrTo(int64(512)),
-			Disk:      tools.PtrTo(int64(256)),
-			MemQuota:  tools.PtrTo(int64(1024)),
-			DiskQuota: tools.PtrTo(int64(2048)),
+			Index:        0,
+			CPU:          tools.PtrTo(500.0),
+			Mem:          tools.PtrTo(int64(512)),
+			Disk:         tools.PtrTo(int64(256)),
+			MemQuota:     tools.PtrTo(int64(1024)),
+			DiskQuota:    tools.PtrTo(int64(2048)),
+			LogRateLimit: tools.PtrTo(int64(4096)),
 		}, {
 			Index:     1,
 			CPU:       tools.PtrTo(501.0),
@@ -69,6 +70,7 @@ var _ = Describe("Process Stats", func() {
 					"state": "RUNNING",
 					"mem_quota": 1024,
 					"disk_quota": 2048,
+					"log_rate_limit": 4096,
 					"uptime": 8,
 					"usage": {
 						"time": "1970-01-01T00:00:10Z",
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -29,14 +30,15 @@ var _ = Describe("Process", func() {
 
 		BeforeEach(func() {
 			record = repositories.ProcessRecord{
-				GUID:             "process-guid",
-				SpaceGUID:        "space-guid",
-				AppGUID:          "app-guid",
-				Type:             "web",
-				Command:          "rackup",
-				DesiredInstances: 5,
-				MemoryMB:         256,
-				DiskQuotaMB:      1024,
+				GUID:                       "process-guid",
+				SpaceGUID:                  "space-guid",
+				AppGUID:                    "app-guid",
+				Type:                       "web",
+				Command:                    "rackup",
+				DesiredInstances:           5,
+				MemoryMB:                   256,
+				DiskQuotaMB:                1024,
+				LogRateLimitBytesPerSecond: 2048,
 				HealthCheck: repositories.HealthCheck{
 					Type: "port",
 				},
@@ -66,13 +68,19 @@ var _ = Describe("Process", func() {
 				"instances": 5,
 				"memory_in_mb": 256,
 				"disk_in_mb": 1024,
+				"log_rate_limit_in_bytes_per_second": 2048,
 				"health_check": {
 					"type": "port",
 					"data": {
 						"timeout": null,
-						"invocation_timeout": null
+						"invocation_timeout": null,
+						"interval": null
 					}
 				},
+				"readiness_health_check": {
+					"type": "process",
+					"data": {}
+				},
 				"relationships": {
 					"app": {
 						"data": {
@@ -110,5 +118,27 @@ var _ = Describe("Process", func() {
 				}
 			}`))
 		})
+
+		When("the process has an http readiness health check", func() {
+			BeforeEach(func() {
+				record.HealthCheck.Data.IntervalSeconds = 10
+				record.ReadinessHealthCheck = repositories.ReadinessHealthCheck{
+					Type: "http",
+					Data: repositories.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 2,
+						IntervalSeconds:          5,
+					},
+				}
+			})
+
+			It("presents both health checks", func() {
+				Expect(output).To(MatchJSONPath("$.health_check.data.interval", BeEquivalentTo(10)))
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.type", "http"))
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.data.invocation_timeout", BeEquivalentTo(2)))
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.data.interval", BeEquivalentTo(5)))
+				Expect(output).To(MatchJSONPath("$.readiness_health_check.data.endpoint", "/ready"))
+			})
+		})
 	})
 })
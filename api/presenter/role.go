@@ -6,6 +6,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/include"
 	"code.cloudfoundry.org/korifi/tools"
+	rbacv1 "k8s.io/api/rbac/v1"
 )
 
 const (
@@ -13,12 +14,20 @@ const (
 )
 
 type RoleResponse struct {
-	GUID          string                       `json:"guid"`
-	CreatedAt     string                       `json:"created_at"`
-	UpdatedAt     string                       `json:"updated_at"`
-	Type          string                       `json:"type"`
-	Relationships map[string]ToOneRelationship `json:"relationships"`
-	Links         RoleLinks                    `json:"links"`
+	GUID          string         `json:"guid"`
+	CreatedAt     string         `json:"created_at"`
+	UpdatedAt     string         `json:"updated_at"`
+	Type          string         `json:"type"`
+	Relationships map[string]any `json:"relationships"`
+	Links         RoleLinks      `json:"links"`
+}
+
+type RoleGroupRelationship struct {
+	Data RoleGroupRelationshipData `json:"data"`
+}
+
+type RoleGroupRelationshipData struct {
+	Name string `json:"name"`
 }
 
 type RoleLinks struct {
@@ -34,17 +43,28 @@ func ForRole(role repositories.RoleRecord, apiBaseURL url.URL, includes ...inclu
 		CreatedAt:     tools.ZeroIfNil(formatTimestamp(&role.CreatedAt)),
 		UpdatedAt:     tools.ZeroIfNil(formatTimestamp(role.UpdatedAt)),
 		Type:          role.Type,
-		Relationships: ForRelationships(role.Relationships()),
+		Relationships: map[string]any{},
 		Links: RoleLinks{
 			Self: &Link{
 				HRef: buildURL(apiBaseURL).appendPath(rolesBase, role.GUID).build(),
 			},
-			User: &Link{
-				HRef: buildURL(apiBaseURL).appendPath(usersBase, role.User).build(),
-			},
 		},
 	}
 
+	for name, relationship := range ForRelationships(role.Relationships()) {
+		resp.Relationships[name] = relationship
+	}
+
+	if role.Kind == rbacv1.GroupKind {
+		resp.Relationships["group"] = RoleGroupRelationship{
+			Data: RoleGroupRelationshipData{Name: role.User},
+		}
+	} else {
+		resp.Links.User = &Link{
+			HRef: buildURL(apiBaseURL).appendPath(usersBase, role.User).build(),
+		}
+	}
+
 	if role.Org != "" {
 		resp.Links.Organization = &Link{
 			HRef: buildURL(apiBaseURL).appendPath(orgsBase, role.Org).build(),
@@ -2,6 +2,7 @@ package presenter_test
 
 import (
 	"encoding/json"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"net/url"
 	"time"
 
@@ -87,4 +88,16 @@ var _ = Describe("Role", func() {
 			Expect(output).To(MatchJSONPath("$.links.space.href", "https://api.example.org/v3/spaces/the-space-guid"))
 		})
 	})
+
+	When("presenting a group role", func() {
+		BeforeEach(func() {
+			record.User = "the-group"
+			record.Kind = rbacv1.GroupKind
+		})
+
+		It("presents the group name instead of a user", func() {
+			Expect(output).To(MatchJSONPath("$.relationships.group.data.name", "the-group"))
+			Expect(output).To(MatchJSONPath("$.links.user", BeNil()))
+		})
+	})
 })
@@ -22,7 +22,7 @@ type RootResponse struct {
 
 const V3APIVersion = "3.117.0+cf-k8s"
 
-func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootResponse {
+func ForRoot(baseURL url.URL, uaaConfig config.UAA, oidcConfig config.OIDC, logCacheURL url.URL) RootResponse {
 	rootResponse := RootResponse{
 		Links: map[string]*APILink{
 			"self": {
@@ -48,6 +48,7 @@ func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootRes
 				},
 			},
 			"uaa":     nil,
+			"oidc":    nil,
 			"credhub": nil,
 			"routing": nil,
 			"logging": nil,
@@ -76,6 +77,14 @@ func ForRoot(baseURL url.URL, uaaConfig config.UAA, logCacheURL url.URL) RootRes
 		}
 	}
 
+	if oidcConfig.Enabled {
+		rootResponse.Links["oidc"] = &APILink{
+			Link: Link{
+				HRef: oidcConfig.IssuerURL,
+			},
+		}
+	}
+
 	return rootResponse
 }
 
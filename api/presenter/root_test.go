@@ -6,6 +6,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -27,14 +28,18 @@ var _ = Describe("Root endpoints", func() {
 	})
 
 	Context("/", func() {
-		var uaaConfig config.UAA
+		var (
+			uaaConfig  config.UAA
+			oidcConfig config.OIDC
+		)
 
 		BeforeEach(func() {
 			uaaConfig = config.UAA{}
+			oidcConfig = config.OIDC{}
 		})
 
 		JustBeforeEach(func() {
-			response := presenter.ForRoot(*baseURL, uaaConfig, *logCacheURL)
+			response := presenter.ForRoot(*baseURL, uaaConfig, oidcConfig, *logCacheURL)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -69,6 +74,7 @@ var _ = Describe("Root endpoints", func() {
 					},
 					"network_policy_v0": null,
 					"network_policy_v1": null,
+					"oidc": null,
 					"routing": null,
 					"self": {
 							"href": "https://api.example.org",
@@ -82,6 +88,20 @@ var _ = Describe("Root endpoints", func() {
 			}`))
 		})
 
+		When("OIDC support is enabled", func() {
+			BeforeEach(func() {
+				oidcConfig = config.OIDC{
+					Enabled:   true,
+					IssuerURL: "https://my.idp",
+				}
+			})
+
+			It("advertises the issuer", func() {
+				Expect(output).To(MatchJSONPath("$.links.oidc.href", "https://my.idp"))
+				Expect(output).To(MatchJSONPath("$.cf_on_k8s", true))
+			})
+		})
+
 		When("UAA support is enabled", func() {
 			BeforeEach(func() {
 				uaaConfig = config.UAA{
@@ -119,6 +139,7 @@ var _ = Describe("Root endpoints", func() {
 					},
 					"network_policy_v0": null,
 					"network_policy_v1": null,
+					"oidc": null,
 					"routing": null,
 					"self": {
 							"href": "https://api.example.org",
@@ -21,6 +21,7 @@ type RouteResponse struct {
 	Path         string             `json:"path"`
 	URL          string             `json:"url"`
 	Destinations []routeDestination `json:"destinations"`
+	Options      routeOptions       `json:"options"`
 
 	CreatedAt     string                       `json:"created_at"`
 	UpdatedAt     string                       `json:"updated_at"`
@@ -29,6 +30,13 @@ type RouteResponse struct {
 	Links         routeLinks                   `json:"links"`
 }
 
+type routeOptions struct {
+	LoadBalancing   string `json:"loadbalancing,omitempty"`
+	HashHeader      string `json:"hash_header,omitempty"`
+	RequestTimeout  *int32 `json:"request_timeout,omitempty"`
+	SessionAffinity bool   `json:"session_affinity,omitempty"`
+}
+
 type RouteDestinationsResponse struct {
 	Destinations []routeDestination     `json:"destinations"`
 	Links        routeDestinationsLinks `json:"links"`
@@ -78,6 +86,7 @@ func ForRoute(route repositories.RouteRecord, baseURL url.URL, includes ...inclu
 		UpdatedAt:     tools.ZeroIfNil(formatTimestamp(route.UpdatedAt)),
 		Relationships: ForRelationships(route.Relationships()),
 		Destinations:  destinations,
+		Options:       forRouteOptions(route.Options),
 		Metadata: Metadata{
 			Labels:      emptyMapIfNil(route.Labels),
 			Annotations: emptyMapIfNil(route.Annotations),
@@ -139,3 +148,51 @@ func routeURL(route repositories.RouteRecord) string {
 		return fmt.Sprintf("%s%s", route.Domain.Name, route.Path)
 	}
 }
+
+func forRouteOptions(options *repositories.RouteOptions) routeOptions {
+	if options == nil {
+		return routeOptions{}
+	}
+
+	return routeOptions{
+		LoadBalancing:   options.LoadBalancing,
+		HashHeader:      options.HashHeader,
+		RequestTimeout:  options.RequestTimeoutSeconds,
+		SessionAffinity: options.SessionAffinity,
+	}
+}
+
+type RouteSharedSpacesResponse struct {
+	Data  []RelationshipData     `json:"data"`
+	Links routeSharedSpacesLinks `json:"links"`
+}
+
+type routeSharedSpacesLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForRouteSharedSpaces(route repositories.RouteRecord, baseURL url.URL) RouteSharedSpacesResponse {
+	data := make([]RelationshipData, 0, len(route.SharedSpaceGUIDs))
+	for _, spaceGUID := range route.SharedSpaceGUIDs {
+		data = append(data, RelationshipData{GUID: spaceGUID})
+	}
+
+	return RouteSharedSpacesResponse{
+		Data: data,
+		Links: routeSharedSpacesLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(routesBase, route.GUID, "relationships/shared_spaces").build(),
+			},
+		},
+	}
+}
+
+type RouteReservationResponse struct {
+	MatchingRoute bool `json:"matching_route"`
+}
+
+func ForRouteReservation(matchingRoute bool) RouteReservationResponse {
+	return RouteReservationResponse{
+		MatchingRoute: matchingRoute,
+	}
+}
@@ -101,6 +101,7 @@ var _ = Describe("Route", func() {
 						"protocol": "http2"
 					}
 				],
+				"options": {},
 				"relationships": {
 					"space": {
 						"data": {
@@ -134,6 +135,23 @@ var _ = Describe("Route", func() {
 			}`))
 		})
 
+		When("the route has options", func() {
+			BeforeEach(func() {
+				record.Options = &repositories.RouteOptions{
+					LoadBalancing:         "hash",
+					HashHeader:            "X-Tenant",
+					RequestTimeoutSeconds: tools.PtrTo[int32](30),
+				}
+			})
+
+			It("presents the options", func() {
+				Expect(output).To(MatchJSONPath("$.options.loadbalancing", "hash"))
+				Expect(output).To(MatchJSONPath("$.options.hash_header", "X-Tenant"))
+				Expect(output).To(MatchJSONPath("$.options.request_timeout", BeEquivalentTo(30)))
+				Expect(output).To(MatchJSONPath("$.options", Not(HaveKey("session_affinity"))))
+			})
+		})
+
 		When("host is empty", func() {
 			BeforeEach(func() {
 				record.Host = ""
@@ -192,4 +210,50 @@ var _ = Describe("Route", func() {
 			}`))
 		})
 	})
+
+	Describe("shared spaces", func() {
+		BeforeEach(func() {
+			record.SharedSpaceGUIDs = []string{"shared-space-1", "shared-space-2"}
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForRouteSharedSpaces(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [
+					{ "guid": "shared-space-1" },
+					{ "guid": "shared-space-2" }
+				],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/routes/test-route-guid/relationships/shared_spaces"
+					}
+				}
+			}`))
+		})
+	})
+
+	When("the route is not shared", func() {
+		It("presents an empty list", func() {
+			record.SharedSpaceGUIDs = nil
+			var err error
+			output, err = json.Marshal(presenter.ForRouteSharedSpaces(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSONPath("$.data", BeEmpty()))
+		})
+	})
+
+	Describe("route reservation", func() {
+		It("returns the expected JSON", func() {
+			var err error
+			output, err = json.Marshal(presenter.ForRouteReservation(true))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(MatchJSON(`{"matching_route": true}`))
+		})
+	})
 })
@@ -13,27 +13,30 @@ const (
 )
 
 type ServiceBrokerLinks struct {
-	Self             Link `json:"self"`
-	ServiceOfferings Link `json:"service_offerings"`
+	Self             Link  `json:"self"`
+	ServiceOfferings Link  `json:"service_offerings"`
+	Space            *Link `json:"space,omitempty"`
 }
 
 type ServiceBrokerResponse struct {
-	GUID      string             `json:"guid"`
-	Name      string             `json:"name"`
-	URL       string             `json:"url"`
-	CreatedAt time.Time          `json:"created_at"`
-	UpdatedAt *time.Time         `json:"updated_at"`
-	Metadata  Metadata           `json:"metadata"`
-	Links     ServiceBrokerLinks `json:"links"`
+	GUID          string                       `json:"guid"`
+	Name          string                       `json:"name"`
+	URL           string                       `json:"url"`
+	CreatedAt     time.Time                    `json:"created_at"`
+	UpdatedAt     *time.Time                   `json:"updated_at"`
+	Relationships map[string]ToOneRelationship `json:"relationships"`
+	Metadata      Metadata                     `json:"metadata"`
+	Links         ServiceBrokerLinks           `json:"links"`
 }
 
 func ForServiceBroker(serviceBrokerRecord repositories.ServiceBrokerRecord, baseURL url.URL, includes ...include.Resource) ServiceBrokerResponse {
-	return ServiceBrokerResponse{
-		GUID:      serviceBrokerRecord.GUID,
-		Name:      serviceBrokerRecord.Name,
-		URL:       serviceBrokerRecord.URL,
-		CreatedAt: serviceBrokerRecord.CreatedAt,
-		UpdatedAt: serviceBrokerRecord.UpdatedAt,
+	response := ServiceBrokerResponse{
+		GUID:          serviceBrokerRecord.GUID,
+		Name:          serviceBrokerRecord.Name,
+		URL:           serviceBrokerRecord.URL,
+		CreatedAt:     serviceBrokerRecord.CreatedAt,
+		UpdatedAt:     serviceBrokerRecord.UpdatedAt,
+		Relationships: ForRelationships(serviceBrokerRecord.Relationships()),
 		Metadata: Metadata{
 			Labels:      serviceBrokerRecord.Metadata.Labels,
 			Annotations: serviceBrokerRecord.Metadata.Annotations,
@@ -47,4 +50,12 @@ func ForServiceBroker(serviceBrokerRecord repositories.ServiceBrokerRecord, base
 			},
 		},
 	}
+
+	if serviceBrokerRecord.SpaceGUID != "" {
+		response.Links.Space = &Link{
+			HRef: buildURL(baseURL).appendPath(spacesBase, serviceBrokerRecord.SpaceGUID).build(),
+		}
+	}
+
+	return response
 }
@@ -7,6 +7,7 @@ import (
 
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -54,6 +55,7 @@ var _ = Describe("Service Broker", func() {
 			"guid": "resource-guid",
 			"created_at": "1970-01-01T00:00:01Z",
 			"updated_at": "1970-01-01T00:00:02Z",
+			"relationships": {},
 			"metadata": {
 			  "labels": {
 				"label": "broker-label"
@@ -72,4 +74,15 @@ var _ = Describe("Service Broker", func() {
 			}
 		}`))
 	})
+
+	When("the broker is space-scoped", func() {
+		BeforeEach(func() {
+			record.SpaceGUID = "space-guid"
+		})
+
+		It("includes the space relationship and link", func() {
+			Expect(output).To(MatchJSONPath("$.relationships.space.data.guid", "space-guid"))
+			Expect(output).To(MatchJSONPath("$.links.space.href", "https://api.example.org/v3/spaces/space-guid"))
+		})
+	})
 })
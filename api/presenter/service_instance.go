@@ -52,10 +52,11 @@ type ServiceInstanceLinks struct {
 
 func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL, includes ...include.Resource) ServiceInstanceResponse {
 	response := ServiceInstanceResponse{
-		Name: serviceInstanceRecord.Name,
-		GUID: serviceInstanceRecord.GUID,
-		Type: serviceInstanceRecord.Type,
-		Tags: emptySliceIfNil(serviceInstanceRecord.Tags),
+		Name:           serviceInstanceRecord.Name,
+		GUID:           serviceInstanceRecord.GUID,
+		Type:           serviceInstanceRecord.Type,
+		Tags:           emptySliceIfNil(serviceInstanceRecord.Tags),
+		SyslogDrainURL: serviceInstanceRecord.SyslogDrainURL,
 		LastOperation: lastOperation{
 			CreatedAt:   tools.ZeroIfNil(formatTimestamp(&serviceInstanceRecord.CreatedAt)),
 			UpdatedAt:   tools.ZeroIfNil(formatTimestamp(serviceInstanceRecord.UpdatedAt)),
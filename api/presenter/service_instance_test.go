@@ -26,14 +26,15 @@ var _ = Describe("Service Instance", func() {
 		baseURL, err = url.Parse("https://api.example.org")
 		Expect(err).NotTo(HaveOccurred())
 		record = repositories.ServiceInstanceRecord{
-			Name:      "service-instance-name",
-			GUID:      "service-instance-guid",
-			PlanGUID:  "service-plan-guid",
-			SpaceGUID: "space-guid",
-			Tags:      []string{"foo", "bar"},
-			Type:      "user-provided",
-			CreatedAt: time.UnixMilli(1000),
-			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+			Name:           "service-instance-name",
+			GUID:           "service-instance-guid",
+			PlanGUID:       "service-plan-guid",
+			SpaceGUID:      "space-guid",
+			Tags:           []string{"foo", "bar"},
+			Type:           "user-provided",
+			SyslogDrainURL: tools.PtrTo("syslog://logs.example.com:514"),
+			CreatedAt:      time.UnixMilli(1000),
+			UpdatedAt:      tools.PtrTo(time.UnixMilli(2000)),
 			Labels: map[string]string{
 				"foo": "bar",
 			},
@@ -103,7 +104,7 @@ var _ = Describe("Service Instance", func() {
 				}
 			},
 			"route_service_url": null,
-			"syslog_drain_url": null,
+			"syslog_drain_url": "syslog://logs.example.com:514",
 			"tags": [
 				"foo",
 				"bar"
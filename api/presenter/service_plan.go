@@ -67,7 +67,8 @@ type ServicePlanFeatures struct {
 }
 
 type MaintenanceInfo struct {
-	Version string `json:"version"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
 }
 
 func ForServicePlan(servicePlan repositories.ServicePlanRecord, baseURL url.URL, includes ...include.Resource) ServicePlanResponse {
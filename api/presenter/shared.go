@@ -181,9 +181,9 @@ func emptyMapIfNil[V any](m map[string]V) map[string]V {
 	return m
 }
 
-func emptySliceIfNil(m []string) []string {
+func emptySliceIfNil[T any](m []T) []T {
 	if m == nil {
-		return []string{}
+		return []T{}
 	}
 	return m
 }
@@ -13,13 +13,17 @@ const (
 )
 
 type StackResponse struct {
-	GUID        string     `json:"guid"`
-	CreatedAt   string     `json:"created_at"`
-	UpdatedAt   string     `json:"updated_at"`
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Metadata    Metadata   `json:"metadata"`
-	Links       StackLinks `json:"links"`
+	GUID             string     `json:"guid"`
+	CreatedAt        string     `json:"created_at"`
+	UpdatedAt        string     `json:"updated_at"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description"`
+	Default          bool       `json:"default"`
+	State            string     `json:"state"`
+	BuildRootfsImage string     `json:"build_rootfs_image"`
+	RunRootfsImage   string     `json:"run_rootfs_image"`
+	Metadata         Metadata   `json:"metadata"`
+	Links            StackLinks `json:"links"`
 }
 
 type StackLinks struct {
@@ -28,10 +32,19 @@ type StackLinks struct {
 
 func ForStack(stackRecord repositories.StackRecord, baseURL url.URL, includes ...include.Resource) StackResponse {
 	return StackResponse{
-		GUID:      stackRecord.GUID,
-		CreatedAt: tools.ZeroIfNil(formatTimestamp(&stackRecord.CreatedAt)),
-		UpdatedAt: tools.ZeroIfNil(formatTimestamp(stackRecord.UpdatedAt)),
-		Name:      stackRecord.Name,
+		GUID:             stackRecord.GUID,
+		CreatedAt:        tools.ZeroIfNil(formatTimestamp(&stackRecord.CreatedAt)),
+		UpdatedAt:        tools.ZeroIfNil(formatTimestamp(stackRecord.UpdatedAt)),
+		Name:             stackRecord.Name,
+		Description:      stackRecord.Description,
+		Default:          stackRecord.Default,
+		State:            stackRecord.State,
+		BuildRootfsImage: stackRecord.BuildRootfsImage,
+		RunRootfsImage:   stackRecord.RunRootfsImage,
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(stackRecord.Labels),
+			Annotations: emptyMapIfNil(stackRecord.Annotations),
+		},
 		Links: StackLinks{
 			Self: Link{
 				HRef: buildURL(baseURL).appendPath(stacksBase, stackRecord.GUID).build(),
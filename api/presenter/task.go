@@ -26,6 +26,7 @@ type TaskResponse struct {
 	UpdatedAt     string                       `json:"updated_at"`
 	MemoryMB      int64                        `json:"memory_in_mb"`
 	DiskMB        int64                        `json:"disk_in_mb"`
+	LogRateLimit  int64                        `json:"log_rate_limit_in_bytes_per_second"`
 	State         string                       `json:"state"`
 	Result        TaskResult                   `json:"result"`
 }
@@ -49,17 +50,18 @@ func ForTask(responseTask repositories.TaskRecord, baseURL url.URL, includes ...
 	}
 
 	return TaskResponse{
-		Name:        responseTask.Name,
-		GUID:        responseTask.GUID,
-		Command:     responseTask.Command,
-		SequenceID:  responseTask.SequenceID,
-		DropletGUID: responseTask.DropletGUID,
-		CreatedAt:   tools.ZeroIfNil(formatTimestamp(&responseTask.CreatedAt)),
-		UpdatedAt:   tools.ZeroIfNil(formatTimestamp(responseTask.UpdatedAt)),
-		MemoryMB:    responseTask.MemoryMB,
-		DiskMB:      responseTask.DiskMB,
-		State:       responseTask.State,
-		Result:      result,
+		Name:         responseTask.Name,
+		GUID:         responseTask.GUID,
+		Command:      responseTask.Command,
+		SequenceID:   responseTask.SequenceID,
+		DropletGUID:  responseTask.DropletGUID,
+		CreatedAt:    tools.ZeroIfNil(formatTimestamp(&responseTask.CreatedAt)),
+		UpdatedAt:    tools.ZeroIfNil(formatTimestamp(responseTask.UpdatedAt)),
+		MemoryMB:     responseTask.MemoryMB,
+		DiskMB:       responseTask.DiskMB,
+		LogRateLimit: responseTask.LogRateLimitBytesPerSecond,
+		State:        responseTask.State,
+		Result:       result,
 		Metadata: Metadata{
 			Labels:      emptyMapIfNil(responseTask.Labels),
 			Annotations: emptyMapIfNil(responseTask.Annotations),
@@ -0,0 +1,163 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/include"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+const (
+	appUsageEventsBase     = "/v3/app_usage_events"
+	serviceUsageEventsBase = "/v3/service_usage_events"
+)
+
+type UsageEventGUIDName struct {
+	GUID *string `json:"guid"`
+	Name *string `json:"name"`
+}
+
+type UsageEventGUID struct {
+	GUID string `json:"guid"`
+}
+
+type UsageEventStateChange[T any] struct {
+	Current  T  `json:"current"`
+	Previous *T `json:"previous"`
+}
+
+type AppUsageEventResponse struct {
+	GUID                  string                        `json:"guid"`
+	CreatedAt             string                        `json:"created_at"`
+	UpdatedAt             string                        `json:"updated_at"`
+	State                 UsageEventStateChange[string] `json:"state"`
+	App                   UsageEventGUIDName            `json:"app"`
+	Process               AppUsageEventProcess          `json:"process"`
+	Space                 UsageEventGUIDName            `json:"space"`
+	Organization          UsageEventGUID                `json:"organization"`
+	Buildpack             UsageEventGUIDName            `json:"buildpack"`
+	Task                  UsageEventGUIDName            `json:"task"`
+	MemoryInMBPerInstance UsageEventStateChange[int64]  `json:"memory_in_mb_per_instance"`
+	InstanceCount         UsageEventStateChange[int32]  `json:"instance_count"`
+	Links                 map[string]Link               `json:"links"`
+}
+
+type AppUsageEventProcess struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+}
+
+func ForAppUsageEvent(record repositories.AppUsageEventRecord, baseURL url.URL, includes ...include.Resource) AppUsageEventResponse {
+	createdAt := tools.ZeroIfNil(formatTimestamp(&record.CreatedAt))
+
+	buildpack := UsageEventGUIDName{}
+	if record.BuildpackName != "" {
+		buildpack.Name = tools.PtrTo(record.BuildpackName)
+	}
+
+	return AppUsageEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		State: UsageEventStateChange[string]{
+			Current:  record.State,
+			Previous: record.PreviousState,
+		},
+		App: UsageEventGUIDName{
+			GUID: tools.PtrTo(record.AppGUID),
+			Name: tools.PtrTo(record.AppName),
+		},
+		Process: AppUsageEventProcess{
+			GUID: record.ProcessGUID,
+			Type: record.ProcessType,
+		},
+		Space: UsageEventGUIDName{
+			GUID: tools.PtrTo(record.SpaceGUID),
+			Name: tools.PtrTo(record.SpaceName),
+		},
+		Organization: UsageEventGUID{
+			GUID: record.OrgGUID,
+		},
+		Buildpack: buildpack,
+		Task:      UsageEventGUIDName{},
+		MemoryInMBPerInstance: UsageEventStateChange[int64]{
+			Current:  record.MemoryInMBPerInstance,
+			Previous: record.PreviousMemoryInMBPerInstance,
+		},
+		InstanceCount: UsageEventStateChange[int32]{
+			Current:  record.InstanceCount,
+			Previous: record.PreviousInstanceCount,
+		},
+		Links: map[string]Link{
+			"self": {
+				HRef: buildURL(baseURL).appendPath(appUsageEventsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+type ServiceUsageEventResponse struct {
+	GUID            string                    `json:"guid"`
+	CreatedAt       string                    `json:"created_at"`
+	UpdatedAt       string                    `json:"updated_at"`
+	State           string                    `json:"state"`
+	Space           UsageEventGUIDName        `json:"space"`
+	Organization    UsageEventGUID            `json:"organization"`
+	ServiceInstance ServiceUsageEventInstance `json:"service_instance"`
+	ServicePlan     UsageEventGUIDName        `json:"service_plan"`
+	ServiceOffering UsageEventGUIDName        `json:"service_offering"`
+	ServiceBroker   UsageEventGUIDName        `json:"service_broker"`
+	Links           map[string]Link           `json:"links"`
+}
+
+type ServiceUsageEventInstance struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+func ForServiceUsageEvent(record repositories.ServiceUsageEventRecord, baseURL url.URL, includes ...include.Resource) ServiceUsageEventResponse {
+	createdAt := tools.ZeroIfNil(formatTimestamp(&record.CreatedAt))
+
+	return ServiceUsageEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: createdAt,
+		UpdatedAt: createdAt,
+		State:     record.State,
+		Space: UsageEventGUIDName{
+			GUID: tools.PtrTo(record.SpaceGUID),
+			Name: tools.PtrTo(record.SpaceName),
+		},
+		Organization: UsageEventGUID{
+			GUID: record.OrgGUID,
+		},
+		ServiceInstance: ServiceUsageEventInstance{
+			GUID: record.ServiceInstanceGUID,
+			Name: record.ServiceInstanceName,
+			Type: record.ServiceInstanceType,
+		},
+		ServicePlan:     usageEventGUIDName(record.ServicePlanGUID, record.ServicePlanName),
+		ServiceOffering: usageEventGUIDName(record.ServiceOfferingGUID, record.ServiceOfferingName),
+		ServiceBroker:   usageEventGUIDName(record.ServiceBrokerGUID, record.ServiceBrokerName),
+		Links: map[string]Link{
+			"self": {
+				HRef: buildURL(baseURL).appendPath(serviceUsageEventsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+// User-provided service instances have no plan, offering or broker, which is
+// presented as null guids and names
+func usageEventGUIDName(guid, name string) UsageEventGUIDName {
+	result := UsageEventGUIDName{}
+	if guid != "" {
+		result.GUID = tools.PtrTo(guid)
+	}
+	if name != "" {
+		result.Name = tools.PtrTo(name)
+	}
+
+	return result
+}
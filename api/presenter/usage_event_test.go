@@ -0,0 +1,161 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Usage Events", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForAppUsageEvent", func() {
+		var record repositories.AppUsageEventRecord
+
+		BeforeEach(func() {
+			record = repositories.AppUsageEventRecord{
+				GUID:                          "event-guid",
+				CreatedAt:                     time.UnixMilli(1000),
+				State:                         "SCALED",
+				PreviousState:                 tools.PtrTo("STARTED"),
+				AppGUID:                       "app-guid",
+				AppName:                       "app-name",
+				ProcessGUID:                   "process-guid",
+				ProcessType:                   "web",
+				SpaceGUID:                     "space-guid",
+				SpaceName:                     "space-name",
+				OrgGUID:                       "org-guid",
+				BuildpackName:                 "go_buildpack",
+				InstanceCount:                 3,
+				PreviousInstanceCount:         tools.PtrTo[int32](1),
+				MemoryInMBPerInstance:         512,
+				PreviousMemoryInMBPerInstance: tools.PtrTo[int64](256),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForAppUsageEvent(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:01Z",
+				"state": {"current": "SCALED", "previous": "STARTED"},
+				"app": {"guid": "app-guid", "name": "app-name"},
+				"process": {"guid": "process-guid", "type": "web"},
+				"space": {"guid": "space-guid", "name": "space-name"},
+				"organization": {"guid": "org-guid"},
+				"buildpack": {"guid": null, "name": "go_buildpack"},
+				"task": {"guid": null, "name": null},
+				"memory_in_mb_per_instance": {"current": 512, "previous": 256},
+				"instance_count": {"current": 3, "previous": 1},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/app_usage_events/event-guid"}
+				}
+			}`))
+		})
+
+		When("there is no previous usage", func() {
+			BeforeEach(func() {
+				record.PreviousState = nil
+				record.PreviousInstanceCount = nil
+				record.PreviousMemoryInMBPerInstance = nil
+				record.BuildpackName = ""
+			})
+
+			It("presents the previous values as null", func() {
+				Expect(output).To(MatchJSONPath("$.state.previous", BeNil()))
+				Expect(output).To(MatchJSONPath("$.instance_count.previous", BeNil()))
+				Expect(output).To(MatchJSONPath("$.memory_in_mb_per_instance.previous", BeNil()))
+				Expect(output).To(MatchJSONPath("$.buildpack.name", BeNil()))
+			})
+		})
+	})
+
+	Describe("ForServiceUsageEvent", func() {
+		var record repositories.ServiceUsageEventRecord
+
+		BeforeEach(func() {
+			record = repositories.ServiceUsageEventRecord{
+				GUID:                "event-guid",
+				CreatedAt:           time.UnixMilli(1000),
+				State:               "CREATED",
+				SpaceGUID:           "space-guid",
+				SpaceName:           "space-name",
+				OrgGUID:             "org-guid",
+				ServiceInstanceGUID: "instance-guid",
+				ServiceInstanceName: "instance-name",
+				ServiceInstanceType: "managed_service_instance",
+				ServicePlanGUID:     "plan-guid",
+				ServicePlanName:     "plan-name",
+				ServiceOfferingGUID: "offering-guid",
+				ServiceOfferingName: "offering-name",
+				ServiceBrokerGUID:   "broker-guid",
+				ServiceBrokerName:   "broker-name",
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForServiceUsageEvent(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:01Z",
+				"state": "CREATED",
+				"space": {"guid": "space-guid", "name": "space-name"},
+				"organization": {"guid": "org-guid"},
+				"service_instance": {"guid": "instance-guid", "name": "instance-name", "type": "managed_service_instance"},
+				"service_plan": {"guid": "plan-guid", "name": "plan-name"},
+				"service_offering": {"guid": "offering-guid", "name": "offering-name"},
+				"service_broker": {"guid": "broker-guid", "name": "broker-name"},
+				"links": {
+					"self": {"href": "https://api.example.org/v3/service_usage_events/event-guid"}
+				}
+			}`))
+		})
+
+		When("the service instance is user-provided", func() {
+			BeforeEach(func() {
+				record.ServiceInstanceType = "user_provided_service_instance"
+				record.ServicePlanGUID = ""
+				record.ServicePlanName = ""
+				record.ServiceOfferingGUID = ""
+				record.ServiceOfferingName = ""
+				record.ServiceBrokerGUID = ""
+				record.ServiceBrokerName = ""
+			})
+
+			It("presents the plan, offering and broker as null", func() {
+				Expect(output).To(MatchJSONPath("$.service_plan.guid", BeNil()))
+				Expect(output).To(MatchJSONPath("$.service_offering.name", BeNil()))
+				Expect(output).To(MatchJSONPath("$.service_broker.guid", BeNil()))
+			})
+		})
+	})
+})
@@ -0,0 +1,221 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const AutoscalingPolicyResourceType = "Autoscaling Policy"
+
+type AutoscalingPolicyRecord struct {
+	AppGUID          string
+	SpaceGUID        string
+	InstanceMinCount int32
+	InstanceMaxCount int32
+	ScalingRules     []korifiv1alpha1.ScalingRule
+	Schedules        *korifiv1alpha1.AutoscalingSchedules
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+func (r AutoscalingPolicyRecord) GetResourceType() string {
+	return AutoscalingPolicyResourceType
+}
+
+type SetAutoscalingPolicyMessage struct {
+	AppGUID          string
+	SpaceGUID        string
+	InstanceMinCount int32
+	InstanceMaxCount int32
+	ScalingRules     []korifiv1alpha1.ScalingRule
+	Schedules        *korifiv1alpha1.AutoscalingSchedules
+}
+
+func (m SetAutoscalingPolicyMessage) apply(policy *korifiv1alpha1.CFAutoscalingPolicy) {
+	policy.Labels = tools.SetMapValue(policy.Labels, korifiv1alpha1.CFAppGUIDLabelKey, m.AppGUID)
+	policy.Spec = korifiv1alpha1.CFAutoscalingPolicySpec{
+		ProcessRef:       corev1.LocalObjectReference{Name: policy.Name},
+		InstanceMinCount: m.InstanceMinCount,
+		InstanceMaxCount: m.InstanceMaxCount,
+		ScalingRules:     m.ScalingRules,
+		Schedules:        m.Schedules,
+	}
+}
+
+type DeleteAutoscalingPolicyMessage struct {
+	AppGUID   string
+	SpaceGUID string
+}
+
+type ScalingEventRecord struct {
+	AppGUID      string
+	Timestamp    time.Time
+	ScalingType  string
+	Status       string
+	OldInstances int32
+	NewInstances int32
+	Reason       string
+	Error        string
+}
+
+type ListScalingHistoryMessage struct {
+	AppGUID    string
+	SpaceGUID  string
+	StartTime  *time.Time
+	EndTime    *time.Time
+	Descending bool
+	Pagination Pagination
+}
+
+func (m ListScalingHistoryMessage) matches(event korifiv1alpha1.ScalingEvent) bool {
+	return (m.StartTime == nil || !event.Timestamp.Time.Before(*m.StartTime)) &&
+		(m.EndTime == nil || !event.Timestamp.Time.After(*m.EndTime))
+}
+
+type AutoscalingPolicyRepo struct {
+	klient Klient
+}
+
+func NewAutoscalingPolicyRepo(klient Klient) *AutoscalingPolicyRepo {
+	return &AutoscalingPolicyRepo{
+		klient: klient,
+	}
+}
+
+// The policy of an app scales its web process and is named after it
+func autoscalingPolicyName(appGUID string) string {
+	return tools.NamespacedUUID(appGUID, korifiv1alpha1.ProcessTypeWeb)
+}
+
+func (r *AutoscalingPolicyRepo) GetAutoscalingPolicy(ctx context.Context, authInfo authorization.Info, spaceGUID, appGUID string) (AutoscalingPolicyRecord, error) {
+	policy := &korifiv1alpha1.CFAutoscalingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: spaceGUID,
+			Name:      autoscalingPolicyName(appGUID),
+		},
+	}
+	err := r.klient.Get(ctx, policy)
+	if err != nil {
+		return AutoscalingPolicyRecord{}, fmt.Errorf("failed to get autoscaling policy for app %q: %w", appGUID, apierrors.FromK8sError(err, AutoscalingPolicyResourceType))
+	}
+
+	return cfAutoscalingPolicyToRecord(*policy), nil
+}
+
+func (r *AutoscalingPolicyRepo) SetAutoscalingPolicy(ctx context.Context, authInfo authorization.Info, message SetAutoscalingPolicyMessage) (AutoscalingPolicyRecord, error) {
+	policy := &korifiv1alpha1.CFAutoscalingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      autoscalingPolicyName(message.AppGUID),
+		},
+	}
+
+	err := r.klient.Get(ctx, policy)
+	if k8serrors.IsNotFound(err) {
+		message.apply(policy)
+		if err = r.klient.Create(ctx, policy); err != nil {
+			return AutoscalingPolicyRecord{}, apierrors.FromK8sError(err, AutoscalingPolicyResourceType)
+		}
+
+		return cfAutoscalingPolicyToRecord(*policy), nil
+	}
+	if err != nil {
+		return AutoscalingPolicyRecord{}, apierrors.FromK8sError(err, AutoscalingPolicyResourceType)
+	}
+
+	err = r.klient.Patch(ctx, policy, func() error {
+		message.apply(policy)
+		return nil
+	})
+	if err != nil {
+		return AutoscalingPolicyRecord{}, apierrors.FromK8sError(err, AutoscalingPolicyResourceType)
+	}
+
+	return cfAutoscalingPolicyToRecord(*policy), nil
+}
+
+func (r *AutoscalingPolicyRepo) DeleteAutoscalingPolicy(ctx context.Context, authInfo authorization.Info, message DeleteAutoscalingPolicyMessage) error {
+	policy := &korifiv1alpha1.CFAutoscalingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      autoscalingPolicyName(message.AppGUID),
+		},
+	}
+
+	return apierrors.FromK8sError(r.klient.Delete(ctx, policy), AutoscalingPolicyResourceType)
+}
+
+// ListScalingHistory returns the scaling events of the app, oldest first
+// unless requested otherwise. Apps without a policy have no scaling history.
+func (r *AutoscalingPolicyRepo) ListScalingHistory(ctx context.Context, authInfo authorization.Info, message ListScalingHistoryMessage) (ListResult[ScalingEventRecord], error) {
+	policy := &korifiv1alpha1.CFAutoscalingPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: message.SpaceGUID,
+			Name:      autoscalingPolicyName(message.AppGUID),
+		},
+	}
+	err := r.klient.Get(ctx, policy)
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return ListResult[ScalingEventRecord]{}, fmt.Errorf("failed to get autoscaling policy for app %q: %w", message.AppGUID, apierrors.FromK8sError(err, AutoscalingPolicyResourceType))
+	}
+
+	records := []ScalingEventRecord{}
+	for _, event := range policy.Status.ScalingHistory {
+		if message.matches(event) {
+			records = append(records, toScalingEventRecord(message.AppGUID, event))
+		}
+	}
+	if message.Descending {
+		slices.Reverse(records)
+	}
+
+	recordsPage := descriptors.SinglePage(records, len(records))
+	if !message.Pagination.IsZero() {
+		recordsPage, err = descriptors.GetPage(records, message.Pagination.PerPage, message.Pagination.Page)
+		if err != nil {
+			return ListResult[ScalingEventRecord]{}, fmt.Errorf("failed to page scaling history: %w", err)
+		}
+	}
+
+	return ListResult[ScalingEventRecord]{
+		PageInfo: recordsPage.PageInfo,
+		Records:  recordsPage.Items,
+	}, nil
+}
+
+func toScalingEventRecord(appGUID string, event korifiv1alpha1.ScalingEvent) ScalingEventRecord {
+	return ScalingEventRecord{
+		AppGUID:      appGUID,
+		Timestamp:    event.Timestamp.Time,
+		ScalingType:  string(event.ScalingType),
+		Status:       string(event.Status),
+		OldInstances: event.OldInstances,
+		NewInstances: event.NewInstances,
+		Reason:       event.Reason,
+		Error:        event.Error,
+	}
+}
+
+func cfAutoscalingPolicyToRecord(policy korifiv1alpha1.CFAutoscalingPolicy) AutoscalingPolicyRecord {
+	return AutoscalingPolicyRecord{
+		AppGUID:          policy.Labels[korifiv1alpha1.CFAppGUIDLabelKey],
+		SpaceGUID:        policy.Namespace,
+		InstanceMinCount: policy.Spec.InstanceMinCount,
+		InstanceMaxCount: policy.Spec.InstanceMaxCount,
+		ScalingRules:     policy.Spec.ScalingRules,
+		Schedules:        policy.Spec.Schedules,
+		CreatedAt:        policy.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(&policy),
+	}
+}
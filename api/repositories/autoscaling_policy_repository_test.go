@@ -0,0 +1,326 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AutoscalingPolicyRepo", func() {
+	var (
+		repo       *repositories.AutoscalingPolicyRepo
+		space      *korifiv1alpha1.CFSpace
+		appGUID    string
+		policyName string
+	)
+
+	BeforeEach(func() {
+		repo = repositories.NewAutoscalingPolicyRepo(spaceScopedKlient)
+		org := createOrgWithCleanup(ctx, uuid.NewString())
+		space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+		appGUID = uuid.NewString()
+		policyName = tools.NamespacedUUID(appGUID, korifiv1alpha1.ProcessTypeWeb)
+	})
+
+	Describe("GetAutoscalingPolicy", func() {
+		var (
+			record repositories.AutoscalingPolicyRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			policy := &korifiv1alpha1.CFAutoscalingPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      policyName,
+					Namespace: space.Name,
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey: appGUID,
+					},
+				},
+				Spec: korifiv1alpha1.CFAutoscalingPolicySpec{
+					ProcessRef:       corev1.LocalObjectReference{Name: policyName},
+					InstanceMinCount: 1,
+					InstanceMaxCount: 3,
+					ScalingRules: []korifiv1alpha1.ScalingRule{{
+						MetricType: korifiv1alpha1.AutoscalingMetricCPU,
+						Threshold:  80,
+						Operator:   ">",
+						Adjustment: "+1",
+					}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetAutoscalingPolicy(ctx, authInfo, space.Name, appGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceManagerRole.Name, space.Name)
+			})
+
+			It("returns the policy", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.AppGUID).To(Equal(appGUID))
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+				Expect(record.InstanceMinCount).To(BeEquivalentTo(1))
+				Expect(record.InstanceMaxCount).To(BeEquivalentTo(3))
+				Expect(record.ScalingRules).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"MetricType": Equal(korifiv1alpha1.AutoscalingMetricCPU),
+					"Threshold":  BeEquivalentTo(80),
+				})))
+			})
+		})
+
+		When("the app has no policy", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				appGUID = uuid.NewString()
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("SetAutoscalingPolicy", func() {
+		var (
+			message repositories.SetAutoscalingPolicyMessage
+			record  repositories.AutoscalingPolicyRecord
+			setErr  error
+		)
+
+		BeforeEach(func() {
+			message = repositories.SetAutoscalingPolicyMessage{
+				AppGUID:          appGUID,
+				SpaceGUID:        space.Name,
+				InstanceMinCount: 2,
+				InstanceMaxCount: 4,
+				ScalingRules: []korifiv1alpha1.ScalingRule{{
+					MetricType: korifiv1alpha1.AutoscalingMetricMemoryUtil,
+					Threshold:  30,
+					Operator:   "<",
+					Adjustment: "-1",
+				}},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, setErr = repo.SetAutoscalingPolicy(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(setErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a policy for the app web process", func() {
+				Expect(setErr).NotTo(HaveOccurred())
+				Expect(record.AppGUID).To(Equal(appGUID))
+				Expect(record.InstanceMinCount).To(BeEquivalentTo(2))
+
+				policy := &korifiv1alpha1.CFAutoscalingPolicy{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: policyName}, policy)).To(Succeed())
+				Expect(policy.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, appGUID))
+				Expect(policy.Spec.ProcessRef.Name).To(Equal(policyName))
+				Expect(policy.Spec.InstanceMaxCount).To(BeEquivalentTo(4))
+				Expect(policy.Spec.ScalingRules).To(Equal(message.ScalingRules))
+			})
+
+			When("the app already has a policy", func() {
+				BeforeEach(func() {
+					_, err := repo.SetAutoscalingPolicy(ctx, authInfo, repositories.SetAutoscalingPolicyMessage{
+						AppGUID:          appGUID,
+						SpaceGUID:        space.Name,
+						InstanceMinCount: 1,
+						InstanceMaxCount: 10,
+						Schedules: &korifiv1alpha1.AutoscalingSchedules{
+							Timezone: "UTC",
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("replaces the policy", func() {
+					Expect(setErr).NotTo(HaveOccurred())
+
+					policy := &korifiv1alpha1.CFAutoscalingPolicy{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: policyName}, policy)).To(Succeed())
+					Expect(policy.Spec.InstanceMinCount).To(BeEquivalentTo(2))
+					Expect(policy.Spec.InstanceMaxCount).To(BeEquivalentTo(4))
+					Expect(policy.Spec.Schedules).To(BeNil())
+				})
+			})
+		})
+	})
+
+	Describe("DeleteAutoscalingPolicy", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFAutoscalingPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      policyName,
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFAutoscalingPolicySpec{
+					ProcessRef:       corev1.LocalObjectReference{Name: policyName},
+					InstanceMinCount: 1,
+					InstanceMaxCount: 3,
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteAutoscalingPolicy(ctx, authInfo, repositories.DeleteAutoscalingPolicyMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: space.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the policy", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				policy := &korifiv1alpha1.CFAutoscalingPolicy{}
+				err := k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: policyName}, policy)
+				Expect(err).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+	})
+
+	Describe("ListScalingHistory", func() {
+		var (
+			message    repositories.ListScalingHistoryMessage
+			result     repositories.ListResult[repositories.ScalingEventRecord]
+			listErr    error
+			eventTimes []time.Time
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+
+			policy := &korifiv1alpha1.CFAutoscalingPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      policyName,
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFAutoscalingPolicySpec{
+					ProcessRef:       corev1.LocalObjectReference{Name: policyName},
+					InstanceMinCount: 1,
+					InstanceMaxCount: 3,
+				},
+			}
+			Expect(k8sClient.Create(ctx, policy)).To(Succeed())
+
+			now := time.Now().Truncate(time.Second)
+			eventTimes = []time.Time{now.Add(-2 * time.Hour), now.Add(-time.Hour), now}
+			Expect(k8s.Patch(ctx, k8sClient, policy, func() {
+				for i, eventTime := range eventTimes {
+					policy.Status.ScalingHistory = append(policy.Status.ScalingHistory, korifiv1alpha1.ScalingEvent{
+						Timestamp:    metav1.NewTime(eventTime),
+						ScalingType:  korifiv1alpha1.ScalingTypeDynamic,
+						Status:       korifiv1alpha1.ScalingStatusSucceeded,
+						OldInstances: int32(i + 1),
+						NewInstances: int32(i + 2),
+						Reason:       "cpu",
+					})
+				}
+			})).To(Succeed())
+
+			message = repositories.ListScalingHistoryMessage{
+				AppGUID:   appGUID,
+				SpaceGUID: space.Name,
+			}
+		})
+
+		JustBeforeEach(func() {
+			result, listErr = repo.ListScalingHistory(ctx, authInfo, message)
+		})
+
+		It("returns the scaling events oldest first", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(result.Records).To(HaveLen(3))
+			Expect(result.Records[0]).To(MatchAllFields(Fields{
+				"AppGUID":      Equal(appGUID),
+				"Timestamp":    BeTemporally("==", eventTimes[0]),
+				"ScalingType":  Equal("dynamic"),
+				"Status":       Equal("succeeded"),
+				"OldInstances": BeEquivalentTo(1),
+				"NewInstances": BeEquivalentTo(2),
+				"Reason":       Equal("cpu"),
+				"Error":        BeEmpty(),
+			}))
+		})
+
+		When("filtering by time and ordering descending", func() {
+			BeforeEach(func() {
+				message.StartTime = tools.PtrTo(eventTimes[1])
+				message.Descending = true
+			})
+
+			It("returns the matching events newest first", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(result.Records).To(HaveLen(2))
+				Expect(result.Records[0].Timestamp).To(BeTemporally("==", eventTimes[2]))
+				Expect(result.Records[1].Timestamp).To(BeTemporally("==", eventTimes[1]))
+			})
+		})
+
+		When("paging", func() {
+			BeforeEach(func() {
+				message.Pagination = repositories.Pagination{PerPage: 2, Page: 2}
+			})
+
+			It("returns the requested page", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(result.PageInfo.TotalResults).To(Equal(3))
+				Expect(result.PageInfo.TotalPages).To(Equal(2))
+				Expect(result.Records).To(HaveLen(1))
+			})
+		})
+
+		When("the app has no policy", func() {
+			BeforeEach(func() {
+				message.AppGUID = uuid.NewString()
+			})
+
+			It("returns an empty history", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(result.Records).To(BeEmpty())
+			})
+		})
+	})
+})
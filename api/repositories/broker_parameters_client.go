@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -9,6 +10,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// ErrInstancesNotRetrievable is returned when the service offering of an
+// instance does not support fetching instances from the broker
+var ErrInstancesNotRetrievable = errors.New("the service offering does not support retrieving instances")
+
 type ParamsResult struct {
 	Parameters map[string]any
 }
@@ -51,3 +56,31 @@ func (c *ServiceBrokerClient) GetServiceBindingParameters(ctx context.Context, s
 
 	return binding.Parameters, nil
 }
+
+func (c *ServiceBrokerClient) GetServiceInstanceParameters(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (map[string]any, error) {
+	assetsClient := osbapi.NewAssets(c.k8sClient, c.rootNamespace)
+	siAssets, err := assetsClient.GetServiceInstanceAssets(ctx, serviceInstance)
+	if err != nil {
+		return map[string]any{}, fmt.Errorf("failed to get service instance assets: %w", err)
+	}
+
+	if !siAssets.ServiceOffering.Spec.BrokerCatalog.Features.InstancesRetrievable {
+		return map[string]any{}, ErrInstancesNotRetrievable
+	}
+
+	osbapiClient, err := c.clientFactory.CreateClient(ctx, siAssets.ServiceBroker)
+	if err != nil {
+		return map[string]any{}, fmt.Errorf("failed to create osbapi client: %w", err)
+	}
+
+	instance, err := osbapiClient.GetServiceInstance(ctx, osbapi.GetServiceInstanceRequest{
+		InstanceID: serviceInstance.Name,
+		ServiceId:  siAssets.ServiceOffering.Spec.BrokerCatalog.ID,
+		PlanID:     siAssets.ServicePlan.Spec.BrokerCatalog.ID,
+	})
+	if err != nil {
+		return map[string]any{}, fmt.Errorf("failed to fetch service instance: %w", err)
+	}
+
+	return instance.Parameters, nil
+}
@@ -13,6 +13,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,22 +21,41 @@ import (
 
 const (
 	BuildpackResourceType = "Buildpack"
+
+	BuildpackStateAwaitingUpload   = "AWAITING_UPLOAD"
+	BuildpackStateProcessingUpload = "PROCESSING_UPLOAD"
+	BuildpackStateReady            = "READY"
 )
 
 type BuildpackRepository struct {
-	builderName   string
-	klient        Klient
-	rootNamespace string
-	sorter        BuildpackSorter
+	builderName       string
+	klient            Klient
+	rootNamespace     string
+	sorter            BuildpackSorter
+	repositoryCreator RepositoryCreator
+	repositoryPrefix  string
 }
 
 type BuildpackRecord struct {
-	Name      string
-	Position  int
-	Stack     string
-	Version   string
-	CreatedAt time.Time
-	UpdatedAt *time.Time
+	GUID          string
+	Name          string
+	Filename      string
+	Position      int
+	Stack         string
+	Version       string
+	State         string
+	Enabled       bool
+	Locked        bool
+	RepositoryRef string
+	Labels        map[string]string
+	Annotations   map[string]string
+	CreatedAt     time.Time
+	UpdatedAt     *time.Time
+	DeletedAt     *time.Time
+}
+
+func (r BuildpackRecord) GetResourceType() string {
+	return BuildpackResourceType
 }
 
 //counterfeiter:generate -o fake -fake-name BuildpackSorter . BuildpackSorter
@@ -82,17 +102,45 @@ type ListBuildpacksMessage struct {
 	Pagination Pagination
 }
 
+type CreateBuildpackMessage struct {
+	Name     string
+	Stack    string
+	Position int
+	Enabled  bool
+	Locked   bool
+	Metadata Metadata
+}
+
+type UpdateBuildpackMessage struct {
+	GUID          string
+	Name          *string
+	Stack         *string
+	Position      *int
+	Enabled       *bool
+	Locked        *bool
+	MetadataPatch MetadataPatch
+}
+
+type UpdateBuildpackSourceMessage struct {
+	GUID     string
+	ImageRef string
+}
+
 func NewBuildpackRepository(
 	klient Klient,
 	builderName string,
 	rootNamespace string,
 	sorter BuildpackSorter,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
 ) *BuildpackRepository {
 	return &BuildpackRepository{
-		klient:        klient,
-		builderName:   builderName,
-		rootNamespace: rootNamespace,
-		sorter:        sorter,
+		klient:            klient,
+		builderName:       builderName,
+		rootNamespace:     rootNamespace,
+		sorter:            sorter,
+		repositoryCreator: repositoryCreator,
+		repositoryPrefix:  repositoryPrefix,
 	}
 }
 
@@ -128,7 +176,12 @@ func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo autho
 		return ListResult[BuildpackRecord]{}, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready: %s", r.builderName, conditionNotReadyMessage))
 	}
 
-	records := r.sorter.Sort(builderInfoToBuildpackRecords(*builderInfo), message.OrderBy)
+	cfBuildpacks := &korifiv1alpha1.CFBuildpackList{}
+	if _, err = r.klient.List(ctx, cfBuildpacks, InNamespace(r.rootNamespace)); err != nil {
+		return ListResult[BuildpackRecord]{}, apierrors.FromK8sError(err, BuildpackResourceType)
+	}
+
+	records := r.sorter.Sort(r.mergeBuildpackRecords(builderInfoToBuildpackRecords(*builderInfo), cfBuildpacks.Items), message.OrderBy)
 
 	recordsPage := descriptors.SinglePage(records, len(records))
 	if !message.Pagination.IsZero() {
@@ -145,13 +198,211 @@ func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo autho
 	}, nil
 }
 
+func (r *BuildpackRepository) GetBuildpack(ctx context.Context, authInfo authorization.Info, guid string) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfBuildpack); err != nil {
+		return BuildpackRecord{}, fmt.Errorf("get-buildpack failed: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	return r.cfBuildpackToBuildpackRecord(*cfBuildpack), nil
+}
+
+func (r *BuildpackRepository) CreateBuildpack(ctx context.Context, authInfo authorization.Info, message CreateBuildpackMessage) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   r.rootNamespace,
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFBuildpackSpec{
+			DisplayName: message.Name,
+			Stack:       message.Stack,
+			Position:    message.Position,
+			Enabled:     message.Enabled,
+			Locked:      message.Locked,
+		},
+	}
+
+	if err := r.klient.Create(ctx, cfBuildpack); err != nil {
+		return BuildpackRecord{}, fmt.Errorf("create-buildpack failed: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	if err := r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(*cfBuildpack)); err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to create buildpack repository: %w", err)
+	}
+
+	return r.cfBuildpackToBuildpackRecord(*cfBuildpack), nil
+}
+
+func (r *BuildpackRepository) UpdateBuildpack(ctx context.Context, authInfo authorization.Info, message UpdateBuildpackMessage) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfBuildpack, func() error {
+		if message.Name != nil {
+			cfBuildpack.Spec.DisplayName = *message.Name
+		}
+		if message.Stack != nil {
+			cfBuildpack.Spec.Stack = *message.Stack
+		}
+		if message.Position != nil {
+			cfBuildpack.Spec.Position = *message.Position
+		}
+		if message.Enabled != nil {
+			cfBuildpack.Spec.Enabled = *message.Enabled
+		}
+		if message.Locked != nil {
+			cfBuildpack.Spec.Locked = *message.Locked
+		}
+		message.MetadataPatch.Apply(cfBuildpack)
+
+		return nil
+	})
+	if err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to patch buildpack: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	return r.cfBuildpackToBuildpackRecord(*cfBuildpack), nil
+}
+
+func (r *BuildpackRepository) UpdateBuildpackSource(ctx context.Context, authInfo authorization.Info, message UpdateBuildpackSourceMessage) (BuildpackRecord, error) {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfBuildpack, func() error {
+		cfBuildpack.Spec.Image = message.ImageRef
+		return nil
+	})
+	if err != nil {
+		return BuildpackRecord{}, fmt.Errorf("failed to update buildpack source: %w", apierrors.FromK8sError(err, BuildpackResourceType))
+	}
+
+	return r.cfBuildpackToBuildpackRecord(*cfBuildpack), nil
+}
+
+func (r *BuildpackRepository) DeleteBuildpack(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Delete(ctx, cfBuildpack); err != nil {
+		return apierrors.FromK8sError(err, BuildpackResourceType)
+	}
+
+	return nil
+}
+
+func (r *BuildpackRepository) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	buildpack, err := r.GetBuildpack(ctx, authInfo, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildpack.DeletedAt, nil
+}
+
+func (r *BuildpackRepository) repositoryRef(cfBuildpack korifiv1alpha1.CFBuildpack) string {
+	return r.repositoryPrefix + cfBuildpack.Name + "-buildpack"
+}
+
+// mergeBuildpackRecords overlays the admin managed CFBuildpacks on top of the
+// builder order. Buildpacks that the builder already knows about replace
+// their builder entry, the rest (e.g. not uploaded yet or disabled) are
+// inserted at their requested position.
+func (r *BuildpackRepository) mergeBuildpackRecords(builderRecords []BuildpackRecord, cfBuildpacks []korifiv1alpha1.CFBuildpack) []BuildpackRecord {
+	records := slices.Clone(builderRecords)
+
+	unordered := []korifiv1alpha1.CFBuildpack{}
+	for _, cfBuildpack := range cfBuildpacks {
+		idx := slices.IndexFunc(records, func(record BuildpackRecord) bool {
+			return record.GUID == "" && cfBuildpack.Status.BuildpackID != "" && record.Name == cfBuildpack.Status.BuildpackID
+		})
+		if idx < 0 {
+			unordered = append(unordered, cfBuildpack)
+			continue
+		}
+
+		records[idx] = r.cfBuildpackToBuildpackRecord(cfBuildpack)
+	}
+
+	slices.SortStableFunc(unordered, func(b1, b2 korifiv1alpha1.CFBuildpack) int {
+		return b1.Spec.Position - b2.Spec.Position
+	})
+	for _, cfBuildpack := range unordered {
+		records = slices.Insert(records, min(cfBuildpack.Spec.Position-1, len(records)), r.cfBuildpackToBuildpackRecord(cfBuildpack))
+	}
+
+	for i := range records {
+		records[i].Position = i + 1
+	}
+
+	return records
+}
+
+func (r *BuildpackRepository) cfBuildpackToBuildpackRecord(cfBuildpack korifiv1alpha1.CFBuildpack) BuildpackRecord {
+	record := BuildpackRecord{
+		GUID:          cfBuildpack.Name,
+		Name:          cfBuildpack.Spec.DisplayName,
+		Position:      cfBuildpack.Spec.Position,
+		Stack:         cfBuildpack.Spec.Stack,
+		Version:       cfBuildpack.Status.Version,
+		State:         buildpackState(cfBuildpack),
+		Enabled:       cfBuildpack.Spec.Enabled,
+		Locked:        cfBuildpack.Spec.Locked,
+		RepositoryRef: r.repositoryRef(cfBuildpack),
+		Labels:        cfBuildpack.Labels,
+		Annotations:   cfBuildpack.Annotations,
+		CreatedAt:     cfBuildpack.CreationTimestamp.Time,
+		UpdatedAt:     getLastUpdatedTime(&cfBuildpack),
+		DeletedAt:     golangTime(cfBuildpack.DeletionTimestamp),
+	}
+
+	if cfBuildpack.Status.BuildpackID != "" {
+		record.Filename = cfBuildpack.Status.BuildpackID + "@" + cfBuildpack.Status.Version
+	}
+
+	return record
+}
+
+func buildpackState(cfBuildpack korifiv1alpha1.CFBuildpack) string {
+	switch {
+	case cfBuildpack.Spec.Image == "":
+		return BuildpackStateAwaitingUpload
+	case meta.IsStatusConditionTrue(cfBuildpack.Status.Conditions, korifiv1alpha1.StatusConditionReady):
+		return BuildpackStateReady
+	default:
+		return BuildpackStateProcessingUpload
+	}
+}
+
 func builderInfoToBuildpackRecords(info korifiv1alpha1.BuilderInfo) []BuildpackRecord {
 	return slices.Collect(it.Right(it.Map2(slices.All(info.Status.Buildpacks), func(i int, b korifiv1alpha1.BuilderInfoStatusBuildpack) (int, BuildpackRecord) {
 		return i, BuildpackRecord{
 			Name:      b.Name,
+			Filename:  b.Name + "@" + b.Version,
 			Version:   b.Version,
 			Position:  i + 1,
 			Stack:     b.Stack,
+			State:     BuildpackStateReady,
+			Enabled:   true,
 			CreatedAt: b.CreationTimestamp.Time,
 			UpdatedAt: &b.UpdatedTimestamp.Time,
 		}
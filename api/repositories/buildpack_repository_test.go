@@ -5,13 +5,18 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -24,6 +29,7 @@ var _ = Describe("BuildpackRepository", func() {
 	var (
 		buildpackRepo *BuildpackRepository
 		sorter        *fake.BuildpackSorter
+		repoCreator   *fake.RepositoryCreator
 	)
 
 	BeforeEach(func() {
@@ -31,8 +37,9 @@ var _ = Describe("BuildpackRepository", func() {
 		sorter.SortStub = func(records []BuildpackRecord, _ string) []BuildpackRecord {
 			return records
 		}
+		repoCreator = new(fake.RepositoryCreator)
 
-		buildpackRepo = NewBuildpackRepository(rootNSKlient, builderName, rootNamespace, sorter)
+		buildpackRepo = NewBuildpackRepository(rootNSKlient, builderName, rootNamespace, sorter, repoCreator, "my-prefix/")
 	})
 
 	Describe("ListBuildpacks", func() {
@@ -107,6 +114,50 @@ var _ = Describe("BuildpackRepository", func() {
 				))
 			})
 
+			When("there are admin buildpacks", func() {
+				BeforeEach(func() {
+					orderedBuildpack := createCFBuildpack(ctx, "ordered", 2, "my.registry/ordered")
+					Expect(k8s.Patch(ctx, k8sClient, orderedBuildpack, func() {
+						orderedBuildpack.Status.BuildpackID = "paketo-buildpacks/buildpack-3-1"
+						orderedBuildpack.Status.Version = "3.1"
+						meta.SetStatusCondition(&orderedBuildpack.Status.Conditions, metav1.Condition{
+							Type:   korifiv1alpha1.StatusConditionReady,
+							Status: metav1.ConditionTrue,
+							Reason: "testing",
+						})
+					})).To(Succeed())
+
+					createCFBuildpack(ctx, "not-uploaded", 1, "")
+				})
+
+				It("merges them with the builder buildpacks", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(buildpacks.Records).To(HaveExactElements(
+						MatchFields(IgnoreExtras, Fields{
+							"Name":     Equal("not-uploaded"),
+							"Position": Equal(1),
+							"State":    Equal(BuildpackStateAwaitingUpload),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"Name":     Equal("paketo-buildpacks/buildpack-1-1"),
+							"Position": Equal(2),
+							"State":    Equal(BuildpackStateReady),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"Name":     Equal("paketo-buildpacks/buildpack-2-1"),
+							"Position": Equal(3),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"GUID":     Not(BeEmpty()),
+							"Name":     Equal("ordered"),
+							"Filename": Equal("paketo-buildpacks/buildpack-3-1@3.1"),
+							"Position": Equal(4),
+							"State":    Equal(BuildpackStateReady),
+						}),
+					))
+				})
+			})
+
 			When("paging is requested", func() {
 				BeforeEach(func() {
 					message.Pagination = Pagination{
@@ -194,8 +245,201 @@ var _ = Describe("BuildpackRepository", func() {
 			})
 		})
 	})
+
+	Describe("CreateBuildpack", func() {
+		var (
+			buildpack BuildpackRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			buildpack, createErr = buildpackRepo.CreateBuildpack(ctx, authInfo, CreateBuildpackMessage{
+				Name:     "my-buildpack",
+				Stack:    "cflinuxfs4",
+				Position: 3,
+				Enabled:  true,
+				Metadata: Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the buildpack", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(buildpack.GUID).NotTo(BeEmpty())
+				Expect(buildpack.State).To(Equal(BuildpackStateAwaitingUpload))
+				Expect(buildpack.RepositoryRef).To(Equal("my-prefix/" + buildpack.GUID + "-buildpack"))
+
+				cfBuildpack := &korifiv1alpha1.CFBuildpack{
+					ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: buildpack.GUID},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+				Expect(cfBuildpack.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(cfBuildpack.Spec).To(Equal(korifiv1alpha1.CFBuildpackSpec{
+					DisplayName: "my-buildpack",
+					Stack:       "cflinuxfs4",
+					Position:    3,
+					Enabled:     true,
+				}))
+			})
+
+			It("creates the buildpack image repository", func() {
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+				_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+				Expect(repoName).To(Equal("my-prefix/" + buildpack.GUID + "-buildpack"))
+			})
+		})
+	})
+
+	Describe("Get, update and delete", func() {
+		var cfBuildpack *korifiv1alpha1.CFBuildpack
+
+		BeforeEach(func() {
+			cfBuildpack = createCFBuildpack(ctx, "my-buildpack", 1, "")
+		})
+
+		Describe("GetBuildpack", func() {
+			It("returns the buildpack", func() {
+				buildpack, err := buildpackRepo.GetBuildpack(ctx, authInfo, cfBuildpack.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(buildpack.GUID).To(Equal(cfBuildpack.Name))
+				Expect(buildpack.Name).To(Equal("my-buildpack"))
+				Expect(buildpack.Enabled).To(BeTrue())
+			})
+
+			When("the buildpack does not exist", func() {
+				It("returns a not found error", func() {
+					_, err := buildpackRepo.GetBuildpack(ctx, authInfo, "i-do-not-exist")
+					Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		Describe("UpdateBuildpack", func() {
+			var (
+				buildpack BuildpackRecord
+				updateErr error
+			)
+
+			JustBeforeEach(func() {
+				buildpack, updateErr = buildpackRepo.UpdateBuildpack(ctx, authInfo, UpdateBuildpackMessage{
+					GUID:     cfBuildpack.Name,
+					Position: tools.PtrTo(5),
+					Locked:   tools.PtrTo(true),
+					MetadataPatch: MetadataPatch{
+						Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+					},
+				})
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(updateErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is an admin", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("updates the buildpack", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(buildpack.Position).To(Equal(5))
+					Expect(buildpack.Locked).To(BeTrue())
+					Expect(buildpack.Name).To(Equal("my-buildpack"))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+					Expect(cfBuildpack.Spec.Position).To(Equal(5))
+					Expect(cfBuildpack.Spec.Locked).To(BeTrue())
+					Expect(cfBuildpack.Spec.Enabled).To(BeTrue())
+					Expect(cfBuildpack.Labels).To(HaveKeyWithValue("foo", "bar"))
+				})
+			})
+		})
+
+		Describe("UpdateBuildpackSource", func() {
+			var (
+				buildpack BuildpackRecord
+				updateErr error
+			)
+
+			JustBeforeEach(func() {
+				buildpack, updateErr = buildpackRepo.UpdateBuildpackSource(ctx, authInfo, UpdateBuildpackSourceMessage{
+					GUID:     cfBuildpack.Name,
+					ImageRef: "my.registry/buildpack@sha256:123",
+				})
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(updateErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is an admin", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("sets the buildpack image", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(buildpack.State).To(Equal(BuildpackStateProcessingUpload))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+					Expect(cfBuildpack.Spec.Image).To(Equal("my.registry/buildpack@sha256:123"))
+				})
+			})
+		})
+
+		Describe("DeleteBuildpack", func() {
+			var deleteErr error
+
+			JustBeforeEach(func() {
+				deleteErr = buildpackRepo.DeleteBuildpack(ctx, authInfo, cfBuildpack.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(deleteErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is an admin", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("deletes the buildpack", func() {
+					Expect(deleteErr).NotTo(HaveOccurred())
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)
+					Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+		})
+	})
 })
 
+func createCFBuildpack(ctx context.Context, name string, position int, image string) *korifiv1alpha1.CFBuildpack {
+	cfBuildpack := &korifiv1alpha1.CFBuildpack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFBuildpackSpec{
+			DisplayName: name,
+			Position:    position,
+			Enabled:     true,
+			Image:       image,
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfBuildpack)).To(Succeed())
+	return cfBuildpack
+}
+
 type buildpackInfo struct {
 	name    string
 	version string
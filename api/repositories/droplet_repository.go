@@ -3,38 +3,56 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"time"
 
 	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // No kubebuilder RBAC tags required, because Build and Droplet are the same CR
 
 const (
 	DropletResourceType = "Droplet"
+
+	DropletStateAwaitingUpload   = "AWAITING_UPLOAD"
+	DropletStateProcessingUpload = "PROCESSING_UPLOAD"
+	DropletStateStaged           = "STAGED"
+	DropletStateFailed           = "FAILED"
 )
 
 type DropletRepo struct {
-	klient Klient
+	klient            Klient
+	repositoryCreator RepositoryCreator
+	repositoryPrefix  string
 }
 
 func NewDropletRepo(
 	klient Klient,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
 ) *DropletRepo {
 	return &DropletRepo{
-		klient: klient,
+		klient:            klient,
+		repositoryCreator: repositoryCreator,
+		repositoryPrefix:  repositoryPrefix,
 	}
 }
 
 type DropletRecord struct {
 	GUID            string
+	SpaceGUID       string
 	State           string
 	CreatedAt       time.Time
 	UpdatedAt       *time.Time
@@ -47,6 +65,9 @@ type DropletRecord struct {
 	Labels          map[string]string
 	Annotations     map[string]string
 	Image           string
+	ImageDigest     string
+	ImageRef        string
+	RepositoryRef   string
 	Ports           []int32
 }
 
@@ -83,7 +104,7 @@ func (r *DropletRepo) GetDroplet(ctx context.Context, authInfo authorization.Inf
 		return DropletRecord{}, err
 	}
 
-	return cfBuildToDroplet(build)
+	return r.cfBuildToDroplet(build)
 }
 
 func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInfo authorization.Info, dropletGUID string) (*korifiv1alpha1.CFBuild, error) {
@@ -99,24 +120,30 @@ func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInf
 	return build, nil
 }
 
-func cfBuildToDroplet(cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
-	if cfBuild.Status.State == korifiv1alpha1.BuildStateStaged {
-		return cfBuildToDropletRecord(*cfBuild), nil
+func (r *DropletRepo) cfBuildToDroplet(cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
+	if cfBuild.Status.State == korifiv1alpha1.BuildStateStaged || cfBuild.Spec.Droplet != nil {
+		return r.cfBuildToDropletRecord(*cfBuild), nil
 	}
 
 	return DropletRecord{}, apierrors.NewNotFoundError(nil, DropletResourceType)
 }
 
-func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
+func (r *DropletRepo) cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
+	droplet := cfBuild.Status.Droplet
+	if droplet == nil {
+		droplet = tools.IfZero(cfBuild.Spec.Droplet, &korifiv1alpha1.BuildDropletStatus{})
+	}
+
 	processTypesMap := make(map[string]string)
-	processTypesArrayObject := cfBuild.Status.Droplet.ProcessTypes
+	processTypesArrayObject := droplet.ProcessTypes
 	for index := range processTypesArrayObject {
 		processTypesMap[processTypesArrayObject[index].Type] = processTypesArrayObject[index].Command
 	}
 
 	result := DropletRecord{
 		GUID:      cfBuild.Name,
-		State:     "STAGED",
+		SpaceGUID: cfBuild.Namespace,
+		State:     dropletState(cfBuild),
 		CreatedAt: cfBuild.CreationTimestamp.Time,
 		UpdatedAt: getLastUpdatedTime(&cfBuild),
 		Lifecycle: Lifecycle{
@@ -126,23 +153,43 @@ func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 				Stack:      cfBuild.Spec.Lifecycle.Data.Stack,
 			},
 		},
-		Stack:        cfBuild.Status.Droplet.Stack,
-		ProcessTypes: processTypesMap,
-		AppGUID:      cfBuild.Spec.AppRef.Name,
-		PackageGUID:  cfBuild.Spec.PackageRef.Name,
-		Labels:       cfBuild.Labels,
-		Annotations:  cfBuild.Annotations,
-		Ports:        cfBuild.Status.Droplet.Ports,
+		Stack:         droplet.Stack,
+		ProcessTypes:  processTypesMap,
+		AppGUID:       cfBuild.Spec.AppRef.Name,
+		PackageGUID:   cfBuild.Spec.PackageRef.Name,
+		Labels:        cfBuild.Labels,
+		Annotations:   cfBuild.Annotations,
+		ImageRef:      droplet.Registry.Image,
+		RepositoryRef: r.repositoryRef(cfBuild),
+		Ports:         droplet.Ports,
 	}
 
 	if cfBuild.Spec.Lifecycle.Type == "docker" {
 		result.Lifecycle.Data = LifecycleData{}
-		result.Image = cfBuild.Status.Droplet.Registry.Image
+		result.Image = droplet.Registry.Image
+		result.ImageDigest = droplet.ImageDigest
 	}
 
 	return result
 }
 
+func dropletState(cfBuild korifiv1alpha1.CFBuild) string {
+	switch {
+	case cfBuild.Status.State == korifiv1alpha1.BuildStateStaged:
+		return DropletStateStaged
+	case cfBuild.Status.State == korifiv1alpha1.BuildStateFailed:
+		return DropletStateFailed
+	case cfBuild.Spec.Droplet != nil && cfBuild.Spec.Droplet.Registry.Image == "":
+		return DropletStateAwaitingUpload
+	default:
+		return DropletStateProcessingUpload
+	}
+}
+
+func (r *DropletRepo) repositoryRef(cfBuild korifiv1alpha1.CFBuild) string {
+	return r.repositoryPrefix + cfBuild.Spec.AppRef.Name + "-droplets"
+}
+
 func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.Info, message ListDropletsMessage) (ListResult[DropletRecord], error) {
 	buildList := &korifiv1alpha1.CFBuildList{}
 	pageInfo, err := r.klient.List(ctx, buildList, message.toListOptions()...)
@@ -151,7 +198,7 @@ func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.I
 	}
 
 	return ListResult[DropletRecord]{
-		Records:  slices.Collect(it.Map(slices.Values(buildList.Items), cfBuildToDropletRecord)),
+		Records:  slices.Collect(it.Map(slices.Values(buildList.Items), r.cfBuildToDropletRecord)),
 		PageInfo: pageInfo,
 	}, nil
 }
@@ -176,5 +223,185 @@ func (r *DropletRepo) UpdateDroplet(ctx context.Context, authInfo authorization.
 		return DropletRecord{}, fmt.Errorf("failed to patch droplet metadata: %w", apierrors.FromK8sError(err, DropletResourceType))
 	}
 
-	return cfBuildToDroplet(build)
+	return r.cfBuildToDroplet(build)
+}
+
+type CreateDropletMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	Lifecycle    Lifecycle
+	ProcessTypes map[string]string
+	Metadata     Metadata
+}
+
+func (m CreateDropletMessage) toCFBuild() korifiv1alpha1.CFBuild {
+	processTypes := []korifiv1alpha1.ProcessType{}
+	for _, processType := range slices.Sorted(maps.Keys(m.ProcessTypes)) {
+		processTypes = append(processTypes, korifiv1alpha1.ProcessType{
+			Type:    processType,
+			Command: m.ProcessTypes[processType],
+		})
+	}
+
+	return korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   m.SpaceGUID,
+			Labels:      m.Metadata.Labels,
+			Annotations: m.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef: corev1.LocalObjectReference{
+				Name: m.AppGUID,
+			},
+			Lifecycle: korifiv1alpha1.Lifecycle{
+				Type: korifiv1alpha1.LifecycleType(m.Lifecycle.Type),
+				Data: korifiv1alpha1.LifecycleData{
+					Buildpacks: m.Lifecycle.Data.Buildpacks,
+					Stack:      m.Lifecycle.Data.Stack,
+				},
+			},
+			Droplet: &korifiv1alpha1.BuildDropletStatus{
+				Stack:        m.Lifecycle.Data.Stack,
+				ProcessTypes: processTypes,
+			},
+		},
+	}
+}
+
+func (r *DropletRepo) CreateDroplet(ctx context.Context, authInfo authorization.Info, message CreateDropletMessage) (DropletRecord, error) {
+	cfBuild := message.toCFBuild()
+	if err := r.klient.Create(ctx, &cfBuild); err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	if cfBuild.Spec.Lifecycle.Type == korifiv1alpha1.BuildpackLifecycle {
+		if err := r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(cfBuild)); err != nil {
+			return DropletRecord{}, fmt.Errorf("failed to create droplet repository: %w", err)
+		}
+	}
+
+	return r.cfBuildToDropletRecord(cfBuild), nil
+}
+
+type CopyDropletMessage struct {
+	SourceGUID string
+	AppGUID    string
+	SpaceGUID  string
+	Metadata   Metadata
+}
+
+func (r *DropletRepo) CopyDroplet(ctx context.Context, authInfo authorization.Info, message CopyDropletMessage) (DropletRecord, error) {
+	sourceBuild, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, message.SourceGUID)
+	if err != nil {
+		return DropletRecord{}, err
+	}
+
+	if sourceBuild.Status.State != korifiv1alpha1.BuildStateStaged || sourceBuild.Status.Droplet == nil {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Source droplet must be staged.")
+	}
+
+	cfBuild := korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   message.SpaceGUID,
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef: corev1.LocalObjectReference{
+				Name: message.AppGUID,
+			},
+			Lifecycle: *sourceBuild.Spec.Lifecycle.DeepCopy(),
+			Droplet:   sourceBuild.Status.Droplet.DeepCopy(),
+		},
+	}
+
+	sourceSecretNames := map[string]string{}
+	if cfBuild.Namespace != sourceBuild.Namespace {
+		// the image pull secrets only exist in the source droplet space, so
+		// the copy refers to its own copies of them, created below
+		for i, secretRef := range cfBuild.Spec.Droplet.Registry.ImagePullSecrets {
+			copyName := cfBuild.Name + "-" + secretRef.Name
+			sourceSecretNames[copyName] = secretRef.Name
+			cfBuild.Spec.Droplet.Registry.ImagePullSecrets[i].Name = copyName
+		}
+	}
+
+	if err = r.klient.Create(ctx, &cfBuild); err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	for copyName, sourceName := range sourceSecretNames {
+		if err = r.copyImagePullSecret(ctx, sourceBuild.Namespace, sourceName, &cfBuild, copyName); err != nil {
+			return DropletRecord{}, err
+		}
+	}
+
+	return r.cfBuildToDropletRecord(cfBuild), nil
+}
+
+func (r *DropletRepo) copyImagePullSecret(ctx context.Context, sourceNamespace, sourceName string, cfBuild *korifiv1alpha1.CFBuild, copyName string) error {
+	sourceSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: sourceNamespace,
+			Name:      sourceName,
+		},
+	}
+	if err := r.klient.Get(ctx, sourceSecret); err != nil {
+		return fmt.Errorf("failed to get droplet image pull secret %q: %w", sourceName, apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	secretCopy := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfBuild.Namespace,
+			Name:      copyName,
+		},
+		Type: sourceSecret.Type,
+		Data: sourceSecret.Data,
+	}
+	if err := controllerutil.SetOwnerReference(cfBuild, secretCopy, scheme.Scheme); err != nil {
+		return fmt.Errorf("failed to set ownership from the droplet to the image pull secret: %w", err)
+	}
+
+	if err := r.klient.Create(ctx, secretCopy); err != nil {
+		return fmt.Errorf("failed to copy droplet image pull secret %q: %w", sourceName, apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	return nil
+}
+
+type UpdateDropletSourceMessage struct {
+	GUID                string
+	ImageRef            string
+	RegistrySecretNames []string
+}
+
+func (r *DropletRepo) UpdateDropletSource(ctx context.Context, authInfo authorization.Info, message UpdateDropletSourceMessage) (DropletRecord, error) {
+	build, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, message.GUID)
+	if err != nil {
+		return DropletRecord{}, err
+	}
+
+	if build.Spec.Droplet == nil {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Only droplets created via the droplets API can be uploaded.")
+	}
+
+	err = r.klient.Patch(ctx, build, func() error {
+		build.Spec.Droplet.Registry = korifiv1alpha1.Registry{
+			Image: message.ImageRef,
+			ImagePullSecrets: slices.Collect(
+				it.Map(slices.Values(message.RegistrySecretNames), func(secret string) corev1.LocalObjectReference {
+					return corev1.LocalObjectReference{Name: secret}
+				}),
+			),
+		}
+
+		return nil
+	})
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to update droplet source: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	return r.cfBuildToDropletRecord(*build), nil
 }
@@ -1,6 +1,7 @@
 package repositories_test
 
 import (
+	"errors"
 	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -29,6 +30,7 @@ var _ = Describe("DropletRepository", func() {
 
 	var (
 		dropletRepo *repositories.DropletRepo
+		repoCreator *fake.RepositoryCreator
 		build       *korifiv1alpha1.CFBuild
 	)
 
@@ -36,7 +38,8 @@ var _ = Describe("DropletRepository", func() {
 		org := createOrgWithCleanup(ctx, uuid.NewString())
 		space := createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
 
-		dropletRepo = repositories.NewDropletRepo(spaceScopedKlient)
+		repoCreator = new(fake.RepositoryCreator)
+		dropletRepo = repositories.NewDropletRepo(spaceScopedKlient, repoCreator, "container.registry/foo/my/prefix-")
 
 		packageGUID := uuid.NewString()
 		appGUID := uuid.NewString()
@@ -153,6 +156,7 @@ var _ = Describe("DropletRepository", func() {
 						Expect(k8s.Patch(ctx, k8sClient, build, func() {
 							build.Spec.Lifecycle.Type = "docker"
 							build.Status.Droplet.Registry.Image = "some/image"
+							build.Status.Droplet.ImageDigest = "sha256:abc"
 						})).To(Succeed())
 					})
 
@@ -160,6 +164,7 @@ var _ = Describe("DropletRepository", func() {
 						Expect(dropletRecord.Lifecycle.Type).To(Equal("docker"))
 						Expect(dropletRecord.Lifecycle.Data).To(Equal(repositories.LifecycleData{}))
 						Expect(dropletRecord.Image).To(Equal("some/image"))
+						Expect(dropletRecord.ImageDigest).To(Equal("sha256:abc"))
 					})
 				})
 			})
@@ -234,7 +239,7 @@ var _ = Describe("DropletRepository", func() {
 
 				BeforeEach(func() {
 					fakeKlient = new(fake.Klient)
-					dropletRepo = repositories.NewDropletRepo(fakeKlient)
+					dropletRepo = repositories.NewDropletRepo(fakeKlient, repoCreator, "container.registry/foo/my/prefix-")
 
 					message = repositories.ListDropletsMessage{
 						GUIDs:        []string{"a1", "a2"},
@@ -364,4 +369,271 @@ var _ = Describe("DropletRepository", func() {
 			})
 		})
 	})
+
+	Describe("CreateDroplet", func() {
+		var (
+			dropletRecord repositories.DropletRecord
+			createErr     error
+			createMsg     repositories.CreateDropletMessage
+		)
+
+		BeforeEach(func() {
+			createMsg = repositories.CreateDropletMessage{
+				AppGUID:   build.Spec.AppRef.Name,
+				SpaceGUID: build.Namespace,
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Stack: dropletStack},
+				},
+				ProcessTypes: map[string]string{
+					"worker": "bundle exec work",
+					"web":    "bundle exec rackup",
+				},
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, createErr = dropletRepo.CreateDroplet(ctx, authInfo, createMsg)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized to create droplets", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, build.Namespace)
+			})
+
+			It("returns a droplet awaiting upload", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.GUID).To(matchers.BeValidUUID())
+				Expect(dropletRecord.State).To(Equal(repositories.DropletStateAwaitingUpload))
+				Expect(dropletRecord.AppGUID).To(Equal(build.Spec.AppRef.Name))
+				Expect(dropletRecord.SpaceGUID).To(Equal(build.Namespace))
+				Expect(dropletRecord.PackageGUID).To(BeEmpty())
+				Expect(dropletRecord.Stack).To(Equal(dropletStack))
+				Expect(dropletRecord.ProcessTypes).To(Equal(createMsg.ProcessTypes))
+				Expect(dropletRecord.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(dropletRecord.RepositoryRef).To(Equal("container.registry/foo/my/prefix-" + build.Spec.AppRef.Name + "-droplets"))
+			})
+
+			It("creates a build providing the droplet", func() {
+				createdBuild := &korifiv1alpha1.CFBuild{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: build.Namespace,
+						Name:      dropletRecord.GUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(createdBuild), createdBuild)).To(Succeed())
+				Expect(createdBuild.Spec.AppRef.Name).To(Equal(build.Spec.AppRef.Name))
+				Expect(createdBuild.Spec.PackageRef.Name).To(BeEmpty())
+				Expect(createdBuild.Spec.Droplet).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Stack":    Equal(dropletStack),
+					"Registry": BeZero(),
+					"ProcessTypes": Equal([]korifiv1alpha1.ProcessType{
+						{Type: "web", Command: "bundle exec rackup"},
+						{Type: "worker", Command: "bundle exec work"},
+					}),
+				})))
+			})
+
+			It("creates the droplet repository", func() {
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+				_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+				Expect(repoName).To(Equal("container.registry/foo/my/prefix-" + build.Spec.AppRef.Name + "-droplets"))
+			})
+
+			When("the lifecycle is docker", func() {
+				BeforeEach(func() {
+					createMsg.Lifecycle = repositories.Lifecycle{Type: "docker"}
+				})
+
+				It("does not create a droplet repository", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(repoCreator.CreateRepositoryCallCount()).To(BeZero())
+				})
+			})
+
+			When("creating the repository fails", func() {
+				BeforeEach(func() {
+					repoCreator.CreateRepositoryReturns(errors.New("repo create error"))
+				})
+
+				It("returns an error", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("repo create error")))
+				})
+			})
+		})
+	})
+
+	Describe("CopyDroplet", func() {
+		var (
+			dropletRecord   repositories.DropletRecord
+			copyErr         error
+			targetAppGUID   string
+			targetSpaceGUID string
+		)
+
+		BeforeEach(func() {
+			targetAppGUID = uuid.NewString()
+			targetSpaceGUID = build.Namespace
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, copyErr = dropletRepo.CopyDroplet(ctx, authInfo, repositories.CopyDropletMessage{
+				SourceGUID: build.Name,
+				AppGUID:    targetAppGUID,
+				SpaceGUID:  targetSpaceGUID,
+				Metadata: repositories.Metadata{
+					Labels:      map[string]string{"copy-label": "foo"},
+					Annotations: map[string]string{"copy-annotation": "bar"},
+				},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized to copy droplets", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, build.Namespace)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+
+			When("the source droplet is staged", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, build, func() {
+						build.Status.State = korifiv1alpha1.BuildStateStaged
+						build.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+							Stack: dropletStack,
+							Registry: korifiv1alpha1.Registry{
+								Image:            registryImage,
+								ImagePullSecrets: []corev1.LocalObjectReference{{Name: registryImageSecret}},
+							},
+							ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "run"}},
+						}
+					})).To(Succeed())
+				})
+
+				It("returns a copy of the droplet for the target app", func() {
+					Expect(copyErr).NotTo(HaveOccurred())
+					Expect(dropletRecord.GUID).NotTo(Equal(build.Name))
+					Expect(dropletRecord.AppGUID).To(Equal(targetAppGUID))
+					Expect(dropletRecord.ImageRef).To(Equal(registryImage))
+					Expect(dropletRecord.ProcessTypes).To(Equal(map[string]string{"web": "run"}))
+				})
+
+				It("creates a build providing the source droplet", func() {
+					createdBuild := &korifiv1alpha1.CFBuild{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: build.Namespace,
+							Name:      dropletRecord.GUID,
+						},
+					}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(createdBuild), createdBuild)).To(Succeed())
+					Expect(createdBuild.Labels).To(Equal(map[string]string{"copy-label": "foo"}))
+					Expect(createdBuild.Annotations).To(Equal(map[string]string{"copy-annotation": "bar"}))
+					Expect(createdBuild.Spec.AppRef.Name).To(Equal(targetAppGUID))
+					Expect(createdBuild.Spec.Lifecycle).To(Equal(build.Spec.Lifecycle))
+					Expect(createdBuild.Spec.Droplet).To(Equal(build.Status.Droplet))
+				})
+
+				When("the droplet is copied to another space", func() {
+					BeforeEach(func() {
+						targetSpace := createSpaceWithCleanup(ctx, createOrgWithCleanup(ctx, uuid.NewString()).Name, uuid.NewString())
+						targetSpaceGUID = targetSpace.Name
+						createRoleBinding(ctx, userName, spaceDeveloperRole.Name, targetSpaceGUID)
+
+						Expect(k8sClient.Create(ctx, &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: build.Namespace,
+								Name:      registryImageSecret,
+							},
+							Type: corev1.SecretTypeDockerConfigJson,
+							Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte("{}")},
+						})).To(Succeed())
+					})
+
+					It("copies the image pull secrets into the target space", func() {
+						Expect(copyErr).NotTo(HaveOccurred())
+
+						createdBuild := &korifiv1alpha1.CFBuild{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: targetSpaceGUID,
+								Name:      dropletRecord.GUID,
+							},
+						}
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(createdBuild), createdBuild)).To(Succeed())
+						Expect(createdBuild.Spec.Droplet.Registry.ImagePullSecrets).To(ConsistOf(
+							corev1.LocalObjectReference{Name: createdBuild.Name + "-" + registryImageSecret},
+						))
+
+						secretCopy := &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: targetSpaceGUID,
+								Name:      createdBuild.Name + "-" + registryImageSecret,
+							},
+						}
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(secretCopy), secretCopy)).To(Succeed())
+						Expect(secretCopy.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+						Expect(secretCopy.Data).To(HaveKeyWithValue(corev1.DockerConfigJsonKey, []byte("{}")))
+						Expect(secretCopy.OwnerReferences).To(ConsistOf(HaveField("UID", createdBuild.UID)))
+					})
+				})
+			})
+		})
+	})
+
+	Describe("UpdateDropletSource", func() {
+		var (
+			dropletRecord repositories.DropletRecord
+			updateErr     error
+		)
+
+		JustBeforeEach(func() {
+			dropletRecord, updateErr = dropletRepo.UpdateDropletSource(ctx, authInfo, repositories.UpdateDropletSourceMessage{
+				GUID:                build.Name,
+				ImageRef:            registryImage,
+				RegistrySecretNames: []string{registryImageSecret},
+			})
+		})
+
+		When("the user is authorized to update droplets", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, build.Namespace)
+			})
+
+			It("returns an unprocessable entity error for builds that stage a package", func() {
+				Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+
+			When("the build provides its droplet", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, build, func() {
+						build.Spec.Droplet = &korifiv1alpha1.BuildDropletStatus{Stack: dropletStack}
+					})).To(Succeed())
+				})
+
+				It("sets the droplet image", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(dropletRecord.ImageRef).To(Equal(registryImage))
+					Expect(dropletRecord.State).To(Equal(repositories.DropletStateProcessingUpload))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(build), build)).To(Succeed())
+					Expect(build.Spec.Droplet.Registry).To(Equal(korifiv1alpha1.Registry{
+						Image:            registryImage,
+						ImagePullSecrets: []corev1.LocalObjectReference{{Name: registryImageSecret}},
+					}))
+				})
+			})
+		})
+	})
 })
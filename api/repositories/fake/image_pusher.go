@@ -11,6 +11,21 @@ import (
 )
 
 type ImagePusher struct {
+	ExportStub        func(context.Context, image.Creds, string) (io.ReadCloser, error)
+	exportMutex       sync.RWMutex
+	exportArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}
+	exportReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	exportReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	PushStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
 	pushMutex       sync.RWMutex
 	pushArgsForCall []struct {
@@ -28,10 +43,111 @@ type ImagePusher struct {
 		result1 string
 		result2 error
 	}
+	PushDropletStub        func(context.Context, image.Creds, string, string, io.Reader, ...string) (string, error)
+	pushDropletMutex       sync.RWMutex
+	pushDropletArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+		arg5 io.Reader
+		arg6 []string
+	}
+	pushDropletReturns struct {
+		result1 string
+		result2 error
+	}
+	pushDropletReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	PushTarballStub        func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)
+	pushTarballMutex       sync.RWMutex
+	pushTarballArgsForCall []struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}
+	pushTarballReturns struct {
+		result1 string
+		result2 error
+	}
+	pushTarballReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ImagePusher) Export(arg1 context.Context, arg2 image.Creds, arg3 string) (io.ReadCloser, error) {
+	fake.exportMutex.Lock()
+	ret, specificReturn := fake.exportReturnsOnCall[len(fake.exportArgsForCall)]
+	fake.exportArgsForCall = append(fake.exportArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ExportStub
+	fakeReturns := fake.exportReturns
+	fake.recordInvocation("Export", []interface{}{arg1, arg2, arg3})
+	fake.exportMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) ExportCallCount() int {
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	return len(fake.exportArgsForCall)
+}
+
+func (fake *ImagePusher) ExportCalls(stub func(context.Context, image.Creds, string) (io.ReadCloser, error)) {
+	fake.exportMutex.Lock()
+	defer fake.exportMutex.Unlock()
+	fake.ExportStub = stub
+}
+
+func (fake *ImagePusher) ExportArgsForCall(i int) (context.Context, image.Creds, string) {
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
+	argsForCall := fake.exportArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ImagePusher) ExportReturns(result1 io.ReadCloser, result2 error) {
+	fake.exportMutex.Lock()
+	defer fake.exportMutex.Unlock()
+	fake.ExportStub = nil
+	fake.exportReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) ExportReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.exportMutex.Lock()
+	defer fake.exportMutex.Unlock()
+	fake.ExportStub = nil
+	if fake.exportReturnsOnCall == nil {
+		fake.exportReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.exportReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImagePusher) Push(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
 	fake.pushMutex.Lock()
 	ret, specificReturn := fake.pushReturnsOnCall[len(fake.pushArgsForCall)]
@@ -100,11 +216,154 @@ func (fake *ImagePusher) PushReturnsOnCall(i int, result1 string, result2 error)
 	}{result1, result2}
 }
 
+func (fake *ImagePusher) PushDroplet(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 string, arg5 io.Reader, arg6 ...string) (string, error) {
+	fake.pushDropletMutex.Lock()
+	ret, specificReturn := fake.pushDropletReturnsOnCall[len(fake.pushDropletArgsForCall)]
+	fake.pushDropletArgsForCall = append(fake.pushDropletArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 string
+		arg5 io.Reader
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.PushDropletStub
+	fakeReturns := fake.pushDropletReturns
+	fake.recordInvocation("PushDroplet", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.pushDropletMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) PushDropletCallCount() int {
+	fake.pushDropletMutex.RLock()
+	defer fake.pushDropletMutex.RUnlock()
+	return len(fake.pushDropletArgsForCall)
+}
+
+func (fake *ImagePusher) PushDropletCalls(stub func(context.Context, image.Creds, string, string, io.Reader, ...string) (string, error)) {
+	fake.pushDropletMutex.Lock()
+	defer fake.pushDropletMutex.Unlock()
+	fake.PushDropletStub = stub
+}
+
+func (fake *ImagePusher) PushDropletArgsForCall(i int) (context.Context, image.Creds, string, string, io.Reader, []string) {
+	fake.pushDropletMutex.RLock()
+	defer fake.pushDropletMutex.RUnlock()
+	argsForCall := fake.pushDropletArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ImagePusher) PushDropletReturns(result1 string, result2 error) {
+	fake.pushDropletMutex.Lock()
+	defer fake.pushDropletMutex.Unlock()
+	fake.PushDropletStub = nil
+	fake.pushDropletReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PushDropletReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushDropletMutex.Lock()
+	defer fake.pushDropletMutex.Unlock()
+	fake.PushDropletStub = nil
+	if fake.pushDropletReturnsOnCall == nil {
+		fake.pushDropletReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushDropletReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PushTarball(arg1 context.Context, arg2 image.Creds, arg3 string, arg4 io.Reader, arg5 ...string) (string, error) {
+	fake.pushTarballMutex.Lock()
+	ret, specificReturn := fake.pushTarballReturnsOnCall[len(fake.pushTarballArgsForCall)]
+	fake.pushTarballArgsForCall = append(fake.pushTarballArgsForCall, struct {
+		arg1 context.Context
+		arg2 image.Creds
+		arg3 string
+		arg4 io.Reader
+		arg5 []string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PushTarballStub
+	fakeReturns := fake.pushTarballReturns
+	fake.recordInvocation("PushTarball", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.pushTarballMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImagePusher) PushTarballCallCount() int {
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
+	return len(fake.pushTarballArgsForCall)
+}
+
+func (fake *ImagePusher) PushTarballCalls(stub func(context.Context, image.Creds, string, io.Reader, ...string) (string, error)) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = stub
+}
+
+func (fake *ImagePusher) PushTarballArgsForCall(i int) (context.Context, image.Creds, string, io.Reader, []string) {
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
+	argsForCall := fake.pushTarballArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *ImagePusher) PushTarballReturns(result1 string, result2 error) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = nil
+	fake.pushTarballReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImagePusher) PushTarballReturnsOnCall(i int, result1 string, result2 error) {
+	fake.pushTarballMutex.Lock()
+	defer fake.pushTarballMutex.Unlock()
+	fake.PushTarballStub = nil
+	if fake.pushTarballReturnsOnCall == nil {
+		fake.pushTarballReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.pushTarballReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImagePusher) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.exportMutex.RLock()
+	defer fake.exportMutex.RUnlock()
 	fake.pushMutex.RLock()
 	defer fake.pushMutex.RUnlock()
+	fake.pushDropletMutex.RLock()
+	defer fake.pushDropletMutex.RUnlock()
+	fake.pushTarballMutex.RLock()
+	defer fake.pushTarballMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -23,6 +23,9 @@ const SourceImageResourceType = "SourceImage"
 
 type ImagePusher interface {
 	Push(ctx context.Context, creds image.Creds, repoRef string, zipReader io.Reader, tags ...string) (string, error)
+	PushTarball(ctx context.Context, creds image.Creds, repoRef string, tarReader io.Reader, tags ...string) (string, error)
+	PushDroplet(ctx context.Context, creds image.Creds, repoRef string, baseImageRef string, dropletReader io.Reader, tags ...string) (string, error)
+	Export(ctx context.Context, creds image.Creds, imageRef string) (io.ReadCloser, error)
 }
 
 type ImageRepository struct {
@@ -47,7 +50,7 @@ func NewImageRepository(
 }
 
 func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (string, error) {
-	authorized, err := r.canIPatchCFPackage(ctx, authInfo, spaceGUID)
+	authorized, err := r.canIPatch(ctx, authInfo, spaceGUID, "cfpackages", PackageResourceType)
 	if err != nil {
 		return "", fmt.Errorf("checking auth to upload source image for failed: %w", err)
 	}
@@ -72,25 +75,89 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 	return pushedRef, nil
 }
 
-func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
+func (r *ImageRepository) UploadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, baseImageRef string, dropletReader io.Reader, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canIPatch(ctx, authInfo, spaceGUID, "cfbuilds", DropletResourceType)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to upload droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuild"), DropletResourceType)
+	}
+
+	_, err = name.ParseReference(imageRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	pushedRef, err := r.pusher.PushDroplet(ctx, image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}, imageRef, baseImageRef, dropletReader, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("pushing droplet image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return pushedRef, nil
+}
+
+func (r *ImageRepository) UploadBuildpackImage(ctx context.Context, authInfo authorization.Info, imageRef string, tarReader io.Reader, tags ...string) (string, error) {
+	authorized, err := r.canIPatch(ctx, authInfo, r.pushSecretNamespace, "cfbuildpacks", BuildpackResourceType)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to upload buildpack image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuildpack"), BuildpackResourceType)
+	}
+
+	_, err = name.ParseReference(imageRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	pushedRef, err := r.pusher.PushTarball(ctx, image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}, imageRef, tarReader, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("pushing buildpack image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return pushedRef, nil
+}
+
+func (r *ImageRepository) DownloadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string) (io.ReadCloser, error) {
+	imageReader, err := r.pusher.Export(ctx, image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}, imageRef)
+	if err != nil {
+		return nil, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("exporting droplet image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return imageReader, nil
+}
+
+func (r *ImageRepository) canIPatch(ctx context.Context, authInfo authorization.Info, spaceGUID, resource, resourceType string) (bool, error) {
 	review := authv1.SelfSubjectAccessReview{
 		Spec: authv1.SelfSubjectAccessReviewSpec{
 			ResourceAttributes: &authv1.ResourceAttributes{
 				Namespace: spaceGUID,
 				Verb:      "patch",
 				Group:     "korifi.cloudfoundry.org",
-				Resource:  "cfpackages",
+				Resource:  resource,
 			},
 		},
 	}
 
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return false, fmt.Errorf("canIPatchCFPackage: failed to build user client: %w", err)
+		return false, fmt.Errorf("canIPatch: failed to build user client: %w", err)
 	}
 
 	if err := userClient.Create(ctx, &review); err != nil {
-		return false, fmt.Errorf("canIPatchCFPackage: failed to create self subject access review: %w", apierrors.FromK8sError(err, PackageResourceType))
+		return false, fmt.Errorf("canIPatch: failed to create self subject access review: %w", apierrors.FromK8sError(err, resourceType))
 	}
 
 	return review.Status.Allowed, nil
@@ -50,56 +50,175 @@ var _ = Describe("ImageRepository", func() {
 		)
 	})
 
-	JustBeforeEach(func() {
-		imageRef, uploadErr = imageRepo.UploadSourceImage(ctx, authInfo, imageName, imageSource, space.Name, tags...)
+	Describe("UploadSourceImage", func() {
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadSourceImage(ctx, authInfo, imageName, imageSource, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("succeeds", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-image"))
+			})
+
+			It("uploads the image to the registry", func() {
+				Expect(imagePusher.PushCallCount()).To(Equal(1))
+				_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(zipReader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("the image name is invalid", func() {
+				BeforeEach(func() {
+					imageName = "invAlid-image"
+				})
+
+				It("fails with an easy to understand unprocessible entity error ", func() {
+					var apiError apierrors.UnprocessableEntityError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+				})
+			})
+
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.PushReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				})
+			})
+		})
 	})
 
-	It("fails with unauthorized error without a valid role in the space", func() {
-		Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+	Describe("UploadDropletImage", func() {
+		BeforeEach(func() {
+			imagePusher.PushDropletReturns("my-pushed-droplet", nil)
+		})
+
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadDropletImage(ctx, authInfo, imageName, "base/image", imageSource, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("uploads the droplet on top of the base image to the registry", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-droplet"))
+
+				Expect(imagePusher.PushDropletCallCount()).To(Equal(1))
+				_, creds, actualRef, actualBaseRef, dropletReader, actualTags := imagePusher.PushDropletArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(actualBaseRef).To(Equal("base/image"))
+				Expect(dropletReader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imagePusher.PushDropletReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+				})
+			})
+		})
 	})
 
-	When("user has role SpaceDeveloper", func() {
+	Describe("UploadBuildpackImage", func() {
 		BeforeEach(func() {
-			createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			imagePusher.PushTarballReturns("my-pushed-buildpack", nil)
 		})
 
-		It("succeeds", func() {
-			Expect(uploadErr).NotTo(HaveOccurred())
-			Expect(imageRef).To(Equal("my-pushed-image"))
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadBuildpackImage(ctx, authInfo, imageName, imageSource, tags...)
 		})
 
-		It("uploads the image to the registry", func() {
-			Expect(imagePusher.PushCallCount()).To(Equal(1))
-			_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
-			Expect(creds.Namespace).To(Equal(rootNamespace))
-			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
-			Expect(actualRef).To(Equal("my-image"))
-			Expect(zipReader).To(Equal(imageSource))
-			Expect(actualTags).To(Equal(tags))
+		It("fails with unauthorized error without the admin role", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
 		})
 
-		When("the image name is invalid", func() {
+		When("user is an admin", func() {
 			BeforeEach(func() {
-				imageName = "invAlid-image"
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
 			})
 
-			It("fails with an easy to understand unprocessible entity error ", func() {
-				var apiError apierrors.UnprocessableEntityError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+			It("uploads the buildpackage tarball to the registry", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-buildpack"))
+
+				Expect(imagePusher.PushTarballCallCount()).To(Equal(1))
+				_, creds, actualRef, tarReader, actualTags := imagePusher.PushTarballArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(tarReader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
 			})
 		})
+	})
+
+	Describe("DownloadDropletImage", func() {
+		var (
+			imageReader io.ReadCloser
+			downloadErr error
+		)
+
+		BeforeEach(func() {
+			imagePusher.ExportReturns(io.NopCloser(bytes.NewBufferString("image-tarball")), nil)
+		})
+
+		JustBeforeEach(func() {
+			imageReader, downloadErr = imageRepo.DownloadDropletImage(ctx, authInfo, imageName)
+		})
+
+		It("exports the image from the registry", func() {
+			Expect(downloadErr).NotTo(HaveOccurred())
+			Expect(io.ReadAll(imageReader)).To(BeEquivalentTo("image-tarball"))
+
+			Expect(imagePusher.ExportCallCount()).To(Equal(1))
+			_, creds, actualRef := imagePusher.ExportArgsForCall(0)
+			Expect(creds.Namespace).To(Equal(rootNamespace))
+			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+			Expect(actualRef).To(Equal("my-image"))
+		})
 
-		When("pushing the image fails", func() {
+		When("exporting the image fails", func() {
 			BeforeEach(func() {
-				imagePusher.PushReturns("", errors.New("push-error"))
+				imagePusher.ExportReturns(nil, errors.New("export-error"))
 			})
 
 			It("fails with a blobstore unavailable error", func() {
-				Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+				Expect(downloadErr).To(MatchError(ContainSubstring("export-error")))
 				var apiError apierrors.BlobstoreUnavailableError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				Expect(errors.As(downloadErr, &apiError)).To(BeTrue())
 			})
 		})
 	})
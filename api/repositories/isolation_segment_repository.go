@@ -0,0 +1,424 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const IsolationSegmentResourceType = "Isolation Segment"
+
+type IsolationSegmentToleration struct {
+	Key      string `json:"key,omitempty"`
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+	Effect   string `json:"effect,omitempty"`
+}
+
+type IsolationSegmentScheduling struct {
+	NodeSelector     map[string]string
+	Tolerations      []IsolationSegmentToleration
+	RuntimeClassName *string
+}
+
+type IsolationSegmentRecord struct {
+	GUID         string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+	DeletedAt    *time.Time
+	Name         string
+	EntitledOrgs []string
+	Scheduling   IsolationSegmentScheduling
+	Labels       map[string]string
+	Annotations  map[string]string
+}
+
+func (r IsolationSegmentRecord) GetResourceType() string {
+	return IsolationSegmentResourceType
+}
+
+type CreateIsolationSegmentMessage struct {
+	Name       string
+	Scheduling IsolationSegmentScheduling
+	Metadata   Metadata
+}
+
+type UpdateIsolationSegmentMessage struct {
+	GUID          string
+	Name          *string
+	Scheduling    *IsolationSegmentScheduling
+	MetadataPatch MetadataPatch
+}
+
+type ListIsolationSegmentsMessage struct {
+	GUIDs             []string
+	Names             []string
+	OrganizationGUIDs []string
+}
+
+func (m ListIsolationSegmentsMessage) matches(cfIsolationSegment korifiv1alpha1.CFIsolationSegment) bool {
+	return tools.EmptyOrContains(m.GUIDs, cfIsolationSegment.Name) &&
+		tools.EmptyOrContains(m.Names, cfIsolationSegment.Spec.DisplayName) &&
+		(len(m.OrganizationGUIDs) == 0 || slices.ContainsFunc(m.OrganizationGUIDs, func(orgGUID string) bool {
+			return slices.Contains(cfIsolationSegment.Spec.EntitledOrgs, orgGUID)
+		}))
+}
+
+type IsolationSegmentRepo struct {
+	rootNSKlient      Klient
+	spaceScopedKlient Klient
+	rootNamespace     string
+}
+
+func NewIsolationSegmentRepo(
+	rootNSKlient Klient,
+	spaceScopedKlient Klient,
+	rootNamespace string,
+) *IsolationSegmentRepo {
+	return &IsolationSegmentRepo{
+		rootNSKlient:      rootNSKlient,
+		spaceScopedKlient: spaceScopedKlient,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *IsolationSegmentRepo) CreateIsolationSegment(ctx context.Context, authInfo authorization.Info, message CreateIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   r.rootNamespace,
+			Name:        uuid.NewString(),
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+			DisplayName: message.Name,
+			Scheduling:  toWorkloadScheduling(message.Scheduling),
+		},
+	}
+
+	if err := r.rootNSKlient.Create(ctx, cfIsolationSegment); err != nil {
+		return IsolationSegmentRecord{}, isolationSegmentWebhookErrorToAPIError(err)
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) GetIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) (IsolationSegmentRecord, error) {
+	cfIsolationSegment, err := r.getCFIsolationSegment(ctx, guid)
+	if err != nil {
+		return IsolationSegmentRecord{}, err
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) ListIsolationSegments(ctx context.Context, authInfo authorization.Info, message ListIsolationSegmentsMessage) ([]IsolationSegmentRecord, error) {
+	cfIsolationSegments := &korifiv1alpha1.CFIsolationSegmentList{}
+	if _, err := r.rootNSKlient.List(ctx, cfIsolationSegments, InNamespace(r.rootNamespace)); err != nil {
+		return nil, apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	filtered := it.Filter(slices.Values(cfIsolationSegments.Items), message.matches)
+	return slices.Collect(it.Map(filtered, toIsolationSegmentRecord)), nil
+}
+
+func (r *IsolationSegmentRepo) UpdateIsolationSegment(ctx context.Context, authInfo authorization.Info, message UpdateIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.rootNSKlient, cfIsolationSegment, func() error {
+		if message.Name != nil {
+			cfIsolationSegment.Spec.DisplayName = *message.Name
+		}
+		if message.Scheduling != nil {
+			cfIsolationSegment.Spec.Scheduling = toWorkloadScheduling(*message.Scheduling)
+		}
+		message.MetadataPatch.Apply(cfIsolationSegment)
+
+		return nil
+	})
+	if err != nil {
+		return IsolationSegmentRecord{}, isolationSegmentWebhookErrorToAPIError(err)
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) DeleteIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfIsolationSegment, err := r.getCFIsolationSegment(ctx, guid)
+	if err != nil {
+		return err
+	}
+
+	if len(cfIsolationSegment.Spec.EntitledOrgs) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("isolation segment %q is entitled to organizations", guid),
+			"Cannot delete isolation segment while it is entitled to organizations. Revoke the entitlements first.",
+		)
+	}
+
+	if err := r.rootNSKlient.Delete(ctx, cfIsolationSegment); err != nil {
+		return apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	return nil
+}
+
+func (r *IsolationSegmentRepo) EntitleOrgs(ctx context.Context, authInfo authorization.Info, guid string, orgGUIDs []string) (IsolationSegmentRecord, error) {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.rootNSKlient, cfIsolationSegment, func() error {
+		cfIsolationSegment.Spec.EntitledOrgs = tools.Uniq(append(cfIsolationSegment.Spec.EntitledOrgs, orgGUIDs...))
+		return nil
+	})
+	if err != nil {
+		return IsolationSegmentRecord{}, apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	return toIsolationSegmentRecord(*cfIsolationSegment), nil
+}
+
+// RevokeOrg removes the entitlement of the org to the isolation segment. The
+// entitlement cannot be revoked while the isolation segment is the default of
+// the org or is assigned to any of its spaces.
+func (r *IsolationSegmentRepo) RevokeOrg(ctx context.Context, authInfo authorization.Info, guid string, orgGUID string) error {
+	cfOrg := &korifiv1alpha1.CFOrg{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      orgGUID,
+		},
+	}
+	if err := r.rootNSKlient.Get(ctx, cfOrg); err != nil {
+		return apierrors.FromK8sError(err, OrgResourceType)
+	}
+
+	if cfOrg.Spec.DefaultIsolationSegmentGUID == guid {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("isolation segment %q is the default of org %q", guid, orgGUID),
+			"Cannot remove the entitlement of an organization to its default isolation segment.",
+		)
+	}
+
+	cfSpaces := &korifiv1alpha1.CFSpaceList{}
+	if _, err := r.spaceScopedKlient.List(ctx, cfSpaces, InNamespace(orgGUID)); err != nil {
+		return apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	if slices.ContainsFunc(cfSpaces.Items, func(cfSpace korifiv1alpha1.CFSpace) bool {
+		return cfSpace.Spec.IsolationSegmentGUID == guid
+	}) {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("isolation segment %q is assigned to spaces of org %q", guid, orgGUID),
+			"Cannot remove the entitlement of an organization to an isolation segment that is assigned to any of its spaces.",
+		)
+	}
+
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.rootNSKlient, cfIsolationSegment, func() error {
+		cfIsolationSegment.Spec.EntitledOrgs = slices.DeleteFunc(cfIsolationSegment.Spec.EntitledOrgs, func(entitledOrg string) bool {
+			return entitledOrg == orgGUID
+		})
+		return nil
+	})
+	if err != nil {
+		return apierrors.FromK8sError(err, IsolationSegmentResourceType)
+	}
+
+	return nil
+}
+
+func (r *IsolationSegmentRepo) GetOrgDefaultIsolationSegment(ctx context.Context, authInfo authorization.Info, orgGUID string) (string, error) {
+	cfOrg := &korifiv1alpha1.CFOrg{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      orgGUID,
+		},
+	}
+	if err := r.rootNSKlient.Get(ctx, cfOrg); err != nil {
+		return "", apierrors.FromK8sError(err, OrgResourceType)
+	}
+
+	return cfOrg.Spec.DefaultIsolationSegmentGUID, nil
+}
+
+// SetOrgDefaultIsolationSegment sets the default isolation segment of the org.
+// An empty guid resets the org to the shared isolation segment.
+func (r *IsolationSegmentRepo) SetOrgDefaultIsolationSegment(ctx context.Context, authInfo authorization.Info, orgGUID string, guid string) error {
+	if err := r.ensureEntitled(ctx, guid, orgGUID); err != nil {
+		return err
+	}
+
+	cfOrg := &korifiv1alpha1.CFOrg{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      orgGUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.rootNSKlient, cfOrg, func() error {
+		cfOrg.Spec.DefaultIsolationSegmentGUID = guid
+		return nil
+	})
+	if err != nil {
+		return apierrors.FromK8sError(err, OrgResourceType)
+	}
+
+	return nil
+}
+
+func (r *IsolationSegmentRepo) GetSpaceIsolationSegment(ctx context.Context, authInfo authorization.Info, spaceGUID string) (string, error) {
+	cfSpace := &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spaceGUID,
+		},
+	}
+	if err := r.spaceScopedKlient.Get(ctx, cfSpace); err != nil {
+		return "", apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	return cfSpace.Spec.IsolationSegmentGUID, nil
+}
+
+// SetSpaceIsolationSegment assigns the isolation segment to the space. An
+// empty guid makes the space fall back to the default isolation segment of its
+// org.
+func (r *IsolationSegmentRepo) SetSpaceIsolationSegment(ctx context.Context, authInfo authorization.Info, spaceGUID string, guid string) error {
+	cfSpace := &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spaceGUID,
+		},
+	}
+	if err := r.spaceScopedKlient.Get(ctx, cfSpace); err != nil {
+		return apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	if err := r.ensureEntitled(ctx, guid, cfSpace.Namespace); err != nil {
+		return err
+	}
+
+	err := r.spaceScopedKlient.Patch(ctx, cfSpace, func() error {
+		cfSpace.Spec.IsolationSegmentGUID = guid
+		return nil
+	})
+	if err != nil {
+		return apierrors.FromK8sError(err, SpaceResourceType)
+	}
+
+	return nil
+}
+
+func (r *IsolationSegmentRepo) ensureEntitled(ctx context.Context, guid string, orgGUID string) error {
+	if guid == "" {
+		return nil
+	}
+
+	cfIsolationSegment, err := r.getCFIsolationSegment(ctx, guid)
+	if err != nil {
+		return apierrors.AsUnprocessableEntity(
+			err,
+			"Unable to assign isolation segment. Ensure it exists and that you have access to it.",
+			apierrors.ForbiddenError{},
+			apierrors.NotFoundError{},
+		)
+	}
+
+	if !slices.Contains(cfIsolationSegment.Spec.EntitledOrgs, orgGUID) {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("org %q is not entitled to isolation segment %q", orgGUID, guid),
+			fmt.Sprintf("Unable to assign isolation segment with guid '%s'. Ensure it has been entitled to the organization.", guid),
+		)
+	}
+
+	return nil
+}
+
+func (r *IsolationSegmentRepo) getCFIsolationSegment(ctx context.Context, guid string) (*korifiv1alpha1.CFIsolationSegment, error) {
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.rootNSKlient.Get(ctx, cfIsolationSegment); err != nil {
+		return nil, fmt.Errorf("get-isolation-segment failed: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return cfIsolationSegment, nil
+}
+
+func isolationSegmentWebhookErrorToAPIError(err error) error {
+	if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
+		if validationError.Type == validation.DuplicateNameErrorType {
+			return apierrors.NewUniquenessError(err, validationError.GetMessage())
+		}
+	}
+
+	return apierrors.FromK8sError(err, IsolationSegmentResourceType)
+}
+
+func toWorkloadScheduling(scheduling IsolationSegmentScheduling) korifiv1alpha1.WorkloadScheduling {
+	return korifiv1alpha1.WorkloadScheduling{
+		NodeSelector: scheduling.NodeSelector,
+		Tolerations: slices.Collect(it.Map(slices.Values(scheduling.Tolerations), func(t IsolationSegmentToleration) corev1.Toleration {
+			return corev1.Toleration{
+				Key:      t.Key,
+				Operator: corev1.TolerationOperator(t.Operator),
+				Value:    t.Value,
+				Effect:   corev1.TaintEffect(t.Effect),
+			}
+		})),
+		RuntimeClassName: scheduling.RuntimeClassName,
+	}
+}
+
+func toIsolationSegmentRecord(cfIsolationSegment korifiv1alpha1.CFIsolationSegment) IsolationSegmentRecord {
+	return IsolationSegmentRecord{
+		GUID:         cfIsolationSegment.Name,
+		Name:         cfIsolationSegment.Spec.DisplayName,
+		EntitledOrgs: cfIsolationSegment.Spec.EntitledOrgs,
+		Scheduling: IsolationSegmentScheduling{
+			NodeSelector: cfIsolationSegment.Spec.Scheduling.NodeSelector,
+			Tolerations: slices.Collect(it.Map(slices.Values(cfIsolationSegment.Spec.Scheduling.Tolerations), func(t corev1.Toleration) IsolationSegmentToleration {
+				return IsolationSegmentToleration{
+					Key:      t.Key,
+					Operator: string(t.Operator),
+					Value:    t.Value,
+					Effect:   string(t.Effect),
+				}
+			})),
+			RuntimeClassName: cfIsolationSegment.Spec.Scheduling.RuntimeClassName,
+		},
+		Labels:      cfIsolationSegment.Labels,
+		Annotations: cfIsolationSegment.Annotations,
+		CreatedAt:   cfIsolationSegment.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(&cfIsolationSegment),
+		DeletedAt:   golangTime(cfIsolationSegment.DeletionTimestamp),
+	}
+}
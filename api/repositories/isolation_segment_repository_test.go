@@ -0,0 +1,230 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("IsolationSegmentRepository", func() {
+	var (
+		isolationSegmentRepo *repositories.IsolationSegmentRepo
+		cfIsolationSegment   *korifiv1alpha1.CFIsolationSegment
+	)
+
+	BeforeEach(func() {
+		isolationSegmentRepo = repositories.NewIsolationSegmentRepo(rootNSKlient, spaceScopedKlient, rootNamespace)
+
+		cfIsolationSegment = &korifiv1alpha1.CFIsolationSegment{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+			},
+			Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+				DisplayName: uuid.NewString(),
+				Scheduling: korifiv1alpha1.WorkloadScheduling{
+					NodeSelector: map[string]string{"pool": "pci"},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfIsolationSegment)).To(Succeed())
+	})
+
+	Describe("CreateIsolationSegment", func() {
+		var (
+			isolationSegment repositories.IsolationSegmentRecord
+			createErr        error
+		)
+
+		JustBeforeEach(func() {
+			isolationSegment, createErr = isolationSegmentRepo.CreateIsolationSegment(ctx, authInfo, repositories.CreateIsolationSegmentMessage{
+				Name: "pci",
+				Scheduling: repositories.IsolationSegmentScheduling{
+					NodeSelector: map[string]string{"pool": "pci"},
+					Tolerations: []repositories.IsolationSegmentToleration{{
+						Key:      "dedicated",
+						Operator: "Equal",
+						Value:    "pci",
+						Effect:   "NoSchedule",
+					}},
+					RuntimeClassName: tools.PtrTo("gvisor"),
+				},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the isolation segment", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(isolationSegment.GUID).NotTo(BeEmpty())
+				Expect(isolationSegment.Name).To(Equal("pci"))
+
+				created := &korifiv1alpha1.CFIsolationSegment{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: isolationSegment.GUID}, created)).To(Succeed())
+				Expect(created.Spec.Scheduling).To(Equal(korifiv1alpha1.WorkloadScheduling{
+					NodeSelector: map[string]string{"pool": "pci"},
+					Tolerations: []corev1.Toleration{{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpEqual,
+						Value:    "pci",
+						Effect:   corev1.TaintEffectNoSchedule,
+					}},
+					RuntimeClassName: tools.PtrTo("gvisor"),
+				}))
+			})
+		})
+	})
+
+	Describe("ListIsolationSegments", func() {
+		var (
+			orgGUID           string
+			message           repositories.ListIsolationSegmentsMessage
+			isolationSegments []repositories.IsolationSegmentRecord
+			listErr           error
+		)
+
+		BeforeEach(func() {
+			orgGUID = uuid.NewString()
+			Expect(k8s.PatchResource(ctx, k8sClient, cfIsolationSegment, func() {
+				cfIsolationSegment.Spec.EntitledOrgs = []string{orgGUID}
+			})).To(Succeed())
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFIsolationSegment{
+				ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: uuid.NewString()},
+				Spec:       korifiv1alpha1.CFIsolationSegmentSpec{DisplayName: uuid.NewString()},
+			})).To(Succeed())
+
+			message = repositories.ListIsolationSegmentsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			isolationSegments, listErr = isolationSegmentRepo.ListIsolationSegments(ctx, authInfo, message)
+		})
+
+		It("lists all isolation segments", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(len(isolationSegments)).To(BeNumerically(">=", 2))
+		})
+
+		When("filtering by organization guid", func() {
+			BeforeEach(func() {
+				message.OrganizationGUIDs = []string{orgGUID}
+			})
+
+			It("returns the isolation segments entitled to the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(isolationSegments).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"GUID":         Equal(cfIsolationSegment.Name),
+					"EntitledOrgs": ConsistOf(orgGUID),
+				})))
+			})
+		})
+	})
+
+	Describe("DeleteIsolationSegment", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = isolationSegmentRepo.DeleteIsolationSegment(ctx, authInfo, cfIsolationSegment.Name)
+		})
+
+		It("deletes the isolation segment", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(MatchError(ContainSubstring("not found")))
+		})
+
+		When("the isolation segment is entitled to an org", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfIsolationSegment, func() {
+					cfIsolationSegment.Spec.EntitledOrgs = []string{uuid.NewString()}
+				})).To(Succeed())
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(deleteErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+
+	Describe("org entitlements and defaults", func() {
+		var (
+			cfOrg   *korifiv1alpha1.CFOrg
+			cfSpace *korifiv1alpha1.CFSpace
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			cfOrg = createOrgWithCleanup(ctx, uuid.NewString())
+			createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, uuid.NewString())
+			createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Name)
+		})
+
+		It("does not allow assigning an isolation segment that is not entitled to the org", func() {
+			err := isolationSegmentRepo.SetOrgDefaultIsolationSegment(ctx, authInfo, cfOrg.Name, cfIsolationSegment.Name)
+			Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+
+			err = isolationSegmentRepo.SetSpaceIsolationSegment(ctx, authInfo, cfSpace.Name, cfIsolationSegment.Name)
+			Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+		})
+
+		When("the isolation segment is entitled to the org", func() {
+			BeforeEach(func() {
+				record, err := isolationSegmentRepo.EntitleOrgs(ctx, authInfo, cfIsolationSegment.Name, []string{cfOrg.Name})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(record.EntitledOrgs).To(ConsistOf(cfOrg.Name))
+			})
+
+			It("can be set as the org default", func() {
+				Expect(isolationSegmentRepo.SetOrgDefaultIsolationSegment(ctx, authInfo, cfOrg.Name, cfIsolationSegment.Name)).To(Succeed())
+
+				defaultGUID, err := isolationSegmentRepo.GetOrgDefaultIsolationSegment(ctx, authInfo, cfOrg.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(defaultGUID).To(Equal(cfIsolationSegment.Name))
+
+				By("refusing to revoke the entitlement of the org", func() {
+					Expect(isolationSegmentRepo.RevokeOrg(ctx, authInfo, cfIsolationSegment.Name, cfOrg.Name)).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			It("can be assigned to a space of the org", func() {
+				Expect(isolationSegmentRepo.SetSpaceIsolationSegment(ctx, authInfo, cfSpace.Name, cfIsolationSegment.Name)).To(Succeed())
+
+				spaceGUID, err := isolationSegmentRepo.GetSpaceIsolationSegment(ctx, authInfo, cfSpace.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaceGUID).To(Equal(cfIsolationSegment.Name))
+
+				By("refusing to revoke the entitlement of the org", func() {
+					Expect(isolationSegmentRepo.RevokeOrg(ctx, authInfo, cfIsolationSegment.Name, cfOrg.Name)).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			It("can be revoked when it is not in use", func() {
+				Expect(isolationSegmentRepo.RevokeOrg(ctx, authInfo, cfIsolationSegment.Name, cfOrg.Name)).To(Succeed())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfIsolationSegment), cfIsolationSegment)).To(Succeed())
+				Expect(cfIsolationSegment.Spec.EntitledOrgs).To(BeEmpty())
+			})
+		})
+	})
+})
@@ -322,6 +322,12 @@ func getResourceType(obj client.Object) (string, error) {
 		return repositories.ServiceBindingResourceType, nil
 	case *korifiv1alpha1.CFServiceInstance:
 		return repositories.ServiceInstanceResourceType, nil
+	case *korifiv1alpha1.CFServiceBroker:
+		return repositories.ServiceBrokerResourceType, nil
+	case *korifiv1alpha1.CFServiceOffering:
+		return repositories.ServiceOfferingResourceType, nil
+	case *korifiv1alpha1.CFServicePlan:
+		return repositories.ServicePlanResourceType, nil
 	case *korifiv1alpha1.CFTask:
 		return repositories.TaskResourceType, nil
 	default:
@@ -242,7 +242,32 @@ func (r *LogRepo) getContainerLogs(ctx context.Context, k8sClient k8sclient.Inte
 		return len(logLine) > 0
 	})
 
-	return it.Map(logLines, logLineToLogRecord)
+	logRecords := it.Map(logLines, logLineToLogRecord)
+
+	limiter := tools.NewLogRateLimiter(pod.Annotations[korifiv1alpha1.LogRateLimitAnnotation])
+	if limiter == nil {
+		return logRecords
+	}
+
+	return throttleLogRecords(logRecords, limiter)
+}
+
+// throttleLogRecords drops the log records that exceed the log rate limit of
+// the instance, replacing them with a single notice per second
+func throttleLogRecords(records iter.Seq[LogRecord], limiter *tools.LogRateLimiter) iter.Seq[LogRecord] {
+	return func(yield func(LogRecord) bool) {
+		for record := range records {
+			message, ok := limiter.Limit(time.Unix(0, record.Timestamp), record.Message)
+			if !ok {
+				continue
+			}
+
+			record.Message = message
+			if !yield(record) {
+				return
+			}
+		}
+	}
 }
 
 func getReadyContainers(pod corev1.Pod) []string {
@@ -259,6 +259,37 @@ var _ = Describe("LogRepository", func() {
 			})
 		})
 
+		When("the app pod has a log rate limit", func() {
+			BeforeEach(func() {
+				message.StartTime = nil
+
+				Expect(k8s.Patch(ctx, k8sClient, appPod, func() {
+					appPod.Annotations = map[string]string{
+						korifiv1alpha1.LogRateLimitAnnotation: "3",
+					}
+				})).To(Succeed())
+			})
+
+			It("drops the app log entries exceeding the limit and reports it", func() {
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(logRecords).To(HaveLen(5))
+				Expect(logRecords).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Message": Equal("app instance exceeded log rate limit (3 bytes/sec)"),
+					"Tags":    HaveKeyWithValue("source_type", "APP"),
+				})))
+			})
+
+			It("does not throttle the build logs", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logRecords).To(ContainElements(
+					matchLogRecord(100, "b0", "STG"),
+					matchLogRecord(1000, "b1", "STG"),
+					matchLogRecord(2000, "b2", "STG"),
+				))
+			})
+		})
+
 		When("descending is requested", func() {
 			BeforeEach(func() {
 				message.Descending = true
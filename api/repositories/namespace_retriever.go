@@ -68,6 +68,24 @@ var (
 		Resource: "cfserviceinstances",
 	}
 
+	CFServiceBrokersGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfservicebrokers",
+	}
+
+	CFServiceOfferingsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfserviceofferings",
+	}
+
+	CFServicePlansGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfserviceplans",
+	}
+
 	CFSpacesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -90,6 +108,9 @@ var (
 		RouteResourceType:           CFRoutesGVR,
 		ServiceBindingResourceType:  CFServiceBindingsGVR,
 		ServiceInstanceResourceType: CFServiceInstancesGVR,
+		ServiceBrokerResourceType:   CFServiceBrokersGVR,
+		ServiceOfferingResourceType: CFServiceOfferingsGVR,
+		ServicePlanResourceType:     CFServicePlansGVR,
 		SpaceResourceType:           CFSpacesGVR,
 		TaskResourceType:            CFTasksGVR,
 	}
@@ -16,6 +16,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
@@ -139,6 +140,12 @@ func (message CreatePackageMessage) toCFPackage() *korifiv1alpha1.CFPackage {
 type UpdatePackageMessage struct {
 	GUID          string
 	MetadataPatch MetadataPatch
+	Username      *string
+	Password      *string
+}
+
+func (m UpdatePackageMessage) hasCredentials() bool {
+	return m.Username != nil && m.Password != nil
 }
 
 type UpdatePackageSourceMessage struct {
@@ -185,7 +192,7 @@ func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.
 	}
 
 	if isPrivateDockerImage(message) {
-		err = r.createImagePullSecret(ctx, cfPackage, message)
+		err = r.ensureImagePullSecret(ctx, cfPackage, *message.Data.Username, *message.Data.Password)
 		if err != nil {
 			return PackageRecord{}, fmt.Errorf("failed to build docker image pull secret: %w", err)
 		}
@@ -205,37 +212,60 @@ func isPrivateDockerImage(message CreatePackageMessage) bool {
 		message.Data.Password != nil
 }
 
-func (r *PackageRepo) createImagePullSecret(ctx context.Context, cfPackage *korifiv1alpha1.CFPackage, message CreatePackageMessage) error {
-	ref, err := name.ParseReference(message.Data.Image)
+func (r *PackageRepo) ensureImagePullSecret(ctx context.Context, cfPackage *korifiv1alpha1.CFPackage, username, password string) error {
+	ref, err := name.ParseReference(cfPackage.Spec.Source.Registry.Image)
 	if err != nil {
 		return fmt.Errorf("failed to parse image ref: %w", err)
 	}
 
-	imgPullSecret, err := dockercfg.CreateDockerConfigSecret(
+	desiredSecret, err := dockercfg.CreateDockerConfigSecret(
 		cfPackage.Namespace,
 		cfPackage.Name,
 		dockercfg.DockerServerConfig{
 			Server:   ref.Context().RegistryStr(),
-			Username: *message.Data.Username,
-			Password: *message.Data.Password,
+			Username: username,
+			Password: password,
 		},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to generate image pull secret: %w", err)
 	}
 
-	err = controllerutil.SetOwnerReference(cfPackage, imgPullSecret, scheme.Scheme)
-	if err != nil {
-		return fmt.Errorf("failed to set ownership from the package to the image pull secret: %w", err)
+	imgPullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: desiredSecret.Namespace,
+			Name:      desiredSecret.Name,
+		},
 	}
+	err = r.klient.Get(ctx, imgPullSecret)
+	if k8serrors.IsNotFound(err) {
+		err = controllerutil.SetOwnerReference(cfPackage, desiredSecret, scheme.Scheme)
+		if err != nil {
+			return fmt.Errorf("failed to set ownership from the package to the image pull secret: %w", err)
+		}
 
-	err = r.klient.Create(ctx, imgPullSecret)
-	if err != nil {
-		return fmt.Errorf("failed create the image pull secret: %w", err)
+		err = r.klient.Create(ctx, desiredSecret)
+		if err != nil {
+			return fmt.Errorf("failed create the image pull secret: %w", err)
+		}
+	} else {
+		if err != nil {
+			return fmt.Errorf("failed to get the image pull secret: %w", err)
+		}
+
+		err = r.klient.Patch(ctx, imgPullSecret, func() error {
+			imgPullSecret.Type = desiredSecret.Type
+			imgPullSecret.Data = desiredSecret.Data
+
+			return controllerutil.SetOwnerReference(cfPackage, imgPullSecret, scheme.Scheme)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to patch the image pull secret: %w", err)
+		}
 	}
 
 	err = r.klient.Patch(ctx, cfPackage, func() error {
-		cfPackage.Spec.Source.Registry.ImagePullSecrets = []corev1.LocalObjectReference{{Name: imgPullSecret.Name}}
+		cfPackage.Spec.Source.Registry.ImagePullSecrets = []corev1.LocalObjectReference{{Name: desiredSecret.Name}}
 
 		return nil
 	})
@@ -258,6 +288,10 @@ func (r *PackageRepo) UpdatePackage(ctx context.Context, authInfo authorization.
 		return PackageRecord{}, fmt.Errorf("failed to get package: %w", apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, PackageResourceType)))
 	}
 
+	if updateMessage.hasCredentials() && cfPackage.Spec.Type != "docker" {
+		return PackageRecord{}, apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("cannot set registry credentials on a %s package", cfPackage.Spec.Type))
+	}
+
 	err = r.klient.Patch(ctx, cfPackage, func() error {
 		updateMessage.MetadataPatch.Apply(cfPackage)
 
@@ -267,6 +301,13 @@ func (r *PackageRepo) UpdatePackage(ctx context.Context, authInfo authorization.
 		return PackageRecord{}, fmt.Errorf("failed to patch package metadata: %w", apierrors.FromK8sError(err, PackageResourceType))
 	}
 
+	if updateMessage.hasCredentials() {
+		err = r.ensureImagePullSecret(ctx, cfPackage, *updateMessage.Username, *updateMessage.Password)
+		if err != nil {
+			return PackageRecord{}, fmt.Errorf("failed to update docker image pull secret: %w", apierrors.FromK8sError(err, PackageResourceType))
+		}
+	}
+
 	return r.cfPackageToPackageRecord(*cfPackage), nil
 }
 
@@ -13,6 +13,8 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/dockercfg"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -707,6 +709,66 @@ var _ = Describe("PackageRepository", func() {
 				})
 			})
 
+			When("registry credentials are set", func() {
+				BeforeEach(func() {
+					updateMessage.Username = tools.PtrTo("bob")
+					updateMessage.Password = tools.PtrTo("paswd")
+				})
+
+				It("returns an unprocessable entity error for a bits package", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+
+				When("the package is a docker package", func() {
+					var imgPullSecret *corev1.Secret
+
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfPackage, func() {
+							cfPackage.Spec.Type = "docker"
+							cfPackage.Spec.Source.Registry.Image = "some/image"
+						})).To(Succeed())
+
+						imgPullSecret = &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: space.Name,
+								Name:      packageGUID,
+							},
+						}
+					})
+
+					It("creates an image pull secret and references it from the package", func() {
+						Expect(updateErr).NotTo(HaveOccurred())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(imgPullSecret), imgPullSecret)).To(Succeed())
+						Expect(imgPullSecret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+						Expect(imgPullSecret.Data).NotTo(BeEmpty())
+
+						Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfPackage), cfPackage)).To(Succeed())
+						Expect(cfPackage.Spec.Source.Registry.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: packageGUID}))
+					})
+
+					When("the image pull secret already exists", func() {
+						BeforeEach(func() {
+							existingSecret, err := dockercfg.CreateDockerConfigSecret(space.Name, packageGUID, dockercfg.DockerServerConfig{
+								Username: "alice",
+								Password: "old",
+							})
+							Expect(err).NotTo(HaveOccurred())
+							Expect(k8sClient.Create(ctx, existingSecret)).To(Succeed())
+							imgPullSecret = existingSecret.DeepCopy()
+						})
+
+						It("rotates the credentials", func() {
+							Expect(updateErr).NotTo(HaveOccurred())
+
+							oldData := imgPullSecret.Data[corev1.DockerConfigJsonKey]
+							Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(imgPullSecret), imgPullSecret)).To(Succeed())
+							Expect(imgPullSecret.Data[corev1.DockerConfigJsonKey]).NotTo(Equal(oldData))
+						})
+					})
+				})
+			})
+
 			When("unsetting a label", func() {
 				BeforeEach(func() {
 					updateMessage.MetadataPatch.Labels["foo"] = nil
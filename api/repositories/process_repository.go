@@ -37,12 +37,15 @@ type ProcessRecord struct {
 	DesiredInstances int32
 	MemoryMB         int64
 	DiskQuotaMB      int64
-	HealthCheck      HealthCheck
-	Labels           map[string]string
-	Annotations      map[string]string
-	CreatedAt        time.Time
-	UpdatedAt        *time.Time
-	InstancesStatus  map[string]korifiv1alpha1.InstanceStatus
+	// LogRateLimitBytesPerSecond is -1 when unlimited
+	LogRateLimitBytesPerSecond int64
+	HealthCheck                HealthCheck
+	ReadinessHealthCheck       ReadinessHealthCheck
+	Labels                     map[string]string
+	Annotations                map[string]string
+	CreatedAt                  time.Time
+	UpdatedAt                  *time.Time
+	InstancesStatus            map[string]korifiv1alpha1.InstanceStatus
 }
 
 func (r ProcessRecord) Relationships() map[string]string {
@@ -64,6 +67,18 @@ type HealthCheckData struct {
 	HTTPEndpoint             string
 	InvocationTimeoutSeconds int32
 	TimeoutSeconds           int32
+	IntervalSeconds          int32
+}
+
+type ReadinessHealthCheck struct {
+	Type string
+	Data ReadinessHealthCheckData
+}
+
+type ReadinessHealthCheckData struct {
+	HTTPEndpoint             string
+	InvocationTimeoutSeconds int32
+	IntervalSeconds          int32
 }
 
 type ScaleProcessMessage struct {
@@ -73,34 +88,43 @@ type ScaleProcessMessage struct {
 }
 
 type ProcessScaleValues struct {
-	Instances *int32
-	MemoryMB  *int64
-	DiskMB    *int64
+	Instances                  *int32
+	MemoryMB                   *int64
+	DiskMB                     *int64
+	LogRateLimitBytesPerSecond *int64
 }
 
 type CreateProcessMessage struct {
-	AppGUID          string
-	SpaceGUID        string
-	Type             string
-	Command          string
-	DiskQuotaMB      int64
-	HealthCheck      HealthCheck
-	DesiredInstances *int32
-	MemoryMB         int64
+	AppGUID                    string
+	SpaceGUID                  string
+	Type                       string
+	Command                    string
+	DiskQuotaMB                int64
+	HealthCheck                HealthCheck
+	ReadinessHealthCheck       ReadinessHealthCheck
+	DesiredInstances           *int32
+	MemoryMB                   int64
+	LogRateLimitBytesPerSecond *int64
 }
 
 type PatchProcessMessage struct {
-	SpaceGUID                           string
-	ProcessGUID                         string
-	Command                             *string
-	DiskQuotaMB                         *int64
-	HealthCheckHTTPEndpoint             *string
-	HealthCheckInvocationTimeoutSeconds *int32
-	HealthCheckTimeoutSeconds           *int32
-	HealthCheckType                     *string
-	DesiredInstances                    *int32
-	MemoryMB                            *int64
-	MetadataPatch                       *MetadataPatch
+	SpaceGUID                                    string
+	ProcessGUID                                  string
+	Command                                      *string
+	DiskQuotaMB                                  *int64
+	HealthCheckHTTPEndpoint                      *string
+	HealthCheckInvocationTimeoutSeconds          *int32
+	HealthCheckTimeoutSeconds                    *int32
+	HealthCheckIntervalSeconds                   *int32
+	HealthCheckType                              *string
+	ReadinessHealthCheckHTTPEndpoint             *string
+	ReadinessHealthCheckInvocationTimeoutSeconds *int32
+	ReadinessHealthCheckIntervalSeconds          *int32
+	ReadinessHealthCheckType                     *string
+	DesiredInstances                             *int32
+	MemoryMB                                     *int64
+	LogRateLimitBytesPerSecond                   *int64
+	MetadataPatch                                *MetadataPatch
 }
 
 type ListProcessesMessage struct {
@@ -170,6 +194,9 @@ func (r *ProcessRepo) ScaleProcess(ctx context.Context, authInfo authorization.I
 		if scaleProcessMessage.DiskMB != nil {
 			cfProcess.Spec.DiskQuotaMB = *scaleProcessMessage.DiskMB
 		}
+		if scaleProcessMessage.LogRateLimitBytesPerSecond != nil {
+			cfProcess.Spec.LogRateLimitBytesPerSecond = scaleProcessMessage.LogRateLimitBytesPerSecond
+		}
 
 		return nil
 	})
@@ -194,9 +221,14 @@ func (r *ProcessRepo) CreateProcess(ctx context.Context, authInfo authorization.
 				Type: korifiv1alpha1.HealthCheckType(message.HealthCheck.Type),
 				Data: korifiv1alpha1.HealthCheckData(message.HealthCheck.Data),
 			},
-			DesiredInstances: message.DesiredInstances,
-			MemoryMB:         message.MemoryMB,
-			DiskQuotaMB:      message.DiskQuotaMB,
+			ReadinessHealthCheck: korifiv1alpha1.ReadinessHealthCheck{
+				Type: korifiv1alpha1.HealthCheckType(message.ReadinessHealthCheck.Type),
+				Data: korifiv1alpha1.ReadinessHealthCheckData(message.ReadinessHealthCheck.Data),
+			},
+			DesiredInstances:           message.DesiredInstances,
+			MemoryMB:                   message.MemoryMB,
+			DiskQuotaMB:                message.DiskQuotaMB,
+			LogRateLimitBytesPerSecond: message.LogRateLimitBytesPerSecond,
 		},
 	}
 	err := r.klient.Create(ctx, process)
@@ -242,6 +274,9 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 		if message.DiskQuotaMB != nil {
 			updatedProcess.Spec.DiskQuotaMB = *message.DiskQuotaMB
 		}
+		if message.LogRateLimitBytesPerSecond != nil {
+			updatedProcess.Spec.LogRateLimitBytesPerSecond = message.LogRateLimitBytesPerSecond
+		}
 		if message.HealthCheckType != nil {
 			// TODO: how do we handle when the type changes? Clear the HTTPEndpoint when type != http? Should we require the endpoint when type == http?
 			updatedProcess.Spec.HealthCheck.Type = korifiv1alpha1.HealthCheckType(*message.HealthCheckType)
@@ -255,6 +290,21 @@ func (r *ProcessRepo) PatchProcess(ctx context.Context, authInfo authorization.I
 		if message.HealthCheckTimeoutSeconds != nil {
 			updatedProcess.Spec.HealthCheck.Data.TimeoutSeconds = *message.HealthCheckTimeoutSeconds
 		}
+		if message.HealthCheckIntervalSeconds != nil {
+			updatedProcess.Spec.HealthCheck.Data.IntervalSeconds = *message.HealthCheckIntervalSeconds
+		}
+		if message.ReadinessHealthCheckType != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Type = korifiv1alpha1.HealthCheckType(*message.ReadinessHealthCheckType)
+		}
+		if message.ReadinessHealthCheckHTTPEndpoint != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Data.HTTPEndpoint = *message.ReadinessHealthCheckHTTPEndpoint
+		}
+		if message.ReadinessHealthCheckInvocationTimeoutSeconds != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Data.InvocationTimeoutSeconds = *message.ReadinessHealthCheckInvocationTimeoutSeconds
+		}
+		if message.ReadinessHealthCheckIntervalSeconds != nil {
+			updatedProcess.Spec.ReadinessHealthCheck.Data.IntervalSeconds = *message.ReadinessHealthCheckIntervalSeconds
+		}
 		if message.MetadataPatch != nil {
 			message.MetadataPatch.Apply(updatedProcess)
 		}
@@ -280,20 +330,30 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) (ProcessRecord
 	}
 
 	return ProcessRecord{
-		GUID:             cfProcess.Name,
-		SpaceGUID:        cfProcess.Namespace,
-		AppGUID:          cfProcess.Spec.AppRef.Name,
-		Type:             cfProcess.Spec.ProcessType,
-		Command:          cmd,
-		DesiredInstances: tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
-		MemoryMB:         cfProcess.Spec.MemoryMB,
-		DiskQuotaMB:      cfProcess.Spec.DiskQuotaMB,
+		GUID:                       cfProcess.Name,
+		SpaceGUID:                  cfProcess.Namespace,
+		AppGUID:                    cfProcess.Spec.AppRef.Name,
+		Type:                       cfProcess.Spec.ProcessType,
+		Command:                    cmd,
+		DesiredInstances:           tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
+		MemoryMB:                   cfProcess.Spec.MemoryMB,
+		DiskQuotaMB:                cfProcess.Spec.DiskQuotaMB,
+		LogRateLimitBytesPerSecond: logRateLimit(cfProcess.Spec.LogRateLimitBytesPerSecond),
 		HealthCheck: HealthCheck{
 			Type: string(cfProcess.Spec.HealthCheck.Type),
 			Data: HealthCheckData{
 				HTTPEndpoint:             cfProcess.Spec.HealthCheck.Data.HTTPEndpoint,
 				InvocationTimeoutSeconds: cfProcess.Spec.HealthCheck.Data.InvocationTimeoutSeconds,
 				TimeoutSeconds:           cfProcess.Spec.HealthCheck.Data.TimeoutSeconds,
+				IntervalSeconds:          cfProcess.Spec.HealthCheck.Data.IntervalSeconds,
+			},
+		},
+		ReadinessHealthCheck: ReadinessHealthCheck{
+			Type: string(cfProcess.Spec.ReadinessHealthCheck.Type),
+			Data: ReadinessHealthCheckData{
+				HTTPEndpoint:             cfProcess.Spec.ReadinessHealthCheck.Data.HTTPEndpoint,
+				InvocationTimeoutSeconds: cfProcess.Spec.ReadinessHealthCheck.Data.InvocationTimeoutSeconds,
+				IntervalSeconds:          cfProcess.Spec.ReadinessHealthCheck.Data.IntervalSeconds,
 			},
 		},
 		Labels:          cfProcess.Labels,
@@ -303,3 +363,13 @@ func cfProcessToProcessRecord(cfProcess korifiv1alpha1.CFProcess) (ProcessRecord
 		InstancesStatus: cfProcess.Status.InstancesStatus,
 	}, nil
 }
+
+// logRateLimit returns the log rate limit in the CF representation, where -1
+// means unlimited
+func logRateLimit(limit *int64) int64 {
+	if limit == nil {
+		return -1
+	}
+
+	return *limit
+}
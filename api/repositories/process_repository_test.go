@@ -52,6 +52,15 @@ var _ = Describe("ProcessRepo", func() {
 						HTTPEndpoint:             "/healthz",
 						InvocationTimeoutSeconds: 5,
 						TimeoutSeconds:           6,
+						IntervalSeconds:          7,
+					},
+				},
+				ReadinessHealthCheck: korifiv1alpha1.ReadinessHealthCheck{
+					Type: "http",
+					Data: korifiv1alpha1.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 2,
+						IntervalSeconds:          3,
 					},
 				},
 				DesiredInstances: tools.PtrTo[int32](1),
@@ -109,6 +118,15 @@ var _ = Describe("ProcessRepo", func() {
 				Expect(processRecord.HealthCheck.Data.InvocationTimeoutSeconds).To(BeEquivalentTo(5))
 				Expect(processRecord.HealthCheck.Data.TimeoutSeconds).To(BeEquivalentTo(6))
 				Expect(processRecord.HealthCheck.Data.HTTPEndpoint).To(Equal("/healthz"))
+				Expect(processRecord.HealthCheck.Data.IntervalSeconds).To(BeEquivalentTo(7))
+				Expect(processRecord.ReadinessHealthCheck).To(Equal(repositories.ReadinessHealthCheck{
+					Type: "http",
+					Data: repositories.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 2,
+						IntervalSeconds:          3,
+					},
+				}))
 				Expect(processRecord.InstancesStatus).To(Equal(map[string]korifiv1alpha1.InstanceStatus{
 					"1": {
 						State: korifiv1alpha1.InstanceStateDown,
@@ -279,9 +297,10 @@ var _ = Describe("ProcessRepo", func() {
 				GUID:      cfProcess.Name,
 				SpaceGUID: space.Name,
 				ProcessScaleValues: repositories.ProcessScaleValues{
-					Instances: tools.PtrTo[int32](7),
-					MemoryMB:  tools.PtrTo[int64](900),
-					DiskMB:    tools.PtrTo[int64](80),
+					Instances:                  tools.PtrTo[int32](7),
+					MemoryMB:                   tools.PtrTo[int64](900),
+					DiskMB:                     tools.PtrTo[int64](80),
+					LogRateLimitBytesPerSecond: tools.PtrTo[int64](1024),
 				},
 			}
 		})
@@ -311,6 +330,9 @@ var _ = Describe("ProcessRepo", func() {
 
 				Expect(scaledRecord.MemoryMB).To(BeEquivalentTo(900))
 				Expect(cfProcess.Spec.MemoryMB).To(BeEquivalentTo(900))
+
+				Expect(scaledRecord.LogRateLimitBytesPerSecond).To(BeEquivalentTo(1024))
+				Expect(cfProcess.Spec.LogRateLimitBytesPerSecond).To(PointTo(BeEquivalentTo(1024)))
 			})
 
 			When("process scale values are not specified", func() {
@@ -368,10 +390,19 @@ var _ = Describe("ProcessRepo", func() {
 						HTTPEndpoint:             "/healthz",
 						InvocationTimeoutSeconds: 20,
 						TimeoutSeconds:           10,
+						IntervalSeconds:          15,
 					},
 				},
-				DesiredInstances: tools.PtrTo[int32](42),
-				MemoryMB:         456,
+				ReadinessHealthCheck: repositories.ReadinessHealthCheck{
+					Type: "port",
+					Data: repositories.ReadinessHealthCheckData{
+						InvocationTimeoutSeconds: 4,
+						IntervalSeconds:          5,
+					},
+				},
+				DesiredInstances:           tools.PtrTo[int32](42),
+				MemoryMB:                   456,
+				LogRateLimitBytesPerSecond: tools.PtrTo[int64](-1),
 			})
 		})
 
@@ -401,11 +432,20 @@ var _ = Describe("ProcessRepo", func() {
 							HTTPEndpoint:             "/healthz",
 							InvocationTimeoutSeconds: 20,
 							TimeoutSeconds:           10,
+							IntervalSeconds:          15,
+						},
+					},
+					ReadinessHealthCheck: korifiv1alpha1.ReadinessHealthCheck{
+						Type: "port",
+						Data: korifiv1alpha1.ReadinessHealthCheckData{
+							InvocationTimeoutSeconds: 4,
+							IntervalSeconds:          5,
 						},
 					},
-					DesiredInstances: tools.PtrTo[int32](42),
-					MemoryMB:         456,
-					DiskQuotaMB:      123,
+					DesiredInstances:           tools.PtrTo[int32](42),
+					MemoryMB:                   456,
+					DiskQuotaMB:                123,
+					LogRateLimitBytesPerSecond: tools.PtrTo[int64](-1),
 				}))
 			})
 
@@ -488,16 +528,22 @@ var _ = Describe("ProcessRepo", func() {
 
 		BeforeEach(func() {
 			message = repositories.PatchProcessMessage{
-				ProcessGUID:                         cfProcess.Name,
-				SpaceGUID:                           space.Name,
-				Command:                             tools.PtrTo("start-web"),
-				HealthCheckType:                     tools.PtrTo("http"),
-				HealthCheckHTTPEndpoint:             tools.PtrTo("/healthz"),
-				HealthCheckInvocationTimeoutSeconds: tools.PtrTo(int32(20)),
-				HealthCheckTimeoutSeconds:           tools.PtrTo(int32(10)),
-				DesiredInstances:                    tools.PtrTo[int32](42),
-				MemoryMB:                            tools.PtrTo(int64(456)),
-				DiskQuotaMB:                         tools.PtrTo(int64(123)),
+				ProcessGUID:                                  cfProcess.Name,
+				SpaceGUID:                                    space.Name,
+				Command:                                      tools.PtrTo("start-web"),
+				HealthCheckType:                              tools.PtrTo("http"),
+				HealthCheckHTTPEndpoint:                      tools.PtrTo("/healthz"),
+				HealthCheckInvocationTimeoutSeconds:          tools.PtrTo(int32(20)),
+				HealthCheckTimeoutSeconds:                    tools.PtrTo(int32(10)),
+				HealthCheckIntervalSeconds:                   tools.PtrTo(int32(15)),
+				ReadinessHealthCheckType:                     tools.PtrTo("http"),
+				ReadinessHealthCheckHTTPEndpoint:             tools.PtrTo("/ready"),
+				ReadinessHealthCheckInvocationTimeoutSeconds: tools.PtrTo(int32(4)),
+				ReadinessHealthCheckIntervalSeconds:          tools.PtrTo(int32(5)),
+				DesiredInstances:                             tools.PtrTo[int32](42),
+				MemoryMB:                                     tools.PtrTo(int64(456)),
+				DiskQuotaMB:                                  tools.PtrTo(int64(123)),
+				LogRateLimitBytesPerSecond:                   tools.PtrTo(int64(2048)),
 				MetadataPatch: &repositories.MetadataPatch{
 					Labels:      map[string]*string{"fool": tools.PtrTo("fool")},
 					Annotations: map[string]*string{"fooa": tools.PtrTo("fooa")},
@@ -531,11 +577,21 @@ var _ = Describe("ProcessRepo", func() {
 							"HTTPEndpoint":             BeEquivalentTo("/healthz"),
 							"InvocationTimeoutSeconds": BeEquivalentTo(20),
 							"TimeoutSeconds":           BeEquivalentTo(10),
+							"IntervalSeconds":          BeEquivalentTo(15),
+						}),
+					}),
+					"ReadinessHealthCheck": MatchAllFields(Fields{
+						"Type": BeEquivalentTo("http"),
+						"Data": MatchAllFields(Fields{
+							"HTTPEndpoint":             Equal("/ready"),
+							"InvocationTimeoutSeconds": BeEquivalentTo(4),
+							"IntervalSeconds":          BeEquivalentTo(5),
 						}),
 					}),
-					"DesiredInstances": PointTo(BeEquivalentTo(42)),
-					"MemoryMB":         BeEquivalentTo(456),
-					"DiskQuotaMB":      BeEquivalentTo(123),
+					"DesiredInstances":           PointTo(BeEquivalentTo(42)),
+					"MemoryMB":                   BeEquivalentTo(456),
+					"DiskQuotaMB":                BeEquivalentTo(123),
+					"LogRateLimitBytesPerSecond": PointTo(BeEquivalentTo(2048)),
 				}))
 			})
 		})
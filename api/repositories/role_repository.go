@@ -40,10 +40,12 @@ type AuthorizedInChecker interface {
 }
 
 type CreateRoleMessage struct {
-	GUID                    string
-	Type                    string
-	Space                   string
-	Org                     string
+	GUID  string
+	Type  string
+	Space string
+	Org   string
+	// User is the name of the role subject, i.e. the group name for roles
+	// granted to a group
 	User                    string
 	Kind                    string
 	ServiceAccountNamespace string
@@ -71,6 +73,11 @@ func (r RoleRecord) Relationships() map[string]string {
 	relationships := map[string]string{
 		"user": r.User,
 	}
+	if r.Kind == rbacv1.GroupKind {
+		relationships = map[string]string{
+			"group": r.User,
+		}
+	}
 	if r.Org != "" {
 		relationships["organization"] = r.Org
 	}
@@ -137,6 +144,7 @@ type ListRolesMessage struct {
 	SpaceGUIDs []string
 	OrgGUIDs   []string
 	UserGUIDs  []string
+	GroupNames []string
 	OrderBy    string
 	Pagination Pagination
 }
@@ -146,7 +154,15 @@ func (m *ListRolesMessage) matches(role RoleRecord) bool {
 		tools.EmptyOrContains(m.Types, role.Type) &&
 		tools.EmptyOrContains(m.SpaceGUIDs, role.Space) &&
 		tools.EmptyOrContains(m.OrgGUIDs, role.Org) &&
-		tools.EmptyOrContains(m.UserGUIDs, role.User)
+		m.matchesSubject(role)
+}
+
+func (m *ListRolesMessage) matchesSubject(role RoleRecord) bool {
+	if role.Kind == rbacv1.GroupKind {
+		return len(m.UserGUIDs) == 0 && tools.EmptyOrContains(m.GroupNames, role.User)
+	}
+
+	return len(m.GroupNames) == 0 && tools.EmptyOrContains(m.UserGUIDs, role.User)
 }
 
 func NewRoleRepo(
@@ -200,7 +216,11 @@ func (r *RoleRepo) CreateRole(ctx context.Context, authInfo authorization.Info,
 	err := r.klient.Create(ctx, &roleBinding)
 	if err != nil {
 		if k8serrors.IsAlreadyExists(err) {
-			errorDetail := fmt.Sprintf("User '%s' already has '%s' role", role.User, role.Type)
+			subjectKind := "User"
+			if role.Kind == rbacv1.GroupKind {
+				subjectKind = "Group"
+			}
+			errorDetail := fmt.Sprintf("%s '%s' already has '%s' role", subjectKind, role.User, role.Type)
 			return RoleRecord{}, apierrors.NewUnprocessableEntityError(
 				fmt.Errorf("rolebinding %s:%s already exists", roleBinding.Namespace, roleBinding.Name),
 				errorDetail,
@@ -256,8 +276,11 @@ func (r *RoleRepo) validateOrgRequirements(ctx context.Context, role CreateRoleM
 	return nil
 }
 
-func calculateRoleBindingName(roleType, roleServiceAccountNamespace, roleUser string) string {
+func calculateRoleBindingName(roleType, roleKind, roleServiceAccountNamespace, roleUser string) string {
 	roleBindingName := roleType + "::"
+	if roleKind == rbacv1.GroupKind {
+		roleBindingName = roleBindingName + "group:"
+	}
 	if roleServiceAccountNamespace != "" {
 		roleBindingName = roleBindingName + roleServiceAccountNamespace + "/"
 	}
@@ -272,7 +295,7 @@ func createRoleBinding(namespace, roleType, roleKind, roleUser, roleServiceAccou
 	return rbacv1.RoleBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: namespace,
-			Name:      calculateRoleBindingName(roleType, roleServiceAccountNamespace, roleUser),
+			Name:      calculateRoleBindingName(roleType, roleKind, roleServiceAccountNamespace, roleUser),
 			Labels: map[string]string{
 				RoleGuidLabel: roleGUID,
 			},
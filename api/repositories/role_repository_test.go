@@ -197,6 +197,24 @@ var _ = Describe("RoleRepository", func() {
 				})
 			})
 
+			When("using a group identity", func() {
+				BeforeEach(func() {
+					roleCreateMessage.Kind = rbacv1.GroupKind
+					roleCreateMessage.User = "corp:devs"
+					// Sha256 sum of "organization_manager::group:corp:devs"
+					expectedName = "cf-98ba86a13585e1d36267536d97a7a362798d41ae195aa977cab73971f2273e8d"
+				})
+
+				It("succeeds and uses a group subject kind", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					roleBinding := getTheRoleBinding(expectedName, cfOrg.Name)
+					Expect(roleBinding.Subjects).To(HaveLen(1))
+					Expect(roleBinding.Subjects[0].Name).To(Equal("corp:devs"))
+					Expect(roleBinding.Subjects[0].Kind).To(Equal(rbacv1.GroupKind))
+				})
+			})
+
 			When("the org does not exist", func() {
 				BeforeEach(func() {
 					roleCreateMessage.Org = "i-do-not-exist"
@@ -588,6 +606,39 @@ var _ = Describe("RoleRepository", func() {
 						))
 					})
 				})
+
+				When("filtering by group name", func() {
+					BeforeEach(func() {
+						groupBinding := rbacv1.RoleBinding{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      uuid.NewString(),
+								Namespace: cfOrg.Name,
+								Labels:    map[string]string{repositories.RoleGuidLabel: "7"},
+							},
+							Subjects: []rbacv1.Subject{{
+								Kind: rbacv1.GroupKind,
+								Name: "my-group",
+							}},
+							RoleRef: rbacv1.RoleRef{
+								Kind: "ClusterRole",
+								Name: orgUserRole.Name,
+							},
+						}
+						Expect(k8sClient.Create(ctx, &groupBinding)).To(Succeed())
+						message.GroupNames = []string{"my-group"}
+					})
+
+					It("returns only the group roles", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(roles.Records).To(ConsistOf(
+							MatchFields(IgnoreExtras, Fields{
+								"GUID": Equal("7"),
+								"Kind": Equal(rbacv1.GroupKind),
+								"User": Equal("my-group"),
+							}),
+						))
+					})
+				})
 			})
 
 			When("ordering is requested", func() {
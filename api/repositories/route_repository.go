@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"maps"
 	"slices"
 	"strconv"
 	"time"
@@ -16,7 +17,9 @@ import (
 	"github.com/BooleanCat/go-functional/v2/it/itx"
 	"github.com/google/uuid"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 const (
@@ -25,23 +28,36 @@ const (
 
 type RouteRepo struct {
 	klient Klient
+	// privilegedClient looks up routes regardless of the user permissions
+	privilegedClient client.Client
 }
 
-func NewRouteRepo(klient Klient) *RouteRepo {
+func NewRouteRepo(klient Klient, privilegedClient client.Client) *RouteRepo {
 	return &RouteRepo{
-		klient: klient,
+		klient:           klient,
+		privilegedClient: privilegedClient,
 	}
 }
 
 type DestinationRecord struct {
-	GUID        string
-	AppGUID     string
-	ProcessType string
-	Port        *int32
-	Protocol    *string
+	GUID    string
+	AppGUID string
+	// AppSpaceGUID is the space of the destination app, which is either the
+	// route space or one of the spaces the route is shared with
+	AppSpaceGUID string
+	ProcessType  string
+	Port         *int32
+	Protocol     *string
 	// Weight intentionally omitted as experimental features
 }
 
+type RouteOptions struct {
+	LoadBalancing         string
+	HashHeader            string
+	RequestTimeoutSeconds *int32
+	SessionAffinity       bool
+}
+
 type RouteRecord struct {
 	GUID         string
 	SpaceGUID    string
@@ -50,11 +66,15 @@ type RouteRecord struct {
 	Path         string
 	Protocol     string
 	Destinations []DestinationRecord
-	Labels       map[string]string
-	Annotations  map[string]string
-	CreatedAt    time.Time
-	UpdatedAt    *time.Time
-	DeletedAt    *time.Time
+	Options      *RouteOptions
+	// SharedSpaceGUIDs are the spaces, other than the route space, whose
+	// apps can be route destinations
+	SharedSpaceGUIDs []string
+	Labels           map[string]string
+	Annotations      map[string]string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+	DeletedAt        *time.Time
 }
 
 func (r RouteRecord) Relationships() map[string]string {
@@ -65,10 +85,12 @@ func (r RouteRecord) Relationships() map[string]string {
 }
 
 type DesiredDestination struct {
-	AppGUID     string
-	ProcessType string
-	Port        *int32
-	Protocol    *string
+	AppGUID string
+	// AppSpaceGUID is optional and defaults to the route space
+	AppSpaceGUID string
+	ProcessType  string
+	Port         *int32
+	Protocol     *string
 	// Weight intentionally omitted as experimental features
 }
 
@@ -89,10 +111,42 @@ func (m *RemoveDestinationMessage) matches(dest korifiv1alpha1.Destination) bool
 	return dest.GUID == m.GUID
 }
 
-type PatchRouteMetadataMessage struct {
+type UpdateDestinationMessage struct {
+	RouteGUID string
+	SpaceGUID string
+	GUID      string
+	Protocol  *string
+}
+
+type ShareRouteMessage struct {
+	RouteGUID        string
+	SpaceGUID        string
+	SharedSpaceGUIDs []string
+}
+
+type UnshareRouteMessage struct {
+	RouteGUID       string
+	SpaceGUID       string
+	SharedSpaceGUID string
+}
+
+type TransferRouteOwnershipMessage struct {
+	RouteGUID    string
+	SpaceGUID    string
+	NewSpaceGUID string
+}
+
+type PatchRouteMessage struct {
 	MetadataPatch
 	RouteGUID string
 	SpaceGUID string
+	Options   *RouteOptions
+}
+
+type RouteReservationMessage struct {
+	DomainGUID string
+	Host       string
+	Path       string
 }
 
 type ListRoutesMessage struct {
@@ -136,6 +190,7 @@ type CreateRouteMessage struct {
 	DomainNamespace string
 	Labels          map[string]string
 	Annotations     map[string]string
+	Options         *RouteOptions
 }
 
 type DeleteRouteMessage struct {
@@ -159,10 +214,37 @@ func (m CreateRouteMessage) toCFRoute() korifiv1alpha1.CFRoute {
 				Name:      m.DomainGUID,
 				Namespace: m.DomainNamespace,
 			},
+			Options: toCFRouteOptions(m.Options),
 		},
 	}
 }
 
+func toCFRouteOptions(options *RouteOptions) *korifiv1alpha1.RouteOptions {
+	if options == nil {
+		return nil
+	}
+
+	return &korifiv1alpha1.RouteOptions{
+		LoadBalancing:         options.LoadBalancing,
+		HashHeader:            options.HashHeader,
+		RequestTimeoutSeconds: options.RequestTimeoutSeconds,
+		SessionAffinity:       options.SessionAffinity,
+	}
+}
+
+func toRouteOptions(options *korifiv1alpha1.RouteOptions) *RouteOptions {
+	if options == nil {
+		return nil
+	}
+
+	return &RouteOptions{
+		LoadBalancing:         options.LoadBalancing,
+		HashHeader:            options.HashHeader,
+		RequestTimeoutSeconds: options.RequestTimeoutSeconds,
+		SessionAffinity:       options.SessionAffinity,
+	}
+}
+
 func (r *RouteRepo) GetRoute(ctx context.Context, authInfo authorization.Info, routeGUID string) (RouteRecord, error) {
 	route := &korifiv1alpha1.CFRoute{
 		ObjectMeta: metav1.ObjectMeta{
@@ -190,6 +272,23 @@ func (r *RouteRepo) ListRoutes(ctx context.Context, authInfo authorization.Info,
 	}, nil
 }
 
+// IsRouteReserved tells whether a route with the given host and path exists
+// on the domain in any space. Only the result is returned to the user, hence
+// the lookup is not restricted to the spaces the user has access to
+func (r *RouteRepo) IsRouteReserved(ctx context.Context, authInfo authorization.Info, message RouteReservationMessage) (bool, error) {
+	cfRouteList := &korifiv1alpha1.CFRouteList{}
+	err := r.privilegedClient.List(ctx, cfRouteList, client.MatchingLabels{
+		korifiv1alpha1.CFDomainGUIDLabelKey: message.DomainGUID,
+		korifiv1alpha1.CFRouteHostLabelKey:  message.Host,
+		korifiv1alpha1.CFRoutePathLabelKey:  tools.EncodeValueToSha224(message.Path),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to list routes: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return len(cfRouteList.Items) > 0, nil
+}
+
 func cfRouteToRouteRecord(cfRoute korifiv1alpha1.CFRoute) RouteRecord {
 	return RouteRecord{
 		GUID:      cfRoute.Name,
@@ -197,26 +296,29 @@ func cfRouteToRouteRecord(cfRoute korifiv1alpha1.CFRoute) RouteRecord {
 		Domain: DomainRecord{
 			GUID: cfRoute.Spec.DomainRef.Name,
 		},
-		Host:         cfRoute.Spec.Host,
-		Path:         cfRoute.Spec.Path,
-		Protocol:     "http", // TODO: Create a mutating webhook to set this default on the CFRoute
-		Destinations: cfRouteDestinationsToDestinationRecords(cfRoute),
-		CreatedAt:    cfRoute.CreationTimestamp.Time,
-		UpdatedAt:    getLastUpdatedTime(&cfRoute),
-		DeletedAt:    golangTime(cfRoute.DeletionTimestamp),
-		Labels:       cfRoute.Labels,
-		Annotations:  cfRoute.Annotations,
+		Host:             cfRoute.Spec.Host,
+		Path:             cfRoute.Spec.Path,
+		Protocol:         "http", // TODO: Create a mutating webhook to set this default on the CFRoute
+		Destinations:     cfRouteDestinationsToDestinationRecords(cfRoute),
+		Options:          toRouteOptions(cfRoute.Spec.Options),
+		SharedSpaceGUIDs: cfRoute.Spec.SharedSpaces,
+		CreatedAt:        cfRoute.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(&cfRoute),
+		DeletedAt:        golangTime(cfRoute.DeletionTimestamp),
+		Labels:           cfRoute.Labels,
+		Annotations:      cfRoute.Annotations,
 	}
 }
 
 func cfRouteDestinationsToDestinationRecords(cfRoute korifiv1alpha1.CFRoute) []DestinationRecord {
 	return slices.Collect(it.Map(slices.Values(cfRoute.Spec.Destinations), func(specDestination korifiv1alpha1.Destination) DestinationRecord {
 		record := DestinationRecord{
-			GUID:        specDestination.GUID,
-			AppGUID:     specDestination.AppRef.Name,
-			ProcessType: specDestination.ProcessType,
-			Port:        specDestination.Port,
-			Protocol:    specDestination.Protocol,
+			GUID:         specDestination.GUID,
+			AppGUID:      specDestination.AppRef.Name,
+			AppSpaceGUID: cfRoute.DestinationNamespace(specDestination),
+			ProcessType:  specDestination.ProcessType,
+			Port:         specDestination.Port,
+			Protocol:     specDestination.Protocol,
 		}
 
 		if record.Port == nil {
@@ -307,7 +409,7 @@ func (r *RouteRepo) AddDestinationsToRoute(ctx context.Context, authInfo authori
 		},
 	}
 	err := GetAndPatch(ctx, r.klient, cfRoute, func() error {
-		cfRoute.Spec.Destinations = mergeDestinations(message.ExistingDestinations, message.NewDestinations)
+		cfRoute.Spec.Destinations = mergeDestinations(cfRoute.Namespace, message.ExistingDestinations, message.NewDestinations)
 		return nil
 	})
 	if err != nil {
@@ -345,32 +447,73 @@ func (r *RouteRepo) RemoveDestinationFromRoute(ctx context.Context, authInfo aut
 	return cfRouteToRouteRecord(*cfRoute), err
 }
 
-func mergeDestinations(existingDestinations []DestinationRecord, desiredDestinations []DesiredDestination) []korifiv1alpha1.Destination {
-	destinations := destinationRecordsToCFDestinations(existingDestinations)
+func (r *RouteRepo) UpdateDestination(ctx context.Context, authInfo authorization.Info, message UpdateDestinationMessage) (RouteRecord, error) {
+	cfRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.RouteGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+	err := r.klient.Get(ctx, cfRoute)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to get route: %w", apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	destinationIndex := slices.IndexFunc(cfRoute.Spec.Destinations, func(dest korifiv1alpha1.Destination) bool {
+		return dest.GUID == message.GUID
+	})
+	if destinationIndex < 0 {
+		return RouteRecord{}, apierrors.NewUnprocessableEntityError(nil, "Unable to update route destination. Ensure the route has a destination with this guid.")
+	}
+
+	err = r.klient.Patch(ctx, cfRoute, func() error {
+		cfRoute.Spec.Destinations[destinationIndex].Protocol = message.Protocol
+		return nil
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to update destination of route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*cfRoute), nil
+}
+
+func mergeDestinations(routeNamespace string, existingDestinations []DestinationRecord, desiredDestinations []DesiredDestination) []korifiv1alpha1.Destination {
+	destinations := destinationRecordsToCFDestinations(routeNamespace, existingDestinations)
 
 	for _, desired := range desiredDestinations {
 		if contains(destinations, desired) {
 			continue
 		}
 
-		destinations = append(destinations, destinationMessageToDestination(desired))
+		destinations = append(destinations, destinationMessageToDestination(routeNamespace, desired))
 	}
 
 	return destinations
 }
 
-func destinationMessageToDestination(m DesiredDestination) korifiv1alpha1.Destination {
+func destinationMessageToDestination(routeNamespace string, m DesiredDestination) korifiv1alpha1.Destination {
 	return korifiv1alpha1.Destination{
 		GUID: uuid.NewString(),
 		Port: m.Port,
 		AppRef: v1.LocalObjectReference{
 			Name: m.AppGUID,
 		},
-		ProcessType: m.ProcessType,
-		Protocol:    m.Protocol,
+		AppNamespace: toAppNamespace(routeNamespace, m.AppSpaceGUID),
+		ProcessType:  m.ProcessType,
+		Protocol:     m.Protocol,
 	}
 }
 
+// toAppNamespace only sets the destination app namespace for apps outside
+// of the route namespace
+func toAppNamespace(routeNamespace, appSpaceGUID string) string {
+	if appSpaceGUID == routeNamespace {
+		return ""
+	}
+
+	return appSpaceGUID
+}
+
 func contains(existingDestinations []korifiv1alpha1.Destination, desired DesiredDestination) bool {
 	_, ok := itx.FromSlice(existingDestinations).Find(func(dest korifiv1alpha1.Destination) bool {
 		return desired.AppGUID == dest.AppRef.Name &&
@@ -412,7 +555,7 @@ func (r *RouteRepo) fetchRouteByFields(ctx context.Context, authInfo authorizati
 	return &matches.Records[0], nil
 }
 
-func destinationRecordsToCFDestinations(destinationRecords []DestinationRecord) []korifiv1alpha1.Destination {
+func destinationRecordsToCFDestinations(routeNamespace string, destinationRecords []DestinationRecord) []korifiv1alpha1.Destination {
 	return slices.Collect(it.Map(itx.FromSlice(destinationRecords), func(destinationRecord DestinationRecord) korifiv1alpha1.Destination {
 		return korifiv1alpha1.Destination{
 			GUID: destinationRecord.GUID,
@@ -420,13 +563,123 @@ func destinationRecordsToCFDestinations(destinationRecords []DestinationRecord)
 			AppRef: v1.LocalObjectReference{
 				Name: destinationRecord.AppGUID,
 			},
-			ProcessType: destinationRecord.ProcessType,
-			Protocol:    destinationRecord.Protocol,
+			AppNamespace: toAppNamespace(routeNamespace, destinationRecord.AppSpaceGUID),
+			ProcessType:  destinationRecord.ProcessType,
+			Protocol:     destinationRecord.Protocol,
 		}
 	}))
 }
 
-func (r *RouteRepo) PatchRouteMetadata(ctx context.Context, authInfo authorization.Info, message PatchRouteMetadataMessage) (RouteRecord, error) {
+func (r *RouteRepo) ShareRoute(ctx context.Context, authInfo authorization.Info, message ShareRouteMessage) (RouteRecord, error) {
+	cfRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.RouteGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+	err := GetAndPatch(ctx, r.klient, cfRoute, func() error {
+		for _, spaceGUID := range message.SharedSpaceGUIDs {
+			if !slices.Contains(cfRoute.Spec.SharedSpaces, spaceGUID) {
+				cfRoute.Spec.SharedSpaces = append(cfRoute.Spec.SharedSpaces, spaceGUID)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to share route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(*cfRoute), nil
+}
+
+func (r *RouteRepo) UnshareRoute(ctx context.Context, authInfo authorization.Info, message UnshareRouteMessage) error {
+	cfRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.RouteGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+	err := GetAndPatch(ctx, r.klient, cfRoute, func() error {
+		cfRoute.Spec.SharedSpaces = slices.DeleteFunc(cfRoute.Spec.SharedSpaces, func(spaceGUID string) bool {
+			return spaceGUID == message.SharedSpaceGUID
+		})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unshare route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return nil
+}
+
+// TransferRouteOwnership moves the route into the new space. As routes
+// cannot change namespace, the route is recreated with the same guid in the
+// new space, while the old space becomes one of the spaces the route is
+// shared with so that existing destinations keep working
+func (r *RouteRepo) TransferRouteOwnership(ctx context.Context, authInfo authorization.Info, message TransferRouteOwnershipMessage) (RouteRecord, error) {
+	cfRoute := &korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.RouteGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+	err := r.klient.Get(ctx, cfRoute)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to get route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	if message.NewSpaceGUID == cfRoute.Namespace {
+		return cfRouteToRouteRecord(*cfRoute), nil
+	}
+
+	transferredRoute := korifiv1alpha1.CFRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cfRoute.Name,
+			Namespace:   message.NewSpaceGUID,
+			Labels:      cfRoute.Labels,
+			Annotations: maps.Clone(cfRoute.Annotations),
+		},
+		Spec: *cfRoute.Spec.DeepCopy(),
+	}
+	delete(transferredRoute.Annotations, korifiv1alpha1.CFRouteTransferredToAnnotation)
+	transferredRoute.Annotations = tools.SetMapValue(transferredRoute.Annotations, korifiv1alpha1.CFRouteTransferredFromAnnotation, cfRoute.Namespace)
+
+	transferredRoute.Spec.SharedSpaces = slices.DeleteFunc(
+		append(transferredRoute.Spec.SharedSpaces, cfRoute.Namespace),
+		func(spaceGUID string) bool { return spaceGUID == message.NewSpaceGUID },
+	)
+
+	for i, destination := range transferredRoute.Spec.Destinations {
+		transferredRoute.Spec.Destinations[i].AppNamespace = toAppNamespace(message.NewSpaceGUID, cfRoute.DestinationNamespace(destination))
+	}
+
+	// the new route is created first and takes over the unique route name
+	// from the old one, so that the route is never missing. A new route left
+	// over by a previous attempt is reused
+	err = r.klient.Create(ctx, &transferredRoute)
+	if err != nil && !k8serrors.IsAlreadyExists(err) {
+		return RouteRecord{}, fmt.Errorf("failed to create route %q in space %q: %w", message.RouteGUID, message.NewSpaceGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	// the old route no longer holds the unique route name, so it must not
+	// release it when deleted
+	err = r.klient.Patch(ctx, cfRoute, func() error {
+		cfRoute.Annotations = tools.SetMapValue(cfRoute.Annotations, korifiv1alpha1.CFRouteTransferredToAnnotation, message.NewSpaceGUID)
+		return nil
+	})
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to mark route %q as transferred: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	err = r.klient.Delete(ctx, cfRoute)
+	if err != nil {
+		return RouteRecord{}, fmt.Errorf("failed to delete route %q: %w", message.RouteGUID, apierrors.FromK8sError(err, RouteResourceType))
+	}
+
+	return cfRouteToRouteRecord(transferredRoute), nil
+}
+
+func (r *RouteRepo) PatchRoute(ctx context.Context, authInfo authorization.Info, message PatchRouteMessage) (RouteRecord, error) {
 	route := &korifiv1alpha1.CFRoute{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: message.SpaceGUID,
@@ -436,6 +689,9 @@ func (r *RouteRepo) PatchRouteMetadata(ctx context.Context, authInfo authorizati
 
 	err := GetAndPatch(ctx, r.klient, route, func() error {
 		message.Apply(route)
+		if message.Options != nil {
+			route.Spec.Options = toCFRouteOptions(message.Options)
+		}
 
 		return nil
 	})
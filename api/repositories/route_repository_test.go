@@ -18,6 +18,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -40,7 +41,7 @@ var _ = Describe("RouteRepository", func() {
 
 		routeGUID = prefixedGUID("route1")
 		domainGUID = prefixedGUID("domain")
-		routeRepo = repositories.NewRouteRepo(spaceScopedKlient)
+		routeRepo = repositories.NewRouteRepo(spaceScopedKlient, k8sClient)
 
 		cfDomain := &korifiv1alpha1.CFDomain{
 			ObjectMeta: metav1.ObjectMeta{
@@ -262,7 +263,7 @@ var _ = Describe("RouteRepository", func() {
 
 			BeforeEach(func() {
 				fakeKlient = new(fake.Klient)
-				routeRepo = repositories.NewRouteRepo(fakeKlient)
+				routeRepo = repositories.NewRouteRepo(fakeKlient, k8sClient)
 
 				message = repositories.ListRoutesMessage{
 					AppGUIDs:    []string{"g1"},
@@ -306,12 +307,14 @@ var _ = Describe("RouteRepository", func() {
 			routeHost          string
 			routePath          string
 			routeNamespace     string
+			routeOptions       *repositories.RouteOptions
 		)
 
 		BeforeEach(func() {
 			routeNamespace = space.Name
 			routeHost = prefixedGUID("route-host-")
 			routePath = prefixedGUID("/test/route/")
+			routeOptions = nil
 			createdRouteRecord = repositories.RouteRecord{}
 			createdRouteErr = nil
 		})
@@ -323,6 +326,7 @@ var _ = Describe("RouteRepository", func() {
 				SpaceGUID:       routeNamespace,
 				DomainGUID:      domainGUID,
 				DomainNamespace: rootNamespace,
+				Options:         routeOptions,
 			})
 		})
 
@@ -364,6 +368,34 @@ var _ = Describe("RouteRepository", func() {
 				Expect(createdRouteRecord.UpdatedAt).To(PointTo(BeTemporally("~", time.Now(), timeCheckThreshold)))
 			})
 
+			When("route options are set", func() {
+				BeforeEach(func() {
+					routeOptions = &repositories.RouteOptions{
+						LoadBalancing:         "least-connection",
+						RequestTimeoutSeconds: tools.PtrTo[int32](30),
+					}
+				})
+
+				It("sets the options on the CFRoute", func() {
+					Expect(createdRouteErr).NotTo(HaveOccurred())
+					createdCFRoute := &korifiv1alpha1.CFRoute{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: space.Name,
+							Name:      createdRouteRecord.GUID,
+						},
+					}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(createdCFRoute), createdCFRoute)).To(Succeed())
+					Expect(createdCFRoute.Spec.Options).To(PointTo(Equal(korifiv1alpha1.RouteOptions{
+						LoadBalancing:         "least-connection",
+						RequestTimeoutSeconds: tools.PtrTo[int32](30),
+					})))
+				})
+
+				It("returns the options in the record", func() {
+					Expect(createdRouteRecord.Options).To(Equal(routeOptions))
+				})
+			})
+
 			When("target namespace isn't set", func() {
 				BeforeEach(func() {
 					routeNamespace = ""
@@ -995,10 +1027,89 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
-	Describe("PatchRouteMetadata", func() {
+	Describe("UpdateDestination", func() {
+		var (
+			destinationGUID      string
+			updateDestinationErr error
+		)
+
+		BeforeEach(func() {
+			destinationGUID = uuid.NewString()
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      routeGUID,
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host: "test-route-host",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: space.Name,
+					},
+					Destinations: []korifiv1alpha1.Destination{{
+						GUID: destinationGUID,
+						Port: tools.PtrTo[int32](8000),
+						AppRef: corev1.LocalObjectReference{
+							Name: uuid.NewString(),
+						},
+						ProcessType: "web",
+						Protocol:    tools.PtrTo("http1"),
+					}},
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			_, updateDestinationErr = routeRepo.UpdateDestination(ctx, authInfo, repositories.UpdateDestinationMessage{
+				RouteGUID: routeGUID,
+				SpaceGUID: space.Name,
+				GUID:      destinationGUID,
+				Protocol:  tools.PtrTo("http2"),
+			})
+		})
+
+		It("returns an error as the user is not authorized", func() {
+			Expect(updateDestinationErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("updates the destination protocol", func() {
+				Expect(updateDestinationErr).NotTo(HaveOccurred())
+				updatedCFRoute := &korifiv1alpha1.CFRoute{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: space.Name,
+						Name:      routeGUID,
+					},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(updatedCFRoute), updatedCFRoute)).To(Succeed())
+
+				Expect(updatedCFRoute.Spec.Destinations).To(HaveLen(1))
+				Expect(updatedCFRoute.Spec.Destinations[0].GUID).To(Equal(destinationGUID))
+				Expect(updatedCFRoute.Spec.Destinations[0].Protocol).To(PointTo(Equal("http2")))
+			})
+
+			When("the destination isn't on the route", func() {
+				BeforeEach(func() {
+					destinationGUID = "some-bogus-guid"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateDestinationErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("PatchRoute", func() {
 		var (
 			cfRoute                       *korifiv1alpha1.CFRoute
 			labelsPatch, annotationsPatch map[string]*string
+			optionsPatch                  *repositories.RouteOptions
 			patchErr                      error
 			routeRecord                   repositories.RouteRecord
 		)
@@ -1024,19 +1135,21 @@ var _ = Describe("RouteRepository", func() {
 
 			labelsPatch = nil
 			annotationsPatch = nil
+			optionsPatch = nil
 		})
 
 		JustBeforeEach(func() {
-			patchMsg := repositories.PatchRouteMetadataMessage{
+			patchMsg := repositories.PatchRouteMessage{
 				RouteGUID: routeGUID,
 				SpaceGUID: space.Name,
 				MetadataPatch: repositories.MetadataPatch{
 					Annotations: annotationsPatch,
 					Labels:      labelsPatch,
 				},
+				Options: optionsPatch,
 			}
 
-			routeRecord, patchErr = routeRepo.PatchRouteMetadata(ctx, authInfo, patchMsg)
+			routeRecord, patchErr = routeRepo.PatchRoute(ctx, authInfo, patchMsg)
 		})
 
 		It("return a forbidden error as the user is not authorized", func() {
@@ -1048,6 +1161,35 @@ var _ = Describe("RouteRepository", func() {
 				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
 			})
 
+			It("does not set route options", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(routeRecord.Options).To(BeNil())
+			})
+
+			When("the options are patched", func() {
+				BeforeEach(func() {
+					optionsPatch = &repositories.RouteOptions{
+						LoadBalancing:         "least-connection",
+						RequestTimeoutSeconds: tools.PtrTo[int32](30),
+					}
+				})
+
+				It("sets the route options", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(routeRecord.Options).To(PointTo(Equal(repositories.RouteOptions{
+						LoadBalancing:         "least-connection",
+						RequestTimeoutSeconds: tools.PtrTo[int32](30),
+					})))
+
+					updatedCFRoute := new(korifiv1alpha1.CFRoute)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), updatedCFRoute)).To(Succeed())
+					Expect(updatedCFRoute.Spec.Options).To(PointTo(Equal(korifiv1alpha1.RouteOptions{
+						LoadBalancing:         "least-connection",
+						RequestTimeoutSeconds: tools.PtrTo[int32](30),
+					})))
+				})
+			})
+
 			When("the route doesn't have any labels or annotations", func() {
 				BeforeEach(func() {
 					labelsPatch = map[string]*string{
@@ -1193,6 +1335,311 @@ var _ = Describe("RouteRepository", func() {
 		})
 	})
 
+	Describe("IsRouteReserved", func() {
+		var (
+			message   repositories.RouteReservationMessage
+			reserved  bool
+			lookupErr error
+		)
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      routeGUID,
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host:     "my-subdomain-1",
+					Path:     "/my-path",
+					Protocol: "http",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: rootNamespace,
+					},
+				},
+			})).To(Succeed())
+
+			message = repositories.RouteReservationMessage{
+				DomainGUID: domainGUID,
+				Host:       "my-subdomain-1",
+				Path:       "/my-path",
+			}
+		})
+
+		JustBeforeEach(func() {
+			reserved, lookupErr = routeRepo.IsRouteReserved(ctx, authInfo, message)
+		})
+
+		It("returns true even though the user cannot see the route", func() {
+			Expect(lookupErr).NotTo(HaveOccurred())
+			Expect(reserved).To(BeTrue())
+		})
+
+		When("the path does not match", func() {
+			BeforeEach(func() {
+				message.Path = "/other-path"
+			})
+
+			It("returns false", func() {
+				Expect(lookupErr).NotTo(HaveOccurred())
+				Expect(reserved).To(BeFalse())
+			})
+		})
+
+		When("the host does not match", func() {
+			BeforeEach(func() {
+				message.Host = "other-subdomain"
+			})
+
+			It("returns false", func() {
+				Expect(lookupErr).NotTo(HaveOccurred())
+				Expect(reserved).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("ShareRoute", func() {
+		var (
+			cfRoute     *korifiv1alpha1.CFRoute
+			spaceA      *korifiv1alpha1.CFSpace
+			spaceB      *korifiv1alpha1.CFSpace
+			routeRecord repositories.RouteRecord
+			shareErr    error
+		)
+
+		BeforeEach(func() {
+			spaceA = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space-a"))
+			spaceB = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space-b"))
+
+			cfRoute = &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      routeGUID,
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host:     "my-subdomain-1",
+					Protocol: "http",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: rootNamespace,
+					},
+					SharedSpaces: []string{spaceA.Name},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfRoute)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			routeRecord, shareErr = routeRepo.ShareRoute(ctx, authInfo, repositories.ShareRouteMessage{
+				RouteGUID:        routeGUID,
+				SpaceGUID:        space.Name,
+				SharedSpaceGUIDs: []string{spaceA.Name, spaceB.Name},
+			})
+		})
+
+		It("returns a forbidden error as the user is not authorized", func() {
+			Expect(shareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			When("the user is a space developer in the shared spaces", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, spaceA.Name)
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, spaceB.Name)
+				})
+
+				It("adds the new spaces to the route shared spaces", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(routeRecord.SharedSpaceGUIDs).To(Equal([]string{spaceA.Name, spaceB.Name}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					Expect(cfRoute.Spec.SharedSpaces).To(Equal([]string{spaceA.Name, spaceB.Name}))
+				})
+			})
+		})
+	})
+
+	Describe("UnshareRoute", func() {
+		var (
+			cfRoute    *korifiv1alpha1.CFRoute
+			unshareErr error
+		)
+
+		BeforeEach(func() {
+			cfRoute = &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      routeGUID,
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host:     "my-subdomain-1",
+					Protocol: "http",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: rootNamespace,
+					},
+					SharedSpaces: []string{"space-a", "space-b"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfRoute)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			unshareErr = routeRepo.UnshareRoute(ctx, authInfo, repositories.UnshareRouteMessage{
+				RouteGUID:       routeGUID,
+				SpaceGUID:       space.Name,
+				SharedSpaceGUID: "space-a",
+			})
+		})
+
+		It("returns a forbidden error as the user is not authorized", func() {
+			Expect(unshareErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in this space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("removes the space from the route shared spaces", func() {
+				Expect(unshareErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+				Expect(cfRoute.Spec.SharedSpaces).To(Equal([]string{"space-b"}))
+			})
+		})
+	})
+
+	Describe("TransferRouteOwnership", func() {
+		var (
+			cfRoute     *korifiv1alpha1.CFRoute
+			newSpace    *korifiv1alpha1.CFSpace
+			routeRecord repositories.RouteRecord
+			transferErr error
+		)
+
+		BeforeEach(func() {
+			newSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space2"))
+
+			cfRoute = &korifiv1alpha1.CFRoute{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      routeGUID,
+					Namespace: space.Name,
+					Labels:    map[string]string{"foo": "bar"},
+				},
+				Spec: korifiv1alpha1.CFRouteSpec{
+					Host:     "my-subdomain-1",
+					Protocol: "http",
+					DomainRef: corev1.ObjectReference{
+						Name:      domainGUID,
+						Namespace: rootNamespace,
+					},
+					SharedSpaces: []string{newSpace.Name},
+					Destinations: []korifiv1alpha1.Destination{
+						{
+							GUID:        "destination-1-guid",
+							AppRef:      corev1.LocalObjectReference{Name: "app-1-guid"},
+							ProcessType: "web",
+						},
+						{
+							GUID:         "destination-2-guid",
+							AppRef:       corev1.LocalObjectReference{Name: "app-2-guid"},
+							AppNamespace: newSpace.Name,
+							ProcessType:  "web",
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfRoute)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			routeRecord, transferErr = routeRepo.TransferRouteOwnership(ctx, authInfo, repositories.TransferRouteOwnershipMessage{
+				RouteGUID:    routeGUID,
+				SpaceGUID:    space.Name,
+				NewSpaceGUID: newSpace.Name,
+			})
+		})
+
+		It("returns a forbidden error as the user is not authorized", func() {
+			Expect(transferErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in the old space only", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(transferErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			It("keeps the route in the old space", func() {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &korifiv1alpha1.CFRoute{})).To(Succeed())
+			})
+		})
+
+		When("the user is a space developer in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, newSpace.Name)
+			})
+
+			It("moves the route into the new space", func() {
+				Expect(transferErr).NotTo(HaveOccurred())
+				Expect(routeRecord.GUID).To(Equal(routeGUID))
+				Expect(routeRecord.SpaceGUID).To(Equal(newSpace.Name))
+
+				transferredRoute := &korifiv1alpha1.CFRoute{}
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: newSpace.Name, Name: routeGUID}, transferredRoute)).To(Succeed())
+				Expect(transferredRoute.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(transferredRoute.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFRouteTransferredFromAnnotation, space.Name))
+				Expect(transferredRoute.Spec.Host).To(Equal("my-subdomain-1"))
+			})
+
+			It("shares the route with the old space", func() {
+				Expect(transferErr).NotTo(HaveOccurred())
+				Expect(routeRecord.SharedSpaceGUIDs).To(ConsistOf(space.Name))
+			})
+
+			It("keeps the destinations pointing at the same apps", func() {
+				Expect(transferErr).NotTo(HaveOccurred())
+				Expect(routeRecord.Destinations).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"AppGUID":      Equal("app-1-guid"),
+						"AppSpaceGUID": Equal(space.Name),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"AppGUID":      Equal("app-2-guid"),
+						"AppSpaceGUID": Equal(newSpace.Name),
+					}),
+				))
+			})
+
+			It("deletes the route from the old space", func() {
+				Expect(transferErr).NotTo(HaveOccurred())
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &korifiv1alpha1.CFRoute{})
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the user is a space developer in the route space only", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(transferErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("GetDeletedAt", func() {
 		var (
 			cfRoute   *korifiv1alpha1.CFRoute
@@ -31,6 +31,7 @@ const (
 
 type ParametersClient interface {
 	GetServiceBindingParameters(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (map[string]any, error)
+	GetServiceInstanceParameters(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (map[string]any, error)
 }
 
 type ServiceBindingRepo struct {
@@ -68,6 +69,7 @@ type ServiceBindingRecord struct {
 	DeletedAt           *time.Time
 	LastOperation       ServiceBindingLastOperation
 	Ready               bool
+	SupersededByGUID    string
 }
 
 func (r ServiceBindingRecord) Relationships() map[string]string {
@@ -95,6 +97,7 @@ type CreateServiceBindingMessage struct {
 	AppGUID             string
 	SpaceGUID           string
 	Parameters          map[string]any
+	PreviousBindingGUID string
 }
 
 type DeleteServiceBindingMessage struct {
@@ -146,6 +149,9 @@ func (m CreateServiceBindingMessage) toCFServiceBinding(instanceType korifiv1alp
 				Name:       m.ServiceInstanceGUID,
 			},
 			Type: m.Type,
+			PreviousBinding: corev1.LocalObjectReference{
+				Name: m.PreviousBindingGUID,
+			},
 		},
 	}
 
@@ -167,13 +173,13 @@ type UpdateServiceBindingMessage struct {
 
 func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
 	if message.Type == korifiv1alpha1.CFServiceBindingTypeApp {
-		return r.createAppServiceBinding(ctx, message)
+		return r.createAppServiceBinding(ctx, authInfo, message)
 	}
 
-	return r.createServiceBinding(ctx, message)
+	return r.createServiceBinding(ctx, authInfo, message)
 }
 
-func (r *ServiceBindingRepo) createAppServiceBinding(ctx context.Context, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
+func (r *ServiceBindingRepo) createAppServiceBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
 	cfApp := &korifiv1alpha1.CFApp{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: message.SpaceGUID,
@@ -191,7 +197,7 @@ func (r *ServiceBindingRepo) createAppServiceBinding(ctx context.Context, messag
 			)
 	}
 
-	bindingRecord, err := r.createServiceBinding(ctx, message)
+	bindingRecord, err := r.createServiceBinding(ctx, authInfo, message)
 	if err != nil {
 		return ServiceBindingRecord{}, err
 	}
@@ -214,7 +220,7 @@ func (r *ServiceBindingRepo) createAppServiceBinding(ctx context.Context, messag
 	return bindingRecord, nil
 }
 
-func (r *ServiceBindingRepo) createServiceBinding(ctx context.Context, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
+func (r *ServiceBindingRepo) createServiceBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceBindingMessage) (ServiceBindingRecord, error) {
 	cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: message.SpaceGUID,
@@ -233,6 +239,9 @@ func (r *ServiceBindingRepo) createServiceBinding(ctx context.Context, message C
 	}
 
 	cfServiceBinding := message.toCFServiceBinding(cfServiceInstance.Spec.Type)
+	if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		cfServiceBinding.Annotations = withOriginatingIdentity(cfServiceBinding.Annotations, authInfo)
+	}
 	err = r.klient.Create(ctx, cfServiceBinding)
 	if err != nil {
 		if validationError, ok := validation.WebhookErrorToValidationError(err); ok {
@@ -298,6 +307,14 @@ func (r *ServiceBindingRepo) DeleteServiceBinding(ctx context.Context, authInfo
 		return apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, ServiceBindingResourceType))
 	}
 
+	err = r.klient.Patch(ctx, binding, func() error {
+		binding.Annotations = withOriginatingIdentity(binding.Annotations, authInfo)
+		return nil
+	})
+	if err != nil {
+		return apierrors.FromK8sError(err, ServiceBindingResourceType)
+	}
+
 	err = r.klient.Delete(ctx, binding)
 	if err != nil {
 		return apierrors.FromK8sError(err, ServiceBindingResourceType)
@@ -394,6 +411,7 @@ func serviceBindingToRecord(binding korifiv1alpha1.CFServiceBinding) ServiceBind
 		DeletedAt:           golangTime(binding.DeletionTimestamp),
 		LastOperation:       serviceBindingRecordLastOperation(binding),
 		Ready:               isBindingReady(binding),
+		SupersededByGUID:    binding.Annotations[korifiv1alpha1.SupersededByAnnotation],
 	}
 }
 
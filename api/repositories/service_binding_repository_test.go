@@ -708,6 +708,27 @@ var _ = Describe("ServiceBindingRepo", func() {
 				})))
 			})
 
+			When("the binding rotates a previous binding", func() {
+				BeforeEach(func() {
+					createMsg.PreviousBindingGUID = "previous-binding-guid"
+				})
+
+				It("sets the previous binding reference", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					serviceBinding := &korifiv1alpha1.CFServiceBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      serviceBindingRecord.GUID,
+							Namespace: space.Name,
+						},
+					}
+					Expect(
+						k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceBinding), serviceBinding),
+					).To(Succeed())
+					Expect(serviceBinding.Spec.PreviousBinding.Name).To(Equal("previous-binding-guid"))
+				})
+			})
+
 			When("the app does not exist", func() {
 				BeforeEach(func() {
 					createMsg.AppGUID = "i-do-not-exits"
@@ -1049,6 +1070,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 		var (
 			serviceBindingGUID string
 			searchGUID         string
+			cfServiceBinding   *korifiv1alpha1.CFServiceBinding
 			serviceBinding     repositories.ServiceBindingRecord
 			getErr             error
 		)
@@ -1057,7 +1079,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 			serviceBindingGUID = uuid.NewString()
 			searchGUID = serviceBindingGUID
 
-			serviceBinding := &korifiv1alpha1.CFServiceBinding{
+			cfServiceBinding = &korifiv1alpha1.CFServiceBinding{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      serviceBindingGUID,
 					Namespace: space.Name,
@@ -1075,7 +1097,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 				},
 			}
 			Expect(
-				k8sClient.Create(ctx, serviceBinding),
+				k8sClient.Create(ctx, cfServiceBinding),
 			).To(Succeed())
 		})
 
@@ -1096,6 +1118,22 @@ var _ = Describe("ServiceBindingRepo", func() {
 				Expect(getErr).NotTo(HaveOccurred())
 
 				Expect(serviceBinding.GUID).To(Equal(serviceBindingGUID))
+				Expect(serviceBinding.SupersededByGUID).To(BeEmpty())
+			})
+
+			When("the CFServiceBinding has been superseded", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfServiceBinding, func() {
+						cfServiceBinding.Annotations = map[string]string{
+							korifiv1alpha1.SupersededByAnnotation: "successor-guid",
+						}
+					})).To(Succeed())
+				})
+
+				It("returns the guid of the successor", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(serviceBinding.SupersededByGUID).To(Equal("successor-guid"))
+				})
 			})
 
 			When("no CFServiceBinding exists", func() {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/BooleanCat/go-functional/v2/it"
@@ -31,11 +33,14 @@ type CreateServiceBrokerMessage struct {
 	URL         string
 	Credentials BrokerCredentials
 	Metadata    Metadata
+	// SpaceGUID is set for space-scoped brokers
+	SpaceGUID string
 }
 
 type ListServiceBrokerMessage struct {
 	Names      []string
 	GUIDs      []string
+	SpaceGUIDs []string
 	Pagination Pagination
 }
 
@@ -43,7 +48,7 @@ func (m *ListServiceBrokerMessage) toListOptions() []ListOption {
 	return []ListOption{
 		WithLabelIn(korifiv1alpha1.GUIDLabelKey, m.GUIDs),
 		WithLabelIn(korifiv1alpha1.CFServiceBrokerDisplayNameLabelKey, tools.EncodeValuesToSha224(m.Names...)),
-		WithPaging(m.Pagination),
+		WithLabelIn(korifiv1alpha1.SpaceGUIDLabelKey, m.SpaceGUIDs),
 	}
 }
 
@@ -67,31 +72,43 @@ func (m UpdateServiceBrokerMessage) apply(broker *korifiv1alpha1.CFServiceBroker
 	m.MetadataPatch.Apply(broker)
 }
 
+// ServiceBrokerRepo manages global brokers, which live in the root namespace,
+// and space-scoped brokers, which live in the namespace of their space
 type ServiceBrokerRepo struct {
-	klient        Klient
-	rootNamespace string
+	rootNSKlient      Klient
+	spaceScopedKlient Klient
+	rootNamespace     string
 }
 
 type ServiceBrokerRecord struct {
 	GUID      string
 	Name      string
 	URL       string
+	SpaceGUID string
 	CreatedAt time.Time
 	UpdatedAt *time.Time
 	Metadata  Metadata
 }
 
 func (r ServiceBrokerRecord) Relationships() map[string]string {
-	return nil
+	if r.SpaceGUID == "" {
+		return nil
+	}
+
+	return map[string]string{
+		"space": r.SpaceGUID,
+	}
 }
 
 func NewServiceBrokerRepo(
-	klient Klient,
+	rootNSKlient Klient,
+	spaceScopedKlient Klient,
 	rootNamespace string,
 ) *ServiceBrokerRepo {
 	return &ServiceBrokerRepo{
-		klient:        klient,
-		rootNamespace: rootNamespace,
+		rootNSKlient:      rootNSKlient,
+		spaceScopedKlient: spaceScopedKlient,
+		rootNamespace:     rootNamespace,
 	}
 }
 
@@ -104,10 +121,15 @@ func (r *ServiceBrokerRepo) CreateServiceBroker(ctx context.Context, authInfo au
 		return ServiceBrokerRecord{}, fmt.Errorf("failed to create credentials secret data: %w", err)
 	}
 
+	klient, namespace := r.rootNSKlient, r.rootNamespace
+	if message.SpaceGUID != "" {
+		klient, namespace = r.spaceScopedKlient, message.SpaceGUID
+	}
+
 	credentialsSecretName := uuid.NewString()
 	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace:   r.rootNamespace,
+			Namespace:   namespace,
 			Name:        uuid.NewString(),
 			Labels:      message.Metadata.Labels,
 			Annotations: message.Metadata.Annotations,
@@ -120,13 +142,13 @@ func (r *ServiceBrokerRepo) CreateServiceBroker(ctx context.Context, authInfo au
 			},
 		},
 	}
-	if err = r.klient.Create(ctx, cfServiceBroker); err != nil {
+	if err = klient.Create(ctx, cfServiceBroker); err != nil {
 		return ServiceBrokerRecord{}, apierrors.FromK8sError(err, ServiceBrokerResourceType)
 	}
 
 	credentialsSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
+			Namespace: namespace,
 			Name:      credentialsSecretName,
 		},
 		Data: credsSecretData,
@@ -136,15 +158,15 @@ func (r *ServiceBrokerRepo) CreateServiceBroker(ctx context.Context, authInfo au
 		return ServiceBrokerRecord{}, apierrors.FromK8sError(err, ServiceBrokerResourceType)
 	}
 
-	if err = r.klient.Create(ctx, credentialsSecret); err != nil {
+	if err = klient.Create(ctx, credentialsSecret); err != nil {
 		return ServiceBrokerRecord{}, apierrors.FromK8sError(err, ServiceBrokerResourceType)
 	}
 
-	return toServiceBrokerRecord(*cfServiceBroker), nil
+	return r.toServiceBrokerRecord(*cfServiceBroker), nil
 }
 
-func toServiceBrokerRecord(cfServiceBroker korifiv1alpha1.CFServiceBroker) ServiceBrokerRecord {
-	return ServiceBrokerRecord{
+func (r *ServiceBrokerRepo) toServiceBrokerRecord(cfServiceBroker korifiv1alpha1.CFServiceBroker) ServiceBrokerRecord {
+	record := ServiceBrokerRecord{
 		Name:      cfServiceBroker.Spec.Name,
 		URL:       cfServiceBroker.Spec.URL,
 		GUID:      cfServiceBroker.Name,
@@ -154,18 +176,18 @@ func toServiceBrokerRecord(cfServiceBroker korifiv1alpha1.CFServiceBroker) Servi
 			Annotations: cfServiceBroker.Annotations,
 		},
 	}
-}
 
-func (r *ServiceBrokerRepo) GetState(ctx context.Context, authInfo authorization.Info, brokerGUID string) (ResourceState, error) {
-	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      brokerGUID,
-		},
+	if cfServiceBroker.Namespace != r.rootNamespace {
+		record.SpaceGUID = cfServiceBroker.Namespace
 	}
 
-	if err := r.klient.Get(ctx, cfServiceBroker); err != nil {
-		return ResourceStateUnknown, apierrors.FromK8sError(err, ServiceBrokerResourceType)
+	return record
+}
+
+func (r *ServiceBrokerRepo) GetState(ctx context.Context, authInfo authorization.Info, brokerGUID string) (ResourceState, error) {
+	cfServiceBroker, err := r.getServiceBroker(ctx, authInfo, brokerGUID)
+	if err != nil {
+		return ResourceStateUnknown, err
 	}
 
 	if cfServiceBroker.Generation != cfServiceBroker.Status.ObservedGeneration {
@@ -180,18 +202,40 @@ func (r *ServiceBrokerRepo) GetState(ctx context.Context, authInfo authorization
 }
 
 func (r *ServiceBrokerRepo) ListServiceBrokers(ctx context.Context, authInfo authorization.Info, message ListServiceBrokerMessage) (ListResult[ServiceBrokerRecord], error) {
-	brokersList := &korifiv1alpha1.CFServiceBrokerList{}
-	pageInfo, err := r.klient.List(ctx, brokersList, message.toListOptions()...)
-	if err != nil {
+	globalBrokers := &korifiv1alpha1.CFServiceBrokerList{}
+	if _, err := r.rootNSKlient.List(ctx, globalBrokers, message.toListOptions()...); err != nil {
 		// All authenticated users are allowed to list brokers. Therefore, the
 		// usual pattern of checking for forbidden error and return an empty
 		// list does not make sense here
 		return ListResult[ServiceBrokerRecord]{}, fmt.Errorf("failed to list brokers: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
 	}
 
+	spaceBrokers := &korifiv1alpha1.CFServiceBrokerList{}
+	if _, err := r.spaceScopedKlient.List(ctx, spaceBrokers, message.toListOptions()...); err != nil {
+		return ListResult[ServiceBrokerRecord]{}, fmt.Errorf("failed to list space-scoped brokers: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	brokers := append(globalBrokers.Items, spaceBrokers.Items...)
+	slices.SortFunc(brokers, func(a, b korifiv1alpha1.CFServiceBroker) int {
+		if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	records := slices.Collect(it.Map(slices.Values(brokers), r.toServiceBrokerRecord))
+
+	recordsPage := descriptors.SinglePage(records, len(records))
+	if !message.Pagination.IsZero() {
+		var err error
+		recordsPage, err = descriptors.GetPage(records, message.Pagination.PerPage, message.Pagination.Page)
+		if err != nil {
+			return ListResult[ServiceBrokerRecord]{}, fmt.Errorf("failed to page service brokers list: %w", err)
+		}
+	}
+
 	return ListResult[ServiceBrokerRecord]{
-		PageInfo: pageInfo,
-		Records:  slices.Collect(it.Map(slices.Values(brokersList.Items), toServiceBrokerRecord)),
+		PageInfo: recordsPage.PageInfo,
+		Records:  recordsPage.Items,
 	}, nil
 }
 
@@ -200,18 +244,19 @@ func (r *ServiceBrokerRepo) GetServiceBroker(ctx context.Context, authInfo autho
 	if err != nil {
 		return ServiceBrokerRecord{}, err
 	}
-	return toServiceBrokerRecord(*serviceBroker), nil
+	return r.toServiceBrokerRecord(*serviceBroker), nil
 }
 
+// The namespace of the broker is resolved from its guid, as it is either the
+// root namespace or the namespace of the space the broker is scoped to
 func (r *ServiceBrokerRepo) getServiceBroker(ctx context.Context, authInfo authorization.Info, guid string) (*korifiv1alpha1.CFServiceBroker, error) {
 	serviceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      guid,
+			Name: guid,
 		},
 	}
 
-	if err := r.klient.Get(ctx, serviceBroker); err != nil {
+	if err := r.spaceScopedKlient.Get(ctx, serviceBroker); err != nil {
 		return nil, apierrors.FromK8sError(err, ServiceBrokerResourceType)
 	}
 
@@ -221,12 +266,11 @@ func (r *ServiceBrokerRepo) getServiceBroker(ctx context.Context, authInfo autho
 func (r *ServiceBrokerRepo) UpdateServiceBroker(ctx context.Context, authInfo authorization.Info, message UpdateServiceBrokerMessage) (ServiceBrokerRecord, error) {
 	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      message.GUID,
-			Namespace: r.rootNamespace,
+			Name: message.GUID,
 		},
 	}
 
-	if err := GetAndPatch(ctx, r.klient, cfServiceBroker, func() error {
+	if err := GetAndPatch(ctx, r.spaceScopedKlient, cfServiceBroker, func() error {
 		message.apply(cfServiceBroker)
 		return nil
 	}); err != nil {
@@ -244,12 +288,12 @@ func (r *ServiceBrokerRepo) UpdateServiceBroker(ctx context.Context, authInfo au
 
 		credentialsSecret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
-				Namespace: r.rootNamespace,
+				Namespace: cfServiceBroker.Namespace,
 				Name:      cfServiceBroker.Spec.Credentials.Name,
 			},
 		}
 
-		if err := GetAndPatch(ctx, r.klient, credentialsSecret, func() error {
+		if err := GetAndPatch(ctx, r.spaceScopedKlient, credentialsSecret, func() error {
 			credentialsSecret.Data = credsSecretData
 			return nil
 		}); err != nil {
@@ -257,19 +301,17 @@ func (r *ServiceBrokerRepo) UpdateServiceBroker(ctx context.Context, authInfo au
 		}
 	}
 
-	return toServiceBrokerRecord(*cfServiceBroker), nil
+	return r.toServiceBrokerRecord(*cfServiceBroker), nil
 }
 
 func (r *ServiceBrokerRepo) DeleteServiceBroker(ctx context.Context, authInfo authorization.Info, guid string) error {
-	serviceBroker := &korifiv1alpha1.CFServiceBroker{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      guid,
-			Namespace: r.rootNamespace,
-		},
+	serviceBroker, err := r.getServiceBroker(ctx, authInfo, guid)
+	if err != nil {
+		return err
 	}
 
 	return apierrors.FromK8sError(
-		r.klient.Delete(ctx, serviceBroker),
+		r.spaceScopedKlient.Delete(ctx, serviceBroker),
 		ServiceBrokerResourceType,
 	)
 }
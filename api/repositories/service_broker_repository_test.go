@@ -25,7 +25,7 @@ var _ = Describe("ServiceBrokerRepo", func() {
 	var repo *repositories.ServiceBrokerRepo
 
 	BeforeEach(func() {
-		repo = repositories.NewServiceBrokerRepo(rootNSKlient, rootNamespace)
+		repo = repositories.NewServiceBrokerRepo(rootNSKlient, spaceScopedKlient, rootNamespace)
 	})
 
 	Describe("Create", func() {
@@ -138,6 +138,38 @@ var _ = Describe("ServiceBrokerRepo", func() {
 				})))
 			})
 		})
+
+		When("the broker is space-scoped", func() {
+			var space *korifiv1alpha1.CFSpace
+
+			BeforeEach(func() {
+				org := createOrgWithCleanup(ctx, uuid.NewString())
+				space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+				createMsg.SpaceGUID = space.Name
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+
+			When("the user is a space developer", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("creates the broker and its credentials in the space namespace", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(brokerRecord.SpaceGUID).To(Equal(space.Name))
+					Expect(brokerRecord.Relationships()).To(Equal(map[string]string{"space": space.Name}))
+
+					cfServiceBroker := &korifiv1alpha1.CFServiceBroker{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: brokerRecord.GUID}, cfServiceBroker)).To(Succeed())
+
+					credentialsSecret := &corev1.Secret{}
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: cfServiceBroker.Spec.Credentials.Name}, credentialsSecret)).To(Succeed())
+				})
+			})
+		})
 	})
 
 	Describe("GetState", func() {
@@ -273,27 +305,69 @@ var _ = Describe("ServiceBrokerRepo", func() {
 			Expect(brokers.PageInfo.TotalResults).To(Equal(1))
 		})
 
+		When("there is a space-scoped broker", func() {
+			var space *korifiv1alpha1.CFSpace
+
+			BeforeEach(func() {
+				org := createOrgWithCleanup(ctx, uuid.NewString())
+				space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceBroker{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: space.Name,
+						Name:      "space-broker",
+					},
+					Spec: korifiv1alpha1.CFServiceBrokerSpec{
+						Name: "space-broker",
+						URL:  "https://space.broker",
+					},
+				})).To(Succeed())
+			})
+
+			It("does not return it to users outside the space", func() {
+				Expect(brokers.Records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal("broker-1")}),
+				))
+			})
+
+			When("the user is a member of the space", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("returns both the global and the space-scoped brokers", func() {
+					Expect(brokers.Records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal("broker-1"), "SpaceGUID": BeEmpty()}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal("space-broker"), "SpaceGUID": Equal(space.Name)}),
+					))
+				})
+			})
+		})
+
 		Describe("parameters to list options", func() {
 			var fakeKlient *fake.Klient
 
 			BeforeEach(func() {
 				fakeKlient = new(fake.Klient)
-				repo = repositories.NewServiceBrokerRepo(fakeKlient, rootNamespace)
+				repo = repositories.NewServiceBrokerRepo(fakeKlient, fakeKlient, rootNamespace)
 				message = repositories.ListServiceBrokerMessage{
 					Names:      []string{"first-broker", "second-broker"},
 					GUIDs:      []string{"broker-1", "broker-2"},
+					SpaceGUIDs: []string{"space-1"},
 					Pagination: repositories.Pagination{Page: 1, PerPage: 10},
 				}
 			})
 
 			It("translates filter parameters to klient list options", func() {
-				Expect(fakeKlient.ListCallCount()).To(Equal(1))
-				_, _, listOptions := fakeKlient.ListArgsForCall(0)
-				Expect(listOptions).To(ConsistOf(
-					repositories.WithLabelIn(korifiv1alpha1.GUIDLabelKey, []string{"broker-1", "broker-2"}),
-					repositories.WithLabelIn(korifiv1alpha1.CFServiceBrokerDisplayNameLabelKey, tools.EncodeValuesToSha224("first-broker", "second-broker")),
-					repositories.WithPaging(repositories.Pagination{Page: 1, PerPage: 10}),
-				))
+				Expect(fakeKlient.ListCallCount()).To(Equal(2))
+				for i := range 2 {
+					_, _, listOptions := fakeKlient.ListArgsForCall(i)
+					Expect(listOptions).To(ConsistOf(
+						repositories.WithLabelIn(korifiv1alpha1.GUIDLabelKey, []string{"broker-1", "broker-2"}),
+						repositories.WithLabelIn(korifiv1alpha1.CFServiceBrokerDisplayNameLabelKey, tools.EncodeValuesToSha224("first-broker", "second-broker")),
+						repositories.WithLabelIn(korifiv1alpha1.SpaceGUIDLabelKey, []string{"space-1"}),
+					))
+				}
 			})
 		})
 	})
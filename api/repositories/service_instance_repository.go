@@ -34,28 +34,32 @@ type NamespaceGetter interface {
 type ServiceInstanceRepo struct {
 	klient        Klient
 	awaiter       Awaiter[*korifiv1alpha1.CFServiceInstance]
+	paramsClient  ParametersClient
 	rootNamespace string
 }
 
 func NewServiceInstanceRepo(
 	klient Klient,
 	awaiter Awaiter[*korifiv1alpha1.CFServiceInstance],
+	paramsClient ParametersClient,
 	rootNamespace string,
 ) *ServiceInstanceRepo {
 	return &ServiceInstanceRepo{
 		klient:        klient,
 		awaiter:       awaiter,
+		paramsClient:  paramsClient,
 		rootNamespace: rootNamespace,
 	}
 }
 
 type CreateUPSIMessage struct {
-	Name        string
-	SpaceGUID   string
-	Credentials map[string]any
-	Tags        []string
-	Labels      map[string]string
-	Annotations map[string]string
+	Name           string
+	SpaceGUID      string
+	Credentials    map[string]any
+	SyslogDrainURL *string
+	Tags           []string
+	Labels         map[string]string
+	Annotations    map[string]string
 }
 
 type CreateManagedSIMessage struct {
@@ -69,11 +73,12 @@ type CreateManagedSIMessage struct {
 }
 
 type PatchServiceInstanceMessage struct {
-	GUID        string
-	SpaceGUID   string
-	Name        *string
-	Credentials *map[string]any
-	Tags        *[]string
+	GUID           string
+	SpaceGUID      string
+	Name           *string
+	Credentials    *map[string]any
+	SyslogDrainURL *string
+	Tags           *[]string
 	MetadataPatch
 }
 
@@ -84,6 +89,12 @@ func (p PatchServiceInstanceMessage) Apply(cfServiceInstance *korifiv1alpha1.CFS
 	if p.Tags != nil {
 		cfServiceInstance.Spec.Tags = *p.Tags
 	}
+	if p.SyslogDrainURL != nil {
+		cfServiceInstance.Spec.SyslogDrainURL = nil
+		if *p.SyslogDrainURL != "" {
+			cfServiceInstance.Spec.SyslogDrainURL = p.SyslogDrainURL
+		}
+	}
 	p.MetadataPatch.Apply(cfServiceInstance)
 }
 
@@ -130,6 +141,7 @@ type ServiceInstanceRecord struct {
 	PlanGUID         string
 	Tags             []string
 	Type             string
+	SyslogDrainURL   *string
 	Labels           map[string]string
 	Annotations      map[string]string
 	CreatedAt        time.Time
@@ -161,10 +173,11 @@ func (r *ServiceInstanceRepo) CreateUserProvidedServiceInstance(ctx context.Cont
 			Annotations: message.Annotations,
 		},
 		Spec: korifiv1alpha1.CFServiceInstanceSpec{
-			DisplayName: message.Name,
-			SecretName:  uuid.NewString(),
-			Type:        korifiv1alpha1.UserProvidedType,
-			Tags:        message.Tags,
+			DisplayName:    message.Name,
+			SecretName:     uuid.NewString(),
+			Type:           korifiv1alpha1.UserProvidedType,
+			Tags:           message.Tags,
+			SyslogDrainURL: message.SyslogDrainURL,
 		},
 	}
 	err := r.klient.Create(ctx, cfServiceInstance)
@@ -195,7 +208,7 @@ func (r *ServiceInstanceRepo) CreateManagedServiceInstance(ctx context.Context,
 			Name:        uuid.NewString(),
 			Namespace:   message.SpaceGUID,
 			Labels:      message.Labels,
-			Annotations: message.Annotations,
+			Annotations: withOriginatingIdentity(message.Annotations, authInfo),
 		},
 		Spec: korifiv1alpha1.CFServiceInstanceSpec{
 			DisplayName: message.Name,
@@ -243,8 +256,7 @@ func (r *ServiceInstanceRepo) createParametersSecret(ctx context.Context, cfServ
 func (r *ServiceInstanceRepo) servicePlanVisible(ctx context.Context, planGUID string, spaceGUID string) (bool, error) {
 	servicePlan := &korifiv1alpha1.CFServicePlan{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      planGUID,
-			Namespace: r.rootNamespace,
+			Name: planGUID,
 		},
 	}
 	err := r.klient.Get(ctx, servicePlan)
@@ -252,10 +264,32 @@ func (r *ServiceInstanceRepo) servicePlanVisible(ctx context.Context, planGUID s
 		return false, err
 	}
 
+	if servicePlan.Spec.Inactive {
+		return false, nil
+	}
+
+	serviceOffering := &korifiv1alpha1.CFServiceOffering{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel],
+		},
+	}
+	err = r.klient.Get(ctx, serviceOffering)
+	if err != nil {
+		return false, err
+	}
+
+	if serviceOffering.Spec.Inactive {
+		return false, nil
+	}
+
 	if servicePlan.Spec.Visibility.Type == korifiv1alpha1.PublicServicePlanVisibilityType {
 		return true, nil
 	}
 
+	if servicePlan.Spec.Visibility.Type == korifiv1alpha1.SpaceServicePlanVisibilityType {
+		return servicePlan.Namespace == spaceGUID, nil
+	}
+
 	if servicePlan.Spec.Visibility.Type == korifiv1alpha1.AdminServicePlanVisibilityType {
 		return false, nil
 	}
@@ -429,6 +463,50 @@ func (r *ServiceInstanceRepo) GetServiceInstanceCredentials(ctx context.Context,
 	return credentials, nil
 }
 
+// Parameters are fetched from the broker when the service offering supports
+// retrieving instances. Otherwise the parameters the instance was created with
+// are returned
+func (r *ServiceInstanceRepo) GetServiceInstanceParameters(ctx context.Context, authInfo authorization.Info, instanceGUID string) (map[string]any, error) {
+	serviceInstance := &korifiv1alpha1.CFServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: instanceGUID,
+		},
+	}
+	if err := r.klient.Get(ctx, serviceInstance); err != nil {
+		return map[string]any{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	parameters, err := r.paramsClient.GetServiceInstanceParameters(ctx, serviceInstance)
+	if err == nil {
+		return parameters, nil
+	}
+
+	if !errors.Is(err, ErrInstancesNotRetrievable) {
+		return map[string]any{}, err
+	}
+
+	if serviceInstance.Spec.Parameters.Name == "" {
+		return map[string]any{}, nil
+	}
+
+	paramsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceInstance.Spec.Parameters.Name,
+			Namespace: serviceInstance.Namespace,
+		},
+	}
+	if err = r.klient.Get(ctx, paramsSecret); err != nil {
+		return map[string]any{}, fmt.Errorf("failed to get parameters secret for service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	parameters, err = tools.FromParametersSecretData(paramsSecret.Data)
+	if err != nil {
+		return map[string]any{}, apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("failed to decode parameters secret for service instance: %s", instanceGUID))
+	}
+
+	return parameters, nil
+}
+
 func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInfo authorization.Info, message DeleteServiceInstanceMessage) (ServiceInstanceRecord, error) {
 	serviceInstance := &korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
@@ -447,6 +525,13 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 		}); err != nil {
 			return ServiceInstanceRecord{}, fmt.Errorf("failed to remove finalizer for service instance: %s, %w", message.GUID, apierrors.FromK8sError(err, ServiceInstanceResourceType))
 		}
+	} else if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		if err := r.klient.Patch(ctx, serviceInstance, func() error {
+			serviceInstance.Annotations = withOriginatingIdentity(serviceInstance.Annotations, authInfo)
+			return nil
+		}); err != nil {
+			return ServiceInstanceRecord{}, fmt.Errorf("failed to set originating identity for service instance: %s, %w", message.GUID, apierrors.FromK8sError(err, ServiceInstanceResourceType))
+		}
 	}
 
 	err := r.klient.Delete(ctx, serviceInstance)
@@ -457,6 +542,16 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 	return cfServiceInstanceToRecord(*serviceInstance), nil
 }
 
+// The originating identity is sent to brokers by the controllers on every
+// broker operation, so it is recorded on managed instances and bindings
+func withOriginatingIdentity(annotations map[string]string, authInfo authorization.Info) map[string]string {
+	if authInfo.UserName == "" {
+		return annotations
+	}
+
+	return tools.SetMapValue(annotations, korifiv1alpha1.OriginatingIdentityAnnotation, authInfo.UserName)
+}
+
 func (r ServiceInstanceRecord) GetResourceType() string {
 	return ServiceInstanceResourceType
 }
@@ -484,19 +579,20 @@ func (r *ServiceInstanceRepo) GetDeletedAt(ctx context.Context, authInfo authori
 
 func cfServiceInstanceToRecord(cfServiceInstance korifiv1alpha1.CFServiceInstance) ServiceInstanceRecord {
 	return ServiceInstanceRecord{
-		Name:          cfServiceInstance.Spec.DisplayName,
-		GUID:          cfServiceInstance.Name,
-		SpaceGUID:     cfServiceInstance.Namespace,
-		PlanGUID:      cfServiceInstance.Spec.PlanGUID,
-		Tags:          cfServiceInstance.Spec.Tags,
-		Type:          string(cfServiceInstance.Spec.Type),
-		Labels:        cfServiceInstance.Labels,
-		Annotations:   cfServiceInstance.Annotations,
-		CreatedAt:     cfServiceInstance.CreationTimestamp.Time,
-		UpdatedAt:     getLastUpdatedTime(&cfServiceInstance),
-		DeletedAt:     golangTime(cfServiceInstance.DeletionTimestamp),
-		LastOperation: cfServiceInstance.Status.LastOperation,
-		Ready:         isInstanceReady(cfServiceInstance),
+		Name:           cfServiceInstance.Spec.DisplayName,
+		GUID:           cfServiceInstance.Name,
+		SpaceGUID:      cfServiceInstance.Namespace,
+		PlanGUID:       cfServiceInstance.Spec.PlanGUID,
+		Tags:           cfServiceInstance.Spec.Tags,
+		Type:           string(cfServiceInstance.Spec.Type),
+		SyslogDrainURL: cfServiceInstance.Spec.SyslogDrainURL,
+		Labels:         cfServiceInstance.Labels,
+		Annotations:    cfServiceInstance.Annotations,
+		CreatedAt:      cfServiceInstance.CreationTimestamp.Time,
+		UpdatedAt:      getLastUpdatedTime(&cfServiceInstance),
+		DeletedAt:      golangTime(cfServiceInstance.DeletionTimestamp),
+		LastOperation:  cfServiceInstance.Status.LastOperation,
+		Ready:          isInstanceReady(cfServiceInstance),
 		MaintenanceInfo: MaintenanceInfo{
 			Version: cfServiceInstance.Status.MaintenanceInfo.Version,
 		},
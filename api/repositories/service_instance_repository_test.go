@@ -12,6 +12,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	"code.cloudfoundry.org/korifi/api/repositories/fakeawaiter"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	osbapifake "code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi/fake"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -39,6 +41,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			*korifiv1alpha1.CFServiceInstanceList,
 		]
 
+		paramsClient        *repositories.ServiceBrokerClient
+		brokerClient        *osbapifake.BrokerClient
 		org                 *korifiv1alpha1.CFOrg
 		space               *korifiv1alpha1.CFSpace
 		serviceInstanceName string
@@ -51,9 +55,20 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			*korifiv1alpha1.CFServiceInstanceList,
 		]{}
 
+		brokerClient = new(osbapifake.BrokerClient)
+		brokerClientFactory := new(osbapifake.BrokerClientFactory)
+		brokerClientFactory.CreateClientReturns(brokerClient, nil)
+
+		paramsClient = repositories.NewServiceBrokerClient(
+			brokerClientFactory,
+			k8sClient,
+			rootNamespace,
+		)
+
 		serviceInstanceRepo = repositories.NewServiceInstanceRepo(
 			spaceScopedKlient,
 			conditionAwaiter,
+			paramsClient,
 			rootNamespace,
 		)
 
@@ -76,7 +91,8 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Credentials: map[string]any{
 					"object": map[string]any{"a": "b"},
 				},
-				Tags: []string{"foo", "bar"},
+				SyslogDrainURL: tools.PtrTo("syslog-tls://logs.example.com:6514"),
+				Tags:           []string{"foo", "bar"},
 			}
 		})
 
@@ -101,6 +117,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(record.Name).To(Equal(serviceInstanceName))
 				Expect(record.Type).To(Equal("user-provided"))
 				Expect(record.Tags).To(ConsistOf([]string{"foo", "bar"}))
+				Expect(record.SyslogDrainURL).To(PointTo(Equal("syslog-tls://logs.example.com:6514")))
 				Expect(record.Relationships()).To(Equal(map[string]string{
 					"space": space.Name,
 				}))
@@ -123,6 +140,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(cfServiceInstance.Spec.DisplayName).To(Equal(serviceInstanceName))
 				Expect(cfServiceInstance.Spec.SecretName).NotTo(BeEmpty())
 				Expect(cfServiceInstance.Spec.Type).To(BeEquivalentTo(korifiv1alpha1.UserProvidedType))
+				Expect(cfServiceInstance.Spec.SyslogDrainURL).To(PointTo(Equal("syslog-tls://logs.example.com:6514")))
 				Expect(cfServiceInstance.Spec.Tags).To(ConsistOf("foo", "bar"))
 			})
 
@@ -158,6 +176,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 
 	Describe("CreateManagedServiceInstance", func() {
 		var (
+			serviceOffering              *korifiv1alpha1.CFServiceOffering
 			servicePlan                  *korifiv1alpha1.CFServicePlan
 			serviceInstanceCreateMessage repositories.CreateManagedSIMessage
 			record                       repositories.ServiceInstanceRecord
@@ -165,10 +184,21 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		)
 
 		BeforeEach(func() {
+			serviceOffering = &korifiv1alpha1.CFServiceOffering{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceOffering)).To(Succeed())
+
 			servicePlan = &korifiv1alpha1.CFServicePlan{
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: rootNamespace,
 					Name:      uuid.NewString(),
+					Labels: map[string]string{
+						korifiv1alpha1.RelServiceOfferingGUIDLabel: serviceOffering.Name,
+					},
 				},
 				Spec: korifiv1alpha1.CFServicePlanSpec{
 					Visibility: korifiv1alpha1.ServicePlanVisibility{
@@ -238,6 +268,28 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(cfServiceInstance.Spec.Parameters.Name).NotTo(BeNil())
 			})
 
+			When("the name of the user is known", func() {
+				BeforeEach(func() {
+					authInfo.UserName = userName
+					DeferCleanup(func() {
+						authInfo.UserName = ""
+					})
+				})
+
+				It("records it as the originating identity of the instance", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					cfServiceInstance := &korifiv1alpha1.CFServiceInstance{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: record.SpaceGUID,
+							Name:      record.GUID,
+						},
+					}
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), cfServiceInstance)).To(Succeed())
+					Expect(cfServiceInstance.Annotations).To(HaveKeyWithValue(korifiv1alpha1.OriginatingIdentityAnnotation, userName))
+				})
+			})
+
 			It("creates the parameters secret", func() {
 				serviceInstance := new(korifiv1alpha1.CFServiceInstance)
 				Expect(
@@ -312,6 +364,30 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				})
 			})
 
+			When("the service plan is inactive", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, servicePlan, func() {
+						servicePlan.Spec.Inactive = true
+					})).To(Succeed())
+				})
+
+				It("returns unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the service offering is inactive", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceOffering, func() {
+						serviceOffering.Spec.Inactive = true
+					})).To(Succeed())
+				})
+
+				It("returns unprocessable entity error", func() {
+					Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
 			When("the service plan does not exist", func() {
 				BeforeEach(func() {
 					serviceInstanceCreateMessage.PlanGUID = "does-not-exist"
@@ -529,11 +605,12 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
 
 			patchMessage = repositories.PatchServiceInstanceMessage{
-				GUID:        cfServiceInstance.Name,
-				SpaceGUID:   space.Name,
-				Name:        tools.PtrTo("new-name"),
-				Credentials: nil,
-				Tags:        &[]string{"new"},
+				GUID:           cfServiceInstance.Name,
+				SpaceGUID:      space.Name,
+				Name:           tools.PtrTo("new-name"),
+				Credentials:    nil,
+				SyslogDrainURL: tools.PtrTo("https://logs.example.com"),
+				Tags:           &[]string{"new"},
 				MetadataPatch: repositories.MetadataPatch{
 					Labels:      map[string]*string{"new-label": tools.PtrTo("new-label-value")},
 					Annotations: map[string]*string{"new-annotation": tools.PtrTo("new-annotation-value")},
@@ -570,6 +647,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 					g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
 					g.Expect(serviceInstance.Spec.DisplayName).To(Equal("new-name"))
 					g.Expect(serviceInstance.Spec.Tags).To(ConsistOf("new"))
+					g.Expect(serviceInstance.Spec.SyslogDrainURL).To(PointTo(Equal("https://logs.example.com")))
 					g.Expect(serviceInstance.Labels).To(HaveKeyWithValue("a-label", "a-label-value"))
 					g.Expect(serviceInstance.Labels).To(HaveKeyWithValue("new-label", "new-label-value"))
 					g.Expect(serviceInstance.Annotations).To(HaveLen(2))
@@ -578,6 +656,22 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				}).Should(Succeed())
 			})
 
+			When("the syslog drain url is empty", func() {
+				BeforeEach(func() {
+					patchMessage.SyslogDrainURL = tools.PtrTo("")
+				})
+
+				It("clears the syslog drain url", func() {
+					Expect(err).NotTo(HaveOccurred())
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+
+					Eventually(func(g Gomega) {
+						g.Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), serviceInstance)).To(Succeed())
+						g.Expect(serviceInstance.Spec.SyslogDrainURL).To(BeNil())
+					}).Should(Succeed())
+				})
+			})
+
 			When("tags is an empty list", func() {
 				BeforeEach(func() {
 					patchMessage.Tags = &[]string{}
@@ -738,7 +832,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			DescribeTable("ordering",
 				func(msg repositories.ListServiceInstanceMessage, match gomega_types.GomegaMatcher) {
 					fakeKlient := new(fake.Klient)
-					instancesRepo := repositories.NewServiceInstanceRepo(fakeKlient, conditionAwaiter, rootNamespace)
+					instancesRepo := repositories.NewServiceInstanceRepo(fakeKlient, conditionAwaiter, paramsClient, rootNamespace)
 
 					_, err := instancesRepo.ListServiceInstances(ctx, authInfo, msg)
 					Expect(err).NotTo(HaveOccurred())
@@ -756,7 +850,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 
 				BeforeEach(func() {
 					fakeKlient = new(fake.Klient)
-					serviceInstanceRepo = repositories.NewServiceInstanceRepo(fakeKlient, conditionAwaiter, rootNamespace)
+					serviceInstanceRepo = repositories.NewServiceInstanceRepo(fakeKlient, conditionAwaiter, paramsClient, rootNamespace)
 					filters = repositories.ListServiceInstanceMessage{
 						Names:         []string{"instance-1", "instance-2"},
 						SpaceGUIDs:    []string{"space-guid-1", "space-guid-2"},
@@ -1005,6 +1099,155 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
+	Describe("GetServiceInstanceParameters", func() {
+		var (
+			instanceGUID     string
+			serviceOffering  *korifiv1alpha1.CFServiceOffering
+			paramsSecret     *corev1.Secret
+			parameters       map[string]any
+			getParametersErr error
+		)
+
+		BeforeEach(func() {
+			instanceGUID = uuid.NewString()
+
+			serviceBroker := &korifiv1alpha1.CFServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceBroker)).To(Succeed())
+
+			serviceOffering = &korifiv1alpha1.CFServiceOffering{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceOfferingSpec{
+					BrokerCatalog: korifiv1alpha1.ServiceBrokerCatalog{
+						ID: "offering-id",
+						Features: korifiv1alpha1.BrokerCatalogFeatures{
+							InstancesRetrievable: true,
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceOffering)).To(Succeed())
+
+			servicePlan := &korifiv1alpha1.CFServicePlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+					Labels: map[string]string{
+						korifiv1alpha1.RelServiceBrokerGUIDLabel:   serviceBroker.Name,
+						korifiv1alpha1.RelServiceOfferingGUIDLabel: serviceOffering.Name,
+					},
+				},
+				Spec: korifiv1alpha1.CFServicePlanSpec{
+					BrokerCatalog: korifiv1alpha1.ServicePlanBrokerCatalog{
+						ID: "plan-id",
+					},
+					Visibility: korifiv1alpha1.ServicePlanVisibility{
+						Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, servicePlan)).To(Succeed())
+
+			serviceInstance := &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      instanceGUID,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					Type:     korifiv1alpha1.ManagedType,
+					PlanGUID: servicePlan.Name,
+					Parameters: corev1.LocalObjectReference{
+						Name: instanceGUID + "-params",
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, serviceInstance)).To(Succeed())
+
+			paramsData, err := tools.ToParametersSecretData(map[string]any{"stored": "param"})
+			Expect(err).NotTo(HaveOccurred())
+			paramsSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      instanceGUID + "-params",
+				},
+				Data: paramsData,
+			}
+			Expect(k8sClient.Create(ctx, paramsSecret)).To(Succeed())
+
+			brokerClient.GetServiceInstanceReturns(osbapi.ServiceInstanceResponse{
+				Parameters: map[string]any{"broker": "param"},
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			parameters, getParametersErr = serviceInstanceRepo.GetServiceInstanceParameters(ctx, authInfo, instanceGUID)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getParametersErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("fetches the parameters from the broker", func() {
+				Expect(getParametersErr).NotTo(HaveOccurred())
+				Expect(parameters).To(Equal(map[string]any{"broker": "param"}))
+
+				Expect(brokerClient.GetServiceInstanceCallCount()).To(Equal(1))
+				_, actualRequest := brokerClient.GetServiceInstanceArgsForCall(0)
+				Expect(actualRequest).To(Equal(osbapi.GetServiceInstanceRequest{
+					InstanceID: instanceGUID,
+					ServiceId:  "offering-id",
+					PlanID:     "plan-id",
+				}))
+			})
+
+			When("fetching the instance from the broker fails", func() {
+				BeforeEach(func() {
+					brokerClient.GetServiceInstanceReturns(osbapi.ServiceInstanceResponse{}, errors.New("fetch-failed"))
+				})
+
+				It("returns the error", func() {
+					Expect(getParametersErr).To(MatchError(ContainSubstring("fetch-failed")))
+				})
+			})
+
+			When("the service offering does not support retrieving instances", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, serviceOffering, func() {
+						serviceOffering.Spec.BrokerCatalog.Features.InstancesRetrievable = false
+					})).To(Succeed())
+				})
+
+				It("returns the stored parameters", func() {
+					Expect(getParametersErr).NotTo(HaveOccurred())
+					Expect(parameters).To(Equal(map[string]any{"stored": "param"}))
+					Expect(brokerClient.GetServiceInstanceCallCount()).To(BeZero())
+				})
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					instanceGUID = "does-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(getParametersErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
 	Describe("DeleteServiceInstance", func() {
 		var (
 			serviceInstance *korifiv1alpha1.CFServiceInstance
@@ -53,6 +53,9 @@ func (r ServiceOfferingRecord) Relationships() map[string]string {
 	}
 }
 
+// ServiceOfferingRepo serves the offerings of global brokers from the root
+// namespace and the offerings of space-scoped brokers from the namespaces of
+// the spaces the user has access to
 type ServiceOfferingRepo struct {
 	rootNSKlient      Klient
 	spaceScopedKlient Klient
@@ -102,12 +105,11 @@ func NewServiceOfferingRepo(
 func (r *ServiceOfferingRepo) GetServiceOffering(ctx context.Context, authInfo authorization.Info, guid string) (ServiceOfferingRecord, error) {
 	offering := &korifiv1alpha1.CFServiceOffering{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      guid,
+			Name: guid,
 		},
 	}
 
-	if err := r.rootNSKlient.Get(ctx, offering); err != nil {
+	if err := r.spaceScopedKlient.Get(ctx, offering); err != nil {
 		return ServiceOfferingRecord{}, fmt.Errorf("failed to get service offering: %s %w", guid, apierrors.FromK8sError(err, ServiceOfferingResourceType))
 	}
 
@@ -115,40 +117,43 @@ func (r *ServiceOfferingRepo) GetServiceOffering(ctx context.Context, authInfo a
 }
 
 func (r *ServiceOfferingRepo) ListOfferings(ctx context.Context, authInfo authorization.Info, message ListServiceOfferingMessage) ([]ServiceOfferingRecord, error) {
-	offeringsList := &korifiv1alpha1.CFServiceOfferingList{}
-	_, err := r.rootNSKlient.List(ctx, offeringsList, message.toListOptions()...)
-	if err != nil {
-		if k8serrors.IsForbidden(err) {
-			return []ServiceOfferingRecord{}, nil
-		}
+	offerings := []korifiv1alpha1.CFServiceOffering{}
+	for _, klient := range []Klient{r.rootNSKlient, r.spaceScopedKlient} {
+		offeringsList := &korifiv1alpha1.CFServiceOfferingList{}
+		_, err := klient.List(ctx, offeringsList, message.toListOptions()...)
+		if err != nil {
+			if k8serrors.IsForbidden(err) {
+				continue
+			}
 
-		return []ServiceOfferingRecord{}, fmt.Errorf("failed to list service offerings: %w",
-			apierrors.FromK8sError(err, ServiceOfferingResourceType),
-		)
+			return []ServiceOfferingRecord{}, fmt.Errorf("failed to list service offerings: %w",
+				apierrors.FromK8sError(err, ServiceOfferingResourceType),
+			)
+		}
+		offerings = append(offerings, offeringsList.Items...)
 	}
 
-	return it.TryCollect(it.MapError(itx.FromSlice(offeringsList.Items), offeringToRecord))
+	return it.TryCollect(it.MapError(itx.FromSlice(offerings), offeringToRecord))
 }
 
 func (r *ServiceOfferingRepo) DeleteOffering(ctx context.Context, authInfo authorization.Info, message DeleteServiceOfferingMessage) error {
 	offering := &korifiv1alpha1.CFServiceOffering{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      message.GUID,
+			Name: message.GUID,
 		},
 	}
 
-	if err := r.rootNSKlient.Get(ctx, offering); err != nil {
+	if err := r.spaceScopedKlient.Get(ctx, offering); err != nil {
 		return fmt.Errorf("failed to get service offering: %w", apierrors.FromK8sError(err, ServiceOfferingResourceType))
 	}
 
 	if message.Purge {
-		if err := r.purgeRelatedResources(ctx, message.GUID); err != nil {
+		if err := r.purgeRelatedResources(ctx, offering); err != nil {
 			return fmt.Errorf("failed to purge service offering resources: %w", apierrors.FromK8sError(err, ServiceOfferingResourceType))
 		}
 	}
 
-	if err := r.rootNSKlient.Delete(ctx, offering); err != nil {
+	if err := r.spaceScopedKlient.Delete(ctx, offering); err != nil {
 		return fmt.Errorf("failed to delete service offering: %w", apierrors.FromK8sError(err, ServiceOfferingResourceType))
 	}
 
@@ -182,8 +187,8 @@ func offeringToRecord(offering korifiv1alpha1.CFServiceOffering) (ServiceOfferin
 	}, nil
 }
 
-func (r *ServiceOfferingRepo) purgeRelatedResources(ctx context.Context, offeringGUID string) error {
-	planGUIDs, err := r.deleteServicePlans(ctx, offeringGUID)
+func (r *ServiceOfferingRepo) purgeRelatedResources(ctx context.Context, offering *korifiv1alpha1.CFServiceOffering) error {
+	planGUIDs, err := r.deleteServicePlans(ctx, offering)
 	if err != nil {
 		return fmt.Errorf("failed to delete service plans: %w", apierrors.FromK8sError(err, ServicePlanResourceType))
 	}
@@ -230,17 +235,17 @@ func (r *ServiceOfferingRepo) purgeRelatedResources(ctx context.Context, offerin
 	return nil
 }
 
-func (r *ServiceOfferingRepo) deleteServicePlans(ctx context.Context, offeringGUID string) ([]string, error) {
+func (r *ServiceOfferingRepo) deleteServicePlans(ctx context.Context, offering *korifiv1alpha1.CFServiceOffering) ([]string, error) {
 	var planGUIDs []string
 	plans := &korifiv1alpha1.CFServicePlanList{}
 
-	if _, err := r.rootNSKlient.List(ctx, plans, WithLabel(korifiv1alpha1.RelServiceOfferingGUIDLabel, offeringGUID)); err != nil {
+	if _, err := r.spaceScopedKlient.List(ctx, plans, InNamespace(offering.Namespace), WithLabel(korifiv1alpha1.RelServiceOfferingGUIDLabel, offering.Name)); err != nil {
 		return []string{}, fmt.Errorf("failed to list service plans: %w", err)
 	}
 
 	for _, plan := range plans.Items {
 		planGUIDs = append(planGUIDs, plan.Name)
-		if err := r.rootNSKlient.Delete(ctx, &plan); err != nil {
+		if err := r.spaceScopedKlient.Delete(ctx, &plan); err != nil {
 			return []string{}, apierrors.FromK8sError(err, ServicePlanResourceType)
 		}
 	}
@@ -271,11 +276,10 @@ func (r *ServiceOfferingRepo) fetchServiceBindings(ctx context.Context, serviceI
 func (r *ServiceOfferingRepo) UpdateServiceOffering(ctx context.Context, authInfo authorization.Info, message UpdateServiceOfferingMessage) (ServiceOfferingRecord, error) {
 	offering := &korifiv1alpha1.CFServiceOffering{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      message.GUID,
+			Name: message.GUID,
 		},
 	}
-	if err := GetAndPatch(ctx, r.rootNSKlient, offering, func() error {
+	if err := GetAndPatch(ctx, r.spaceScopedKlient, offering, func() error {
 		message.apply(offering)
 		return nil
 	}); err != nil {
@@ -282,6 +282,43 @@ var _ = Describe("ServiceOfferingRepo", func() {
 			})
 		})
 
+		When("there is an offering of a space-scoped broker", func() {
+			var spaceOfferingGUID string
+
+			BeforeEach(func() {
+				spaceOfferingGUID = uuid.NewString()
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceOffering{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: space.Name,
+						Name:      spaceOfferingGUID,
+					},
+					Spec: korifiv1alpha1.CFServiceOfferingSpec{
+						Name: "space-offering",
+					},
+				})).To(Succeed())
+			})
+
+			It("does not return it to users outside the space", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listedOfferings).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"GUID": Equal(spaceOfferingGUID),
+				})))
+			})
+
+			When("the user is a member of the space", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				})
+
+				It("returns it", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(listedOfferings).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"GUID": Equal(spaceOfferingGUID),
+					})))
+				})
+			})
+		})
+
 		Describe("filter parameters to list options", func() {
 			var fakeKlient *fake.Klient
 
@@ -289,7 +326,7 @@ var _ = Describe("ServiceOfferingRepo", func() {
 				fakeKlient = new(fake.Klient)
 				repo = repositories.NewServiceOfferingRepo(
 					fakeKlient,
-					nil,
+					fakeKlient,
 					rootNamespace,
 				)
 				message = repositories.ListServiceOfferingMessage{
@@ -301,7 +338,7 @@ var _ = Describe("ServiceOfferingRepo", func() {
 
 			It("translates filter parameters to klient list options", func() {
 				Expect(listErr).NotTo(HaveOccurred())
-				Expect(fakeKlient.ListCallCount()).To(Equal(1))
+				Expect(fakeKlient.ListCallCount()).To(Equal(2))
 				_, _, listOptions := fakeKlient.ListArgsForCall(0)
 				Expect(listOptions).To(ConsistOf(
 					repositories.WithLabelIn(korifiv1alpha1.CFServiceOfferingNameKey, tools.EncodeValuesToSha224("n1", "n2")),
@@ -60,12 +60,14 @@ type ServicePlanSchemas struct {
 }
 
 type ServicePlanFeatures struct {
-	PlanUpdateable bool
-	Bindable       bool
+	PlanUpdateable   bool
+	Bindable         bool
+	BindingRotatable bool
 }
 
 type MaintenanceInfo struct {
-	Version string
+	Version     string
+	Description string
 }
 
 func (r ServicePlanRecord) Relationships() map[string]string {
@@ -85,9 +87,10 @@ type VisibilityOrganization struct {
 }
 
 type ServicePlanRepo struct {
-	klient        Klient
-	rootNamespace string
-	orgRepo       *OrgRepo
+	rootNSKlient      Klient
+	spaceScopedKlient Klient
+	rootNamespace     string
+	orgRepo           *OrgRepo
 }
 
 type ListServicePlanMessage struct {
@@ -166,24 +169,33 @@ func (m *DeleteServicePlanVisibilityMessage) apply(cfServicePlan *korifiv1alpha1
 }
 
 func NewServicePlanRepo(
-	klient Klient,
+	rootNSKlient Klient,
+	spaceScopedKlient Klient,
 	rootNamespace string,
 	orgRepo *OrgRepo,
 ) *ServicePlanRepo {
 	return &ServicePlanRepo{
-		klient:        klient,
-		rootNamespace: rootNamespace,
-		orgRepo:       orgRepo,
+		rootNSKlient:      rootNSKlient,
+		spaceScopedKlient: spaceScopedKlient,
+		rootNamespace:     rootNamespace,
+		orgRepo:           orgRepo,
 	}
 }
 
+// ListPlans lists the plans of global brokers from the root namespace and the
+// plans of space-scoped brokers from the namespaces of the spaces the user has
+// access to
 func (r *ServicePlanRepo) ListPlans(ctx context.Context, authInfo authorization.Info, message ListServicePlanMessage) ([]ServicePlanRecord, error) {
-	cfServicePlans := &korifiv1alpha1.CFServicePlanList{}
-	if _, err := r.klient.List(ctx, cfServicePlans, message.toListOptions()...); err != nil {
-		return nil, apierrors.FromK8sError(err, ServicePlanResourceType)
+	plans := []korifiv1alpha1.CFServicePlan{}
+	for _, klient := range []Klient{r.rootNSKlient, r.spaceScopedKlient} {
+		cfServicePlans := &korifiv1alpha1.CFServicePlanList{}
+		if _, err := klient.List(ctx, cfServicePlans, message.toListOptions()...); err != nil {
+			return nil, apierrors.FromK8sError(err, ServicePlanResourceType)
+		}
+		plans = append(plans, cfServicePlans.Items...)
 	}
 
-	return it.TryCollect(it.MapError(slices.Values(cfServicePlans.Items), func(plan korifiv1alpha1.CFServicePlan) (ServicePlanRecord, error) {
+	return it.TryCollect(it.MapError(slices.Values(plans), func(plan korifiv1alpha1.CFServicePlan) (ServicePlanRecord, error) {
 		return r.planToRecord(ctx, authInfo, plan)
 	}))
 }
@@ -191,12 +203,11 @@ func (r *ServicePlanRepo) ListPlans(ctx context.Context, authInfo authorization.
 func (r *ServicePlanRepo) GetPlan(ctx context.Context, authInfo authorization.Info, planGUID string) (ServicePlanRecord, error) {
 	cfServicePlan := &korifiv1alpha1.CFServicePlan{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      planGUID,
+			Name: planGUID,
 		},
 	}
 
-	err := r.klient.Get(ctx, cfServicePlan)
+	err := r.spaceScopedKlient.Get(ctx, cfServicePlan)
 	if err != nil {
 		return ServicePlanRecord{}, apierrors.FromK8sError(err, ServicePlanVisibilityResourceType)
 	}
@@ -222,12 +233,15 @@ func (r *ServicePlanRepo) DeletePlanVisibility(ctx context.Context, authInfo aut
 func (r *ServicePlanRepo) DeletePlan(ctx context.Context, authInfo authorization.Info, planGUID string) error {
 	cfServicePlan := &korifiv1alpha1.CFServicePlan{
 		ObjectMeta: metav1.ObjectMeta{
-			Namespace: r.rootNamespace,
-			Name:      planGUID,
+			Name: planGUID,
 		},
 	}
 
-	if err := r.klient.Delete(ctx, cfServicePlan); err != nil {
+	if err := r.spaceScopedKlient.Get(ctx, cfServicePlan); err != nil {
+		return apierrors.FromK8sError(err, ServicePlanResourceType)
+	}
+
+	if err := r.spaceScopedKlient.Delete(ctx, cfServicePlan); err != nil {
 		return apierrors.FromK8sError(err, ServicePlanResourceType)
 	}
 
@@ -247,7 +261,9 @@ func (r *ServicePlanRepo) patchServicePlan(
 		},
 	}
 
-	if err := GetAndPatch(ctx, r.klient, cfServicePlan, func() error {
+	// Visibility only applies to plans of global brokers, plans of
+	// space-scoped brokers are always visible in their space only
+	if err := GetAndPatch(ctx, r.rootNSKlient, cfServicePlan, func() error {
 		patchFunc(cfServicePlan)
 		return nil
 	}); err != nil {
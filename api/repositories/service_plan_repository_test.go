@@ -37,7 +37,7 @@ var _ = Describe("ServicePlanRepo", func() {
 			korifiv1alpha1.CFOrgList,
 			*korifiv1alpha1.CFOrgList,
 		]{})
-		repo = repositories.NewServicePlanRepo(rootNSKlient, rootNamespace, orgRepo)
+		repo = repositories.NewServicePlanRepo(rootNSKlient, spaceScopedKlient, rootNamespace, orgRepo)
 
 		planGUID = uuid.NewString()
 		metadata, err := korifiv1alpha1.AsRawExtension(map[string]any{
@@ -248,7 +248,7 @@ var _ = Describe("ServicePlanRepo", func() {
 
 			BeforeEach(func() {
 				fakeKlient = new(fake.Klient)
-				repo = repositories.NewServicePlanRepo(fakeKlient, rootNamespace, orgRepo)
+				repo = repositories.NewServicePlanRepo(fakeKlient, fakeKlient, rootNamespace, orgRepo)
 				message = repositories.ListServicePlanMessage{
 					GUIDs:                []string{"g1", "g2"},
 					Names:                []string{"n1", "n2"},
@@ -261,7 +261,7 @@ var _ = Describe("ServicePlanRepo", func() {
 
 			It("translates filter parameters to klient list options", func() {
 				Expect(listErr).NotTo(HaveOccurred())
-				Expect(fakeKlient.ListCallCount()).To(Equal(1))
+				Expect(fakeKlient.ListCallCount()).To(Equal(2))
 				_, _, listOptions := fakeKlient.ListArgsForCall(0)
 				Expect(listOptions).To(ConsistOf(
 					repositories.WithLabelIn(korifiv1alpha1.GUIDLabelKey, []string{"g1", "g2"}),
@@ -281,7 +281,7 @@ var _ = Describe("ServicePlanRepo", func() {
 
 				It("translates the available parameters field to a list options", func() {
 					Expect(listErr).NotTo(HaveOccurred())
-					Expect(fakeKlient.ListCallCount()).To(Equal(1))
+					Expect(fakeKlient.ListCallCount()).To(Equal(2))
 					_, _, listOptions := fakeKlient.ListArgsForCall(0)
 					Expect(listOptions).To(ContainElement(
 						repositories.WithLabel(korifiv1alpha1.CFServicePlanAvailableKey, "true"),
@@ -296,7 +296,7 @@ var _ = Describe("ServicePlanRepo", func() {
 
 				It("translates the available parameters field to a list options", func() {
 					Expect(listErr).NotTo(HaveOccurred())
-					Expect(fakeKlient.ListCallCount()).To(Equal(1))
+					Expect(fakeKlient.ListCallCount()).To(Equal(2))
 					_, _, listOptions := fakeKlient.ListArgsForCall(0)
 					Expect(listOptions).To(ContainElement(
 						repositories.WithLabel(korifiv1alpha1.CFServicePlanAvailableKey, "false"),
@@ -2,12 +2,14 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"slices"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"github.com/google/uuid"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -17,6 +19,9 @@ import (
 
 const (
 	StackResourceType = "Stack"
+
+	StackStateActive     = korifiv1alpha1.StackStateActive
+	StackStateDeprecated = korifiv1alpha1.StackStateDeprecated
 )
 
 type StackRepository struct {
@@ -26,11 +31,40 @@ type StackRepository struct {
 }
 
 type StackRecord struct {
-	GUID        string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
-	Name        string
-	Description string
+	GUID             string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+	DeletedAt        *time.Time
+	Name             string
+	Description      string
+	Default          bool
+	State            string
+	BuildRootfsImage string
+	RunRootfsImage   string
+	Labels           map[string]string
+	Annotations      map[string]string
+}
+
+func (r StackRecord) GetResourceType() string {
+	return StackResourceType
+}
+
+type CreateStackMessage struct {
+	Name               string
+	Description        string
+	Default            bool
+	State              string
+	ClusterBuilderName string
+	Metadata           Metadata
+}
+
+type UpdateStackMessage struct {
+	GUID               string
+	Description        *string
+	Default            *bool
+	State              *string
+	ClusterBuilderName *string
+	MetadataPatch      MetadataPatch
 }
 
 func NewStackRepository(
@@ -62,7 +96,202 @@ func (r *StackRepository) ListStacks(ctx context.Context, authInfo authorization
 		return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready", r.builderName))
 	}
 
-	return builderInfoToStackRecords(*builderInfo), nil
+	cfStacks := &korifiv1alpha1.CFStackList{}
+	if _, err = r.klient.List(ctx, cfStacks, InNamespace(r.rootNamespace)); err != nil {
+		return nil, apierrors.FromK8sError(err, StackResourceType)
+	}
+
+	return mergeStackRecords(builderInfoToStackRecords(*builderInfo), cfStacks.Items), nil
+}
+
+func (r *StackRepository) GetStack(ctx context.Context, authInfo authorization.Info, guid string) (StackRecord, error) {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Get(ctx, cfStack); err != nil {
+		return StackRecord{}, fmt.Errorf("get-stack failed: %w", apierrors.FromK8sError(err, StackResourceType))
+	}
+
+	return cfStackToStackRecord(*cfStack), nil
+}
+
+// GetStackByName returns the admin managed stack with the given name. Stacks
+// that are only reported by the builder are not managed and therefore never
+// returned.
+func (r *StackRepository) GetStackByName(ctx context.Context, authInfo authorization.Info, name string) (StackRecord, error) {
+	cfStacks := &korifiv1alpha1.CFStackList{}
+	if _, err := r.klient.List(ctx, cfStacks, InNamespace(r.rootNamespace)); err != nil {
+		return StackRecord{}, apierrors.FromK8sError(err, StackResourceType)
+	}
+
+	idx := slices.IndexFunc(cfStacks.Items, func(cfStack korifiv1alpha1.CFStack) bool {
+		return cfStack.Spec.DisplayName == name
+	})
+	if idx < 0 {
+		return StackRecord{}, apierrors.NewNotFoundError(fmt.Errorf("stack %q not found", name), StackResourceType)
+	}
+
+	return cfStackToStackRecord(cfStacks.Items[idx]), nil
+}
+
+// GetDefaultStack returns the admin managed stack marked as default. A
+// NotFoundError is returned when no stack is marked as default.
+func (r *StackRepository) GetDefaultStack(ctx context.Context, authInfo authorization.Info) (StackRecord, error) {
+	cfStacks := &korifiv1alpha1.CFStackList{}
+	if _, err := r.klient.List(ctx, cfStacks, InNamespace(r.rootNamespace)); err != nil {
+		return StackRecord{}, apierrors.FromK8sError(err, StackResourceType)
+	}
+
+	idx := slices.IndexFunc(cfStacks.Items, func(cfStack korifiv1alpha1.CFStack) bool {
+		return cfStack.Spec.Default
+	})
+	if idx < 0 {
+		return StackRecord{}, apierrors.NewNotFoundError(errors.New("no default stack configured"), StackResourceType)
+	}
+
+	return cfStackToStackRecord(cfStacks.Items[idx]), nil
+}
+
+func (r *StackRepository) CreateStack(ctx context.Context, authInfo authorization.Info, message CreateStackMessage) (StackRecord, error) {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   r.rootNamespace,
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFStackSpec{
+			DisplayName:        message.Name,
+			Description:        message.Description,
+			Default:            message.Default,
+			ClusterBuilderName: message.ClusterBuilderName,
+			State:              message.State,
+		},
+	}
+
+	if err := r.klient.Create(ctx, cfStack); err != nil {
+		return StackRecord{}, fmt.Errorf("create-stack failed: %w", apierrors.FromK8sError(err, StackResourceType))
+	}
+
+	if cfStack.Spec.Default {
+		if err := r.unsetOtherDefaults(ctx, cfStack.Name); err != nil {
+			return StackRecord{}, err
+		}
+	}
+
+	return cfStackToStackRecord(*cfStack), nil
+}
+
+func (r *StackRepository) UpdateStack(ctx context.Context, authInfo authorization.Info, message UpdateStackMessage) (StackRecord, error) {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      message.GUID,
+		},
+	}
+
+	err := GetAndPatch(ctx, r.klient, cfStack, func() error {
+		if message.Description != nil {
+			cfStack.Spec.Description = *message.Description
+		}
+		if message.Default != nil {
+			cfStack.Spec.Default = *message.Default
+		}
+		if message.State != nil {
+			cfStack.Spec.State = *message.State
+		}
+		if message.ClusterBuilderName != nil {
+			cfStack.Spec.ClusterBuilderName = *message.ClusterBuilderName
+		}
+		message.MetadataPatch.Apply(cfStack)
+
+		return nil
+	})
+	if err != nil {
+		return StackRecord{}, fmt.Errorf("failed to patch stack: %w", apierrors.FromK8sError(err, StackResourceType))
+	}
+
+	if cfStack.Spec.Default {
+		if err := r.unsetOtherDefaults(ctx, cfStack.Name); err != nil {
+			return StackRecord{}, err
+		}
+	}
+
+	return cfStackToStackRecord(*cfStack), nil
+}
+
+func (r *StackRepository) DeleteStack(ctx context.Context, authInfo authorization.Info, guid string) error {
+	cfStack := &korifiv1alpha1.CFStack{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.klient.Delete(ctx, cfStack); err != nil {
+		return apierrors.FromK8sError(err, StackResourceType)
+	}
+
+	return nil
+}
+
+// unsetOtherDefaults makes sure that there is at most one default stack
+func (r *StackRepository) unsetOtherDefaults(ctx context.Context, defaultGUID string) error {
+	cfStacks := &korifiv1alpha1.CFStackList{}
+	if _, err := r.klient.List(ctx, cfStacks, InNamespace(r.rootNamespace)); err != nil {
+		return apierrors.FromK8sError(err, StackResourceType)
+	}
+
+	for _, cfStack := range cfStacks.Items {
+		if cfStack.Name == defaultGUID || !cfStack.Spec.Default {
+			continue
+		}
+
+		err := GetAndPatch(ctx, r.klient, &cfStack, func() error {
+			cfStack.Spec.Default = false
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to unset default stack %q: %w", cfStack.Name, apierrors.FromK8sError(err, StackResourceType))
+		}
+	}
+
+	return nil
+}
+
+// mergeStackRecords lists the admin managed CFStacks followed by the builder
+// stacks that are not managed by a CFStack with the same name.
+func mergeStackRecords(builderRecords []StackRecord, cfStacks []korifiv1alpha1.CFStack) []StackRecord {
+	records := slices.Collect(it.Map(slices.Values(cfStacks), cfStackToStackRecord))
+
+	for _, builderRecord := range builderRecords {
+		if !slices.ContainsFunc(records, func(record StackRecord) bool { return record.Name == builderRecord.Name }) {
+			records = append(records, builderRecord)
+		}
+	}
+
+	return records
+}
+
+func cfStackToStackRecord(cfStack korifiv1alpha1.CFStack) StackRecord {
+	return StackRecord{
+		GUID:             cfStack.Name,
+		Name:             cfStack.Spec.DisplayName,
+		Description:      cfStack.Spec.Description,
+		Default:          cfStack.Spec.Default,
+		State:            cfStack.Spec.State,
+		BuildRootfsImage: cfStack.Status.BuildRootfsImage,
+		RunRootfsImage:   cfStack.Status.RunRootfsImage,
+		Labels:           cfStack.Labels,
+		Annotations:      cfStack.Annotations,
+		CreatedAt:        cfStack.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(&cfStack),
+		DeletedAt:        golangTime(cfStack.DeletionTimestamp),
+	}
 }
 
 func builderInfoToStackRecords(info korifiv1alpha1.BuilderInfo) []StackRecord {
@@ -70,6 +299,7 @@ func builderInfoToStackRecords(info korifiv1alpha1.BuilderInfo) []StackRecord {
 		return StackRecord{
 			Name:        s.Name,
 			Description: s.Description,
+			State:       StackStateActive,
 			CreatedAt:   s.CreationTimestamp.Time,
 			UpdatedAt:   &s.UpdatedTimestamp.Time,
 		}
@@ -6,6 +6,7 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
@@ -13,6 +14,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("StackRepository", func() {
@@ -74,6 +76,44 @@ var _ = Describe("StackRepository", func() {
 			})))
 		})
 
+		When("there are admin managed stacks", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFStack{
+					ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: uuid.NewString()},
+					Spec: korifiv1alpha1.CFStackSpec{
+						DisplayName: "my-stack",
+						Description: "managed stack",
+						State:       korifiv1alpha1.StackStateDeprecated,
+					},
+				})).To(Succeed())
+				Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFStack{
+					ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: uuid.NewString()},
+					Spec: korifiv1alpha1.CFStackSpec{
+						DisplayName: "other-stack",
+						Default:     true,
+					},
+				})).To(Succeed())
+			})
+
+			It("lists them instead of the builder stacks with the same name", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(stacks).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":        Not(BeEmpty()),
+						"Name":        Equal("my-stack"),
+						"Description": Equal("managed stack"),
+						"State":       Equal(repositories.StackStateDeprecated),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"GUID":    Not(BeEmpty()),
+						"Name":    Equal("other-stack"),
+						"Default": BeTrue(),
+						"State":   Equal(repositories.StackStateActive),
+					}),
+				))
+			})
+		})
+
 		When("the builderInfo is not ready", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, k8sClient, builderInfo, func() {
@@ -101,4 +141,153 @@ var _ = Describe("StackRepository", func() {
 			})
 		})
 	})
+
+	Describe("CreateStack", func() {
+		var (
+			stack     repositories.StackRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			stack, createErr = stackRepo.CreateStack(ctx, authInfo, repositories.CreateStackMessage{
+				Name:               "cflinuxfs4",
+				Description:        "Jammy",
+				Default:            true,
+				ClusterBuilderName: "jammy-builder",
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			var previousDefault *korifiv1alpha1.CFStack
+
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+
+				previousDefault = &korifiv1alpha1.CFStack{
+					ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: uuid.NewString()},
+					Spec: korifiv1alpha1.CFStackSpec{
+						DisplayName: "cflinuxfs3",
+						Default:     true,
+					},
+				}
+				Expect(k8sClient.Create(ctx, previousDefault)).To(Succeed())
+			})
+
+			It("creates the stack", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(stack.GUID).NotTo(BeEmpty())
+				Expect(stack.Default).To(BeTrue())
+				Expect(stack.State).To(Equal(repositories.StackStateActive))
+
+				cfStack := &korifiv1alpha1.CFStack{
+					ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: stack.GUID},
+				}
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(Succeed())
+				Expect(cfStack.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(cfStack.Spec).To(Equal(korifiv1alpha1.CFStackSpec{
+					DisplayName:        "cflinuxfs4",
+					Description:        "Jammy",
+					Default:            true,
+					ClusterBuilderName: "jammy-builder",
+					State:              korifiv1alpha1.StackStateActive,
+				}))
+			})
+
+			It("unsets the previous default stack", func() {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(previousDefault), previousDefault)).To(Succeed())
+				Expect(previousDefault.Spec.Default).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("GetStack, UpdateStack and DeleteStack", func() {
+		var cfStack *korifiv1alpha1.CFStack
+
+		BeforeEach(func() {
+			cfStack = &korifiv1alpha1.CFStack{
+				ObjectMeta: metav1.ObjectMeta{Namespace: rootNamespace, Name: uuid.NewString()},
+				Spec: korifiv1alpha1.CFStackSpec{
+					DisplayName: "cflinuxfs4",
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfStack)).To(Succeed())
+		})
+
+		It("gets the stack", func() {
+			stack, err := stackRepo.GetStack(ctx, authInfo, cfStack.Name)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stack.Name).To(Equal("cflinuxfs4"))
+		})
+
+		It("gets the stack by name", func() {
+			stack, err := stackRepo.GetStackByName(ctx, authInfo, "cflinuxfs4")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stack.GUID).To(Equal(cfStack.Name))
+		})
+
+		It("returns a not found error for unmanaged stacks", func() {
+			_, err := stackRepo.GetStackByName(ctx, authInfo, "my-stack")
+			Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		It("returns a not found error when there is no default stack", func() {
+			_, err := stackRepo.GetDefaultStack(ctx, authInfo)
+			Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the stack is the default one", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfStack, func() {
+					cfStack.Spec.Default = true
+				})).To(Succeed())
+			})
+
+			It("gets the default stack", func() {
+				stack, err := stackRepo.GetDefaultStack(ctx, authInfo)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stack.GUID).To(Equal(cfStack.Name))
+				Expect(stack.Default).To(BeTrue())
+			})
+		})
+
+		It("does not allow non-admins to update the stack", func() {
+			_, err := stackRepo.UpdateStack(ctx, authInfo, repositories.UpdateStackMessage{
+				GUID:  cfStack.Name,
+				State: tools.PtrTo(repositories.StackStateDeprecated),
+			})
+			Expect(err).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the stack", func() {
+				stack, err := stackRepo.UpdateStack(ctx, authInfo, repositories.UpdateStackMessage{
+					GUID:  cfStack.Name,
+					State: tools.PtrTo(repositories.StackStateDeprecated),
+					MetadataPatch: repositories.MetadataPatch{
+						Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stack.State).To(Equal(repositories.StackStateDeprecated))
+				Expect(stack.Labels).To(HaveKeyWithValue("foo", "bar"))
+			})
+
+			It("deletes the stack", func() {
+				Expect(stackRepo.DeleteStack(ctx, authInfo, cfStack.Name)).To(Succeed())
+				_, err := stackRepo.GetStack(ctx, authInfo, cfStack.Name)
+				Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
 })
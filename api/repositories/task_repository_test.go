@@ -86,9 +86,10 @@ var _ = Describe("TaskRepository", func() {
 			}
 
 			createMessage = repositories.CreateTaskMessage{
-				Command:   "echo 'hello world'",
-				SpaceGUID: space.Name,
-				AppGUID:   cfApp.Name,
+				Command:                    "echo 'hello world'",
+				SpaceGUID:                  space.Name,
+				AppGUID:                    cfApp.Name,
+				LogRateLimitBytesPerSecond: tools.PtrTo[int64](512),
 				Metadata: repositories.Metadata{
 					Labels:      map[string]string{"color": "blue"},
 					Annotations: map[string]string{"extra-bugs": "true"},
@@ -133,6 +134,7 @@ var _ = Describe("TaskRepository", func() {
 
 				Expect(taskRecord.MemoryMB).To(BeEquivalentTo(256))
 				Expect(taskRecord.DiskMB).To(BeEquivalentTo(128))
+				Expect(taskRecord.LogRateLimitBytesPerSecond).To(BeEquivalentTo(512))
 				Expect(taskRecord.DropletGUID).To(Equal(cfApp.Spec.CurrentDropletRef.Name))
 				Expect(taskRecord.State).To(Equal(repositories.TaskStatePending))
 				Expect(taskRecord.Labels).To(HaveKeyWithValue("color", "blue"))
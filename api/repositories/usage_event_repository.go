@@ -0,0 +1,502 @@
+package repositories
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories/k8sklient/descriptors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/BooleanCat/go-functional/v2/it"
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AppUsageEventResourceType     = "App Usage Event"
+	ServiceUsageEventResourceType = "Service Usage Event"
+
+	managedServiceInstanceUsageType      = "managed_service_instance"
+	userProvidedServiceInstanceUsageType = "user_provided_service_instance"
+)
+
+type AppUsageEventRecord struct {
+	GUID                          string
+	CreatedAt                     time.Time
+	State                         string
+	PreviousState                 *string
+	AppGUID                       string
+	AppName                       string
+	ProcessGUID                   string
+	ProcessType                   string
+	SpaceGUID                     string
+	SpaceName                     string
+	OrgGUID                       string
+	BuildpackName                 string
+	InstanceCount                 int32
+	PreviousInstanceCount         *int32
+	MemoryInMBPerInstance         int64
+	PreviousMemoryInMBPerInstance *int64
+}
+
+func (r AppUsageEventRecord) GetResourceType() string {
+	return AppUsageEventResourceType
+}
+
+type ServiceUsageEventRecord struct {
+	GUID                string
+	CreatedAt           time.Time
+	State               string
+	SpaceGUID           string
+	SpaceName           string
+	OrgGUID             string
+	ServiceInstanceGUID string
+	ServiceInstanceName string
+	ServiceInstanceType string
+	ServicePlanGUID     string
+	ServicePlanName     string
+	ServiceOfferingGUID string
+	ServiceOfferingName string
+	ServiceBrokerGUID   string
+	ServiceBrokerName   string
+}
+
+func (r ServiceUsageEventRecord) GetResourceType() string {
+	return ServiceUsageEventResourceType
+}
+
+type ListUsageEventsMessage struct {
+	GUIDs      []string
+	AfterGUID  string
+	Descending bool
+	Pagination Pagination
+}
+
+func (m ListUsageEventsMessage) matches(event korifiv1alpha1.CFUsageEvent) bool {
+	return tools.EmptyOrContains(m.GUIDs, event.Name)
+}
+
+// UsageEventRepo serves the usage event log recorded by the controllers. The
+// log is append-only, except for purging it, so that billing tools can page
+// through it with an after_guid cursor.
+type UsageEventRepo struct {
+	rootNSKlient      Klient
+	spaceScopedKlient Klient
+	rootNamespace     string
+}
+
+func NewUsageEventRepo(
+	rootNSKlient Klient,
+	spaceScopedKlient Klient,
+	rootNamespace string,
+) *UsageEventRepo {
+	return &UsageEventRepo{
+		rootNSKlient:      rootNSKlient,
+		spaceScopedKlient: spaceScopedKlient,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+func (r *UsageEventRepo) ListAppUsageEvents(ctx context.Context, authInfo authorization.Info, message ListUsageEventsMessage) (ListResult[AppUsageEventRecord], error) {
+	events, err := r.listUsageEvents(ctx, korifiv1alpha1.AppUsageEventType, AppUsageEventResourceType, message)
+	if err != nil {
+		return ListResult[AppUsageEventRecord]{}, err
+	}
+
+	return toUsageEventListResult(events, message.Pagination, toAppUsageEventRecord)
+}
+
+func (r *UsageEventRepo) GetAppUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (AppUsageEventRecord, error) {
+	event, err := r.getUsageEvent(ctx, korifiv1alpha1.AppUsageEventType, AppUsageEventResourceType, guid)
+	if err != nil {
+		return AppUsageEventRecord{}, err
+	}
+
+	return toAppUsageEventRecord(event), nil
+}
+
+func (r *UsageEventRepo) ListServiceUsageEvents(ctx context.Context, authInfo authorization.Info, message ListUsageEventsMessage) (ListResult[ServiceUsageEventRecord], error) {
+	events, err := r.listUsageEvents(ctx, korifiv1alpha1.ServiceUsageEventType, ServiceUsageEventResourceType, message)
+	if err != nil {
+		return ListResult[ServiceUsageEventRecord]{}, err
+	}
+
+	return toUsageEventListResult(events, message.Pagination, toServiceUsageEventRecord)
+}
+
+func (r *UsageEventRepo) GetServiceUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (ServiceUsageEventRecord, error) {
+	event, err := r.getUsageEvent(ctx, korifiv1alpha1.ServiceUsageEventType, ServiceUsageEventResourceType, guid)
+	if err != nil {
+		return ServiceUsageEventRecord{}, err
+	}
+
+	return toServiceUsageEventRecord(event), nil
+}
+
+// PurgeAndReseedAppUsageEvents deletes all app usage events and records a
+// STARTED event for every process that is currently running
+func (r *UsageEventRepo) PurgeAndReseedAppUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	if err := r.purgeUsageEvents(ctx, korifiv1alpha1.AppUsageEventType, AppUsageEventResourceType); err != nil {
+		return err
+	}
+
+	cfApps := &korifiv1alpha1.CFAppList{}
+	if _, err := r.spaceScopedKlient.List(ctx, cfApps); err != nil {
+		return apierrors.FromK8sError(err, AppResourceType)
+	}
+
+	cfProcesses := &korifiv1alpha1.CFProcessList{}
+	if _, err := r.spaceScopedKlient.List(ctx, cfProcesses); err != nil {
+		return apierrors.FromK8sError(err, ProcessResourceType)
+	}
+
+	appsByGUID := map[string]korifiv1alpha1.CFApp{}
+	for _, cfApp := range cfApps.Items {
+		appsByGUID[cfApp.Name] = cfApp
+	}
+
+	spaces := newUsageSpaceResolver(r.spaceScopedKlient)
+	for _, cfProcess := range cfProcesses.Items {
+		cfApp, ok := appsByGUID[cfProcess.Spec.AppRef.Name]
+		if !ok || cfApp.Spec.DesiredState != korifiv1alpha1.StartedState || tools.ZeroIfNil(cfProcess.Spec.DesiredInstances) == 0 {
+			continue
+		}
+
+		spec, err := spaces.eventSpec(ctx, cfProcess.Namespace)
+		if err != nil {
+			return err
+		}
+		spec.Type = korifiv1alpha1.AppUsageEventType
+		spec.State = korifiv1alpha1.AppUsageStateStarted
+		spec.App = &korifiv1alpha1.AppUsage{
+			AppGUID:               cfApp.Name,
+			AppName:               cfApp.Spec.DisplayName,
+			ProcessGUID:           cfProcess.Name,
+			ProcessType:           cfProcess.Spec.ProcessType,
+			InstanceCount:         tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
+			MemoryInMBPerInstance: cfProcess.Spec.MemoryMB,
+		}
+		if len(cfApp.Spec.Lifecycle.Data.Buildpacks) > 0 {
+			spec.App.BuildpackName = cfApp.Spec.Lifecycle.Data.Buildpacks[0]
+		}
+
+		if err = r.createUsageEvent(ctx, spec, AppUsageEventResourceType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PurgeAndReseedServiceUsageEvents deletes all service usage events and
+// records a CREATED event for every existing service instance
+func (r *UsageEventRepo) PurgeAndReseedServiceUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	if err := r.purgeUsageEvents(ctx, korifiv1alpha1.ServiceUsageEventType, ServiceUsageEventResourceType); err != nil {
+		return err
+	}
+
+	cfServiceInstances := &korifiv1alpha1.CFServiceInstanceList{}
+	if _, err := r.spaceScopedKlient.List(ctx, cfServiceInstances); err != nil {
+		return apierrors.FromK8sError(err, ServiceInstanceResourceType)
+	}
+
+	cfServicePlans := &korifiv1alpha1.CFServicePlanList{}
+	if _, err := r.rootNSKlient.List(ctx, cfServicePlans, InNamespace(r.rootNamespace)); err != nil {
+		return apierrors.FromK8sError(err, ServicePlanResourceType)
+	}
+
+	cfServiceOfferings := &korifiv1alpha1.CFServiceOfferingList{}
+	if _, err := r.rootNSKlient.List(ctx, cfServiceOfferings, InNamespace(r.rootNamespace)); err != nil {
+		return apierrors.FromK8sError(err, ServiceOfferingResourceType)
+	}
+
+	cfServiceBrokers := &korifiv1alpha1.CFServiceBrokerList{}
+	if _, err := r.rootNSKlient.List(ctx, cfServiceBrokers, InNamespace(r.rootNamespace)); err != nil {
+		return apierrors.FromK8sError(err, ServiceBrokerResourceType)
+	}
+
+	spaces := newUsageSpaceResolver(r.spaceScopedKlient)
+	for _, cfServiceInstance := range cfServiceInstances.Items {
+		if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		spec, err := spaces.eventSpec(ctx, cfServiceInstance.Namespace)
+		if err != nil {
+			return err
+		}
+		spec.Type = korifiv1alpha1.ServiceUsageEventType
+		spec.State = korifiv1alpha1.ServiceUsageStateCreated
+		spec.Service = &korifiv1alpha1.ServiceUsage{
+			ServiceInstanceGUID: cfServiceInstance.Name,
+			ServiceInstanceName: cfServiceInstance.Spec.DisplayName,
+			ServiceInstanceType: userProvidedServiceInstanceUsageType,
+		}
+
+		if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+			spec.Service.ServiceInstanceType = managedServiceInstanceUsageType
+			setServicePlanUsage(spec.Service, cfServiceInstance.Spec.PlanGUID, cfServicePlans.Items, cfServiceOfferings.Items, cfServiceBrokers.Items)
+		}
+
+		if err = r.createUsageEvent(ctx, spec, ServiceUsageEventResourceType); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setServicePlanUsage(
+	serviceUsage *korifiv1alpha1.ServiceUsage,
+	planGUID string,
+	plans []korifiv1alpha1.CFServicePlan,
+	offerings []korifiv1alpha1.CFServiceOffering,
+	brokers []korifiv1alpha1.CFServiceBroker,
+) {
+	serviceUsage.ServicePlanGUID = planGUID
+
+	planIdx := slices.IndexFunc(plans, func(p korifiv1alpha1.CFServicePlan) bool { return p.Name == planGUID })
+	if planIdx < 0 {
+		return
+	}
+	plan := plans[planIdx]
+	serviceUsage.ServicePlanName = plan.Spec.Name
+
+	offeringGUID := plan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]
+	if offeringIdx := slices.IndexFunc(offerings, func(o korifiv1alpha1.CFServiceOffering) bool { return o.Name == offeringGUID }); offeringIdx >= 0 {
+		serviceUsage.ServiceOfferingGUID = offeringGUID
+		serviceUsage.ServiceOfferingName = offerings[offeringIdx].Spec.Name
+	}
+
+	brokerGUID := plan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel]
+	if brokerIdx := slices.IndexFunc(brokers, func(b korifiv1alpha1.CFServiceBroker) bool { return b.Name == brokerGUID }); brokerIdx >= 0 {
+		serviceUsage.ServiceBrokerGUID = brokerGUID
+		serviceUsage.ServiceBrokerName = brokers[brokerIdx].Spec.Name
+	}
+}
+
+func (r *UsageEventRepo) listUsageEvents(
+	ctx context.Context,
+	eventType korifiv1alpha1.UsageEventType,
+	resourceType string,
+	message ListUsageEventsMessage,
+) ([]korifiv1alpha1.CFUsageEvent, error) {
+	cfUsageEvents := &korifiv1alpha1.CFUsageEventList{}
+	if _, err := r.rootNSKlient.List(ctx, cfUsageEvents, InNamespace(r.rootNamespace), WithLabel(korifiv1alpha1.UsageEventTypeLabelKey, string(eventType))); err != nil {
+		return nil, apierrors.FromK8sError(err, resourceType)
+	}
+
+	events := cfUsageEvents.Items
+	slices.SortFunc(events, compareUsageEvents)
+
+	if message.AfterGUID != "" {
+		afterIdx := slices.IndexFunc(events, func(e korifiv1alpha1.CFUsageEvent) bool { return e.Name == message.AfterGUID })
+		if afterIdx < 0 {
+			return nil, apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("usage event %q not found", message.AfterGUID),
+				fmt.Sprintf("After guid filter must be a valid %s guid.", strings.ToLower(resourceType)),
+			)
+		}
+		events = events[afterIdx+1:]
+	}
+
+	events = slices.Collect(it.Filter(slices.Values(events), message.matches))
+	if message.Descending {
+		slices.Reverse(events)
+	}
+
+	return events, nil
+}
+
+// Events are ordered by the order they were stored in. Usage events are never
+// updated, so their resource version is the storage revision they were
+// created at. Unlike the timestamps set by the writers, it only grows, so an
+// event can never appear before the after_guid cursor of a reader. Ties,
+// which only happen if the storage does not use numeric revisions, are broken
+// by timestamp and name.
+func compareUsageEvents(a, b korifiv1alpha1.CFUsageEvent) int {
+	aRevision, aErr := strconv.ParseUint(a.ResourceVersion, 10, 64)
+	bRevision, bErr := strconv.ParseUint(b.ResourceVersion, 10, 64)
+	if aErr == nil && bErr == nil {
+		if c := cmp.Compare(aRevision, bRevision); c != 0 {
+			return c
+		}
+	}
+
+	if c := a.Spec.Timestamp.Compare(b.Spec.Timestamp.Time); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.Name, b.Name)
+}
+
+func toUsageEventListResult[T any](events []korifiv1alpha1.CFUsageEvent, pagination Pagination, toRecord func(korifiv1alpha1.CFUsageEvent) T) (ListResult[T], error) {
+	records := slices.Collect(it.Map(slices.Values(events), toRecord))
+
+	recordsPage := descriptors.SinglePage(records, len(records))
+	if !pagination.IsZero() {
+		var err error
+		recordsPage, err = descriptors.GetPage(records, pagination.PerPage, pagination.Page)
+		if err != nil {
+			return ListResult[T]{}, fmt.Errorf("failed to page usage events list: %w", err)
+		}
+	}
+
+	return ListResult[T]{
+		PageInfo: recordsPage.PageInfo,
+		Records:  recordsPage.Items,
+	}, nil
+}
+
+func (r *UsageEventRepo) getUsageEvent(
+	ctx context.Context,
+	eventType korifiv1alpha1.UsageEventType,
+	resourceType string,
+	guid string,
+) (korifiv1alpha1.CFUsageEvent, error) {
+	cfUsageEvent := &korifiv1alpha1.CFUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      guid,
+		},
+	}
+
+	if err := r.rootNSKlient.Get(ctx, cfUsageEvent); err != nil {
+		return korifiv1alpha1.CFUsageEvent{}, fmt.Errorf("get-usage-event failed: %w", apierrors.FromK8sError(err, resourceType))
+	}
+
+	if cfUsageEvent.Spec.Type != eventType {
+		return korifiv1alpha1.CFUsageEvent{}, apierrors.NewNotFoundError(fmt.Errorf("usage event %q is not of type %q", guid, eventType), resourceType)
+	}
+
+	return *cfUsageEvent, nil
+}
+
+func (r *UsageEventRepo) purgeUsageEvents(ctx context.Context, eventType korifiv1alpha1.UsageEventType, resourceType string) error {
+	cfUsageEvents := &korifiv1alpha1.CFUsageEventList{}
+	if _, err := r.rootNSKlient.List(ctx, cfUsageEvents, InNamespace(r.rootNamespace), WithLabel(korifiv1alpha1.UsageEventTypeLabelKey, string(eventType))); err != nil {
+		return apierrors.FromK8sError(err, resourceType)
+	}
+
+	var errs []error
+	for i := range cfUsageEvents.Items {
+		if err := r.rootNSKlient.Delete(ctx, &cfUsageEvents.Items[i]); err != nil {
+			errs = append(errs, apierrors.FromK8sError(err, resourceType))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *UsageEventRepo) createUsageEvent(ctx context.Context, spec korifiv1alpha1.CFUsageEventSpec, resourceType string) error {
+	spec.Timestamp = metav1.NewMicroTime(time.Now())
+
+	cfUsageEvent := &korifiv1alpha1.CFUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      uuid.NewString(),
+			Labels: map[string]string{
+				korifiv1alpha1.UsageEventTypeLabelKey: string(spec.Type),
+			},
+		},
+		Spec: spec,
+	}
+
+	if err := r.rootNSKlient.Create(ctx, cfUsageEvent); err != nil {
+		return apierrors.FromK8sError(err, resourceType)
+	}
+
+	return nil
+}
+
+// usageSpaceResolver looks up the space name and org of every space only once
+type usageSpaceResolver struct {
+	klient Klient
+	specs  map[string]korifiv1alpha1.CFUsageEventSpec
+}
+
+func newUsageSpaceResolver(klient Klient) *usageSpaceResolver {
+	return &usageSpaceResolver{
+		klient: klient,
+		specs:  map[string]korifiv1alpha1.CFUsageEventSpec{},
+	}
+}
+
+func (s *usageSpaceResolver) eventSpec(ctx context.Context, spaceGUID string) (korifiv1alpha1.CFUsageEventSpec, error) {
+	if spec, ok := s.specs[spaceGUID]; ok {
+		return spec, nil
+	}
+
+	cfSpace := &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spaceGUID,
+		},
+	}
+	if err := s.klient.Get(ctx, cfSpace); err != nil {
+		return korifiv1alpha1.CFUsageEventSpec{}, fmt.Errorf("failed to get space %q: %w", spaceGUID, apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	s.specs[spaceGUID] = korifiv1alpha1.CFUsageEventSpec{
+		SpaceGUID: spaceGUID,
+		SpaceName: cfSpace.Spec.DisplayName,
+		OrgGUID:   cfSpace.Namespace,
+	}
+
+	return s.specs[spaceGUID], nil
+}
+
+func toAppUsageEventRecord(event korifiv1alpha1.CFUsageEvent) AppUsageEventRecord {
+	app := tools.ZeroIfNil(event.Spec.App)
+	record := AppUsageEventRecord{
+		GUID:                  event.Name,
+		CreatedAt:             event.Spec.Timestamp.Time,
+		State:                 event.Spec.State,
+		AppGUID:               app.AppGUID,
+		AppName:               app.AppName,
+		ProcessGUID:           app.ProcessGUID,
+		ProcessType:           app.ProcessType,
+		SpaceGUID:             event.Spec.SpaceGUID,
+		SpaceName:             event.Spec.SpaceName,
+		OrgGUID:               event.Spec.OrgGUID,
+		BuildpackName:         app.BuildpackName,
+		InstanceCount:         app.InstanceCount,
+		MemoryInMBPerInstance: app.MemoryInMBPerInstance,
+	}
+
+	if event.Spec.PreviousState != "" {
+		record.PreviousState = tools.PtrTo(event.Spec.PreviousState)
+		record.PreviousInstanceCount = tools.PtrTo(app.PreviousInstanceCount)
+		record.PreviousMemoryInMBPerInstance = tools.PtrTo(app.PreviousMemoryInMBPerInstance)
+	}
+
+	return record
+}
+
+func toServiceUsageEventRecord(event korifiv1alpha1.CFUsageEvent) ServiceUsageEventRecord {
+	service := tools.ZeroIfNil(event.Spec.Service)
+	return ServiceUsageEventRecord{
+		GUID:                event.Name,
+		CreatedAt:           event.Spec.Timestamp.Time,
+		State:               event.Spec.State,
+		SpaceGUID:           event.Spec.SpaceGUID,
+		SpaceName:           event.Spec.SpaceName,
+		OrgGUID:             event.Spec.OrgGUID,
+		ServiceInstanceGUID: service.ServiceInstanceGUID,
+		ServiceInstanceName: service.ServiceInstanceName,
+		ServiceInstanceType: service.ServiceInstanceType,
+		ServicePlanGUID:     service.ServicePlanGUID,
+		ServicePlanName:     service.ServicePlanName,
+		ServiceOfferingGUID: service.ServiceOfferingGUID,
+		ServiceOfferingName: service.ServiceOfferingName,
+		ServiceBrokerGUID:   service.ServiceBrokerGUID,
+		ServiceBrokerName:   service.ServiceBrokerName,
+	}
+}
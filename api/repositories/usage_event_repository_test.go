@@ -0,0 +1,269 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("UsageEventRepository", func() {
+	var (
+		usageEventRepo *repositories.UsageEventRepo
+		firstEvent     *korifiv1alpha1.CFUsageEvent
+		secondEvent    *korifiv1alpha1.CFUsageEvent
+		serviceEvent   *korifiv1alpha1.CFUsageEvent
+	)
+
+	createUsageEvent := func(eventType korifiv1alpha1.UsageEventType, state string, timestamp time.Time) *korifiv1alpha1.CFUsageEvent {
+		GinkgoHelper()
+
+		event := &korifiv1alpha1.CFUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: rootNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.UsageEventTypeLabelKey: string(eventType),
+				},
+			},
+			Spec: korifiv1alpha1.CFUsageEventSpec{
+				Type:      eventType,
+				Timestamp: metav1.NewMicroTime(timestamp),
+				State:     state,
+				SpaceGUID: "space-guid",
+				SpaceName: "space-name",
+				OrgGUID:   "org-guid",
+			},
+		}
+		if eventType == korifiv1alpha1.AppUsageEventType {
+			event.Spec.App = &korifiv1alpha1.AppUsage{
+				AppGUID:               "app-guid",
+				AppName:               "app-name",
+				ProcessGUID:           "process-guid",
+				ProcessType:           "web",
+				InstanceCount:         2,
+				MemoryInMBPerInstance: 256,
+			}
+		} else {
+			event.Spec.Service = &korifiv1alpha1.ServiceUsage{
+				ServiceInstanceGUID: "instance-guid",
+				ServiceInstanceName: "instance-name",
+				ServiceInstanceType: "user_provided_service_instance",
+			}
+		}
+		Expect(k8sClient.Create(ctx, event)).To(Succeed())
+
+		return event
+	}
+
+	BeforeEach(func() {
+		usageEventRepo = repositories.NewUsageEventRepo(rootNSKlient, spaceScopedKlient, rootNamespace)
+		createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+
+		now := time.Now()
+		firstEvent = createUsageEvent(korifiv1alpha1.AppUsageEventType, korifiv1alpha1.AppUsageStateStarted, now)
+		// written by a writer with a clock lagging behind
+		secondEvent = createUsageEvent(korifiv1alpha1.AppUsageEventType, korifiv1alpha1.AppUsageStateScaled, now.Add(-time.Minute))
+		serviceEvent = createUsageEvent(korifiv1alpha1.ServiceUsageEventType, korifiv1alpha1.ServiceUsageStateCreated, now)
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFUsageEvent{}, client.InNamespace(rootNamespace))).To(Succeed())
+	})
+
+	Describe("ListAppUsageEvents", func() {
+		var (
+			message    repositories.ListUsageEventsMessage
+			listResult repositories.ListResult[repositories.AppUsageEventRecord]
+			listErr    error
+		)
+
+		BeforeEach(func() {
+			message = repositories.ListUsageEventsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			listResult, listErr = usageEventRepo.ListAppUsageEvents(ctx, authInfo, message)
+		})
+
+		It("returns the app usage events in the order they were stored", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{
+					"GUID":          Equal(firstEvent.Name),
+					"State":         Equal(korifiv1alpha1.AppUsageStateStarted),
+					"PreviousState": BeNil(),
+					"AppGUID":       Equal("app-guid"),
+					"ProcessType":   Equal("web"),
+					"SpaceName":     Equal("space-name"),
+					"InstanceCount": BeEquivalentTo(2),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"GUID": Equal(secondEvent.Name),
+				}),
+			))
+		})
+
+		When("ordering descending", func() {
+			BeforeEach(func() {
+				message.Descending = true
+			})
+
+			It("returns the newest event first", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(HaveExactElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(secondEvent.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(firstEvent.Name)}),
+				))
+			})
+		})
+
+		When("filtering by guids", func() {
+			BeforeEach(func() {
+				message.GUIDs = []string{secondEvent.Name}
+			})
+
+			It("returns the matching events only", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(HaveExactElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(secondEvent.Name)}),
+				))
+			})
+		})
+
+		When("filtering by after_guid", func() {
+			BeforeEach(func() {
+				message.AfterGUID = firstEvent.Name
+			})
+
+			It("returns the events after the given one", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(listResult.Records).To(HaveExactElements(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(secondEvent.Name)}),
+				))
+			})
+
+			When("the after_guid is not an app usage event", func() {
+				BeforeEach(func() {
+					message.AfterGUID = serviceEvent.Name
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(listErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetAppUsageEvent", func() {
+		var (
+			guid   string
+			record repositories.AppUsageEventRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			guid = firstEvent.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = usageEventRepo.GetAppUsageEvent(ctx, authInfo, guid)
+		})
+
+		It("returns the event", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(firstEvent.Name))
+			Expect(record.State).To(Equal(korifiv1alpha1.AppUsageStateStarted))
+		})
+
+		When("the event is a service usage event", func() {
+			BeforeEach(func() {
+				guid = serviceEvent.Name
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListServiceUsageEvents", func() {
+		It("returns the service usage events", func() {
+			listResult, err := usageEventRepo.ListServiceUsageEvents(ctx, authInfo, repositories.ListUsageEventsMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{
+					"GUID":                Equal(serviceEvent.Name),
+					"State":               Equal(korifiv1alpha1.ServiceUsageStateCreated),
+					"ServiceInstanceGUID": Equal("instance-guid"),
+					"ServiceInstanceType": Equal("user_provided_service_instance"),
+				}),
+			))
+		})
+	})
+
+	Describe("PurgeAndReseedAppUsageEvents", func() {
+		var (
+			space    *korifiv1alpha1.CFSpace
+			cfApp    *korifiv1alpha1.CFApp
+			purgeErr error
+		)
+
+		BeforeEach(func() {
+			org := createOrgWithCleanup(ctx, uuid.NewString())
+			space = createSpaceWithCleanup(ctx, org.Name, uuid.NewString())
+			cfApp = createAppCR(ctx, k8sClient, "my-app", uuid.NewString(), space.Name, string(korifiv1alpha1.StartedState))
+
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: space.Name,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef:           corev1.LocalObjectReference{Name: cfApp.Name},
+					ProcessType:      "web",
+					DesiredInstances: tools.PtrTo[int32](3),
+					MemoryMB:         512,
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			purgeErr = usageEventRepo.PurgeAndReseedAppUsageEvents(ctx, authInfo)
+		})
+
+		It("replaces the app usage events with STARTED events for running processes", func() {
+			Expect(purgeErr).NotTo(HaveOccurred())
+
+			listResult, err := usageEventRepo.ListAppUsageEvents(ctx, authInfo, repositories.ListUsageEventsMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(HaveExactElements(
+				MatchFields(IgnoreExtras, Fields{
+					"State":                 Equal(korifiv1alpha1.AppUsageStateStarted),
+					"AppGUID":               Equal(cfApp.Name),
+					"SpaceGUID":             Equal(space.Name),
+					"SpaceName":             Equal(space.Spec.DisplayName),
+					"InstanceCount":         BeEquivalentTo(3),
+					"MemoryInMBPerInstance": BeEquivalentTo(512),
+				}),
+			))
+		})
+
+		It("keeps the service usage events", func() {
+			Expect(purgeErr).NotTo(HaveOccurred())
+
+			listResult, err := usageEventRepo.ListServiceUsageEvents(ctx, authInfo, repositories.ListUsageEventsMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(listResult.Records).To(HaveLen(1))
+		})
+	})
+})
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -15,6 +16,7 @@ import (
 type Response struct {
 	httpStatus int
 	body       interface{}
+	content    io.Reader
 	headers    map[string][]string
 }
 
@@ -35,6 +37,12 @@ func (r *Response) WithBody(body interface{}) *Response {
 	return r
 }
 
+// WithContent streams raw content (e.g. a file download) instead of a JSON body
+func (r *Response) WithContent(content io.Reader) *Response {
+	r.content = content
+	return r
+}
+
 //counterfeiter:generate -o fake -fake-name Handler . Handler
 
 type Handler func(r *http.Request) (*Response, error)
@@ -86,6 +94,10 @@ func (response *Response) writeTo(w http.ResponseWriter) error {
 		}
 	}
 
+	if response.content != nil {
+		return response.writeContentTo(w)
+	}
+
 	if response.body == nil {
 		w.WriteHeader(response.httpStatus)
 		return nil
@@ -104,3 +116,20 @@ func (response *Response) writeTo(w http.ResponseWriter) error {
 
 	return nil
 }
+
+func (response *Response) writeContentTo(w http.ResponseWriter) error {
+	if closer, ok := response.content.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/octet-stream")
+	}
+	w.WriteHeader(response.httpStatus)
+
+	if _, err := io.Copy(w, response.content); err != nil {
+		return fmt.Errorf("failed to write response content: %w", err)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package routing_test
 import (
 	"errors"
 	"net/http"
+	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/routing"
@@ -59,6 +60,30 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("the response has raw content", func() {
+		BeforeEach(func() {
+			response = response.WithContent(strings.NewReader("raw-bytes"))
+		})
+
+		It("sets the application/octet-stream content type in the response", func() {
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/octet-stream"))
+		})
+
+		It("streams the content", func() {
+			Expect(rr).To(HaveHTTPBody("raw-bytes"))
+		})
+
+		When("the response sets a content type", func() {
+			BeforeEach(func() {
+				response = response.WithHeader("Content-Type", "application/x-tar")
+			})
+
+			It("keeps the content type", func() {
+				Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/x-tar"))
+			})
+		})
+	})
+
 	When("the response sets header values", func() {
 		BeforeEach(func() {
 			response = response.WithHeader("Location", "/home")
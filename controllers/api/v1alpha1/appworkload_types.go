@@ -56,6 +56,24 @@ type AppWorkloadSpec struct {
 	// Reference to service credentials secrets to be projected onto the app workload
 	// They are in the [servicebinding.io](https://servicebinding.io/spec/core/1.1.0/) format
 	Services []ServiceBinding `json:"services,omitempty"`
+
+	// Scheduling constraints of the isolation segment the workload runs on
+	// +kubebuilder:validation:Optional
+	Scheduling *WorkloadScheduling `json:"scheduling,omitempty"`
+
+	// Name prefix of the secrets holding the instance identity credentials of
+	// the workload instances. See InstanceIdentityInstanceSecretName
+	// +kubebuilder:validation:Optional
+	InstanceIdentitySecretName string `json:"instanceIdentitySecretName,omitempty"`
+
+	// The number of log bytes per second each instance may emit. Unlimited when
+	// unset or -1
+	// +kubebuilder:validation:Optional
+	LogRateLimitBytesPerSecond *int64 `json:"logRateLimitBytesPerSecond,omitempty"`
+
+	// Grace period given to the instances to shut down after SIGTERM
+	// +kubebuilder:validation:Optional
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
 }
 
 // AppWorkloadStatus defines the observed state of AppWorkload
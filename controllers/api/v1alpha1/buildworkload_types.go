@@ -37,6 +37,10 @@ type BuildWorkloadSpec struct {
 	// If no values are specified, then all available buildpacks will be used for auto-detection
 	Buildpacks []string `json:"buildpacks,omitempty"`
 
+	// The stack the app is built on. Used by builders to pick the stack specific build image
+	//+kubebuilder:validation:Optional
+	Stack string `json:"stack,omitempty"`
+
 	// The environment variables to set on the container that builds the image
 	Env []v1.EnvVar `json:"env,omitempty"`
 
@@ -45,6 +49,10 @@ type BuildWorkloadSpec struct {
 	// The name of the builder that should reconcile this BuildWorkload resource and execute the image building
 	// +kubebuilder:validation:Required
 	BuilderName string `json:"builderName"`
+
+	// Scheduling constraints of the isolation segment the workload runs on
+	// +kubebuilder:validation:Optional
+	Scheduling *WorkloadScheduling `json:"scheduling,omitempty"`
 }
 
 // BuildWorkloadStatus defines the observed state of BuildWorkload
@@ -0,0 +1,236 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AutoscalingMetricCPU        AutoscalingMetricType = "cpu"
+	AutoscalingMetricMemoryUsed AutoscalingMetricType = "memoryused"
+	AutoscalingMetricMemoryUtil AutoscalingMetricType = "memoryutil"
+	AutoscalingMetricThroughput AutoscalingMetricType = "throughput"
+
+	ScalingTypeDynamic  ScalingType = "dynamic"
+	ScalingTypeSchedule ScalingType = "schedule"
+
+	ScalingStatusSucceeded ScalingStatus = "succeeded"
+	ScalingStatusFailed    ScalingStatus = "failed"
+
+	MetricsAvailableConditionType = "MetricsAvailable"
+)
+
+// CFAutoscalingPolicySpec defines the desired state of CFAutoscalingPolicy
+type CFAutoscalingPolicySpec struct {
+	// A reference to the CFProcess that is scaled by this policy. The CFProcess must be in the same namespace.
+	ProcessRef v1.LocalObjectReference `json:"processRef"`
+
+	// The minimum number of instances the process is scaled down to
+	//+kubebuilder:validation:Minimum=1
+	InstanceMinCount int32 `json:"instanceMinCount"`
+
+	// The maximum number of instances the process is scaled up to
+	//+kubebuilder:validation:Minimum=1
+	InstanceMaxCount int32 `json:"instanceMaxCount"`
+
+	// The rules that scale the process based on its metrics
+	//+kubebuilder:validation:Optional
+	ScalingRules []ScalingRule `json:"scalingRules,omitempty"`
+
+	// The schedules that override the instance limits at given times
+	//+kubebuilder:validation:Optional
+	Schedules *AutoscalingSchedules `json:"schedules,omitempty"`
+}
+
+// AutoscalingMetricType is the metric a scaling rule is evaluated against
+// +kubebuilder:validation:Enum=cpu;memoryused;memoryutil;throughput
+type AutoscalingMetricType string
+
+type ScalingRule struct {
+	// The metric the rule is evaluated against: cpu is the average usage as a percentage of one core, memoryused the
+	// average memory usage in MB, memoryutil the average memory usage as a percentage of the memory limit and
+	// throughput the number of requests per second across all instances
+	MetricType AutoscalingMetricType `json:"metricType"`
+
+	// The value the metric is compared to
+	Threshold int64 `json:"threshold"`
+
+	// How the metric is compared to the threshold
+	// +kubebuilder:validation:Enum=<;>;<=;>=
+	Operator string `json:"operator"`
+
+	// How long the metric has to breach the threshold before the process is scaled
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	BreachDurationSeconds int32 `json:"breachDurationSeconds,omitempty"`
+
+	// How long to wait after scaling before evaluating the rules again
+	//+kubebuilder:validation:Optional
+	//+kubebuilder:validation:Minimum=0
+	CoolDownSeconds int32 `json:"coolDownSeconds,omitempty"`
+
+	// The change in instances, either absolute (e.g. "+1", "-2") or relative to the current instances (e.g. "+10%")
+	// +kubebuilder:validation:Pattern=`^[-+][1-9][0-9]*%?$`
+	Adjustment string `json:"adjustment"`
+}
+
+type AutoscalingSchedules struct {
+	// The IANA time zone the schedules are expressed in
+	Timezone string `json:"timezone"`
+
+	//+kubebuilder:validation:Optional
+	RecurringSchedules []RecurringSchedule `json:"recurringSchedules,omitempty"`
+
+	//+kubebuilder:validation:Optional
+	SpecificDates []SpecificDateSchedule `json:"specificDates,omitempty"`
+}
+
+type ScheduleLimits struct {
+	// The minimum number of instances while the schedule is active
+	InstanceMinCount int32 `json:"instanceMinCount"`
+
+	// The maximum number of instances while the schedule is active
+	InstanceMaxCount int32 `json:"instanceMaxCount"`
+
+	// The number of instances the process is scaled up to when the schedule starts
+	//+kubebuilder:validation:Optional
+	InitialMinInstanceCount *int32 `json:"initialMinInstanceCount,omitempty"`
+}
+
+type RecurringSchedule struct {
+	ScheduleLimits `json:",inline"`
+
+	// The time of day the schedule starts at, formatted as HH:MM
+	StartTime string `json:"startTime"`
+
+	// The time of day the schedule ends at, formatted as HH:MM
+	EndTime string `json:"endTime"`
+
+	// The days of the week the schedule is active on, from 1 (Monday) to 7 (Sunday)
+	//+kubebuilder:validation:Optional
+	DaysOfWeek []int32 `json:"daysOfWeek,omitempty"`
+
+	// The days of the month the schedule is active on, from 1 to 31
+	//+kubebuilder:validation:Optional
+	DaysOfMonth []int32 `json:"daysOfMonth,omitempty"`
+
+	// The date the schedule becomes effective, formatted as YYYY-MM-DD
+	//+kubebuilder:validation:Optional
+	StartDate string `json:"startDate,omitempty"`
+
+	// The date the schedule stops being effective, formatted as YYYY-MM-DD
+	//+kubebuilder:validation:Optional
+	EndDate string `json:"endDate,omitempty"`
+}
+
+type SpecificDateSchedule struct {
+	ScheduleLimits `json:",inline"`
+
+	// The date and time the schedule starts at, formatted as YYYY-MM-DDTHH:MM
+	StartDateTime string `json:"startDateTime"`
+
+	// The date and time the schedule ends at, formatted as YYYY-MM-DDTHH:MM
+	EndDateTime string `json:"endDateTime"`
+}
+
+// ScalingType tells whether a scaling event was caused by a scaling rule or by a schedule
+type ScalingType string
+
+// ScalingStatus is the outcome of a scaling event
+type ScalingStatus string
+
+type ScalingEvent struct {
+	Timestamp    metav1.Time   `json:"timestamp"`
+	ScalingType  ScalingType   `json:"scalingType"`
+	Status       ScalingStatus `json:"status"`
+	OldInstances int32         `json:"oldInstances"`
+	NewInstances int32         `json:"newInstances"`
+	Reason       string        `json:"reason"`
+
+	//+kubebuilder:validation:Optional
+	Error string `json:"error,omitempty"`
+}
+
+type RuleBreach struct {
+	// The index of the breached rule in the scaling rules of the policy
+	Rule int32 `json:"rule"`
+
+	// The time the rule was first observed breaching its threshold
+	Since metav1.Time `json:"since"`
+}
+
+// CFAutoscalingPolicyStatus defines the observed state of CFAutoscalingPolicy
+type CFAutoscalingPolicyStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFAutoscalingPolicy that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The schedule whose limits are currently in effect, if any
+	//+kubebuilder:validation:Optional
+	ActiveSchedule string `json:"activeSchedule,omitempty"`
+
+	// The scaling rules currently breaching their threshold
+	//+kubebuilder:validation:Optional
+	Breaches []RuleBreach `json:"breaches,omitempty"`
+
+	// The scaling rules are not evaluated until this time after the process has been scaled
+	//+kubebuilder:validation:Optional
+	CoolDownExpiresAt *metav1.Time `json:"coolDownExpiresAt,omitempty"`
+
+	// The most recent scaling events, oldest first
+	//+kubebuilder:validation:Optional
+	ScalingHistory []ScalingEvent `json:"scalingHistory,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Process",type=string,JSONPath=`.spec.processRef.name`
+//+kubebuilder:printcolumn:name="Min",type=integer,JSONPath=`.spec.instanceMinCount`
+//+kubebuilder:printcolumn:name="Max",type=integer,JSONPath=`.spec.instanceMaxCount`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAutoscalingPolicy is the Schema for the cfautoscalingpolicies API
+type CFAutoscalingPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFAutoscalingPolicySpec   `json:"spec,omitempty"`
+	Status CFAutoscalingPolicyStatus `json:"status,omitempty"`
+}
+
+func (p *CFAutoscalingPolicy) StatusConditions() *[]metav1.Condition {
+	return &p.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFAutoscalingPolicyList contains a list of CFAutoscalingPolicy
+type CFAutoscalingPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAutoscalingPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAutoscalingPolicy{}, &CFAutoscalingPolicyList{})
+}
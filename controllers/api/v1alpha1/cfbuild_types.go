@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"strings"
+
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,7 +33,9 @@ const (
 
 // CFBuildSpec defines the desired state of CFBuild
 type CFBuildSpec struct {
-	// The CFPackage associated with this build. Must be in the same namespace
+	// The CFPackage associated with this build. Must be in the same namespace.
+	// Not set for builds that provide their droplet directly
+	//+kubebuilder:validation:Optional
 	PackageRef v1.LocalObjectReference `json:"packageRef"`
 	// The CFApp associated with this build. Must be in the same namespace
 	AppRef v1.LocalObjectReference `json:"appRef"`
@@ -43,6 +47,11 @@ type CFBuildSpec struct {
 
 	// Specifies the buildpacks and stack for the build
 	Lifecycle Lifecycle `json:"lifecycle"`
+
+	// A prebuilt droplet (e.g. an uploaded or copied one) that does not need staging.
+	// When set, the build succeeds with this droplet as soon as its image is set, without running a BuildWorkload
+	//+kubebuilder:validation:Optional
+	Droplet *BuildDropletStatus `json:"droplet,omitempty"`
 }
 
 // CFBuildStatus defines the observed state of CFBuild
@@ -75,6 +84,12 @@ type BuildDropletStatus struct {
 	// The exposed ports for the application
 	//+kubebuilder:validation:Optional
 	Ports []int32 `json:"ports"`
+
+	// The digest the Registry image resolved to at staging time, e.g.
+	// sha256:... When set, workloads run the image pinned to this digest
+	// rather than the (possibly mutable) Registry image
+	//+kubebuilder:validation:Optional
+	ImageDigest string `json:"imageDigest,omitempty"`
 }
 
 // ProcessType is a map of process names and associated start commands for the Droplet
@@ -101,6 +116,21 @@ type CFBuild struct {
 	Status CFBuildStatus `json:"status,omitempty"`
 }
 
+// RunImage returns the image workloads should run for this droplet, i.e. the
+// Registry image pinned to the ImageDigest if there is one
+func (d *BuildDropletStatus) RunImage() string {
+	if d.ImageDigest == "" {
+		return d.Registry.Image
+	}
+
+	repository, _, _ := strings.Cut(d.Registry.Image, "@")
+	if tagIdx := strings.LastIndex(repository, ":"); tagIdx > strings.LastIndex(repository, "/") {
+		repository = repository[:tagIdx]
+	}
+
+	return repository + "@" + d.ImageDigest
+}
+
 func (b *CFBuild) StatusConditions() *[]metav1.Condition {
 	return &b.Status.Conditions
 }
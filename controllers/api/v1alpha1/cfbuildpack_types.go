@@ -0,0 +1,103 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFBuildpackFinalizerName = "kpack-image-builder.korifi.cloudfoundry.org/cfbuildpack"
+)
+
+// CFBuildpackSpec defines the desired state of CFBuildpack
+type CFBuildpackSpec struct {
+	// The CF name of the buildpack
+	DisplayName string `json:"displayName"`
+
+	// The stack the buildpack is restricted to. Empty means the buildpack works with any stack
+	//+kubebuilder:validation:Optional
+	Stack string `json:"stack,omitempty"`
+
+	// The 1-based position of the buildpack in the builder detection order
+	//+kubebuilder:validation:Minimum=1
+	Position int `json:"position"`
+
+	// Disabled buildpacks are kept in the store but are not part of the builder detection order
+	Enabled bool `json:"enabled"`
+
+	// Locked buildpacks cannot have their bits updated
+	Locked bool `json:"locked"`
+
+	// The CNB buildpack (or buildpackage) image. Empty until the buildpack bits are uploaded
+	//+kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+}
+
+// CFBuildpackStatus defines the observed state of CFBuildpack
+type CFBuildpackStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFBuildpack that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The CNB buildpack id, as resolved by the builder store from the buildpack image
+	//+kubebuilder:validation:Optional
+	BuildpackID string `json:"buildpackID,omitempty"`
+
+	// The CNB buildpack version, as resolved by the builder store from the buildpack image
+	//+kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="Buildpack Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Position",type=integer,JSONPath=`.spec.position`
+//+kubebuilder:printcolumn:name="Enabled",type=boolean,JSONPath=`.spec.enabled`
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=='Ready')].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFBuildpack is the Schema for the cfbuildpacks API
+type CFBuildpack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFBuildpackSpec   `json:"spec,omitempty"`
+	Status CFBuildpackStatus `json:"status,omitempty"`
+}
+
+func (b *CFBuildpack) StatusConditions() *[]metav1.Condition {
+	return &b.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFBuildpackList contains a list of CFBuildpack
+type CFBuildpackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFBuildpack `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFBuildpack{}, &CFBuildpackList{})
+}
@@ -28,6 +28,11 @@ const (
 type CFDomainSpec struct {
 	// The domain name. It is required and must conform to RFC 1035
 	Name string `json:"name"`
+	// Protocol is optional and defaults to http. When set to grpc, all routes
+	// on the domain are exposed via a GRPCRoute, regardless of their own
+	// protocol
+	//+kubebuilder:validation:Optional
+	Protocol Protocol `json:"protocol,omitempty"`
 }
 
 // CFDomainStatus defines the observed state of CFDomain
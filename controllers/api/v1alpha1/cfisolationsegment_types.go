@@ -0,0 +1,90 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFIsolationSegmentSpec defines the desired state of CFIsolationSegment
+type CFIsolationSegmentSpec struct {
+	// The mutable, user-friendly name of the isolation segment
+	DisplayName string `json:"displayName"`
+
+	// The GUIDs of the orgs that are entitled to use the isolation segment
+	//+kubebuilder:validation:Optional
+	EntitledOrgs []string `json:"entitledOrgs,omitempty"`
+
+	// The scheduling constraints applied to the pods of every workload in the spaces assigned to the isolation segment
+	//+kubebuilder:validation:Optional
+	Scheduling WorkloadScheduling `json:"scheduling,omitempty"`
+}
+
+// CFIsolationSegmentStatus defines the observed state of CFIsolationSegment
+type CFIsolationSegmentStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFIsolationSegment that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
+//+kubebuilder:printcolumn:name="Updated At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/updated_at`
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFIsolationSegment is the Schema for the cfisolationsegments API
+type CFIsolationSegment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFIsolationSegmentSpec   `json:"spec,omitempty"`
+	Status CFIsolationSegmentStatus `json:"status,omitempty"`
+}
+
+func (s CFIsolationSegment) UniqueName() string {
+	return strings.ToLower(s.Spec.DisplayName)
+}
+
+func (s CFIsolationSegment) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("Isolation Segment names are case insensitive and must be unique. The name '%s' is already taken.", s.Spec.DisplayName)
+}
+
+func (s *CFIsolationSegment) StatusConditions() *[]metav1.Condition {
+	return &s.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFIsolationSegmentList contains a list of CFIsolationSegment
+type CFIsolationSegmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFIsolationSegment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFIsolationSegment{}, &CFIsolationSegmentList{})
+}
@@ -35,6 +35,10 @@ type CFOrgSpec struct {
 	// The mutable, user-friendly name of the CFOrg. Unlike metadata.name, the user can change this field.
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// The GUID of the isolation segment used by spaces that are not assigned to an isolation segment
+	//+kubebuilder:validation:Optional
+	DefaultIsolationSegmentGUID string `json:"defaultIsolationSegmentGUID,omitempty"`
 }
 
 // CFOrgStatus defines the observed state of CFOrg
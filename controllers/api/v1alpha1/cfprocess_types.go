@@ -39,9 +39,14 @@ type CFProcessSpec struct {
 	// The default command for this process as defined by the build. This field is ignored when the Command field is set
 	DetectedCommand string `json:"detectedCommand,omitempty"`
 
-	// Used to build the Liveness and Readiness Probes for the process' AppWorkload.
+	// Used to build the Startup and Liveness Probes for the process' AppWorkload.
 	HealthCheck HealthCheck `json:"healthCheck"`
 
+	// Used to build the Readiness Probe for the process' AppWorkload. Instances
+	// failing the readiness check stop receiving traffic but are not restarted.
+	// +optional
+	ReadinessHealthCheck ReadinessHealthCheck `json:"readinessHealthCheck,omitempty"`
+
 	// The desired number of replicas to deploy
 	DesiredInstances *int32 `json:"desiredInstances,omitempty"`
 
@@ -51,6 +56,19 @@ type CFProcessSpec struct {
 	// The disk limit in MiB
 	DiskQuotaMB int64 `json:"diskQuotaMB"`
 
+	// The number of log bytes per second each instance may emit. Unlimited when
+	// unset or -1. Lines exceeding it are dropped from the API logs and the
+	// syslog drains, the container output itself is not throttled
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=-1
+	LogRateLimitBytesPerSecond *int64 `json:"logRateLimitBytesPerSecond,omitempty"`
+
+	// How long instances are given to shut down gracefully after being sent
+	// SIGTERM before they are killed
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+
 	// The ports to expose
 	// Deprecated: No longer used
 	// +kubebuilder:validation:Optional
@@ -78,6 +96,36 @@ type HealthCheckData struct {
 
 	InvocationTimeoutSeconds int32 `json:"invocationTimeoutSeconds"`
 	TimeoutSeconds           int32 `json:"timeoutSeconds"`
+
+	// The interval between liveness checks. Defaults to 30 seconds when unset
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
+}
+
+type ReadinessHealthCheck struct {
+	// The type of Readiness Health Check the App process will use
+	// Valid values are "http", "port", and "process". The default type is "process",
+	// which considers instances ready as soon as they have started.
+	// +optional
+	Type HealthCheckType `json:"type,omitempty"`
+
+	// The input parameters for the readiness probe in kubernetes
+	// +optional
+	Data ReadinessHealthCheckData `json:"data,omitempty"`
+}
+
+// ReadinessHealthCheckData used to pass through input parameters to readiness probe
+type ReadinessHealthCheckData struct {
+	// The http endpoint to use with "http" readiness checks
+	// +optional
+	HTTPEndpoint string `json:"httpEndpoint,omitempty"`
+
+	// +optional
+	InvocationTimeoutSeconds int32 `json:"invocationTimeoutSeconds,omitempty"`
+
+	// The interval between readiness checks. Defaults to 30 seconds when unset
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
 }
 
 // CFProcessStatus defines the observed state of CFProcess
@@ -93,6 +141,10 @@ type CFProcessStatus struct {
 
 	//+kubebuilder:validation:Optional
 	InstancesStatus map[string]InstanceStatus `json:"instancesStatus"`
+
+	// The usage of the process as of the last recorded app usage event
+	//+kubebuilder:validation:Optional
+	LastRecordedUsage *RecordedAppUsage `json:"lastRecordedUsage,omitempty"`
 }
 
 //+kubebuilder:object:root=true
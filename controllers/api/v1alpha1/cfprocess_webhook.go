@@ -29,16 +29,18 @@ import (
 var cfprocesslog = logf.Log.WithName("cfprocess-resource")
 
 type CFProcessDefaulter struct {
-	defaultMemoryMB    int64
-	defaultDiskQuotaMB int64
-	defaultTimeout     int32
+	defaultMemoryMB                      int64
+	defaultDiskQuotaMB                   int64
+	defaultTimeout                       int32
+	defaultTerminationGracePeriodSeconds int64
 }
 
-func NewCFProcessDefaulter(defaultMemoryMB, defaultDiskQuotaMB int64, defaultTimeout int32) *CFProcessDefaulter {
+func NewCFProcessDefaulter(defaultMemoryMB, defaultDiskQuotaMB int64, defaultTimeout int32, defaultTerminationGracePeriodSeconds int64) *CFProcessDefaulter {
 	return &CFProcessDefaulter{
-		defaultMemoryMB:    defaultMemoryMB,
-		defaultDiskQuotaMB: defaultDiskQuotaMB,
-		defaultTimeout:     defaultTimeout,
+		defaultMemoryMB:                      defaultMemoryMB,
+		defaultDiskQuotaMB:                   defaultDiskQuotaMB,
+		defaultTimeout:                       defaultTimeout,
+		defaultTerminationGracePeriodSeconds: defaultTerminationGracePeriodSeconds,
 	}
 }
 
@@ -58,6 +60,7 @@ func (d *CFProcessDefaulter) Default(ctx context.Context, obj runtime.Object) er
 	d.defaultResources(process)
 	d.defaultInstances(process)
 	d.defaultHealthCheck(process)
+	d.defaultReadinessHealthCheck(process)
 
 	return nil
 }
@@ -70,6 +73,10 @@ func (d *CFProcessDefaulter) defaultResources(process *CFProcess) {
 	if process.Spec.DiskQuotaMB == 0 {
 		process.Spec.DiskQuotaMB = d.defaultDiskQuotaMB
 	}
+
+	if process.Spec.TerminationGracePeriodSeconds == nil {
+		process.Spec.TerminationGracePeriodSeconds = tools.PtrTo(d.defaultTerminationGracePeriodSeconds)
+	}
 }
 
 func (d *CFProcessDefaulter) defaultInstances(process *CFProcess) {
@@ -100,3 +107,9 @@ func (d *CFProcessDefaulter) defaultHealthCheck(process *CFProcess) {
 
 	process.Spec.HealthCheck.Type = "process"
 }
+
+func (d *CFProcessDefaulter) defaultReadinessHealthCheck(process *CFProcess) {
+	if process.Spec.ReadinessHealthCheck.Type == "" {
+		process.Spec.ReadinessHealthCheck.Type = ProcessHealthCheckType
+	}
+}
@@ -87,6 +87,22 @@ var _ = Describe("CFProcessMutatingWebhook", func() {
 		})
 	})
 
+	Describe("termination grace period", func() {
+		It("sets the configured default termination grace period", func() {
+			Expect(cfProcess.Spec.TerminationGracePeriodSeconds).To(gstruct.PointTo(BeEquivalentTo(defaultTerminationGracePeriod)))
+		})
+
+		When("the process already has a termination grace period set", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.TerminationGracePeriodSeconds = tools.PtrTo[int64](42)
+			})
+
+			It("preserves it", func() {
+				Expect(cfProcess.Spec.TerminationGracePeriodSeconds).To(gstruct.PointTo(BeEquivalentTo(42)))
+			})
+		})
+	})
+
 	Describe("instances", func() {
 		It("defaults desired instances to zero", func() {
 			Expect(cfProcess.Spec.DesiredInstances).To(gstruct.PointTo(BeZero()))
@@ -158,4 +174,20 @@ var _ = Describe("CFProcessMutatingWebhook", func() {
 			})
 		})
 	})
+
+	Describe("readiness healthcheck", func() {
+		It("defaults the readiness healthcheck type to process", func() {
+			Expect(cfProcess.Spec.ReadinessHealthCheck.Type).To(BeEquivalentTo("process"))
+		})
+
+		When("the type is already set", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ReadinessHealthCheck.Type = "http"
+			})
+
+			It("preserves the value", func() {
+				Expect(cfProcess.Spec.ReadinessHealthCheck.Type).To(BeEquivalentTo("http"))
+			})
+		})
+	})
 })
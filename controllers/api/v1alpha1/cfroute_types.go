@@ -27,9 +27,31 @@ import (
 const (
 	// Deprecated. Used for removing leftover finalizers
 	CFRouteFinalizerName = "cfRoute.korifi.cloudfoundry.org"
+	// Ensures the services and reference grants of shared destinations,
+	// which live outside the route namespace, are cleaned up
+	CFRouteSharedDestinationsFinalizerName = "korifi.cloudfoundry.org/cfroute-shared-destinations"
 
 	DestinationAppGUIDLabelPrefix = "korifi.cloudfoundry.org/destination-app-guid-"
 	CFRouteIsUnmappedLabelKey     = "korifi.cloudfoundry.org/unmapped"
+
+	// CFRouteTransferredFromAnnotation is set on a route created to take the
+	// place of the route with the same guid in the annotated namespace. The
+	// unique route name is handed over to it
+	CFRouteTransferredFromAnnotation = "korifi.cloudfoundry.org/transferred-from"
+	// CFRouteTransferredToAnnotation is set on a route that has been replaced
+	// by the route with the same guid in the annotated namespace. Its unique
+	// route name is held by its replacement
+	CFRouteTransferredToAnnotation = "korifi.cloudfoundry.org/transferred-to"
+
+	DestinationProtocolHTTP1 = "http1"
+	DestinationProtocolHTTP2 = "http2"
+
+	RouteProtocolHTTP Protocol = "http"
+	RouteProtocolGRPC Protocol = "grpc"
+
+	LoadBalancingRoundRobin      = "round-robin"
+	LoadBalancingLeastConnection = "least-connection"
+	LoadBalancingHash            = "hash"
 )
 
 // Destination defines a target for a CFRoute, does not carry meaning outside of a CF context
@@ -41,20 +63,49 @@ type Destination struct {
 	// droplet
 	//+kubebuilder:validation:Optional
 	Port *int32 `json:"port,omitempty"`
-	// A required reference to the CFApp that will receive traffic. The CFApp must be in the same namespace
+	// A required reference to the CFApp that will receive traffic. The CFApp must be in the
+	// same namespace, unless AppNamespace is set
 	AppRef v1.LocalObjectReference `json:"appRef"`
+	// The namespace of the CFApp. AppNamespace is optional and defaults to
+	// the route namespace. When set, it must be one of the route shared spaces
+	//+kubebuilder:validation:Optional
+	AppNamespace string `json:"appNamespace,omitempty"`
 	// The process type on the CFApp app which will receive traffic
 	ProcessType string `json:"processType"`
-	// Protocol is optional, when set must be "http1"
-	// +kubebuilder:validation:Enum=http1
+	// Protocol is optional, when set must be either "http1" or "http2".
+	// Destinations using "http2" are served over cleartext HTTP/2 (h2c)
+	// +kubebuilder:validation:Enum=http1;http2
 	//+kubebuilder:validation:Optional
 	Protocol *string `json:"protocol,omitempty"`
 }
 
 // Protocol defines the transport protocol of the route
-// +kubebuilder:validation:Enum=http;tcp
+// +kubebuilder:validation:Enum=http;tcp;grpc
 type Protocol string
 
+// RouteOptions configure how the gateway balances traffic across the route
+// destinations
+type RouteOptions struct {
+	// The load-balancing algorithm. Optional, defaults to the gateway
+	// implementation default (usually round-robin)
+	// +kubebuilder:validation:Enum=round-robin;least-connection;hash
+	//+kubebuilder:validation:Optional
+	LoadBalancing string `json:"loadBalancing,omitempty"`
+	// The request header whose value is hashed in order to pick a
+	// destination. Required when the load-balancing algorithm is hash
+	//+kubebuilder:validation:Optional
+	HashHeader string `json:"hashHeader,omitempty"`
+	// The time in seconds the gateway waits for a response from a
+	// destination. Optional, defaults to the gateway implementation default
+	// +kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Optional
+	RequestTimeoutSeconds *int32 `json:"requestTimeoutSeconds,omitempty"`
+	// When enabled, requests from the same client are sent to the same
+	// destination by means of a session cookie
+	//+kubebuilder:validation:Optional
+	SessionAffinity bool `json:"sessionAffinity,omitempty"`
+}
+
 // CFRouteSpec defines the desired state of CFRoute
 type CFRouteSpec struct {
 	// The subdomain of the route within the domain. Host is optional and defaults to empty.
@@ -62,12 +113,21 @@ type CFRouteSpec struct {
 	Host string `json:"host,omitempty"`
 	// Path is optional, defaults to empty
 	Path string `json:"path,omitempty"`
-	// Protocol is optional and defaults to http. Currently only http is supported
+	// Protocol is optional and defaults to http. Routes with the grpc
+	// protocol are exposed via a GRPCRoute rather than an HTTPRoute. tcp is
+	// currently not supported
 	Protocol Protocol `json:"protocol,omitempty"`
 	// A reference to the CFDomain this CFRoute is assigned to, including name and namespace
 	DomainRef v1.ObjectReference `json:"domainRef"`
 	// Destinations are optional. A route can exist without any destinations, independently of any CFApps
 	Destinations []Destination `json:"destinations,omitempty"`
+	// Options are optional and configure load-balancing, timeouts and session affinity
+	//+kubebuilder:validation:Optional
+	Options *RouteOptions `json:"options,omitempty"`
+	// SharedSpaces are the GUIDs of the spaces, other than the route space,
+	// whose apps can be route destinations
+	//+kubebuilder:validation:Optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
 }
 
 // CFRouteStatus defines the observed state of CFRoute
@@ -133,6 +193,21 @@ func (r CFRoute) UniqueValidationErrorMessage() string {
 	return fmt.Sprintf("Route already exists with host '%s'%s for domain '%s'.", r.Spec.Host, pathDetails, r.Status.FQDN)
 }
 
+// DestinationNamespace returns the namespace of the destination app
+func (r CFRoute) DestinationNamespace(destination Destination) string {
+	if destination.AppNamespace == "" {
+		return r.Namespace
+	}
+
+	return destination.AppNamespace
+}
+
+// IsSharedDestination returns true when the destination app does not live
+// in the route namespace
+func (r CFRoute) IsSharedDestination(destination Destination) bool {
+	return r.DestinationNamespace(destination) != r.Namespace
+}
+
 func (r *CFRoute) StatusConditions() *[]metav1.Condition {
 	return &r.Status.Conditions
 }
@@ -18,6 +18,10 @@ type CFServiceOfferingSpec struct {
 	// +kubebuilder:validation:Optional
 	DocumentationURL *string              `json:"documentationUrl"`
 	BrokerCatalog    ServiceBrokerCatalog `json:"brokerCatalog"`
+	// True if the offering is no longer in the broker catalog but still has
+	// plans with service instances
+	// +kubebuilder:validation:Optional
+	Inactive bool `json:"inactive,omitempty"`
 }
 
 type ServiceBrokerCatalog struct {
@@ -18,6 +18,10 @@ type CFServicePlanSpec struct {
 	Schemas         ServicePlanSchemas       `json:"schemas"`
 	MaintenanceInfo MaintenanceInfo          `json:"maintenanceInfo"`
 	Visibility      ServicePlanVisibility    `json:"visibility"`
+	// True if the plan is no longer in the broker catalog but still has
+	// service instances. Inactive plans are not available for new instances
+	// +kubebuilder:validation:Optional
+	Inactive bool `json:"inactive,omitempty"`
 }
 
 type ServicePlanBrokerCatalog struct {
@@ -48,8 +52,9 @@ type ServicePlanSchemas struct {
 }
 
 type ServicePlanFeatures struct {
-	PlanUpdateable bool `json:"planUpdateable"`
-	Bindable       bool `json:"bindable"`
+	PlanUpdateable   bool `json:"planUpdateable"`
+	Bindable         bool `json:"bindable"`
+	BindingRotatable bool `json:"bindingRotatable,omitempty"`
 }
 
 type VisibilityOrganization struct {
@@ -59,16 +64,21 @@ type VisibilityOrganization struct {
 
 type MaintenanceInfo struct {
 	Version string `json:"version"`
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
 }
 
 const (
 	AdminServicePlanVisibilityType        = "admin"
 	PublicServicePlanVisibilityType       = "public"
 	OrganizationServicePlanVisibilityType = "organization"
+	SpaceServicePlanVisibilityType        = "space"
 )
 
 type ServicePlanVisibility struct {
-	// +kubebuilder:validation:Enum=admin;public;organization
+	// Plans of space-scoped brokers have the space visibility type and are
+	// only visible in the space of their broker
+	// +kubebuilder:validation:Enum=admin;public;organization;space
 	Type string `json:"type"`
 	// +kubebuilder:validation:Optional
 	Organizations []string `json:"organizations,omitempty"`
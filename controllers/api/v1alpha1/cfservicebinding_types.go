@@ -21,6 +21,7 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
@@ -38,6 +39,10 @@ const (
 	CFServiceInstanceGUIDLabelKey = "korifi.cloudfoundry.org/service-instance-guid"
 
 	CFServiceBindingTypeLabelKey = "korifi.cloudfoundry.org/service-binding-type"
+
+	// SupersededByAnnotation is set on a service binding that has been
+	// replaced by a rotated successor binding
+	SupersededByAnnotation = "korifi.cloudfoundry.org/superseded-by"
 )
 
 // CFServiceBindingSpec defines the desired state of CFServiceBinding
@@ -58,6 +63,12 @@ type CFServiceBindingSpec struct {
 	// The type of the binding. There are two possible values - "key" or "app"
 	// +kubebuilder:validation:Enum=app;key
 	Type string `json:"type"`
+
+	// A reference to the binding this binding rotates. It is set while the
+	// rotation is in progress and cleared once the previous binding has been
+	// replaced. Only makes sense for bindings to managed service instances
+	// +optional
+	PreviousBinding v1.LocalObjectReference `json:"previousBinding,omitempty"`
 }
 
 // CFServiceBindingStatus defines the observed state of CFServiceBinding
@@ -81,10 +92,68 @@ type CFServiceBindingStatus struct {
 	//+kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// Volume mounts returned by the broker when binding to a volume service
+	//+kubebuilder:validation:Optional
+	VolumeMounts []ServiceBindingVolumeMount `json:"volumeMounts,omitempty"`
+
+	// URL to which the logs of the bound app are forwarded
+	//+kubebuilder:validation:Optional
+	SyslogDrainURL string `json:"syslogDrainURL,omitempty"`
+
+	// The time the binding credentials expire at, as reported by the broker
+	//+kubebuilder:validation:Optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// The time after which the binding should be rotated, as reported by the
+	// broker
+	//+kubebuilder:validation:Optional
+	RenewBefore *metav1.Time `json:"renewBefore,omitempty"`
+
+	// The number of failed attempts to unbind after binding failed with an
+	// unknown outcome. Only makes sense for bindings to managed service
+	// instances
+	//+kubebuilder:validation:Optional
+	OrphanMitigationAttempts int32 `json:"orphanMitigationAttempts,omitempty"`
+
 	// ObservedGeneration captures the latest generation of the CFServiceBinding that has been reconciled
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// ServiceBindingVolumeMount is a volume mount returned by the broker of a
+// volume service when binding, together with the volume it resolves to
+type ServiceBindingVolumeMount struct {
+	// Name of the volume driver managing the device
+	Driver string `json:"driver"`
+
+	// Absolute path in the app container the device is mounted at
+	ContainerDir string `json:"containerDir"`
+
+	// "r" for read-only or "rw" for read-write
+	Mode string `json:"mode"`
+
+	// The type of the device. Only "shared" devices are supported
+	DeviceType string `json:"deviceType"`
+
+	Device VolumeMountDevice `json:"device"`
+
+	// Set when the device volume ID names a persistent volume claim in the
+	// binding namespace
+	// +kubebuilder:validation:Optional
+	PersistentVolumeClaim *v1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// Set when the device is provided inline by the CSI driver named after
+	// the volume mount driver
+	// +kubebuilder:validation:Optional
+	CSI *v1.CSIVolumeSource `json:"csi,omitempty"`
+}
+
+type VolumeMountDevice struct {
+	VolumeID string `json:"volumeId"`
+
+	// +kubebuilder:validation:Optional
+	MountConfig *runtime.RawExtension `json:"mountConfig,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Created At",type="string",JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/created_at`
@@ -34,6 +34,9 @@ const (
 
 	ProvisioningFailedCondition   = "ProvisioningFailed"
 	DeprovisioningFailedCondition = "DeprovisioningFailed"
+	// OrphanedCondition is set on service instances whose plan has been
+	// removed from the broker catalog
+	OrphanedCondition = "Orphaned"
 )
 
 // CFServiceInstanceSpec defines the desired state of CFServiceInstance
@@ -56,6 +59,12 @@ type CFServiceInstanceSpec struct {
 	// Tags are used by apps to identify service instances
 	Tags []string `json:"tags,omitempty"`
 
+	// URL to which logs of apps bound to this service instance are
+	// forwarded. Only supported for user-provided service instances. The
+	// scheme must be one of `syslog`, `syslog-tls` or `https`
+	// +optional
+	SyslogDrainURL *string `json:"syslogDrainURL,omitempty"`
+
 	PlanGUID string `json:"planGuid"`
 
 	Parameters corev1.LocalObjectReference `json:"parameters,omitempty"`
@@ -93,6 +102,16 @@ type CFServiceInstanceStatus struct {
 	// True if there is an upgrade available for for the service instance (i.e. the plan has a new version). Only makes seense for managed service instances
 	//+kubebuilder:validation:Optional
 	UpgradeAvailable bool `json:"upgradeAvailable"`
+
+	// The usage of the service instance as of the last recorded service usage event
+	//+kubebuilder:validation:Optional
+	LastRecordedUsage *RecordedServiceUsage `json:"lastRecordedUsage,omitempty"`
+
+	// The number of failed attempts to deprovision the service instance after
+	// its provisioning failed with an unknown outcome. Only makes sense for
+	// managed service instances
+	//+kubebuilder:validation:Optional
+	OrphanMitigationAttempts int32 `json:"orphanMitigationAttempts,omitempty"`
 }
 
 type LastOperation struct {
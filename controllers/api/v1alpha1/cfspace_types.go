@@ -35,6 +35,10 @@ type CFSpaceSpec struct {
 	// The mutable, user-friendly name of the space. Unlike metadata.name, the user can change this field
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// The GUID of the isolation segment the space workloads run on. Defaults to the org default isolation segment
+	//+kubebuilder:validation:Optional
+	IsolationSegmentGUID string `json:"isolationSegmentGUID,omitempty"`
 }
 
 // CFSpaceStatus defines the observed state of CFSpace
@@ -46,6 +50,10 @@ type CFSpaceStatus struct {
 
 	// ObservedGeneration captures the latest generation of the CFSpace that has been reconciled
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The GUID of the isolation segment the space workloads run on, taking the org default into account
+	//+kubebuilder:validation:Optional
+	IsolationSegmentGUID string `json:"isolationSegmentGUID,omitempty"`
 }
 
 //+kubebuilder:object:root=true
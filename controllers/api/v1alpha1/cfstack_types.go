@@ -0,0 +1,106 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	StackStateActive     = "ACTIVE"
+	StackStateDeprecated = "DEPRECATED"
+)
+
+// CFStackSpec defines the desired state of CFStack
+type CFStackSpec struct {
+	// The CF name of the stack, as referenced by the app lifecycle
+	DisplayName string `json:"displayName"`
+
+	//+kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+
+	// Builds of apps whose stack does not match any CFStack are staged with the default stack builder
+	//+kubebuilder:validation:Optional
+	Default bool `json:"default,omitempty"`
+
+	// The name of the kpack ClusterBuilder used to stage apps on this stack. Defaults to the configured ClusterBuilder
+	//+kubebuilder:validation:Optional
+	ClusterBuilderName string `json:"clusterBuilderName,omitempty"`
+
+	// Deprecated stacks can still be used, but clients are warned when staging apps on them
+	//+kubebuilder:validation:Enum=ACTIVE;DEPRECATED
+	//+kubebuilder:default=ACTIVE
+	State string `json:"state,omitempty"`
+}
+
+// CFStackStatus defines the observed state of CFStack
+type CFStackStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFStack that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// The ClusterBuilder that stages apps on this stack
+	//+kubebuilder:validation:Optional
+	ClusterBuilderName string `json:"clusterBuilderName,omitempty"`
+
+	// The build image of the ClusterStack used by the builder
+	//+kubebuilder:validation:Optional
+	BuildRootfsImage string `json:"buildRootfsImage,omitempty"`
+
+	// The run image of the ClusterStack used by the builder
+	//+kubebuilder:validation:Optional
+	RunRootfsImage string `json:"runRootfsImage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced
+//+kubebuilder:printcolumn:name="Stack Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Default",type=boolean,JSONPath=`.spec.default`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=='Ready')].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFStack is the Schema for the cfstacks API
+type CFStack struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFStackSpec   `json:"spec,omitempty"`
+	Status CFStackStatus `json:"status,omitempty"`
+}
+
+func (s *CFStack) StatusConditions() *[]metav1.Condition {
+	return &s.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFStackList contains a list of CFStack
+type CFStackList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFStack `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFStack{}, &CFStackList{})
+}
@@ -39,6 +39,12 @@ type CFTaskSpec struct {
 	// A boolean describing whether the CFTask has been canceled
 	// +optional
 	Canceled bool `json:"canceled"`
+	// The number of log bytes per second the task may emit. Unlimited when
+	// unset or -1. Lines exceeding it are dropped from the API logs and the
+	// syslog drains, the container output itself is not throttled
+	// +optional
+	// +kubebuilder:validation:Minimum=-1
+	LogRateLimitBytesPerSecond *int64 `json:"logRateLimitBytesPerSecond,omitempty"`
 }
 
 // CFTaskStatus defines the observed state of CFTask
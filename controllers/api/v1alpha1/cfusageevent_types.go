@@ -0,0 +1,167 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type UsageEventType string
+
+const (
+	AppUsageEventType     UsageEventType = "app"
+	ServiceUsageEventType UsageEventType = "service"
+
+	UsageEventTypeLabelKey = "korifi.cloudfoundry.org/usage-event-type"
+
+	AppUsageStateStarted = "STARTED"
+	AppUsageStateStopped = "STOPPED"
+	AppUsageStateScaled  = "SCALED"
+
+	ServiceUsageStateCreated = "CREATED"
+	ServiceUsageStateUpdated = "UPDATED"
+	ServiceUsageStateDeleted = "DELETED"
+)
+
+// CFUsageEventSpec defines a single, immutable entry in the usage event log
+type CFUsageEventSpec struct {
+	// +kubebuilder:validation:Enum=app;service
+	Type UsageEventType `json:"type"`
+
+	// The time the usage change was observed. Events are ordered by the
+	// resource version they were created with rather than by timestamp, as
+	// the clocks of the writers may differ
+	Timestamp metav1.MicroTime `json:"timestamp"`
+
+	// The usage state recorded by the event, e.g. STARTED or DELETED
+	State string `json:"state"`
+
+	// The usage state recorded by the previous event for the same resource
+	//+kubebuilder:validation:Optional
+	PreviousState string `json:"previousState,omitempty"`
+
+	SpaceGUID string `json:"spaceGUID"`
+
+	//+kubebuilder:validation:Optional
+	SpaceName string `json:"spaceName,omitempty"`
+
+	//+kubebuilder:validation:Optional
+	OrgGUID string `json:"orgGUID,omitempty"`
+
+	// Set for app usage events
+	//+kubebuilder:validation:Optional
+	App *AppUsage `json:"app,omitempty"`
+
+	// Set for service usage events
+	//+kubebuilder:validation:Optional
+	Service *ServiceUsage `json:"service,omitempty"`
+}
+
+type AppUsage struct {
+	AppGUID string `json:"appGUID"`
+	AppName string `json:"appName"`
+
+	ProcessGUID string `json:"processGUID"`
+	ProcessType string `json:"processType"`
+
+	InstanceCount int32 `json:"instanceCount"`
+
+	//+kubebuilder:validation:Optional
+	PreviousInstanceCount int32 `json:"previousInstanceCount,omitempty"`
+
+	MemoryInMBPerInstance int64 `json:"memoryInMBPerInstance"`
+
+	//+kubebuilder:validation:Optional
+	PreviousMemoryInMBPerInstance int64 `json:"previousMemoryInMBPerInstance,omitempty"`
+
+	//+kubebuilder:validation:Optional
+	BuildpackName string `json:"buildpackName,omitempty"`
+}
+
+type ServiceUsage struct {
+	ServiceInstanceGUID string `json:"serviceInstanceGUID"`
+	ServiceInstanceName string `json:"serviceInstanceName"`
+
+	// +kubebuilder:validation:Enum=managed_service_instance;user_provided_service_instance
+	ServiceInstanceType string `json:"serviceInstanceType"`
+
+	//+kubebuilder:validation:Optional
+	ServicePlanGUID string `json:"servicePlanGUID,omitempty"`
+	//+kubebuilder:validation:Optional
+	ServicePlanName string `json:"servicePlanName,omitempty"`
+
+	//+kubebuilder:validation:Optional
+	ServiceOfferingGUID string `json:"serviceOfferingGUID,omitempty"`
+	//+kubebuilder:validation:Optional
+	ServiceOfferingName string `json:"serviceOfferingName,omitempty"`
+
+	//+kubebuilder:validation:Optional
+	ServiceBrokerGUID string `json:"serviceBrokerGUID,omitempty"`
+	//+kubebuilder:validation:Optional
+	ServiceBrokerName string `json:"serviceBrokerName,omitempty"`
+}
+
+// RecordedAppUsage is the usage of a process as of the last recorded app usage event
+type RecordedAppUsage struct {
+	State     string `json:"state"`
+	Instances int32  `json:"instances"`
+	MemoryMB  int64  `json:"memoryMB"`
+
+	// The number of usage events recorded for the process so far
+	EventCount int64 `json:"eventCount"`
+}
+
+// RecordedServiceUsage is the usage of a service instance as of the last recorded service usage event
+type RecordedServiceUsage struct {
+	State string `json:"state"`
+	Name  string `json:"name"`
+
+	//+kubebuilder:validation:Optional
+	PlanGUID string `json:"planGUID,omitempty"`
+
+	// The number of usage events recorded for the service instance so far
+	EventCount int64 `json:"eventCount"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="Timestamp",type=string,JSONPath=`.spec.timestamp`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFUsageEvent is the Schema for the cfusageevents API
+type CFUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// CFUsageEventList contains a list of CFUsageEvent
+type CFUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFUsageEvent{}, &CFUsageEventList{})
+}
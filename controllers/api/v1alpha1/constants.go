@@ -12,4 +12,8 @@ const (
 	ProcessHealthCheckType HealthCheckType = "process"
 
 	StatusConditionReady = "Ready"
+
+	// OrphanMitigationCondition is true while the resources a broker may have
+	// created during a failed provision or bind are being cleaned up
+	OrphanMitigationCondition = "OrphanMitigationInProgress"
 )
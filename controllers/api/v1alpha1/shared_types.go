@@ -28,6 +28,7 @@ const (
 	CFDomainGUIDLabelKey        = "korifi.cloudfoundry.org/domain-guid"
 	CFEncodedDomainNameLabelKey = "korifi.cloudfoundry.org/domain-name"
 	CFRouteGUIDLabelKey         = "korifi.cloudfoundry.org/route-guid"
+	CFRouteNamespaceLabelKey    = "korifi.cloudfoundry.org/route-namespace"
 	CFRouteHostLabelKey         = "korifi.cloudfoundry.org/route-host"
 	CFRoutePathLabelKey         = "korifi.cloudfoundry.org/route-path"
 	CFTaskGUIDLabelKey          = "korifi.cloudfoundry.org/task-guid"
@@ -40,6 +41,8 @@ const (
 	UpdatedAtLabelKey       = "korifi.cloudfoundry.org/updated_at"
 	PlanGUIDLabelKey        = "korifi.cloudfoundry.org/plan-guid"
 
+	IsolationSegmentGUIDLabelKey = "korifi.cloudfoundry.org/isolation-segment-guid"
+
 	PodIndexLabelKey = "apps.kubernetes.io/pod-index"
 
 	StagingConditionType   = "Staging"
@@ -50,6 +53,17 @@ const (
 	PropagateDeletionAnnotation       = "cloudfoundry.org/propagate-deletion"
 	PropagatedFromLabel               = "cloudfoundry.org/propagated-from"
 
+	// OriginatingIdentityAnnotation holds the name of the user who requested
+	// the last broker operation on a service instance or binding
+	OriginatingIdentityAnnotation = "korifi.cloudfoundry.org/originating-identity"
+
+	// LogRateLimitAnnotation is set by runners on workload pods and holds the
+	// number of log bytes per second an instance may emit. Lines exceeding it
+	// are dropped from the logs served by the API and forwarded to syslog
+	// drains. The limit is advisory: the container output itself is not
+	// throttled and remains available in full through `kubectl logs`
+	LogRateLimitAnnotation = "korifi.cloudfoundry.org/log-rate-limit-bytes-per-second"
+
 	RelationshipsLabelPrefix    = "korifi.cloudfoundry.org/rel-"
 	RelServiceBrokerGUIDLabel   = RelationshipsLabelPrefix + "service-broker-guid"
 	RelServiceBrokerNameLabel   = RelationshipsLabelPrefix + "service-broker-name"
@@ -120,6 +134,73 @@ type ServiceBinding struct {
 
 	// Name of the binding secret
 	Secret string `json:"secret"`
+
+	// Volumes provided by the binding to a volume service
+	// +kubebuilder:validation:Optional
+	VolumeMounts []VolumeMount `json:"volumeMounts,omitempty"`
+}
+
+// VolumeMount is a volume to be mounted onto a workload container
+type VolumeMount struct {
+	// Name of the volume. Unique within the workload
+	Name string `json:"name"`
+
+	// Absolute path in the container the volume is mounted at
+	ContainerDir string `json:"containerDir"`
+
+	// +kubebuilder:validation:Optional
+	ReadOnly bool `json:"readOnly,omitempty"`
+
+	// The persistent volume claim backing the volume. Exactly one of
+	// PersistentVolumeClaim and CSI is set
+	// +kubebuilder:validation:Optional
+	PersistentVolumeClaim *corev1.PersistentVolumeClaimVolumeSource `json:"persistentVolumeClaim,omitempty"`
+
+	// The CSI driver providing the volume inline
+	// +kubebuilder:validation:Optional
+	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
+}
+
+// WorkloadScheduling constrains the nodes that the pods of a workload can be scheduled on
+type WorkloadScheduling struct {
+	// Node labels the workload pods must be scheduled on
+	//+kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the workload pods
+	//+kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// The RuntimeClass used to run the workload pods
+	//+kubebuilder:validation:Optional
+	RuntimeClassName *string `json:"runtimeClassName,omitempty"`
+}
+
+const (
+	// InstanceIdentityMountPath is where runners mount the instance identity
+	// secret of a workload
+	InstanceIdentityMountPath = "/etc/cf-instance-credentials"
+
+	// InstanceIdentityCAKey holds the CA certificate that signed the instance
+	// identity certificates
+	InstanceIdentityCAKey = "ca.crt"
+
+	// InstanceIdentityCertKey and InstanceIdentityKeyKey hold the certificate
+	// and the private key of the instance in its instance identity secret
+	InstanceIdentityCertKey = "tls.crt"
+	InstanceIdentityKeyKey  = "tls.key"
+
+	// InstanceIdentitySecretLabelKey is set on the instance identity secrets
+	// of a workload to the workload InstanceIdentitySecretName
+	InstanceIdentitySecretLabelKey = "korifi.cloudfoundry.org/instance-identity-secret"
+)
+
+// InstanceIdentityInstanceSecretName is the name of the secret holding the
+// instance identity credentials of the workload instance with the given
+// index. Each instance gets a secret of its own so that it cannot read the
+// private keys of the other instances
+func InstanceIdentityInstanceSecretName(secretName string, index string) string {
+	return secretName + "-" + index
 }
 
 func AsMap(obj *runtime.RawExtension) (map[string]any, error) {
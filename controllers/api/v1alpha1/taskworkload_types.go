@@ -37,6 +37,25 @@ type TaskWorkloadSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Env []corev1.EnvVar `json:"env"`
+
+	// Scheduling constraints of the isolation segment the workload runs on
+	// +kubebuilder:validation:Optional
+	Scheduling *WorkloadScheduling `json:"scheduling,omitempty"`
+
+	// Volumes provided by the volume services bound to the app
+	// +kubebuilder:validation:Optional
+	VolumeMounts []VolumeMount `json:"volumeMounts,omitempty"`
+
+	// Name prefix of the secret holding the instance identity credentials of
+	// the task. The task runs as instance 0, see
+	// InstanceIdentityInstanceSecretName
+	// +kubebuilder:validation:Optional
+	InstanceIdentitySecretName string `json:"instanceIdentitySecretName,omitempty"`
+
+	// The number of log bytes per second the task may emit. Unlimited when
+	// unset or -1
+	// +kubebuilder:validation:Optional
+	LogRateLimitBytesPerSecond *int64 `json:"logRateLimitBytesPerSecond,omitempty"`
 }
 
 // TaskWorkloadStatus defines the observed state of TaskWorkload
@@ -45,9 +45,10 @@ import (
 )
 
 const (
-	defaultMemoryMB    = 128
-	defaultDiskQuotaMB = 256
-	defaultTimeout     = 60
+	defaultMemoryMB               = 128
+	defaultDiskQuotaMB            = 256
+	defaultTimeout                = 60
+	defaultTerminationGracePeriod = 10
 )
 
 var (
@@ -111,7 +112,7 @@ var _ = BeforeSuite(func() {
 
 	Expect(domains.NewValidator(uncachedClient).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
-	Expect(korifiv1alpha1.NewCFProcessDefaulter(defaultMemoryMB, defaultDiskQuotaMB, defaultTimeout).
+	Expect(korifiv1alpha1.NewCFProcessDefaulter(defaultMemoryMB, defaultDiskQuotaMB, defaultTimeout, defaultTerminationGracePeriod).
 		SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect(adminClient.Create(ctx, &corev1.Namespace{
@@ -26,6 +26,21 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppUsage) DeepCopyInto(out *AppUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppUsage.
+func (in *AppUsage) DeepCopy() *AppUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(AppUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWorkload) DeepCopyInto(out *AppWorkload) {
 	*out = *in
@@ -129,7 +144,24 @@ func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 	if in.Services != nil {
 		in, out := &in.Services, &out.Services
 		*out = make([]ServiceBinding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(WorkloadScheduling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LogRateLimitBytesPerSecond != nil {
+		in, out := &in.LogRateLimitBytesPerSecond, &out.LogRateLimitBytesPerSecond
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
 	}
 }
 
@@ -172,6 +204,35 @@ func (in *AppWorkloadStatus) DeepCopy() *AppWorkloadStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalingSchedules) DeepCopyInto(out *AutoscalingSchedules) {
+	*out = *in
+	if in.RecurringSchedules != nil {
+		in, out := &in.RecurringSchedules, &out.RecurringSchedules
+		*out = make([]RecurringSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SpecificDates != nil {
+		in, out := &in.SpecificDates, &out.SpecificDates
+		*out = make([]SpecificDateSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalingSchedules.
+func (in *AutoscalingSchedules) DeepCopy() *AutoscalingSchedules {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalingSchedules)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BrokerCatalogFeatures) DeepCopyInto(out *BrokerCatalogFeatures) {
 	*out = *in
@@ -294,6 +355,11 @@ func (in *BuildWorkloadSpec) DeepCopyInto(out *BuildWorkloadSpec) {
 		*out = make([]v1.ObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(WorkloadScheduling)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildWorkloadSpec.
@@ -581,7 +647,9 @@ func (in *CFAppStatus) DeepCopyInto(out *CFAppStatus) {
 	if in.ServiceBindings != nil {
 		in, out := &in.ServiceBindings, &out.ServiceBindings
 		*out = make([]ServiceBinding, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -596,7 +664,7 @@ func (in *CFAppStatus) DeepCopy() *CFAppStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuild) DeepCopyInto(out *CFBuild) {
+func (in *CFAutoscalingPolicy) DeepCopyInto(out *CFAutoscalingPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -604,18 +672,18 @@ func (in *CFBuild) DeepCopyInto(out *CFBuild) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuild.
-func (in *CFBuild) DeepCopy() *CFBuild {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAutoscalingPolicy.
+func (in *CFAutoscalingPolicy) DeepCopy() *CFAutoscalingPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuild)
+	out := new(CFAutoscalingPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFBuild) DeepCopyObject() runtime.Object {
+func (in *CFAutoscalingPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -623,31 +691,31 @@ func (in *CFBuild) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildList) DeepCopyInto(out *CFBuildList) {
+func (in *CFAutoscalingPolicyList) DeepCopyInto(out *CFAutoscalingPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFBuild, len(*in))
+		*out = make([]CFAutoscalingPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildList.
-func (in *CFBuildList) DeepCopy() *CFBuildList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAutoscalingPolicyList.
+func (in *CFAutoscalingPolicyList) DeepCopy() *CFAutoscalingPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildList)
+	out := new(CFAutoscalingPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFBuildList) DeepCopyObject() runtime.Object {
+func (in *CFAutoscalingPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -655,31 +723,34 @@ func (in *CFBuildList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildSpec) DeepCopyInto(out *CFBuildSpec) {
+func (in *CFAutoscalingPolicySpec) DeepCopyInto(out *CFAutoscalingPolicySpec) {
 	*out = *in
-	out.PackageRef = in.PackageRef
-	out.AppRef = in.AppRef
-	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
+	out.ProcessRef = in.ProcessRef
+	if in.ScalingRules != nil {
+		in, out := &in.ScalingRules, &out.ScalingRules
+		*out = make([]ScalingRule, len(*in))
+		copy(*out, *in)
+	}
+	if in.Schedules != nil {
+		in, out := &in.Schedules, &out.Schedules
+		*out = new(AutoscalingSchedules)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildSpec.
-func (in *CFBuildSpec) DeepCopy() *CFBuildSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAutoscalingPolicySpec.
+func (in *CFAutoscalingPolicySpec) DeepCopy() *CFAutoscalingPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildSpec)
+	out := new(CFAutoscalingPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildStatus) DeepCopyInto(out *CFBuildStatus) {
+func (in *CFAutoscalingPolicyStatus) DeepCopyInto(out *CFAutoscalingPolicyStatus) {
 	*out = *in
-	if in.Droplet != nil {
-		in, out := &in.Droplet, &out.Droplet
-		*out = new(BuildDropletStatus)
-		(*in).DeepCopyInto(*out)
-	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -687,39 +758,57 @@ func (in *CFBuildStatus) DeepCopyInto(out *CFBuildStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Breaches != nil {
+		in, out := &in.Breaches, &out.Breaches
+		*out = make([]RuleBreach, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CoolDownExpiresAt != nil {
+		in, out := &in.CoolDownExpiresAt, &out.CoolDownExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ScalingHistory != nil {
+		in, out := &in.ScalingHistory, &out.ScalingHistory
+		*out = make([]ScalingEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildStatus.
-func (in *CFBuildStatus) DeepCopy() *CFBuildStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAutoscalingPolicyStatus.
+func (in *CFAutoscalingPolicyStatus) DeepCopy() *CFAutoscalingPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildStatus)
+	out := new(CFAutoscalingPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomain) DeepCopyInto(out *CFDomain) {
+func (in *CFBuild) DeepCopyInto(out *CFBuild) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomain.
-func (in *CFDomain) DeepCopy() *CFDomain {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuild.
+func (in *CFBuild) DeepCopy() *CFBuild {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomain)
+	out := new(CFBuild)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFDomain) DeepCopyObject() runtime.Object {
+func (in *CFBuild) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -727,31 +816,31 @@ func (in *CFDomain) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainList) DeepCopyInto(out *CFDomainList) {
+func (in *CFBuildList) DeepCopyInto(out *CFBuildList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFDomain, len(*in))
+		*out = make([]CFBuild, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainList.
-func (in *CFDomainList) DeepCopy() *CFDomainList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildList.
+func (in *CFBuildList) DeepCopy() *CFBuildList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainList)
+	out := new(CFBuildList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFDomainList) DeepCopyObject() runtime.Object {
+func (in *CFBuildList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -759,23 +848,36 @@ func (in *CFDomainList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainSpec) DeepCopyInto(out *CFDomainSpec) {
+func (in *CFBuildSpec) DeepCopyInto(out *CFBuildSpec) {
 	*out = *in
+	out.PackageRef = in.PackageRef
+	out.AppRef = in.AppRef
+	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
+	if in.Droplet != nil {
+		in, out := &in.Droplet, &out.Droplet
+		*out = new(BuildDropletStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainSpec.
-func (in *CFDomainSpec) DeepCopy() *CFDomainSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildSpec.
+func (in *CFBuildSpec) DeepCopy() *CFBuildSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainSpec)
+	out := new(CFBuildSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
+func (in *CFBuildStatus) DeepCopyInto(out *CFBuildStatus) {
 	*out = *in
+	if in.Droplet != nil {
+		in, out := &in.Droplet, &out.Droplet
+		*out = new(BuildDropletStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -785,18 +887,18 @@ func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainStatus.
-func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildStatus.
+func (in *CFBuildStatus) DeepCopy() *CFBuildStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainStatus)
+	out := new(CFBuildStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrg) DeepCopyInto(out *CFOrg) {
+func (in *CFBuildpack) DeepCopyInto(out *CFBuildpack) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -804,18 +906,18 @@ func (in *CFOrg) DeepCopyInto(out *CFOrg) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrg.
-func (in *CFOrg) DeepCopy() *CFOrg {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpack.
+func (in *CFBuildpack) DeepCopy() *CFBuildpack {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrg)
+	out := new(CFBuildpack)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFOrg) DeepCopyObject() runtime.Object {
+func (in *CFBuildpack) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -823,31 +925,31 @@ func (in *CFOrg) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgList) DeepCopyInto(out *CFOrgList) {
+func (in *CFBuildpackList) DeepCopyInto(out *CFBuildpackList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFOrg, len(*in))
+		*out = make([]CFBuildpack, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgList.
-func (in *CFOrgList) DeepCopy() *CFOrgList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpackList.
+func (in *CFBuildpackList) DeepCopy() *CFBuildpackList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgList)
+	out := new(CFBuildpackList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFOrgList) DeepCopyObject() runtime.Object {
+func (in *CFBuildpackList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -855,22 +957,22 @@ func (in *CFOrgList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
+func (in *CFBuildpackSpec) DeepCopyInto(out *CFBuildpackSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgSpec.
-func (in *CFOrgSpec) DeepCopy() *CFOrgSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpackSpec.
+func (in *CFBuildpackSpec) DeepCopy() *CFBuildpackSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgSpec)
+	out := new(CFBuildpackSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
+func (in *CFBuildpackStatus) DeepCopyInto(out *CFBuildpackStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -881,37 +983,37 @@ func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgStatus.
-func (in *CFOrgStatus) DeepCopy() *CFOrgStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildpackStatus.
+func (in *CFBuildpackStatus) DeepCopy() *CFBuildpackStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgStatus)
+	out := new(CFBuildpackStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackage) DeepCopyInto(out *CFPackage) {
+func (in *CFDomain) DeepCopyInto(out *CFDomain) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackage.
-func (in *CFPackage) DeepCopy() *CFPackage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomain.
+func (in *CFDomain) DeepCopy() *CFDomain {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackage)
+	out := new(CFDomain)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFPackage) DeepCopyObject() runtime.Object {
+func (in *CFDomain) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -919,31 +1021,31 @@ func (in *CFPackage) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageList) DeepCopyInto(out *CFPackageList) {
+func (in *CFDomainList) DeepCopyInto(out *CFDomainList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFPackage, len(*in))
+		*out = make([]CFDomain, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageList.
-func (in *CFPackageList) DeepCopy() *CFPackageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainList.
+func (in *CFDomainList) DeepCopy() *CFDomainList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageList)
+	out := new(CFDomainList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFPackageList) DeepCopyObject() runtime.Object {
+func (in *CFDomainList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -951,24 +1053,22 @@ func (in *CFPackageList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageSpec) DeepCopyInto(out *CFPackageSpec) {
+func (in *CFDomainSpec) DeepCopyInto(out *CFDomainSpec) {
 	*out = *in
-	out.AppRef = in.AppRef
-	in.Source.DeepCopyInto(&out.Source)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageSpec.
-func (in *CFPackageSpec) DeepCopy() *CFPackageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainSpec.
+func (in *CFDomainSpec) DeepCopy() *CFDomainSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageSpec)
+	out := new(CFDomainSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
+func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -979,18 +1079,18 @@ func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageStatus.
-func (in *CFPackageStatus) DeepCopy() *CFPackageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainStatus.
+func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageStatus)
+	out := new(CFDomainStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcess) DeepCopyInto(out *CFProcess) {
+func (in *CFIsolationSegment) DeepCopyInto(out *CFIsolationSegment) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -998,18 +1098,18 @@ func (in *CFProcess) DeepCopyInto(out *CFProcess) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcess.
-func (in *CFProcess) DeepCopy() *CFProcess {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegment.
+func (in *CFIsolationSegment) DeepCopy() *CFIsolationSegment {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcess)
+	out := new(CFIsolationSegment)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFProcess) DeepCopyObject() runtime.Object {
+func (in *CFIsolationSegment) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1017,46 +1117,31 @@ func (in *CFProcess) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessDefaulter) DeepCopyInto(out *CFProcessDefaulter) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessDefaulter.
-func (in *CFProcessDefaulter) DeepCopy() *CFProcessDefaulter {
-	if in == nil {
-		return nil
-	}
-	out := new(CFProcessDefaulter)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessList) DeepCopyInto(out *CFProcessList) {
+func (in *CFIsolationSegmentList) DeepCopyInto(out *CFIsolationSegmentList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFProcess, len(*in))
+		*out = make([]CFIsolationSegment, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessList.
-func (in *CFProcessList) DeepCopy() *CFProcessList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentList.
+func (in *CFIsolationSegmentList) DeepCopy() *CFIsolationSegmentList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessList)
+	out := new(CFIsolationSegmentList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFProcessList) DeepCopyObject() runtime.Object {
+func (in *CFIsolationSegmentList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1064,34 +1149,28 @@ func (in *CFProcessList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
+func (in *CFIsolationSegmentSpec) DeepCopyInto(out *CFIsolationSegmentSpec) {
 	*out = *in
-	out.AppRef = in.AppRef
-	out.HealthCheck = in.HealthCheck
-	if in.DesiredInstances != nil {
-		in, out := &in.DesiredInstances, &out.DesiredInstances
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
-		*out = make([]int32, len(*in))
+	if in.EntitledOrgs != nil {
+		in, out := &in.EntitledOrgs, &out.EntitledOrgs
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessSpec.
-func (in *CFProcessSpec) DeepCopy() *CFProcessSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentSpec.
+func (in *CFIsolationSegmentSpec) DeepCopy() *CFIsolationSegmentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessSpec)
+	out := new(CFIsolationSegmentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
+func (in *CFIsolationSegmentStatus) DeepCopyInto(out *CFIsolationSegmentStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -1100,31 +1179,364 @@ func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.InstancesStatus != nil {
-		in, out := &in.InstancesStatus, &out.InstancesStatus
-		*out = make(map[string]InstanceStatus, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessStatus.
-func (in *CFProcessStatus) DeepCopy() *CFProcessStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentStatus.
+func (in *CFIsolationSegmentStatus) DeepCopy() *CFIsolationSegmentStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessStatus)
+	out := new(CFIsolationSegmentStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFRoute) DeepCopyInto(out *CFRoute) {
+func (in *CFOrg) DeepCopyInto(out *CFOrg) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrg.
+func (in *CFOrg) DeepCopy() *CFOrg {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrg) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgList) DeepCopyInto(out *CFOrgList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFOrg, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgList.
+func (in *CFOrgList) DeepCopy() *CFOrgList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgSpec.
+func (in *CFOrgSpec) DeepCopy() *CFOrgSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgStatus.
+func (in *CFOrgStatus) DeepCopy() *CFOrgStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackage) DeepCopyInto(out *CFPackage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackage.
+func (in *CFPackage) DeepCopy() *CFPackage {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFPackage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageList) DeepCopyInto(out *CFPackageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFPackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageList.
+func (in *CFPackageList) DeepCopy() *CFPackageList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFPackageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageSpec) DeepCopyInto(out *CFPackageSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageSpec.
+func (in *CFPackageSpec) DeepCopy() *CFPackageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageStatus.
+func (in *CFPackageStatus) DeepCopy() *CFPackageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcess) DeepCopyInto(out *CFProcess) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcess.
+func (in *CFProcess) DeepCopy() *CFProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFProcess) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessDefaulter) DeepCopyInto(out *CFProcessDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessDefaulter.
+func (in *CFProcessDefaulter) DeepCopy() *CFProcessDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessList) DeepCopyInto(out *CFProcessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFProcess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessList.
+func (in *CFProcessList) DeepCopy() *CFProcessList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFProcessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	out.HealthCheck = in.HealthCheck
+	out.ReadinessHealthCheck = in.ReadinessHealthCheck
+	if in.DesiredInstances != nil {
+		in, out := &in.DesiredInstances, &out.DesiredInstances
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LogRateLimitBytesPerSecond != nil {
+		in, out := &in.LogRateLimitBytesPerSecond, &out.LogRateLimitBytesPerSecond
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessSpec.
+func (in *CFProcessSpec) DeepCopy() *CFProcessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InstancesStatus != nil {
+		in, out := &in.InstancesStatus, &out.InstancesStatus
+		*out = make(map[string]InstanceStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.LastRecordedUsage != nil {
+		in, out := &in.LastRecordedUsage, &out.LastRecordedUsage
+		*out = new(RecordedAppUsage)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessStatus.
+func (in *CFProcessStatus) DeepCopy() *CFProcessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRoute) DeepCopyInto(out *CFRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -1189,6 +1601,16 @@ func (in *CFRouteSpec) DeepCopyInto(out *CFRouteSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Options != nil {
+		in, out := &in.Options, &out.Options
+		*out = new(RouteOptions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SharedSpaces != nil {
+		in, out := &in.SharedSpaces, &out.SharedSpaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteSpec.
@@ -1409,6 +1831,7 @@ func (in *CFServiceBindingSpec) DeepCopyInto(out *CFServiceBindingSpec) {
 	out.Service = in.Service
 	out.AppRef = in.AppRef
 	out.Parameters = in.Parameters
+	out.PreviousBinding = in.PreviousBinding
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingSpec.
@@ -1433,6 +1856,21 @@ func (in *CFServiceBindingStatus) DeepCopyInto(out *CFServiceBindingStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]ServiceBindingVolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingStatus.
@@ -1614,6 +2052,11 @@ func (in *CFServiceInstanceSpec) DeepCopyInto(out *CFServiceInstanceSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SyslogDrainURL != nil {
+		in, out := &in.SyslogDrainURL, &out.SyslogDrainURL
+		*out = new(string)
+		**out = **in
+	}
 	out.Parameters = in.Parameters
 }
 
@@ -1640,6 +2083,11 @@ func (in *CFServiceInstanceStatus) DeepCopyInto(out *CFServiceInstanceStatus) {
 	out.Credentials = in.Credentials
 	out.LastOperation = in.LastOperation
 	out.MaintenanceInfo = in.MaintenanceInfo
+	if in.LastRecordedUsage != nil {
+		in, out := &in.LastRecordedUsage, &out.LastRecordedUsage
+		*out = new(RecordedServiceUsage)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceStatus.
@@ -1915,7 +2363,7 @@ func (in *CFSpaceStatus) DeepCopy() *CFSpaceStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTask) DeepCopyInto(out *CFTask) {
+func (in *CFStack) DeepCopyInto(out *CFStack) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -1923,6 +2371,102 @@ func (in *CFTask) DeepCopyInto(out *CFTask) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStack.
+func (in *CFStack) DeepCopy() *CFStack {
+	if in == nil {
+		return nil
+	}
+	out := new(CFStack)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFStack) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFStackList) DeepCopyInto(out *CFStackList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFStack, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStackList.
+func (in *CFStackList) DeepCopy() *CFStackList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFStackList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFStackList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFStackSpec) DeepCopyInto(out *CFStackSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStackSpec.
+func (in *CFStackSpec) DeepCopy() *CFStackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFStackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFStackStatus) DeepCopyInto(out *CFStackStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFStackStatus.
+func (in *CFStackStatus) DeepCopy() *CFStackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFStackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTask) DeepCopyInto(out *CFTask) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTask.
 func (in *CFTask) DeepCopy() *CFTask {
 	if in == nil {
@@ -1948,25 +2492,127 @@ func (in *CFTaskList) DeepCopyInto(out *CFTaskList) {
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFTask, len(*in))
+		*out = make([]CFTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskList.
+func (in *CFTaskList) DeepCopy() *CFTaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFTaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskSpec) DeepCopyInto(out *CFTaskSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	if in.LogRateLimitBytesPerSecond != nil {
+		in, out := &in.LogRateLimitBytesPerSecond, &out.LogRateLimitBytesPerSecond
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskSpec.
+func (in *CFTaskSpec) DeepCopy() *CFTaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFTaskStatus) DeepCopyInto(out *CFTaskStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.DropletRef = in.DropletRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskStatus.
+func (in *CFTaskStatus) DeepCopy() *CFTaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFTaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFUsageEvent) DeepCopyInto(out *CFUsageEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUsageEvent.
+func (in *CFUsageEvent) DeepCopy() *CFUsageEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(CFUsageEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFUsageEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFUsageEventList) DeepCopyInto(out *CFUsageEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFUsageEvent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskList.
-func (in *CFTaskList) DeepCopy() *CFTaskList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUsageEventList.
+func (in *CFUsageEventList) DeepCopy() *CFUsageEventList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFTaskList)
+	out := new(CFUsageEventList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFTaskList) DeepCopyObject() runtime.Object {
+func (in *CFUsageEventList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1974,40 +2620,27 @@ func (in *CFTaskList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTaskSpec) DeepCopyInto(out *CFTaskSpec) {
+func (in *CFUsageEventSpec) DeepCopyInto(out *CFUsageEventSpec) {
 	*out = *in
-	out.AppRef = in.AppRef
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskSpec.
-func (in *CFTaskSpec) DeepCopy() *CFTaskSpec {
-	if in == nil {
-		return nil
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+	if in.App != nil {
+		in, out := &in.App, &out.App
+		*out = new(AppUsage)
+		**out = **in
 	}
-	out := new(CFTaskSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFTaskStatus) DeepCopyInto(out *CFTaskStatus) {
-	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Service != nil {
+		in, out := &in.Service, &out.Service
+		*out = new(ServiceUsage)
+		**out = **in
 	}
-	out.DropletRef = in.DropletRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFTaskStatus.
-func (in *CFTaskStatus) DeepCopy() *CFTaskStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFUsageEventSpec.
+func (in *CFUsageEventSpec) DeepCopy() *CFUsageEventSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFTaskStatus)
+	out := new(CFUsageEventSpec)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2205,6 +2838,93 @@ func (in *ProcessType) DeepCopy() *ProcessType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessHealthCheck) DeepCopyInto(out *ReadinessHealthCheck) {
+	*out = *in
+	out.Data = in.Data
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessHealthCheck.
+func (in *ReadinessHealthCheck) DeepCopy() *ReadinessHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessHealthCheckData) DeepCopyInto(out *ReadinessHealthCheckData) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessHealthCheckData.
+func (in *ReadinessHealthCheckData) DeepCopy() *ReadinessHealthCheckData {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessHealthCheckData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordedAppUsage) DeepCopyInto(out *RecordedAppUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordedAppUsage.
+func (in *RecordedAppUsage) DeepCopy() *RecordedAppUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordedAppUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecordedServiceUsage) DeepCopyInto(out *RecordedServiceUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecordedServiceUsage.
+func (in *RecordedServiceUsage) DeepCopy() *RecordedServiceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(RecordedServiceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RecurringSchedule) DeepCopyInto(out *RecurringSchedule) {
+	*out = *in
+	in.ScheduleLimits.DeepCopyInto(&out.ScheduleLimits)
+	if in.DaysOfWeek != nil {
+		in, out := &in.DaysOfWeek, &out.DaysOfWeek
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.DaysOfMonth != nil {
+		in, out := &in.DaysOfMonth, &out.DaysOfMonth
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RecurringSchedule.
+func (in *RecurringSchedule) DeepCopy() *RecurringSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(RecurringSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -2240,6 +2960,42 @@ func (in *RequiredLocalObjectReference) DeepCopy() *RequiredLocalObjectReference
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteOptions) DeepCopyInto(out *RouteOptions) {
+	*out = *in
+	if in.RequestTimeoutSeconds != nil {
+		in, out := &in.RequestTimeoutSeconds, &out.RequestTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteOptions.
+func (in *RouteOptions) DeepCopy() *RouteOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuleBreach) DeepCopyInto(out *RuleBreach) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleBreach.
+func (in *RuleBreach) DeepCopy() *RuleBreach {
+	if in == nil {
+		return nil
+	}
+	out := new(RuleBreach)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnerInfo) DeepCopyInto(out *RunnerInfo) {
 	*out = *in
@@ -2352,6 +3108,57 @@ func (in *RunnerInfoStatus) DeepCopy() *RunnerInfoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingEvent) DeepCopyInto(out *ScalingEvent) {
+	*out = *in
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingEvent.
+func (in *ScalingEvent) DeepCopy() *ScalingEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScalingRule) DeepCopyInto(out *ScalingRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScalingRule.
+func (in *ScalingRule) DeepCopy() *ScalingRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ScalingRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleLimits) DeepCopyInto(out *ScheduleLimits) {
+	*out = *in
+	if in.InitialMinInstanceCount != nil {
+		in, out := &in.InitialMinInstanceCount, &out.InitialMinInstanceCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleLimits.
+func (in *ScheduleLimits) DeepCopy() *ScheduleLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
 	*out = *in
@@ -2385,6 +3192,13 @@ func (in *SecurityGroupWorkloads) DeepCopy() *SecurityGroupWorkloads {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBinding) DeepCopyInto(out *ServiceBinding) {
 	*out = *in
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBinding.
@@ -2413,6 +3227,32 @@ func (in *ServiceBindingSchema) DeepCopy() *ServiceBindingSchema {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingVolumeMount) DeepCopyInto(out *ServiceBindingVolumeMount) {
+	*out = *in
+	in.Device.DeepCopyInto(&out.Device)
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(v1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	if in.CSI != nil {
+		in, out := &in.CSI, &out.CSI
+		*out = new(v1.CSIVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingVolumeMount.
+func (in *ServiceBindingVolumeMount) DeepCopy() *ServiceBindingVolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingVolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBrokerCatalog) DeepCopyInto(out *ServiceBrokerCatalog) {
 	*out = *in
@@ -2524,6 +3364,37 @@ func (in *ServicePlanVisibility) DeepCopy() *ServicePlanVisibility {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceUsage) DeepCopyInto(out *ServiceUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceUsage.
+func (in *ServiceUsage) DeepCopy() *ServiceUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpecificDateSchedule) DeepCopyInto(out *SpecificDateSchedule) {
+	*out = *in
+	in.ScheduleLimits.DeepCopyInto(&out.ScheduleLimits)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecificDateSchedule.
+func (in *SpecificDateSchedule) DeepCopy() *SpecificDateSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(SpecificDateSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskWorkload) DeepCopyInto(out *TaskWorkload) {
 	*out = *in
@@ -2604,6 +3475,23 @@ func (in *TaskWorkloadSpec) DeepCopyInto(out *TaskWorkloadSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Scheduling != nil {
+		in, out := &in.Scheduling, &out.Scheduling
+		*out = new(WorkloadScheduling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LogRateLimitBytesPerSecond != nil {
+		in, out := &in.LogRateLimitBytesPerSecond, &out.LogRateLimitBytesPerSecond
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskWorkloadSpec.
@@ -2652,3 +3540,82 @@ func (in *VisibilityOrganization) DeepCopy() *VisibilityOrganization {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeMount) DeepCopyInto(out *VolumeMount) {
+	*out = *in
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(v1.PersistentVolumeClaimVolumeSource)
+		**out = **in
+	}
+	if in.CSI != nil {
+		in, out := &in.CSI, &out.CSI
+		*out = new(v1.CSIVolumeSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeMount.
+func (in *VolumeMount) DeepCopy() *VolumeMount {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeMountDevice) DeepCopyInto(out *VolumeMountDevice) {
+	*out = *in
+	if in.MountConfig != nil {
+		in, out := &in.MountConfig, &out.MountConfig
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeMountDevice.
+func (in *VolumeMountDevice) DeepCopy() *VolumeMountDevice {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeMountDevice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadScheduling) DeepCopyInto(out *WorkloadScheduling) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadScheduling.
+func (in *WorkloadScheduling) DeepCopy() *WorkloadScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
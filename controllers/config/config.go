@@ -1,6 +1,8 @@
 package config
 
 import (
+	"fmt"
+	"net/netip"
 	"time"
 
 	"go.uber.org/zap/zapcore"
@@ -23,18 +25,29 @@ type ControllerConfig struct {
 	MaxRetainedBuildsPerApp          int                `yaml:"maxRetainedBuildsPerApp"`
 	LogLevel                         zapcore.Level      `yaml:"logLevel"`
 	SpaceFinalizerAppDeletionTimeout *int32             `yaml:"spaceFinalizerAppDeletionTimeout"`
+	AutoscalerEvaluationInterval     string             `yaml:"autoscalerEvaluationInterval"`
+	AutoscalerThroughputMetric       string             `yaml:"autoscalerThroughputMetric"`
+	BrokerCatalogResyncInterval      string             `yaml:"brokerCatalogResyncInterval"`
+	InstanceIdentityCertValidity     string             `yaml:"instanceIdentityCertValidity"`
+	// LogDrainBlockedRanges lists the CIDR ranges that app syslog drains
+	// must not connect to
+	LogDrainBlockedRanges []string `yaml:"logDrainBlockedRanges"`
 
 	Networking Networking `yaml:"networking"`
 
 	ExperimentalManagedServicesEnabled bool `yaml:"experimentalManagedServicesEnabled"`
 	TrustInsecureServiceBrokers        bool `yaml:"trustInsecureServiceBrokers"`
 	DisableRouteController             bool `yaml:"disableRouteController"`
+	// AllowedVolumeMountDrivers lists the CSI drivers that service brokers
+	// may use to provide volume mounts to bound apps
+	AllowedVolumeMountDrivers []string `yaml:"allowedVolumeMountDrivers"`
 }
 
 type CFProcessDefaults struct {
-	MemoryMB    int64  `yaml:"memoryMB"`
-	DiskQuotaMB int64  `yaml:"diskQuotaMB"`
-	Timeout     *int32 `yaml:"timeout"`
+	MemoryMB                      int64  `yaml:"memoryMB"`
+	DiskQuotaMB                   int64  `yaml:"diskQuotaMB"`
+	Timeout                       *int32 `yaml:"timeout"`
+	TerminationGracePeriodSeconds *int64 `yaml:"terminationGracePeriodSeconds"`
 }
 
 type CFStagingResources struct {
@@ -46,13 +59,21 @@ type CFStagingResources struct {
 type Networking struct {
 	GatewayName      string `yaml:"gatewayName"`
 	GatewayNamespace string `yaml:"gatewayNamespace"`
+	// GatewayAdapter selects how route options not covered by the Gateway
+	// API are applied. Either "standard" (default) or "envoy-gateway"
+	GatewayAdapter string `yaml:"gatewayAdapter"`
 }
 
 const (
-	defaultTaskTTL            = 30 * 24 * time.Hour
-	defaultTimeout      int32 = 60
-	defaultJobTTL             = 24 * time.Hour
-	defaultBuildCacheMB       = 2048
+	defaultTaskTTL                             = 30 * 24 * time.Hour
+	defaultTimeout                       int32 = 60
+	defaultTerminationGracePeriodSeconds int64 = 10
+	defaultJobTTL                              = 24 * time.Hour
+	defaultBuildCacheMB                        = 2048
+
+	defaultAutoscalerEvaluationInterval = 30 * time.Second
+	defaultBrokerCatalogResyncInterval  = 10 * time.Minute
+	defaultInstanceIdentityCertValidity = 24 * time.Hour
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -66,6 +87,10 @@ func LoadFromPath(path string) (*ControllerConfig, error) {
 		config.CFProcessDefaults.Timeout = tools.PtrTo(defaultTimeout)
 	}
 
+	if config.CFProcessDefaults.TerminationGracePeriodSeconds == nil {
+		config.CFProcessDefaults.TerminationGracePeriodSeconds = tools.PtrTo(defaultTerminationGracePeriodSeconds)
+	}
+
 	if config.SpaceFinalizerAppDeletionTimeout == nil {
 		config.SpaceFinalizerAppDeletionTimeout = tools.PtrTo(defaultTimeout)
 	}
@@ -93,3 +118,40 @@ func (c ControllerConfig) ParseTaskTTL() (time.Duration, error) {
 
 	return tools.ParseDuration(c.TaskTTL)
 }
+
+func (c ControllerConfig) ParseAutoscalerEvaluationInterval() (time.Duration, error) {
+	if c.AutoscalerEvaluationInterval == "" {
+		return defaultAutoscalerEvaluationInterval, nil
+	}
+
+	return tools.ParseDuration(c.AutoscalerEvaluationInterval)
+}
+
+func (c ControllerConfig) ParseBrokerCatalogResyncInterval() (time.Duration, error) {
+	if c.BrokerCatalogResyncInterval == "" {
+		return defaultBrokerCatalogResyncInterval, nil
+	}
+
+	return tools.ParseDuration(c.BrokerCatalogResyncInterval)
+}
+
+func (c ControllerConfig) ParseInstanceIdentityCertValidity() (time.Duration, error) {
+	if c.InstanceIdentityCertValidity == "" {
+		return defaultInstanceIdentityCertValidity, nil
+	}
+
+	return tools.ParseDuration(c.InstanceIdentityCertValidity)
+}
+
+func (c ControllerConfig) ParseLogDrainBlockedRanges() ([]netip.Prefix, error) {
+	blockedRanges := []netip.Prefix{}
+	for _, blockedRange := range c.LogDrainBlockedRanges {
+		prefix, err := netip.ParsePrefix(blockedRange)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log drain blocked range %q: %w", blockedRange, err)
+		}
+		blockedRanges = append(blockedRanges, prefix.Masked())
+	}
+
+	return blockedRanges, nil
+}
@@ -1,6 +1,7 @@
 package config_test
 
 import (
+	"net/netip"
 	"os"
 	"path/filepath"
 	"time"
@@ -31,9 +32,10 @@ var _ = Describe("LoadFromPath", func() {
 
 		cfg = config.ControllerConfig{
 			CFProcessDefaults: config.CFProcessDefaults{
-				MemoryMB:    1024,
-				DiskQuotaMB: 512,
-				Timeout:     tools.PtrTo(int32(30)),
+				MemoryMB:                      1024,
+				DiskQuotaMB:                   512,
+				Timeout:                       tools.PtrTo(int32(30)),
+				TerminationGracePeriodSeconds: tools.PtrTo(int64(20)),
 			},
 			CFStagingResources: config.CFStagingResources{
 				BuildCacheMB: 1024,
@@ -53,6 +55,8 @@ var _ = Describe("LoadFromPath", func() {
 			},
 			ExperimentalManagedServicesEnabled: true,
 			TrustInsecureServiceBrokers:        true,
+			AllowedVolumeMountDrivers:          []string{"nfs.csi.k8s.io"},
+			LogDrainBlockedRanges:              []string{"169.254.0.0/16"},
 		}
 	})
 
@@ -72,9 +76,10 @@ var _ = Describe("LoadFromPath", func() {
 		Expect(retErr).NotTo(HaveOccurred())
 		Expect(*retConfig).To(Equal(config.ControllerConfig{
 			CFProcessDefaults: config.CFProcessDefaults{
-				MemoryMB:    1024,
-				DiskQuotaMB: 512,
-				Timeout:     tools.PtrTo(int32(30)),
+				MemoryMB:                      1024,
+				DiskQuotaMB:                   512,
+				Timeout:                       tools.PtrTo(int32(30)),
+				TerminationGracePeriodSeconds: tools.PtrTo(int64(20)),
 			},
 			CFStagingResources: config.CFStagingResources{
 				BuildCacheMB: 1024,
@@ -96,6 +101,8 @@ var _ = Describe("LoadFromPath", func() {
 			},
 			ExperimentalManagedServicesEnabled: true,
 			TrustInsecureServiceBrokers:        true,
+			AllowedVolumeMountDrivers:          []string{"nfs.csi.k8s.io"},
+			LogDrainBlockedRanges:              []string{"169.254.0.0/16"},
 		}))
 	})
 
@@ -109,6 +116,16 @@ var _ = Describe("LoadFromPath", func() {
 		})
 	})
 
+	When("the CFProcess default termination grace period is not set", func() {
+		BeforeEach(func() {
+			cfg.CFProcessDefaults.TerminationGracePeriodSeconds = nil
+		})
+
+		It("uses the default", func() {
+			Expect(retConfig.CFProcessDefaults.TerminationGracePeriodSeconds).To(gstruct.PointTo(BeEquivalentTo(10)))
+		})
+	})
+
 	When("the disable route controller is not set", func() {
 		BeforeEach(func() {
 		})
@@ -194,3 +211,190 @@ var _ = Describe("ParseTaskTTL", func() {
 		})
 	})
 })
+
+var _ = Describe("ParseAutoscalerEvaluationInterval", func() {
+	var (
+		intervalString string
+		interval       time.Duration
+		parseErr       error
+	)
+
+	BeforeEach(func() {
+		intervalString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			AutoscalerEvaluationInterval: intervalString,
+		}
+
+		interval, parseErr = cfg.ParseAutoscalerEvaluationInterval()
+	})
+
+	It("returns 30 seconds by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(30 * time.Second))
+	})
+
+	When("the interval is set", func() {
+		BeforeEach(func() {
+			intervalString = "1m"
+		})
+
+		It("parses it", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(interval).To(Equal(time.Minute))
+		})
+	})
+
+	When("the interval cannot be parsed", func() {
+		BeforeEach(func() {
+			intervalString = "often"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseBrokerCatalogResyncInterval", func() {
+	var (
+		intervalString string
+		interval       time.Duration
+		parseErr       error
+	)
+
+	BeforeEach(func() {
+		intervalString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			BrokerCatalogResyncInterval: intervalString,
+		}
+
+		interval, parseErr = cfg.ParseBrokerCatalogResyncInterval()
+	})
+
+	It("returns 10 minutes by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(interval).To(Equal(10 * time.Minute))
+	})
+
+	When("the interval is set", func() {
+		BeforeEach(func() {
+			intervalString = "1h"
+		})
+
+		It("parses it", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(interval).To(Equal(time.Hour))
+		})
+	})
+
+	When("the interval cannot be parsed", func() {
+		BeforeEach(func() {
+			intervalString = "often"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseInstanceIdentityCertValidity", func() {
+	var (
+		validityString string
+		validity       time.Duration
+		parseErr       error
+	)
+
+	BeforeEach(func() {
+		validityString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			InstanceIdentityCertValidity: validityString,
+		}
+
+		validity, parseErr = cfg.ParseInstanceIdentityCertValidity()
+	})
+
+	It("returns 24 hours by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(validity).To(Equal(24 * time.Hour))
+	})
+
+	When("the validity is set", func() {
+		BeforeEach(func() {
+			validityString = "2h"
+		})
+
+		It("parses it", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(validity).To(Equal(2 * time.Hour))
+		})
+	})
+
+	When("the validity cannot be parsed", func() {
+		BeforeEach(func() {
+			validityString = "forever"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseLogDrainBlockedRanges", func() {
+	var (
+		blockedRanges []string
+		prefixes      []netip.Prefix
+		parseErr      error
+	)
+
+	BeforeEach(func() {
+		blockedRanges = nil
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			LogDrainBlockedRanges: blockedRanges,
+		}
+
+		prefixes, parseErr = cfg.ParseLogDrainBlockedRanges()
+	})
+
+	It("returns no ranges by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(prefixes).To(BeEmpty())
+	})
+
+	When("blocked ranges are set", func() {
+		BeforeEach(func() {
+			blockedRanges = []string{"10.1.2.3/8", "fe80::/10"}
+		})
+
+		It("parses them", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(prefixes).To(Equal([]netip.Prefix{
+				netip.MustParsePrefix("10.0.0.0/8"),
+				netip.MustParsePrefix("fe80::/10"),
+			}))
+		})
+	})
+
+	When("a blocked range cannot be parsed", func() {
+		BeforeEach(func() {
+			blockedRanges = []string{"10.0.0.0"}
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(MatchError(ContainSubstring(`invalid log drain blocked range "10.0.0.0"`)))
+		})
+	})
+})
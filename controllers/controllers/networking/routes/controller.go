@@ -23,12 +23,14 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/gateway"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -48,6 +50,7 @@ type Reconciler struct {
 	scheme           *runtime.Scheme
 	log              logr.Logger
 	controllerConfig *config.ControllerConfig
+	gatewayAdapter   gateway.Adapter
 }
 
 func NewReconciler(
@@ -55,8 +58,15 @@ func NewReconciler(
 	scheme *runtime.Scheme,
 	log logr.Logger,
 	controllerConfig *config.ControllerConfig,
+	gatewayAdapter gateway.Adapter,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFRoute] {
-	routeReconciler := Reconciler{client: client, scheme: scheme, log: log, controllerConfig: controllerConfig}
+	routeReconciler := Reconciler{
+		client:           client,
+		scheme:           scheme,
+		log:              log,
+		controllerConfig: controllerConfig,
+		gatewayAdapter:   gatewayAdapter,
+	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFRoute](log, client, &routeReconciler)
 }
 
@@ -78,10 +88,11 @@ func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object)
 	}
 
 	var appRoutes korifiv1alpha1.CFRouteList
+	// routes shared with the app space live in other namespaces, hence
+	// routes are looked up across all namespaces
 	err := r.client.List(
 		ctx,
 		&appRoutes,
-		client.InNamespace(cfApp.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
@@ -106,6 +117,8 @@ func (r *Reconciler) enqueueCFAppRequests(ctx context.Context, o client.Object)
 
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes/status,verbs=get
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=referencegrants,verbs=get;list;watch;create;update;patch;delete
 
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 
@@ -124,6 +137,10 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 		return ctrl.Result{}, err
 	}
 
+	if hasSharedDestinations(cfRoute) {
+		controllerutil.AddFinalizer(cfRoute, korifiv1alpha1.CFRouteSharedDestinationsFinalizerName)
+	}
+
 	cfDomain := &korifiv1alpha1.CFDomain{}
 	err := r.client.Get(ctx, types.NamespacedName{Name: cfRoute.Spec.DomainRef.Name, Namespace: cfRoute.Spec.DomainRef.Namespace}, cfDomain)
 	if err != nil {
@@ -135,16 +152,30 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("CreatePatchServices")
 	}
 
-	err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain)
+	routeKind := "HTTPRoute"
+	if isGRPC(cfRoute, cfDomain) {
+		routeKind = "GRPCRoute"
+		err = r.reconcileGRPCRoute(ctx, cfRoute, cfDomain)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileGRPCRoute")
+		}
+	} else {
+		err = r.reconcileHTTPRoute(ctx, cfRoute, cfDomain)
+		if err != nil {
+			return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileHTTPRoute")
+		}
+	}
+
+	err = r.gatewayAdapter.ReconcileRouteOptions(ctx, cfRoute, routeKind)
 	if err != nil {
-		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileHTTPRoute")
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("ReconcileRouteOptions")
 	}
 
 	fqdn := buildFQDN(cfRoute, cfDomain)
 	cfRoute.Status.FQDN = fqdn
 	cfRoute.Status.URI = fqdn + cfRoute.Spec.Path
 
-	effectiveDestinations, err := r.buildEffectiveDestinations(ctx, cfRoute)
+	effectiveDestinations, err := r.buildEffectiveDestinations(ctx, cfRoute, cfDomain)
 	if err != nil {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("BuildEffectiveDestinations")
 	}
@@ -163,6 +194,19 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alp
 func (r *Reconciler) finalizeCFRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCRRoute")
 
+	if controllerutil.ContainsFinalizer(cfRoute, korifiv1alpha1.CFRouteSharedDestinationsFinalizerName) {
+		// services and reference grants in other namespaces cannot be owned
+		// by the route, so they have to be deleted explicitly
+		err := r.deleteSharedDestinationResources(ctx, cfRoute)
+		if err != nil {
+			return err
+		}
+
+		if controllerutil.RemoveFinalizer(cfRoute, korifiv1alpha1.CFRouteSharedDestinationsFinalizerName) {
+			log.V(1).Info("shared destinations finalizer removed")
+		}
+	}
+
 	if !controllerutil.ContainsFinalizer(cfRoute, korifiv1alpha1.CFRouteFinalizerName) {
 		return nil
 	}
@@ -174,6 +218,52 @@ func (r *Reconciler) finalizeCFRoute(ctx context.Context, cfRoute *korifiv1alpha
 	return nil
 }
 
+func (r *Reconciler) deleteSharedDestinationResources(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("deleteSharedDestinationResources")
+
+	routeLabels := client.MatchingLabels{korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name}
+
+	serviceList := &corev1.ServiceList{}
+	err := r.client.List(ctx, serviceList, routeLabels)
+	if err != nil {
+		log.Info("failed to list services", "reason", err)
+		return err
+	}
+
+	for i := range serviceList.Items {
+		if serviceList.Items[i].Namespace == cfRoute.Namespace || !isRouteResource(cfRoute, &serviceList.Items[i]) {
+			continue
+		}
+
+		err = r.client.Delete(ctx, &serviceList.Items[i])
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete service", "serviceName", serviceList.Items[i].Name, "reason", err)
+			return err
+		}
+	}
+
+	referenceGrantList := &gatewayv1beta1.ReferenceGrantList{}
+	err = r.client.List(ctx, referenceGrantList, routeLabels)
+	if err != nil {
+		log.Info("failed to list reference grants", "reason", err)
+		return err
+	}
+
+	for i := range referenceGrantList.Items {
+		if !isRouteResource(cfRoute, &referenceGrantList.Items[i]) {
+			continue
+		}
+
+		err = r.client.Delete(ctx, &referenceGrantList.Items[i])
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete reference grant", "referenceGrantName", referenceGrantList.Items[i].Name, "reason", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchServices")
 
@@ -188,24 +278,30 @@ func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv
 		service := &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      serviceName,
-				Namespace: cfRoute.Namespace,
+				Namespace: cfRoute.DestinationNamespace(destination),
 			},
 		}
 
 		result, err := controllerutil.CreateOrPatch(ctx, r.client, service, func() error {
 			service.Labels = map[string]string{
-				korifiv1alpha1.CFAppGUIDLabelKey:   destination.AppRef.Name,
-				korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
+				korifiv1alpha1.CFAppGUIDLabelKey:        destination.AppRef.Name,
+				korifiv1alpha1.CFRouteGUIDLabelKey:      cfRoute.Name,
+				korifiv1alpha1.CFRouteNamespaceLabelKey: cfRoute.Namespace,
 			}
 
-			err := controllerutil.SetControllerReference(cfRoute, service, r.scheme)
-			if err != nil {
-				loopLog.Info("failed to set OwnerRef on Service", "reason", err)
-				return err
+			// owner references cannot cross namespaces, services of shared
+			// destinations are cleaned up by the route finalizer instead
+			if !cfRoute.IsSharedDestination(destination) {
+				err := controllerutil.SetControllerReference(cfRoute, service, r.scheme)
+				if err != nil {
+					loopLog.Info("failed to set OwnerRef on Service", "reason", err)
+					return err
+				}
 			}
 
 			service.Spec.Ports = []corev1.ServicePort{{
-				Port: int32(*destination.Port),
+				Port:        int32(*destination.Port),
+				AppProtocol: appProtocol(destination),
 			}}
 
 			service.Spec.Selector = map[string]string{
@@ -221,23 +317,85 @@ func (r *Reconciler) createOrPatchServices(ctx context.Context, cfRoute *korifiv
 		}
 
 		log.V(1).Info("Service reconciled", "operation", result)
+
+		if cfRoute.IsSharedDestination(destination) {
+			err = r.createOrPatchReferenceGrant(ctx, cfRoute, destination)
+			if err != nil {
+				return fmt.Errorf("reference grant reconciliation failed for CFRoute/%s destinations: %w", cfRoute.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// createOrPatchReferenceGrant allows the gateway routes in the route
+// namespace to reference the service of a shared destination
+func (r *Reconciler) createOrPatchReferenceGrant(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, destination korifiv1alpha1.Destination) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchReferenceGrant")
+
+	serviceName := generateServiceName(destination)
+	referenceGrant := &gatewayv1beta1.ReferenceGrant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: cfRoute.DestinationNamespace(destination),
+		},
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, referenceGrant, func() error {
+		referenceGrant.Labels = map[string]string{
+			korifiv1alpha1.CFAppGUIDLabelKey:        destination.AppRef.Name,
+			korifiv1alpha1.CFRouteGUIDLabelKey:      cfRoute.Name,
+			korifiv1alpha1.CFRouteNamespaceLabelKey: cfRoute.Namespace,
+		}
+
+		referenceGrant.Spec.From = []gatewayv1beta1.ReferenceGrantFrom{
+			{
+				Group:     gatewayv1beta1.GroupName,
+				Kind:      "HTTPRoute",
+				Namespace: gatewayv1beta1.Namespace(cfRoute.Namespace),
+			},
+			{
+				Group:     gatewayv1beta1.GroupName,
+				Kind:      "GRPCRoute",
+				Namespace: gatewayv1beta1.Namespace(cfRoute.Namespace),
+			},
+		}
+		referenceGrant.Spec.To = []gatewayv1beta1.ReferenceGrantTo{{
+			Group: "",
+			Kind:  "Service",
+			Name:  tools.PtrTo(gatewayv1beta1.ObjectName(serviceName)),
+		}}
+
+		return nil
+	})
+	if err != nil {
+		log.Info("failed to create/patch ReferenceGrant", "reason", err)
+		return err
 	}
 
+	log.V(1).Info("ReferenceGrant reconciled", "operation", result)
 	return nil
 }
 
-func (r *Reconciler) buildEffectiveDestinations(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) ([]korifiv1alpha1.Destination, error) {
+func (r *Reconciler) buildEffectiveDestinations(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) ([]korifiv1alpha1.Destination, error) {
 	effectiveDestinations := []korifiv1alpha1.Destination{}
 
+	// gRPC is served over HTTP/2 only
+	defaultProtocol := korifiv1alpha1.DestinationProtocolHTTP1
+	if isGRPC(cfRoute, cfDomain) {
+		defaultProtocol = korifiv1alpha1.DestinationProtocolHTTP2
+	}
+
 	for _, dest := range cfRoute.Spec.Destinations {
 		effectiveDest := dest.DeepCopy()
 
 		if effectiveDest.Protocol == nil {
-			effectiveDest.Protocol = tools.PtrTo("http1")
+			effectiveDest.Protocol = tools.PtrTo(defaultProtocol)
 		}
 
 		if effectiveDest.Port == nil {
-			droplet, err := r.getAppCurrentDroplet(ctx, cfRoute.Namespace, dest.AppRef.Name)
+			droplet, err := r.getAppCurrentDroplet(ctx, cfRoute.DestinationNamespace(dest), dest.AppRef.Name)
 			if err != nil {
 				return []korifiv1alpha1.Destination{}, err
 			}
@@ -299,9 +457,20 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 		},
 	}
 
+	err := r.deleteRoute(ctx, &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	})
+	if err != nil {
+		log.Info("failed to delete existing GRPCRoute", "reason", err)
+		return err
+	}
+
 	if len(cfRoute.Status.Destinations) == 0 {
-		err := r.client.Delete(ctx, httpRoute)
-		if client.IgnoreNotFound(err) != nil {
+		err = r.deleteRoute(ctx, httpRoute)
+		if err != nil {
 			log.Info("failed to delete existing HTTPRoutes", "reason", err)
 			return err
 		}
@@ -321,7 +490,8 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 		}
 
 		httpRoute.Spec.Rules = []gatewayv1beta1.HTTPRouteRule{{
-			BackendRefs: toBackendRefs(cfRoute.Status.Destinations),
+			BackendRefs: toBackendRefs(cfRoute),
+			Timeouts:    toTimeouts(cfRoute.Spec.Options),
 		}}
 		if cfRoute.Spec.Path != "" {
 			httpRoute.Spec.Rules[0].Matches = []gatewayv1beta1.HTTPRouteMatch{{
@@ -343,6 +513,77 @@ func (r *Reconciler) reconcileHTTPRoute(ctx context.Context, cfRoute *korifiv1al
 	return nil
 }
 
+func (r *Reconciler) reconcileGRPCRoute(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
+	fqdn := buildFQDN(cfRoute, cfDomain)
+	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchGRPCRoute").WithValues("fqdn", fqdn)
+
+	grpcRoute := &gatewayv1.GRPCRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	}
+
+	err := r.deleteRoute(ctx, &gatewayv1beta1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRoute.Name,
+			Namespace: cfRoute.Namespace,
+		},
+	})
+	if err != nil {
+		log.Info("failed to delete existing HTTPRoute", "reason", err)
+		return err
+	}
+
+	if len(cfRoute.Status.Destinations) == 0 {
+		err = r.deleteRoute(ctx, grpcRoute)
+		if err != nil {
+			log.Info("failed to delete existing GRPCRoute", "reason", err)
+			return err
+		}
+		return nil
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, r.client, grpcRoute, func() error {
+		grpcRoute.Spec.ParentRefs = []gatewayv1.ParentReference{{
+			Group:     tools.PtrTo(gatewayv1.Group("gateway.networking.k8s.io")),
+			Kind:      tools.PtrTo(gatewayv1.Kind("Gateway")),
+			Namespace: tools.PtrTo(gatewayv1.Namespace(r.controllerConfig.Networking.GatewayNamespace)),
+			Name:      gatewayv1.ObjectName(r.controllerConfig.Networking.GatewayName),
+		}}
+
+		grpcRoute.Spec.Hostnames = []gatewayv1.Hostname{
+			gatewayv1.Hostname(fqdn),
+		}
+
+		// gRPC requests are matched on service and method rather than
+		// path, hence the route path is not taken into account
+		grpcRoute.Spec.Rules = []gatewayv1.GRPCRouteRule{{
+			BackendRefs: toGRPCBackendRefs(cfRoute),
+		}}
+
+		return controllerutil.SetControllerReference(cfRoute, grpcRoute, r.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create/patch GRPCRoute", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("GRPCRoute reconciled", "operation", result)
+	return nil
+}
+
+// deleteRoute tolerates missing route kinds as clusters with older Gateway
+// API installations may not have the GRPCRoute CRD
+func (r *Reconciler) deleteRoute(ctx context.Context, route client.Object) error {
+	err := r.client.Delete(ctx, route)
+	if meta.IsNoMatchError(err) {
+		return nil
+	}
+
+	return client.IgnoreNotFound(err)
+}
+
 func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("deleteOrphanedServices")
 
@@ -350,24 +591,18 @@ func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifi
 		korifiv1alpha1.CFRouteGUIDLabelKey: cfRoute.Name,
 	}
 
-	serviceList, err := r.fetchServicesByMatchingLabels(ctx, matchingLabelSet, cfRoute.Namespace)
+	// services of shared destinations live in the app namespaces, hence
+	// services are looked up across all namespaces
+	serviceList, err := r.fetchServicesByMatchingLabels(ctx, matchingLabelSet, "")
 	if err != nil {
 		log.Info("failed to fetch services using label", "label", korifiv1alpha1.CFRouteGUIDLabelKey, "value", cfRoute.Name, "reason", err)
 		return err
 	}
 
 	for i, service := range serviceList.Items {
-		loopLog := log.WithValues("serviceName", service.Name)
-
-		isOrphan := true
-		for _, destination := range cfRoute.Status.Destinations {
-			if service.Name == generateServiceName(destination) {
-				isOrphan = false
-				break
-			}
-		}
+		loopLog := log.WithValues("serviceName", service.Name, "serviceNamespace", service.Namespace)
 
-		if isOrphan {
+		if isRouteResource(cfRoute, &serviceList.Items[i]) && isOrphan(cfRoute, &serviceList.Items[i]) {
 			err = r.client.Delete(ctx, &serviceList.Items[i])
 			if err != nil {
 				loopLog.Info("failed to delete service", "reason", err)
@@ -376,9 +611,45 @@ func (r *Reconciler) deleteOrphanedServices(ctx context.Context, cfRoute *korifi
 		}
 	}
 
+	referenceGrantList := &gatewayv1beta1.ReferenceGrantList{}
+	err = r.client.List(ctx, referenceGrantList, client.MatchingLabels(matchingLabelSet))
+	if err != nil {
+		log.Info("failed to list reference grants", "reason", err)
+		return err
+	}
+
+	for i, referenceGrant := range referenceGrantList.Items {
+		if isRouteResource(cfRoute, &referenceGrantList.Items[i]) && isOrphan(cfRoute, &referenceGrantList.Items[i]) {
+			err = r.client.Delete(ctx, &referenceGrantList.Items[i])
+			if client.IgnoreNotFound(err) != nil {
+				log.Info("failed to delete reference grant", "referenceGrantName", referenceGrant.Name, "reason", err)
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
+// isOrphan returns true when none of the route destinations is served by the
+// given service or reference grant
+func isOrphan(cfRoute *korifiv1alpha1.CFRoute, obj client.Object) bool {
+	for _, destination := range cfRoute.Status.Destinations {
+		if obj.GetName() == generateServiceName(destination) && obj.GetNamespace() == cfRoute.DestinationNamespace(destination) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isRouteResource tells whether obj has been created for this very route,
+// as a route transferred to another space keeps its GUID and the resources
+// of both the old and the new route share the route GUID label
+func isRouteResource(cfRoute *korifiv1alpha1.CFRoute, obj client.Object) bool {
+	return metav1.IsControlledBy(obj, cfRoute) || obj.GetLabels()[korifiv1alpha1.CFRouteNamespaceLabelKey] == cfRoute.Namespace
+}
+
 func (r *Reconciler) fetchServicesByMatchingLabels(ctx context.Context, labelSet map[string]string, namespace string) (*corev1.ServiceList, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("fetchServicesByMatchingLabels")
 
@@ -406,20 +677,76 @@ func buildFQDN(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomai
 	return fmt.Sprintf("%s.%s", strings.ToLower(cfRoute.Spec.Host), cfDomain.Spec.Name)
 }
 
-func toBackendRefs(destinations []korifiv1alpha1.Destination) []gatewayv1beta1.HTTPBackendRef {
+func toTimeouts(options *korifiv1alpha1.RouteOptions) *gatewayv1.HTTPRouteTimeouts {
+	if options == nil || options.RequestTimeoutSeconds == nil {
+		return nil
+	}
+
+	return &gatewayv1.HTTPRouteTimeouts{
+		Request: tools.PtrTo(gatewayv1.Duration(fmt.Sprintf("%ds", *options.RequestTimeoutSeconds))),
+	}
+}
+
+func hasSharedDestinations(cfRoute *korifiv1alpha1.CFRoute) bool {
+	for _, destination := range cfRoute.Spec.Destinations {
+		if cfRoute.IsSharedDestination(destination) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isGRPC(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) bool {
+	return cfRoute.Spec.Protocol == korifiv1alpha1.RouteProtocolGRPC || cfDomain.Spec.Protocol == korifiv1alpha1.RouteProtocolGRPC
+}
+
+func appProtocol(destination korifiv1alpha1.Destination) *string {
+	if destination.Protocol != nil && *destination.Protocol == korifiv1alpha1.DestinationProtocolHTTP2 {
+		return tools.PtrTo("kubernetes.io/h2c")
+	}
+
+	return nil
+}
+
+func toBackendRefs(cfRoute *korifiv1alpha1.CFRoute) []gatewayv1beta1.HTTPBackendRef {
 	backendRefs := []gatewayv1beta1.HTTPBackendRef{}
 
-	for _, destination := range destinations {
+	for _, destination := range cfRoute.Status.Destinations {
 		backendRefs = append(backendRefs, gatewayv1beta1.HTTPBackendRef{
 			BackendRef: gatewayv1beta1.BackendRef{
-				BackendObjectReference: gatewayv1beta1.BackendObjectReference{
-					Kind: tools.PtrTo(gatewayv1beta1.Kind("Service")),
-					Name: gatewayv1beta1.ObjectName(generateServiceName(destination)),
-					Port: tools.PtrTo(gatewayv1beta1.PortNumber(*destination.Port)),
-				},
+				BackendObjectReference: toBackendObjectReference(cfRoute, destination),
 			},
 		})
 	}
 
 	return backendRefs
 }
+
+func toGRPCBackendRefs(cfRoute *korifiv1alpha1.CFRoute) []gatewayv1.GRPCBackendRef {
+	backendRefs := []gatewayv1.GRPCBackendRef{}
+
+	for _, destination := range cfRoute.Status.Destinations {
+		backendRefs = append(backendRefs, gatewayv1.GRPCBackendRef{
+			BackendRef: gatewayv1.BackendRef{
+				BackendObjectReference: toBackendObjectReference(cfRoute, destination),
+			},
+		})
+	}
+
+	return backendRefs
+}
+
+func toBackendObjectReference(cfRoute *korifiv1alpha1.CFRoute, destination korifiv1alpha1.Destination) gatewayv1.BackendObjectReference {
+	backendObjectReference := gatewayv1.BackendObjectReference{
+		Kind: tools.PtrTo(gatewayv1.Kind("Service")),
+		Name: gatewayv1.ObjectName(generateServiceName(destination)),
+		Port: tools.PtrTo(gatewayv1.PortNumber(*destination.Port)),
+	}
+
+	if cfRoute.IsSharedDestination(destination) {
+		backendObjectReference.Namespace = tools.PtrTo(gatewayv1.Namespace(cfRoute.DestinationNamespace(destination)))
+	}
+
+	return backendObjectReference
+}
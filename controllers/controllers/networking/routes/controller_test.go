@@ -85,6 +85,21 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 		return httpRoute
 	}
 
+	getGRPCRoute := func() *gatewayv1.GRPCRoute {
+		GinkgoHelper()
+
+		grpcRoute := &gatewayv1.GRPCRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cfRoute.Name,
+				Namespace: cfRoute.Namespace,
+			},
+		}
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(grpcRoute), grpcRoute)).To(Succeed())
+		}).Should(Succeed())
+		return grpcRoute
+	}
+
 	JustBeforeEach(func() {
 		Expect(adminClient.Create(ctx, cfRoute)).To(Succeed())
 	})
@@ -386,6 +401,271 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the route has options", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+					LoadBalancing:         "least-connection",
+					RequestTimeoutSeconds: tools.PtrTo[int32](30),
+				}
+			})
+
+			It("sets the request timeout on the HTTPRoute", func() {
+				httpRoute := getHTTPRoute()
+				Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+				Expect(httpRoute.Spec.Rules[0].Timeouts).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Request": PointTo(Equal(gatewayv1.Duration("30s"))),
+				})))
+			})
+
+			It("applies the options via the gateway adapter", func() {
+				Eventually(func(g Gomega) {
+					var routeKinds []string
+					for i := range gatewayAdapter.ReconcileRouteOptionsCallCount() {
+						_, actualRoute, routeKind := gatewayAdapter.ReconcileRouteOptionsArgsForCall(i)
+						if actualRoute.Name == cfRoute.Name {
+							g.Expect(actualRoute.Spec.Options).To(Equal(cfRoute.Spec.Options))
+							routeKinds = append(routeKinds, routeKind)
+						}
+					}
+					g.Expect(routeKinds).To(ContainElement("HTTPRoute"))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the destination protocol is http2", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Destinations[0].Protocol = tools.PtrTo("http2")
+			})
+
+			It("sets the h2c app protocol on the destination service", func() {
+				serviceName := fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+				Eventually(func(g Gomega) {
+					var svc corev1.Service
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: ns.Name}, &svc)).To(Succeed())
+					g.Expect(svc.Spec.Ports).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Port":        Equal(int32(80)),
+						"AppProtocol": PointTo(Equal("kubernetes.io/h2c")),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the route protocol is grpc", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Protocol = "grpc"
+			})
+
+			It("creates a GRPCRoute", func() {
+				grpcRoute := getGRPCRoute()
+
+				Expect(grpcRoute.Spec.ParentRefs).To(ConsistOf(gatewayv1.ParentReference{
+					Group:     tools.PtrTo(gatewayv1.Group("gateway.networking.k8s.io")),
+					Kind:      tools.PtrTo(gatewayv1.Kind("Gateway")),
+					Namespace: tools.PtrTo(gatewayv1.Namespace("korifi-gateway")),
+					Name:      gatewayv1.ObjectName("korifi"),
+				}))
+				Expect(grpcRoute.Spec.Hostnames).To(ConsistOf(gatewayv1.Hostname(getCfRouteFQDN())))
+				Expect(grpcRoute.Spec.Rules).To(HaveLen(1))
+				Expect(grpcRoute.Spec.Rules[0].BackendRefs).To(HaveLen(1))
+				Expect(grpcRoute.Spec.Rules[0].BackendRefs[0].BackendRef.BackendObjectReference).To(Equal(gatewayv1.BackendObjectReference{
+					Group: tools.PtrTo(gatewayv1.Group("")),
+					Kind:  tools.PtrTo(gatewayv1.Kind("Service")),
+					Name:  gatewayv1.ObjectName(fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)),
+					Port:  tools.PtrTo(gatewayv1.PortNumber(80)),
+				}))
+				Expect(grpcRoute.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind": Equal("CFRoute"),
+					"Name": Equal(cfRoute.Name),
+				})))
+			})
+
+			It("does not create an HTTPRoute", func() {
+				Consistently(func(g Gomega) {
+					httpRoutes := &gatewayv1beta1.HTTPRouteList{}
+					g.Expect(adminClient.List(ctx, httpRoutes, client.InNamespace(ns.Name))).To(Succeed())
+					g.Expect(httpRoutes.Items).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			It("defaults the destination protocol to http2", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					g.Expect(cfRoute.Status.Destinations).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Protocol": PointTo(Equal("http2")),
+					})))
+				}).Should(Succeed())
+			})
+
+			When("the route protocol is changed back to http", func() {
+				JustBeforeEach(func() {
+					getGRPCRoute()
+					Expect(k8s.PatchResource(ctx, adminClient, cfRoute, func() {
+						cfRoute.Spec.Protocol = "http"
+					})).To(Succeed())
+				})
+
+				It("replaces the GRPCRoute with an HTTPRoute", func() {
+					getHTTPRoute()
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), &gatewayv1.GRPCRoute{})
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the domain protocol is grpc", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfDomain, func() {
+					cfDomain.Spec.Protocol = "grpc"
+				})).To(Succeed())
+			})
+
+			It("creates a GRPCRoute", func() {
+				grpcRoute := getGRPCRoute()
+				Expect(grpcRoute.Spec.Hostnames).To(ConsistOf(gatewayv1.Hostname(getCfRouteFQDN())))
+			})
+		})
+
+		When("the destination app is in a space the route is shared with", func() {
+			var (
+				sharedNs    *corev1.Namespace
+				sharedApp   *korifiv1alpha1.CFApp
+				serviceName string
+			)
+
+			BeforeEach(func() {
+				sharedNs = &corev1.Namespace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: uuid.NewString(),
+					},
+				}
+				Expect(adminClient.Create(ctx, sharedNs)).To(Succeed())
+
+				sharedApp = &korifiv1alpha1.CFApp{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: sharedNs.Name,
+						Name:      uuid.NewString(),
+					},
+					Spec: korifiv1alpha1.CFAppSpec{
+						Lifecycle: korifiv1alpha1.Lifecycle{
+							Type: "buildpack",
+						},
+						DesiredState: "STARTED",
+						DisplayName:  uuid.NewString(),
+					},
+				}
+				Expect(adminClient.Create(ctx, sharedApp)).To(Succeed())
+
+				cfRoute.Spec.SharedSpaces = []string{sharedNs.Name}
+				cfRoute.Spec.Destinations[0].AppRef.Name = sharedApp.Name
+				cfRoute.Spec.Destinations[0].AppNamespace = sharedNs.Name
+				serviceName = fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID)
+			})
+
+			It("creates the destination service in the app namespace", func() {
+				Eventually(func(g Gomega) {
+					svc := new(corev1.Service)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: sharedNs.Name}, svc)).To(Succeed())
+					g.Expect(svc.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFRouteGUIDLabelKey, cfRoute.Name))
+					g.Expect(svc.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFRouteNamespaceLabelKey, ns.Name))
+					g.Expect(svc.OwnerReferences).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			It("grants the route namespace access to the destination service", func() {
+				Eventually(func(g Gomega) {
+					referenceGrant := new(gatewayv1beta1.ReferenceGrant)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: sharedNs.Name}, referenceGrant)).To(Succeed())
+					g.Expect(referenceGrant.Spec.From).To(ContainElement(gatewayv1beta1.ReferenceGrantFrom{
+						Group:     gatewayv1beta1.GroupName,
+						Kind:      "HTTPRoute",
+						Namespace: gatewayv1beta1.Namespace(ns.Name),
+					}))
+					g.Expect(referenceGrant.Spec.To).To(ConsistOf(gatewayv1beta1.ReferenceGrantTo{
+						Kind: "Service",
+						Name: tools.PtrTo(gatewayv1beta1.ObjectName(serviceName)),
+					}))
+				}).Should(Succeed())
+			})
+
+			It("references the service namespace in the HTTPRoute backend ref", func() {
+				Eventually(func(g Gomega) {
+					httpRoute := getHTTPRoute()
+					g.Expect(httpRoute.Spec.Rules).To(HaveLen(1))
+					g.Expect(httpRoute.Spec.Rules[0].BackendRefs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"BackendRef": MatchFields(IgnoreExtras, Fields{
+							"BackendObjectReference": MatchFields(IgnoreExtras, Fields{
+								"Name":      BeEquivalentTo(serviceName),
+								"Namespace": PointTo(BeEquivalentTo(sharedNs.Name)),
+							}),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("adds the shared destinations finalizer", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)).To(Succeed())
+					g.Expect(cfRoute.Finalizers).To(ContainElement(korifiv1alpha1.CFRouteSharedDestinationsFinalizerName))
+				}).Should(Succeed())
+			})
+
+			When("the route is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: sharedNs.Name}, new(corev1.Service))).To(Succeed())
+					}).Should(Succeed())
+					Expect(adminClient.Delete(ctx, cfRoute)).To(Succeed())
+				})
+
+				It("deletes the service and the reference grant in the app namespace", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: sharedNs.Name}, new(corev1.Service))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+						err = adminClient.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: sharedNs.Name}, new(gatewayv1beta1.ReferenceGrant))
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+
+						err = adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)
+						g.Expect(errors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+
+				When("a route with the same guid in another space has a service in the app namespace", func() {
+					var otherService *corev1.Service
+
+					BeforeEach(func() {
+						otherService = &corev1.Service{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: sharedNs.Name,
+								Name:      uuid.NewString(),
+								Labels: map[string]string{
+									korifiv1alpha1.CFRouteGUIDLabelKey:      cfRoute.Name,
+									korifiv1alpha1.CFRouteNamespaceLabelKey: uuid.NewString(),
+								},
+							},
+							Spec: corev1.ServiceSpec{
+								Ports: []corev1.ServicePort{{Port: 8080}},
+							},
+						}
+						Expect(adminClient.Create(ctx, otherService)).To(Succeed())
+					})
+
+					It("does not delete it", func() {
+						Eventually(func(g Gomega) {
+							err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfRoute), cfRoute)
+							g.Expect(errors.IsNotFound(err)).To(BeTrue())
+						}).Should(Succeed())
+
+						Consistently(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(otherService), new(corev1.Service))).To(Succeed())
+						}).Should(Succeed())
+					})
+				})
+			})
+		})
+
 		When("the destinations are deleted from the route", func() {
 			var (
 				httpRoute   *gatewayv1beta1.HTTPRoute
@@ -0,0 +1,38 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	StandardAdapterName     = "standard"
+	EnvoyGatewayAdapterName = "envoy-gateway"
+)
+
+//counterfeiter:generate -o fake -fake-name Adapter . Adapter
+
+// Adapter translates the CFRoute options that cannot be expressed via the
+// Gateway API (e.g. the load-balancing algorithm) into policies specific to
+// the gateway implementation. routeKind is the kind of the Gateway API route
+// generated for the CFRoute, i.e. HTTPRoute or GRPCRoute
+type Adapter interface {
+	ReconcileRouteOptions(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, routeKind string) error
+}
+
+// NewAdapter returns the adapter with the given name. An empty name selects
+// the standard adapter
+func NewAdapter(name string, k8sClient client.Client, scheme *runtime.Scheme) (Adapter, error) {
+	switch name {
+	case "", StandardAdapterName:
+		return NewStandardAdapter(), nil
+	case EnvoyGatewayAdapterName:
+		return NewEnvoyGatewayAdapter(k8sClient, scheme), nil
+	default:
+		return nil, fmt.Errorf("unsupported gateway adapter %q", name)
+	}
+}
@@ -0,0 +1,25 @@
+package gateway_test
+
+import (
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/gateway"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = DescribeTable("NewAdapter",
+	func(name string, matchAdapter OmegaMatcher) {
+		adapter, err := gateway.NewAdapter(name, new(controllerfake.Client), runtime.NewScheme())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(adapter).To(matchAdapter)
+	},
+	Entry("default", "", BeAssignableToTypeOf(&gateway.StandardAdapter{})),
+	Entry("standard", "standard", BeAssignableToTypeOf(&gateway.StandardAdapter{})),
+	Entry("envoy-gateway", "envoy-gateway", BeAssignableToTypeOf(&gateway.EnvoyGatewayAdapter{})),
+)
+
+var _ = It("fails to create an unknown adapter", func() {
+	_, err := gateway.NewAdapter("unknown", new(controllerfake.Client), runtime.NewScheme())
+	Expect(err).To(MatchError(ContainSubstring(`unsupported gateway adapter "unknown"`)))
+})
@@ -0,0 +1,116 @@
+package gateway
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// sessionAffinityCookieName matches the sticky session cookie of the CF
+// gorouter so that apps migrating to Korifi keep working
+const sessionAffinityCookieName = "__VCAP_ID__"
+
+var backendTrafficPolicyGVK = schema.GroupVersionKind{
+	Group:   "gateway.envoyproxy.io",
+	Version: "v1alpha1",
+	Kind:    "BackendTrafficPolicy",
+}
+
+//+kubebuilder:rbac:groups=gateway.envoyproxy.io,resources=backendtrafficpolicies,verbs=get;list;watch;create;update;patch;delete
+
+// EnvoyGatewayAdapter configures load-balancing and session affinity via
+// Envoy Gateway BackendTrafficPolicy objects targeting the generated routes.
+// The policies are owned by the CFRoute, so they are deleted along with it
+type EnvoyGatewayAdapter struct {
+	client client.Client
+	scheme *runtime.Scheme
+}
+
+func NewEnvoyGatewayAdapter(k8sClient client.Client, scheme *runtime.Scheme) *EnvoyGatewayAdapter {
+	return &EnvoyGatewayAdapter{
+		client: k8sClient,
+		scheme: scheme,
+	}
+}
+
+func (a *EnvoyGatewayAdapter) ReconcileRouteOptions(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, routeKind string) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("envoy-gateway-adapter")
+
+	policy := &unstructured.Unstructured{}
+	policy.SetGroupVersionKind(backendTrafficPolicyGVK)
+	policy.SetNamespace(cfRoute.Namespace)
+	policy.SetName(cfRoute.Name)
+
+	loadBalancer := toLoadBalancer(cfRoute.Spec.Options)
+	if loadBalancer == nil {
+		return client.IgnoreNotFound(a.client.Delete(ctx, policy))
+	}
+
+	result, err := controllerutil.CreateOrPatch(ctx, a.client, policy, func() error {
+		policy.Object["spec"] = map[string]any{
+			"targetRefs": []any{
+				map[string]any{
+					"group": "gateway.networking.k8s.io",
+					"kind":  routeKind,
+					"name":  cfRoute.Name,
+				},
+			},
+			"loadBalancer": loadBalancer,
+		}
+
+		return controllerutil.SetControllerReference(cfRoute, policy, a.scheme)
+	})
+	if err != nil {
+		log.Info("failed to create/patch BackendTrafficPolicy", "reason", err)
+		return err
+	}
+
+	log.V(1).Info("BackendTrafficPolicy reconciled", "operation", result)
+	return nil
+}
+
+func toLoadBalancer(options *korifiv1alpha1.RouteOptions) map[string]any {
+	if options == nil {
+		return nil
+	}
+
+	if options.SessionAffinity {
+		return map[string]any{
+			"type": "ConsistentHash",
+			"consistentHash": map[string]any{
+				"type": "Cookie",
+				"cookie": map[string]any{
+					"name": sessionAffinityCookieName,
+					// a zero TTL makes Envoy generate a session cookie
+					// when the request does not have one
+					"ttl": "0s",
+				},
+			},
+		}
+	}
+
+	switch options.LoadBalancing {
+	case korifiv1alpha1.LoadBalancingRoundRobin:
+		return map[string]any{"type": "RoundRobin"}
+	case korifiv1alpha1.LoadBalancingLeastConnection:
+		return map[string]any{"type": "LeastRequest"}
+	case korifiv1alpha1.LoadBalancingHash:
+		return map[string]any{
+			"type": "ConsistentHash",
+			"consistentHash": map[string]any{
+				"type": "Header",
+				"header": map[string]any{
+					"name": options.HashHeader,
+				},
+			},
+		}
+	}
+
+	return nil
+}
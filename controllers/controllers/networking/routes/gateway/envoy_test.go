@@ -0,0 +1,167 @@
+package gateway_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/gateway"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var _ = Describe("EnvoyGatewayAdapter", func() {
+	var (
+		fakeClient *controllerfake.Client
+		adapter    *gateway.EnvoyGatewayAdapter
+		cfRoute    *korifiv1alpha1.CFRoute
+		reconErr   error
+	)
+
+	BeforeEach(func() {
+		fakeClient = new(controllerfake.Client)
+		fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "policy"))
+
+		scheme := runtime.NewScheme()
+		Expect(korifiv1alpha1.AddToScheme(scheme)).To(Succeed())
+		adapter = gateway.NewEnvoyGatewayAdapter(fakeClient, scheme)
+
+		cfRoute = &korifiv1alpha1.CFRoute{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "route-guid",
+				Namespace: "space-guid",
+			},
+			Spec: korifiv1alpha1.CFRouteSpec{
+				Options: &korifiv1alpha1.RouteOptions{
+					LoadBalancing: "least-connection",
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		reconErr = adapter.ReconcileRouteOptions(context.Background(), cfRoute, "HTTPRoute")
+	})
+
+	createdPolicy := func() *unstructured.Unstructured {
+		GinkgoHelper()
+
+		Expect(fakeClient.CreateCallCount()).To(Equal(1))
+		_, obj, _ := fakeClient.CreateArgsForCall(0)
+		policy, ok := obj.(*unstructured.Unstructured)
+		Expect(ok).To(BeTrue())
+		return policy
+	}
+
+	It("creates a BackendTrafficPolicy targeting the route", func() {
+		Expect(reconErr).NotTo(HaveOccurred())
+
+		policy := createdPolicy()
+		Expect(policy.GetAPIVersion()).To(Equal("gateway.envoyproxy.io/v1alpha1"))
+		Expect(policy.GetKind()).To(Equal("BackendTrafficPolicy"))
+		Expect(policy.GetNamespace()).To(Equal("space-guid"))
+		Expect(policy.GetName()).To(Equal("route-guid"))
+		Expect(policy.GetOwnerReferences()).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+			"Kind":       Equal("CFRoute"),
+			"Name":       Equal("route-guid"),
+			"Controller": PointTo(BeTrue()),
+		})))
+		Expect(policy.Object["spec"]).To(Equal(map[string]any{
+			"targetRefs": []any{
+				map[string]any{
+					"group": "gateway.networking.k8s.io",
+					"kind":  "HTTPRoute",
+					"name":  "route-guid",
+				},
+			},
+			"loadBalancer": map[string]any{"type": "LeastRequest"},
+		}))
+	})
+
+	When("the load-balancing algorithm is hash", func() {
+		BeforeEach(func() {
+			cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+				LoadBalancing: "hash",
+				HashHeader:    "X-Tenant",
+			}
+		})
+
+		It("configures consistent hashing on the header", func() {
+			Expect(createdPolicy().Object["spec"]).To(HaveKeyWithValue("loadBalancer", map[string]any{
+				"type": "ConsistentHash",
+				"consistentHash": map[string]any{
+					"type": "Header",
+					"header": map[string]any{
+						"name": "X-Tenant",
+					},
+				},
+			}))
+		})
+	})
+
+	When("session affinity is enabled", func() {
+		BeforeEach(func() {
+			cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+				SessionAffinity: true,
+			}
+		})
+
+		It("configures consistent hashing on the session cookie", func() {
+			Expect(createdPolicy().Object["spec"]).To(HaveKeyWithValue("loadBalancer", map[string]any{
+				"type": "ConsistentHash",
+				"consistentHash": map[string]any{
+					"type": "Cookie",
+					"cookie": map[string]any{
+						"name": "__VCAP_ID__",
+						"ttl":  "0s",
+					},
+				},
+			}))
+		})
+	})
+
+	When("the route has no load-balancing options", func() {
+		BeforeEach(func() {
+			cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+				RequestTimeoutSeconds: tools.PtrTo[int32](10),
+			}
+		})
+
+		It("deletes the policy", func() {
+			Expect(reconErr).NotTo(HaveOccurred())
+			Expect(fakeClient.CreateCallCount()).To(BeZero())
+			Expect(fakeClient.DeleteCallCount()).To(Equal(1))
+			_, obj, _ := fakeClient.DeleteArgsForCall(0)
+			Expect(obj.GetName()).To(Equal("route-guid"))
+			Expect(obj.GetNamespace()).To(Equal("space-guid"))
+		})
+
+		When("the policy does not exist", func() {
+			BeforeEach(func() {
+				fakeClient.DeleteReturns(k8serrors.NewNotFound(schema.GroupResource{}, "policy"))
+			})
+
+			It("succeeds", func() {
+				Expect(reconErr).NotTo(HaveOccurred())
+			})
+		})
+	})
+
+	When("creating the policy fails", func() {
+		BeforeEach(func() {
+			fakeClient.CreateReturns(errors.New("create-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(reconErr).To(MatchError(ContainSubstring("create-err")))
+		})
+	})
+})
@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/gateway"
+)
+
+type Adapter struct {
+	ReconcileRouteOptionsStub        func(context.Context, *v1alpha1.CFRoute, string) error
+	reconcileRouteOptionsMutex       sync.RWMutex
+	reconcileRouteOptionsArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFRoute
+		arg3 string
+	}
+	reconcileRouteOptionsReturns struct {
+		result1 error
+	}
+	reconcileRouteOptionsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *Adapter) ReconcileRouteOptions(arg1 context.Context, arg2 *v1alpha1.CFRoute, arg3 string) error {
+	fake.reconcileRouteOptionsMutex.Lock()
+	ret, specificReturn := fake.reconcileRouteOptionsReturnsOnCall[len(fake.reconcileRouteOptionsArgsForCall)]
+	fake.reconcileRouteOptionsArgsForCall = append(fake.reconcileRouteOptionsArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFRoute
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ReconcileRouteOptionsStub
+	fakeReturns := fake.reconcileRouteOptionsReturns
+	fake.recordInvocation("ReconcileRouteOptions", []interface{}{arg1, arg2, arg3})
+	fake.reconcileRouteOptionsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *Adapter) ReconcileRouteOptionsCallCount() int {
+	fake.reconcileRouteOptionsMutex.RLock()
+	defer fake.reconcileRouteOptionsMutex.RUnlock()
+	return len(fake.reconcileRouteOptionsArgsForCall)
+}
+
+func (fake *Adapter) ReconcileRouteOptionsCalls(stub func(context.Context, *v1alpha1.CFRoute, string) error) {
+	fake.reconcileRouteOptionsMutex.Lock()
+	defer fake.reconcileRouteOptionsMutex.Unlock()
+	fake.ReconcileRouteOptionsStub = stub
+}
+
+func (fake *Adapter) ReconcileRouteOptionsArgsForCall(i int) (context.Context, *v1alpha1.CFRoute, string) {
+	fake.reconcileRouteOptionsMutex.RLock()
+	defer fake.reconcileRouteOptionsMutex.RUnlock()
+	argsForCall := fake.reconcileRouteOptionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *Adapter) ReconcileRouteOptionsReturns(result1 error) {
+	fake.reconcileRouteOptionsMutex.Lock()
+	defer fake.reconcileRouteOptionsMutex.Unlock()
+	fake.ReconcileRouteOptionsStub = nil
+	fake.reconcileRouteOptionsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Adapter) ReconcileRouteOptionsReturnsOnCall(i int, result1 error) {
+	fake.reconcileRouteOptionsMutex.Lock()
+	defer fake.reconcileRouteOptionsMutex.Unlock()
+	fake.ReconcileRouteOptionsStub = nil
+	if fake.reconcileRouteOptionsReturnsOnCall == nil {
+		fake.reconcileRouteOptionsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.reconcileRouteOptionsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *Adapter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.reconcileRouteOptionsMutex.RLock()
+	defer fake.reconcileRouteOptionsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *Adapter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gateway.Adapter = new(Adapter)
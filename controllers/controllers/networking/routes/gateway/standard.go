@@ -0,0 +1,32 @@
+package gateway
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/go-logr/logr"
+)
+
+// StandardAdapter is used with gateway implementations Korifi has no
+// specific knowledge of. Only the route options supported by the Gateway API
+// itself take effect
+type StandardAdapter struct{}
+
+func NewStandardAdapter() *StandardAdapter {
+	return &StandardAdapter{}
+}
+
+func (a *StandardAdapter) ReconcileRouteOptions(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, routeKind string) error {
+	options := cfRoute.Spec.Options
+	if options == nil || (options.LoadBalancing == "" && !options.SessionAffinity) {
+		return nil
+	}
+
+	logr.FromContextOrDiscard(ctx).Info(
+		"load-balancing and session affinity route options are not supported by the standard gateway adapter",
+		"loadBalancing", options.LoadBalancing,
+		"sessionAffinity", options.SessionAffinity,
+	)
+
+	return nil
+}
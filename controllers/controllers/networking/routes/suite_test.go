@@ -9,6 +9,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/gateway/fake"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
@@ -20,6 +21,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	//+kubebuilder:scaffold:imports
 )
@@ -30,6 +32,7 @@ var (
 	testEnv         *envtest.Environment
 	adminClient     client.Client
 	ctx             context.Context
+	gatewayAdapter  *fake.Adapter
 )
 
 func TestNetworkingControllers(t *testing.T) {
@@ -58,6 +61,7 @@ var _ = BeforeSuite(func() {
 	Expect(err).NotTo(HaveOccurred())
 
 	Expect(korifiv1alpha1.AddToScheme(scheme.Scheme)).To(Succeed())
+	Expect(gatewayv1.Install(scheme.Scheme)).To(Succeed())
 	Expect(gatewayv1beta1.Install(scheme.Scheme)).To(Succeed())
 
 	k8sManager := helpers.NewK8sManager(testEnv, filepath.Join("helm", "korifi", "controllers", "role.yaml"))
@@ -65,6 +69,7 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	gatewayAdapter = new(fake.Adapter)
 	Expect(routes.NewReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
@@ -79,6 +84,7 @@ var _ = BeforeSuite(func() {
 				GatewayNamespace: "korifi-gateway",
 			},
 		},
+		gatewayAdapter,
 	).SetupWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
@@ -21,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -142,6 +144,28 @@ var _ = Describe("CFServiceBinding", func() {
 			}).Should(Succeed())
 		})
 
+		It("does not set the syslog drain url", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+				g.Expect(binding.Status.SyslogDrainURL).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		When("the service instance has a syslog drain url", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, instance, func() {
+					instance.Spec.SyslogDrainURL = tools.PtrTo("syslog-tls://logs.example.com:6514")
+				})).To(Succeed())
+			})
+
+			It("sets the syslog drain url in the binding status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.SyslogDrainURL).To(Equal("syslog-tls://logs.example.com:6514"))
+				}).Should(Succeed())
+			})
+		})
+
 		It("creates the mount secret", func() {
 			Eventually(func(g Gomega) {
 				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
@@ -435,6 +459,9 @@ var _ = Describe("CFServiceBinding", func() {
 						BindResource: osbapi.BindResource{
 							AppGUID: cfAppGUID,
 						},
+						Context: osbapi.PlatformContext{
+							Platform: "cloudfoundry",
+						},
 					},
 				}))
 			}).Should(Succeed())
@@ -598,6 +625,217 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 
+		When("the broker returns volume mounts", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, serviceOffering, func() {
+					serviceOffering.Spec.Requires = []string{"volume_mount"}
+				})).To(Succeed())
+
+				Expect(adminClient.Create(ctx, &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: testNamespace,
+						Name:      "my-claim",
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: resource.MustParse("1Gi"),
+							},
+						},
+					},
+				})).To(Succeed())
+
+				brokerClient.BindReturns(osbapi.BindResponse{
+					Credentials: map[string]any{},
+					VolumeMounts: []osbapi.VolumeMount{
+						{
+							Driver:       "some-driver",
+							ContainerDir: "/data/pvc",
+							Mode:         "r",
+							DeviceType:   "shared",
+							Device: osbapi.Device{
+								VolumeID: "my-claim",
+							},
+						},
+						{
+							Driver:       "nfs.csi.k8s.io",
+							ContainerDir: "/data/nfs",
+							Mode:         "rw",
+							DeviceType:   "shared",
+							Device: osbapi.Device{
+								VolumeID: "nfs-volume",
+								MountConfig: map[string]any{
+									"server": "nfs.example.com",
+									"uid":    1000,
+								},
+							},
+						},
+					},
+				}, nil)
+			})
+
+			It("records the volume mounts resolved to volumes", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.VolumeMounts).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"Driver":       Equal("some-driver"),
+							"ContainerDir": Equal("/data/pvc"),
+							"Mode":         Equal("r"),
+							"DeviceType":   Equal("shared"),
+							"Device": MatchFields(IgnoreExtras, Fields{
+								"VolumeID": Equal("my-claim"),
+							}),
+							"PersistentVolumeClaim": PointTo(Equal(corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "my-claim",
+								ReadOnly:  true,
+							})),
+							"CSI": BeNil(),
+						}),
+						MatchFields(IgnoreExtras, Fields{
+							"Driver":       Equal("nfs.csi.k8s.io"),
+							"ContainerDir": Equal("/data/nfs"),
+							"Mode":         Equal("rw"),
+							"Device": MatchFields(IgnoreExtras, Fields{
+								"VolumeID": Equal("nfs-volume"),
+								"MountConfig": PointTo(MatchFields(IgnoreExtras, Fields{
+									"Raw": MatchJSON(`{"server": "nfs.example.com", "uid": 1000}`),
+								})),
+							}),
+							"PersistentVolumeClaim": BeNil(),
+							"CSI": PointTo(Equal(corev1.CSIVolumeSource{
+								Driver:   "nfs.csi.k8s.io",
+								ReadOnly: tools.PtrTo(false),
+								VolumeAttributes: map[string]string{
+									"server": "nfs.example.com",
+									"uid":    "1000",
+								},
+							})),
+						}),
+					))
+				}).Should(Succeed())
+			})
+
+			When("the service offering does not require volume mounts", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, serviceOffering, func() {
+						serviceOffering.Spec.Requires = nil
+					})).To(Succeed())
+				})
+
+				It("fails the binding", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+							HasReason(Equal("VolumeMountsNotSupported")),
+						)))
+						g.Expect(brokerClient.UnbindCallCount()).NotTo(BeZero())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.OrphanMitigationCondition)),
+							HasReason(Equal("OrphanMitigated")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+
+			Describe("invalid volume mounts", func() {
+				var (
+					volumeMount    osbapi.VolumeMount
+					expectedReason string
+				)
+
+				BeforeEach(func() {
+					volumeMount = osbapi.VolumeMount{
+						Driver:       "nfs.csi.k8s.io",
+						ContainerDir: "/data/nfs",
+						Mode:         "rw",
+						DeviceType:   "shared",
+						Device: osbapi.Device{
+							VolumeID: "nfs-volume",
+						},
+					}
+					expectedReason = "InvalidVolumeMounts"
+				})
+
+				JustBeforeEach(func() {
+					brokerClient.BindReturns(osbapi.BindResponse{
+						Credentials:  map[string]any{},
+						VolumeMounts: []osbapi.VolumeMount{volumeMount},
+					}, nil)
+				})
+
+				itFailsTheBinding := func() {
+					It("fails the binding and unbinds it", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+							g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+								HasReason(Equal(expectedReason)),
+							)))
+							g.Expect(binding.Status.VolumeMounts).To(BeEmpty())
+							g.Expect(brokerClient.UnbindCallCount()).NotTo(BeZero())
+							g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.OrphanMitigationCondition)),
+								HasReason(Equal("OrphanMitigated")),
+							)))
+						}).Should(Succeed())
+					})
+				}
+
+				When("the volume mount driver is not allowed", func() {
+					BeforeEach(func() {
+						volumeMount.Driver = "hostpath.csi.k8s.io"
+						expectedReason = "VolumeMountDriverNotAllowed"
+					})
+
+					itFailsTheBinding()
+				})
+
+				When("the container directory is not absolute", func() {
+					BeforeEach(func() {
+						volumeMount.ContainerDir = "data/nfs"
+					})
+
+					itFailsTheBinding()
+				})
+
+				When("the device type is not shared", func() {
+					BeforeEach(func() {
+						volumeMount.DeviceType = "dedicated"
+					})
+
+					itFailsTheBinding()
+				})
+			})
+
+			When("two volume mounts use the same container directory", func() {
+				BeforeEach(func() {
+					brokerClient.BindReturns(osbapi.BindResponse{
+						Credentials: map[string]any{},
+						VolumeMounts: []osbapi.VolumeMount{
+							{Driver: "nfs.csi.k8s.io", ContainerDir: "/data", Mode: "r", DeviceType: "shared"},
+							{Driver: "nfs.csi.k8s.io", ContainerDir: "/data/", Mode: "r", DeviceType: "shared"},
+						},
+					}, nil)
+				})
+
+				It("fails the binding", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+							HasReason(Equal("InvalidVolumeMounts")),
+						)))
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("the binding credentials have been reconciled", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, binding, func() {
@@ -635,6 +873,216 @@ var _ = Describe("CFServiceBinding", func() {
 			})
 		})
 
+		When("the broker returns unparsable binding metadata", func() {
+			BeforeEach(func() {
+				brokerClient.BindReturns(osbapi.BindResponse{
+					Credentials: map[string]any{},
+					Metadata: osbapi.BindingMetadata{
+						ExpiresAt: "next tuesday",
+					},
+				}, nil)
+			})
+
+			It("fails the binding", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+						HasReason(Equal("InvalidBindingMetadata")),
+					)))
+					g.Expect(binding.Status.ExpiresAt).To(BeNil())
+					g.Expect(brokerClient.UnbindCallCount()).NotTo(BeZero())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the broker returns binding metadata", func() {
+			BeforeEach(func() {
+				brokerClient.BindReturns(osbapi.BindResponse{
+					Credentials: map[string]any{
+						"foo": "bar",
+					},
+					Metadata: osbapi.BindingMetadata{
+						ExpiresAt:   "2124-10-01T12:00:00Z",
+						RenewBefore: "2124-09-30T12:00:00Z",
+					},
+				}, nil)
+			})
+
+			It("records the expiry in the binding status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.ExpiresAt).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Time": BeTemporally("==", time.Date(2124, 10, 1, 12, 0, 0, 0, time.UTC)),
+					})))
+					g.Expect(binding.Status.RenewBefore).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Time": BeTemporally("==", time.Date(2124, 9, 30, 12, 0, 0, 0, time.UTC)),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("does not rotate the binding", func() {
+				Consistently(func(g Gomega) {
+					successor := &korifiv1alpha1.CFServiceBinding{}
+					err := adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: tools.NamespacedUUID(binding.Name, "successor")}, successor)
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			When("the binding is due for renewal", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+						servicePlan.Spec.BrokerCatalog.Features.BindingRotatable = true
+					})).To(Succeed())
+
+					brokerClient.BindStub = func(_ context.Context, payload osbapi.BindPayload) (osbapi.BindResponse, error) {
+						if payload.BindingID != binding.Name {
+							return osbapi.BindResponse{}, nil
+						}
+
+						return osbapi.BindResponse{
+							Metadata: osbapi.BindingMetadata{
+								RenewBefore: "2024-09-30T12:00:00Z",
+							},
+						}, nil
+					}
+				})
+
+				It("creates a successor binding", func() {
+					Eventually(func(g Gomega) {
+						successor := &korifiv1alpha1.CFServiceBinding{}
+						g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: tools.NamespacedUUID(binding.Name, "successor")}, successor)).To(Succeed())
+						g.Expect(successor.Spec.Service.Name).To(Equal(instance.Name))
+						g.Expect(successor.Spec.AppRef.Name).To(Equal(cfAppGUID))
+						g.Expect(successor.Spec.Type).To(Equal(korifiv1alpha1.CFServiceBindingTypeApp))
+					}).Should(Succeed())
+				})
+
+				It("replaces the binding with its successor", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the binding rotates a previous binding", func() {
+			var (
+				successor        *korifiv1alpha1.CFServiceBinding
+				bindingRotatable bool
+			)
+
+			BeforeEach(func() {
+				bindingRotatable = true
+			})
+
+			JustBeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+					servicePlan.Spec.BrokerCatalog.Features.BindingRotatable = bindingRotatable
+				})).To(Succeed())
+
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Status.MountSecretRef.Name).NotTo(BeEmpty())
+				}).Should(Succeed())
+
+				successor = &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: testNamespace,
+						Finalizers: []string{
+							korifiv1alpha1.CFServiceBindingFinalizerName,
+						},
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						Service:         binding.Spec.Service,
+						AppRef:          binding.Spec.AppRef,
+						Type:            korifiv1alpha1.CFServiceBindingTypeApp,
+						PreviousBinding: corev1.LocalObjectReference{Name: binding.Name},
+					},
+				}
+				Expect(adminClient.Create(ctx, successor)).To(Succeed())
+			})
+
+			It("sends the previous binding id to the broker", func() {
+				Eventually(func(g Gomega) {
+					payloads := []osbapi.BindPayload{}
+					for i := range brokerClient.BindCallCount() {
+						_, payload := brokerClient.BindArgsForCall(i)
+						payloads = append(payloads, payload)
+					}
+					g.Expect(payloads).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"BindingID": Equal(successor.Name),
+						"BindRequest": MatchFields(IgnoreExtras, Fields{
+							"PredecessorBindingID": Equal(binding.Name),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("takes over the mount secret of the previous binding", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(successor), successor)).To(Succeed())
+					g.Expect(successor.Status.MountSecretRef.Name).To(Equal(binding.Status.MountSecretRef.Name))
+
+					mountSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: successor.Namespace,
+							Name:      successor.Status.MountSecretRef.Name,
+						},
+					}
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(mountSecret), mountSecret)).To(Succeed())
+					g.Expect(mountSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Name": Equal(successor.Name),
+					})))
+				}).Should(Succeed())
+			})
+
+			It("marks the previous binding as superseded and completes the rotation", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.Annotations).To(HaveKeyWithValue(korifiv1alpha1.SupersededByAnnotation, successor.Name))
+
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(successor), successor)).To(Succeed())
+					g.Expect(successor.Spec.PreviousBinding.Name).To(BeEmpty())
+					g.Expect(meta.IsStatusConditionTrue(successor.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			It("keeps the previous binding for the app instances still using it", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+					g.Expect(binding.DeletionTimestamp).To(BeNil())
+				}).Should(Succeed())
+			})
+
+			When("the service plan does not support binding rotation", func() {
+				BeforeEach(func() {
+					bindingRotatable = false
+				})
+
+				It("fails the binding", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(successor), successor)).To(Succeed())
+						g.Expect(successor.Status.Conditions).To(ContainElement(SatisfyAll(
+							HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+							HasStatus(Equal(metav1.ConditionTrue)),
+							HasReason(Equal("BindingRotationNotSupported")),
+						)))
+					}).Should(Succeed())
+				})
+
+				It("keeps the previous binding", func() {
+					Consistently(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.DeletionTimestamp).To(BeNil())
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("binding is asynchronous", func() {
 			BeforeEach(func() {
 				brokerClient.BindReturns(osbapi.BindResponse{
@@ -799,6 +1247,9 @@ var _ = Describe("CFServiceBinding", func() {
 								BindResource: osbapi.BindResource{
 									AppGUID: cfAppGUID,
 								},
+								Context: osbapi.PlatformContext{
+									Platform: "cloudfoundry",
+								},
 							},
 						}))
 					}).Should(Succeed())
@@ -839,6 +1290,86 @@ var _ = Describe("CFServiceBinding", func() {
 					}).Should(Succeed())
 				})
 			})
+
+			When("bind fails with an unknown outcome", func() {
+				BeforeEach(func() {
+					brokerClient.BindReturns(osbapi.BindResponse{}, osbapi.OrphanMitigationRequiredError{
+						Cause: errors.New("binding request failed with code: 500"),
+					})
+					brokerClient.UnbindReturns(osbapi.UnbindResponse{}, nil)
+				})
+
+				It("unbinds the binding with the broker", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(brokerClient.UnbindCallCount()).NotTo(BeZero())
+						_, actualUnbindRequest := brokerClient.UnbindArgsForCall(0)
+						g.Expect(actualUnbindRequest).To(Equal(osbapi.UnbindPayload{
+							BindingID:  binding.Name,
+							InstanceID: instance.Name,
+							UnbindRequestParameters: osbapi.UnbindRequestParameters{
+								ServiceId: "service-offering-id",
+								PlanID:    "service-plan-id",
+							},
+						}))
+					}).Should(Succeed())
+				})
+
+				It("fails the binding once the orphan is mitigated", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+						g.Expect(binding.Status.Conditions).To(ContainElements(
+							SatisfyAll(
+								HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+								HasStatus(Equal(metav1.ConditionFalse)),
+							),
+							SatisfyAll(
+								HasType(Equal(korifiv1alpha1.BindingFailedCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+								HasReason(Equal("BindingFailed")),
+							),
+							SatisfyAll(
+								HasType(Equal(korifiv1alpha1.OrphanMitigationCondition)),
+								HasStatus(Equal(metav1.ConditionFalse)),
+								HasReason(Equal("OrphanMitigated")),
+							),
+						))
+					}).Should(Succeed())
+				})
+
+				When("unbinding the orphan fails", func() {
+					BeforeEach(func() {
+						brokerClient.UnbindReturns(osbapi.UnbindResponse{}, errors.New("unbinding-failed"))
+					})
+
+					It("keeps the orphan mitigation in progress and records the attempt", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+							g.Expect(binding.Status.OrphanMitigationAttempts).To(BeNumerically(">=", 1))
+							g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.OrphanMitigationCondition)),
+								HasStatus(Equal(metav1.ConditionTrue)),
+							)))
+						}).Should(Succeed())
+					})
+				})
+
+				When("unbinding the orphan fails with unrecoverable error", func() {
+					BeforeEach(func() {
+						brokerClient.UnbindReturns(osbapi.UnbindResponse{}, osbapi.UnrecoverableError{Status: http.StatusUnprocessableEntity})
+					})
+
+					It("gives up the orphan mitigation", func() {
+						Eventually(func(g Gomega) {
+							g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(binding), binding)).To(Succeed())
+							g.Expect(binding.Status.Conditions).To(ContainElement(SatisfyAll(
+								HasType(Equal(korifiv1alpha1.OrphanMitigationCondition)),
+								HasStatus(Equal(metav1.ConditionFalse)),
+								HasReason(Equal("OrphanMitigationFailed")),
+							)))
+						}).Should(Succeed())
+					})
+				})
+			})
 		})
 
 		Describe("binding deletion", func() {
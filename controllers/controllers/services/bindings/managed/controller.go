@@ -2,7 +2,11 @@ package managed
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"path"
+	"slices"
 	"time"
 
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
@@ -14,6 +18,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -22,19 +27,34 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
+const (
+	volumeMountRequirement = "volume_mount"
+	sharedVolumeDeviceType = "shared"
+)
+
 type ManagedBindingsReconciler struct {
-	k8sClient           client.Client
-	osbapiClientFactory osbapi.BrokerClientFactory
-	scheme              *runtime.Scheme
-	assets              *osbapi.Assets
+	k8sClient                 client.Client
+	osbapiClientFactory       osbapi.BrokerClientFactory
+	scheme                    *runtime.Scheme
+	assets                    *osbapi.Assets
+	retryPolicy               osbapi.RetryPolicy
+	allowedVolumeMountDrivers []string
 }
 
-func NewReconciler(k8sClient client.Client, brokerClientFactory osbapi.BrokerClientFactory, rootNamespace string, scheme *runtime.Scheme) *ManagedBindingsReconciler {
+func NewReconciler(
+	k8sClient client.Client,
+	brokerClientFactory osbapi.BrokerClientFactory,
+	rootNamespace string,
+	allowedVolumeMountDrivers []string,
+	scheme *runtime.Scheme,
+) *ManagedBindingsReconciler {
 	return &ManagedBindingsReconciler{
-		k8sClient:           k8sClient,
-		osbapiClientFactory: brokerClientFactory,
-		scheme:              scheme,
-		assets:              osbapi.NewAssets(k8sClient, rootNamespace),
+		k8sClient:                 k8sClient,
+		osbapiClientFactory:       brokerClientFactory,
+		scheme:                    scheme,
+		assets:                    osbapi.NewAssets(k8sClient, rootNamespace),
+		retryPolicy:               osbapi.DefaultOrphanMitigationRetryPolicy,
+		allowedVolumeMountDrivers: allowedVolumeMountDrivers,
 	}
 }
 
@@ -58,13 +78,30 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 	}
 
 	if isReconciled(cfServiceBinding) {
-		return ctrl.Result{}, nil
+		return r.rotateIfExpiring(ctx, cfServiceBinding, assets)
+	}
+
+	if isOrphanMitigationInProgress(cfServiceBinding) {
+		return r.mitigateOrphan(ctx, cfServiceBinding, assets, osbapiClient)
 	}
 
 	if isFailed(cfServiceBinding) {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingFailed").WithNoRequeue()
 	}
 
+	if cfServiceBinding.Spec.PreviousBinding.Name != "" && !assets.ServicePlan.Spec.BrokerCatalog.Features.BindingRotatable {
+		message := "The service plan does not support binding rotation"
+		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.BindingFailedCondition,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: cfServiceBinding.Generation,
+			LastTransitionTime: metav1.NewTime(time.Now()),
+			Reason:             "BindingRotationNotSupported",
+			Message:            message,
+		})
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingFailed").WithMessage(message).WithNoRequeue()
+	}
+
 	cfServiceBinding.Labels = tools.SetMapValue(cfServiceBinding.Labels, korifiv1alpha1.PlanGUIDLabelKey, assets.ServicePlan.Name)
 
 	bindResponse, err := r.bind(ctx, cfServiceBinding, assets, osbapiClient)
@@ -82,6 +119,16 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 		return r.processBindOperation(cfServiceBinding, lastOpResponse)
 	}
 
+	cfServiceBinding.Status.ExpiresAt, err = parseBindingTime(bindResponse.Metadata.ExpiresAt)
+	if err != nil {
+		return ctrl.Result{}, failBoundBinding(cfServiceBinding, "InvalidBindingMetadata", fmt.Sprintf("The binding expires_at metadata is invalid: %s", err))
+	}
+
+	cfServiceBinding.Status.RenewBefore, err = parseBindingTime(bindResponse.Metadata.RenewBefore)
+	if err != nil {
+		return ctrl.Result{}, failBoundBinding(cfServiceBinding, "InvalidBindingMetadata", fmt.Sprintf("The binding renew_before metadata is invalid: %s", err))
+	}
+
 	envSecret, err := r.createEnvSecret(ctx, cfServiceBinding, bindResponse.Credentials)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -89,20 +136,143 @@ func (r *ManagedBindingsReconciler) ReconcileResource(ctx context.Context, cfSer
 
 	cfServiceBinding.Status.EnvSecretRef.Name = envSecret.Name
 
+	predecessor, err := r.getPredecessor(ctx, cfServiceBinding)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if cfServiceBinding.Spec.Type == korifiv1alpha1.CFServiceBindingTypeKey {
-		return ctrl.Result{}, nil
+		return ctrl.Result{}, r.completeRotation(ctx, cfServiceBinding, predecessor)
 	}
 
-	mountSecret, err := r.createMountSecret(ctx, cfServiceBinding, bindResponse.Credentials)
+	cfServiceBinding.Status.VolumeMounts, err = r.resolveVolumeMounts(ctx, cfServiceBinding, assets, bindResponse.VolumeMounts)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	mountSecretName := cfServiceBinding.Name + "-sbio"
+	if predecessor != nil && predecessor.Status.MountSecretRef.Name != "" {
+		// Taking over the mount secret of the predecessor keeps the app
+		// workload spec unchanged, so the new credentials are projected into
+		// the running app containers without restarting them
+		mountSecretName = predecessor.Status.MountSecretRef.Name
+	}
+
+	mountSecret, err := r.createMountSecret(ctx, cfServiceBinding, mountSecretName, bindResponse.Credentials)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	cfServiceBinding.Status.MountSecretRef.Name = mountSecret.Name
 
+	return ctrl.Result{}, r.completeRotation(ctx, cfServiceBinding, predecessor)
+}
+
+func (r *ManagedBindingsReconciler) getPredecessor(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (*korifiv1alpha1.CFServiceBinding, error) {
+	if cfServiceBinding.Spec.PreviousBinding.Name == "" {
+		return nil, nil
+	}
+
+	predecessor := &korifiv1alpha1.CFServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfServiceBinding.Namespace,
+			Name:      cfServiceBinding.Spec.PreviousBinding.Name,
+		},
+	}
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(predecessor), predecessor)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get previous binding %q: %w", predecessor.Name, err)
+	}
+
+	return predecessor, nil
+}
+
+// completeRotation marks the predecessor binding as superseded and clears
+// the reference to it once the binding has taken its place. The predecessor
+// is not deleted, as app instances started before the rotation keep reading
+// its credentials from VCAP_SERVICES until they are restarted. As in Cloud
+// Foundry, deleting it is left to the user
+func (r *ManagedBindingsReconciler) completeRotation(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	predecessor *korifiv1alpha1.CFServiceBinding,
+) error {
+	if predecessor != nil {
+		err := k8s.PatchResource(ctx, r.k8sClient, predecessor, func() {
+			predecessor.Annotations = tools.SetMapValue(predecessor.Annotations, korifiv1alpha1.SupersededByAnnotation, cfServiceBinding.Name)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to mark previous binding %q as superseded: %w", predecessor.Name, err)
+		}
+	}
+
+	cfServiceBinding.Spec.PreviousBinding = corev1.LocalObjectReference{}
+	return nil
+}
+
+// rotateIfExpiring creates a successor of the binding once the renew_before
+// time reported by the broker has passed. Otherwise the binding is requeued
+// to be checked again at that time
+func (r *ManagedBindingsReconciler) rotateIfExpiring(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if cfServiceBinding.Status.RenewBefore == nil || !assets.ServicePlan.Spec.BrokerCatalog.Features.BindingRotatable {
+		return ctrl.Result{}, nil
+	}
+
+	if cfServiceBinding.Annotations[korifiv1alpha1.SupersededByAnnotation] != "" {
+		return ctrl.Result{}, nil
+	}
+
+	if renewIn := time.Until(cfServiceBinding.Status.RenewBefore.Time); renewIn > 0 {
+		return ctrl.Result{RequeueAfter: renewIn}, nil
+	}
+
+	successor := &korifiv1alpha1.CFServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   cfServiceBinding.Namespace,
+			Name:        tools.NamespacedUUID(cfServiceBinding.Name, "successor"),
+			Labels:      maps.Clone(cfServiceBinding.Labels),
+			Annotations: maps.Clone(cfServiceBinding.Annotations),
+		},
+		Spec: korifiv1alpha1.CFServiceBindingSpec{
+			DisplayName:     cfServiceBinding.Spec.DisplayName,
+			Service:         cfServiceBinding.Spec.Service,
+			AppRef:          cfServiceBinding.Spec.AppRef,
+			Type:            cfServiceBinding.Spec.Type,
+			PreviousBinding: corev1.LocalObjectReference{Name: cfServiceBinding.Name},
+		},
+	}
+
+	err := r.k8sClient.Create(ctx, successor)
+	if err != nil && !apierrors.IsAlreadyExists(err) {
+		log.Error(err, "failed to create successor binding")
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
+func parseBindingTime(value string) (*metav1.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &metav1.Time{Time: t}, nil
+}
+
 func (r *ManagedBindingsReconciler) bind(
 	ctx context.Context,
 	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
@@ -116,9 +286,16 @@ func (r *ManagedBindingsReconciler) bind(
 		return osbapi.BindResponse{}, k8s.NewNotReadyError().WithReason("InvalidParameters")
 	}
 
+	platformContext, err := r.assets.GetPlatformContext(ctx, cfServiceBinding.Namespace)
+	if err != nil {
+		log.Error(err, "failed to get platform context")
+		return osbapi.BindResponse{}, err
+	}
+
 	bindResponse, err := osbapiClient.Bind(ctx, osbapi.BindPayload{
-		BindingID:  cfServiceBinding.Name,
-		InstanceID: assets.ServiceInstance.Name,
+		BindingID:           cfServiceBinding.Name,
+		InstanceID:          assets.ServiceInstance.Name,
+		OriginatingIdentity: cfServiceBinding.Annotations[korifiv1alpha1.OriginatingIdentityAnnotation],
 		BindRequest: osbapi.BindRequest{
 			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
 			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
@@ -126,7 +303,9 @@ func (r *ManagedBindingsReconciler) bind(
 			BindResource: osbapi.BindResource{
 				AppGUID: cfServiceBinding.Spec.AppRef.Name,
 			},
-			Parameters: parameters,
+			Parameters:           parameters,
+			Context:              platformContext,
+			PredecessorBindingID: cfServiceBinding.Spec.PreviousBinding.Name,
 		},
 	})
 	if err != nil {
@@ -144,12 +323,93 @@ func (r *ManagedBindingsReconciler) bind(
 			return osbapi.BindResponse{}, k8s.NewNotReadyError().WithReason("BindingFailed")
 		}
 
+		if osbapi.RequiresOrphanMitigation(err) {
+			meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+				Type:               korifiv1alpha1.BindingFailedCondition,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: cfServiceBinding.Generation,
+				LastTransitionTime: metav1.NewTime(time.Now()),
+				Reason:             "BindingFailed",
+				Message:            err.Error(),
+			})
+			return osbapi.BindResponse{}, startOrphanMitigation(cfServiceBinding, "Unbinding as the outcome of the binding is unknown")
+		}
+
 		return osbapi.BindResponse{}, err
 	}
 
 	return bindResponse, nil
 }
 
+func startOrphanMitigation(cfServiceBinding *korifiv1alpha1.CFServiceBinding, message string) error {
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.OrphanMitigationCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "OrphanMitigationInProgress",
+		Message:            message,
+	})
+	cfServiceBinding.Status.OrphanMitigationAttempts = 0
+	return k8s.NewNotReadyError().WithReason("OrphanMitigationInProgress").WithRequeue()
+}
+
+// mitigateOrphan unbinds a binding whose bind request failed with an unknown
+// outcome, or that the broker bound with a response Korifi cannot use, in
+// order to clean up any credentials the broker may have created.
+// Failed attempts are retried according to the retry policy. The binding
+// remains failed once the mitigation is over
+func (r *ManagedBindingsReconciler) mitigateOrphan(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	osbapiClient osbapi.BrokerClient,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("orphan-mitigation")
+
+	// An asynchronous unbind is not polled as the broker has accepted the
+	// responsibility for cleaning up
+	_, err := osbapiClient.Unbind(ctx, osbapi.UnbindPayload{
+		InstanceID:          cfServiceBinding.Spec.Service.Name,
+		BindingID:           cfServiceBinding.Name,
+		OriginatingIdentity: cfServiceBinding.Annotations[korifiv1alpha1.OriginatingIdentityAnnotation],
+		UnbindRequestParameters: osbapi.UnbindRequestParameters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+		},
+	})
+	if osbapi.IgnoreGone(err) != nil {
+		log.Error(err, "failed to unbind orphaned binding", "attempt", cfServiceBinding.Status.OrphanMitigationAttempts+1)
+
+		cfServiceBinding.Status.OrphanMitigationAttempts++
+		if retryIn, retry := r.retryPolicy.NextRetry(cfServiceBinding.Status.OrphanMitigationAttempts, err); retry {
+			return ctrl.Result{}, k8s.NewNotReadyError().
+				WithReason("OrphanMitigationInProgress").
+				WithMessage(err.Error()).
+				WithRequeueAfter(retryIn)
+		}
+
+		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.OrphanMitigationCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: cfServiceBinding.Generation,
+			LastTransitionTime: metav1.NewTime(time.Now()),
+			Reason:             "OrphanMitigationFailed",
+			Message:            err.Error(),
+		})
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingFailed").WithNoRequeue()
+	}
+
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.OrphanMitigationCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "OrphanMitigated",
+	})
+	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingFailed").WithNoRequeue()
+}
+
 func (r *ManagedBindingsReconciler) getParameters(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (map[string]any, error) {
 	if cfServiceBinding.Spec.Parameters.Name == "" {
 		return nil, nil
@@ -193,6 +453,159 @@ func (r *ManagedBindingsReconciler) processBindOperation(
 	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("BindingInProgress").WithRequeue()
 }
 
+func (r *ManagedBindingsReconciler) resolveVolumeMounts(
+	ctx context.Context,
+	cfServiceBinding *korifiv1alpha1.CFServiceBinding,
+	assets osbapi.ServiceBindingAssets,
+	volumeMounts []osbapi.VolumeMount,
+) ([]korifiv1alpha1.ServiceBindingVolumeMount, error) {
+	if len(volumeMounts) == 0 {
+		return nil, nil
+	}
+
+	if !slices.Contains(assets.ServiceOffering.Spec.Requires, volumeMountRequirement) {
+		return nil, failBoundBinding(cfServiceBinding, "VolumeMountsNotSupported",
+			"The service is attempting to supply volume mounts to your application, but is not registered as a volume mount service")
+	}
+
+	if message := validateVolumeMounts(volumeMounts); message != "" {
+		return nil, failBoundBinding(cfServiceBinding, "InvalidVolumeMounts", message)
+	}
+
+	resolvedMounts := []korifiv1alpha1.ServiceBindingVolumeMount{}
+	for _, volumeMount := range volumeMounts {
+		resolvedMount, err := r.resolveVolumeMount(ctx, cfServiceBinding.Namespace, volumeMount)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve volume mount %q: %w", volumeMount.ContainerDir, err)
+		}
+
+		if resolvedMount.CSI != nil && !slices.Contains(r.allowedVolumeMountDrivers, resolvedMount.CSI.Driver) {
+			return nil, failBoundBinding(cfServiceBinding, "VolumeMountDriverNotAllowed",
+				fmt.Sprintf("The volume mount driver %q is not allowed", resolvedMount.CSI.Driver))
+		}
+
+		resolvedMounts = append(resolvedMounts, resolvedMount)
+	}
+
+	return resolvedMounts, nil
+}
+
+// validateVolumeMounts returns a message describing the first invalid volume
+// mount, or an empty string if all volume mounts are valid
+func validateVolumeMounts(volumeMounts []osbapi.VolumeMount) string {
+	containerDirs := map[string]bool{}
+	for _, volumeMount := range volumeMounts {
+		if volumeMount.DeviceType != sharedVolumeDeviceType {
+			return fmt.Sprintf("The volume mount device type %q is not supported, only %q is", volumeMount.DeviceType, sharedVolumeDeviceType)
+		}
+
+		if !path.IsAbs(volumeMount.ContainerDir) {
+			return fmt.Sprintf("The volume mount container directory %q is not an absolute path", volumeMount.ContainerDir)
+		}
+
+		containerDir := path.Clean(volumeMount.ContainerDir)
+		if containerDirs[containerDir] {
+			return fmt.Sprintf("The volume mount container directory %q is used by more than one volume mount", volumeMount.ContainerDir)
+		}
+		containerDirs[containerDir] = true
+	}
+
+	return ""
+}
+
+// failBoundBinding fails a binding the broker has already bound and starts
+// the orphan mitigation, so that the broker is asked to unbind it
+func failBoundBinding(cfServiceBinding *korifiv1alpha1.CFServiceBinding, reason string, message string) error {
+	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.BindingFailedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: cfServiceBinding.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             reason,
+		Message:            message,
+	})
+	return startOrphanMitigation(cfServiceBinding, "Unbinding as the binding response cannot be used")
+}
+
+// resolveVolumeMount resolves the broker volume mount to a persistent volume
+// claim in the binding namespace if the device volume ID names one, or to a
+// CSI inline volume provided by the volume mount driver otherwise. Only the
+// drivers allowed by the operator may provide CSI inline volumes
+func (r *ManagedBindingsReconciler) resolveVolumeMount(ctx context.Context, namespace string, volumeMount osbapi.VolumeMount) (korifiv1alpha1.ServiceBindingVolumeMount, error) {
+	mountConfig, err := korifiv1alpha1.AsRawExtension(volumeMount.Device.MountConfig)
+	if err != nil {
+		return korifiv1alpha1.ServiceBindingVolumeMount{}, err
+	}
+
+	resolvedMount := korifiv1alpha1.ServiceBindingVolumeMount{
+		Driver:       volumeMount.Driver,
+		ContainerDir: volumeMount.ContainerDir,
+		Mode:         volumeMount.Mode,
+		DeviceType:   volumeMount.DeviceType,
+		Device: korifiv1alpha1.VolumeMountDevice{
+			VolumeID:    volumeMount.Device.VolumeID,
+			MountConfig: mountConfig,
+		},
+	}
+	readOnly := volumeMount.Mode == "r"
+
+	if volumeMount.Device.VolumeID != "" {
+		pvc := &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      volumeMount.Device.VolumeID,
+			},
+		}
+		err = r.k8sClient.Get(ctx, client.ObjectKeyFromObject(pvc), pvc)
+		if err == nil {
+			resolvedMount.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: pvc.Name,
+				ReadOnly:  readOnly,
+			}
+			return resolvedMount, nil
+		}
+
+		if !apierrors.IsNotFound(err) {
+			return korifiv1alpha1.ServiceBindingVolumeMount{}, err
+		}
+	}
+
+	volumeAttributes, err := toVolumeAttributes(volumeMount.Device.MountConfig)
+	if err != nil {
+		return korifiv1alpha1.ServiceBindingVolumeMount{}, err
+	}
+
+	resolvedMount.CSI = &corev1.CSIVolumeSource{
+		Driver:           volumeMount.Driver,
+		ReadOnly:         tools.PtrTo(readOnly),
+		VolumeAttributes: volumeAttributes,
+	}
+
+	return resolvedMount, nil
+}
+
+func toVolumeAttributes(mountConfig map[string]any) (map[string]string, error) {
+	if len(mountConfig) == 0 {
+		return nil, nil
+	}
+
+	attributes := map[string]string{}
+	for key, value := range mountConfig {
+		if stringValue, ok := value.(string); ok {
+			attributes[key] = stringValue
+			continue
+		}
+
+		valueBytes, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal mount config %q: %w", key, err)
+		}
+		attributes[key] = string(valueBytes)
+	}
+
+	return attributes, nil
+}
+
 func (r *ManagedBindingsReconciler) createEnvSecret(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, creds map[string]any) (*corev1.Secret, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
@@ -218,12 +631,12 @@ func (r *ManagedBindingsReconciler) createEnvSecret(ctx context.Context, cfServi
 	return credentialsSecret, nil
 }
 
-func (r *ManagedBindingsReconciler) createMountSecret(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, creds map[string]any) (*corev1.Secret, error) {
+func (r *ManagedBindingsReconciler) createMountSecret(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, secretName string, creds map[string]any) (*corev1.Secret, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
 	mountSecret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      cfServiceBinding.Name + "-sbio",
+			Name:      secretName,
 			Namespace: cfServiceBinding.Namespace,
 		},
 	}
@@ -236,6 +649,8 @@ func (r *ManagedBindingsReconciler) createMountSecret(ctx context.Context, cfSer
 			return err
 		}
 
+		// the secret may still be controlled by the predecessor binding
+		mountSecret.OwnerReferences = nil
 		return controllerutil.SetControllerReference(cfServiceBinding, mountSecret, r.scheme)
 	})
 	if err != nil {
@@ -369,8 +784,9 @@ func (r *ManagedBindingsReconciler) deleteServiceBinding(
 	osbapiClient osbapi.BrokerClient,
 ) (osbapi.UnbindResponse, error) {
 	unbindResponse, err := osbapiClient.Unbind(ctx, osbapi.UnbindPayload{
-		InstanceID: serviceBinding.Spec.Service.Name,
-		BindingID:  serviceBinding.Name,
+		InstanceID:          serviceBinding.Spec.Service.Name,
+		BindingID:           serviceBinding.Name,
+		OriginatingIdentity: serviceBinding.Annotations[korifiv1alpha1.OriginatingIdentityAnnotation],
 		UnbindRequestParameters: osbapi.UnbindRequestParameters{
 			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
 			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
@@ -395,6 +811,10 @@ func (r *ManagedBindingsReconciler) deleteServiceBinding(
 	return unbindResponse, nil
 }
 
+func isOrphanMitigationInProgress(binding *korifiv1alpha1.CFServiceBinding) bool {
+	return meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.OrphanMitigationCondition)
+}
+
 func isFailed(binding *korifiv1alpha1.CFServiceBinding) bool {
 	return meta.IsStatusConditionTrue(binding.Status.Conditions, korifiv1alpha1.BindingFailedCondition)
 }
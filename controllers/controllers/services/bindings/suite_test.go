@@ -107,7 +107,7 @@ var _ = BeforeEach(func() {
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
 		upsi.NewReconciler(k8sManager.GetClient(), k8sManager.GetScheme()),
-		managed.NewReconciler(k8sManager.GetClient(), brokerClientFactory, rootNamespace, k8sManager.GetScheme()),
+		managed.NewReconciler(k8sManager.GetClient(), brokerClientFactory, rootNamespace, []string{"nfs.csi.k8s.io"}, k8sManager.GetScheme()),
 	).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 })
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -59,6 +60,7 @@ func (r *UPSIBindingReconciler) ReconcileResource(ctx context.Context, cfService
 	}
 
 	cfServiceBinding.Status.EnvSecretRef.Name = cfServiceInstance.Status.Credentials.Name
+	cfServiceBinding.Status.SyslogDrainURL = tools.ZeroIfNil(cfServiceInstance.Spec.SyslogDrainURL)
 
 	mountSecret, err := r.createMountSecret(ctx, cfServiceInstance, cfServiceBinding)
 	if err != nil {
@@ -44,13 +44,19 @@ import (
 type Reconciler struct {
 	k8sClient           client.Client
 	osbapiClientFactory osbapi.BrokerClientFactory
-	scheme              *runtime.Scheme
-	log                 logr.Logger
+	rootNamespace       string
+	// catalogResyncInterval is how often broker catalogs are fetched again.
+	// A zero interval disables the periodic refresh
+	catalogResyncInterval time.Duration
+	scheme                *runtime.Scheme
+	log                   logr.Logger
 }
 
 func NewReconciler(
 	client client.Client,
 	osbapiClientFactory osbapi.BrokerClientFactory,
+	rootNamespace string,
+	catalogResyncInterval time.Duration,
 	scheme *runtime.Scheme,
 	log logr.Logger,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceBroker] {
@@ -58,10 +64,12 @@ func NewReconciler(
 		log,
 		client,
 		&Reconciler{
-			k8sClient:           client,
-			osbapiClientFactory: osbapiClientFactory,
-			scheme:              scheme,
-			log:                 log,
+			k8sClient:             client,
+			osbapiClientFactory:   osbapiClientFactory,
+			rootNamespace:         rootNamespace,
+			catalogResyncInterval: catalogResyncInterval,
+			scheme:                scheme,
+			log:                   log,
 		},
 	)
 }
@@ -102,6 +110,7 @@ func (r *Reconciler) secretToServiceBroker(ctx context.Context, o client.Object)
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceofferings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("broker-id", cfServiceBroker.Name)
@@ -144,7 +153,13 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceBroker *kor
 		return ctrl.Result{}, fmt.Errorf("failed to reconcile catalog: %v", err)
 	}
 
-	return ctrl.Result{}, nil
+	err = r.reconcileRemovedCatalogEntries(ctx, cfServiceBroker, catalog)
+	if err != nil {
+		log.Error(err, "failed to reconcile removed catalog entries")
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile removed catalog entries: %v", err)
+	}
+
+	return ctrl.Result{RequeueAfter: r.catalogResyncInterval}, nil
 }
 
 func (r *Reconciler) reconcileCatalog(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker, catalog osbapi.Catalog) error {
@@ -212,6 +227,11 @@ func (r *Reconciler) reconcileCatalogPlan(ctx context.Context, serviceOffering *
 		if servicePlan.Spec.Visibility.Type != "" {
 			visibilityType = servicePlan.Spec.Visibility.Type
 		}
+		// Plans of space-scoped brokers live in the space namespace and
+		// are only visible there
+		if servicePlan.Namespace != r.rootNamespace {
+			visibilityType = korifiv1alpha1.SpaceServicePlanVisibilityType
+		}
 
 		metadata, err := korifiv1alpha1.AsRawExtension(catalogPlan.Metadata)
 		if err != nil {
@@ -241,8 +261,9 @@ func (r *Reconciler) reconcileCatalogPlan(ctx context.Context, serviceOffering *
 				ID:       catalogPlan.ID,
 				Metadata: metadata,
 				Features: korifiv1alpha1.ServicePlanFeatures{
-					PlanUpdateable: catalogPlan.PlanUpdateable,
-					Bindable:       catalogPlan.Bindable,
+					PlanUpdateable:   catalogPlan.PlanUpdateable,
+					Bindable:         catalogPlan.Bindable,
+					BindingRotatable: catalogPlan.BindingRotatable,
 				},
 			},
 			Schemas: korifiv1alpha1.ServicePlanSchemas{
@@ -261,7 +282,8 @@ func (r *Reconciler) reconcileCatalogPlan(ctx context.Context, serviceOffering *
 				},
 			},
 			MaintenanceInfo: korifiv1alpha1.MaintenanceInfo{
-				Version: catalogPlan.MaintenanceInfo.Version,
+				Version:     catalogPlan.MaintenanceInfo.Version,
+				Description: catalogPlan.MaintenanceInfo.Description,
 			},
 			Visibility: korifiv1alpha1.ServicePlanVisibility{
 				Type: visibilityType,
@@ -274,6 +296,94 @@ func (r *Reconciler) reconcileCatalogPlan(ctx context.Context, serviceOffering *
 	return err
 }
 
+// reconcileRemovedCatalogEntries handles offerings and plans that are no
+// longer in the broker catalog. Plans that still have service instances are
+// kept as inactive so that the instances can be deprovisioned, while plans
+// without instances are deleted. Offerings follow their plans.
+func (r *Reconciler) reconcileRemovedCatalogEntries(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker, catalog osbapi.Catalog) error {
+	catalogOfferingGUIDs := map[string]bool{}
+	catalogPlanGUIDs := map[string]bool{}
+	for _, service := range catalog.Services {
+		catalogOfferingGUIDs[tools.NamespacedUUID(cfServiceBroker.Name, service.ID)] = true
+		for _, plan := range service.Plans {
+			catalogPlanGUIDs[tools.NamespacedUUID(cfServiceBroker.Name, plan.ID)] = true
+		}
+	}
+
+	plans := &korifiv1alpha1.CFServicePlanList{}
+	err := r.k8sClient.List(ctx, plans,
+		client.InNamespace(cfServiceBroker.Namespace),
+		client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: cfServiceBroker.Name},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list service plans: %w", err)
+	}
+
+	remainingPlansPerOffering := map[string]int{}
+	for _, plan := range plans.Items {
+		if catalogPlanGUIDs[plan.Name] {
+			remainingPlansPerOffering[plan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]]++
+			continue
+		}
+
+		var deleted bool
+		deleted, err = r.removeServicePlan(ctx, &plan)
+		if err != nil {
+			return fmt.Errorf("failed to remove service plan %q: %w", plan.Name, err)
+		}
+
+		if !deleted {
+			remainingPlansPerOffering[plan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel]]++
+		}
+	}
+
+	offerings := &korifiv1alpha1.CFServiceOfferingList{}
+	err = r.k8sClient.List(ctx, offerings,
+		client.InNamespace(cfServiceBroker.Namespace),
+		client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: cfServiceBroker.Name},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list service offerings: %w", err)
+	}
+
+	for _, offering := range offerings.Items {
+		if catalogOfferingGUIDs[offering.Name] {
+			continue
+		}
+
+		if remainingPlansPerOffering[offering.Name] == 0 {
+			if err = r.k8sClient.Delete(ctx, &offering); client.IgnoreNotFound(err) != nil {
+				return fmt.Errorf("failed to delete service offering %q: %w", offering.Name, err)
+			}
+			continue
+		}
+
+		if err = k8s.Patch(ctx, r.k8sClient, &offering, func() {
+			offering.Spec.Inactive = true
+		}); err != nil {
+			return fmt.Errorf("failed to mark service offering %q as inactive: %w", offering.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) removeServicePlan(ctx context.Context, plan *korifiv1alpha1.CFServicePlan) (bool, error) {
+	serviceInstances := &korifiv1alpha1.CFServiceInstanceList{}
+	err := r.k8sClient.List(ctx, serviceInstances, client.MatchingFields{shared.IndexServiceInstancePlanGUID: plan.Name})
+	if err != nil {
+		return false, err
+	}
+
+	if len(serviceInstances.Items) == 0 {
+		return true, client.IgnoreNotFound(r.k8sClient.Delete(ctx, plan))
+	}
+
+	return false, k8s.Patch(ctx, r.k8sClient, plan, func() {
+		plan.Spec.Inactive = true
+	})
+}
+
 func toServiceOfferingSpec(catalogService osbapi.Service) (korifiv1alpha1.CFServiceOfferingSpec, error) {
 	metadata, err := korifiv1alpha1.AsRawExtension(catalogService.Metadata)
 	if err != nil {
@@ -21,11 +21,13 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 var _ = Describe("CFServiceBroker", func() {
 	var (
 		brokerClient  *fake.BrokerClient
+		catalog       osbapi.Catalog
 		brokerSecret  *corev1.Secret
 		serviceBroker *korifiv1alpha1.CFServiceBroker
 	)
@@ -33,7 +35,7 @@ var _ = Describe("CFServiceBroker", func() {
 	BeforeEach(func() {
 		brokerClient = new(fake.BrokerClient)
 		brokerClientFactory.CreateClientReturns(brokerClient, nil)
-		brokerClient.GetCatalogReturns(osbapi.Catalog{
+		catalog = osbapi.Catalog{
 			Services: []osbapi.Service{{
 				ID:                   "service-id",
 				Name:                 "service-name",
@@ -82,11 +84,13 @@ var _ = Describe("CFServiceBroker", func() {
 						},
 					},
 					MaintenanceInfo: osbapi.MaintenanceInfo{
-						Version: "1.2.3",
+						Version:     "1.2.3",
+						Description: "maintenance description",
 					},
 				}},
 			}},
-		}, nil)
+		}
+		brokerClient.GetCatalogReturns(catalog, nil)
 
 		brokerSecret = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
@@ -165,6 +169,7 @@ var _ = Describe("CFServiceBroker", func() {
 							}`),
 					})),
 				}),
+				"Inactive": BeFalse(),
 			}))
 		}).Should(Succeed())
 	})
@@ -203,8 +208,9 @@ var _ = Describe("CFServiceBroker", func() {
 						"Raw": MatchJSON(`{"plan-md": "plan-md-value"}`),
 					})),
 					"Features": Equal(korifiv1alpha1.ServicePlanFeatures{
-						PlanUpdateable: true,
-						Bindable:       true,
+						PlanUpdateable:   true,
+						Bindable:         true,
+						BindingRotatable: true,
 					}),
 				}),
 				"Schemas": MatchFields(IgnoreExtras, Fields{
@@ -229,12 +235,14 @@ var _ = Describe("CFServiceBroker", func() {
 					}),
 				}),
 				"MaintenanceInfo": Equal(korifiv1alpha1.MaintenanceInfo{
-					Version: "1.2.3",
+					Version:     "1.2.3",
+					Description: "maintenance description",
 				}),
 				"Visibility": MatchAllFields(Fields{
 					"Type":          Equal(korifiv1alpha1.AdminServicePlanVisibilityType),
 					"Organizations": BeEmpty(),
 				}),
+				"Inactive": BeFalse(),
 			}))
 		}).Should(Succeed())
 	})
@@ -285,6 +293,188 @@ var _ = Describe("CFServiceBroker", func() {
 		})
 	})
 
+	It("periodically refreshes the catalog", func() {
+		Eventually(brokerClient.GetCatalogCallCount).Should(BeNumerically(">", 1))
+	})
+
+	When("the broker catalog changes", func() {
+		var updatedCatalog osbapi.Catalog
+
+		BeforeEach(func() {
+			updatedCatalog = catalog
+		})
+
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(serviceBroker), serviceBroker)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(serviceBroker.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+			}).Should(Succeed())
+
+			brokerClient.GetCatalogReturns(updatedCatalog, nil)
+		})
+
+		When("a plan is added to the catalog", func() {
+			BeforeEach(func() {
+				service := catalog.Services[0]
+				service.Plans = append(slices.Clone(service.Plans), osbapi.Plan{
+					ID:   "new-plan-id",
+					Name: "new-plan-name",
+				})
+				updatedCatalog = osbapi.Catalog{Services: []osbapi.Service{service}}
+			})
+
+			It("creates the new plan without the broker being updated", func() {
+				Eventually(func(g Gomega) {
+					plans := &korifiv1alpha1.CFServicePlanList{}
+					g.Expect(adminClient.List(ctx, plans,
+						client.InNamespace(serviceBroker.Namespace),
+						client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name},
+					)).To(Succeed())
+					g.Expect(plans.Items).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{"Name": Equal("plan-name")})}),
+						MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{"Name": Equal("new-plan-name")})}),
+					))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the maintenance info version of a plan is bumped", func() {
+			BeforeEach(func() {
+				service := catalog.Services[0]
+				plan := service.Plans[0]
+				plan.MaintenanceInfo = osbapi.MaintenanceInfo{Version: "1.2.4"}
+				service.Plans = []osbapi.Plan{plan}
+				updatedCatalog = osbapi.Catalog{Services: []osbapi.Service{service}}
+			})
+
+			It("updates the plan maintenance info", func() {
+				Eventually(func(g Gomega) {
+					plan := &korifiv1alpha1.CFServicePlan{}
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{
+						Namespace: serviceBroker.Namespace,
+						Name:      tools.NamespacedUUID(serviceBroker.Name, "plan-id"),
+					}, plan)).To(Succeed())
+					g.Expect(plan.Spec.MaintenanceInfo.Version).To(Equal("1.2.4"))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the offering is removed from the catalog", func() {
+			BeforeEach(func() {
+				updatedCatalog = osbapi.Catalog{}
+			})
+
+			It("deletes the offering and its plans", func() {
+				Eventually(func(g Gomega) {
+					offerings := &korifiv1alpha1.CFServiceOfferingList{}
+					g.Expect(adminClient.List(ctx, offerings,
+						client.InNamespace(serviceBroker.Namespace),
+						client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name},
+					)).To(Succeed())
+					g.Expect(offerings.Items).To(BeEmpty())
+
+					plans := &korifiv1alpha1.CFServicePlanList{}
+					g.Expect(adminClient.List(ctx, plans,
+						client.InNamespace(serviceBroker.Namespace),
+						client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name},
+					)).To(Succeed())
+					g.Expect(plans.Items).To(BeEmpty())
+				}).Should(Succeed())
+			})
+
+			When("the plan has service instances", func() {
+				BeforeEach(func() {
+					Expect(adminClient.Create(ctx, &korifiv1alpha1.CFServiceInstance{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: rootNamespace,
+							Name:      uuid.NewString(),
+						},
+						Spec: korifiv1alpha1.CFServiceInstanceSpec{
+							DisplayName: "orphan",
+							Type:        korifiv1alpha1.ManagedType,
+							PlanGUID:    tools.NamespacedUUID(serviceBroker.Name, "plan-id"),
+						},
+					})).To(Succeed())
+				})
+
+				It("marks the offering and the plan as inactive", func() {
+					Eventually(func(g Gomega) {
+						offerings := &korifiv1alpha1.CFServiceOfferingList{}
+						g.Expect(adminClient.List(ctx, offerings,
+							client.InNamespace(serviceBroker.Namespace),
+							client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name},
+						)).To(Succeed())
+						g.Expect(offerings.Items).To(ConsistOf(
+							MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{"Inactive": BeTrue()})}),
+						))
+
+						plans := &korifiv1alpha1.CFServicePlanList{}
+						g.Expect(adminClient.List(ctx, plans,
+							client.InNamespace(serviceBroker.Namespace),
+							client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: serviceBroker.Name},
+						)).To(Succeed())
+						g.Expect(plans.Items).To(ConsistOf(
+							MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{"Inactive": BeTrue()})}),
+						))
+					}).Should(Succeed())
+				})
+			})
+		})
+	})
+
+	When("the broker is space-scoped", func() {
+		var spaceServiceBroker *korifiv1alpha1.CFServiceBroker
+
+		BeforeEach(func() {
+			spaceNamespace := uuid.NewString()
+			Expect(adminClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: spaceNamespace,
+				},
+			})).To(Succeed())
+
+			spaceBrokerSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceNamespace,
+					Name:      uuid.NewString(),
+				},
+			}
+			Expect(adminClient.Create(ctx, spaceBrokerSecret)).To(Succeed())
+
+			spaceServiceBroker = &korifiv1alpha1.CFServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: spaceNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceBrokerSpec{
+					Name: "my-space-broker",
+					URL:  "some-url",
+					Credentials: corev1.LocalObjectReference{
+						Name: spaceBrokerSecret.Name,
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, spaceServiceBroker)).To(Succeed())
+		})
+
+		It("creates space visible CFServicePlans in the space namespace", func() {
+			Eventually(func(g Gomega) {
+				plans := &korifiv1alpha1.CFServicePlanList{}
+				g.Expect(adminClient.List(ctx, plans,
+					client.InNamespace(spaceServiceBroker.Namespace),
+					client.MatchingLabels{korifiv1alpha1.RelServiceBrokerGUIDLabel: spaceServiceBroker.Name},
+				)).To(Succeed())
+				g.Expect(plans.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Visibility": MatchFields(IgnoreExtras, Fields{
+							"Type": Equal(korifiv1alpha1.SpaceServicePlanVisibilityType),
+						}),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
 	When("there are multiple brokers serving the same catalog", func() {
 		var anotherServiceBroker *korifiv1alpha1.CFServiceBroker
 
@@ -101,6 +101,8 @@ var _ = BeforeEach(func() {
 	err := (brokers.NewReconciler(
 		k8sManager.GetClient(),
 		brokerClientFactory,
+		rootNamespace,
+		time.Second,
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFServiceBroker"),
 	)).SetupWithManager(k8sManager)
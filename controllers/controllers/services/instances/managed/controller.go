@@ -26,6 +26,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -52,6 +53,8 @@ type Reconciler struct {
 	rootNamespace       string
 	log                 logr.Logger
 	assets              *osbapi.Assets
+	usageRecorder       *usage.Recorder
+	retryPolicy         osbapi.RetryPolicy
 }
 
 func NewReconciler(
@@ -68,6 +71,8 @@ func NewReconciler(
 		rootNamespace:       rootNamespace,
 		log:                 log,
 		assets:              osbapi.NewAssets(client, rootNamespace),
+		usageRecorder:       usage.NewRecorder(client, rootNamespace),
+		retryPolicy:         osbapi.DefaultOrphanMitigationRetryPolicy,
 	})
 }
 
@@ -142,11 +147,20 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 	}
 
 	serviceInstance.Status.UpgradeAvailable = serviceInstance.Status.MaintenanceInfo.Version != serviceInstanceAssets.ServicePlan.Spec.MaintenanceInfo.Version
+	setOrphanedCondition(serviceInstance, serviceInstanceAssets.ServicePlan)
 
 	if isReady(serviceInstance) {
+		if err = r.usageRecorder.RecordServiceInstanceUsage(ctx, serviceInstance, serviceUsage(serviceInstanceAssets)); err != nil {
+			log.Error(err, "failed to record service instance usage")
+			return ctrl.Result{}, err
+		}
 		return ctrl.Result{}, nil
 	}
 
+	if isOrphanMitigationInProgress(serviceInstance) {
+		return r.mitigateOrphan(ctx, serviceInstance, serviceInstanceAssets, osbapiClient)
+	}
+
 	if isFailed(serviceInstance) {
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("ProvisioningFailed").WithNoRequeue()
 	}
@@ -185,6 +199,22 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, serviceInstance *kor
 	return ctrl.Result{}, nil
 }
 
+func setOrphanedCondition(serviceInstance *korifiv1alpha1.CFServiceInstance, servicePlan *korifiv1alpha1.CFServicePlan) {
+	if !servicePlan.Spec.Inactive {
+		meta.RemoveStatusCondition(&serviceInstance.Status.Conditions, korifiv1alpha1.OrphanedCondition)
+		return
+	}
+
+	meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.OrphanedCondition,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: serviceInstance.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "PlanRemovedFromCatalog",
+		Message:            fmt.Sprintf("service plan %q is no longer offered by the service broker", servicePlan.Spec.Name),
+	})
+}
+
 func (r *Reconciler) provisionServiceInstance(
 	ctx context.Context,
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
@@ -199,11 +229,12 @@ func (r *Reconciler) provisionServiceInstance(
 		return osbapi.ProvisionResponse{}, k8s.NewNotReadyError().WithReason("InvalidParameters")
 	}
 
-	namespace, err := r.getNamespace(ctx, serviceInstance.Namespace)
+	platformContext, err := r.assets.GetPlatformContext(ctx, serviceInstance.Namespace)
 	if err != nil {
-		log.Error(err, "failed to get namespace")
+		log.Error(err, "failed to get platform context")
 		return osbapi.ProvisionResponse{}, err
 	}
+	platformContext.InstanceName = serviceInstance.Spec.DisplayName
 
 	serviceInstance.Status.LastOperation = korifiv1alpha1.LastOperation{
 		Type:  "create",
@@ -212,13 +243,15 @@ func (r *Reconciler) provisionServiceInstance(
 
 	var provisionResponse osbapi.ProvisionResponse
 	provisionResponse, err = osbapiClient.Provision(ctx, osbapi.ProvisionPayload{
-		InstanceID: serviceInstance.Name,
+		InstanceID:          serviceInstance.Name,
+		OriginatingIdentity: serviceInstance.Annotations[korifiv1alpha1.OriginatingIdentityAnnotation],
 		ProvisionRequest: osbapi.ProvisionRequest{
 			ServiceId:  assets.ServiceOffering.Spec.BrokerCatalog.ID,
 			PlanID:     assets.ServicePlan.Spec.BrokerCatalog.ID,
-			SpaceGUID:  namespace.Labels[korifiv1alpha1.SpaceGUIDLabelKey],
-			OrgGUID:    namespace.Labels[korifiv1alpha1.CFOrgGUIDKey],
+			SpaceGUID:  platformContext.SpaceGUID,
+			OrgGUID:    platformContext.OrganizationGUID,
 			Parameters: parametersMap,
+			Context:    platformContext,
 		},
 	})
 	if err != nil {
@@ -238,12 +271,89 @@ func (r *Reconciler) provisionServiceInstance(
 				k8s.NewNotReadyError().WithReason("ProvisionFailed")
 		}
 
+		if osbapi.RequiresOrphanMitigation(err) {
+			serviceInstance.Status.LastOperation.State = "failed"
+			meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+				Type:               korifiv1alpha1.ProvisioningFailedCondition,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: serviceInstance.Generation,
+				LastTransitionTime: metav1.NewTime(time.Now()),
+				Reason:             "ProvisionFailed",
+				Message:            err.Error(),
+			})
+			meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+				Type:               korifiv1alpha1.OrphanMitigationCondition,
+				Status:             metav1.ConditionTrue,
+				ObservedGeneration: serviceInstance.Generation,
+				LastTransitionTime: metav1.NewTime(time.Now()),
+				Reason:             "OrphanMitigationInProgress",
+				Message:            "Deprovisioning the service instance as the outcome of its provisioning is unknown",
+			})
+			serviceInstance.Status.OrphanMitigationAttempts = 0
+			return osbapi.ProvisionResponse{},
+				k8s.NewNotReadyError().WithReason("OrphanMitigationInProgress").WithRequeue()
+		}
+
 		return osbapi.ProvisionResponse{}, err
 	}
 
 	return provisionResponse, nil
 }
 
+// mitigateOrphan deprovisions a service instance whose provisioning failed
+// with an unknown outcome, in order to clean up any resources the broker may
+// have created. Failed attempts are retried according to the retry policy.
+// The instance remains failed once the mitigation is over
+func (r *Reconciler) mitigateOrphan(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	assets osbapi.ServiceInstanceAssets,
+	osbapiClient osbapi.BrokerClient,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("orphan-mitigation")
+
+	// An asynchronous deprovision is not polled as the broker has accepted
+	// the responsibility for cleaning up
+	_, err := osbapiClient.Deprovision(ctx, osbapi.DeprovisionPayload{
+		ID:                  serviceInstance.Name,
+		OriginatingIdentity: serviceInstance.Annotations[korifiv1alpha1.OriginatingIdentityAnnotation],
+		DeprovisionRequestParamaters: osbapi.DeprovisionRequestParamaters{
+			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
+			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
+		},
+	})
+	if osbapi.IgnoreGone(err) != nil {
+		log.Error(err, "failed to deprovision orphaned service instance", "attempt", serviceInstance.Status.OrphanMitigationAttempts+1)
+
+		serviceInstance.Status.OrphanMitigationAttempts++
+		if retryIn, retry := r.retryPolicy.NextRetry(serviceInstance.Status.OrphanMitigationAttempts, err); retry {
+			return ctrl.Result{}, k8s.NewNotReadyError().
+				WithReason("OrphanMitigationInProgress").
+				WithMessage(err.Error()).
+				WithRequeueAfter(retryIn)
+		}
+
+		meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.OrphanMitigationCondition,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: serviceInstance.Generation,
+			LastTransitionTime: metav1.NewTime(time.Now()),
+			Reason:             "OrphanMitigationFailed",
+			Message:            err.Error(),
+		})
+		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("ProvisionFailed").WithNoRequeue()
+	}
+
+	meta.SetStatusCondition(&serviceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.OrphanMitigationCondition,
+		Status:             metav1.ConditionFalse,
+		ObservedGeneration: serviceInstance.Generation,
+		LastTransitionTime: metav1.NewTime(time.Now()),
+		Reason:             "OrphanMitigated",
+	})
+	return ctrl.Result{}, k8s.NewNotReadyError().WithReason("ProvisionFailed").WithNoRequeue()
+}
+
 func (r *Reconciler) processProvisionOperation(
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
 	lastOpResponse osbapi.LastOperationResponse,
@@ -279,6 +389,10 @@ func (r *Reconciler) finalize(
 		return ctrl.Result{}, err
 	}
 
+	if err := r.recordServiceInstanceDeleted(ctx, serviceInstance); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	controllerutil.RemoveFinalizer(serviceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
 	logr.FromContextOrDiscard(ctx).WithName("finalizeCFServiceInstance").V(1).Info("finalizer removed")
 	return ctrl.Result{}, nil
@@ -324,6 +438,28 @@ func (r *Reconciler) finalizeCFServiceInstance(
 	return nil
 }
 
+// The plan, offering and broker may already be gone when an instance is
+// deprovisioned without the broker, so their details are best effort
+func (r *Reconciler) recordServiceInstanceDeleted(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) error {
+	usageDetails := korifiv1alpha1.ServiceUsage{ServicePlanGUID: serviceInstance.Spec.PlanGUID}
+	if assets, err := r.assets.GetServiceInstanceAssets(ctx, serviceInstance); err == nil {
+		usageDetails = serviceUsage(assets)
+	}
+
+	return r.usageRecorder.RecordServiceInstanceUsage(ctx, serviceInstance, usageDetails)
+}
+
+func serviceUsage(assets osbapi.ServiceInstanceAssets) korifiv1alpha1.ServiceUsage {
+	return korifiv1alpha1.ServiceUsage{
+		ServicePlanGUID:     assets.ServicePlan.Name,
+		ServicePlanName:     assets.ServicePlan.Spec.Name,
+		ServiceOfferingGUID: assets.ServiceOffering.Name,
+		ServiceOfferingName: assets.ServiceOffering.Spec.Name,
+		ServiceBrokerGUID:   assets.ServiceBroker.Name,
+		ServiceBrokerName:   assets.ServiceBroker.Spec.Name,
+	}
+}
+
 func (r *Reconciler) deprovisionServiceInstance(
 	ctx context.Context,
 	serviceInstance *korifiv1alpha1.CFServiceInstance,
@@ -335,7 +471,8 @@ func (r *Reconciler) deprovisionServiceInstance(
 		State: "initial",
 	}
 	deprovisionResponse, err := osbapiClient.Deprovision(ctx, osbapi.DeprovisionPayload{
-		ID: serviceInstance.Name,
+		ID:                  serviceInstance.Name,
+		OriginatingIdentity: serviceInstance.Annotations[korifiv1alpha1.OriginatingIdentityAnnotation],
 		DeprovisionRequestParamaters: osbapi.DeprovisionRequestParamaters{
 			ServiceId: assets.ServiceOffering.Spec.BrokerCatalog.ID,
 			PlanID:    assets.ServicePlan.Spec.BrokerCatalog.ID,
@@ -444,6 +581,10 @@ func (r *Reconciler) isServicePlanVisible(
 		return true, nil
 	}
 
+	if servicePlan.Spec.Visibility.Type == korifiv1alpha1.SpaceServicePlanVisibilityType {
+		return servicePlan.Namespace == serviceInstance.Namespace, nil
+	}
+
 	namespace := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: serviceInstance.Namespace,
@@ -457,18 +598,8 @@ func (r *Reconciler) isServicePlanVisible(
 	return slices.Contains(servicePlan.Spec.Visibility.Organizations, namespace.Labels[korifiv1alpha1.CFOrgGUIDKey]), nil
 }
 
-func (r *Reconciler) getNamespace(ctx context.Context, namespaceName string) (*corev1.Namespace, error) {
-	namespace := &corev1.Namespace{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: namespaceName,
-		},
-	}
-
-	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get namespace %q: %w", namespaceName, err)
-	}
-	return namespace, nil
+func isOrphanMitigationInProgress(instance *korifiv1alpha1.CFServiceInstance) bool {
+	return meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.OrphanMitigationCondition)
 }
 
 func isFailed(instance *korifiv1alpha1.CFServiceInstance) bool {
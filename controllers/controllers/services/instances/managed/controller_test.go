@@ -109,6 +109,9 @@ var _ = Describe("CFServiceInstance", func() {
 				Finalizers: []string{
 					korifiv1alpha1.CFServiceInstanceFinalizerName,
 				},
+				Annotations: map[string]string{
+					korifiv1alpha1.OriginatingIdentityAnnotation: "alice",
+				},
 			},
 			Spec: korifiv1alpha1.CFServiceInstanceSpec{
 				DisplayName: "service-instance-name",
@@ -186,12 +189,19 @@ var _ = Describe("CFServiceInstance", func() {
 			g.Expect(brokerClient.ProvisionCallCount()).NotTo(BeZero())
 			_, payload := brokerClient.ProvisionArgsForCall(0)
 			g.Expect(payload).To(Equal(osbapi.ProvisionPayload{
-				InstanceID: instance.Name,
+				InstanceID:          instance.Name,
+				OriginatingIdentity: "alice",
 				ProvisionRequest: osbapi.ProvisionRequest{
 					ServiceId: "service-offering-id",
 					PlanID:    "service-plan-id",
 					SpaceGUID: "space-guid",
 					OrgGUID:   "org-guid",
+					Context: osbapi.PlatformContext{
+						Platform:         "cloudfoundry",
+						OrganizationGUID: "org-guid",
+						SpaceGUID:        "space-guid",
+						InstanceName:     "service-instance-name",
+					},
 				},
 			}))
 		}).Should(Succeed())
@@ -473,12 +483,19 @@ var _ = Describe("CFServiceInstance", func() {
 				g.Expect(brokerClient.ProvisionCallCount()).To(BeNumerically(">", 1))
 				_, provisionPayload := brokerClient.ProvisionArgsForCall(1)
 				g.Expect(provisionPayload).To(Equal(osbapi.ProvisionPayload{
-					InstanceID: instance.Name,
+					InstanceID:          instance.Name,
+					OriginatingIdentity: "alice",
 					ProvisionRequest: osbapi.ProvisionRequest{
 						ServiceId: "service-offering-id",
 						PlanID:    "service-plan-id",
 						SpaceGUID: "space-guid",
 						OrgGUID:   "org-guid",
+						Context: osbapi.PlatformContext{
+							Platform:         "cloudfoundry",
+							OrganizationGUID: "org-guid",
+							SpaceGUID:        "space-guid",
+							InstanceName:     "service-instance-name",
+						},
 					},
 				}))
 			}).Should(Succeed())
@@ -532,6 +549,113 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 
+	When("service provisioning fails with an unknown outcome", func() {
+		BeforeEach(func() {
+			brokerClient.ProvisionReturns(osbapi.ProvisionResponse{}, osbapi.OrphanMitigationRequiredError{
+				Cause: errors.New("provision request failed with status code: 500"),
+			})
+		})
+
+		It("deprovisions the instance", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(brokerClient.DeprovisionCallCount()).To(BeNumerically(">=", 1))
+				_, deprovisionPayload := brokerClient.DeprovisionArgsForCall(0)
+				g.Expect(deprovisionPayload).To(Equal(osbapi.DeprovisionPayload{
+					ID:                  instance.Name,
+					OriginatingIdentity: "alice",
+					DeprovisionRequestParamaters: osbapi.DeprovisionRequestParamaters{
+						ServiceId: "service-offering-id",
+						PlanID:    "service-plan-id",
+					},
+				}))
+			}).Should(Succeed())
+		})
+
+		It("fails the instance once the orphan is mitigated", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+
+				g.Expect(instance.Status.Conditions).To(ContainElements(
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+					),
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.ProvisioningFailedCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+						HasReason(Equal("ProvisionFailed")),
+					),
+					SatisfyAll(
+						HasType(Equal(korifiv1alpha1.OrphanMitigationCondition)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("OrphanMitigated")),
+					),
+				))
+				g.Expect(instance.Status.LastOperation).To(Equal(korifiv1alpha1.LastOperation{
+					Type:  "create",
+					State: "failed",
+				}))
+			}).Should(Succeed())
+		})
+
+		It("does not retry the provisioning", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(brokerClient.ProvisionCallCount()).To(BeNumerically("<=", 1))
+			}).Should(Succeed())
+		})
+
+		When("the instance is already gone from the broker", func() {
+			BeforeEach(func() {
+				brokerClient.DeprovisionReturns(osbapi.ProvisionResponse{}, osbapi.GoneError{})
+			})
+
+			It("considers the orphan mitigated", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.OrphanMitigationCondition)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("OrphanMitigated")),
+					)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("deprovisioning the orphan fails", func() {
+			BeforeEach(func() {
+				brokerClient.DeprovisionReturns(osbapi.ProvisionResponse{}, errors.New("deprovision-failed"))
+			})
+
+			It("keeps the orphan mitigation in progress and records the attempt", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.OrphanMitigationAttempts).To(BeNumerically(">=", 1))
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.OrphanMitigationCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+					)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("deprovisioning the orphan fails with unrecoverable error", func() {
+			BeforeEach(func() {
+				brokerClient.DeprovisionReturns(osbapi.ProvisionResponse{}, osbapi.UnrecoverableError{Status: http.StatusUnprocessableEntity})
+			})
+
+			It("gives up the orphan mitigation", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.OrphanMitigationCondition)),
+						HasStatus(Equal(metav1.ConditionFalse)),
+						HasReason(Equal("OrphanMitigationFailed")),
+					)))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the instance has become ready", func() {
 		BeforeEach(func() {
 			Expect(k8s.Patch(ctx, adminClient, instance, func() {
@@ -601,6 +725,32 @@ var _ = Describe("CFServiceInstance", func() {
 				}).Should(Succeed())
 			})
 		})
+
+		When("the service plan is removed from the broker catalog", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, servicePlan, func() {
+					servicePlan.Spec.Inactive = true
+				})).To(Succeed())
+			})
+
+			It("sets the orphaned condition", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(instance.Status.Conditions).To(ContainElement(SatisfyAll(
+						HasType(Equal(korifiv1alpha1.OrphanedCondition)),
+						HasStatus(Equal(metav1.ConditionTrue)),
+						HasReason(Equal("PlanRemovedFromCatalog")),
+					)))
+				}).Should(Succeed())
+			})
+
+			It("remains ready", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(instance.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
 	})
 
 	When("the instance provisioning has failed", func() {
@@ -789,7 +939,8 @@ var _ = Describe("CFServiceInstance", func() {
 				g.Expect(brokerClient.DeprovisionCallCount()).To(Equal(1))
 				_, actualDeprovisionRequest := brokerClient.DeprovisionArgsForCall(0)
 				Expect(actualDeprovisionRequest).To(Equal(osbapi.DeprovisionPayload{
-					ID: instance.Name,
+					ID:                  instance.Name,
+					OriginatingIdentity: "alice",
 					DeprovisionRequestParamaters: osbapi.DeprovisionRequestParamaters{
 						ServiceId: "service-offering-id",
 						PlanID:    "service-plan-id",
@@ -1024,7 +1175,8 @@ var _ = Describe("CFServiceInstance", func() {
 						g.Expect(brokerClient.DeprovisionCallCount()).To(BeNumerically(">", 1))
 						_, actualDeprovisionRequest := brokerClient.DeprovisionArgsForCall(0)
 						g.Expect(actualDeprovisionRequest).To(Equal(osbapi.DeprovisionPayload{
-							ID: instance.Name,
+							ID:                  instance.Name,
+							OriginatingIdentity: "alice",
 							DeprovisionRequestParamaters: osbapi.DeprovisionRequestParamaters{
 								ServiceId: "service-offering-id",
 								PlanID:    "service-plan-id",
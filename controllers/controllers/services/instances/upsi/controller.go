@@ -24,6 +24,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/instances"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -44,17 +45,19 @@ import (
 )
 
 type Reconciler struct {
-	k8sClient client.Client
-	scheme    *runtime.Scheme
-	log       logr.Logger
+	k8sClient     client.Client
+	scheme        *runtime.Scheme
+	log           logr.Logger
+	usageRecorder *usage.Recorder
 }
 
 func NewReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
 	log logr.Logger,
+	usageRecorder *usage.Recorder,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance] {
-	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log}
+	serviceInstanceReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, usageRecorder: usageRecorder}
 	return k8s.NewPatchingReconciler(log, client, &serviceInstanceReconciler)
 }
 
@@ -145,6 +148,10 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfServiceInstance *k
 
 	cfServiceInstance.Status.CredentialsObservedVersion = credentialsSecret.ResourceVersion
 
+	if err = r.usageRecorder.RecordServiceInstanceUsage(ctx, cfServiceInstance, korifiv1alpha1.ServiceUsage{}); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -162,6 +169,10 @@ func (r *Reconciler) finalizeCFServiceInstance(
 		return ctrl.Result{}, err
 	}
 
+	if err := r.usageRecorder.RecordServiceInstanceUsage(ctx, serviceInstance, korifiv1alpha1.ServiceUsage{}); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	controllerutil.RemoveFinalizer(serviceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName)
 	log.V(1).Info("finalizer removed")
 
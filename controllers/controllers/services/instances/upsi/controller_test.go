@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -144,6 +145,21 @@ var _ = Describe("CFServiceInstance", func() {
 				}).Should(Succeed())
 			})
 
+			It("records a CREATED service usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listServiceUsageEvents(g, instance.Name)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":     Equal(korifiv1alpha1.ServiceUsageStateCreated),
+							"SpaceGUID": Equal(testNamespace),
+							"Service": PointTo(MatchFields(IgnoreExtras, Fields{
+								"ServiceInstanceName": Equal("service-instance-name"),
+								"ServiceInstanceType": Equal("user_provided_service_instance"),
+							})),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+
 			When("the credentials secret changes", func() {
 				var secretVersion string
 
@@ -217,6 +233,17 @@ var _ = Describe("CFServiceInstance", func() {
 					}).Should(Succeed())
 				})
 
+				It("records a DELETED service usage event", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(listServiceUsageEvents(g, instance.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+							"Spec": MatchFields(IgnoreExtras, Fields{
+								"State":         Equal(korifiv1alpha1.ServiceUsageStateDeleted),
+								"PreviousState": Equal(korifiv1alpha1.ServiceUsageStateCreated),
+							}),
+						})))
+					}).Should(Succeed())
+				})
+
 				When("the instance has bindings", func() {
 					var binding *korifiv1alpha1.CFServiceBinding
 
@@ -281,3 +308,17 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 })
+
+func listServiceUsageEvents(g Gomega, serviceInstanceGUID string) []korifiv1alpha1.CFUsageEvent {
+	var usageEvents korifiv1alpha1.CFUsageEventList
+	g.Expect(adminClient.List(ctx, &usageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+
+	result := []korifiv1alpha1.CFUsageEvent{}
+	for _, event := range usageEvents.Items {
+		if event.Spec.Service != nil && event.Spec.Service.ServiceInstanceGUID == serviceInstanceGUID {
+			result = append(result, event)
+		}
+	}
+
+	return result
+}
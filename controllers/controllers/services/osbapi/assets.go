@@ -3,8 +3,12 @@ package osbapi
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -28,17 +32,19 @@ type ServiceInstanceAssets struct {
 }
 
 func (r *Assets) GetServiceInstanceAssets(ctx context.Context, serviceInstance *korifiv1alpha1.CFServiceInstance) (ServiceInstanceAssets, error) {
-	servicePlan, err := r.getServicePlan(ctx, serviceInstance.Spec.PlanGUID)
+	servicePlan, err := r.getServicePlan(ctx, serviceInstance.Namespace, serviceInstance.Spec.PlanGUID)
 	if err != nil {
 		return ServiceInstanceAssets{}, err
 	}
 
-	serviceBroker, err := r.getServiceBroker(ctx, servicePlan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel])
+	// The broker and the offering live in the same namespace as the plan,
+	// i.e. the root namespace or the namespace of a space-scoped broker
+	serviceBroker, err := r.getServiceBroker(ctx, servicePlan.Namespace, servicePlan.Labels[korifiv1alpha1.RelServiceBrokerGUIDLabel])
 	if err != nil {
 		return ServiceInstanceAssets{}, err
 	}
 
-	serviceOffering, err := r.getServiceOffering(ctx, servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel])
+	serviceOffering, err := r.getServiceOffering(ctx, servicePlan.Namespace, servicePlan.Labels[korifiv1alpha1.RelServiceOfferingGUIDLabel])
 	if err != nil {
 		return ServiceInstanceAssets{}, err
 	}
@@ -78,11 +84,11 @@ func (r *Assets) GetServiceBindingAssets(ctx context.Context, serviceBinding *ko
 	}, nil
 }
 
-func (r *Assets) getServiceOffering(ctx context.Context, offeringGUID string) (*korifiv1alpha1.CFServiceOffering, error) {
+func (r *Assets) getServiceOffering(ctx context.Context, namespace, offeringGUID string) (*korifiv1alpha1.CFServiceOffering, error) {
 	serviceOffering := &korifiv1alpha1.CFServiceOffering{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      offeringGUID,
-			Namespace: r.rootNamespace,
+			Namespace: namespace,
 		},
 	}
 	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceOffering), serviceOffering)
@@ -93,25 +99,26 @@ func (r *Assets) getServiceOffering(ctx context.Context, offeringGUID string) (*
 	return serviceOffering, nil
 }
 
-func (r *Assets) getServicePlan(ctx context.Context, planGUID string) (*korifiv1alpha1.CFServicePlan, error) {
-	servicePlan := &korifiv1alpha1.CFServicePlan{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      planGUID,
-			Namespace: r.rootNamespace,
-		},
+// Plans of global brokers live in the root namespace, while plans of
+// space-scoped brokers live in the namespace of the space, i.e. the namespace
+// of the service instance
+func (r *Assets) getServicePlan(ctx context.Context, instanceNamespace, planGUID string) (*korifiv1alpha1.CFServicePlan, error) {
+	servicePlan := &korifiv1alpha1.CFServicePlan{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: planGUID}, servicePlan)
+	if k8serrors.IsNotFound(err) {
+		err = r.k8sClient.Get(ctx, client.ObjectKey{Namespace: instanceNamespace, Name: planGUID}, servicePlan)
 	}
-	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(servicePlan), servicePlan)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get service plan %q: %w", planGUID, err)
 	}
 	return servicePlan, nil
 }
 
-func (r *Assets) getServiceBroker(ctx context.Context, brokerGUID string) (*korifiv1alpha1.CFServiceBroker, error) {
+func (r *Assets) getServiceBroker(ctx context.Context, namespace, brokerGUID string) (*korifiv1alpha1.CFServiceBroker, error) {
 	serviceBroker := &korifiv1alpha1.CFServiceBroker{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      brokerGUID,
-			Namespace: r.rootNamespace,
+			Namespace: namespace,
 		},
 	}
 	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(serviceBroker), serviceBroker)
@@ -121,3 +128,67 @@ func (r *Assets) getServiceBroker(ctx context.Context, brokerGUID string) (*kori
 
 	return serviceBroker, nil
 }
+
+// The org and space names and annotations in the platform context are
+// informational, so they are left empty if the CFOrg or the CFSpace cannot be
+// found rather than failing the broker request
+func (r *Assets) GetPlatformContext(ctx context.Context, spaceNamespace string) (PlatformContext, error) {
+	namespace := &corev1.Namespace{}
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Name: spaceNamespace}, namespace)
+	if err != nil {
+		return PlatformContext{}, fmt.Errorf("failed to get namespace %q: %w", spaceNamespace, err)
+	}
+
+	platformContext := PlatformContext{
+		Platform:         PlatformCloudFoundry,
+		OrganizationGUID: namespace.Labels[korifiv1alpha1.CFOrgGUIDKey],
+		SpaceGUID:        namespace.Labels[korifiv1alpha1.SpaceGUIDLabelKey],
+		SpaceName:        namespace.Annotations[korifiv1alpha1.CFSpaceDisplayNameKey],
+	}
+
+	if platformContext.OrganizationGUID == "" {
+		return platformContext, nil
+	}
+
+	cfOrg := &korifiv1alpha1.CFOrg{}
+	err = r.k8sClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: platformContext.OrganizationGUID}, cfOrg)
+	if client.IgnoreNotFound(err) != nil {
+		return PlatformContext{}, fmt.Errorf("failed to get org %q: %w", platformContext.OrganizationGUID, err)
+	}
+	if err == nil {
+		platformContext.OrganizationName = cfOrg.Spec.DisplayName
+		platformContext.OrganizationAnnotations = userAnnotations(cfOrg.Annotations)
+	}
+
+	if platformContext.SpaceGUID == "" {
+		return platformContext, nil
+	}
+
+	cfSpace := &korifiv1alpha1.CFSpace{}
+	err = r.k8sClient.Get(ctx, client.ObjectKey{Namespace: platformContext.OrganizationGUID, Name: platformContext.SpaceGUID}, cfSpace)
+	if client.IgnoreNotFound(err) != nil {
+		return PlatformContext{}, fmt.Errorf("failed to get space %q: %w", platformContext.SpaceGUID, err)
+	}
+	if err == nil {
+		platformContext.SpaceName = cfSpace.Spec.DisplayName
+		platformContext.SpaceAnnotations = userAnnotations(cfSpace.Annotations)
+	}
+
+	return platformContext, nil
+}
+
+// userAnnotations drops the annotations set by Kubernetes and Korifi, leaving
+// the ones users can set through the CF API metadata
+func userAnnotations(annotations map[string]string) map[string]string {
+	var result map[string]string
+	for key, value := range annotations {
+		prefix, _, found := strings.Cut(key, "/")
+		if found && (strings.HasSuffix(prefix, "kubernetes.io") || strings.HasSuffix(prefix, "k8s.io") || strings.HasSuffix(prefix, "cloudfoundry.org")) {
+			continue
+		}
+
+		result = tools.SetMapValue(result, key, value)
+	}
+
+	return result
+}
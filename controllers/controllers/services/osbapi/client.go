@@ -8,11 +8,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
 )
 
-const osbapiVersion = "2.17"
+const (
+	osbapiVersion        = "2.17"
+	PlatformCloudFoundry = "cloudfoundry"
+)
 
 type GoneError struct{}
 
@@ -28,6 +35,27 @@ func (c UnrecoverableError) Error() string {
 	return fmt.Sprintf("The server responded with status: %d", c.Status)
 }
 
+// OrphanMitigationRequiredError is returned by provision and bind requests
+// with an unknown outcome, i.e. when the broker responds with a server error,
+// the request times out or the broker response cannot be parsed. As per the
+// OSBAPI spec the platform should then deprovision or unbind in order to
+// clean up any resources the broker may have created
+type OrphanMitigationRequiredError struct {
+	Cause error
+}
+
+func (e OrphanMitigationRequiredError) Error() string {
+	return e.Cause.Error()
+}
+
+func (e OrphanMitigationRequiredError) Unwrap() error {
+	return e.Cause
+}
+
+func RequiresOrphanMitigation(err error) bool {
+	return errors.As(err, &OrphanMitigationRequiredError{})
+}
+
 func IgnoreGone(err error) error {
 	if errors.As(err, &GoneError{}) {
 		return nil
@@ -80,6 +108,7 @@ func (c *Client) Provision(ctx context.Context, payload ProvisionPayload) (Provi
 	statusCode, respBytes, err := c.newBrokerRequester().
 		forBroker(c.broker).
 		async().
+		withOriginatingIdentity(payload.OriginatingIdentity).
 		sendRequest(
 			ctx,
 			"/v2/service_instances/"+payload.InstanceID,
@@ -88,14 +117,17 @@ func (c *Client) Provision(ctx context.Context, payload ProvisionPayload) (Provi
 			payload.ProvisionRequest,
 		)
 	if err != nil {
-		return ProvisionResponse{}, fmt.Errorf("provision request failed: %w", err)
+		return ProvisionResponse{}, withOrphanMitigation(fmt.Errorf("provision request failed: %w", err), isTimeout(err))
 	}
 	if statusCode == http.StatusBadRequest || statusCode == http.StatusConflict || statusCode == http.StatusUnprocessableEntity {
 		return ProvisionResponse{}, UnrecoverableError{Status: statusCode}
 	}
 
-	if statusCode >= 300 {
-		return ProvisionResponse{}, fmt.Errorf("provision request failed with status code: %d", statusCode)
+	if statusCode >= 300 || !isKnownSuccessStatus(statusCode) {
+		return ProvisionResponse{}, withOrphanMitigation(
+			fmt.Errorf("provision request failed with status code: %d", statusCode),
+			statusRequiresOrphanMitigation(statusCode),
+		)
 	}
 
 	response := ProvisionResponse{
@@ -104,7 +136,10 @@ func (c *Client) Provision(ctx context.Context, payload ProvisionPayload) (Provi
 
 	err = json.Unmarshal(respBytes, &response)
 	if err != nil {
-		return ProvisionResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+		return ProvisionResponse{}, withOrphanMitigation(
+			fmt.Errorf("failed to unmarshal response: %w", err),
+			statusCode == http.StatusCreated,
+		)
 	}
 
 	return response, nil
@@ -114,6 +149,7 @@ func (c *Client) Deprovision(ctx context.Context, payload DeprovisionPayload) (P
 	statusCode, respBytes, err := c.newBrokerRequester().
 		forBroker(c.broker).
 		async().
+		withOriginatingIdentity(payload.OriginatingIdentity).
 		sendRequest(
 			ctx,
 			"/v2/service_instances/"+payload.ID,
@@ -152,6 +188,40 @@ func (c *Client) Deprovision(ctx context.Context, payload DeprovisionPayload) (P
 	return response, nil
 }
 
+func (c *Client) GetServiceInstance(ctx context.Context, request GetServiceInstanceRequest) (ServiceInstanceResponse, error) {
+	statusCode, respBytes, err := c.newBrokerRequester().
+		forBroker(c.broker).
+		sendRequest(
+			ctx,
+			"/v2/service_instances/"+request.InstanceID,
+			http.MethodGet,
+			map[string]string{
+				"service_id": request.ServiceId,
+				"plan_id":    request.PlanID,
+			},
+			nil,
+		)
+	if err != nil {
+		return ServiceInstanceResponse{}, fmt.Errorf("fetching service instance failed: %w", err)
+	}
+
+	if statusCode == http.StatusNotFound {
+		return ServiceInstanceResponse{}, UnrecoverableError{Status: statusCode}
+	}
+
+	if statusCode != http.StatusOK {
+		return ServiceInstanceResponse{}, fmt.Errorf("fetching service instance failed with code: %d", statusCode)
+	}
+
+	var response ServiceInstanceResponse
+	err = json.Unmarshal(respBytes, &response)
+	if err != nil {
+		return ServiceInstanceResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return response, nil
+}
+
 func (c *Client) GetServiceInstanceLastOperation(ctx context.Context, request GetInstanceLastOperationRequest) (LastOperationResponse, error) {
 	statusCode, respBytes, err := c.newBrokerRequester().
 		forBroker(c.broker).
@@ -191,6 +261,7 @@ func (c *Client) Bind(ctx context.Context, payload BindPayload) (BindResponse, e
 	statusCode, respBytes, err := c.newBrokerRequester().
 		forBroker(c.broker).
 		async().
+		withOriginatingIdentity(payload.OriginatingIdentity).
 		sendRequest(
 			ctx,
 			"/v2/service_instances/"+payload.InstanceID+"/service_bindings/"+payload.BindingID,
@@ -199,15 +270,18 @@ func (c *Client) Bind(ctx context.Context, payload BindPayload) (BindResponse, e
 			payload.BindRequest,
 		)
 	if err != nil {
-		return BindResponse{}, fmt.Errorf("bind request failed: %w", err)
+		return BindResponse{}, withOrphanMitigation(fmt.Errorf("bind request failed: %w", err), isTimeout(err))
 	}
 
 	if statusCode == http.StatusBadRequest || statusCode == http.StatusConflict || statusCode == http.StatusUnprocessableEntity {
 		return BindResponse{}, UnrecoverableError{Status: statusCode}
 	}
 
-	if statusCode >= 300 {
-		return BindResponse{}, fmt.Errorf("binding request failed with code: %d", statusCode)
+	if statusCode >= 300 || !isKnownSuccessStatus(statusCode) {
+		return BindResponse{}, withOrphanMitigation(
+			fmt.Errorf("binding request failed with code: %d", statusCode),
+			statusRequiresOrphanMitigation(statusCode),
+		)
 	}
 
 	response := BindResponse{
@@ -216,7 +290,10 @@ func (c *Client) Bind(ctx context.Context, payload BindPayload) (BindResponse, e
 
 	err = json.Unmarshal(respBytes, &response)
 	if err != nil {
-		return BindResponse{}, fmt.Errorf("failed to unmarshal response: %w", err)
+		return BindResponse{}, withOrphanMitigation(
+			fmt.Errorf("failed to unmarshal response: %w", err),
+			statusCode == http.StatusCreated,
+		)
 	}
 
 	return response, nil
@@ -261,6 +338,7 @@ func (c *Client) Unbind(ctx context.Context, payload UnbindPayload) (UnbindRespo
 	statusCode, respBytes, err := c.newBrokerRequester().
 		forBroker(c.broker).
 		async().
+		withOriginatingIdentity(payload.OriginatingIdentity).
 		sendRequest(
 			ctx,
 			"/v2/service_instances/"+payload.InstanceID+"/service_bindings/"+payload.BindingID,
@@ -342,9 +420,10 @@ func payloadToReader(payload any) (io.Reader, error) {
 }
 
 type brokerRequester struct {
-	broker            Broker
-	acceptsIncomplete bool
-	httpClient        *http.Client
+	broker              Broker
+	acceptsIncomplete   bool
+	originatingIdentity string
+	httpClient          *http.Client
 }
 
 func (c *Client) newBrokerRequester() *brokerRequester {
@@ -361,6 +440,11 @@ func (r *brokerRequester) async() *brokerRequester {
 	return r
 }
 
+func (r *brokerRequester) withOriginatingIdentity(userID string) *brokerRequester {
+	r.originatingIdentity = userID
+	return r
+}
+
 func (r *brokerRequester) sendRequest(ctx context.Context, requestPath string, method string, queryParams map[string]string, payload any) (int, []byte, error) {
 	requestUrl, err := url.JoinPath(r.broker.URL, requestPath)
 	if err != nil {
@@ -385,6 +469,18 @@ func (r *brokerRequester) sendRequest(ctx context.Context, requestPath string, m
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("X-Broker-API-Version", osbapiVersion)
 
+	requestIdentity := uuid.NewString()
+	req.Header.Add("X-Broker-API-Request-Identity", requestIdentity)
+	logr.FromContextOrDiscard(ctx).V(1).Info("sending broker request", "method", method, "path", requestPath, "requestIdentity", requestIdentity)
+
+	if r.originatingIdentity != "" {
+		originatingIdentityHeader, err := buildOriginatingIdentityHeaderValue(r.originatingIdentity)
+		if err != nil {
+			return 0, nil, fmt.Errorf("failed to build X-Broker-API-Originating-Identity request header value: %w", err)
+		}
+		req.Header.Add("X-Broker-API-Originating-Identity", originatingIdentityHeader)
+	}
+
 	queryValues := req.URL.Query()
 	for queryParam, queryParamValue := range queryParams {
 		if queryParamValue == "" {
@@ -417,8 +513,43 @@ func (r *brokerRequester) sendRequest(ctx context.Context, requestPath string, m
 	return resp.StatusCode, respBody, nil
 }
 
+func isKnownSuccessStatus(statusCode int) bool {
+	return statusCode == http.StatusOK || statusCode == http.StatusCreated || statusCode == http.StatusAccepted
+}
+
+// statusRequiresOrphanMitigation follows the orphan mitigation table of the
+// OSBAPI spec: server errors and unexpected success codes leave the broker in
+// an unknown state, while other client errors do not
+func statusRequiresOrphanMitigation(statusCode int) bool {
+	return statusCode >= 500 || (statusCode >= 200 && statusCode < 300)
+}
+
+func isTimeout(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+func withOrphanMitigation(err error, required bool) error {
+	if !required {
+		return err
+	}
+
+	return OrphanMitigationRequiredError{Cause: err}
+}
+
 func (r *brokerRequester) buildAuthorizationHeaderValue() (string, error) {
 	authPlain := fmt.Sprintf("%s:%s", r.broker.Username, r.broker.Password)
 	auth := base64.StdEncoding.EncodeToString([]byte(authPlain))
 	return "Basic " + auth, nil
 }
+
+// The originating identity header value is the platform name followed by the
+// base64 encoded JSON identity of the user, as defined by the OSBAPI spec
+func buildOriginatingIdentityHeaderValue(userID string) (string, error) {
+	identity, err := json.Marshal(map[string]string{"user_id": userID})
+	if err != nil {
+		return "", err
+	}
+
+	return PlatformCloudFoundry + " " + base64.StdEncoding.EncodeToString(identity), nil
+}
@@ -123,7 +123,8 @@ var _ = Describe("OSBAPI Client", func() {
 
 			JustBeforeEach(func() {
 				provisionResp, provisionErr = brokerClient.Provision(ctx, osbapi.ProvisionPayload{
-					InstanceID: "my-service-instance",
+					InstanceID:          "my-service-instance",
+					OriginatingIdentity: "alice",
 					ProvisionRequest: osbapi.ProvisionRequest{
 						ServiceId: "service-guid",
 						PlanID:    "plan-guid",
@@ -132,6 +133,20 @@ var _ = Describe("OSBAPI Client", func() {
 						Parameters: map[string]any{
 							"foo": "bar",
 						},
+						Context: osbapi.PlatformContext{
+							Platform:         "cloudfoundry",
+							OrganizationGUID: "org-guid",
+							OrganizationName: "org-name",
+							OrganizationAnnotations: map[string]string{
+								"org-annotation": "org-annotation-value",
+							},
+							SpaceGUID: "space-guid",
+							SpaceName: "space-name",
+							SpaceAnnotations: map[string]string{
+								"space-annotation": "space-annotation-value",
+							},
+							InstanceName: "my-instance",
+						},
 					},
 				})
 			})
@@ -167,9 +182,39 @@ var _ = Describe("OSBAPI Client", func() {
 					"parameters": MatchAllKeys(Keys{
 						"foo": Equal("bar"),
 					}),
+					"context": MatchAllKeys(Keys{
+						"platform":          Equal("cloudfoundry"),
+						"organization_guid": Equal("org-guid"),
+						"organization_name": Equal("org-name"),
+						"organization_annotations": MatchAllKeys(Keys{
+							"org-annotation": Equal("org-annotation-value"),
+						}),
+						"space_guid": Equal("space-guid"),
+						"space_name": Equal("space-name"),
+						"space_annotations": MatchAllKeys(Keys{
+							"space-annotation": Equal("space-annotation-value"),
+						}),
+						"instance_name": Equal("my-instance"),
+					}),
 				}))
 			})
 
+			It("sends the originating identity of the user", func() {
+				Expect(provisionErr).NotTo(HaveOccurred())
+				Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Header": HaveKeyWithValue(
+						"X-Broker-Api-Originating-Identity", ConsistOf("cloudfoundry "+base64.StdEncoding.EncodeToString([]byte(`{"user_id":"alice"}`))),
+					),
+				}))))
+			})
+
+			It("sends a request identity", func() {
+				Expect(provisionErr).NotTo(HaveOccurred())
+				Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Header": HaveKeyWithValue("X-Broker-Api-Request-Identity", ConsistOf(Not(BeEmpty()))),
+				}))))
+			})
+
 			It("provisions the service synchronously", func() {
 				Expect(provisionErr).NotTo(HaveOccurred())
 				Expect(provisionResp).To(Equal(osbapi.ProvisionResponse{}))
@@ -233,6 +278,49 @@ var _ = Describe("OSBAPI Client", func() {
 				It("returns an error", func() {
 					Expect(provisionErr).To(MatchError(ContainSubstring("provision request failed")))
 				})
+
+				It("requires orphan mitigation", func() {
+					Expect(osbapi.RequiresOrphanMitigation(provisionErr)).To(BeTrue())
+				})
+			})
+
+			When("the provision request fails with a client error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusTeapot)
+				})
+
+				It("does not require orphan mitigation", func() {
+					Expect(provisionErr).To(HaveOccurred())
+					Expect(osbapi.RequiresOrphanMitigation(provisionErr)).To(BeFalse())
+				})
+			})
+
+			When("the broker responds with an unexpected success status", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse("/v2/service_instances/{id}", nil, http.StatusNoContent)
+				})
+
+				It("requires orphan mitigation", func() {
+					Expect(provisionErr).To(MatchError(ContainSubstring("provision request failed")))
+					Expect(osbapi.RequiresOrphanMitigation(provisionErr)).To(BeTrue())
+				})
+			})
+
+			When("the broker responds with a malformed created response", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{id}",
+						map[string]any{
+							"operation": 42,
+						},
+						http.StatusCreated,
+					)
+				})
+
+				It("requires orphan mitigation", func() {
+					Expect(provisionErr).To(MatchError(ContainSubstring("failed to unmarshal response")))
+					Expect(osbapi.RequiresOrphanMitigation(provisionErr)).To(BeTrue())
+				})
 			})
 		})
 
@@ -458,11 +546,13 @@ var _ = Describe("OSBAPI Client", func() {
 	Describe("Bindings", func() {
 		Describe("Bind", func() {
 			var (
-				bindResp osbapi.BindResponse
-				bindErr  error
+				bindResp             osbapi.BindResponse
+				bindErr              error
+				predecessorBindingID string
 			)
 
 			BeforeEach(func() {
+				predecessorBindingID = ""
 				brokerServer.WithResponse(
 					"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
 					map[string]any{
@@ -476,8 +566,9 @@ var _ = Describe("OSBAPI Client", func() {
 
 			JustBeforeEach(func() {
 				bindResp, bindErr = brokerClient.Bind(ctx, osbapi.BindPayload{
-					InstanceID: "instance-id",
-					BindingID:  "binding-id",
+					InstanceID:          "instance-id",
+					BindingID:           "binding-id",
+					OriginatingIdentity: "alice",
 					BindRequest: osbapi.BindRequest{
 						ServiceId: "service-guid",
 						PlanID:    "plan-guid",
@@ -488,6 +579,14 @@ var _ = Describe("OSBAPI Client", func() {
 						Parameters: map[string]any{
 							"foo": "bar",
 						},
+						Context: osbapi.PlatformContext{
+							Platform:         "cloudfoundry",
+							OrganizationGUID: "org-guid",
+							OrganizationName: "org-name",
+							SpaceGUID:        "space-guid",
+							SpaceName:        "space-name",
+						},
+						PredecessorBindingID: predecessorBindingID,
 					},
 				})
 			})
@@ -528,9 +627,27 @@ var _ = Describe("OSBAPI Client", func() {
 					"parameters": MatchAllKeys(Keys{
 						"foo": Equal("bar"),
 					}),
+					"context": MatchAllKeys(Keys{
+						"platform":                 Equal("cloudfoundry"),
+						"organization_guid":        Equal("org-guid"),
+						"organization_name":        Equal("org-name"),
+						"organization_annotations": BeNil(),
+						"space_guid":               Equal("space-guid"),
+						"space_name":               Equal("space-name"),
+						"space_annotations":        BeNil(),
+					}),
 				}))
 			})
 
+			It("sends the originating identity of the user", func() {
+				Expect(bindErr).NotTo(HaveOccurred())
+				Expect(brokerServer.ServedRequests()).To(ConsistOf(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Header": HaveKeyWithValue(
+						"X-Broker-Api-Originating-Identity", ConsistOf("cloudfoundry "+base64.StdEncoding.EncodeToString([]byte(`{"user_id":"alice"}`))),
+					),
+				}))))
+			})
+
 			It("binds the service", func() {
 				Expect(bindErr).NotTo(HaveOccurred())
 				Expect(bindResp).To(Equal(osbapi.BindResponse{
@@ -540,6 +657,88 @@ var _ = Describe("OSBAPI Client", func() {
 				}))
 			})
 
+			When("the broker returns volume mounts", func() {
+				BeforeEach(func() {
+					brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						map[string]any{
+							"credentials": map[string]string{},
+							"volume_mounts": []map[string]any{{
+								"driver":        "nfs.csi.k8s.io",
+								"container_dir": "/var/vcap/data/nfs",
+								"mode":          "rw",
+								"device_type":   "shared",
+								"device": map[string]any{
+									"volume_id": "volume-id",
+									"mount_config": map[string]any{
+										"server": "nfs.example.com",
+									},
+								},
+							}},
+						},
+						http.StatusCreated,
+					)
+				})
+
+				It("returns them", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					Expect(bindResp.VolumeMounts).To(ConsistOf(osbapi.VolumeMount{
+						Driver:       "nfs.csi.k8s.io",
+						ContainerDir: "/var/vcap/data/nfs",
+						Mode:         "rw",
+						DeviceType:   "shared",
+						Device: osbapi.Device{
+							VolumeID: "volume-id",
+							MountConfig: map[string]any{
+								"server": "nfs.example.com",
+							},
+						},
+					}))
+				})
+			})
+
+			When("the binding rotates a predecessor binding", func() {
+				BeforeEach(func() {
+					predecessorBindingID = "predecessor-binding-id"
+				})
+
+				It("sends the predecessor binding id", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					requests := brokerServer.ServedRequests()
+					Expect(requests).To(HaveLen(1))
+
+					requestBytes, err := io.ReadAll(requests[0].Body)
+					Expect(err).NotTo(HaveOccurred())
+					requestBody := map[string]any{}
+					Expect(json.Unmarshal(requestBytes, &requestBody)).To(Succeed())
+					Expect(requestBody).To(HaveKeyWithValue("predecessor_binding_id", "predecessor-binding-id"))
+				})
+			})
+
+			When("the broker returns binding metadata", func() {
+				BeforeEach(func() {
+					brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						map[string]any{
+							"credentials": map[string]string{},
+							"metadata": map[string]any{
+								"expires_at":   "2024-10-01T12:00:00Z",
+								"renew_before": "2024-09-30T12:00:00Z",
+							},
+						},
+						http.StatusCreated,
+					)
+				})
+
+				It("returns it", func() {
+					Expect(bindErr).NotTo(HaveOccurred())
+					Expect(bindResp.Metadata).To(Equal(osbapi.BindingMetadata{
+						ExpiresAt:   "2024-10-01T12:00:00Z",
+						RenewBefore: "2024-09-30T12:00:00Z",
+					}))
+				})
+			})
+
 			When("bind is asynchronous", func() {
 				BeforeEach(func() {
 					brokerServer.WithResponse(
@@ -572,6 +771,42 @@ var _ = Describe("OSBAPI Client", func() {
 				It("returns an error", func() {
 					Expect(bindErr).To(MatchError(ContainSubstring("binding request failed")))
 				})
+
+				It("does not require orphan mitigation", func() {
+					Expect(osbapi.RequiresOrphanMitigation(bindErr)).To(BeFalse())
+				})
+			})
+
+			When("binding request fails with a server error", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						nil,
+						http.StatusInternalServerError,
+					)
+				})
+
+				It("requires orphan mitigation", func() {
+					Expect(bindErr).To(MatchError(ContainSubstring("binding request failed")))
+					Expect(osbapi.RequiresOrphanMitigation(bindErr)).To(BeTrue())
+				})
+			})
+
+			When("the broker responds with a malformed created response", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}/service_bindings/{binding_id}",
+						map[string]any{
+							"credentials": "not-an-object",
+						},
+						http.StatusCreated,
+					)
+				})
+
+				It("requires orphan mitigation", func() {
+					Expect(bindErr).To(MatchError(ContainSubstring("failed to unmarshal response")))
+					Expect(osbapi.RequiresOrphanMitigation(bindErr)).To(BeTrue())
+				})
 			})
 
 			When("binding request fails with 409 Conflict", func() {
@@ -617,6 +852,82 @@ var _ = Describe("OSBAPI Client", func() {
 			})
 		})
 
+		Describe("GetServiceInstance", func() {
+			var (
+				instanceResp   osbapi.ServiceInstanceResponse
+				getInstanceErr error
+			)
+			BeforeEach(func() {
+				brokerServer.WithResponse(
+					"/v2/service_instances/{instance_id}",
+					map[string]any{
+						"service_id": "my-service-offering-id",
+						"plan_id":    "my-plan-id",
+						"parameters": map[string]string{
+							"billing-account": "abcde12345",
+						},
+					},
+					http.StatusOK,
+				)
+			})
+			JustBeforeEach(func() {
+				instanceResp, getInstanceErr = brokerClient.GetServiceInstance(ctx, osbapi.GetServiceInstanceRequest{
+					InstanceID: "my-service-instance",
+					ServiceId:  "my-service-offering-id",
+					PlanID:     "my-plan-id",
+				})
+			})
+
+			It("gets the service instance", func() {
+				Expect(getInstanceErr).NotTo(HaveOccurred())
+
+				requests := brokerServer.ServedRequests()
+				Expect(requests).To(HaveLen(1))
+				Expect(requests[0].Method).To(Equal(http.MethodGet))
+				Expect(requests[0].URL.Path).To(Equal("/v2/service_instances/my-service-instance"))
+				Expect(requests[0].URL.Query()).To(BeEquivalentTo(map[string][]string{
+					"service_id": {"my-service-offering-id"},
+					"plan_id":    {"my-plan-id"},
+				}))
+
+				Expect(instanceResp).To(Equal(osbapi.ServiceInstanceResponse{
+					ServiceID: "my-service-offering-id",
+					PlanID:    "my-plan-id",
+					Parameters: map[string]any{
+						"billing-account": "abcde12345",
+					},
+				}))
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}",
+						nil,
+						http.StatusNotFound,
+					)
+				})
+
+				It("returns an unrecoverable error", func() {
+					Expect(getInstanceErr).To(MatchError(ContainSubstring(fmt.Sprintf("The server responded with status: %d", http.StatusNotFound))))
+				})
+			})
+
+			When("fetching the service instance fails", func() {
+				BeforeEach(func() {
+					brokerServer = brokerServer.WithResponse(
+						"/v2/service_instances/{instance_id}",
+						nil,
+						http.StatusTeapot,
+					)
+				})
+
+				It("returns an error", func() {
+					Expect(getInstanceErr).To(MatchError(ContainSubstring(strconv.Itoa(http.StatusTeapot))))
+				})
+			})
+		})
+
 		Describe("GetServiceBinding", func() {
 			var (
 				bindingResp osbapi.BindingResponse
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tools"
@@ -14,10 +15,15 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// brokerRequestTimeout bounds broker requests, so that requests to brokers
+// which never respond fail (and are orphan mitigated) instead of hanging
+const brokerRequestTimeout = 60 * time.Second
+
 //counterfeiter:generate -o fake -fake-name BrokerClient code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi.BrokerClient
 type BrokerClient interface {
 	Provision(context.Context, ProvisionPayload) (ProvisionResponse, error)
 	Deprovision(context.Context, DeprovisionPayload) (ProvisionResponse, error)
+	GetServiceInstance(context.Context, GetServiceInstanceRequest) (ServiceInstanceResponse, error)
 	GetServiceInstanceLastOperation(context.Context, GetInstanceLastOperationRequest) (LastOperationResponse, error)
 	GetCatalog(context.Context) (Catalog, error)
 	Bind(context.Context, BindPayload) (BindResponse, error)
@@ -68,8 +74,11 @@ func (f *ClientFactory) CreateClient(ctx context.Context, cfServiceBroker *korif
 			Username: creds["username"],
 			Password: creds["password"],
 		},
-		&http.Client{Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: f.trustInsecureBrokers}, //#nosec G402
-		}},
+		&http.Client{
+			Timeout: brokerRequestTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: f.trustInsecureBrokers}, //#nosec G402
+			},
+		},
 	), nil
 }
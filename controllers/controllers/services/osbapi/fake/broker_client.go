@@ -78,6 +78,20 @@ type BrokerClient struct {
 		result1 osbapi.LastOperationResponse
 		result2 error
 	}
+	GetServiceInstanceStub        func(context.Context, osbapi.GetServiceInstanceRequest) (osbapi.ServiceInstanceResponse, error)
+	getServiceInstanceMutex       sync.RWMutex
+	getServiceInstanceArgsForCall []struct {
+		arg1 context.Context
+		arg2 osbapi.GetServiceInstanceRequest
+	}
+	getServiceInstanceReturns struct {
+		result1 osbapi.ServiceInstanceResponse
+		result2 error
+	}
+	getServiceInstanceReturnsOnCall map[int]struct {
+		result1 osbapi.ServiceInstanceResponse
+		result2 error
+	}
 	GetServiceInstanceLastOperationStub        func(context.Context, osbapi.GetInstanceLastOperationRequest) (osbapi.LastOperationResponse, error)
 	getServiceInstanceLastOperationMutex       sync.RWMutex
 	getServiceInstanceLastOperationArgsForCall []struct {
@@ -448,6 +462,71 @@ func (fake *BrokerClient) GetServiceBindingLastOperationReturnsOnCall(i int, res
 	}{result1, result2}
 }
 
+func (fake *BrokerClient) GetServiceInstance(arg1 context.Context, arg2 osbapi.GetServiceInstanceRequest) (osbapi.ServiceInstanceResponse, error) {
+	fake.getServiceInstanceMutex.Lock()
+	ret, specificReturn := fake.getServiceInstanceReturnsOnCall[len(fake.getServiceInstanceArgsForCall)]
+	fake.getServiceInstanceArgsForCall = append(fake.getServiceInstanceArgsForCall, struct {
+		arg1 context.Context
+		arg2 osbapi.GetServiceInstanceRequest
+	}{arg1, arg2})
+	stub := fake.GetServiceInstanceStub
+	fakeReturns := fake.getServiceInstanceReturns
+	fake.recordInvocation("GetServiceInstance", []interface{}{arg1, arg2})
+	fake.getServiceInstanceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *BrokerClient) GetServiceInstanceCallCount() int {
+	fake.getServiceInstanceMutex.RLock()
+	defer fake.getServiceInstanceMutex.RUnlock()
+	return len(fake.getServiceInstanceArgsForCall)
+}
+
+func (fake *BrokerClient) GetServiceInstanceCalls(stub func(context.Context, osbapi.GetServiceInstanceRequest) (osbapi.ServiceInstanceResponse, error)) {
+	fake.getServiceInstanceMutex.Lock()
+	defer fake.getServiceInstanceMutex.Unlock()
+	fake.GetServiceInstanceStub = stub
+}
+
+func (fake *BrokerClient) GetServiceInstanceArgsForCall(i int) (context.Context, osbapi.GetServiceInstanceRequest) {
+	fake.getServiceInstanceMutex.RLock()
+	defer fake.getServiceInstanceMutex.RUnlock()
+	argsForCall := fake.getServiceInstanceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *BrokerClient) GetServiceInstanceReturns(result1 osbapi.ServiceInstanceResponse, result2 error) {
+	fake.getServiceInstanceMutex.Lock()
+	defer fake.getServiceInstanceMutex.Unlock()
+	fake.GetServiceInstanceStub = nil
+	fake.getServiceInstanceReturns = struct {
+		result1 osbapi.ServiceInstanceResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *BrokerClient) GetServiceInstanceReturnsOnCall(i int, result1 osbapi.ServiceInstanceResponse, result2 error) {
+	fake.getServiceInstanceMutex.Lock()
+	defer fake.getServiceInstanceMutex.Unlock()
+	fake.GetServiceInstanceStub = nil
+	if fake.getServiceInstanceReturnsOnCall == nil {
+		fake.getServiceInstanceReturnsOnCall = make(map[int]struct {
+			result1 osbapi.ServiceInstanceResponse
+			result2 error
+		})
+	}
+	fake.getServiceInstanceReturnsOnCall[i] = struct {
+		result1 osbapi.ServiceInstanceResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *BrokerClient) GetServiceInstanceLastOperation(arg1 context.Context, arg2 osbapi.GetInstanceLastOperationRequest) (osbapi.LastOperationResponse, error) {
 	fake.getServiceInstanceLastOperationMutex.Lock()
 	ret, specificReturn := fake.getServiceInstanceLastOperationReturnsOnCall[len(fake.getServiceInstanceLastOperationArgsForCall)]
@@ -656,6 +735,8 @@ func (fake *BrokerClient) Invocations() map[string][][]interface{} {
 	defer fake.getServiceBindingMutex.RUnlock()
 	fake.getServiceBindingLastOperationMutex.RLock()
 	defer fake.getServiceBindingLastOperationMutex.RUnlock()
+	fake.getServiceInstanceMutex.RLock()
+	defer fake.getServiceInstanceMutex.RUnlock()
 	fake.getServiceInstanceLastOperationMutex.RLock()
 	defer fake.getServiceInstanceLastOperationMutex.RUnlock()
 	fake.provisionMutex.RLock()
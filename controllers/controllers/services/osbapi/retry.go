@@ -0,0 +1,35 @@
+package osbapi
+
+import "time"
+
+// RetryPolicy defines the exponential backoff between attempts to send a
+// request to a broker. Unrecoverable errors are never retried
+type RetryPolicy struct {
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxAttempts     int32
+}
+
+// DefaultOrphanMitigationRetryPolicy keeps trying to clean up after failed
+// provision and bind requests for about an hour
+var DefaultOrphanMitigationRetryPolicy = RetryPolicy{
+	InitialInterval: 10 * time.Second,
+	MaxInterval:     10 * time.Minute,
+	MaxAttempts:     12,
+}
+
+// NextRetry returns how long to wait before the next attempt given the
+// number of failed attempts so far and the error of the last one. It returns
+// false if no further attempts should be made
+func (p RetryPolicy) NextRetry(failedAttempts int32, err error) (time.Duration, bool) {
+	if IsUnrecoveralbeError(err) || failedAttempts >= p.MaxAttempts {
+		return 0, false
+	}
+
+	interval := p.InitialInterval
+	for i := int32(1); i < failedAttempts && interval < p.MaxInterval; i++ {
+		interval *= 2
+	}
+
+	return min(interval, p.MaxInterval), true
+}
@@ -0,0 +1,82 @@
+package osbapi_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RetryPolicy", func() {
+	var (
+		policy         osbapi.RetryPolicy
+		failedAttempts int32
+		lastErr        error
+		retryIn        time.Duration
+		retry          bool
+	)
+
+	BeforeEach(func() {
+		policy = osbapi.RetryPolicy{
+			InitialInterval: time.Second,
+			MaxInterval:     5 * time.Second,
+			MaxAttempts:     5,
+		}
+		failedAttempts = 1
+		lastErr = errors.New("boom")
+	})
+
+	JustBeforeEach(func() {
+		retryIn, retry = policy.NextRetry(failedAttempts, lastErr)
+	})
+
+	It("retries after the initial interval", func() {
+		Expect(retry).To(BeTrue())
+		Expect(retryIn).To(Equal(time.Second))
+	})
+
+	When("there have been several failed attempts", func() {
+		BeforeEach(func() {
+			failedAttempts = 3
+		})
+
+		It("backs off exponentially", func() {
+			Expect(retry).To(BeTrue())
+			Expect(retryIn).To(Equal(4 * time.Second))
+		})
+	})
+
+	When("the backoff exceeds the max interval", func() {
+		BeforeEach(func() {
+			failedAttempts = 4
+		})
+
+		It("retries after the max interval", func() {
+			Expect(retry).To(BeTrue())
+			Expect(retryIn).To(Equal(5 * time.Second))
+		})
+	})
+
+	When("the max attempts have been reached", func() {
+		BeforeEach(func() {
+			failedAttempts = 5
+		})
+
+		It("does not retry", func() {
+			Expect(retry).To(BeFalse())
+		})
+	})
+
+	When("the error is unrecoverable", func() {
+		BeforeEach(func() {
+			lastErr = osbapi.UnrecoverableError{Status: http.StatusConflict}
+		})
+
+		It("does not retry", func() {
+			Expect(retry).To(BeFalse())
+		})
+	})
+})
@@ -49,7 +49,8 @@ type ServicePlanSchemas struct {
 }
 
 type MaintenanceInfo struct {
-	Version string `json:"version"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
 }
 
 type ServiceInstanceSchema struct {
@@ -65,17 +66,32 @@ type InputParameterSchema struct {
 	Parameters map[string]any `json:"parameters,omitempty"`
 }
 
+// PlatformContext is the platform specific contextual information sent to
+// brokers in the `context` field of provision and bind requests
+type PlatformContext struct {
+	Platform                string            `json:"platform"`
+	OrganizationGUID        string            `json:"organization_guid"`
+	OrganizationName        string            `json:"organization_name"`
+	OrganizationAnnotations map[string]string `json:"organization_annotations"`
+	SpaceGUID               string            `json:"space_guid"`
+	SpaceName               string            `json:"space_name"`
+	SpaceAnnotations        map[string]string `json:"space_annotations"`
+	InstanceName            string            `json:"instance_name,omitempty"`
+}
+
 type ProvisionPayload struct {
-	InstanceID string
+	InstanceID          string
+	OriginatingIdentity string
 	ProvisionRequest
 }
 
 type ProvisionRequest struct {
-	ServiceId  string         `json:"service_id"`
-	PlanID     string         `json:"plan_id"`
-	SpaceGUID  string         `json:"space_guid"`
-	OrgGUID    string         `json:"organization_guid"`
-	Parameters map[string]any `json:"parameters"`
+	ServiceId  string          `json:"service_id"`
+	PlanID     string          `json:"plan_id"`
+	SpaceGUID  string          `json:"space_guid"`
+	OrgGUID    string          `json:"organization_guid"`
+	Parameters map[string]any  `json:"parameters"`
+	Context    PlatformContext `json:"context"`
 }
 
 type ProvisionResponse struct {
@@ -83,6 +99,19 @@ type ProvisionResponse struct {
 	Operation string `json:"operation,omitempty"`
 }
 
+type GetServiceInstanceRequest struct {
+	InstanceID string
+	ServiceId  string
+	PlanID     string
+}
+
+type ServiceInstanceResponse struct {
+	ServiceID    string         `json:"service_id"`
+	PlanID       string         `json:"plan_id"`
+	DashboardURL string         `json:"dashboard_url"`
+	Parameters   map[string]any `json:"parameters"`
+}
+
 type GetBindingRequest struct {
 	InstanceID string
 	BindingID  string
@@ -111,7 +140,8 @@ type GetLastOperationRequestParameters struct {
 	Operation string
 }
 type DeprovisionPayload struct {
-	ID string
+	ID                  string
+	OriginatingIdentity string
 	DeprovisionRequestParamaters
 }
 
@@ -121,23 +151,50 @@ type DeprovisionRequestParamaters struct {
 }
 
 type BindRequest struct {
-	ServiceId    string         `json:"service_id"`
-	PlanID       string         `json:"plan_id"`
-	AppGUID      string         `json:"app_guid"`
-	BindResource BindResource   `json:"bind_resource"`
-	Parameters   map[string]any `json:"parameters"`
+	ServiceId    string          `json:"service_id"`
+	PlanID       string          `json:"plan_id"`
+	AppGUID      string          `json:"app_guid"`
+	BindResource BindResource    `json:"bind_resource"`
+	Parameters   map[string]any  `json:"parameters"`
+	Context      PlatformContext `json:"context"`
+
+	// PredecessorBindingID is the ID of the binding being rotated
+	PredecessorBindingID string `json:"predecessor_binding_id,omitempty"`
 }
 
 type BindPayload struct {
-	BindingID  string
-	InstanceID string
+	BindingID           string
+	InstanceID          string
+	OriginatingIdentity string
 	BindRequest
 }
 
 type BindResponse struct {
-	Credentials map[string]any `json:"credentials"`
-	Operation   string         `json:"operation"`
-	IsAsync     bool
+	Credentials  map[string]any  `json:"credentials"`
+	VolumeMounts []VolumeMount   `json:"volume_mounts"`
+	Metadata     BindingMetadata `json:"metadata"`
+	Operation    string          `json:"operation"`
+	IsAsync      bool
+}
+
+// BindingMetadata is the binding metadata returned by brokers. The
+// timestamps are in ISO 8601 format
+type BindingMetadata struct {
+	ExpiresAt   string `json:"expires_at,omitempty"`
+	RenewBefore string `json:"renew_before,omitempty"`
+}
+
+type VolumeMount struct {
+	Driver       string `json:"driver"`
+	ContainerDir string `json:"container_dir"`
+	Mode         string `json:"mode"`
+	DeviceType   string `json:"device_type"`
+	Device       Device `json:"device"`
+}
+
+type Device struct {
+	VolumeID    string         `json:"volume_id"`
+	MountConfig map[string]any `json:"mount_config"`
 }
 
 type BindingResponse struct {
@@ -149,8 +206,9 @@ type BindResource struct {
 }
 
 type UnbindPayload struct {
-	BindingID  string
-	InstanceID string
+	BindingID           string
+	InstanceID          string
+	OriginatingIdentity string
 	UnbindRequestParameters
 }
 
@@ -0,0 +1,37 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetWorkloadScheduling returns the scheduling constraints of the isolation
+// segment that the space namespace is assigned to, or nil if the space is not
+// assigned to an isolation segment. The assignment is recorded as a label on
+// the namespace by the CFSpace controller.
+func GetWorkloadScheduling(ctx context.Context, k8sClient client.Client, rootNamespace, spaceNamespace string) (*korifiv1alpha1.WorkloadScheduling, error) {
+	namespace := new(corev1.Namespace)
+	err := k8sClient.Get(ctx, types.NamespacedName{Name: spaceNamespace}, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace %q: %w", spaceNamespace, err)
+	}
+
+	isolationSegmentGUID := namespace.Labels[korifiv1alpha1.IsolationSegmentGUIDLabelKey]
+	if isolationSegmentGUID == "" {
+		return nil, nil
+	}
+
+	isolationSegment := new(korifiv1alpha1.CFIsolationSegment)
+	err = k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: isolationSegmentGUID}, isolationSegment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get isolation segment %q: %w", isolationSegmentGUID, err)
+	}
+
+	return isolationSegment.Spec.Scheduling.DeepCopy(), nil
+}
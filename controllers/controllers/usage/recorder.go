@@ -0,0 +1,226 @@
+package usage
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ManagedServiceInstanceType      = "managed_service_instance"
+	UserProvidedServiceInstanceType = "user_provided_service_instance"
+)
+
+// Recorder appends usage events to the usage event log in the root namespace
+// whenever the billable usage of a process or a service instance changes.
+//
+// Event names are derived from the UID of the recorded object and the number
+// of events recorded for it so far, so recording the same transition twice
+// (e.g. after a failed status update) results in a single event.
+type Recorder struct {
+	k8sClient     client.Client
+	rootNamespace string
+}
+
+func NewRecorder(k8sClient client.Client, rootNamespace string) *Recorder {
+	return &Recorder{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+	}
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfusageevents,verbs=get;list;watch;create
+
+// RecordAppUsage records a STARTED, STOPPED or SCALED event when the usage of
+// the process differs from the last recorded one and updates the process
+// status accordingly. A process of an app that is being deleted is considered
+// stopped.
+func (r *Recorder) RecordAppUsage(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) error {
+	current := korifiv1alpha1.RecordedAppUsage{
+		State:     korifiv1alpha1.AppUsageStateStopped,
+		Instances: tools.ZeroIfNil(cfProcess.Spec.DesiredInstances),
+		MemoryMB:  cfProcess.Spec.MemoryMB,
+	}
+	if isRunning(cfApp, cfProcess) {
+		current.State = korifiv1alpha1.AppUsageStateStarted
+	}
+
+	last := cfProcess.Status.LastRecordedUsage
+	state, changed := appUsageTransition(last, current)
+	if !changed {
+		return nil
+	}
+
+	appUsage := &korifiv1alpha1.AppUsage{
+		AppGUID:               cfApp.Name,
+		AppName:               cfApp.Spec.DisplayName,
+		ProcessGUID:           cfProcess.Name,
+		ProcessType:           cfProcess.Spec.ProcessType,
+		InstanceCount:         current.Instances,
+		MemoryInMBPerInstance: current.MemoryMB,
+		BuildpackName:         buildpackName(cfApp),
+	}
+
+	previousState := ""
+	current.EventCount = 1
+	if last != nil {
+		previousState = last.State
+		appUsage.PreviousInstanceCount = last.Instances
+		appUsage.PreviousMemoryInMBPerInstance = last.MemoryMB
+		current.EventCount = last.EventCount + 1
+	}
+
+	err := r.createEvent(ctx, cfProcess, current.EventCount, cfApp.Namespace, korifiv1alpha1.CFUsageEventSpec{
+		Type:          korifiv1alpha1.AppUsageEventType,
+		State:         state,
+		PreviousState: previousState,
+		App:           appUsage,
+	})
+	if err != nil {
+		return err
+	}
+
+	// SCALED is not a state of its own, the process keeps running
+	current.State = state
+	if state == korifiv1alpha1.AppUsageStateScaled {
+		current.State = korifiv1alpha1.AppUsageStateStarted
+	}
+	cfProcess.Status.LastRecordedUsage = &current
+
+	return nil
+}
+
+func isRunning(cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) bool {
+	return cfApp.GetDeletionTimestamp().IsZero() &&
+		cfApp.Spec.DesiredState == korifiv1alpha1.StartedState &&
+		tools.ZeroIfNil(cfProcess.Spec.DesiredInstances) > 0
+}
+
+func appUsageTransition(last *korifiv1alpha1.RecordedAppUsage, current korifiv1alpha1.RecordedAppUsage) (string, bool) {
+	lastRunning := last != nil && last.State != korifiv1alpha1.AppUsageStateStopped
+	currentRunning := current.State == korifiv1alpha1.AppUsageStateStarted
+
+	switch {
+	case currentRunning && !lastRunning:
+		return korifiv1alpha1.AppUsageStateStarted, true
+	case !currentRunning && lastRunning:
+		return korifiv1alpha1.AppUsageStateStopped, true
+	case currentRunning && (last.Instances != current.Instances || last.MemoryMB != current.MemoryMB):
+		return korifiv1alpha1.AppUsageStateScaled, true
+	default:
+		return "", false
+	}
+}
+
+// Droplets do not record the detected buildpack, so the first buildpack
+// requested by the app is the best approximation available
+func buildpackName(cfApp *korifiv1alpha1.CFApp) string {
+	if len(cfApp.Spec.Lifecycle.Data.Buildpacks) == 0 {
+		return ""
+	}
+
+	return cfApp.Spec.Lifecycle.Data.Buildpacks[0]
+}
+
+// RecordServiceInstanceUsage records a CREATED, UPDATED or DELETED event when
+// the service instance has been created, has changed its name or plan, or is
+// being deleted, and updates the service instance status accordingly. The plan,
+// offering and broker details are taken from the given service usage.
+func (r *Recorder) RecordServiceInstanceUsage(
+	ctx context.Context,
+	serviceInstance *korifiv1alpha1.CFServiceInstance,
+	serviceUsage korifiv1alpha1.ServiceUsage,
+) error {
+	current := korifiv1alpha1.RecordedServiceUsage{
+		State:    korifiv1alpha1.ServiceUsageStateCreated,
+		Name:     serviceInstance.Spec.DisplayName,
+		PlanGUID: serviceInstance.Spec.PlanGUID,
+	}
+
+	last := serviceInstance.Status.LastRecordedUsage
+	switch {
+	case !serviceInstance.GetDeletionTimestamp().IsZero():
+		if last == nil || last.State == korifiv1alpha1.ServiceUsageStateDeleted {
+			return nil
+		}
+		current.State = korifiv1alpha1.ServiceUsageStateDeleted
+	case last == nil:
+		current.State = korifiv1alpha1.ServiceUsageStateCreated
+	case last.Name != current.Name || last.PlanGUID != current.PlanGUID:
+		current.State = korifiv1alpha1.ServiceUsageStateUpdated
+	default:
+		return nil
+	}
+
+	previousState := ""
+	current.EventCount = 1
+	if last != nil {
+		previousState = last.State
+		current.EventCount = last.EventCount + 1
+	}
+
+	serviceUsage.ServiceInstanceGUID = serviceInstance.Name
+	serviceUsage.ServiceInstanceName = serviceInstance.Spec.DisplayName
+	serviceUsage.ServiceInstanceType = UserProvidedServiceInstanceType
+	if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		serviceUsage.ServiceInstanceType = ManagedServiceInstanceType
+	}
+
+	err := r.createEvent(ctx, serviceInstance, current.EventCount, serviceInstance.Namespace, korifiv1alpha1.CFUsageEventSpec{
+		Type:          korifiv1alpha1.ServiceUsageEventType,
+		State:         current.State,
+		PreviousState: previousState,
+		Service:       &serviceUsage,
+	})
+	if err != nil {
+		return err
+	}
+
+	serviceInstance.Status.LastRecordedUsage = &current
+
+	return nil
+}
+
+func (r *Recorder) createEvent(
+	ctx context.Context,
+	recordedObject client.Object,
+	eventCount int64,
+	spaceNamespace string,
+	spec korifiv1alpha1.CFUsageEventSpec,
+) error {
+	namespace := &corev1.Namespace{}
+	if err := r.k8sClient.Get(ctx, client.ObjectKey{Name: spaceNamespace}, namespace); err != nil {
+		return fmt.Errorf("failed to get space namespace %q: %w", spaceNamespace, err)
+	}
+
+	spec.Timestamp = metav1.NewMicroTime(time.Now())
+	spec.SpaceGUID = spaceNamespace
+	spec.SpaceName = namespace.Annotations[korifiv1alpha1.CFSpaceDisplayNameKey]
+	spec.OrgGUID = namespace.Labels[korifiv1alpha1.CFOrgGUIDKey]
+
+	event := &korifiv1alpha1.CFUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      tools.NamespacedUUID(string(recordedObject.GetUID()), strconv.FormatInt(eventCount, 10)),
+			Labels: map[string]string{
+				korifiv1alpha1.UsageEventTypeLabelKey: string(spec.Type),
+			},
+		},
+		Spec: spec,
+	}
+
+	if err := r.k8sClient.Create(ctx, event); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create usage event: %w", err)
+	}
+
+	return nil
+}
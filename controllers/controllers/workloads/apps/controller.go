@@ -9,6 +9,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/tools/k8s/conditions"
@@ -39,15 +40,17 @@ type Reconciler struct {
 	scheme                    *runtime.Scheme
 	vcapServicesEnvBuilder    EnvValueBuilder
 	vcapApplicationEnvBuilder EnvValueBuilder
+	usageRecorder             *usage.Recorder
 }
 
-func NewReconciler(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger, vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder) *k8s.PatchingReconciler[korifiv1alpha1.CFApp] {
+func NewReconciler(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger, vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder, usageRecorder *usage.Recorder) *k8s.PatchingReconciler[korifiv1alpha1.CFApp] {
 	appReconciler := Reconciler{
 		log:                       log,
 		k8sClient:                 k8sClient,
 		scheme:                    scheme,
 		vcapServicesEnvBuilder:    vcapServicesBuilder,
 		vcapApplicationEnvBuilder: vcapApplicationBuilder,
+		usageRecorder:             usageRecorder,
 	}
 	return k8s.NewPatchingReconciler(log, k8sClient, &appReconciler)
 }
@@ -186,8 +189,12 @@ func (r *Reconciler) getServiceBindings(ctx context.Context, cfApp *korifiv1alph
 		return nil, err
 	}
 
+	// Bindings rotating a previous binding are only picked up once the
+	// rotation completes, until then the previous binding stays in use.
+	// Superseded bindings are kept until the user deletes them, but are no
+	// longer used
 	return slices.Collect(it.Exclude(slices.Values(bindings.Items), func(b korifiv1alpha1.CFServiceBinding) bool {
-		return b.DeletionTimestamp != nil
+		return b.DeletionTimestamp != nil || b.Spec.PreviousBinding.Name != "" || b.Annotations[korifiv1alpha1.SupersededByAnnotation] != ""
 	})), nil
 }
 
@@ -205,13 +212,29 @@ func bindingObjectRefs(bindings []korifiv1alpha1.CFServiceBinding) []korifiv1alp
 		}
 
 		return korifiv1alpha1.ServiceBinding{
-			GUID:   binding.Name,
-			Name:   bindingName,
-			Secret: binding.Status.MountSecretRef.Name,
+			GUID:         binding.Name,
+			Name:         bindingName,
+			Secret:       binding.Status.MountSecretRef.Name,
+			VolumeMounts: bindingVolumeMounts(binding),
 		}
 	}))
 }
 
+func bindingVolumeMounts(binding korifiv1alpha1.CFServiceBinding) []korifiv1alpha1.VolumeMount {
+	var volumeMounts []korifiv1alpha1.VolumeMount
+	for i, volumeMount := range binding.Status.VolumeMounts {
+		volumeMounts = append(volumeMounts, korifiv1alpha1.VolumeMount{
+			Name:                  fmt.Sprintf("%s-volume-%d", binding.Name, i),
+			ContainerDir:          volumeMount.ContainerDir,
+			ReadOnly:              volumeMount.Mode == "r",
+			PersistentVolumeClaim: volumeMount.PersistentVolumeClaim,
+			CSI:                   volumeMount.CSI,
+		})
+	}
+
+	return volumeMounts
+}
+
 func getActualState(processes []*korifiv1alpha1.CFProcess) korifiv1alpha1.AppState {
 	processInstances := int32(0)
 	for _, p := range processes {
@@ -369,6 +392,11 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 		return sbFinalizationResult, nil
 	}
 
+	err = r.recordProcessesStopped(ctx, cfApp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if controllerutil.RemoveFinalizer(cfApp, korifiv1alpha1.CFAppFinalizerName) {
 		log.V(1).Info("finalizer removed")
 	}
@@ -377,7 +405,7 @@ func (r *Reconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 }
 
 func (r *Reconciler) finalizeCFAppRoutes(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
-	cfRoutes, err := r.getCFRoutes(ctx, cfApp.Name, cfApp.Namespace)
+	cfRoutes, err := r.getCFRoutes(ctx, cfApp.Name)
 	if err != nil {
 		return err
 	}
@@ -390,6 +418,24 @@ func (r *Reconciler) finalizeCFAppRoutes(ctx context.Context, cfApp *korifiv1alp
 	return nil
 }
 
+// The app processes are garbage collected once the app is gone, so they may
+// never get the chance to record that they have stopped themselves
+func (r *Reconciler) recordProcessesStopped(ctx context.Context, cfApp *korifiv1alpha1.CFApp) error {
+	cfProcessList := korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, &cfProcessList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		return fmt.Errorf("error listing app CFProcesses: %w", err)
+	}
+
+	for i := range cfProcessList.Items {
+		if err = r.usageRecorder.RecordAppUsage(ctx, cfApp, &cfProcessList.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *Reconciler) finalizeCFServiceBindings(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFServiceBindings")
 
@@ -443,12 +489,13 @@ func (r *Reconciler) updateRouteDestinations(ctx context.Context, cfAppGUID stri
 	return nil
 }
 
-func (r *Reconciler) getCFRoutes(ctx context.Context, cfAppGUID string, cfAppNamespace string) ([]korifiv1alpha1.CFRoute, error) {
+func (r *Reconciler) getCFRoutes(ctx context.Context, cfAppGUID string) ([]korifiv1alpha1.CFRoute, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("getCFRoutes")
 
 	var foundRoutes korifiv1alpha1.CFRouteList
+	// routes in other spaces can have the app as a shared destination
 	matchingFields := client.MatchingFields{shared.IndexRouteDestinationAppName: cfAppGUID}
-	err := r.k8sClient.List(context.Background(), &foundRoutes, client.InNamespace(cfAppNamespace), matchingFields)
+	err := r.k8sClient.List(context.Background(), &foundRoutes, matchingFields)
 	if err != nil {
 		log.Info("failed to List CFRoutes", "reason", err)
 		return []korifiv1alpha1.CFRoute{}, err
@@ -464,6 +464,38 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the binding is rotating a previous binding", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, binding, func() {
+					binding.Spec.PreviousBinding.Name = "previous-binding"
+				})).To(Succeed())
+			})
+
+			It("does not add the binding to the app status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+					g.Expect(cfApp.Status.ServiceBindings).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the binding has been superseded by a rotated successor", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, binding, func() {
+					binding.Annotations = tools.SetMapValue(binding.Annotations, korifiv1alpha1.SupersededByAnnotation, "successor-binding")
+				})).To(Succeed())
+			})
+
+			It("does not add the binding to the app status", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(cfApp.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+					g.Expect(cfApp.Status.ServiceBindings).To(BeEmpty())
+				}).Should(Succeed())
+			})
+		})
+
 		When("the binding becomes ready", func() {
 			BeforeEach(func() {
 				Expect(k8s.Patch(ctx, adminClient, binding, func() {
@@ -501,13 +533,46 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 					}).Should(Succeed())
 				})
 			})
+
+			When("the binding has volume mounts", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, binding, func() {
+						binding.Status.VolumeMounts = []korifiv1alpha1.ServiceBindingVolumeMount{{
+							Driver:       "nfs.csi.k8s.io",
+							ContainerDir: "/data/nfs",
+							Mode:         "r",
+							DeviceType:   "shared",
+							CSI: &corev1.CSIVolumeSource{
+								Driver: "nfs.csi.k8s.io",
+							},
+						}}
+					})).To(Succeed())
+				})
+
+				It("adds the volume mounts to the app service bindings", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						g.Expect(cfApp.Status.ServiceBindings).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"VolumeMounts": ConsistOf(korifiv1alpha1.VolumeMount{
+								Name:         binding.Name + "-volume-0",
+								ContainerDir: "/data/nfs",
+								ReadOnly:     true,
+								CSI: &corev1.CSIVolumeSource{
+									Driver: "nfs.csi.k8s.io",
+								},
+							}),
+						})))
+					}).Should(Succeed())
+				})
+			})
 		})
 	})
 
 	Describe("finalization", func() {
 		var (
-			cfDomainGUID string
-			cfRoute      *korifiv1alpha1.CFRoute
+			cfDomainGUID   string
+			cfRoute        *korifiv1alpha1.CFRoute
+			runningProcess *korifiv1alpha1.CFProcess
 		)
 
 		BeforeEach(func() {
@@ -564,9 +629,52 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 			}
 			Expect(adminClient.Create(ctx, &cfServiceBinding)).To(Succeed())
 
+			runningProcess = &korifiv1alpha1.CFProcess{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: testNamespace,
+					Labels: map[string]string{
+						korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+						korifiv1alpha1.CFProcessTypeLabelKey: "worker",
+					},
+				},
+				Spec: korifiv1alpha1.CFProcessSpec{
+					AppRef:           corev1.LocalObjectReference{Name: cfApp.Name},
+					ProcessType:      "worker",
+					DesiredInstances: tools.PtrTo[int32](2),
+					MemoryMB:         256,
+				},
+			}
+			Expect(adminClient.Create(ctx, runningProcess)).To(Succeed())
+			Expect(k8s.Patch(ctx, adminClient, runningProcess, func() {
+				runningProcess.Status.LastRecordedUsage = &korifiv1alpha1.RecordedAppUsage{
+					State:      korifiv1alpha1.AppUsageStateStarted,
+					Instances:  2,
+					MemoryMB:   256,
+					EventCount: 1,
+				}
+			})).To(Succeed())
+
 			Expect(k8sManager.GetClient().Delete(ctx, cfApp)).To(Succeed())
 		})
 
+		It("records a STOPPED usage event for the running app processes", func() {
+			Eventually(func(g Gomega) {
+				var usageEvents korifiv1alpha1.CFUsageEventList
+				g.Expect(adminClient.List(ctx, &usageEvents, client.InNamespace(testNamespace))).To(Succeed())
+				g.Expect(usageEvents.Items).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"State":         Equal(korifiv1alpha1.AppUsageStateStopped),
+						"PreviousState": Equal(korifiv1alpha1.AppUsageStateStarted),
+						"App": PointTo(MatchFields(IgnoreExtras, Fields{
+							"ProcessGUID":           Equal(runningProcess.Name),
+							"PreviousInstanceCount": BeEquivalentTo(2),
+						})),
+					}),
+				})))
+			}).Should(Succeed())
+		})
+
 		It("deletes the app", func() {
 			Eventually(func(g Gomega) {
 				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)
@@ -8,6 +8,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
 	"code.cloudfoundry.org/korifi/tests/helpers"
@@ -71,16 +72,17 @@ var _ = BeforeEach(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	testNamespace = uuid.NewString()
 	err := apps.NewReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFApp"),
 		env.NewVCAPServicesEnvValueBuilder(k8sManager.GetClient()),
 		env.NewVCAPApplicationEnvValueBuilder(k8sManager.GetClient(), nil),
+		usage.NewRecorder(k8sManager.GetClient(), testNamespace),
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
-	testNamespace = uuid.NewString()
 	Expect(adminClient.Create(ctx, &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: testNamespace,
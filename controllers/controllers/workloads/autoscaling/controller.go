@@ -0,0 +1,183 @@
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/autoscaling/evaluator"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const maxScalingHistory = 100
+
+type Reconciler struct {
+	k8sClient          client.Client
+	scheme             *runtime.Scheme
+	log                logr.Logger
+	metricsFetcher     MetricsFetcher
+	evaluationInterval time.Duration
+}
+
+func NewReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	metricsFetcher MetricsFetcher,
+	evaluationInterval time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFAutoscalingPolicy] {
+	policyReconciler := Reconciler{
+		k8sClient:          client,
+		scheme:             scheme,
+		log:                log,
+		metricsFetcher:     metricsFetcher,
+		evaluationInterval: evaluationInterval,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFAutoscalingPolicy](log, client, &policyReconciler)
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFAutoscalingPolicy{})
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfautoscalingpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfautoscalingpolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfautoscalingpolicies/finalizers,verbs=update
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+//+kubebuilder:rbac:groups=metrics.k8s.io,resources=pods,verbs=get;list
+//+kubebuilder:rbac:groups=custom.metrics.k8s.io,resources=pods/*,verbs=get;list
+
+func (r *Reconciler) ReconcileResource(ctx context.Context, policy *korifiv1alpha1.CFAutoscalingPolicy) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !policy.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	policy.Status.ObservedGeneration = policy.Generation
+	log.V(1).Info("set observed generation", "generation", policy.Status.ObservedGeneration)
+
+	cfProcess := new(korifiv1alpha1.CFProcess)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: policy.Spec.ProcessRef.Name, Namespace: policy.Namespace}, cfProcess)
+	if err != nil {
+		log.Info("error when trying to fetch CFProcess", "namespace", policy.Namespace, "name", policy.Spec.ProcessRef.Name, "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	err = controllerutil.SetControllerReference(cfProcess, policy, r.scheme)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfProcess.Spec.AppRef.Name, Namespace: policy.Namespace}, cfApp)
+	if err != nil {
+		log.Info("error when trying to fetch CFApp", "namespace", policy.Namespace, "name", cfProcess.Spec.AppRef.Name, "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	currentInstances := tools.ZeroIfNil(cfProcess.Spec.DesiredInstances)
+	if cfApp.Spec.DesiredState != korifiv1alpha1.StartedState || currentInstances == 0 {
+		log.V(1).Info("app is not running, skipping evaluation", "app", cfApp.Name)
+		policy.Status.Breaches = nil
+		return ctrl.Result{RequeueAfter: r.evaluationInterval}, nil
+	}
+
+	metrics, err := r.metricsFetcher.Fetch(ctx, cfProcess)
+	if err != nil {
+		log.Info("failed to fetch process metrics", "reason", err)
+		return ctrl.Result{}, err
+	}
+	setMetricsAvailableCondition(policy, evaluator.MissingMetrics(policy, metrics))
+
+	now := time.Now()
+	decision, err := evaluator.Evaluate(policy, currentInstances, metrics, now)
+	if err != nil {
+		return ctrl.Result{}, k8s.NewNotReadyError().WithCause(err).WithReason("InvalidPolicy")
+	}
+
+	if decision != nil {
+		r.scale(ctx, policy, cfProcess, currentInstances, *decision, now)
+	}
+
+	return ctrl.Result{RequeueAfter: r.evaluationInterval}, nil
+}
+
+func (r *Reconciler) scale(
+	ctx context.Context,
+	policy *korifiv1alpha1.CFAutoscalingPolicy,
+	cfProcess *korifiv1alpha1.CFProcess,
+	currentInstances int32,
+	decision evaluator.Decision,
+	now time.Time,
+) {
+	log := logr.FromContextOrDiscard(ctx).WithName("scale")
+
+	event := korifiv1alpha1.ScalingEvent{
+		Timestamp:    metav1.NewTime(now),
+		ScalingType:  decision.ScalingType,
+		Status:       korifiv1alpha1.ScalingStatusSucceeded,
+		OldInstances: currentInstances,
+		NewInstances: decision.NewInstances,
+		Reason:       decision.Reason,
+	}
+
+	err := k8s.PatchResource(ctx, r.k8sClient, cfProcess, func() {
+		cfProcess.Spec.DesiredInstances = tools.PtrTo(decision.NewInstances)
+	})
+	if err != nil {
+		log.Info("failed to scale process", "process", cfProcess.Name, "reason", err)
+		event.Status = korifiv1alpha1.ScalingStatusFailed
+		event.Error = err.Error()
+	} else {
+		log.Info("scaled process", "process", cfProcess.Name, "from", currentInstances, "to", decision.NewInstances, "reason", decision.Reason)
+		policy.Status.Breaches = nil
+		policy.Status.CoolDownExpiresAt = tools.PtrTo(metav1.NewTime(now.Add(decision.CoolDown)))
+	}
+
+	policy.Status.ScalingHistory = append(policy.Status.ScalingHistory, event)
+	if len(policy.Status.ScalingHistory) > maxScalingHistory {
+		policy.Status.ScalingHistory = policy.Status.ScalingHistory[len(policy.Status.ScalingHistory)-maxScalingHistory:]
+	}
+}
+
+func setMetricsAvailableCondition(policy *korifiv1alpha1.CFAutoscalingPolicy, missingMetrics []korifiv1alpha1.AutoscalingMetricType) {
+	if len(missingMetrics) == 0 {
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:               korifiv1alpha1.MetricsAvailableConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "MetricsAvailable",
+			ObservedGeneration: policy.Generation,
+		})
+		return
+	}
+
+	missing := make([]string, 0, len(missingMetrics))
+	for _, metric := range missingMetrics {
+		missing = append(missing, string(metric))
+	}
+
+	meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.MetricsAvailableConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "MetricsMissing",
+		Message:            fmt.Sprintf("no values available for metrics: %s", strings.Join(missing, ", ")),
+		ObservedGeneration: policy.Generation,
+	})
+}
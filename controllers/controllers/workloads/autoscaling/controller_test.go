@@ -0,0 +1,217 @@
+package autoscaling_test
+
+import (
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/autoscaling/evaluator"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFAutoscalingPolicyReconciler Integration Tests", func() {
+	var (
+		cfApp     *korifiv1alpha1.CFApp
+		cfProcess *korifiv1alpha1.CFProcess
+		policy    *korifiv1alpha1.CFAutoscalingPolicy
+	)
+
+	BeforeEach(func() {
+		metricsFetcher.FetchReturns(evaluator.ProcessMetrics{
+			korifiv1alpha1.AutoscalingMetricCPU: 50,
+		}, nil)
+
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "test-app",
+				DesiredState: korifiv1alpha1.StartedState,
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+		cfProcess = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:           corev1.LocalObjectReference{Name: cfApp.Name},
+				ProcessType:      korifiv1alpha1.ProcessTypeWeb,
+				DesiredInstances: tools.PtrTo[int32](2),
+				MemoryMB:         256,
+			},
+		}
+		Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+
+		policy = &korifiv1alpha1.CFAutoscalingPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      cfProcess.Name,
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFAutoscalingPolicySpec{
+				ProcessRef:       corev1.LocalObjectReference{Name: cfProcess.Name},
+				InstanceMinCount: 1,
+				InstanceMaxCount: 4,
+				ScalingRules: []korifiv1alpha1.ScalingRule{{
+					MetricType:      korifiv1alpha1.AutoscalingMetricCPU,
+					Threshold:       80,
+					Operator:        ">",
+					CoolDownSeconds: 600,
+					Adjustment:      "+1",
+				}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, policy)).To(Succeed())
+	})
+
+	It("sets the observed generation and the owner reference", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+			g.Expect(policy.Status.ObservedGeneration).To(Equal(policy.Generation))
+			g.Expect(policy.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("CFProcess"),
+				"Name": Equal(cfProcess.Name),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("sets the MetricsAvailable condition", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+			g.Expect(policy.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(korifiv1alpha1.MetricsAvailableConditionType),
+				"Status": Equal(metav1.ConditionTrue),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("does not scale the process", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+			g.Expect(cfProcess.Spec.DesiredInstances).To(PointTo(BeEquivalentTo(2)))
+		}, "2s").Should(Succeed())
+	})
+
+	When("a scaling rule is breached", func() {
+		BeforeEach(func() {
+			metricsFetcher.FetchReturns(evaluator.ProcessMetrics{
+				korifiv1alpha1.AutoscalingMetricCPU: 90,
+			}, nil)
+		})
+
+		It("scales the process", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(cfProcess.Spec.DesiredInstances).To(PointTo(BeEquivalentTo(3)))
+			}).Should(Succeed())
+		})
+
+		It("records the scaling event and starts the cool down", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+				g.Expect(policy.Status.ScalingHistory).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"ScalingType":  Equal(korifiv1alpha1.ScalingTypeDynamic),
+					"Status":       Equal(korifiv1alpha1.ScalingStatusSucceeded),
+					"OldInstances": BeEquivalentTo(2),
+					"NewInstances": BeEquivalentTo(3),
+					"Reason":       ContainSubstring("cpu > 80"),
+				})))
+				g.Expect(policy.Status.CoolDownExpiresAt).NotTo(BeNil())
+				g.Expect(policy.Status.CoolDownExpiresAt.Time).To(BeTemporally(">", time.Now().Add(5*time.Minute)))
+			}).Should(Succeed())
+		})
+
+		It("does not scale again while cooling down", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(cfProcess.Spec.DesiredInstances).To(PointTo(BeEquivalentTo(3)))
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(cfProcess.Spec.DesiredInstances).To(PointTo(BeEquivalentTo(3)))
+			}, "3s").Should(Succeed())
+		})
+
+		When("the app is stopped", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+				})).To(Succeed())
+			})
+
+			It("does not scale the process", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					g.Expect(cfProcess.Spec.DesiredInstances).To(PointTo(BeEquivalentTo(2)))
+				}, "2s").Should(Succeed())
+			})
+		})
+	})
+
+	When("the process instances are outside of the policy limits", func() {
+		BeforeEach(func() {
+			policy.Spec.InstanceMinCount = 3
+		})
+
+		It("scales the process within the limits", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(cfProcess.Spec.DesiredInstances).To(PointTo(BeEquivalentTo(3)))
+			}).Should(Succeed())
+		})
+	})
+
+	When("a metric a rule depends on is not available", func() {
+		BeforeEach(func() {
+			policy.Spec.ScalingRules[0].MetricType = korifiv1alpha1.AutoscalingMetricThroughput
+		})
+
+		It("sets the MetricsAvailable condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+				g.Expect(policy.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(korifiv1alpha1.MetricsAvailableConditionType),
+					"Status":  Equal(metav1.ConditionFalse),
+					"Message": ContainSubstring("throughput"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("fetching the metrics fails", func() {
+		BeforeEach(func() {
+			metricsFetcher.FetchReturns(nil, errors.New("metrics-error"))
+		})
+
+		It("sets the ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(policy), policy)).To(Succeed())
+				g.Expect(policy.Status.Conditions).To(ContainElement(SatisfyAll(
+					matchers.HasType(Equal(korifiv1alpha1.StatusConditionReady)),
+					matchers.HasStatus(Equal(metav1.ConditionFalse)),
+				)))
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,152 @@
+package evaluator
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ProcessMetrics holds the value of each metric aggregated across the
+// instances of a process. Metrics that could not be observed are missing.
+type ProcessMetrics map[korifiv1alpha1.AutoscalingMetricType]int64
+
+type Decision struct {
+	NewInstances int32
+	ScalingType  korifiv1alpha1.ScalingType
+	Reason       string
+	CoolDown     time.Duration
+}
+
+// Evaluate decides whether the process scaled by the policy needs to change
+// its number of instances. It keeps track of the active schedule and of the
+// breached rules in the policy status, and returns nil when no scaling is
+// needed.
+func Evaluate(policy *korifiv1alpha1.CFAutoscalingPolicy, currentInstances int32, metrics ProcessMetrics, now time.Time) (*Decision, error) {
+	limits, err := activeLimits(policy, now)
+	if err != nil {
+		return nil, err
+	}
+
+	previousSchedule := policy.Status.ActiveSchedule
+	policy.Status.ActiveSchedule = limits.schedule
+
+	scheduleStarted := limits.schedule != "" && limits.schedule != previousSchedule
+	if scheduleStarted && limits.initialMin != nil && currentInstances < *limits.initialMin {
+		return &Decision{
+			NewInstances: clamp(*limits.initialMin, limits),
+			ScalingType:  korifiv1alpha1.ScalingTypeSchedule,
+			Reason:       fmt.Sprintf("schedule %s started with initial minimum instance count %d", limits.schedule, *limits.initialMin),
+		}, nil
+	}
+
+	if target := clamp(currentInstances, limits); target != currentInstances {
+		scalingType := korifiv1alpha1.ScalingTypeDynamic
+		if limits.schedule != "" {
+			scalingType = korifiv1alpha1.ScalingTypeSchedule
+		}
+
+		return &Decision{
+			NewInstances: target,
+			ScalingType:  scalingType,
+			Reason:       fmt.Sprintf("%d instance(s) outside of the limits [%d, %d]", currentInstances, limits.min, limits.max),
+		}, nil
+	}
+
+	updateBreaches(policy, metrics, now)
+
+	if policy.Status.CoolDownExpiresAt != nil && now.Before(policy.Status.CoolDownExpiresAt.Time) {
+		return nil, nil
+	}
+
+	ruleIndex, ok := firedRule(policy, now)
+	if !ok {
+		return nil, nil
+	}
+
+	rule := policy.Spec.ScalingRules[ruleIndex]
+	adjusted, err := adjustInstances(currentInstances, rule.Adjustment)
+	if err != nil {
+		return nil, err
+	}
+
+	newInstances := clamp(adjusted, limits)
+	if newInstances == currentInstances {
+		return nil, nil
+	}
+
+	return &Decision{
+		NewInstances: newInstances,
+		ScalingType:  korifiv1alpha1.ScalingTypeDynamic,
+		Reason:       describeRule(rule, metrics[rule.MetricType]),
+		CoolDown:     time.Duration(rule.CoolDownSeconds) * time.Second,
+	}, nil
+}
+
+// MissingMetrics returns the metrics the scaling rules of the policy depend
+// on that have not been observed
+func MissingMetrics(policy *korifiv1alpha1.CFAutoscalingPolicy, metrics ProcessMetrics) []korifiv1alpha1.AutoscalingMetricType {
+	missing := []korifiv1alpha1.AutoscalingMetricType{}
+	for _, rule := range policy.Spec.ScalingRules {
+		if _, ok := metrics[rule.MetricType]; !ok && !slices.Contains(missing, rule.MetricType) {
+			missing = append(missing, rule.MetricType)
+		}
+	}
+
+	return missing
+}
+
+func updateBreaches(policy *korifiv1alpha1.CFAutoscalingPolicy, metrics ProcessMetrics, now time.Time) {
+	breaches := []korifiv1alpha1.RuleBreach{}
+	for i, rule := range policy.Spec.ScalingRules {
+		value, ok := metrics[rule.MetricType]
+		if !ok || !isBreached(rule, value) {
+			continue
+		}
+
+		since := metav1.NewTime(now)
+		if existing, found := findBreach(policy.Status.Breaches, int32(i)); found {
+			since = existing.Since
+		}
+
+		breaches = append(breaches, korifiv1alpha1.RuleBreach{Rule: int32(i), Since: since})
+	}
+
+	policy.Status.Breaches = breaches
+}
+
+func findBreach(breaches []korifiv1alpha1.RuleBreach, rule int32) (korifiv1alpha1.RuleBreach, bool) {
+	idx := slices.IndexFunc(breaches, func(b korifiv1alpha1.RuleBreach) bool {
+		return b.Rule == rule
+	})
+	if idx < 0 {
+		return korifiv1alpha1.RuleBreach{}, false
+	}
+
+	return breaches[idx], true
+}
+
+// firedRule returns the first rule that has been breaching for longer than
+// its breach duration, preferring rules that scale out over rules that
+// scale in
+func firedRule(policy *korifiv1alpha1.CFAutoscalingPolicy, now time.Time) (int, bool) {
+	scaleIn := -1
+	for _, breach := range policy.Status.Breaches {
+		rule := policy.Spec.ScalingRules[breach.Rule]
+		if now.Sub(breach.Since.Time) < time.Duration(rule.BreachDurationSeconds)*time.Second {
+			continue
+		}
+
+		if isScaleOut(rule) {
+			return int(breach.Rule), true
+		}
+
+		if scaleIn < 0 {
+			scaleIn = int(breach.Rule)
+		}
+	}
+
+	return scaleIn, scaleIn >= 0
+}
@@ -0,0 +1,312 @@
+package evaluator_test
+
+import (
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/autoscaling/evaluator"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Evaluate", func() {
+	var (
+		policy           *korifiv1alpha1.CFAutoscalingPolicy
+		currentInstances int32
+		metrics          evaluator.ProcessMetrics
+		now              time.Time
+
+		decision    *evaluator.Decision
+		evaluateErr error
+	)
+
+	BeforeEach(func() {
+		// a Wednesday
+		now = time.Date(2024, time.May, 15, 12, 0, 0, 0, time.UTC)
+		currentInstances = 2
+		metrics = evaluator.ProcessMetrics{
+			korifiv1alpha1.AutoscalingMetricCPU:        50,
+			korifiv1alpha1.AutoscalingMetricMemoryUsed: 100,
+		}
+
+		policy = &korifiv1alpha1.CFAutoscalingPolicy{
+			Spec: korifiv1alpha1.CFAutoscalingPolicySpec{
+				InstanceMinCount: 1,
+				InstanceMaxCount: 5,
+				ScalingRules: []korifiv1alpha1.ScalingRule{
+					{
+						MetricType:            korifiv1alpha1.AutoscalingMetricCPU,
+						Threshold:             80,
+						Operator:              ">=",
+						BreachDurationSeconds: 60,
+						CoolDownSeconds:       300,
+						Adjustment:            "+1",
+					},
+					{
+						MetricType:            korifiv1alpha1.AutoscalingMetricCPU,
+						Threshold:             20,
+						Operator:              "<",
+						BreachDurationSeconds: 60,
+						CoolDownSeconds:       120,
+						Adjustment:            "-1",
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		decision, evaluateErr = evaluator.Evaluate(policy, currentInstances, metrics, now)
+	})
+
+	It("does not scale when no rule is breached", func() {
+		Expect(evaluateErr).NotTo(HaveOccurred())
+		Expect(decision).To(BeNil())
+		Expect(policy.Status.Breaches).To(BeEmpty())
+		Expect(policy.Status.ActiveSchedule).To(BeEmpty())
+	})
+
+	When("a rule starts breaching its threshold", func() {
+		BeforeEach(func() {
+			metrics[korifiv1alpha1.AutoscalingMetricCPU] = 90
+		})
+
+		It("records the breach without scaling", func() {
+			Expect(evaluateErr).NotTo(HaveOccurred())
+			Expect(decision).To(BeNil())
+			Expect(policy.Status.Breaches).To(ConsistOf(MatchAllFields(Fields{
+				"Rule":  BeEquivalentTo(0),
+				"Since": Equal(metav1.NewTime(now)),
+			})))
+		})
+
+		When("the rule has been breaching for longer than its breach duration", func() {
+			BeforeEach(func() {
+				policy.Status.Breaches = []korifiv1alpha1.RuleBreach{
+					{Rule: 0, Since: metav1.NewTime(now.Add(-2 * time.Minute))},
+				}
+			})
+
+			It("applies the rule adjustment", func() {
+				Expect(evaluateErr).NotTo(HaveOccurred())
+				Expect(decision).To(PointTo(MatchAllFields(Fields{
+					"NewInstances": BeEquivalentTo(3),
+					"ScalingType":  Equal(korifiv1alpha1.ScalingTypeDynamic),
+					"Reason":       ContainSubstring("cpu >= 80"),
+					"CoolDown":     Equal(5 * time.Minute),
+				})))
+			})
+
+			It("keeps the time the breach started", func() {
+				Expect(policy.Status.Breaches).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Since": Equal(metav1.NewTime(now.Add(-2 * time.Minute))),
+				})))
+			})
+
+			When("the cool down has not expired", func() {
+				BeforeEach(func() {
+					policy.Status.CoolDownExpiresAt = tools.PtrTo(metav1.NewTime(now.Add(time.Minute)))
+				})
+
+				It("does not scale", func() {
+					Expect(evaluateErr).NotTo(HaveOccurred())
+					Expect(decision).To(BeNil())
+				})
+			})
+
+			When("the process already runs the maximum number of instances", func() {
+				BeforeEach(func() {
+					currentInstances = 5
+				})
+
+				It("does not scale", func() {
+					Expect(evaluateErr).NotTo(HaveOccurred())
+					Expect(decision).To(BeNil())
+				})
+			})
+
+			When("the adjustment is relative", func() {
+				BeforeEach(func() {
+					policy.Spec.InstanceMaxCount = 10
+					policy.Spec.ScalingRules[0].Adjustment = "+50%"
+					currentInstances = 3
+				})
+
+				It("rounds the adjustment up", func() {
+					Expect(evaluateErr).NotTo(HaveOccurred())
+					Expect(decision.NewInstances).To(BeEquivalentTo(5))
+				})
+			})
+		})
+	})
+
+	When("a rule is no longer breaching", func() {
+		BeforeEach(func() {
+			policy.Status.Breaches = []korifiv1alpha1.RuleBreach{
+				{Rule: 0, Since: metav1.NewTime(now.Add(-2 * time.Minute))},
+			}
+		})
+
+		It("clears the breach", func() {
+			Expect(evaluateErr).NotTo(HaveOccurred())
+			Expect(decision).To(BeNil())
+			Expect(policy.Status.Breaches).To(BeEmpty())
+		})
+	})
+
+	When("both a scale out and a scale in rule fire", func() {
+		BeforeEach(func() {
+			metrics[korifiv1alpha1.AutoscalingMetricMemoryUsed] = 500
+			policy.Spec.ScalingRules[1] = korifiv1alpha1.ScalingRule{
+				MetricType: korifiv1alpha1.AutoscalingMetricMemoryUsed,
+				Threshold:  200,
+				Operator:   ">",
+				Adjustment: "-1",
+			}
+			policy.Spec.ScalingRules = append(policy.Spec.ScalingRules, korifiv1alpha1.ScalingRule{
+				MetricType: korifiv1alpha1.AutoscalingMetricMemoryUsed,
+				Threshold:  400,
+				Operator:   ">",
+				Adjustment: "+2",
+			})
+		})
+
+		It("prefers scaling out", func() {
+			Expect(evaluateErr).NotTo(HaveOccurred())
+			Expect(decision.NewInstances).To(BeEquivalentTo(4))
+		})
+	})
+
+	When("a rule depends on a metric that is not available", func() {
+		BeforeEach(func() {
+			delete(metrics, korifiv1alpha1.AutoscalingMetricCPU)
+			policy.Status.Breaches = []korifiv1alpha1.RuleBreach{
+				{Rule: 0, Since: metav1.NewTime(now.Add(-2 * time.Minute))},
+			}
+		})
+
+		It("ignores the rule", func() {
+			Expect(evaluateErr).NotTo(HaveOccurred())
+			Expect(decision).To(BeNil())
+			Expect(policy.Status.Breaches).To(BeEmpty())
+		})
+	})
+
+	When("the current instances are outside of the limits", func() {
+		BeforeEach(func() {
+			currentInstances = 7
+		})
+
+		It("scales the process within the limits", func() {
+			Expect(evaluateErr).NotTo(HaveOccurred())
+			Expect(decision).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"NewInstances": BeEquivalentTo(5),
+				"ScalingType":  Equal(korifiv1alpha1.ScalingTypeDynamic),
+			})))
+		})
+	})
+
+	Describe("schedules", func() {
+		BeforeEach(func() {
+			policy.Spec.Schedules = &korifiv1alpha1.AutoscalingSchedules{
+				Timezone: "Europe/London",
+				RecurringSchedules: []korifiv1alpha1.RecurringSchedule{{
+					ScheduleLimits: korifiv1alpha1.ScheduleLimits{
+						InstanceMinCount:        4,
+						InstanceMaxCount:        8,
+						InitialMinInstanceCount: tools.PtrTo[int32](6),
+					},
+					StartTime:  "12:30",
+					EndTime:    "14:00",
+					DaysOfWeek: []int32{3},
+				}},
+			}
+		})
+
+		It("applies the recurring schedule in its time zone", func() {
+			Expect(evaluateErr).NotTo(HaveOccurred())
+			Expect(policy.Status.ActiveSchedule).To(Equal("recurring-0"))
+			Expect(decision).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"NewInstances": BeEquivalentTo(6),
+				"ScalingType":  Equal(korifiv1alpha1.ScalingTypeSchedule),
+			})))
+		})
+
+		When("the schedule was already active", func() {
+			BeforeEach(func() {
+				policy.Status.ActiveSchedule = "recurring-0"
+			})
+
+			It("scales to the schedule minimum rather than the initial minimum", func() {
+				Expect(evaluateErr).NotTo(HaveOccurred())
+				Expect(decision).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"NewInstances": BeEquivalentTo(4),
+					"ScalingType":  Equal(korifiv1alpha1.ScalingTypeSchedule),
+				})))
+			})
+		})
+
+		When("the schedule is not active on the current day", func() {
+			BeforeEach(func() {
+				policy.Spec.Schedules.RecurringSchedules[0].DaysOfWeek = []int32{1, 7}
+			})
+
+			It("applies the default limits", func() {
+				Expect(evaluateErr).NotTo(HaveOccurred())
+				Expect(policy.Status.ActiveSchedule).To(BeEmpty())
+				Expect(decision).To(BeNil())
+			})
+		})
+
+		When("a specific date schedule is active at the same time", func() {
+			BeforeEach(func() {
+				policy.Spec.Schedules.SpecificDates = []korifiv1alpha1.SpecificDateSchedule{{
+					ScheduleLimits: korifiv1alpha1.ScheduleLimits{
+						InstanceMinCount: 3,
+						InstanceMaxCount: 3,
+					},
+					StartDateTime: "2024-05-15T13:00",
+					EndDateTime:   "2024-05-15T14:00",
+				}}
+			})
+
+			It("takes precedence over the recurring schedule", func() {
+				Expect(evaluateErr).NotTo(HaveOccurred())
+				Expect(policy.Status.ActiveSchedule).To(Equal("specific-date-0"))
+				Expect(decision.NewInstances).To(BeEquivalentTo(3))
+			})
+		})
+
+		When("the time zone is invalid", func() {
+			BeforeEach(func() {
+				policy.Spec.Schedules.Timezone = "Nowhere/Special"
+			})
+
+			It("returns an error", func() {
+				Expect(evaluateErr).To(MatchError(ContainSubstring("invalid timezone")))
+			})
+		})
+	})
+})
+
+var _ = Describe("MissingMetrics", func() {
+	It("lists the metrics the rules depend on that are not available", func() {
+		policy := &korifiv1alpha1.CFAutoscalingPolicy{
+			Spec: korifiv1alpha1.CFAutoscalingPolicySpec{
+				ScalingRules: []korifiv1alpha1.ScalingRule{
+					{MetricType: korifiv1alpha1.AutoscalingMetricCPU},
+					{MetricType: korifiv1alpha1.AutoscalingMetricThroughput},
+					{MetricType: korifiv1alpha1.AutoscalingMetricThroughput},
+				},
+			},
+		}
+
+		Expect(evaluator.MissingMetrics(policy, evaluator.ProcessMetrics{
+			korifiv1alpha1.AutoscalingMetricCPU: 10,
+		})).To(ConsistOf(korifiv1alpha1.AutoscalingMetricThroughput))
+	})
+})
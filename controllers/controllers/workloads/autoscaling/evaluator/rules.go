@@ -0,0 +1,58 @@
+package evaluator
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+func isBreached(rule korifiv1alpha1.ScalingRule, value int64) bool {
+	switch rule.Operator {
+	case "<":
+		return value < rule.Threshold
+	case "<=":
+		return value <= rule.Threshold
+	case ">":
+		return value > rule.Threshold
+	case ">=":
+		return value >= rule.Threshold
+	default:
+		return false
+	}
+}
+
+func isScaleOut(rule korifiv1alpha1.ScalingRule) bool {
+	return strings.HasPrefix(rule.Adjustment, "+")
+}
+
+// adjustInstances applies the adjustment of a scaling rule to the current
+// number of instances. Relative adjustments always change the instances by
+// at least one.
+func adjustInstances(current int32, adjustment string) (int32, error) {
+	amount, err := strconv.Atoi(strings.TrimSuffix(adjustment, "%"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid adjustment %q: %w", adjustment, err)
+	}
+
+	if !strings.HasSuffix(adjustment, "%") {
+		return current + int32(amount), nil
+	}
+
+	delta := int32(math.Ceil(float64(current) * math.Abs(float64(amount)) / 100))
+	if amount < 0 {
+		delta = -delta
+	}
+
+	return current + delta, nil
+}
+
+func clamp(instances int32, limits instanceLimits) int32 {
+	return min(max(instances, limits.min), limits.max)
+}
+
+func describeRule(rule korifiv1alpha1.ScalingRule, value int64) string {
+	return fmt.Sprintf("%s instance(s) because %s %s %d (current value: %d)", rule.Adjustment, rule.MetricType, rule.Operator, rule.Threshold, value)
+}
@@ -0,0 +1,135 @@
+package evaluator
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02T15:04"
+	timeLayout     = "15:04"
+)
+
+type instanceLimits struct {
+	min        int32
+	max        int32
+	initialMin *int32
+	schedule   string
+}
+
+// activeLimits returns the instance limits in effect at the given time.
+// Specific date schedules take precedence over recurring schedules, which in
+// turn take precedence over the default limits of the policy.
+func activeLimits(policy *korifiv1alpha1.CFAutoscalingPolicy, now time.Time) (instanceLimits, error) {
+	defaultLimits := instanceLimits{
+		min: policy.Spec.InstanceMinCount,
+		max: policy.Spec.InstanceMaxCount,
+	}
+
+	schedules := policy.Spec.Schedules
+	if schedules == nil {
+		return defaultLimits, nil
+	}
+
+	location, err := time.LoadLocation(schedules.Timezone)
+	if err != nil {
+		return instanceLimits{}, fmt.Errorf("invalid timezone %q: %w", schedules.Timezone, err)
+	}
+	localNow := now.In(location)
+
+	for i, schedule := range schedules.SpecificDates {
+		active, err := isSpecificDateActive(schedule, localNow, location)
+		if err != nil {
+			return instanceLimits{}, err
+		}
+		if active {
+			return scheduleLimits(schedule.ScheduleLimits, fmt.Sprintf("specific-date-%d", i)), nil
+		}
+	}
+
+	for i, schedule := range schedules.RecurringSchedules {
+		active, err := isRecurringScheduleActive(schedule, localNow)
+		if err != nil {
+			return instanceLimits{}, err
+		}
+		if active {
+			return scheduleLimits(schedule.ScheduleLimits, fmt.Sprintf("recurring-%d", i)), nil
+		}
+	}
+
+	return defaultLimits, nil
+}
+
+func scheduleLimits(limits korifiv1alpha1.ScheduleLimits, name string) instanceLimits {
+	return instanceLimits{
+		min:        limits.InstanceMinCount,
+		max:        limits.InstanceMaxCount,
+		initialMin: limits.InitialMinInstanceCount,
+		schedule:   name,
+	}
+}
+
+func isSpecificDateActive(schedule korifiv1alpha1.SpecificDateSchedule, now time.Time, location *time.Location) (bool, error) {
+	start, err := time.ParseInLocation(dateTimeLayout, schedule.StartDateTime, location)
+	if err != nil {
+		return false, fmt.Errorf("invalid schedule start date time %q: %w", schedule.StartDateTime, err)
+	}
+
+	end, err := time.ParseInLocation(dateTimeLayout, schedule.EndDateTime, location)
+	if err != nil {
+		return false, fmt.Errorf("invalid schedule end date time %q: %w", schedule.EndDateTime, err)
+	}
+
+	return !now.Before(start) && now.Before(end), nil
+}
+
+func isRecurringScheduleActive(schedule korifiv1alpha1.RecurringSchedule, now time.Time) (bool, error) {
+	today := now.Format(dateLayout)
+	if schedule.StartDate != "" && today < schedule.StartDate {
+		return false, nil
+	}
+	if schedule.EndDate != "" && today > schedule.EndDate {
+		return false, nil
+	}
+
+	if len(schedule.DaysOfWeek) > 0 && !slices.Contains(schedule.DaysOfWeek, isoWeekday(now)) {
+		return false, nil
+	}
+	if len(schedule.DaysOfMonth) > 0 && !slices.Contains(schedule.DaysOfMonth, int32(now.Day())) {
+		return false, nil
+	}
+
+	start, err := minuteOfDay(schedule.StartTime)
+	if err != nil {
+		return false, err
+	}
+
+	end, err := minuteOfDay(schedule.EndTime)
+	if err != nil {
+		return false, err
+	}
+
+	current := now.Hour()*60 + now.Minute()
+	return current >= start && current < end, nil
+}
+
+func minuteOfDay(timeOfDay string) (int, error) {
+	parsed, err := time.Parse(timeLayout, timeOfDay)
+	if err != nil {
+		return 0, fmt.Errorf("invalid schedule time %q: %w", timeOfDay, err)
+	}
+
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+// isoWeekday numbers the days of the week from 1 (Monday) to 7 (Sunday)
+func isoWeekday(t time.Time) int32 {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int32(t.Weekday())
+}
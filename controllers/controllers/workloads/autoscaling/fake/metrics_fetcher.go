@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/autoscaling"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/autoscaling/evaluator"
+)
+
+type MetricsFetcher struct {
+	FetchStub        func(context.Context, *v1alpha1.CFProcess) (evaluator.ProcessMetrics, error)
+	fetchMutex       sync.RWMutex
+	fetchArgsForCall []struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFProcess
+	}
+	fetchReturns struct {
+		result1 evaluator.ProcessMetrics
+		result2 error
+	}
+	fetchReturnsOnCall map[int]struct {
+		result1 evaluator.ProcessMetrics
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *MetricsFetcher) Fetch(arg1 context.Context, arg2 *v1alpha1.CFProcess) (evaluator.ProcessMetrics, error) {
+	fake.fetchMutex.Lock()
+	ret, specificReturn := fake.fetchReturnsOnCall[len(fake.fetchArgsForCall)]
+	fake.fetchArgsForCall = append(fake.fetchArgsForCall, struct {
+		arg1 context.Context
+		arg2 *v1alpha1.CFProcess
+	}{arg1, arg2})
+	stub := fake.FetchStub
+	fakeReturns := fake.fetchReturns
+	fake.recordInvocation("Fetch", []interface{}{arg1, arg2})
+	fake.fetchMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *MetricsFetcher) FetchCallCount() int {
+	fake.fetchMutex.RLock()
+	defer fake.fetchMutex.RUnlock()
+	return len(fake.fetchArgsForCall)
+}
+
+func (fake *MetricsFetcher) FetchCalls(stub func(context.Context, *v1alpha1.CFProcess) (evaluator.ProcessMetrics, error)) {
+	fake.fetchMutex.Lock()
+	defer fake.fetchMutex.Unlock()
+	fake.FetchStub = stub
+}
+
+func (fake *MetricsFetcher) FetchArgsForCall(i int) (context.Context, *v1alpha1.CFProcess) {
+	fake.fetchMutex.RLock()
+	defer fake.fetchMutex.RUnlock()
+	argsForCall := fake.fetchArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *MetricsFetcher) FetchReturns(result1 evaluator.ProcessMetrics, result2 error) {
+	fake.fetchMutex.Lock()
+	defer fake.fetchMutex.Unlock()
+	fake.FetchStub = nil
+	fake.fetchReturns = struct {
+		result1 evaluator.ProcessMetrics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *MetricsFetcher) FetchReturnsOnCall(i int, result1 evaluator.ProcessMetrics, result2 error) {
+	fake.fetchMutex.Lock()
+	defer fake.fetchMutex.Unlock()
+	fake.FetchStub = nil
+	if fake.fetchReturnsOnCall == nil {
+		fake.fetchReturnsOnCall = make(map[int]struct {
+			result1 evaluator.ProcessMetrics
+			result2 error
+		})
+	}
+	fake.fetchReturnsOnCall[i] = struct {
+		result1 evaluator.ProcessMetrics
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *MetricsFetcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.fetchMutex.RLock()
+	defer fake.fetchMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *MetricsFetcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ autoscaling.MetricsFetcher = new(MetricsFetcher)
@@ -0,0 +1,113 @@
+package autoscaling
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/autoscaling/evaluator"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	custommetrics "k8s.io/metrics/pkg/client/custom_metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//counterfeiter:generate -o fake -fake-name MetricsFetcher . MetricsFetcher
+
+type MetricsFetcher interface {
+	Fetch(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) (evaluator.ProcessMetrics, error)
+}
+
+// PodMetricsFetcher reads the resource metrics of the process pods from the
+// metrics API. Throughput is read from the pods custom metric named by
+// throughputMetric, e.g. the request rate of the gateway (Envoy) upstream of
+// each pod as served by a Prometheus adapter. Throughput is not reported when
+// no such metric is configured or the custom metrics API cannot serve it.
+type PodMetricsFetcher struct {
+	k8sReader        client.Reader
+	customMetrics    custommetrics.NamespacedMetricsGetter
+	throughputMetric string
+}
+
+func NewPodMetricsFetcher(k8sReader client.Reader, customMetrics custommetrics.NamespacedMetricsGetter, throughputMetric string) *PodMetricsFetcher {
+	return &PodMetricsFetcher{
+		k8sReader:        k8sReader,
+		customMetrics:    customMetrics,
+		throughputMetric: throughputMetric,
+	}
+}
+
+func (f *PodMetricsFetcher) Fetch(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) (evaluator.ProcessMetrics, error) {
+	podList := &corev1.PodList{}
+	err := f.k8sReader.List(ctx, podList,
+		client.InNamespace(cfProcess.Namespace),
+		client.MatchingLabels{korifiv1alpha1.GUIDLabelKey: cfProcess.Name},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods for process %q: %w", cfProcess.Name, err)
+	}
+
+	var cpuMillis, memoryBytes, observedPods int64
+	for _, pod := range podList.Items {
+		podMetrics := &metricsv1beta1.PodMetrics{}
+		err = f.k8sReader.Get(ctx, client.ObjectKeyFromObject(&pod), podMetrics)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get metrics for pod %q: %w", pod.Name, err)
+		}
+
+		for _, container := range podMetrics.Containers {
+			cpuMillis += container.Usage.Cpu().MilliValue()
+			memoryBytes += container.Usage.Memory().Value()
+		}
+		observedPods++
+	}
+
+	if observedPods == 0 {
+		return evaluator.ProcessMetrics{}, nil
+	}
+
+	memoryUsedMB := memoryBytes / observedPods / (1024 * 1024)
+	metrics := evaluator.ProcessMetrics{
+		korifiv1alpha1.AutoscalingMetricCPU:        cpuMillis / observedPods / 10,
+		korifiv1alpha1.AutoscalingMetricMemoryUsed: memoryUsedMB,
+	}
+	if cfProcess.Spec.MemoryMB > 0 {
+		metrics[korifiv1alpha1.AutoscalingMetricMemoryUtil] = memoryUsedMB * 100 / cfProcess.Spec.MemoryMB
+	}
+	if throughput, ok := f.fetchThroughput(cfProcess); ok {
+		metrics[korifiv1alpha1.AutoscalingMetricThroughput] = throughput
+	}
+
+	return metrics, nil
+}
+
+// fetchThroughput returns the number of requests per second across all the
+// process pods
+func (f *PodMetricsFetcher) fetchThroughput(cfProcess *korifiv1alpha1.CFProcess) (int64, bool) {
+	if f.customMetrics == nil || f.throughputMetric == "" {
+		return 0, false
+	}
+
+	metricValues, err := f.customMetrics.NamespacedMetrics(cfProcess.Namespace).GetForObjects(
+		schema.GroupKind{Kind: "Pod"},
+		labels.SelectorFromSet(labels.Set{korifiv1alpha1.GUIDLabelKey: cfProcess.Name}),
+		f.throughputMetric,
+		labels.Everything(),
+	)
+	if err != nil || len(metricValues.Items) == 0 {
+		return 0, false
+	}
+
+	var requestMillisPerSecond int64
+	for _, metricValue := range metricValues.Items {
+		requestMillisPerSecond += metricValue.Value.MilliValue()
+	}
+
+	return requestMillisPerSecond / 1000, true
+}
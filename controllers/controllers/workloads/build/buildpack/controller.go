@@ -23,6 +23,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -125,6 +126,9 @@ func buildworkloadToBuild(ctx context.Context, o client.Object) []reconcile.Requ
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/status,verbs=get
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/finalizers,verbs=update
 
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfisolationsegments,verbs=get;list;watch
+
 func (r *buildpackBuildReconciler) ReconcileBuild(
 	ctx context.Context,
 	cfBuild *korifiv1alpha1.CFBuild,
@@ -235,6 +239,7 @@ func (r *buildpackBuildReconciler) createBuildWorkload(ctx context.Context, cfBu
 			},
 			BuilderName: r.controllerConfig.BuilderName,
 			Buildpacks:  cfBuild.Spec.Lifecycle.Data.Buildpacks,
+			Stack:       cfBuild.Spec.Lifecycle.Data.Stack,
 			Services: slices.Collect(it.Map(slices.Values(cfApp.Status.ServiceBindings),
 				func(binding korifiv1alpha1.ServiceBinding) corev1.ObjectReference {
 					return corev1.ObjectReference{
@@ -254,6 +259,13 @@ func (r *buildpackBuildReconciler) createBuildWorkload(ctx context.Context, cfBu
 	}
 	desiredWorkload.Spec.Env = imageEnvironment
 
+	scheduling, err := shared.GetWorkloadScheduling(ctx, r.k8sClient, r.controllerConfig.CFRootNamespace, namespace)
+	if err != nil {
+		log.Info("failed to get the workload scheduling", "reason", err)
+		return err
+	}
+	desiredWorkload.Spec.Scheduling = scheduling
+
 	err = controllerutil.SetControllerReference(cfBuild, &desiredWorkload, r.scheme)
 	if err != nil {
 		log.Info("failed to set OwnerRef on BuildWorkload", "reason", err)
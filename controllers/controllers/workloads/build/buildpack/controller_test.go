@@ -127,6 +127,7 @@ var _ = Describe("CFBuildpackBuildReconciler Integration Tests", func() {
 					Type: "buildpack",
 					Data: korifiv1alpha1.LifecycleData{
 						Buildpacks: []string{"first-buildpack", "second-buildpack"},
+						Stack:      "cflinuxfs4",
 					},
 				},
 			},
@@ -189,6 +190,7 @@ var _ = Describe("CFBuildpackBuildReconciler Integration Tests", func() {
 				}),
 			))
 			g.Expect(workload.Spec.Buildpacks).To(ConsistOf("first-buildpack", "second-buildpack"))
+			g.Expect(workload.Spec.Stack).To(Equal("cflinuxfs4"))
 			g.Expect(workload.GetOwnerReferences()).To(ConsistOf(metav1.OwnerReference{
 				UID:                cfBuild.UID,
 				Kind:               "CFBuild",
@@ -97,6 +97,10 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfBuild *korifiv1alp
 		return ctrl.Result{}, err
 	}
 
+	if cfBuild.Spec.Droplet != nil {
+		return r.reconcileProvidedDroplet(ctx, cfBuild)
+	}
+
 	cfPackage := new(korifiv1alpha1.CFPackage)
 	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfBuild.Spec.PackageRef.Name, Namespace: cfBuild.Namespace}, cfPackage)
 	if err != nil {
@@ -127,6 +131,32 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfBuild *korifiv1alp
 	return r.delegate.ReconcileBuild(ctx, cfBuild, cfApp, cfPackage)
 }
 
+func (r *Reconciler) reconcileProvidedDroplet(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if cfBuild.Spec.Droplet.Registry.Image == "" {
+		log.Info("waiting for the droplet image to be uploaded")
+		return ctrl.Result{}, nil
+	}
+
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.StagingConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildNotRunning",
+		ObservedGeneration: cfBuild.Generation,
+	})
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "DropletProvided",
+		ObservedGeneration: cfBuild.Generation,
+	})
+	cfBuild.Status.State = korifiv1alpha1.BuildStateStaged
+	cfBuild.Status.Droplet = cfBuild.Spec.Droplet.DeepCopy()
+
+	return ctrl.Result{}, nil
+}
+
 func validateLifecycleTypes(
 	cfApp *korifiv1alpha1.CFApp,
 	cfPackage *korifiv1alpha1.CFPackage,
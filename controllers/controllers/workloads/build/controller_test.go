@@ -215,6 +215,49 @@ var _ = Describe("CFBuildReconciler", func() {
 		})
 	})
 
+	When("the build provides its droplet", func() {
+		BeforeEach(func() {
+			cfBuild.Spec.PackageRef = v1.LocalObjectReference{}
+			cfBuild.Spec.Droplet = &korifiv1alpha1.BuildDropletStatus{
+				Registry: korifiv1alpha1.Registry{
+					Image:            "my-image",
+					ImagePullSecrets: []v1.LocalObjectReference{{Name: "my-secret"}},
+				},
+				ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "run"}},
+			}
+		})
+
+		It("succeeds the build with the provided droplet", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeTrue())
+				g.Expect(meta.IsStatusConditionFalse(cfBuild.Status.Conditions, korifiv1alpha1.StagingConditionType)).To(BeTrue())
+				g.Expect(cfBuild.Status.State).To(Equal(korifiv1alpha1.BuildStateStaged))
+				g.Expect(cfBuild.Status.Droplet).To(PointTo(Equal(*cfBuild.Spec.Droplet)))
+			}).Should(Succeed())
+		})
+
+		It("does not invoke the delegate", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(reconciledBuilds()).NotTo(HaveKey(cfBuild.Name))
+			}).Should(Succeed())
+		})
+
+		When("the droplet image has not been uploaded yet", func() {
+			BeforeEach(func() {
+				cfBuild.Spec.Droplet.Registry = korifiv1alpha1.Registry{}
+			})
+
+			It("waits for the upload", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuild), cfBuild)).To(Succeed())
+					g.Expect(cfBuild.Status.Conditions).To(BeEmpty())
+					g.Expect(cfBuild.Status.Droplet).To(BeNil())
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the build succeeds", func() {
 		JustBeforeEach(func() {
 			Eventually(func(g Gomega) {
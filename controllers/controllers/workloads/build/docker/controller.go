@@ -165,8 +165,9 @@ func (r *dockerBuildReconciler) ReconcileBuild(
 	cfBuild.Status.State = korifiv1alpha1.BuildStateStaged
 
 	cfBuild.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
-		Registry: cfPackage.Spec.Source.Registry,
-		Ports:    imageConfig.ExposedPorts,
+		Registry:    cfPackage.Spec.Source.Registry,
+		Ports:       imageConfig.ExposedPorts,
+		ImageDigest: imageConfig.Digest,
 	}
 
 	return ctrl.Result{}, nil
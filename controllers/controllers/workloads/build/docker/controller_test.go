@@ -115,6 +115,7 @@ var _ = Describe("CFDockerBuildReconciler Integration Tests", func() {
 			g.Expect(meta.IsStatusConditionTrue(cfBuild.Status.Conditions, korifiv1alpha1.SucceededConditionType)).To(BeTrue())
 			g.Expect(cfBuild.Status.Droplet).NotTo(BeNil())
 			g.Expect(cfBuild.Status.Droplet.Registry.Image).To(Equal(imageRef))
+			g.Expect(cfBuild.Status.Droplet.ImageDigest).To(HavePrefix("sha256:"))
 			g.Expect(cfBuild.Status.Droplet.Registry.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: imageSecret.Name}))
 			g.Expect(cfBuild.Status.Droplet.Ports).To(BeEmpty())
 			g.Expect(cfBuild.Status.State).To(Equal(korifiv1alpha1.BuildStateStaged))
@@ -28,7 +28,13 @@ type ServiceDetails struct {
 	BindingName    *string        `json:"binding_name"`
 	Credentials    map[string]any `json:"credentials"`
 	SyslogDrainURL *string        `json:"syslog_drain_url"`
-	VolumeMounts   []string       `json:"volume_mounts"`
+	VolumeMounts   []VolumeMount  `json:"volume_mounts"`
+}
+
+type VolumeMount struct {
+	ContainerDir string `json:"container_dir"`
+	Mode         string `json:"mode"`
+	DeviceType   string `json:"device_type"`
 }
 
 type AppEnvBuilder struct {
@@ -128,7 +134,6 @@ func (b *ProcessEnvBuilder) Build(ctx context.Context, cfApp *korifiv1alpha1.CFA
 func (b *ProcessEnvBuilder) buildPortEnv(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) ([]corev1.EnvVar, error) {
 	var cfRoutesForProcess korifiv1alpha1.CFRouteList
 	err := b.k8sClient.List(ctx, &cfRoutesForProcess,
-		client.InNamespace(cfProcess.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
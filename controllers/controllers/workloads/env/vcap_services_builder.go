@@ -8,6 +8,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/credentials"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,6 +45,16 @@ func (b *VCAPServicesEnvValueBuilder) BuildEnvValue(ctx context.Context, cfApp *
 			continue
 		}
 
+		// If still rotating the previous binding do not append
+		if currentServiceBinding.Spec.PreviousBinding.Name != "" {
+			continue
+		}
+
+		// If replaced by a rotated successor do not append
+		if currentServiceBinding.Annotations[korifiv1alpha1.SupersededByAnnotation] != "" {
+			continue
+		}
+
 		var serviceEnv ServiceDetails
 		var serviceLabel string
 		serviceEnv, serviceLabel, err = buildSingleServiceEnv(ctx, b.k8sClient, currentServiceBinding)
@@ -134,7 +145,28 @@ func fromServiceBinding(
 		BindingGUID:    serviceBinding.Name,
 		BindingName:    bindingName,
 		Credentials:    creds,
-		SyslogDrainURL: nil,
-		VolumeMounts:   []string{},
+		SyslogDrainURL: syslogDrainURL(serviceBinding),
+		VolumeMounts:   volumeMounts(serviceBinding),
 	}, nil
 }
+
+func syslogDrainURL(serviceBinding korifiv1alpha1.CFServiceBinding) *string {
+	if serviceBinding.Status.SyslogDrainURL == "" {
+		return nil
+	}
+
+	return tools.PtrTo(serviceBinding.Status.SyslogDrainURL)
+}
+
+func volumeMounts(serviceBinding korifiv1alpha1.CFServiceBinding) []VolumeMount {
+	volumeMounts := []VolumeMount{}
+	for _, volumeMount := range serviceBinding.Status.VolumeMounts {
+		volumeMounts = append(volumeMounts, VolumeMount{
+			ContainerDir: volumeMount.ContainerDir,
+			Mode:         volumeMount.Mode,
+			DeviceType:   volumeMount.DeviceType,
+		})
+	}
+
+	return volumeMounts
+}
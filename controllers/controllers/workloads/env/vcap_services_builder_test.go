@@ -184,6 +184,80 @@ var _ = Describe("Builder", func() {
 			}))
 		})
 
+		When("the service binding has volume mounts", func() {
+			BeforeEach(func() {
+				helpers.EnsurePatch(controllersClient, serviceBinding, func(sb *korifiv1alpha1.CFServiceBinding) {
+					sb.Status.VolumeMounts = []korifiv1alpha1.ServiceBindingVolumeMount{{
+						Driver:       "nfs.csi.k8s.io",
+						ContainerDir: "/data/nfs",
+						Mode:         "rw",
+						DeviceType:   "shared",
+						Device: korifiv1alpha1.VolumeMountDevice{
+							VolumeID: "nfs-volume",
+						},
+					}}
+				})
+			})
+
+			It("includes them in the service info", func() {
+				Expect(parseVcapServices(vcapServices)).To(MatchKeys(IgnoreExtras, Keys{
+					"sb-1-type": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"volume_mounts": ConsistOf(MatchAllKeys(Keys{
+							"container_dir": Equal("/data/nfs"),
+							"mode":          Equal("rw"),
+							"device_type":   Equal("shared"),
+						})),
+					})),
+				}))
+			})
+		})
+
+		When("the service binding has a syslog drain url", func() {
+			BeforeEach(func() {
+				helpers.EnsurePatch(controllersClient, serviceBinding, func(sb *korifiv1alpha1.CFServiceBinding) {
+					sb.Status.SyslogDrainURL = "syslog-tls://logs.example.com:6514"
+				})
+			})
+
+			It("includes it in the service info", func() {
+				Expect(parseVcapServices(vcapServices)).To(MatchKeys(IgnoreExtras, Keys{
+					"sb-1-type": ConsistOf(MatchKeys(IgnoreExtras, Keys{
+						"syslog_drain_url": Equal("syslog-tls://logs.example.com:6514"),
+					})),
+				}))
+			})
+		})
+
+		When("the service binding is rotating a previous binding", func() {
+			BeforeEach(func() {
+				helpers.EnsurePatch(controllersClient, serviceBinding, func(sb *korifiv1alpha1.CFServiceBinding) {
+					sb.Spec.PreviousBinding.Name = "previous-binding"
+				})
+			})
+
+			It("does not include it in the service info", func() {
+				Expect(buildVCAPServicesEnvValueErr).NotTo(HaveOccurred())
+				Expect(parseVcapServices(vcapServices)).To(MatchAllKeys(Keys{
+					"custom-service-2": HaveLen(1),
+				}))
+			})
+		})
+
+		When("the service binding has been superseded by a rotated successor", func() {
+			BeforeEach(func() {
+				helpers.EnsurePatch(controllersClient, serviceBinding, func(sb *korifiv1alpha1.CFServiceBinding) {
+					sb.Annotations = tools.SetMapValue(sb.Annotations, korifiv1alpha1.SupersededByAnnotation, "successor-binding")
+				})
+			})
+
+			It("does not include it in the service info", func() {
+				Expect(buildVCAPServicesEnvValueErr).NotTo(HaveOccurred())
+				Expect(parseVcapServices(vcapServices)).To(MatchAllKeys(Keys{
+					"custom-service-2": HaveLen(1),
+				}))
+			})
+		})
+
 		When("the service binding has no name", func() {
 			BeforeEach(func() {
 				helpers.EnsurePatch(controllersClient, serviceBinding, func(s *korifiv1alpha1.CFServiceBinding) {
@@ -0,0 +1,202 @@
+package instanceidentity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+)
+
+// Identity is what an instance identity certificate asserts about the
+// instance it has been issued to
+type Identity struct {
+	InstanceName string
+	OrgGUID      string
+	SpaceGUID    string
+	AppGUID      string
+}
+
+// organizationalUnits returns the subject OUs in the format CF apps expect,
+// e.g. "app:<app-guid>"
+func (i Identity) organizationalUnits() []string {
+	return []string{
+		"organization:" + i.OrgGUID,
+		"space:" + i.SpaceGUID,
+		"app:" + i.AppGUID,
+	}
+}
+
+type CA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func GenerateCA(now time.Time, validity time.Duration) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{"Korifi"},
+			CommonName:   "Korifi Instance Identity CA",
+		},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+
+	keyPEM, err = encodeKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return encodeCert(certDER), keyPEM, nil
+}
+
+func ParseCA(certPEM, keyPEM []byte) (*CA, error) {
+	cert, err := parseCert(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, errors.New("failed to decode CA key PEM")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("CA key cannot be used for signing")
+	}
+
+	return &CA{cert: cert, key: signer}, nil
+}
+
+func (c *CA) CertPEM() []byte {
+	return encodeCert(c.cert.Raw)
+}
+
+// Issue signs a new client and server certificate for the given identity
+func (c *CA) Issue(identity Identity, now time.Time, validity time.Duration) (certPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate instance key: %w", err)
+	}
+
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			CommonName:         identity.InstanceName,
+			OrganizationalUnit: identity.organizationalUnits(),
+		},
+		DNSNames:    []string{identity.InstanceName},
+		NotBefore:   now.Add(-time.Minute),
+		NotAfter:    now.Add(validity),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+
+	certDER, err := x509.CreateCertificate(rand.Reader, template, c.cert, key.Public(), c.key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create instance certificate: %w", err)
+	}
+
+	keyPEM, err = encodeKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return encodeCert(certDER), keyPEM, nil
+}
+
+// RenewAt returns when the given certificate should be replaced. A
+// certificate is due for renewal once two thirds of its validity have
+// elapsed, or right away when it is unparseable, has not been signed by the
+// CA or does not assert the given identity.
+func (c *CA) RenewAt(certPEM []byte, identity Identity) time.Time {
+	cert, err := parseCert(certPEM)
+	if err != nil {
+		return time.Time{}
+	}
+
+	if cert.CheckSignatureFrom(c.cert) != nil {
+		return time.Time{}
+	}
+
+	if cert.Subject.CommonName != identity.InstanceName || !sameElements(cert.Subject.OrganizationalUnit, identity.organizationalUnits()) {
+		return time.Time{}
+	}
+
+	validity := cert.NotAfter.Sub(cert.NotBefore)
+	return cert.NotAfter.Add(-validity / 3)
+}
+
+func parseCert(certPEM []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil {
+		return nil, errors.New("failed to decode certificate PEM")
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
+
+func encodeCert(certDER []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+}
+
+func encodeKey(key *ecdsa.PrivateKey) ([]byte, error) {
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), nil
+}
+
+func newSerialNumber() (*big.Int, error) {
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serial number: %w", err)
+	}
+
+	return serialNumber, nil
+}
+
+// sameElements compares the OUs regardless of their order, which does not
+// survive encoding and parsing the certificate subject
+func sameElements(a, b []string) bool {
+	return slices.Equal(slices.Sorted(slices.Values(a)), slices.Sorted(slices.Values(b)))
+}
@@ -0,0 +1,144 @@
+package instanceidentity_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/instanceidentity"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CA", func() {
+	var (
+		now      time.Time
+		ca       *instanceidentity.CA
+		identity instanceidentity.Identity
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+
+		caCertPEM, caKeyPEM, err := instanceidentity.GenerateCA(now, 24*time.Hour)
+		Expect(err).NotTo(HaveOccurred())
+
+		ca, err = instanceidentity.ParseCA(caCertPEM, caKeyPEM)
+		Expect(err).NotTo(HaveOccurred())
+
+		identity = instanceidentity.Identity{
+			InstanceName: "process-guid-0",
+			OrgGUID:      "org-guid",
+			SpaceGUID:    "space-guid",
+			AppGUID:      "app-guid",
+		}
+	})
+
+	Describe("Issue", func() {
+		var (
+			certPEM []byte
+			keyPEM  []byte
+		)
+
+		BeforeEach(func() {
+			var err error
+			certPEM, keyPEM, err = ca.Issue(identity, now, 3*time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("issues a key pair", func() {
+			_, err := tls.X509KeyPair(certPEM, keyPEM)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("encodes the identity in the certificate subject", func() {
+			cert := parseCert(certPEM)
+			Expect(cert.Subject.CommonName).To(Equal("process-guid-0"))
+			Expect(cert.Subject.OrganizationalUnit).To(ConsistOf(
+				"organization:org-guid",
+				"space:space-guid",
+				"app:app-guid",
+			))
+		})
+
+		It("issues a certificate that is valid for the given duration", func() {
+			cert := parseCert(certPEM)
+			Expect(cert.NotAfter).To(BeTemporally("~", now.Add(3*time.Hour), time.Second))
+		})
+
+		It("issues a certificate usable for client and server authentication", func() {
+			cert := parseCert(certPEM)
+			Expect(cert.ExtKeyUsage).To(ConsistOf(x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth))
+		})
+
+		It("issues a certificate signed by the CA", func() {
+			pool := x509.NewCertPool()
+			Expect(pool.AppendCertsFromPEM(ca.CertPEM())).To(BeTrue())
+
+			_, err := parseCert(certPEM).Verify(x509.VerifyOptions{
+				Roots:     pool,
+				KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("RenewAt", func() {
+		var certPEM []byte
+
+		BeforeEach(func() {
+			var err error
+			certPEM, _, err = ca.Issue(identity, now, 3*time.Hour)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("renews the certificate once two thirds of its validity have elapsed", func() {
+			validity := 3*time.Hour + time.Minute
+			Expect(ca.RenewAt(certPEM, identity)).To(BeTemporally("~", now.Add(3*time.Hour).Add(-validity/3), time.Second))
+		})
+
+		When("the certificate is missing", func() {
+			It("renews it right away", func() {
+				Expect(ca.RenewAt(nil, identity)).To(BeZero())
+			})
+		})
+
+		When("the certificate asserts a different identity", func() {
+			BeforeEach(func() {
+				identity.AppGUID = "another-app-guid"
+			})
+
+			It("renews it right away", func() {
+				Expect(ca.RenewAt(certPEM, identity)).To(BeZero())
+			})
+		})
+
+		When("the certificate has been signed by another CA", func() {
+			BeforeEach(func() {
+				otherCACertPEM, otherCAKeyPEM, err := instanceidentity.GenerateCA(now, 24*time.Hour)
+				Expect(err).NotTo(HaveOccurred())
+
+				ca, err = instanceidentity.ParseCA(otherCACertPEM, otherCAKeyPEM)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("renews it right away", func() {
+				Expect(ca.RenewAt(certPEM, identity)).To(BeZero())
+			})
+		})
+	})
+})
+
+func parseCert(certPEM []byte) *x509.Certificate {
+	GinkgoHelper()
+
+	block, _ := pem.Decode(certPEM)
+	Expect(block).NotTo(BeNil())
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	Expect(err).NotTo(HaveOccurred())
+
+	return cert
+}
@@ -0,0 +1,13 @@
+package instanceidentity_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestInstanceIdentity(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Instance Identity Suite")
+}
@@ -0,0 +1,215 @@
+package instanceidentity
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	CASecretName = "korifi-instance-identity-ca"
+	caValidity   = 10 * 365 * 24 * time.Hour
+)
+
+// Issuer keeps the instance identity credentials of app and task workloads
+// up to date.
+//
+// The credentials are signed by an internal CA that the issuer creates in the
+// root namespace on first use. Each workload instance gets a secret holding
+// its own certificate and key only, which runners mount into the instance
+// containers. Certificates are reissued well before they expire and the
+// kubelet propagates the new secret contents into running containers.
+type Issuer struct {
+	k8sClient     client.Client
+	rootNamespace string
+	validity      time.Duration
+}
+
+func NewIssuer(k8sClient client.Client, rootNamespace string, validity time.Duration) *Issuer {
+	return &Issuer{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+		validity:      validity,
+	}
+}
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;patch;delete
+
+// EnsureCredentials makes sure that the instances of the app with indexes
+// below the given number of instances have a secret, owned by owner, holding
+// valid credentials. The secret names are derived from secretName, see
+// InstanceIdentityInstanceSecretName. Secrets of surplus instances are
+// removed. It returns how long until the next certificate is due for renewal.
+func (i *Issuer) EnsureCredentials(
+	ctx context.Context,
+	owner client.Object,
+	secretName string,
+	appGUID string,
+	instances int32,
+) (time.Duration, error) {
+	ca, err := i.getCA(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	namespace := &corev1.Namespace{}
+	if err = i.k8sClient.Get(ctx, client.ObjectKey{Name: owner.GetNamespace()}, namespace); err != nil {
+		return 0, fmt.Errorf("failed to get space namespace %q: %w", owner.GetNamespace(), err)
+	}
+
+	now := time.Now()
+	nextRenewal := now.Add(i.validity)
+	instanceSecretNames := map[string]bool{}
+
+	for index := range instances {
+		indexString := strconv.Itoa(int(index))
+		instanceSecretName := korifiv1alpha1.InstanceIdentityInstanceSecretName(secretName, indexString)
+		instanceSecretNames[instanceSecretName] = true
+
+		renewAt, err := i.ensureInstanceCredentials(ctx, ca, owner, secretName, instanceSecretName, Identity{
+			InstanceName: owner.GetName() + "-" + indexString,
+			OrgGUID:      namespace.Labels[korifiv1alpha1.CFOrgGUIDKey],
+			SpaceGUID:    owner.GetNamespace(),
+			AppGUID:      appGUID,
+		}, now)
+		if err != nil {
+			return 0, err
+		}
+
+		if renewAt.Before(nextRenewal) {
+			nextRenewal = renewAt
+		}
+	}
+
+	if err = i.deleteSurplusSecrets(ctx, owner.GetNamespace(), secretName, instanceSecretNames); err != nil {
+		return 0, err
+	}
+
+	return time.Until(nextRenewal), nil
+}
+
+func (i *Issuer) ensureInstanceCredentials(
+	ctx context.Context,
+	ca *CA,
+	owner client.Object,
+	secretName string,
+	instanceSecretName string,
+	identity Identity,
+	now time.Time,
+) (time.Time, error) {
+	var renewAt time.Time
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instanceSecretName,
+			Namespace: owner.GetNamespace(),
+		},
+	}
+	_, err := controllerutil.CreateOrPatch(ctx, i.k8sClient, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[korifiv1alpha1.InstanceIdentitySecretLabelKey] = secretName
+
+		certPEM := secret.Data[korifiv1alpha1.InstanceIdentityCertKey]
+		keyPEM := secret.Data[korifiv1alpha1.InstanceIdentityKeyKey]
+
+		renewAt = ca.RenewAt(certPEM, identity)
+		if !now.Before(renewAt) {
+			var issueErr error
+			certPEM, keyPEM, issueErr = ca.Issue(identity, now, i.validity)
+			if issueErr != nil {
+				return issueErr
+			}
+			renewAt = ca.RenewAt(certPEM, identity)
+		}
+
+		secret.Data = map[string][]byte{
+			korifiv1alpha1.InstanceIdentityCAKey:   ca.CertPEM(),
+			korifiv1alpha1.InstanceIdentityCertKey: certPEM,
+			korifiv1alpha1.InstanceIdentityKeyKey:  keyPEM,
+		}
+
+		return controllerutil.SetControllerReference(owner, secret, i.k8sClient.Scheme())
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to create or patch instance identity secret %q: %w", instanceSecretName, err)
+	}
+
+	return renewAt, nil
+}
+
+func (i *Issuer) deleteSurplusSecrets(ctx context.Context, namespace string, secretName string, instanceSecretNames map[string]bool) error {
+	secrets := &corev1.SecretList{}
+	err := i.k8sClient.List(ctx, secrets,
+		client.InNamespace(namespace),
+		client.MatchingLabels{korifiv1alpha1.InstanceIdentitySecretLabelKey: secretName},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list instance identity secrets: %w", err)
+	}
+
+	for index := range secrets.Items {
+		if instanceSecretNames[secrets.Items[index].Name] {
+			continue
+		}
+
+		if err = i.k8sClient.Delete(ctx, &secrets.Items[index]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete instance identity secret %q: %w", secrets.Items[index].Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (i *Issuer) getCA(ctx context.Context) (*CA, error) {
+	caSecret := &corev1.Secret{}
+	err := i.k8sClient.Get(ctx, client.ObjectKey{Namespace: i.rootNamespace, Name: CASecretName}, caSecret)
+	if k8serrors.IsNotFound(err) {
+		caSecret, err = i.createCA(ctx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get instance identity CA: %w", err)
+	}
+
+	return ParseCA(caSecret.Data[corev1.TLSCertKey], caSecret.Data[corev1.TLSPrivateKeyKey])
+}
+
+func (i *Issuer) createCA(ctx context.Context) (*corev1.Secret, error) {
+	certPEM, keyPEM, err := GenerateCA(time.Now(), caValidity)
+	if err != nil {
+		return nil, err
+	}
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: i.rootNamespace,
+			Name:      CASecretName,
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+
+	err = i.k8sClient.Create(ctx, caSecret)
+	if k8serrors.IsAlreadyExists(err) {
+		// another controller created the CA concurrently
+		err = i.k8sClient.Get(ctx, client.ObjectKeyFromObject(caSecret), caSecret)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return caSecret, nil
+}
@@ -0,0 +1,381 @@
+package logdrains
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// maxLogLineBytes is the maximum length of a forwarded log line
+const maxLogLineBytes = 64 * 1024
+
+// Reconciler forwards the logs of app workload pods to the syslog drains of
+// the user-provided service instances bound to the app. It keeps a log tailer
+// running for every running pod of an app with drains, and restarts it
+// whenever the set of drain URLs changes.
+type Reconciler struct {
+	log           logr.Logger
+	k8sClient     client.Client
+	logStreamer   LogStreamer
+	drainFactory  DrainFactory
+	retryInterval time.Duration
+
+	ctx     context.Context
+	stop    context.CancelFunc
+	mu      sync.Mutex
+	tailers map[types.NamespacedName]*tailer
+}
+
+type tailer struct {
+	drainURLs []string
+	stop      context.CancelFunc
+	done      chan struct{}
+}
+
+func NewReconciler(
+	k8sClient client.Client,
+	log logr.Logger,
+	logStreamer LogStreamer,
+	drainFactory DrainFactory,
+	retryInterval time.Duration,
+) *Reconciler {
+	ctx, stop := context.WithCancel(context.Background())
+
+	return &Reconciler{
+		log:           log,
+		k8sClient:     k8sClient,
+		logStreamer:   logStreamer,
+		drainFactory:  drainFactory,
+		retryInterval: retryInterval,
+		ctx:           ctx,
+		stop:          stop,
+		tailers:       map[types.NamespacedName]*tailer{},
+	}
+}
+
+func (r *Reconciler) SetupWithManager(mgr manager.Manager) error {
+	// ignoring error as this construction is not dynamic
+	appPodSelector, _ := predicate.LabelSelectorPredicate(metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+				Operator: metav1.LabelSelectorOpExists,
+			},
+			{
+				Key:      korifiv1alpha1.CFProcessTypeLabelKey,
+				Operator: metav1.LabelSelectorOpExists,
+			},
+		},
+	})
+
+	// stop all tailers when the manager shuts down
+	err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		r.stopAll()
+		return nil
+	}))
+	if err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("logdrains").
+		For(&corev1.Pod{}, builder.WithPredicates(appPodSelector)).
+		Watches(
+			&korifiv1alpha1.CFServiceBinding{},
+			handler.EnqueueRequestsFromMapFunc(r.serviceBindingToPods),
+		).
+		Complete(r)
+}
+
+func (r *Reconciler) serviceBindingToPods(ctx context.Context, o client.Object) []reconcile.Request {
+	serviceBinding, ok := o.(*korifiv1alpha1.CFServiceBinding)
+	if !ok {
+		return nil
+	}
+
+	podList := &corev1.PodList{}
+	err := r.k8sClient.List(ctx, podList,
+		client.InNamespace(serviceBinding.Namespace),
+		client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: serviceBinding.Spec.AppRef.Name},
+	)
+	if err != nil {
+		r.log.Info("failed to list app pods", "app", serviceBinding.Spec.AppRef.Name, "reason", err)
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, pod := range podList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&pod)})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get
+
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (ctrl.Result, error) {
+	log := r.log.WithName("LogDrains").
+		WithValues("namespace", req.Namespace).
+		WithValues("name", req.Name).
+		WithValues("logID", uuid.NewString())
+
+	pod := &corev1.Pod{}
+	err := r.k8sClient.Get(ctx, req.NamespacedName, pod)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			r.stopTailer(req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
+		log.Info("unable to fetch pod", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if !pod.GetDeletionTimestamp().IsZero() || pod.Status.Phase != corev1.PodRunning {
+		r.stopTailer(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	appGUID := pod.Labels[korifiv1alpha1.CFAppGUIDLabelKey]
+	drainURLs, err := r.getDrainURLs(ctx, pod.Namespace, appGUID)
+	if err != nil {
+		log.Info("failed to get app drain urls", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if len(drainURLs) == 0 {
+		r.stopTailer(req.NamespacedName)
+		return ctrl.Result{}, nil
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{}
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: appGUID}, cfApp)
+	if err != nil {
+		log.Info("failed to get app", "reason", err)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	r.ensureTailer(log, pod, cfApp, drainURLs)
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) getDrainURLs(ctx context.Context, namespace, appGUID string) ([]string, error) {
+	serviceBindings := &korifiv1alpha1.CFServiceBindingList{}
+	err := r.k8sClient.List(ctx, serviceBindings,
+		client.InNamespace(namespace),
+		client.MatchingFields{shared.IndexServiceBindingAppGUID: appGUID},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	drainURLs := []string{}
+	for _, serviceBinding := range serviceBindings.Items {
+		if serviceBinding.Status.SyslogDrainURL != "" {
+			drainURLs = append(drainURLs, serviceBinding.Status.SyslogDrainURL)
+		}
+	}
+
+	return tools.Uniq(drainURLs), nil
+}
+
+func (r *Reconciler) ensureTailer(log logr.Logger, pod *corev1.Pod, cfApp *korifiv1alpha1.CFApp, drainURLs []string) {
+	podKey := client.ObjectKeyFromObject(pod)
+
+	r.mu.Lock()
+	existing, ok := r.tailers[podKey]
+	r.mu.Unlock()
+
+	if ok && slices.Equal(existing.drainURLs, drainURLs) {
+		return
+	}
+
+	r.stopTailer(podKey)
+
+	tailerCtx, stop := context.WithCancel(r.ctx)
+	t := &tailer{
+		drainURLs: drainURLs,
+		stop:      stop,
+		done:      make(chan struct{}),
+	}
+
+	r.mu.Lock()
+	r.tailers[podKey] = t
+	r.mu.Unlock()
+
+	msgTemplate := Message{
+		Hostname:   cfApp.Spec.DisplayName,
+		AppGUID:    cfApp.Name,
+		InstanceID: tools.GetMapValue(pod.Labels, korifiv1alpha1.PodIndexLabelKey, "0"),
+		SourceType: "APP/PROC/" + strings.ToUpper(pod.Labels[korifiv1alpha1.CFProcessTypeLabelKey]),
+	}
+
+	log.V(1).Info("starting log tailer", "drains", len(drainURLs))
+	go func() {
+		defer close(t.done)
+		r.tail(tailerCtx, log, pod.DeepCopy(), msgTemplate, drainURLs)
+	}()
+}
+
+func (r *Reconciler) stopTailer(podKey types.NamespacedName) {
+	r.mu.Lock()
+	t, ok := r.tailers[podKey]
+	delete(r.tailers, podKey)
+	r.mu.Unlock()
+
+	if !ok {
+		return
+	}
+
+	t.stop()
+	<-t.done
+}
+
+func (r *Reconciler) stopAll() {
+	r.stop()
+
+	r.mu.Lock()
+	tailers := r.tailers
+	r.tailers = map[types.NamespacedName]*tailer{}
+	r.mu.Unlock()
+
+	for _, t := range tailers {
+		<-t.done
+	}
+}
+
+// tail follows the pod logs and forwards every line to the drains until its
+// context is cancelled. The log stream is reopened when it ends (e.g. on
+// container restart), resuming from the timestamp of the last forwarded line.
+// Lines exceeding the log rate limit of the instance are not forwarded.
+func (r *Reconciler) tail(ctx context.Context, log logr.Logger, pod *corev1.Pod, msgTemplate Message, drainURLs []string) {
+	drains := []Drain{}
+	for _, drainURL := range drainURLs {
+		drain, err := r.drainFactory(drainURL)
+		if err != nil {
+			log.Info("skipping invalid drain", "reason", err)
+			continue
+		}
+		drains = append(drains, drain)
+	}
+	defer func() {
+		for _, drain := range drains {
+			_ = drain.Close()
+		}
+	}()
+
+	limiter := tools.NewLogRateLimiter(pod.Annotations[korifiv1alpha1.LogRateLimitAnnotation])
+
+	var lastForwarded time.Time
+	for {
+		var sinceTime *metav1.Time
+		if !lastForwarded.IsZero() {
+			sinceTime = &metav1.Time{Time: lastForwarded}
+		}
+
+		stream, err := r.logStreamer.Stream(ctx, pod, sinceTime)
+		if err != nil {
+			log.Info("failed to stream pod logs", "reason", err)
+		} else {
+			lastForwarded = r.forward(ctx, log, stream, msgTemplate, drains, limiter, lastForwarded)
+			stream.Close()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.retryInterval):
+		}
+	}
+}
+
+func (r *Reconciler) forward(ctx context.Context, log logr.Logger, stream io.Reader, msgTemplate Message, drains []Drain, limiter *tools.LogRateLimiter, lastForwarded time.Time) time.Time {
+	reader := bufio.NewReader(stream)
+	for {
+		line, err := readLogLine(reader)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				log.Info("failed to read pod logs", "reason", err)
+			}
+			return lastForwarded
+		}
+
+		timestamp, body := parseLogLine(line)
+		if !timestamp.After(lastForwarded) {
+			continue
+		}
+		lastForwarded = timestamp
+
+		body, ok := limiter.Limit(timestamp, body)
+		if !ok {
+			continue
+		}
+
+		msg := msgTemplate
+		msg.Timestamp = timestamp
+		msg.Body = body
+
+		for _, drain := range drains {
+			if err := drain.Write(ctx, msg); err != nil {
+				log.Info("failed to forward log line", "reason", err)
+			}
+		}
+	}
+}
+
+// readLogLine reads the next line of the log stream. Lines longer than
+// maxLogLineBytes are truncated and the rest of the line is discarded
+func readLogLine(reader *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+
+		if room := maxLogLineBytes - len(line); room > 0 {
+			line = append(line, chunk[:min(len(chunk), room)]...)
+		}
+
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
+
+func parseLogLine(line string) (time.Time, string) {
+	timestampString, body, _ := strings.Cut(line, " ")
+	timestamp, err := time.Parse(time.RFC3339Nano, timestampString)
+	if err != nil {
+		return time.Now(), line
+	}
+
+	return timestamp, body
+}
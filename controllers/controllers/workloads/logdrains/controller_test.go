@@ -0,0 +1,273 @@
+package logdrains_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/logdrains"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("LogDrains Reconciler", func() {
+	var (
+		cfApp          *korifiv1alpha1.CFApp
+		serviceBinding *korifiv1alpha1.CFServiceBinding
+		pod            *corev1.Pod
+		podPhase       corev1.PodPhase
+		podAnnotations map[string]string
+	)
+
+	BeforeEach(func() {
+		logStreamer.StreamStub = func(context.Context, *corev1.Pod, *metav1.Time) (io.ReadCloser, error) {
+			return io.NopCloser(strings.NewReader(
+				"2024-10-01T12:00:00.000000001Z first line\n" +
+					"2024-10-01T12:00:01.000000001Z second line\n",
+			)), nil
+		}
+
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName:  "my-app",
+				DesiredState: korifiv1alpha1.StartedState,
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+			},
+		}
+		helpers.EnsureCreate(adminClient, cfApp)
+
+		serviceBinding = &korifiv1alpha1.CFServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+			},
+			Spec: korifiv1alpha1.CFServiceBindingSpec{
+				Type: korifiv1alpha1.CFServiceBindingTypeApp,
+				Service: corev1.ObjectReference{
+					Kind:       "CFServiceInstance",
+					APIVersion: korifiv1alpha1.SchemeGroupVersion.Identifier(),
+					Name:       uuid.NewString(),
+				},
+				AppRef: corev1.LocalObjectReference{
+					Name: cfApp.Name,
+				},
+			},
+		}
+		helpers.EnsureCreate(adminClient, serviceBinding)
+		helpers.EnsurePatch(adminClient, serviceBinding, func(sb *korifiv1alpha1.CFServiceBinding) {
+			sb.Status.SyslogDrainURL = "syslog-tls://logs.example.com:6514"
+		})
+
+		podPhase = corev1.PodRunning
+		podAnnotations = nil
+	})
+
+	JustBeforeEach(func() {
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: testNamespace,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+					korifiv1alpha1.CFProcessTypeLabelKey: "web",
+					korifiv1alpha1.PodIndexLabelKey:      "3",
+				},
+				Annotations: podAnnotations,
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "application",
+					Image: "my-image",
+				}},
+			},
+		}
+		helpers.EnsureCreate(adminClient, pod)
+		helpers.EnsurePatch(adminClient, pod, func(p *corev1.Pod) {
+			p.Status.Phase = podPhase
+		})
+	})
+
+	It("forwards the pod logs to the drain", func() {
+		Eventually(func(g Gomega) {
+			drain := getDrain("syslog-tls://logs.example.com:6514")
+			g.Expect(drain).NotTo(BeNil())
+			g.Expect(drain.WriteCallCount()).To(Equal(2))
+
+			_, firstMessage := drain.WriteArgsForCall(0)
+			g.Expect(firstMessage).To(Equal(logdrains.Message{
+				Timestamp:  time.Date(2024, 10, 1, 12, 0, 0, 1, time.UTC),
+				Hostname:   "my-app",
+				AppGUID:    cfApp.Name,
+				InstanceID: "3",
+				SourceType: "APP/PROC/WEB",
+				Body:       "first line",
+			}))
+
+			_, secondMessage := drain.WriteArgsForCall(1)
+			g.Expect(secondMessage.Body).To(Equal("second line"))
+		}).Should(Succeed())
+	})
+
+	It("does not forward lines again when the log stream is reopened", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(streamCallsFor(pod)).To(BeNumerically(">", 1))
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(getDrain("syslog-tls://logs.example.com:6514").WriteCallCount()).To(Equal(2))
+		}, "1s").Should(Succeed())
+	})
+
+	When("a log line exceeds the maximum line length", func() {
+		BeforeEach(func() {
+			logStreamer.StreamStub = func(context.Context, *corev1.Pod, *metav1.Time) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(
+					"2024-10-01T12:00:00.000000001Z " + strings.Repeat("a", 100*1024) + "\n" +
+						"2024-10-01T12:00:01.000000001Z second line\n",
+				)), nil
+			}
+		})
+
+		It("truncates the long line and forwards the following ones", func() {
+			Eventually(func(g Gomega) {
+				drain := getDrain("syslog-tls://logs.example.com:6514")
+				g.Expect(drain).NotTo(BeNil())
+				g.Expect(drain.WriteCallCount()).To(Equal(2))
+
+				_, firstMessage := drain.WriteArgsForCall(0)
+				g.Expect(firstMessage.Body).To(HavePrefix("aaaa"))
+				g.Expect(len(firstMessage.Body)).To(BeNumerically("<", 64*1024))
+
+				_, secondMessage := drain.WriteArgsForCall(1)
+				g.Expect(secondMessage.Body).To(Equal("second line"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the instance exceeds its log rate limit", func() {
+		BeforeEach(func() {
+			podAnnotations = map[string]string{
+				korifiv1alpha1.LogRateLimitAnnotation: "15",
+			}
+
+			logStreamer.StreamStub = func(context.Context, *corev1.Pod, *metav1.Time) (io.ReadCloser, error) {
+				return io.NopCloser(strings.NewReader(
+					"2024-10-01T12:00:00.000000001Z first line\n" +
+						"2024-10-01T12:00:00.000000002Z second line\n" +
+						"2024-10-01T12:00:00.000000003Z third line\n" +
+						"2024-10-01T12:00:01.000000001Z fourth line\n",
+				)), nil
+			}
+		})
+
+		It("replaces the lines exceeding the limit within a second with a single notice", func() {
+			Eventually(func(g Gomega) {
+				drain := getDrain("syslog-tls://logs.example.com:6514")
+				g.Expect(drain).NotTo(BeNil())
+				g.Expect(drain.WriteCallCount()).To(Equal(3))
+
+				_, firstMessage := drain.WriteArgsForCall(0)
+				g.Expect(firstMessage.Body).To(Equal("first line"))
+
+				_, secondMessage := drain.WriteArgsForCall(1)
+				g.Expect(secondMessage.Body).To(Equal("app instance exceeded log rate limit (15 bytes/sec)"))
+
+				_, thirdMessage := drain.WriteArgsForCall(2)
+				g.Expect(thirdMessage.Body).To(Equal("fourth line"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the app has no bindings with drains", func() {
+		BeforeEach(func() {
+			helpers.EnsurePatch(adminClient, serviceBinding, func(sb *korifiv1alpha1.CFServiceBinding) {
+				sb.Status.SyslogDrainURL = ""
+			})
+		})
+
+		It("does not stream the pod logs", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(streamCallsFor(pod)).To(BeZero())
+			}, "1s").Should(Succeed())
+		})
+	})
+
+	When("the pod is not running", func() {
+		BeforeEach(func() {
+			podPhase = corev1.PodPending
+		})
+
+		It("does not stream the pod logs", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(streamCallsFor(pod)).To(BeZero())
+			}, "1s").Should(Succeed())
+		})
+	})
+
+	When("the drain url changes", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				drain := getDrain("syslog-tls://logs.example.com:6514")
+				g.Expect(drain).NotTo(BeNil())
+				g.Expect(drain.WriteCallCount()).To(Equal(2))
+			}).Should(Succeed())
+
+			helpers.EnsurePatch(adminClient, serviceBinding, func(sb *korifiv1alpha1.CFServiceBinding) {
+				sb.Status.SyslogDrainURL = "https://logs.example.com"
+			})
+		})
+
+		It("closes the old drain and forwards the logs to the new one", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(getDrain("syslog-tls://logs.example.com:6514").CloseCallCount()).To(Equal(1))
+
+				drain := getDrain("https://logs.example.com")
+				g.Expect(drain).NotTo(BeNil())
+				g.Expect(drain.WriteCallCount()).To(Equal(2))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the pod is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				drain := getDrain("syslog-tls://logs.example.com:6514")
+				g.Expect(drain).NotTo(BeNil())
+				g.Expect(drain.WriteCallCount()).To(Equal(2))
+			}).Should(Succeed())
+
+			helpers.EnsureDelete(adminClient, pod)
+		})
+
+		It("stops forwarding logs", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(getDrain("syslog-tls://logs.example.com:6514").CloseCallCount()).To(Equal(1))
+			}).Should(Succeed())
+		})
+	})
+})
+
+func streamCallsFor(pod *corev1.Pod) int {
+	calls := 0
+	for i := range logStreamer.StreamCallCount() {
+		_, streamedPod, _ := logStreamer.StreamArgsForCall(i)
+		if streamedPod.Name == pod.Name {
+			calls++
+		}
+	}
+
+	return calls
+}
@@ -0,0 +1,164 @@
+package logdrains
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const drainTimeout = 10 * time.Second
+
+//counterfeiter:generate -o fake -fake-name Drain . Drain
+
+type Drain interface {
+	Write(ctx context.Context, msg Message) error
+	Close() error
+}
+
+type DrainFactory func(drainURL string) (Drain, error)
+
+// NewDrainFactory returns a factory creating drains for `syslog` (plain
+// TCP), `syslog-tls` (TCP over TLS) and `https` drain URLs. Drains refuse to
+// connect to addresses within any of the blocked ranges
+func NewDrainFactory(tlsConfig *tls.Config, blockedRanges []netip.Prefix) DrainFactory {
+	dialer := newDialer(blockedRanges)
+
+	return func(drainURL string) (Drain, error) {
+		u, err := url.Parse(drainURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse drain url: %w", err)
+		}
+
+		switch u.Scheme {
+		case "syslog":
+			return &tcpDrain{address: u.Host, dialer: dialer}, nil
+		case "syslog-tls":
+			return &tcpDrain{address: u.Host, dialer: dialer, tlsConfig: tlsConfig}, nil
+		case "https":
+			return &httpsDrain{
+				url: drainURL,
+				httpClient: &http.Client{
+					Timeout: drainTimeout,
+					Transport: &http.Transport{
+						DialContext:     dialer.DialContext,
+						TLSClientConfig: tlsConfig,
+					},
+				},
+			}, nil
+		}
+
+		return nil, fmt.Errorf("unsupported drain url scheme %q", u.Scheme)
+	}
+}
+
+// newDialer returns a dialer that checks the resolved address right before
+// connecting, so that drain hostnames resolving into a blocked range are
+// rejected as well
+func newDialer(blockedRanges []netip.Prefix) *net.Dialer {
+	return &net.Dialer{
+		Timeout: drainTimeout,
+		ControlContext: func(_ context.Context, _, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("failed to parse drain address %q: %w", address, err)
+			}
+
+			addr := addrPort.Addr().Unmap()
+			for _, blockedRange := range blockedRanges {
+				if blockedRange.Contains(addr) {
+					return fmt.Errorf("drain address %s is within blocked range %s", addr, blockedRange)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+// tcpDrain writes messages to a syslog server using octet counting framing
+// as defined in RFC 6587. The connection is (re)established lazily on write
+type tcpDrain struct {
+	address   string
+	dialer    *net.Dialer
+	tlsConfig *tls.Config
+	conn      net.Conn
+}
+
+func (d *tcpDrain) Write(ctx context.Context, msg Message) error {
+	if d.conn == nil {
+		conn, err := d.dial(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to connect to drain %q: %w", d.address, err)
+		}
+		d.conn = conn
+	}
+
+	formatted := msg.Format()
+	err := d.conn.SetWriteDeadline(time.Now().Add(drainTimeout))
+	if err == nil {
+		_, err = fmt.Fprintf(d.conn, "%d %s", len(formatted), formatted)
+	}
+	if err != nil {
+		_ = d.Close()
+		return fmt.Errorf("failed to write to drain %q: %w", d.address, err)
+	}
+
+	return nil
+}
+
+func (d *tcpDrain) dial(ctx context.Context) (net.Conn, error) {
+	if d.tlsConfig == nil {
+		return d.dialer.DialContext(ctx, "tcp", d.address)
+	}
+
+	tlsDialer := &tls.Dialer{NetDialer: d.dialer, Config: d.tlsConfig}
+	return tlsDialer.DialContext(ctx, "tcp", d.address)
+}
+
+func (d *tcpDrain) Close() error {
+	if d.conn == nil {
+		return nil
+	}
+
+	err := d.conn.Close()
+	d.conn = nil
+	return err
+}
+
+// httpsDrain posts every message to the drain URL
+type httpsDrain struct {
+	url        string
+	httpClient *http.Client
+}
+
+func (d *httpsDrain) Write(ctx context.Context, msg Message) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(msg.Format()))
+	if err != nil {
+		return fmt.Errorf("failed to create drain request: %w", err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := d.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to drain: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("drain responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (d *httpsDrain) Close() error {
+	d.httpClient.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,213 @@
+package logdrains_test
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/logdrains"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Drains", func() {
+	var (
+		msg          logdrains.Message
+		drainFactory logdrains.DrainFactory
+		drain        logdrains.Drain
+		drainURL     string
+		factoryErr   error
+		writeErr     error
+	)
+
+	BeforeEach(func() {
+		msg = logdrains.Message{
+			Timestamp:  time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC),
+			Hostname:   "my-app",
+			AppGUID:    "app-guid",
+			InstanceID: "0",
+			SourceType: "APP/PROC/WEB",
+			Body:       "hello world",
+		}
+		drainFactory = logdrains.NewDrainFactory(&tls.Config{}, nil)
+	})
+
+	JustBeforeEach(func() {
+		drain, factoryErr = drainFactory(drainURL)
+		if factoryErr != nil {
+			return
+		}
+		DeferCleanup(func() {
+			Expect(drain.Close()).To(Succeed())
+		})
+
+		writeErr = drain.Write(ctx, msg)
+	})
+
+	Describe("syslog", func() {
+		var (
+			listener net.Listener
+			received chan string
+		)
+
+		BeforeEach(func() {
+			var err error
+			listener, err = net.Listen("tcp", "127.0.0.1:0")
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(func() {
+				_ = listener.Close()
+			})
+
+			received = make(chan string, 1)
+			go acceptOctetCountedMessage(listener, received)
+
+			drainURL = "syslog://" + listener.Addr().String()
+		})
+
+		It("sends the octet counted message over tcp", func() {
+			Expect(writeErr).NotTo(HaveOccurred())
+			Eventually(received).Should(Receive(Equal(string(msg.Format()))))
+		})
+
+		When("the drain is not reachable", func() {
+			BeforeEach(func() {
+				Expect(listener.Close()).To(Succeed())
+			})
+
+			It("returns an error", func() {
+				Expect(writeErr).To(MatchError(ContainSubstring("failed to connect to drain")))
+			})
+		})
+
+		When("the drain address is within a blocked range", func() {
+			BeforeEach(func() {
+				drainFactory = logdrains.NewDrainFactory(&tls.Config{}, []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")})
+			})
+
+			It("refuses to connect", func() {
+				Expect(writeErr).To(MatchError(ContainSubstring("within blocked range 127.0.0.0/8")))
+				Consistently(received).ShouldNot(Receive())
+			})
+		})
+	})
+
+	Describe("syslog-tls", func() {
+		var received chan string
+
+		BeforeEach(func() {
+			server := httptest.NewUnstartedServer(nil)
+			server.StartTLS()
+			certPool := x509.NewCertPool()
+			certPool.AddCert(server.Certificate())
+			tlsConfig := server.TLS.Clone()
+			server.Close()
+
+			listener, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+			Expect(err).NotTo(HaveOccurred())
+			DeferCleanup(listener.Close)
+
+			received = make(chan string, 1)
+			go acceptOctetCountedMessage(listener, received)
+
+			drainFactory = logdrains.NewDrainFactory(&tls.Config{RootCAs: certPool}, nil)
+			drainURL = "syslog-tls://" + listener.Addr().String()
+		})
+
+		It("sends the octet counted message over tls", func() {
+			Expect(writeErr).NotTo(HaveOccurred())
+			Eventually(received).Should(Receive(Equal(string(msg.Format()))))
+		})
+	})
+
+	Describe("https", func() {
+		var (
+			server     *httptest.Server
+			statusCode int
+			received   chan string
+		)
+
+		BeforeEach(func() {
+			statusCode = http.StatusOK
+			received = make(chan string, 1)
+			server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				received <- r.Method + " " + r.Header.Get("Content-Type") + " " + string(body)
+				w.WriteHeader(statusCode)
+			}))
+			DeferCleanup(server.Close)
+
+			certPool := x509.NewCertPool()
+			certPool.AddCert(server.Certificate())
+			drainFactory = logdrains.NewDrainFactory(&tls.Config{RootCAs: certPool}, nil)
+			drainURL = server.URL
+		})
+
+		It("posts the message", func() {
+			Expect(writeErr).NotTo(HaveOccurred())
+			Expect(received).To(Receive(Equal("POST text/plain " + string(msg.Format()))))
+		})
+
+		When("the drain responds with an error", func() {
+			BeforeEach(func() {
+				statusCode = http.StatusBadGateway
+			})
+
+			It("returns an error", func() {
+				Expect(writeErr).To(MatchError(ContainSubstring("502")))
+			})
+		})
+
+		When("the drain address is within a blocked range", func() {
+			BeforeEach(func() {
+				drainFactory = logdrains.NewDrainFactory(&tls.Config{}, []netip.Prefix{netip.MustParsePrefix("127.0.0.1/32")})
+			})
+
+			It("refuses to connect", func() {
+				Expect(writeErr).To(MatchError(ContainSubstring("within blocked range 127.0.0.1/32")))
+				Expect(received).NotTo(Receive())
+			})
+		})
+	})
+
+	When("the drain url scheme is not supported", func() {
+		BeforeEach(func() {
+			drainURL = "ftp://logs.example.com"
+		})
+
+		It("returns an error", func() {
+			Expect(factoryErr).To(MatchError(ContainSubstring("unsupported drain url scheme")))
+		})
+	})
+})
+
+func acceptOctetCountedMessage(listener net.Listener, received chan<- string) {
+	defer GinkgoRecover()
+
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	length, err := reader.ReadString(' ')
+	Expect(err).NotTo(HaveOccurred())
+
+	msgLength, err := strconv.Atoi(strings.TrimSpace(length))
+	Expect(err).NotTo(HaveOccurred())
+
+	msg := make([]byte, msgLength)
+	_, err = io.ReadFull(reader, msg)
+	Expect(err).NotTo(HaveOccurred())
+
+	received <- string(msg)
+}
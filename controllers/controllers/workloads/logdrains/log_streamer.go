@@ -0,0 +1,36 @@
+package logdrains
+
+import (
+	"context"
+	"io"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+//counterfeiter:generate -o fake -fake-name LogStreamer . LogStreamer
+
+type LogStreamer interface {
+	Stream(ctx context.Context, pod *corev1.Pod, sinceTime *metav1.Time) (io.ReadCloser, error)
+}
+
+// PodLogStreamer follows the logs of the app workload pod container. Every
+// line is prefixed with its RFC 3339 timestamp
+type PodLogStreamer struct {
+	k8sClient kubernetes.Interface
+}
+
+func NewPodLogStreamer(k8sClient kubernetes.Interface) *PodLogStreamer {
+	return &PodLogStreamer{
+		k8sClient: k8sClient,
+	}
+}
+
+func (s *PodLogStreamer) Stream(ctx context.Context, pod *corev1.Pod, sinceTime *metav1.Time) (io.ReadCloser, error) {
+	return s.k8sClient.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{
+		Follow:     true,
+		Timestamps: true,
+		SinceTime:  sinceTime,
+	}).Stream(ctx)
+}
@@ -0,0 +1,78 @@
+package logdrains
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// syslog facility "user" (1) and severity "informational" (6)
+	appLogPriority = 1*8 + 6
+
+	// the private enterprise number used by Cloud Foundry for the tags
+	// structured data element
+	cfEnterpriseNumber = 47450
+
+	maxHostnameLength = 255
+	maxAppNameLength  = 48
+	maxProcIDLength   = 128
+)
+
+// Message is a single app log line to be forwarded to a syslog drain
+type Message struct {
+	Timestamp  time.Time
+	Hostname   string
+	AppGUID    string
+	InstanceID string
+	SourceType string
+	Body       string
+}
+
+// Format renders the message as an RFC 5424 syslog message, carrying the
+// app GUID, instance ID and source type in a CF tags structured data element
+func (m Message) Format() []byte {
+	return fmt.Appendf(nil, "<%d>1 %s %s %s %s - %s %s",
+		appLogPriority,
+		m.Timestamp.UTC().Format(time.RFC3339Nano),
+		headerField(m.Hostname, maxHostnameLength),
+		headerField(m.AppGUID, maxAppNameLength),
+		headerField(fmt.Sprintf("[%s/%s]", m.SourceType, m.InstanceID), maxProcIDLength),
+		m.structuredData(),
+		m.Body,
+	)
+}
+
+func (m Message) structuredData() string {
+	return fmt.Sprintf(`[tags@%d app_id="%s" instance_id="%s" source_type="%s"]`,
+		cfEnterpriseNumber,
+		escapeParamValue(m.AppGUID),
+		escapeParamValue(m.InstanceID),
+		escapeParamValue(m.SourceType),
+	)
+}
+
+// headerField makes the value a valid RFC 5424 header field: printable
+// US-ASCII without spaces, truncated to the maximum field length
+func headerField(value string, maxLength int) string {
+	if value == "" {
+		return "-"
+	}
+
+	field := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return '-'
+		}
+		return r
+	}, value)
+
+	if len(field) > maxLength {
+		return field[:maxLength]
+	}
+
+	return field
+}
+
+func escapeParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
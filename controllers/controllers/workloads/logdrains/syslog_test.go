@@ -0,0 +1,61 @@
+package logdrains_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/logdrains"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Message", func() {
+	var msg logdrains.Message
+
+	BeforeEach(func() {
+		msg = logdrains.Message{
+			Timestamp:  time.Date(2024, 10, 1, 12, 0, 0, 1000, time.UTC),
+			Hostname:   "my-app",
+			AppGUID:    "app-guid",
+			InstanceID: "2",
+			SourceType: "APP/PROC/WEB",
+			Body:       "hello world",
+		}
+	})
+
+	It("formats the message as RFC 5424 syslog", func() {
+		Expect(string(msg.Format())).To(Equal(
+			`<14>1 2024-10-01T12:00:00.000001Z my-app app-guid [APP/PROC/WEB/2] - [tags@47450 app_id="app-guid" instance_id="2" source_type="APP/PROC/WEB"] hello world`,
+		))
+	})
+
+	When("the hostname contains spaces", func() {
+		BeforeEach(func() {
+			msg.Hostname = "my fancy app"
+		})
+
+		It("replaces them", func() {
+			Expect(string(msg.Format())).To(HavePrefix("<14>1 2024-10-01T12:00:00.000001Z my-fancy-app app-guid "))
+		})
+	})
+
+	When("the hostname is empty", func() {
+		BeforeEach(func() {
+			msg.Hostname = ""
+		})
+
+		It("uses the nil value", func() {
+			Expect(string(msg.Format())).To(HavePrefix("<14>1 2024-10-01T12:00:00.000001Z - app-guid "))
+		})
+	})
+
+	When("structured data values contain special characters", func() {
+		BeforeEach(func() {
+			msg.InstanceID = `a"b]c\d`
+		})
+
+		It("escapes them", func() {
+			Expect(string(msg.Format())).To(ContainSubstring(`instance_id="a\"b\]c\\d"`))
+		})
+	})
+})
@@ -22,10 +22,13 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/instanceidentity"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/ports"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -48,6 +51,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+const defaultProbePeriodSeconds = 30
+
 type ProcessEnvBuilder interface {
 	Build(context.Context, *korifiv1alpha1.CFApp, *korifiv1alpha1.CFProcess) ([]corev1.EnvVar, error)
 }
@@ -58,6 +63,8 @@ type Reconciler struct {
 	log              logr.Logger
 	controllerConfig *config.ControllerConfig
 	envBuilder       ProcessEnvBuilder
+	usageRecorder    *usage.Recorder
+	identityIssuer   *instanceidentity.Issuer
 }
 
 func NewReconciler(
@@ -66,8 +73,18 @@ func NewReconciler(
 	log logr.Logger,
 	controllerConfig *config.ControllerConfig,
 	envBuilder ProcessEnvBuilder,
+	usageRecorder *usage.Recorder,
+	identityIssuer *instanceidentity.Issuer,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFProcess] {
-	processReconciler := Reconciler{k8sClient: client, scheme: scheme, log: log, controllerConfig: controllerConfig, envBuilder: envBuilder}
+	processReconciler := Reconciler{
+		k8sClient:        client,
+		scheme:           scheme,
+		log:              log,
+		controllerConfig: controllerConfig,
+		envBuilder:       envBuilder,
+		usageRecorder:    usageRecorder,
+		identityIssuer:   identityIssuer,
+	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFProcess](log, client, &processReconciler)
 }
 
@@ -82,6 +99,14 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&korifiv1alpha1.CFRoute{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForRoute),
+		).
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForNamespace),
+		).
+		Watches(
+			&korifiv1alpha1.CFIsolationSegment{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForIsolationSegment),
 		)
 }
 
@@ -120,6 +145,38 @@ func (r *Reconciler) enqueueCFProcessRequestsForRoute(ctx context.Context, o cli
 	return result
 }
 
+func (r *Reconciler) enqueueCFProcessRequestsForNamespace(ctx context.Context, o client.Object) []reconcile.Request {
+	processList := &korifiv1alpha1.CFProcessList{}
+	err := r.k8sClient.List(ctx, processList, client.InNamespace(o.GetName()))
+	if err != nil {
+		r.log.Error(fmt.Errorf("listing CFProcesses for namespace failed: %w", err), "namespace", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range processList.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&processList.Items[i])})
+	}
+
+	return requests
+}
+
+func (r *Reconciler) enqueueCFProcessRequestsForIsolationSegment(ctx context.Context, o client.Object) []reconcile.Request {
+	namespaceList := &corev1.NamespaceList{}
+	err := r.k8sClient.List(ctx, namespaceList, client.MatchingLabels{korifiv1alpha1.IsolationSegmentGUIDLabelKey: o.GetName()})
+	if err != nil {
+		r.log.Error(fmt.Errorf("listing namespaces for isolation segment failed: %w", err), "isolationSegmentGUID", o.GetName())
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for i := range namespaceList.Items {
+		requests = append(requests, r.enqueueCFProcessRequestsForNamespace(ctx, &namespaceList.Items[i])...)
+	}
+
+	return requests
+}
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/finalizers,verbs=update
@@ -127,6 +184,8 @@ func (r *Reconciler) enqueueCFProcessRequestsForRoute(ctx context.Context, o cli
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfisolationsegments,verbs=get;list;watch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -150,7 +209,14 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		return ctrl.Result{}, err
 	}
 
+	var identityRenewIn time.Duration
 	if needsAppWorkload(cfApp, cfProcess) {
+		identityRenewIn, err = r.identityIssuer.EnsureCredentials(ctx, cfProcess, instanceIdentitySecretName(cfProcess), cfApp.Name, *cfProcess.Spec.DesiredInstances)
+		if err != nil {
+			log.Info("error when ensuring instance identity credentials", "reason", err)
+			return ctrl.Result{}, err
+		}
+
 		err = r.createOrPatchAppWorkload(ctx, cfApp, cfProcess)
 		if err != nil {
 			return ctrl.Result{}, err
@@ -162,6 +228,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		return ctrl.Result{}, err
 	}
 
+	err = r.usageRecorder.RecordAppUsage(ctx, cfApp, cfProcess)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
 	appWorkloads, err := r.fetchAppWorkloadsForProcess(ctx, cfProcess)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -174,7 +245,11 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1a
 		return ctrl.Result{}, k8s.NewNotReadyError().WithReason("AppWorkloadsNotReady").WithRequeue()
 	}
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: identityRenewIn}, nil
+}
+
+func instanceIdentitySecretName(cfProcess *korifiv1alpha1.CFProcess) string {
+	return cfProcess.Name + "-instance-identity"
 }
 
 func allReady(appWorkloads []korifiv1alpha1.AppWorkload) bool {
@@ -239,7 +314,6 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 
 	var cfRoutesForProcess korifiv1alpha1.CFRouteList
 	err = r.k8sClient.List(ctx, &cfRoutesForProcess,
-		client.InNamespace(cfProcess.Namespace),
 		client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name},
 	)
 	if err != nil {
@@ -254,6 +328,12 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 		return err
 	}
 
+	scheduling, err := shared.GetWorkloadScheduling(ctx, r.k8sClient, r.controllerConfig.CFRootNamespace, cfProcess.Namespace)
+	if err != nil {
+		log.Info("error when trying to get the workload scheduling", "namespace", cfProcess.Namespace, "reason", err)
+		return err
+	}
+
 	appWorkload := &korifiv1alpha1.AppWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      getDesiredAppWorkloadName(cfApp, cfProcess),
@@ -285,7 +365,7 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 		appWorkload.Spec.ProcessType = cfProcess.Spec.ProcessType
 		appWorkload.Spec.Command = commandForProcess(cfProcess, cfApp)
 		appWorkload.Spec.AppGUID = cfApp.Name
-		appWorkload.Spec.Image = cfBuild.Status.Droplet.Registry.Image
+		appWorkload.Spec.Image = cfBuild.Status.Droplet.RunImage()
 		appWorkload.Spec.ImagePullSecrets = cfBuild.Status.Droplet.Registry.ImagePullSecrets
 
 		appWorkload.Spec.Ports = appPorts
@@ -295,7 +375,12 @@ func (r *Reconciler) createOrPatchAppWorkload(ctx context.Context, cfApp *korifi
 
 		appWorkload.Spec.StartupProbe = startupProbe(cfProcess, appPorts)
 		appWorkload.Spec.LivenessProbe = livenessProbe(cfProcess, appPorts)
+		appWorkload.Spec.ReadinessProbe = readinessProbe(cfProcess, appPorts)
 		appWorkload.Spec.RunnerName = r.controllerConfig.RunnerName
+		appWorkload.Spec.Scheduling = scheduling
+		appWorkload.Spec.LogRateLimitBytesPerSecond = cfProcess.Spec.LogRateLimitBytesPerSecond
+		appWorkload.Spec.TerminationGracePeriodSeconds = cfProcess.Spec.TerminationGracePeriodSeconds
+		appWorkload.Spec.InstanceIdentitySecretName = instanceIdentitySecretName(cfProcess)
 
 		if appWorkload.CreationTimestamp.IsZero() {
 			appWorkload.Spec.Services = cfApp.Status.ServiceBindings
@@ -390,13 +475,13 @@ func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CF
 	return []string{"/bin/sh", "-c", cmd}
 }
 
-func makeProbeHandler(cfProcess *korifiv1alpha1.CFProcess, port int32) corev1.ProbeHandler {
+func makeProbeHandler(healthCheckType korifiv1alpha1.HealthCheckType, httpEndpoint string, port int32) corev1.ProbeHandler {
 	var probeHandler corev1.ProbeHandler
 
-	switch cfProcess.Spec.HealthCheck.Type {
+	switch healthCheckType {
 	case korifiv1alpha1.HTTPHealthCheckType:
 		probeHandler.HTTPGet = &corev1.HTTPGetAction{
-			Path: cfProcess.Spec.HealthCheck.Data.HTTPEndpoint,
+			Path: httpEndpoint,
 			Port: intstr.FromInt32(port),
 		}
 	case korifiv1alpha1.PortHealthCheckType:
@@ -418,7 +503,7 @@ func startupProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.Pr
 	}
 
 	return &corev1.Probe{
-		ProbeHandler:   makeProbeHandler(cfProcess, ports[0]),
+		ProbeHandler:   makeProbeHandler(cfProcess.Spec.HealthCheck.Type, cfProcess.Spec.HealthCheck.Data.HTTPEndpoint, ports[0]),
 		TimeoutSeconds: int32(cfProcess.Spec.HealthCheck.Data.InvocationTimeoutSeconds),
 		PeriodSeconds:  2,
 		FailureThreshold: int32(cfProcess.Spec.HealthCheck.Data.TimeoutSeconds/2 +
@@ -436,13 +521,41 @@ func livenessProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.P
 	}
 
 	return &corev1.Probe{
-		ProbeHandler:     makeProbeHandler(cfProcess, ports[0]),
+		ProbeHandler:     makeProbeHandler(cfProcess.Spec.HealthCheck.Type, cfProcess.Spec.HealthCheck.Data.HTTPEndpoint, ports[0]),
 		TimeoutSeconds:   int32(cfProcess.Spec.HealthCheck.Data.InvocationTimeoutSeconds),
-		PeriodSeconds:    30,
+		PeriodSeconds:    probePeriodSeconds(cfProcess.Spec.HealthCheck.Data.IntervalSeconds),
 		FailureThreshold: 1,
 	}
 }
 
+// readinessProbe only takes instances out of routing, so unlike the liveness
+// probe it tolerates a few failures before marking the instance unready
+func readinessProbe(cfProcess *korifiv1alpha1.CFProcess, ports []int32) *corev1.Probe {
+	readinessHealthCheck := cfProcess.Spec.ReadinessHealthCheck
+	if readinessHealthCheck.Type == "" || readinessHealthCheck.Type == korifiv1alpha1.ProcessHealthCheckType {
+		return nil
+	}
+
+	if len(ports) == 0 {
+		return nil
+	}
+
+	return &corev1.Probe{
+		ProbeHandler:     makeProbeHandler(readinessHealthCheck.Type, readinessHealthCheck.Data.HTTPEndpoint, ports[0]),
+		TimeoutSeconds:   readinessHealthCheck.Data.InvocationTimeoutSeconds,
+		PeriodSeconds:    probePeriodSeconds(readinessHealthCheck.Data.IntervalSeconds),
+		FailureThreshold: 3,
+	}
+}
+
+func probePeriodSeconds(intervalSeconds int32) int32 {
+	if intervalSeconds == 0 {
+		return defaultProbePeriodSeconds
+	}
+
+	return intervalSeconds
+}
+
 func mebibyteQuantity(miB int64) resource.Quantity {
 	return *resource.NewQuantity(miB*1024*1024, resource.BinarySI)
 }
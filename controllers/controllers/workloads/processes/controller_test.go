@@ -13,6 +13,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -151,6 +152,9 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				DesiredInstances: tools.PtrTo[int32](1),
 				MemoryMB:         1024,
 				DiskQuotaMB:      100,
+
+				LogRateLimitBytesPerSecond:    tools.PtrTo[int64](1024),
+				TerminationGracePeriodSeconds: tools.PtrTo[int64](15),
 			},
 		}
 	})
@@ -180,6 +184,17 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	It("does not record usage events for a process that has never run", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+			g.Expect(cfProcess.Status.ObservedGeneration).To(Equal(cfProcess.Generation))
+		}).Should(Succeed())
+
+		Consistently(func(g Gomega) {
+			g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(BeEmpty())
+		}, "1s").Should(Succeed())
+	})
+
 	When("the process is being deleted gracefully", func() {
 		BeforeEach(func() {
 			cfProcess.Finalizers = []string{"do-not-delete-yet"}
@@ -210,6 +225,58 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})).To(Succeed())
 		})
 
+		It("records a STARTED app usage event", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"State":         Equal(korifiv1alpha1.AppUsageStateStarted),
+						"PreviousState": BeEmpty(),
+						"SpaceGUID":     Equal(testNamespace),
+						"App": PointTo(MatchFields(IgnoreExtras, Fields{
+							"AppGUID":               Equal(cfApp.Name),
+							"AppName":               Equal("test-app-name"),
+							"ProcessType":           Equal(korifiv1alpha1.ProcessTypeWeb),
+							"InstanceCount":         BeEquivalentTo(1),
+							"MemoryInMBPerInstance": BeEquivalentTo(1024),
+						})),
+					}),
+				})))
+
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(cfProcess.Status.LastRecordedUsage).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"State":      Equal(korifiv1alpha1.AppUsageStateStarted),
+					"EventCount": BeEquivalentTo(1),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the process is scaled", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(HaveLen(1))
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](3)
+				})).To(Succeed())
+			})
+
+			It("records a SCALED app usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":         Equal(korifiv1alpha1.AppUsageStateScaled),
+							"PreviousState": Equal(korifiv1alpha1.AppUsageStateStarted),
+							"App": PointTo(MatchFields(IgnoreExtras, Fields{
+								"InstanceCount":         BeEquivalentTo(3),
+								"PreviousInstanceCount": BeEquivalentTo(1),
+							})),
+						}),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
 		It("reconciles the CFProcess into an AppWorkload", func() {
 			withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
 				g.Expect(appWorkload.OwnerReferences).To(ConsistOf(metav1.OwnerReference{
@@ -248,6 +315,9 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				g.Expect(appWorkload.Spec.Resources.Requests.Memory()).To(matchers.RepresentResourceQuantity(cfProcess.Spec.MemoryMB, "Mi"))
 				g.Expect(appWorkload.Spec.Resources.Requests.Cpu()).To(matchers.RepresentResourceQuantity(100, "m"))
 
+				g.Expect(appWorkload.Spec.LogRateLimitBytesPerSecond).To(PointTo(BeEquivalentTo(1024)))
+				g.Expect(appWorkload.Spec.TerminationGracePeriodSeconds).To(PointTo(BeEquivalentTo(15)))
+
 				g.Expect(appWorkload.Spec.Env).To(ConsistOf(
 					MatchFields(IgnoreExtras, Fields{"Name": Equal("PORT")}),
 					MatchFields(IgnoreExtras, Fields{"Name": Equal("MEMORY_LIMIT")}),
@@ -260,6 +330,78 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 				))
 
 				g.Expect(appWorkload.Spec.RunnerName).To(Equal("cf-process-controller-test"))
+				g.Expect(appWorkload.Spec.InstanceIdentitySecretName).To(Equal(cfProcess.Name + "-instance-identity"))
+			})
+		})
+
+		When("the droplet image is pinned to a digest", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, adminClient, cfBuild, func() {
+					cfBuild.Status.Droplet.ImageDigest = "sha256:abc"
+				})).To(Succeed())
+			})
+
+			It("runs the pinned image", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Image).To(Equal("image/registry/url@sha256:abc"))
+				})
+			})
+		})
+
+		It("issues instance identity credentials for each process instance", func() {
+			Eventually(func(g Gomega) {
+				identitySecret := &corev1.Secret{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: cfProcess.Name + "-instance-identity-0"}, identitySecret)).To(Succeed())
+				g.Expect(identitySecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind": Equal("CFProcess"),
+					"Name": Equal(cfProcess.Name),
+				})))
+				g.Expect(identitySecret.Labels).To(HaveKeyWithValue(korifiv1alpha1.InstanceIdentitySecretLabelKey, cfProcess.Name+"-instance-identity"))
+				g.Expect(identitySecret.Data).To(MatchAllKeys(Keys{
+					"ca.crt":  Not(BeEmpty()),
+					"tls.crt": Not(BeEmpty()),
+					"tls.key": Not(BeEmpty()),
+				}))
+			}).Should(Succeed())
+		})
+
+		When("the process instances are scaled", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: cfProcess.Name + "-instance-identity-0"}, &corev1.Secret{})).To(Succeed())
+				}).Should(Succeed())
+
+				Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](2)
+				})).To(Succeed())
+			})
+
+			It("issues credentials for the new instances in a secret of their own", func() {
+				Eventually(func(g Gomega) {
+					identitySecret := &corev1.Secret{}
+					g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: cfProcess.Name + "-instance-identity-1"}, identitySecret)).To(Succeed())
+					g.Expect(identitySecret.Data).To(HaveKey("tls.crt"))
+					g.Expect(identitySecret.Data).To(HaveKey("tls.key"))
+				}).Should(Succeed())
+			})
+
+			When("the process instances are scaled down again", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: cfProcess.Name + "-instance-identity-1"}, &corev1.Secret{})).To(Succeed())
+					}).Should(Succeed())
+
+					Expect(k8s.Patch(ctx, adminClient, cfProcess, func() {
+						cfProcess.Spec.DesiredInstances = tools.PtrTo[int32](1)
+					})).To(Succeed())
+				})
+
+				It("deletes the secrets of the surplus instances", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: cfProcess.Name + "-instance-identity-1"}, &corev1.Secret{})
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
 			})
 		})
 
@@ -352,6 +494,63 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the CFProcess health check sets an interval", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.HealthCheck = korifiv1alpha1.HealthCheck{
+					Type: "port",
+					Data: korifiv1alpha1.HealthCheckData{
+						InvocationTimeoutSeconds: 3,
+						TimeoutSeconds:           9,
+						IntervalSeconds:          12,
+					},
+				}
+			})
+
+			It("uses the interval as the liveness probe period", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.LivenessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.LivenessProbe.PeriodSeconds).To(BeEquivalentTo(12))
+				})
+			})
+		})
+
+		When("the CFProcess has an http readiness health check", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ReadinessHealthCheck = korifiv1alpha1.ReadinessHealthCheck{
+					Type: "http",
+					Data: korifiv1alpha1.ReadinessHealthCheckData{
+						HTTPEndpoint:             "/ready",
+						InvocationTimeoutSeconds: 4,
+						IntervalSeconds:          5,
+					},
+				}
+			})
+
+			It("sets a readiness probe on the AppWorkload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.ReadinessProbe).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.HTTPGet).ToNot(BeNil())
+					g.Expect(appWorkload.Spec.ReadinessProbe.HTTPGet.Path).To(Equal("/ready"))
+					g.Expect(appWorkload.Spec.ReadinessProbe.HTTPGet.Port.IntValue()).To(Equal(8080))
+					g.Expect(appWorkload.Spec.ReadinessProbe.PeriodSeconds).To(BeEquivalentTo(5))
+					g.Expect(appWorkload.Spec.ReadinessProbe.TimeoutSeconds).To(BeEquivalentTo(4))
+					g.Expect(appWorkload.Spec.ReadinessProbe.FailureThreshold).To(BeEquivalentTo(3))
+				})
+			})
+		})
+
+		When("the CFProcess has a process readiness health check", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.ReadinessHealthCheck = korifiv1alpha1.ReadinessHealthCheck{Type: "process"}
+			})
+
+			It("does not set a readiness probe on the AppWorkload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.ReadinessProbe).To(BeNil())
+				})
+			})
+		})
+
 		When("the CFProcess has a process health check", func() {
 			BeforeEach(func() {
 				cfProcess.Spec.HealthCheck = korifiv1alpha1.HealthCheck{Type: "process"}
@@ -453,6 +652,65 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		It("does not constrain the app workload scheduling", func() {
+			withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.Scheduling).To(BeNil())
+			})
+		})
+
+		When("the space is assigned to an isolation segment", func() {
+			var isolationSegment *korifiv1alpha1.CFIsolationSegment
+
+			BeforeEach(func() {
+				isolationSegment = &korifiv1alpha1.CFIsolationSegment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace,
+					},
+					Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+						DisplayName: "pci",
+						Scheduling: korifiv1alpha1.WorkloadScheduling{
+							NodeSelector: map[string]string{"pool": "pci"},
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+
+				namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}}
+				Expect(k8s.PatchResource(ctx, adminClient, namespace, func() {
+					namespace.Labels = map[string]string{korifiv1alpha1.IsolationSegmentGUIDLabelKey: isolationSegment.Name}
+				})).To(Succeed())
+			})
+
+			It("sets the isolation segment scheduling on the app workload", func() {
+				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Scheduling).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"NodeSelector": Equal(map[string]string{"pool": "pci"}),
+					})))
+				})
+			})
+
+			When("the isolation segment scheduling changes", func() {
+				JustBeforeEach(func() {
+					withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.Scheduling).NotTo(BeNil())
+					})
+
+					Expect(k8s.PatchResource(ctx, adminClient, isolationSegment, func() {
+						isolationSegment.Spec.Scheduling.NodeSelector = map[string]string{"pool": "pci-2"}
+					})).To(Succeed())
+				})
+
+				It("updates the app workload scheduling", func() {
+					withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.Scheduling).To(PointTo(MatchFields(IgnoreExtras, Fields{
+							"NodeSelector": Equal(map[string]string{"pool": "pci-2"}),
+						})))
+					})
+				})
+			})
+		})
+
 		When("the app bindings change after the workload has been created", func() {
 			JustBeforeEach(func() {
 				withAppWorkload(func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
@@ -528,6 +786,17 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 					g.Expect(appWorkloads.Items).To(BeEmpty())
 				}).Should(Succeed())
 			})
+
+			It("records a STOPPED app usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, cfProcess.Name)).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":         Equal(korifiv1alpha1.AppUsageStateStopped),
+							"PreviousState": Equal(korifiv1alpha1.AppUsageStateStarted),
+						}),
+					})))
+				}).Should(Succeed())
+			})
 		})
 
 		When("the app process instances are scaled down to 0", func() {
@@ -607,6 +876,20 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 	})
 })
 
+func listAppUsageEvents(g Gomega, processGUID string) []korifiv1alpha1.CFUsageEvent {
+	var usageEvents korifiv1alpha1.CFUsageEventList
+	g.Expect(adminClient.List(ctx, &usageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+
+	result := []korifiv1alpha1.CFUsageEvent{}
+	for _, event := range usageEvents.Items {
+		if event.Spec.App != nil && event.Spec.App.ProcessGUID == processGUID {
+			result = append(result, event)
+		}
+	}
+
+	return result
+}
+
 func withAppWorkload(shouldFn func(Gomega, korifiv1alpha1.AppWorkload)) {
 	GinkgoHelper()
 
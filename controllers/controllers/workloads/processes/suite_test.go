@@ -9,7 +9,9 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/instanceidentity"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/processes"
 	"code.cloudfoundry.org/korifi/tests/helpers"
 
@@ -35,6 +37,7 @@ var (
 	testEnv         *envtest.Environment
 	adminClient     client.Client
 	testNamespace   string
+	rootNamespace   string
 	k8sManager      manager.Manager
 )
 
@@ -69,8 +72,16 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	rootNamespace = uuid.NewString()
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: rootNamespace,
+		},
+	})).To(Succeed())
+
 	controllerConfig := &config.ControllerConfig{
-		RunnerName: "cf-process-controller-test",
+		RunnerName:      "cf-process-controller-test",
+		CFRootNamespace: rootNamespace,
 	}
 
 	err = processes.NewReconciler(
@@ -79,6 +90,8 @@ var _ = BeforeSuite(func() {
 		ctrl.Log.WithName("controllers").WithName("CFProcess"),
 		controllerConfig,
 		env.NewProcessEnvBuilder(k8sManager.GetClient()),
+		usage.NewRecorder(k8sManager.GetClient(), rootNamespace),
+		instanceidentity.NewIssuer(k8sManager.GetClient(), rootNamespace, time.Hour),
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
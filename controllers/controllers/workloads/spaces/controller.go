@@ -18,6 +18,7 @@ package spaces
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -31,6 +32,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8s_labels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -91,6 +93,10 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 		Watches(
 			&corev1.ServiceAccount{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForServiceAccount),
+		).
+		Watches(
+			&korifiv1alpha1.CFOrg{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFSpaceRequestsForOrg),
 		)
 }
 
@@ -128,16 +134,41 @@ func (r *Reconciler) enqueueCFSpaceRequestsForServiceAccount(ctx context.Context
 	return requests
 }
 
+func (r *Reconciler) enqueueCFSpaceRequestsForOrg(ctx context.Context, object client.Object) []reconcile.Request {
+	cfSpaceList := &korifiv1alpha1.CFSpaceList{}
+	err := r.client.List(ctx, cfSpaceList, client.InNamespace(object.GetName()))
+	if err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := make([]reconcile.Request, len(cfSpaceList.Items))
+	for i := range cfSpaceList.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cfSpaceList.Items[i])}
+	}
+
+	return requests
+}
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces/finalizers,verbs=update
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgs,verbs=get;list;watch
+
 //+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups="rbac.authorization.k8s.io",resources=rolebindings,verbs=create;patch;delete;get;list;watch
 //+kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;patch;delete
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfSpace *korifiv1alpha1.CFSpace) (ctrl.Result, error) {
+	if cfSpace.GetDeletionTimestamp().IsZero() {
+		isolationSegmentGUID, err := r.getIsolationSegmentGUID(ctx, cfSpace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		cfSpace.Status.IsolationSegmentGUID = isolationSegmentGUID
+	}
+
 	nsReconcileResult, err := r.namespaceReconciler.ReconcileResource(ctx, cfSpace)
 	if (nsReconcileResult != ctrl.Result{}) || (err != nil) {
 		return nsReconcileResult, err
@@ -154,6 +185,22 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfSpace *korifiv1alp
 	return ctrl.Result{}, nil
 }
 
+// getIsolationSegmentGUID returns the isolation segment assigned to the space,
+// falling back to the default isolation segment of its org
+func (r *Reconciler) getIsolationSegmentGUID(ctx context.Context, cfSpace *korifiv1alpha1.CFSpace) (string, error) {
+	if cfSpace.Spec.IsolationSegmentGUID != "" {
+		return cfSpace.Spec.IsolationSegmentGUID, nil
+	}
+
+	cfOrg := new(korifiv1alpha1.CFOrg)
+	err := r.client.Get(ctx, types.NamespacedName{Namespace: r.rootNamespace, Name: cfSpace.Namespace}, cfOrg)
+	if err != nil {
+		return "", client.IgnoreNotFound(fmt.Errorf("failed to get org %q: %w", cfSpace.Namespace, err))
+	}
+
+	return cfOrg.Spec.DefaultIsolationSegmentGUID, nil
+}
+
 func (r *Reconciler) reconcileServiceAccounts(ctx context.Context, space client.Object) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("reconcileServiceAccounts").
 		WithValues("rootNamespace", r.rootNamespace, "targetNamespace", space.GetName())
@@ -284,9 +331,10 @@ type cfSpaceMetadataCompiler struct {
 
 func (c *cfSpaceMetadataCompiler) CompileLabels(cfSpace *korifiv1alpha1.CFSpace) map[string]string {
 	return c.labelCompiler.Compile(map[string]string{
-		korifiv1alpha1.CFSpaceDisplayNameKey: cfSpace.Labels[korifiv1alpha1.CFSpaceDisplayNameKey],
-		korifiv1alpha1.SpaceGUIDLabelKey:     cfSpace.Name,
-		korifiv1alpha1.CFOrgGUIDKey:          cfSpace.Namespace,
+		korifiv1alpha1.CFSpaceDisplayNameKey:        cfSpace.Labels[korifiv1alpha1.CFSpaceDisplayNameKey],
+		korifiv1alpha1.SpaceGUIDLabelKey:            cfSpace.Name,
+		korifiv1alpha1.CFOrgGUIDKey:                 cfSpace.Namespace,
+		korifiv1alpha1.IsolationSegmentGUIDLabelKey: cfSpace.Status.IsolationSegmentGUID,
 	})
 }
 
@@ -71,6 +71,67 @@ var _ = Describe("CFSpaceReconciler Integration Tests", func() {
 		}).Should(Succeed())
 	})
 
+	Describe("isolation segments", func() {
+		var cfOrg *korifiv1alpha1.CFOrg
+
+		BeforeEach(func() {
+			cfOrg = &korifiv1alpha1.CFOrg{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testNamespace,
+					Namespace: cfRootNamespace,
+				},
+				Spec: korifiv1alpha1.CFOrgSpec{
+					DisplayName:                 uuid.NewString(),
+					DefaultIsolationSegmentGUID: "org-default-segment",
+				},
+			}
+			Expect(adminClient.Create(ctx, cfOrg)).To(Succeed())
+		})
+
+		It("labels the namespace with the org default isolation segment", func() {
+			Eventually(func(g Gomega) {
+				var ns corev1.Namespace
+				g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, &ns)).To(Succeed())
+				g.Expect(ns.Labels).To(HaveKeyWithValue(korifiv1alpha1.IsolationSegmentGUIDLabelKey, "org-default-segment"))
+
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), cfSpace)).To(Succeed())
+				g.Expect(cfSpace.Status.IsolationSegmentGUID).To(Equal("org-default-segment"))
+			}).Should(Succeed())
+		})
+
+		When("the space is assigned to an isolation segment", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfSpace, func() {
+					cfSpace.Spec.IsolationSegmentGUID = "space-segment"
+				})).To(Succeed())
+			})
+
+			It("labels the namespace with the space isolation segment", func() {
+				Eventually(func(g Gomega) {
+					var ns corev1.Namespace
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, &ns)).To(Succeed())
+					g.Expect(ns.Labels).To(HaveKeyWithValue(korifiv1alpha1.IsolationSegmentGUIDLabelKey, "space-segment"))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the org default isolation segment changes", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfOrg, func() {
+					cfOrg.Spec.DefaultIsolationSegmentGUID = "another-segment"
+				})).To(Succeed())
+			})
+
+			It("updates the namespace label", func() {
+				Eventually(func(g Gomega) {
+					var ns corev1.Namespace
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: cfSpace.Name}, &ns)).To(Succeed())
+					g.Expect(ns.Labels).To(HaveKeyWithValue(korifiv1alpha1.IsolationSegmentGUIDLabelKey, "another-segment"))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	Describe("service account propagation", func() {
 		var serviceAccount *corev1.ServiceAccount
 
@@ -23,6 +23,9 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/instanceidentity"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
@@ -56,6 +59,8 @@ type Reconciler struct {
 	log             logr.Logger
 	envBuilder      TaskEnvBuilder
 	taskTTLDuration time.Duration
+	rootNamespace   string
+	identityIssuer  *instanceidentity.Issuer
 }
 
 func NewReconciler(
@@ -65,6 +70,8 @@ func NewReconciler(
 	log logr.Logger,
 	envBuilder TaskEnvBuilder,
 	taskTTLDuration time.Duration,
+	rootNamespace string,
+	identityIssuer *instanceidentity.Issuer,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFTask] {
 	taskReconciler := Reconciler{
 		k8sClient:       client,
@@ -73,6 +80,8 @@ func NewReconciler(
 		log:             log,
 		envBuilder:      envBuilder,
 		taskTTLDuration: taskTTLDuration,
+		rootNamespace:   rootNamespace,
+		identityIssuer:  identityIssuer,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFTask](log, client, &taskReconciler)
 }
@@ -88,6 +97,8 @@ func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cftasks/finalizers,verbs=update
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=taskworkloads,verbs=get;list;watch;create;patch;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfisolationsegments,verbs=get;list;watch
 
 func (r *Reconciler) ReconcileResource(ctx context.Context, cfTask *korifiv1alpha1.CFTask) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
@@ -143,16 +154,35 @@ func (r *Reconciler) ReconcileResource(ctx context.Context, cfTask *korifiv1alph
 		return r.reconcileResult(cfTask, err)
 	}
 
-	taskWorkload, err := r.createOrPatchTaskWorkload(ctx, cfTask, cfDroplet, webProcess, env)
+	// completed tasks do not run anymore, hence their credentials are not
+	// renewed
+	var identityRenewIn time.Duration
+	if _, isCompleted := getCompletionTime(cfTask); !isCompleted {
+		identityRenewIn, err = r.identityIssuer.EnsureCredentials(ctx, cfTask, instanceIdentitySecretName(cfTask), cfApp.Name, 1)
+		if err != nil {
+			log.Info("failed to ensure instance identity credentials", "reason", err)
+			return r.reconcileResult(cfTask, err)
+		}
+	}
+
+	taskWorkload, err := r.createOrPatchTaskWorkload(ctx, cfTask, cfDroplet, webProcess, env, appVolumeMounts(cfApp))
 	if err != nil {
 		return r.reconcileResult(cfTask, err)
 	}
 
 	r.setTaskStatus(cfTask, taskWorkload.Status.Conditions)
 
+	if _, isCompleted := getCompletionTime(cfTask); !isCompleted {
+		return ctrl.Result{RequeueAfter: identityRenewIn}, nil
+	}
+
 	return r.reconcileResult(cfTask, nil)
 }
 
+func instanceIdentitySecretName(cfTask *korifiv1alpha1.CFTask) string {
+	return cfTask.Name + "-instance-identity"
+}
+
 func (r *Reconciler) setTaskStatus(cfTask *korifiv1alpha1.CFTask, taskWorkloadConditions []metav1.Condition) {
 	for _, conditionType := range []string{
 		korifiv1alpha1.TaskStartedConditionType,
@@ -248,9 +278,31 @@ func (r *Reconciler) getWebProcess(ctx context.Context, cfApp *korifiv1alpha1.CF
 	return processList.Items[0], nil
 }
 
-func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild, webProcess korifiv1alpha1.CFProcess, env []corev1.EnvVar) (*korifiv1alpha1.TaskWorkload, error) {
+func appVolumeMounts(cfApp *korifiv1alpha1.CFApp) []korifiv1alpha1.VolumeMount {
+	var volumeMounts []korifiv1alpha1.VolumeMount
+	for _, binding := range cfApp.Status.ServiceBindings {
+		volumeMounts = append(volumeMounts, binding.VolumeMounts...)
+	}
+
+	return volumeMounts
+}
+
+func (r *Reconciler) createOrPatchTaskWorkload(
+	ctx context.Context,
+	cfTask *korifiv1alpha1.CFTask,
+	cfDroplet *korifiv1alpha1.CFBuild,
+	webProcess korifiv1alpha1.CFProcess,
+	env []corev1.EnvVar,
+	volumeMounts []korifiv1alpha1.VolumeMount,
+) (*korifiv1alpha1.TaskWorkload, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchTaskWorkload")
 
+	scheduling, err := shared.GetWorkloadScheduling(ctx, r.k8sClient, r.rootNamespace, cfTask.Namespace)
+	if err != nil {
+		log.Info("failed to get the workload scheduling", "reason", err)
+		return nil, err
+	}
+
 	taskWorkload := &korifiv1alpha1.TaskWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cfTask.Name,
@@ -266,7 +318,7 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 		taskWorkload.Labels[korifiv1alpha1.CFTaskGUIDLabelKey] = cfTask.Name
 
 		taskWorkload.Spec.Command = []string{LifecycleLauncherPath, cfTask.Spec.Command}
-		taskWorkload.Spec.Image = cfDroplet.Status.Droplet.Registry.Image
+		taskWorkload.Spec.Image = cfDroplet.Status.Droplet.RunImage()
 		taskWorkload.Spec.ImagePullSecrets = cfDroplet.Status.Droplet.Registry.ImagePullSecrets
 
 		if taskWorkload.Spec.Resources.Requests == nil {
@@ -283,6 +335,13 @@ func (r *Reconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *kori
 		taskWorkload.Spec.Resources.Limits[corev1.ResourceEphemeralStorage] = *resource.NewScaledQuantity(cfTask.Status.DiskQuotaMB, resource.Mega)
 		taskWorkload.Spec.Resources.Requests[corev1.ResourceCPU] = *resource.NewScaledQuantity(calculateDefaultCPURequestMillicores(webProcess.Spec.MemoryMB), resource.Milli)
 		taskWorkload.Spec.Env = env
+		taskWorkload.Spec.VolumeMounts = volumeMounts
+		taskWorkload.Spec.InstanceIdentitySecretName = instanceIdentitySecretName(cfTask)
+		taskWorkload.Spec.LogRateLimitBytesPerSecond = tools.IfNil(cfTask.Spec.LogRateLimitBytesPerSecond, webProcess.Spec.LogRateLimitBytesPerSecond)
+
+		if taskWorkload.CreationTimestamp.IsZero() {
+			taskWorkload.Spec.Scheduling = scheduling
+		}
 
 		if err := ctrl.SetControllerReference(cfTask, taskWorkload, r.scheme); err != nil {
 			log.Info("failed to set owner ref", "reason", err)
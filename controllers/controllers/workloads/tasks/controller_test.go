@@ -2,6 +2,7 @@ package tasks_test
 
 import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/tools/k8s/conditions"
 
@@ -10,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -102,6 +104,8 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 				ProcessType: "web",
 				Command:     "echo hello",
 				MemoryMB:    768,
+
+				LogRateLimitBytesPerSecond: tools.PtrTo[int64](2048),
 				HealthCheck: korifiv1alpha1.HealthCheck{
 					Type: "process",
 				},
@@ -221,6 +225,8 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 				g.Expect(taskWorkload.Spec.Resources.Requests.StorageEphemeral().String()).To(Equal("256M"))
 				g.Expect(taskWorkload.Spec.Resources.Limits.StorageEphemeral().String()).To(Equal("256M"))
 				g.Expect(taskWorkload.Spec.Resources.Requests.Cpu().String()).To(Equal("75m"))
+				g.Expect(taskWorkload.Spec.InstanceIdentitySecretName).To(Equal(cfTask.Name + "-instance-identity"))
+				g.Expect(taskWorkload.Spec.LogRateLimitBytesPerSecond).To(PointTo(BeEquivalentTo(2048)))
 				g.Expect(taskWorkload.GetOwnerReferences()).To(ConsistOf(SatisfyAll(
 					HaveField("Name", cfTask.Name),
 					HaveField("Controller", PointTo(BeTrue())),
@@ -278,6 +284,17 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 			))
 		})
 
+		It("issues instance identity credentials for the task", func() {
+			Eventually(func(g Gomega) {
+				identitySecret := &corev1.Secret{}
+				g.Expect(adminClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: cfTask.Name + "-instance-identity-0"}, identitySecret)).To(Succeed())
+				g.Expect(identitySecret.GetOwnerReferences()).To(ConsistOf(HaveField("Name", cfTask.Name)))
+				g.Expect(identitySecret.Data).To(HaveKey("ca.crt"))
+				g.Expect(identitySecret.Data).To(HaveKey("tls.crt"))
+				g.Expect(identitySecret.Data).To(HaveKey("tls.key"))
+			}).Should(Succeed())
+		})
+
 		It("records a TaskWorkloadCreated event", func() {
 			Expect(eventRecorder.EventfCallCount()).To(Equal(eventCallCount+1), "eventRecorder.Eventf call count mismatch")
 			eventTaskObj, eventType, eventReason, eventMessage, eventMessageArgs := eventRecorder.EventfArgsForCall(eventCallCount)
@@ -401,6 +418,24 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 			Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfTask), cfTask)).To(Succeed())
 		})
 
+		It("does not issue instance identity credentials anymore", func() {
+			identitySecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: testNamespace,
+					Name:      cfTask.Name + "-instance-identity-0",
+				},
+			}
+			Expect(adminClient.Delete(ctx, identitySecret)).To(Succeed())
+			Expect(k8s.PatchResource(ctx, adminClient, cfTask, func() {
+				cfTask.Labels = map[string]string{"foo": "bar"}
+			})).To(Succeed())
+
+			Consistently(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(identitySecret), identitySecret)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
 		It("deletes the task after it expires", func() {
 			task := new(korifiv1alpha1.CFTask)
 
@@ -9,6 +9,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/instanceidentity"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/tasks"
 	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/tests/helpers"
@@ -70,6 +71,12 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	Expect(adminClient.Create(ctx, &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cf",
+		},
+	})).To(Succeed())
+
 	eventRecorder = new(controllerfake.EventRecorder)
 
 	err = tasks.NewReconciler(
@@ -79,6 +86,8 @@ var _ = BeforeSuite(func() {
 		ctrl.Log.WithName("controllers").WithName("CFTask"),
 		env.NewAppEnvBuilder(k8sManager.GetClient()),
 		2*time.Second,
+		"cf",
+		instanceidentity.NewIssuer(k8sManager.GetClient(), "cf", time.Hour),
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha1"
 	"fmt"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"github.com/go-logr/logr"
@@ -158,6 +159,41 @@ func (r NameRegistry) UnlockName(ctx context.Context, namespace, name string) er
 	return nil
 }
 
+// TransferNameOwnership atomically changes the owner of a registered name. It
+// fails if the name is not owned by the given current owner
+func (r NameRegistry) TransferNameOwnership(ctx context.Context, namespace, name, ownerNamespace, ownerName, newOwnerNamespace, newOwnerName string) error {
+	logger := r.logger.WithName("transfer-name-ownership").WithValues("namespace", namespace, "name", name)
+
+	if isDryRun(ctx, logger) {
+		return nil
+	}
+
+	lease := &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      r.hashName(name),
+			Namespace: namespace,
+		},
+	}
+	jsonPatch := fmt.Sprintf(`[
+    {"op":"test", "path":"/metadata/annotations/%[1]s", "value": %[3]q},
+    {"op":"test", "path":"/metadata/annotations/%[2]s", "value": %[4]q},
+    {"op":"replace", "path":"/metadata/annotations/%[1]s", "value": %[5]q},
+    {"op":"replace", "path":"/metadata/annotations/%[2]s", "value": %[6]q}
+    ]`,
+		jsonPointerEscape(OwnerNamespaceAnnotation), jsonPointerEscape(OwnerNameAnnotation),
+		ownerNamespace, ownerName, newOwnerNamespace, newOwnerName,
+	)
+
+	if err := r.client.Patch(ctx, lease, client.RawPatch(types.JSONPatchType, []byte(jsonPatch))); err != nil {
+		logger.Info("failed-to-transfer-name-ownership", "reason", err)
+		return fmt.Errorf("failed to transfer lease ownership: %w", err)
+	}
+
+	logger.V(1).Info("transferred-name-ownership", "newOwnerNamespace", newOwnerNamespace, "newOwnerName", newOwnerName)
+
+	return nil
+}
+
 func (r NameRegistry) CheckNameOwnership(ctx context.Context, namespace, name, ownerNamespace, ownerName string) (bool, error) {
 	r.logger.V(1).Info("checking-name-ownership")
 
@@ -175,6 +211,10 @@ func (r NameRegistry) hashName(name string) string {
 	return fmt.Sprintf("%s%x", hashedNamePrefix, sha1.Sum([]byte(input)))
 }
 
+func jsonPointerEscape(s string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(s, "~", "~0"), "/", "~1")
+}
+
 func isDryRun(ctx context.Context, logger logr.Logger) bool {
 	req, err := admission.RequestFromContext(ctx)
 	if err != nil {
@@ -2,6 +2,7 @@ package coordination_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	"code.cloudfoundry.org/korifi/controllers/coordination"
@@ -247,4 +248,62 @@ var _ = Describe("NameRegistry", func() {
 			})
 		})
 	})
+
+	Describe("TransferNameOwnership", func() {
+		JustBeforeEach(func() {
+			err = nameRegistry.TransferNameOwnership(ctx, "the-namespace", "the-name", "the-owner-namespace", "the-owner-name", "the-new-owner-namespace", "the-new-owner-name")
+		})
+
+		It("replaces the owner atomically using test and replace patching", func() {
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(client.PatchCallCount()).To(Equal(1))
+			_, obj, patch, _ := client.PatchArgsForCall(0)
+			Expect(obj).To(BeAssignableToTypeOf(&coordinationv1.Lease{}))
+			lease := obj.(*coordinationv1.Lease)
+			Expect(lease.Namespace).To(Equal("the-namespace"))
+			Expect(lease.Name).To(Equal("n-23d52b682183ce677443e399507b80c921c68a69"))
+
+			Expect(patch.Type()).To(Equal(types.JSONPatchType))
+			data, dataErr := patch.Data(nil)
+			Expect(dataErr).NotTo(HaveOccurred())
+
+			var ops []map[string]string
+			Expect(json.Unmarshal(data, &ops)).To(Succeed())
+			Expect(ops).To(Equal([]map[string]string{
+				{"op": "test", "path": "/metadata/annotations/coordination.cloudfoundry.org~1owner-namespace", "value": "the-owner-namespace"},
+				{"op": "test", "path": "/metadata/annotations/coordination.cloudfoundry.org~1owner-name", "value": "the-owner-name"},
+				{"op": "replace", "path": "/metadata/annotations/coordination.cloudfoundry.org~1owner-namespace", "value": "the-new-owner-namespace"},
+				{"op": "replace", "path": "/metadata/annotations/coordination.cloudfoundry.org~1owner-name", "value": "the-new-owner-name"},
+			}))
+		})
+
+		When("patching fails", func() {
+			BeforeEach(func() {
+				client.PatchReturns(errors.New("boom!"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError(SatisfyAll(
+					ContainSubstring("boom!"),
+					ContainSubstring("failed to transfer lease ownership"),
+				)))
+			})
+		})
+
+		When("in a dry-run request context", func() {
+			BeforeEach(func() {
+				ctx = admission.NewContextWithRequest(ctx, admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						DryRun: tools.PtrTo(true),
+					},
+				})
+			})
+
+			It("does not patch the lease", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(client.PatchCallCount()).To(BeZero())
+			})
+		})
+	})
 })
@@ -18,9 +18,11 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"log"
+	"net/netip"
 	"os"
 	"time"
 
@@ -29,6 +31,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/domains"
 	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes"
+	"code.cloudfoundry.org/korifi/controllers/controllers/networking/routes/gateway"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/bindings"
 	managed_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/managed"
 	upsi_bindings "code.cloudfoundry.org/korifi/controllers/controllers/services/bindings/upsi"
@@ -37,11 +40,15 @@ import (
 	upsi_instances "code.cloudfoundry.org/korifi/controllers/controllers/services/instances/upsi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/controllers/usage"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/apps"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/autoscaling"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/buildpack"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/build/docker"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/instanceidentity"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/labels"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/logdrains"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/orgs"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/packages"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/processes"
@@ -61,6 +68,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	versionwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/version"
 	appswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/apps"
+	isolationsegmentswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/isolationsegments"
 	orgswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/orgs"
 	packageswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/packages"
 	spaceswebhook "code.cloudfoundry.org/korifi/controllers/webhooks/workloads/spaces"
@@ -75,17 +83,20 @@ import (
 	k8sclient "k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/klog/v2"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	admission "k8s.io/pod-security-admission/api"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gatewayv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	custommetrics "k8s.io/metrics/pkg/client/custom_metrics"
 	//+kubebuilder:scaffold:imports
 )
 
@@ -97,8 +108,10 @@ var (
 func init() {
 	utilruntime.Must(buildv1alpha2.AddToScheme(scheme))
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(gatewayv1.Install(scheme))
 	utilruntime.Must(gatewayv1beta1.Install(scheme))
 	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(metricsv1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -170,6 +183,15 @@ func main() {
 	if os.Getenv("ENABLE_CONTROLLERS") != "false" {
 		controllersLog := ctrl.Log.WithName("controllers")
 		imageClient := image.NewClient(k8sClient)
+		usageRecorder := usage.NewRecorder(controllersClient, controllerConfig.CFRootNamespace)
+
+		var instanceIdentityCertValidity time.Duration
+		instanceIdentityCertValidity, err = controllerConfig.ParseInstanceIdentityCertValidity()
+		if err != nil {
+			setupLog.Error(err, "failed to parse instance identity certificate validity", "instanceIdentityCertValidity", controllerConfig.InstanceIdentityCertValidity)
+			os.Exit(1)
+		}
+		identityIssuer := instanceidentity.NewIssuer(controllersClient, controllerConfig.CFRootNamespace, instanceIdentityCertValidity)
 
 		if err = apps.NewReconciler(
 			controllersClient,
@@ -177,6 +199,7 @@ func main() {
 			controllersLog,
 			env.NewVCAPServicesEnvValueBuilder(controllersClient),
 			env.NewVCAPApplicationEnvValueBuilder(controllersClient, controllerConfig.ExtraVCAPApplicationValues),
+			usageRecorder,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFApp")
 			os.Exit(1)
@@ -224,15 +247,56 @@ func main() {
 			controllersLog,
 			controllerConfig,
 			env.NewProcessEnvBuilder(controllersClient),
+			usageRecorder,
+			identityIssuer,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFProcess")
 			os.Exit(1)
 		}
 
+		var autoscalerEvaluationInterval time.Duration
+		autoscalerEvaluationInterval, err = controllerConfig.ParseAutoscalerEvaluationInterval()
+		if err != nil {
+			setupLog.Error(err, "failed to parse autoscaler evaluation interval", "controller", "CFAutoscalingPolicy", "autoscalerEvaluationInterval", controllerConfig.AutoscalerEvaluationInterval)
+			os.Exit(1)
+		}
+		if err = autoscaling.NewReconciler(
+			controllersClient,
+			mgr.GetScheme(),
+			controllersLog,
+			autoscaling.NewPodMetricsFetcher(
+				mgr.GetAPIReader(),
+				custommetrics.NewForConfig(conf, mgr.GetRESTMapper(), custommetrics.NewAvailableAPIsGetter(k8sClient.Discovery())),
+				controllerConfig.AutoscalerThroughputMetric,
+			),
+			autoscalerEvaluationInterval,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFAutoscalingPolicy")
+			os.Exit(1)
+		}
+
+		var logDrainBlockedRanges []netip.Prefix
+		logDrainBlockedRanges, err = controllerConfig.ParseLogDrainBlockedRanges()
+		if err != nil {
+			setupLog.Error(err, "failed to parse log drain blocked ranges", "controller", "LogDrains", "logDrainBlockedRanges", controllerConfig.LogDrainBlockedRanges)
+			os.Exit(1)
+		}
+		if err = logdrains.NewReconciler(
+			controllersClient,
+			controllersLog,
+			logdrains.NewPodLogStreamer(k8sClient),
+			logdrains.NewDrainFactory(&tls.Config{MinVersion: tls.VersionTLS12}, logDrainBlockedRanges),
+			5*time.Second,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "LogDrains")
+			os.Exit(1)
+		}
+
 		if err = (upsi_instances.NewReconciler(
 			controllersClient,
 			mgr.GetScheme(),
 			controllersLog,
+			usageRecorder,
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "UPSICFServiceInstance")
 			os.Exit(1)
@@ -247,6 +311,7 @@ func main() {
 				controllersClient,
 				osbapi.NewClientFactory(controllersClient, controllerConfig.TrustInsecureServiceBrokers),
 				controllerConfig.CFRootNamespace,
+				controllerConfig.AllowedVolumeMountDrivers,
 				mgr.GetScheme(),
 			),
 		)).SetupWithManager(mgr); err != nil {
@@ -297,6 +362,8 @@ func main() {
 			controllersLog,
 			env.NewAppEnvBuilder(controllersClient),
 			taskTTL,
+			controllerConfig.CFRootNamespace,
+			identityIssuer,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFTask")
 			os.Exit(1)
@@ -312,9 +379,17 @@ func main() {
 		}
 
 		if controllerConfig.ExperimentalManagedServicesEnabled {
+			var brokerCatalogResyncInterval time.Duration
+			brokerCatalogResyncInterval, err = controllerConfig.ParseBrokerCatalogResyncInterval()
+			if err != nil {
+				setupLog.Error(err, "failed to parse broker catalog resync interval", "controller", "CFServiceBroker", "brokerCatalogResyncInterval", controllerConfig.BrokerCatalogResyncInterval)
+				os.Exit(1)
+			}
 			if err = brokers.NewReconciler(
 				controllersClient,
 				osbapi.NewClientFactory(controllersClient, controllerConfig.TrustInsecureServiceBrokers),
+				controllerConfig.CFRootNamespace,
+				brokerCatalogResyncInterval,
 				mgr.GetScheme(),
 				controllersLog,
 			).SetupWithManager(mgr); err != nil {
@@ -344,11 +419,18 @@ func main() {
 		}
 
 		if !controllerConfig.DisableRouteController {
+			gatewayAdapter, err := gateway.NewAdapter(controllerConfig.Networking.GatewayAdapter, controllersClient, mgr.GetScheme())
+			if err != nil {
+				setupLog.Error(err, "unable to create gateway adapter")
+				os.Exit(1)
+			}
+
 			if err = routes.NewReconciler(
 				controllersClient,
 				mgr.GetScheme(),
 				controllersLog,
 				controllerConfig,
+				gatewayAdapter,
 			).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "CFRoute")
 				os.Exit(1)
@@ -370,6 +452,7 @@ func main() {
 			controllerConfig.CFProcessDefaults.MemoryMB,
 			controllerConfig.CFProcessDefaults.DiskQuotaMB,
 			*controllerConfig.CFProcessDefaults.Timeout,
+			*controllerConfig.CFProcessDefaults.TerminationGracePeriodSeconds,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFProcess")
 			os.Exit(1)
@@ -408,6 +491,7 @@ func main() {
 
 		if err = bindingswebhook.NewCFServiceBindingValidator(
 			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, bindingswebhook.ServiceBindingEntityType)),
+			uncachedClient,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceBinding")
 			os.Exit(1)
@@ -451,6 +535,14 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err = isolationsegmentswebhook.NewValidator(
+			validation.NewDuplicateValidator(coordination.NewNameRegistry(uncachedClient, isolationsegmentswebhook.IsolationSegmentEntityType)),
+			controllerConfig.CFRootNamespace,
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFIsolationSegment")
+			os.Exit(1)
+		}
+
 		if err = taskswebhook.NewDefaulter(controllerConfig.CFProcessDefaults).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFTask")
 			os.Exit(1)
@@ -54,6 +54,23 @@ type NameRegistry struct {
 	registerNameReturnsOnCall map[int]struct {
 		result1 error
 	}
+	TransferNameOwnershipStub        func(context.Context, string, string, string, string, string, string) error
+	transferNameOwnershipMutex       sync.RWMutex
+	transferNameOwnershipArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+		arg7 string
+	}
+	transferNameOwnershipReturns struct {
+		result1 error
+	}
+	transferNameOwnershipReturnsOnCall map[int]struct {
+		result1 error
+	}
 	TryLockNameStub        func(context.Context, string, string) error
 	tryLockNameMutex       sync.RWMutex
 	tryLockNameArgsForCall []struct {
@@ -280,6 +297,73 @@ func (fake *NameRegistry) RegisterNameReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *NameRegistry) TransferNameOwnership(arg1 context.Context, arg2 string, arg3 string, arg4 string, arg5 string, arg6 string, arg7 string) error {
+	fake.transferNameOwnershipMutex.Lock()
+	ret, specificReturn := fake.transferNameOwnershipReturnsOnCall[len(fake.transferNameOwnershipArgsForCall)]
+	fake.transferNameOwnershipArgsForCall = append(fake.transferNameOwnershipArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 string
+		arg7 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	stub := fake.TransferNameOwnershipStub
+	fakeReturns := fake.transferNameOwnershipReturns
+	fake.recordInvocation("TransferNameOwnership", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+	fake.transferNameOwnershipMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *NameRegistry) TransferNameOwnershipCallCount() int {
+	fake.transferNameOwnershipMutex.RLock()
+	defer fake.transferNameOwnershipMutex.RUnlock()
+	return len(fake.transferNameOwnershipArgsForCall)
+}
+
+func (fake *NameRegistry) TransferNameOwnershipCalls(stub func(context.Context, string, string, string, string, string, string) error) {
+	fake.transferNameOwnershipMutex.Lock()
+	defer fake.transferNameOwnershipMutex.Unlock()
+	fake.TransferNameOwnershipStub = stub
+}
+
+func (fake *NameRegistry) TransferNameOwnershipArgsForCall(i int) (context.Context, string, string, string, string, string, string) {
+	fake.transferNameOwnershipMutex.RLock()
+	defer fake.transferNameOwnershipMutex.RUnlock()
+	argsForCall := fake.transferNameOwnershipArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+}
+
+func (fake *NameRegistry) TransferNameOwnershipReturns(result1 error) {
+	fake.transferNameOwnershipMutex.Lock()
+	defer fake.transferNameOwnershipMutex.Unlock()
+	fake.TransferNameOwnershipStub = nil
+	fake.transferNameOwnershipReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *NameRegistry) TransferNameOwnershipReturnsOnCall(i int, result1 error) {
+	fake.transferNameOwnershipMutex.Lock()
+	defer fake.transferNameOwnershipMutex.Unlock()
+	fake.TransferNameOwnershipStub = nil
+	if fake.transferNameOwnershipReturnsOnCall == nil {
+		fake.transferNameOwnershipReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.transferNameOwnershipReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *NameRegistry) TryLockName(arg1 context.Context, arg2 string, arg3 string) error {
 	fake.tryLockNameMutex.Lock()
 	ret, specificReturn := fake.tryLockNameReturnsOnCall[len(fake.tryLockNameArgsForCall)]
@@ -415,6 +499,8 @@ func (fake *NameRegistry) Invocations() map[string][][]interface{} {
 	defer fake.deregisterNameMutex.RUnlock()
 	fake.registerNameMutex.RLock()
 	defer fake.registerNameMutex.RUnlock()
+	fake.transferNameOwnershipMutex.RLock()
+	defer fake.transferNameOwnershipMutex.RUnlock()
 	fake.tryLockNameMutex.RLock()
 	defer fake.tryLockNameMutex.RUnlock()
 	fake.unlockNameMutex.RLock()
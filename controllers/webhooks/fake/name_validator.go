@@ -38,6 +38,21 @@ type NameValidator struct {
 	validateDeleteReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ValidateTransferStub        func(context.Context, logr.Logger, string, webhooks.UniqueClientObject, webhooks.UniqueClientObject) error
+	validateTransferMutex       sync.RWMutex
+	validateTransferArgsForCall []struct {
+		arg1 context.Context
+		arg2 logr.Logger
+		arg3 string
+		arg4 webhooks.UniqueClientObject
+		arg5 webhooks.UniqueClientObject
+	}
+	validateTransferReturns struct {
+		result1 error
+	}
+	validateTransferReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ValidateUpdateStub        func(context.Context, logr.Logger, string, webhooks.UniqueClientObject, webhooks.UniqueClientObject) error
 	validateUpdateMutex       sync.RWMutex
 	validateUpdateArgsForCall []struct {
@@ -185,6 +200,71 @@ func (fake *NameValidator) ValidateDeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *NameValidator) ValidateTransfer(arg1 context.Context, arg2 logr.Logger, arg3 string, arg4 webhooks.UniqueClientObject, arg5 webhooks.UniqueClientObject) error {
+	fake.validateTransferMutex.Lock()
+	ret, specificReturn := fake.validateTransferReturnsOnCall[len(fake.validateTransferArgsForCall)]
+	fake.validateTransferArgsForCall = append(fake.validateTransferArgsForCall, struct {
+		arg1 context.Context
+		arg2 logr.Logger
+		arg3 string
+		arg4 webhooks.UniqueClientObject
+		arg5 webhooks.UniqueClientObject
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.ValidateTransferStub
+	fakeReturns := fake.validateTransferReturns
+	fake.recordInvocation("ValidateTransfer", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.validateTransferMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *NameValidator) ValidateTransferCallCount() int {
+	fake.validateTransferMutex.RLock()
+	defer fake.validateTransferMutex.RUnlock()
+	return len(fake.validateTransferArgsForCall)
+}
+
+func (fake *NameValidator) ValidateTransferCalls(stub func(context.Context, logr.Logger, string, webhooks.UniqueClientObject, webhooks.UniqueClientObject) error) {
+	fake.validateTransferMutex.Lock()
+	defer fake.validateTransferMutex.Unlock()
+	fake.ValidateTransferStub = stub
+}
+
+func (fake *NameValidator) ValidateTransferArgsForCall(i int) (context.Context, logr.Logger, string, webhooks.UniqueClientObject, webhooks.UniqueClientObject) {
+	fake.validateTransferMutex.RLock()
+	defer fake.validateTransferMutex.RUnlock()
+	argsForCall := fake.validateTransferArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *NameValidator) ValidateTransferReturns(result1 error) {
+	fake.validateTransferMutex.Lock()
+	defer fake.validateTransferMutex.Unlock()
+	fake.ValidateTransferStub = nil
+	fake.validateTransferReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *NameValidator) ValidateTransferReturnsOnCall(i int, result1 error) {
+	fake.validateTransferMutex.Lock()
+	defer fake.validateTransferMutex.Unlock()
+	fake.ValidateTransferStub = nil
+	if fake.validateTransferReturnsOnCall == nil {
+		fake.validateTransferReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateTransferReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *NameValidator) ValidateUpdate(arg1 context.Context, arg2 logr.Logger, arg3 string, arg4 webhooks.UniqueClientObject, arg5 webhooks.UniqueClientObject) error {
 	fake.validateUpdateMutex.Lock()
 	ret, specificReturn := fake.validateUpdateReturnsOnCall[len(fake.validateUpdateArgsForCall)]
@@ -257,6 +337,8 @@ func (fake *NameValidator) Invocations() map[string][][]interface{} {
 	defer fake.validateCreateMutex.RUnlock()
 	fake.validateDeleteMutex.RLock()
 	defer fake.validateDeleteMutex.RUnlock()
+	fake.validateTransferMutex.RLock()
+	defer fake.validateTransferMutex.RUnlock()
 	fake.validateUpdateMutex.RLock()
 	defer fake.validateUpdateMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -2,6 +2,7 @@
 package fake
 
 import (
+	"context"
 	"sync"
 
 	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -9,6 +10,19 @@ import (
 )
 
 type NamespaceValidator struct {
+	ValidateIsolationSegmentStub        func(context.Context, string, string) error
+	validateIsolationSegmentMutex       sync.RWMutex
+	validateIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}
+	validateIsolationSegmentReturns struct {
+		result1 error
+	}
+	validateIsolationSegmentReturnsOnCall map[int]struct {
+		result1 error
+	}
 	ValidateOrgCreateStub        func(v1alpha1.CFOrg) error
 	validateOrgCreateMutex       sync.RWMutex
 	validateOrgCreateArgsForCall []struct {
@@ -35,6 +49,69 @@ type NamespaceValidator struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *NamespaceValidator) ValidateIsolationSegment(arg1 context.Context, arg2 string, arg3 string) error {
+	fake.validateIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.validateIsolationSegmentReturnsOnCall[len(fake.validateIsolationSegmentArgsForCall)]
+	fake.validateIsolationSegmentArgsForCall = append(fake.validateIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ValidateIsolationSegmentStub
+	fakeReturns := fake.validateIsolationSegmentReturns
+	fake.recordInvocation("ValidateIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.validateIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *NamespaceValidator) ValidateIsolationSegmentCallCount() int {
+	fake.validateIsolationSegmentMutex.RLock()
+	defer fake.validateIsolationSegmentMutex.RUnlock()
+	return len(fake.validateIsolationSegmentArgsForCall)
+}
+
+func (fake *NamespaceValidator) ValidateIsolationSegmentCalls(stub func(context.Context, string, string) error) {
+	fake.validateIsolationSegmentMutex.Lock()
+	defer fake.validateIsolationSegmentMutex.Unlock()
+	fake.ValidateIsolationSegmentStub = stub
+}
+
+func (fake *NamespaceValidator) ValidateIsolationSegmentArgsForCall(i int) (context.Context, string, string) {
+	fake.validateIsolationSegmentMutex.RLock()
+	defer fake.validateIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.validateIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *NamespaceValidator) ValidateIsolationSegmentReturns(result1 error) {
+	fake.validateIsolationSegmentMutex.Lock()
+	defer fake.validateIsolationSegmentMutex.Unlock()
+	fake.ValidateIsolationSegmentStub = nil
+	fake.validateIsolationSegmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *NamespaceValidator) ValidateIsolationSegmentReturnsOnCall(i int, result1 error) {
+	fake.validateIsolationSegmentMutex.Lock()
+	defer fake.validateIsolationSegmentMutex.Unlock()
+	fake.ValidateIsolationSegmentStub = nil
+	if fake.validateIsolationSegmentReturnsOnCall == nil {
+		fake.validateIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateIsolationSegmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *NamespaceValidator) ValidateOrgCreate(arg1 v1alpha1.CFOrg) error {
 	fake.validateOrgCreateMutex.Lock()
 	ret, specificReturn := fake.validateOrgCreateReturnsOnCall[len(fake.validateOrgCreateArgsForCall)]
@@ -160,6 +237,8 @@ func (fake *NamespaceValidator) ValidateSpaceCreateReturnsOnCall(i int, result1
 func (fake *NamespaceValidator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.validateIsolationSegmentMutex.RLock()
+	defer fake.validateIsolationSegmentMutex.RUnlock()
 	fake.validateOrgCreateMutex.RLock()
 	defer fake.validateOrgCreateMutex.RUnlock()
 	fake.validateSpaceCreateMutex.RLock()
@@ -102,20 +102,30 @@ func NewWebhook() *LabelIndexerWebhook {
 				LabelRule{Label: korifiv1alpha1.ReadyLabelKey, IndexingFunc: Unquote(SingleValue(JSONValue("$.status.conditions[?@.type == \"Ready\"].status")))},
 			},
 			"CFServiceOffering": {
+				LabelRule{Label: korifiv1alpha1.SpaceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.metadata.namespace"))},
 				LabelRule{Label: korifiv1alpha1.CFServiceOfferingNameKey, IndexingFunc: SHA224(Unquote(JSONValue("$.spec.name")))},
 			},
 			"CFServicePlan": {
+				LabelRule{Label: korifiv1alpha1.SpaceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.metadata.namespace"))},
 				LabelRule{Label: korifiv1alpha1.CFServicePlanNameKey, IndexingFunc: SHA224(Unquote(JSONValue("$.spec.name")))},
 				LabelRule{Label: korifiv1alpha1.CFServicePlanAvailableKey, IndexingFunc: Map(
-					Unquote(JSONValue("$.spec.visibility.type")),
+					DefaultIfEmpty(JSONValue("$.spec.inactive"), ConstantValue("false")),
 					map[string]IndexValueFunc{
-						korifiv1alpha1.AdminServicePlanVisibilityType:        ConstantValue("false"),
-						korifiv1alpha1.PublicServicePlanVisibilityType:       ConstantValue("true"),
-						korifiv1alpha1.OrganizationServicePlanVisibilityType: ConstantValue("true"),
+						"true": ConstantValue("false"),
+						"false": Map(
+							Unquote(JSONValue("$.spec.visibility.type")),
+							map[string]IndexValueFunc{
+								korifiv1alpha1.AdminServicePlanVisibilityType:        ConstantValue("false"),
+								korifiv1alpha1.PublicServicePlanVisibilityType:       ConstantValue("true"),
+								korifiv1alpha1.OrganizationServicePlanVisibilityType: ConstantValue("true"),
+								korifiv1alpha1.SpaceServicePlanVisibilityType:        ConstantValue("true"),
+							},
+						),
 					},
 				)},
 			},
 			"CFServiceBroker": {
+				LabelRule{Label: korifiv1alpha1.SpaceGUIDLabelKey, IndexingFunc: Unquote(JSONValue("$.metadata.namespace"))},
 				LabelRule{Label: korifiv1alpha1.CFServiceBrokerDisplayNameLabelKey, IndexingFunc: SHA224(Unquote(JSONValue("$.spec.name")))},
 			},
 		},
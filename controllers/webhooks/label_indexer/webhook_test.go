@@ -492,6 +492,7 @@ var _ = Describe("LabelIndexerWebhook", func() {
 			Eventually(func(g Gomega) {
 				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(offering), offering)).To(Succeed())
 				g.Expect(offering.Labels).To(MatchKeys(IgnoreExtras, Keys{
+					korifiv1alpha1.SpaceGUIDLabelKey:        Equal(namespace),
 					korifiv1alpha1.CFServiceOfferingNameKey: Equal("dfcda624de1c07d0ecde233a93cccc75171934a53876dd616f321cae"), // SHA224 hash of "my-awesome-offering"
 				}))
 			}).Should(Succeed())
@@ -524,6 +525,7 @@ var _ = Describe("LabelIndexerWebhook", func() {
 			Eventually(func(g Gomega) {
 				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
 				g.Expect(plan.Labels).To(MatchKeys(IgnoreExtras, Keys{
+					korifiv1alpha1.SpaceGUIDLabelKey:         Equal(namespace),
 					korifiv1alpha1.CFServicePlanNameKey:      Equal("17f65f9393e1826ece32c78a186b397b259ae4317ada0891021d9ea9"), // SHA224 hash of "my-awesome-plan"
 					korifiv1alpha1.CFServicePlanAvailableKey: Equal("true"),
 				}))
@@ -545,6 +547,21 @@ var _ = Describe("LabelIndexerWebhook", func() {
 			})
 		})
 
+		When("the plan visibility is space", func() {
+			BeforeEach(func() {
+				plan.Spec.Visibility.Type = korifiv1alpha1.SpaceServicePlanVisibilityType
+			})
+
+			It("labels the CFServicePlan as available", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+					g.Expect(plan.Labels).To(MatchKeys(IgnoreExtras, Keys{
+						korifiv1alpha1.CFServicePlanAvailableKey: Equal("true"),
+					}))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the plan visibility is admin", func() {
 			BeforeEach(func() {
 				plan.Spec.Visibility.Type = korifiv1alpha1.AdminServicePlanVisibilityType
@@ -559,6 +576,21 @@ var _ = Describe("LabelIndexerWebhook", func() {
 				}).Should(Succeed())
 			})
 		})
+
+		When("the plan is inactive", func() {
+			BeforeEach(func() {
+				plan.Spec.Inactive = true
+			})
+
+			It("labels the CFServicePlan as unavailable", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+					g.Expect(plan.Labels).To(MatchKeys(IgnoreExtras, Keys{
+						korifiv1alpha1.CFServicePlanAvailableKey: Equal("false"),
+					}))
+				}).Should(Succeed())
+			})
+		})
 	})
 
 	Describe("CFServiceBroker", func() {
@@ -584,6 +616,7 @@ var _ = Describe("LabelIndexerWebhook", func() {
 			Eventually(func(g Gomega) {
 				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(broker), broker)).To(Succeed())
 				g.Expect(broker.Labels).To(MatchKeys(IgnoreExtras, Keys{
+					korifiv1alpha1.SpaceGUIDLabelKey:                  Equal(namespace),
 					korifiv1alpha1.CFServiceBrokerDisplayNameLabelKey: Equal("aa9617cd3975446998c91bf393c5c58b3425953b7bdb3f9f1ae8230b"), // SHA224 hash of "my-broker"
 				}))
 			}).Should(Succeed())
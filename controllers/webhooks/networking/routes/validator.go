@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -12,6 +13,7 @@ import (
 	validationwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"github.com/hashicorp/go-multierror"
 
+	authorizationv1 "k8s.io/api/authorization/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -28,10 +30,15 @@ const (
 
 	RouteDestinationNotInSpaceErrorType    = "RouteDestinationNotInSpaceError"
 	RouteDestinationNotInSpaceErrorMessage = "Route destination app not found in space"
+	RouteDestinationNotSharedErrorType     = "RouteDestinationNotSharedError"
+	RouteDestinationNotSharedErrorMessage  = "Route destination app must be in the route space or in one of the spaces the route is shared with"
+	RouteSharedSpaceForbiddenErrorType     = "RouteSharedSpaceForbiddenError"
+	RouteSharedSpaceForbiddenErrorMessage  = "Routes can only be shared with spaces the user is a space developer in"
 	RouteHostNameValidationErrorType       = "RouteHostNameValidationError"
 	RoutePathValidationErrorType           = "RoutePathValidationError"
 	RouteSubdomainValidationErrorType      = "RouteSubdomainValidationError"
 	RouteSubdomainValidationErrorMessage   = "Subdomains must each be at most 63 characters"
+	RouteOptionsValidationErrorType        = "RouteOptionsValidationError"
 
 	HostEmptyError  = "host cannot be empty"
 	HostLengthError = "host is too long (maximum is 63 characters)"
@@ -41,10 +48,19 @@ const (
 	PathIsSlashError         = "Path cannot be a single slash"
 	PathHasQuestionMarkError = "Path cannot contain a question mark"
 	PathLengthExceededError  = "Path cannot exceed 128 characters"
+	GRPCPathError            = "Path cannot be set on routes with the grpc protocol"
+
+	HashHeaderRequiredError      = "Hash header must be present when loadbalancing is set to hash"
+	HashHeaderNotAllowedError    = "Hash header can only be set when loadbalancing is set to hash"
+	SessionAffinityConflictError = "Session affinity cannot be combined with the hash or least-connection loadbalancing algorithms"
+	RequestTimeoutError          = "Request timeout must be a positive number of seconds"
+	GRPCRequestTimeoutError      = "Request timeout cannot be set on routes with the grpc protocol"
 )
 
 var logger = logf.Log.WithName("route-validation")
 
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfroute,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfroutes,verbs=create;update;delete,versions=v1alpha1,name=vcfroute.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
 type Validator struct {
@@ -87,9 +103,34 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 
 	route.Status.FQDN = cfDomain.Spec.Name
 
+	if transferredFrom := route.Annotations[korifiv1alpha1.CFRouteTransferredFromAnnotation]; transferredFrom != "" {
+		return nil, v.validateTransfer(ctx, transferredFrom, route)
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, route)
 }
 
+// validateTransfer hands the unique name of the route with the same guid in
+// the namespace the route is transferred from over to the route. If that
+// route is gone, the name is registered as for any new route
+func (v *Validator) validateTransfer(ctx context.Context, transferredFrom string, route *korifiv1alpha1.CFRoute) error {
+	previousRoute := &korifiv1alpha1.CFRoute{}
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: transferredFrom, Name: route.Name}, previousRoute)
+	if client.IgnoreNotFound(err) != nil {
+		logger.Info("failed to get transferred route", "namespace", transferredFrom, "name", route.Name, "reason", err)
+		return validationwebhook.ValidationError{
+			Type:    validationwebhook.UnknownErrorType,
+			Message: validationwebhook.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	if err != nil || !previousRoute.GetDeletionTimestamp().IsZero() {
+		return v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, route)
+	}
+
+	return v.duplicateValidator.ValidateTransfer(ctx, logger, v.rootNamespace, previousRoute, route)
+}
+
 func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
 	route, ok := obj.(*korifiv1alpha1.CFRoute)
 	if !ok {
@@ -129,11 +170,33 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		return nil, immutableError.ExportJSONError()
 	}
 
-	err := v.validateDestinations(ctx, route)
+	err := v.validateSharedSpaces(ctx, oldRoute.Spec.SharedSpaces, route)
 	if err != nil {
 		return nil, err
 	}
 
+	err = v.validateDestinations(ctx, route)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = validateOptions(route.Spec.Options); err != nil {
+		return nil, err
+	}
+
+	// the domain protocol only matters for routes with a request timeout
+	if route.Spec.Options != nil && route.Spec.Options.RequestTimeoutSeconds != nil {
+		var domain *korifiv1alpha1.CFDomain
+		domain, err = v.fetchDomain(ctx, route)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = validateGRPCOptions(route, domain); err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, logger, v.rootNamespace, oldRoute, route)
 }
 
@@ -143,6 +206,11 @@ func (v *Validator) ValidateDelete(ctx context.Context, obj runtime.Object) (adm
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFRoute but got a %T", obj))
 	}
 
+	// The unique name of a transferred route is held by its replacement
+	if route.Annotations[korifiv1alpha1.CFRouteTransferredToAnnotation] != "" {
+		return nil, nil
+	}
+
 	return nil, v.duplicateValidator.ValidateDelete(ctx, logger, v.rootNamespace, route)
 }
 
@@ -152,6 +220,11 @@ func (v *Validator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFR
 		return domain, err
 	}
 
+	err = v.validateSharedSpaces(ctx, nil, route)
+	if err != nil {
+		return domain, err
+	}
+
 	err = v.validateDestinations(ctx, route)
 	if err != nil {
 		return domain, err
@@ -165,6 +238,18 @@ func (v *Validator) validateRoute(ctx context.Context, route *korifiv1alpha1.CFR
 		return nil, err
 	}
 
+	if err = validateGRPCPath(route, domain); err != nil {
+		return nil, err
+	}
+
+	if err = validateOptions(route.Spec.Options); err != nil {
+		return nil, err
+	}
+
+	if err = validateGRPCOptions(route, domain); err != nil {
+		return nil, err
+	}
+
 	return domain, nil
 }
 
@@ -182,7 +267,78 @@ func (v *Validator) fetchDomain(ctx context.Context, route *korifiv1alpha1.CFRou
 	return domain, err
 }
 
+// validateSharedSpaces makes sure that the user sharing the route is a space
+// developer in every space the route gets shared with, i.e. is allowed to
+// create routes there
+func (v *Validator) validateSharedSpaces(ctx context.Context, oldSharedSpaces []string, route *korifiv1alpha1.CFRoute) error {
+	for _, spaceGUID := range route.Spec.SharedSpaces {
+		if slices.Contains(oldSharedSpaces, spaceGUID) {
+			continue
+		}
+
+		allowed, err := v.canCreateRoute(ctx, spaceGUID)
+		if err != nil {
+			logger.Info("failed to check route create permission", "namespace", spaceGUID, "reason", err)
+			return validationwebhook.ValidationError{
+				Type:    validationwebhook.UnknownErrorType,
+				Message: validationwebhook.UnknownErrorMessage,
+			}.ExportJSONError()
+		}
+
+		if !allowed {
+			return validationwebhook.ValidationError{
+				Type:    RouteSharedSpaceForbiddenErrorType,
+				Message: RouteSharedSpaceForbiddenErrorMessage,
+			}.ExportJSONError()
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) canCreateRoute(ctx context.Context, namespace string) (bool, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, value := range req.UserInfo.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			Groups: req.UserInfo.Groups,
+			UID:    req.UserInfo.UID,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "create",
+				Group:     korifiv1alpha1.SchemeGroupVersion.Group,
+				Resource:  "cfroutes",
+			},
+		},
+	}
+
+	if err = v.client.Create(ctx, review); err != nil {
+		return false, err
+	}
+
+	return review.Status.Allowed, nil
+}
+
 func (v *Validator) validateDestinations(ctx context.Context, route *korifiv1alpha1.CFRoute) error {
+	for _, destination := range route.Spec.Destinations {
+		if route.IsSharedDestination(destination) && !slices.Contains(route.Spec.SharedSpaces, destination.AppNamespace) {
+			return validationwebhook.ValidationError{
+				Type:    RouteDestinationNotSharedErrorType,
+				Message: RouteDestinationNotSharedErrorMessage,
+			}.ExportJSONError()
+		}
+	}
+
 	err := v.checkDestinationsExistInNamespace(ctx, *route)
 	if err != nil {
 		validationErr := validationwebhook.ValidationError{}
@@ -280,9 +436,72 @@ func validatePath(path string) error {
 	return nil
 }
 
+// validateGRPCPath rejects paths on grpc routes as they are matched on the
+// gRPC service and method only
+func validateGRPCPath(route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) error {
+	if route.Spec.Path == "" || !isGRPC(route, domain) {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RoutePathValidationErrorType,
+		Message: GRPCPathError,
+	}.ExportJSONError()
+}
+
+// validateGRPCOptions rejects the request timeout on grpc routes as the
+// GRPCRoute API does not support timeouts
+func validateGRPCOptions(route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) error {
+	if route.Spec.Options == nil || route.Spec.Options.RequestTimeoutSeconds == nil || !isGRPC(route, domain) {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RouteOptionsValidationErrorType,
+		Message: GRPCRequestTimeoutError,
+	}.ExportJSONError()
+}
+
+func isGRPC(route *korifiv1alpha1.CFRoute, domain *korifiv1alpha1.CFDomain) bool {
+	return route.Spec.Protocol == korifiv1alpha1.RouteProtocolGRPC || domain.Spec.Protocol == korifiv1alpha1.RouteProtocolGRPC
+}
+
+func validateOptions(options *korifiv1alpha1.RouteOptions) error {
+	if options == nil {
+		return nil
+	}
+
+	var errStrings []string
+
+	if options.LoadBalancing == korifiv1alpha1.LoadBalancingHash && options.HashHeader == "" {
+		errStrings = append(errStrings, HashHeaderRequiredError)
+	}
+
+	if options.LoadBalancing != korifiv1alpha1.LoadBalancingHash && options.HashHeader != "" {
+		errStrings = append(errStrings, HashHeaderNotAllowedError)
+	}
+
+	if options.SessionAffinity && (options.LoadBalancing == korifiv1alpha1.LoadBalancingHash || options.LoadBalancing == korifiv1alpha1.LoadBalancingLeastConnection) {
+		errStrings = append(errStrings, SessionAffinityConflictError)
+	}
+
+	if options.RequestTimeoutSeconds != nil && *options.RequestTimeoutSeconds <= 0 {
+		errStrings = append(errStrings, RequestTimeoutError)
+	}
+
+	if len(errStrings) == 0 {
+		return nil
+	}
+
+	return validationwebhook.ValidationError{
+		Type:    RouteOptionsValidationErrorType,
+		Message: strings.Join(errStrings, ", "),
+	}.ExportJSONError()
+}
+
 func (v *Validator) checkDestinationsExistInNamespace(ctx context.Context, route korifiv1alpha1.CFRoute) error {
 	for _, destination := range route.Spec.Destinations {
-		err := v.client.Get(ctx, client.ObjectKey{Namespace: route.Namespace, Name: destination.AppRef.Name}, &korifiv1alpha1.CFApp{})
+		err := v.client.Get(ctx, client.ObjectKey{Namespace: route.DestinationNamespace(destination), Name: destination.AppRef.Name}, &korifiv1alpha1.CFApp{})
 		if err != nil {
 			return err
 		}
@@ -13,9 +13,14 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/webhooks/networking/routes"
 	validationwebhook "code.cloudfoundry.org/korifi/controllers/webhooks/validation"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	v1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -23,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var _ = Describe("CFRouteValidator", func() {
@@ -45,15 +51,27 @@ var _ = Describe("CFRouteValidator", func() {
 		testDomainNamespace string
 		rootNamespace       string
 
-		getDomainError error
-		getAppError    error
-		retErr         error
+		getDomainError        error
+		getAppError           error
+		getPreviousRouteError error
+		retErr                error
+
+		previousRoute *korifiv1alpha1.CFRoute
+
+		sharedSpaceAllowed bool
 
 		getDomainCallCount int
 	)
 
 	BeforeEach(func() {
-		ctx = context.Background()
+		ctx = admission.NewContextWithRequest(context.Background(), admission.Request{
+			AdmissionRequest: admissionv1.AdmissionRequest{
+				UserInfo: authenticationv1.UserInfo{
+					Username: "some-user",
+					Groups:   []string{"some-group"},
+				},
+			},
+		})
 
 		scheme := runtime.NewScheme()
 		err := korifiv1alpha1.AddToScheme(scheme)
@@ -70,7 +88,9 @@ var _ = Describe("CFRouteValidator", func() {
 		rootNamespace = "root-ns"
 		getDomainError = nil
 		getAppError = nil
+		getPreviousRouteError = nil
 		getDomainCallCount = 0
+		sharedSpaceAllowed = true
 
 		cfRoute = initializeRouteCR(testRouteProtocol, testRouteHost, testRoutePath, testRouteGUID, testRouteNamespace, testDomainGUID, testDomainNamespace)
 
@@ -97,11 +117,23 @@ var _ = Describe("CFRouteValidator", func() {
 			case *korifiv1alpha1.CFApp:
 				cfApp.DeepCopyInto(obj)
 				return getAppError
+			case *korifiv1alpha1.CFRoute:
+				previousRoute.DeepCopyInto(obj)
+				return getPreviousRouteError
 			default:
 				panic("TestClient Get provided an unexpected object type")
 			}
 		}
 
+		fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+			review, ok := obj.(*authorizationv1.SubjectAccessReview)
+			if !ok {
+				panic("TestClient Create provided an unexpected object type")
+			}
+			review.Status.Allowed = sharedSpaceAllowed
+			return nil
+		}
+
 		validatingWebhook = routes.NewValidator(duplicateValidator, rootNamespace, fakeClient)
 	})
 
@@ -173,6 +205,57 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the route is transferred from another space", func() {
+			BeforeEach(func() {
+				cfRoute.Annotations = map[string]string{
+					korifiv1alpha1.CFRouteTransferredFromAnnotation: "previous-ns",
+				}
+				previousRoute = initializeRouteCR(testRouteProtocol, testRouteHost, testRoutePath, testRouteGUID, "previous-ns", testDomainGUID, testDomainNamespace)
+			})
+
+			It("hands the route name over from the previous route", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+				Expect(duplicateValidator.ValidateTransferCallCount()).To(Equal(1))
+				_, _, actualNamespace, actualOldResource, actualNewResource := duplicateValidator.ValidateTransferArgsForCall(0)
+				Expect(actualNamespace).To(Equal(rootNamespace))
+				Expect(actualOldResource).To(Equal(previousRoute))
+				Expect(actualNewResource).To(Equal(cfRoute))
+			})
+
+			When("the previous route does not exist", func() {
+				BeforeEach(func() {
+					getPreviousRouteError = k8serrors.NewNotFound(schema.GroupResource{}, "previous-route")
+				})
+
+				It("registers the route name", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+					Expect(duplicateValidator.ValidateTransferCallCount()).To(BeZero())
+					Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+				})
+			})
+
+			When("getting the previous route fails", func() {
+				BeforeEach(func() {
+					getPreviousRouteError = errors.New("get-route-error")
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(validationwebhook.UnknownErrorType, Equal(validationwebhook.UnknownErrorMessage)))
+				})
+			})
+
+			When("handing the route name over fails", func() {
+				BeforeEach(func() {
+					duplicateValidator.ValidateTransferReturns(errors.New("foo"))
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(MatchError("foo"))
+				})
+			})
+		})
+
 		When("the FQDN is too long", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Host = "a-very-looooooooooooong-invalid-host-name-that-should-fail-validation"
@@ -254,6 +337,133 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		When("the route uses the grpc protocol", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Protocol = korifiv1alpha1.RouteProtocolGRPC
+			})
+
+			It("denies a route with a path", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RoutePathValidationErrorType,
+					Equal(routes.GRPCPathError),
+				))
+			})
+
+			When("the route has no path", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Path = ""
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+
+				When("the route has a request timeout", func() {
+					BeforeEach(func() {
+						cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+							RequestTimeoutSeconds: tools.PtrTo[int32](30),
+						}
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(
+							routes.RouteOptionsValidationErrorType,
+							Equal(routes.GRPCRequestTimeoutError),
+						))
+					})
+				})
+			})
+		})
+
+		When("the route domain uses the grpc protocol", func() {
+			BeforeEach(func() {
+				cfDomain.Spec.Protocol = korifiv1alpha1.RouteProtocolGRPC
+			})
+
+			It("denies a route with a path", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RoutePathValidationErrorType,
+					Equal(routes.GRPCPathError),
+				))
+			})
+		})
+
+		When("the route has valid options", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+					LoadBalancing:         "hash",
+					HashHeader:            "X-Tenant",
+					RequestTimeoutSeconds: tools.PtrTo[int32](30),
+				}
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the load-balancing algorithm is hash without a hash header", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+					LoadBalancing: "hash",
+				}
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteOptionsValidationErrorType,
+					Equal(routes.HashHeaderRequiredError),
+				))
+			})
+		})
+
+		When("a hash header is set without hash load-balancing", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+					LoadBalancing: "round-robin",
+					HashHeader:    "X-Tenant",
+				}
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteOptionsValidationErrorType,
+					Equal(routes.HashHeaderNotAllowedError),
+				))
+			})
+		})
+
+		When("session affinity is combined with least-connection load-balancing", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+					LoadBalancing:   "least-connection",
+					SessionAffinity: true,
+				}
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteOptionsValidationErrorType,
+					Equal(routes.SessionAffinityConflictError),
+				))
+			})
+		})
+
+		When("the request timeout is not positive", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+					RequestTimeoutSeconds: tools.PtrTo[int32](0),
+				}
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(
+					routes.RouteOptionsValidationErrorType,
+					Equal(routes.RequestTimeoutError),
+				))
+			})
+		})
+
 		When("the route has destinations", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Destinations = []korifiv1alpha1.Destination{
@@ -294,6 +504,86 @@ var _ = Describe("CFRouteValidator", func() {
 					))
 				})
 			})
+
+			When("the destination app is in a space the route is shared with", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.SharedSpaces = []string{"other-ns"}
+					cfRoute.Spec.Destinations[0].AppNamespace = "other-ns"
+				})
+
+				It("allows the request", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+				})
+
+				It("looks up the app in the shared space", func() {
+					Expect(fakeClient.GetCallCount()).To(Equal(2))
+					_, appKey, _, _ := fakeClient.GetArgsForCall(1)
+					Expect(appKey).To(Equal(types.NamespacedName{Namespace: "other-ns", Name: "some-name"}))
+				})
+			})
+
+			When("the route is shared with a space", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.SharedSpaces = []string{"other-ns"}
+				})
+
+				It("checks the user can create routes in the shared space", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+					Expect(fakeClient.CreateCallCount()).To(Equal(1))
+					_, obj, _ := fakeClient.CreateArgsForCall(0)
+					Expect(obj).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"User":   Equal("some-user"),
+							"Groups": ConsistOf("some-group"),
+							"ResourceAttributes": PointTo(MatchFields(IgnoreExtras, Fields{
+								"Namespace": Equal("other-ns"),
+								"Verb":      Equal("create"),
+								"Group":     Equal("korifi.cloudfoundry.org"),
+								"Resource":  Equal("cfroutes"),
+							})),
+						}),
+					})))
+				})
+
+				When("the user is not allowed to create routes in the shared space", func() {
+					BeforeEach(func() {
+						sharedSpaceAllowed = false
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(
+							routes.RouteSharedSpaceForbiddenErrorType,
+							Equal(routes.RouteSharedSpaceForbiddenErrorMessage),
+						))
+					})
+				})
+
+				When("checking the user permissions fails", func() {
+					BeforeEach(func() {
+						fakeClient.CreateReturns(errors.New("boom"))
+					})
+
+					It("denies the request", func() {
+						Expect(retErr).To(matchers.BeValidationError(
+							validationwebhook.UnknownErrorType,
+							Equal(validationwebhook.UnknownErrorMessage),
+						))
+					})
+				})
+			})
+
+			When("the destination app is in a space the route is not shared with", func() {
+				BeforeEach(func() {
+					cfRoute.Spec.Destinations[0].AppNamespace = "other-ns"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteDestinationNotSharedErrorType,
+						Equal(routes.RouteDestinationNotSharedErrorMessage),
+					))
+				})
+			})
 		})
 	})
 
@@ -332,6 +622,35 @@ var _ = Describe("CFRouteValidator", func() {
 			Expect(getDomainCallCount).To(Equal(0), "Expected get domain call count mismatch")
 		})
 
+		When("the route gets shared with another space", func() {
+			BeforeEach(func() {
+				cfRoute.Spec.SharedSpaces = []string{"shared-ns"}
+				updatedCFRoute.Spec.SharedSpaces = []string{"shared-ns", "other-ns"}
+			})
+
+			It("only checks the user permissions in the newly shared space", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(fakeClient.CreateCallCount()).To(Equal(1))
+				_, obj, _ := fakeClient.CreateArgsForCall(0)
+				review, ok := obj.(*authorizationv1.SubjectAccessReview)
+				Expect(ok).To(BeTrue())
+				Expect(review.Spec.ResourceAttributes.Namespace).To(Equal("other-ns"))
+			})
+
+			When("the user is not allowed to create routes in the newly shared space", func() {
+				BeforeEach(func() {
+					sharedSpaceAllowed = false
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteSharedSpaceForbiddenErrorType,
+						Equal(routes.RouteSharedSpaceForbiddenErrorMessage),
+					))
+				})
+			})
+		})
+
 		When("the route is being deleted", func() {
 			BeforeEach(func() {
 				updatedCFRoute.DeletionTimestamp = &metav1.Time{Time: time.Now()}
@@ -429,6 +748,46 @@ var _ = Describe("CFRouteValidator", func() {
 				))
 			})
 		})
+
+		When("the options are updated", func() {
+			BeforeEach(func() {
+				updatedCFRoute.Spec.Options = &korifiv1alpha1.RouteOptions{
+					SessionAffinity: true,
+				}
+			})
+
+			It("allows the request", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+
+			When("the updated options are invalid", func() {
+				BeforeEach(func() {
+					updatedCFRoute.Spec.Options.LoadBalancing = "hash"
+					updatedCFRoute.Spec.Options.HashHeader = "X-Tenant"
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteOptionsValidationErrorType,
+						Equal(routes.SessionAffinityConflictError),
+					))
+				})
+			})
+
+			When("a request timeout is set on a route of a grpc domain", func() {
+				BeforeEach(func() {
+					cfDomain.Spec.Protocol = korifiv1alpha1.RouteProtocolGRPC
+					updatedCFRoute.Spec.Options.RequestTimeoutSeconds = tools.PtrTo[int32](30)
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						routes.RouteOptionsValidationErrorType,
+						Equal(routes.GRPCRequestTimeoutError),
+					))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateDelete", func() {
@@ -457,6 +816,19 @@ var _ = Describe("CFRouteValidator", func() {
 				Expect(retErr).To(MatchError("foo"))
 			})
 		})
+
+		When("the route has been transferred to another space", func() {
+			BeforeEach(func() {
+				cfRoute.Annotations = map[string]string{
+					korifiv1alpha1.CFRouteTransferredToAnnotation: "new-ns",
+				}
+			})
+
+			It("leaves the route name to the route replacing it", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateDeleteCallCount()).To(BeZero())
+			})
+		})
 	})
 })
 
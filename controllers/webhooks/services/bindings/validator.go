@@ -3,6 +3,7 @@ package bindings
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
@@ -11,6 +12,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -35,13 +37,15 @@ func (v *CFServiceBindingValidator) SetupWebhookWithManager(mgr ctrl.Manager) er
 
 type CFServiceBindingValidator struct {
 	duplicateValidator webhooks.NameValidator
+	client             client.Client
 }
 
 var _ webhook.CustomValidator = &CFServiceBindingValidator{}
 
-func NewCFServiceBindingValidator(duplicateValidator webhooks.NameValidator) *CFServiceBindingValidator {
+func NewCFServiceBindingValidator(duplicateValidator webhooks.NameValidator, client client.Client) *CFServiceBindingValidator {
 	return &CFServiceBindingValidator{
 		duplicateValidator: duplicateValidator,
+		client:             client,
 	}
 }
 
@@ -51,9 +55,47 @@ func (v *CFServiceBindingValidator) ValidateCreate(ctx context.Context, obj runt
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBinding but got a %T", obj))
 	}
 
+	if serviceBinding.Spec.PreviousBinding.Name != "" {
+		previousBinding, err := v.getPreviousBinding(ctx, serviceBinding)
+		if err != nil {
+			return nil, err
+		}
+
+		// A binding rotating a previous binding takes over its lock
+		return nil, v.duplicateValidator.ValidateTransfer(ctx, cfservicebindinglog, serviceBinding.Namespace, previousBinding, serviceBinding)
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfservicebindinglog, serviceBinding.Namespace, serviceBinding)
 }
 
+func (v *CFServiceBindingValidator) getPreviousBinding(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (*korifiv1alpha1.CFServiceBinding, error) {
+	previousBinding := &korifiv1alpha1.CFServiceBinding{}
+	err := v.client.Get(ctx, client.ObjectKey{Namespace: serviceBinding.Namespace, Name: serviceBinding.Spec.PreviousBinding.Name}, previousBinding)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, validation.ValidationError{
+				Type:    ServiceBindingErrorType,
+				Message: fmt.Sprintf("Previous binding %q does not exist", serviceBinding.Spec.PreviousBinding.Name),
+			}.ExportJSONError()
+		}
+
+		cfservicebindinglog.Info("failed to get previous binding", "name", serviceBinding.Spec.PreviousBinding.Name, "reason", err)
+		return nil, validation.ValidationError{
+			Type:    validation.UnknownErrorType,
+			Message: validation.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	if previousBinding.Annotations[korifiv1alpha1.SupersededByAnnotation] != "" || !previousBinding.GetDeletionTimestamp().IsZero() {
+		return nil, validation.ValidationError{
+			Type:    ServiceBindingErrorType,
+			Message: fmt.Sprintf("Previous binding %q has already been superseded or is being deleted", previousBinding.Name),
+		}.ExportJSONError()
+	}
+
+	return previousBinding, nil
+}
+
 func (v *CFServiceBindingValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
 	serviceBinding, ok := obj.(*korifiv1alpha1.CFServiceBinding)
 	if !ok {
@@ -90,5 +132,54 @@ func (v *CFServiceBindingValidator) ValidateDelete(ctx context.Context, obj runt
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBinding but got a %T", obj))
 	}
 
+	// The lock of a superseded binding is held by its successor
+	if serviceBinding.Annotations[korifiv1alpha1.SupersededByAnnotation] != "" {
+		return nil, nil
+	}
+
+	if serviceBinding.Spec.PreviousBinding.Name != "" {
+		previousBinding := &korifiv1alpha1.CFServiceBinding{}
+		err := v.client.Get(ctx, client.ObjectKey{Namespace: serviceBinding.Namespace, Name: serviceBinding.Spec.PreviousBinding.Name}, previousBinding)
+		if client.IgnoreNotFound(err) != nil {
+			cfservicebindinglog.Info("failed to get previous binding", "name", serviceBinding.Spec.PreviousBinding.Name, "reason", err)
+			return nil, validation.ValidationError{
+				Type:    validation.UnknownErrorType,
+				Message: validation.UnknownErrorMessage,
+			}.ExportJSONError()
+		}
+
+		// The rotation has not completed, so the lock goes back to the
+		// previous binding if it is here to stay
+		if err == nil && previousBinding.Annotations[korifiv1alpha1.SupersededByAnnotation] == "" && previousBinding.GetDeletionTimestamp().IsZero() {
+			return nil, v.duplicateValidator.ValidateTransfer(ctx, cfservicebindinglog, serviceBinding.Namespace, serviceBinding, previousBinding)
+		}
+	}
+
+	hasSuccessor, err := v.hasSuccessor(ctx, serviceBinding)
+	if err != nil {
+		cfservicebindinglog.Info("failed to list successor bindings", "name", serviceBinding.Name, "reason", err)
+		return nil, validation.ValidationError{
+			Type:    validation.UnknownErrorType,
+			Message: validation.UnknownErrorMessage,
+		}.ExportJSONError()
+	}
+
+	// The lock has been handed over to a successor which is still rotating
+	// this binding
+	if hasSuccessor {
+		return nil, nil
+	}
+
 	return nil, v.duplicateValidator.ValidateDelete(ctx, cfservicebindinglog, serviceBinding.Namespace, serviceBinding)
 }
+
+func (v *CFServiceBindingValidator) hasSuccessor(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) (bool, error) {
+	serviceBindings := &korifiv1alpha1.CFServiceBindingList{}
+	if err := v.client.List(ctx, serviceBindings, client.InNamespace(serviceBinding.Namespace)); err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(serviceBindings.Items, func(b korifiv1alpha1.CFServiceBinding) bool {
+		return b.Spec.PreviousBinding.Name == serviceBinding.Name
+	}), nil
+}
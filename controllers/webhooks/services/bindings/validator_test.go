@@ -6,16 +6,21 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	controllersfake "code.cloudfoundry.org/korifi/controllers/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services/bindings"
+	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("CFServiceBindingValidatingWebhook", func() {
@@ -28,6 +33,8 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 		serviceInstanceGUID string
 		ctx                 context.Context
 		duplicateValidator  *fake.NameValidator
+		k8sClient           *controllersfake.Client
+		existingBindings    []korifiv1alpha1.CFServiceBinding
 		serviceBinding      *korifiv1alpha1.CFServiceBinding
 		validatingWebhook   *bindings.CFServiceBindingValidator
 		retErr              error
@@ -58,8 +65,25 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			},
 		}
 
+		existingBindings = nil
+		k8sClient = new(controllersfake.Client)
+		k8sClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+			for _, b := range existingBindings {
+				if b.Namespace == key.Namespace && b.Name == key.Name {
+					b.DeepCopyInto(obj.(*korifiv1alpha1.CFServiceBinding))
+					return nil
+				}
+			}
+			return k8serrors.NewNotFound(schema.GroupResource{}, key.Name)
+		}
+		k8sClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			existingBindingList := korifiv1alpha1.CFServiceBindingList{Items: existingBindings}
+			existingBindingList.DeepCopyInto(list.(*korifiv1alpha1.CFServiceBindingList))
+			return nil
+		}
+
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = bindings.NewCFServiceBindingValidator(duplicateValidator)
+		validatingWebhook = bindings.NewCFServiceBindingValidator(duplicateValidator, k8sClient)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -88,6 +112,83 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 				Expect(retErr).To(MatchError("foo"))
 			})
 		})
+
+		When("the service binding rotates a previous binding", func() {
+			var previousBinding *korifiv1alpha1.CFServiceBinding
+
+			BeforeEach(func() {
+				previousBinding = serviceBinding.DeepCopy()
+				previousBinding.Name = "previous-binding"
+				existingBindings = []korifiv1alpha1.CFServiceBinding{*previousBinding}
+
+				serviceBinding.Spec.PreviousBinding.Name = "previous-binding"
+			})
+
+			It("transfers the lock of the previous binding instead of creating one", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+
+				Expect(duplicateValidator.ValidateTransferCallCount()).To(Equal(1))
+				_, _, actualNamespace, actualOldResource, actualNewResource := duplicateValidator.ValidateTransferArgsForCall(0)
+				Expect(actualNamespace).To(Equal(defaultNamespace))
+				Expect(actualOldResource.GetName()).To(Equal("previous-binding"))
+				Expect(actualNewResource).To(Equal(serviceBinding))
+			})
+
+			When("the lock cannot be transferred", func() {
+				BeforeEach(func() {
+					duplicateValidator.ValidateTransferReturns(errors.New("foo"))
+				})
+
+				It("prevents the creation of the service binding", func() {
+					Expect(retErr).To(MatchError("foo"))
+				})
+			})
+
+			When("the previous binding does not exist", func() {
+				BeforeEach(func() {
+					existingBindings = nil
+				})
+
+				It("prevents the creation of the service binding", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						bindings.ServiceBindingErrorType,
+						Equal(`Previous binding "previous-binding" does not exist`),
+					))
+					Expect(duplicateValidator.ValidateTransferCallCount()).To(BeZero())
+				})
+			})
+
+			When("the previous binding has already been superseded", func() {
+				BeforeEach(func() {
+					existingBindings[0].Annotations = map[string]string{
+						korifiv1alpha1.SupersededByAnnotation: "another-binding",
+					}
+				})
+
+				It("prevents the creation of the service binding", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						bindings.ServiceBindingErrorType,
+						ContainSubstring("has already been superseded or is being deleted"),
+					))
+					Expect(duplicateValidator.ValidateTransferCallCount()).To(BeZero())
+				})
+			})
+
+			When("the previous binding is being deleted", func() {
+				BeforeEach(func() {
+					existingBindings[0].DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				})
+
+				It("prevents the creation of the service binding", func() {
+					Expect(retErr).To(matchers.BeValidationError(
+						bindings.ServiceBindingErrorType,
+						ContainSubstring("has already been superseded or is being deleted"),
+					))
+					Expect(duplicateValidator.ValidateTransferCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -172,5 +273,77 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 				Expect(retErr).To(MatchError("foo"))
 			})
 		})
+
+		When("the service binding has been superseded by a rotated binding", func() {
+			BeforeEach(func() {
+				serviceBinding.Annotations = map[string]string{
+					korifiv1alpha1.SupersededByAnnotation: "successor-binding",
+				}
+			})
+
+			It("keeps the lock for the successor binding", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateDeleteCallCount()).To(BeZero())
+			})
+		})
+
+		When("the service binding is being rotated by a successor", func() {
+			BeforeEach(func() {
+				successor := serviceBinding.DeepCopy()
+				successor.Name = "successor-binding"
+				successor.Spec.PreviousBinding.Name = serviceBinding.Name
+				existingBindings = []korifiv1alpha1.CFServiceBinding{*successor}
+			})
+
+			It("keeps the lock for the successor binding", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateDeleteCallCount()).To(BeZero())
+			})
+		})
+
+		When("the service binding is still rotating a previous binding", func() {
+			BeforeEach(func() {
+				previousBinding := serviceBinding.DeepCopy()
+				previousBinding.Name = "previous-binding"
+				existingBindings = []korifiv1alpha1.CFServiceBinding{*previousBinding}
+
+				serviceBinding.Spec.PreviousBinding.Name = "previous-binding"
+			})
+
+			It("hands the lock back to the previous binding", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateDeleteCallCount()).To(BeZero())
+
+				Expect(duplicateValidator.ValidateTransferCallCount()).To(Equal(1))
+				_, _, actualNamespace, actualOldResource, actualNewResource := duplicateValidator.ValidateTransferArgsForCall(0)
+				Expect(actualNamespace).To(Equal(defaultNamespace))
+				Expect(actualOldResource).To(Equal(serviceBinding))
+				Expect(actualNewResource.GetName()).To(Equal("previous-binding"))
+			})
+
+			When("the previous binding no longer exists", func() {
+				BeforeEach(func() {
+					existingBindings = nil
+				})
+
+				It("deletes the lock", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+					Expect(duplicateValidator.ValidateTransferCallCount()).To(BeZero())
+					Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+				})
+			})
+
+			When("the previous binding is being deleted", func() {
+				BeforeEach(func() {
+					existingBindings[0].DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				})
+
+				It("deletes the lock", func() {
+					Expect(retErr).NotTo(HaveOccurred())
+					Expect(duplicateValidator.ValidateTransferCallCount()).To(BeZero())
+					Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+				})
+			})
+		})
 	})
 })
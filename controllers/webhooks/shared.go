@@ -23,6 +23,7 @@ type NameValidator interface {
 	ValidateCreate(ctx context.Context, logger logr.Logger, namespace string, obj UniqueClientObject) error
 	ValidateUpdate(ctx context.Context, logger logr.Logger, namespace string, oldObj, newObj UniqueClientObject) error
 	ValidateDelete(ctx context.Context, logger logr.Logger, namespace string, obj UniqueClientObject) error
+	ValidateTransfer(ctx context.Context, logger logr.Logger, namespace string, oldObj, newObj UniqueClientObject) error
 }
 
 //counterfeiter:generate -o fake -fake-name NamespaceValidator . NamespaceValidator
@@ -30,6 +31,7 @@ type NameValidator interface {
 type NamespaceValidator interface {
 	ValidateOrgCreate(org korifiv1alpha1.CFOrg) error
 	ValidateSpaceCreate(space korifiv1alpha1.CFSpace) error
+	ValidateIsolationSegment(ctx context.Context, orgGUID, isolationSegmentGUID string) error
 }
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -41,6 +43,7 @@ type NameRegistry interface {
 	TryLockName(ctx context.Context, namespace, name string) error
 	UnlockName(ctx context.Context, namespace, name string) error
 	CheckNameOwnership(ctx context.Context, namespace, name, ownerNamespace, ownerName string) (bool, error)
+	TransferNameOwnership(ctx context.Context, namespace, name, ownerNamespace, ownerName, newOwnerNamespace, newOwnerName string) error
 }
 
 //counterfeiter:generate -o fake -fake-name UniqueClientObject . UniqueClientObject
@@ -131,6 +131,39 @@ func (v DuplicateValidator) ValidateDelete(ctx context.Context, logger logr.Logg
 	return nil
 }
 
+// ValidateTransfer hands the unique name held by oldObj over to newObj, which
+// must have the same unique name. The transfer fails if the name is not owned
+// by oldObj, e.g. because it has already been handed over to another object.
+// An unregistered name is registered for newObj
+func (v DuplicateValidator) ValidateTransfer(ctx context.Context, logger logr.Logger, namespace string, oldObj, newObj webhooks.UniqueClientObject) error {
+	logger = logger.
+		WithName("duplicateValidator.ValidateTransfer").
+		WithValues("namespace", namespace, "name", newObj.UniqueName(), "oldOwner", oldObj.GetName(), "newOwner", newObj.GetName())
+
+	if oldObj.UniqueName() != newObj.UniqueName() {
+		logger.Info("cannot transfer name to an object with a different unique name", "oldName", oldObj.UniqueName())
+		return duplicateError(newObj)
+	}
+
+	err := v.nameRegistry.TransferNameOwnership(ctx, namespace, newObj.UniqueName(), oldObj.GetNamespace(), oldObj.GetName(), newObj.GetNamespace(), newObj.GetName())
+	if err != nil {
+		logger.Info("failed to transfer name ownership", "reason", err)
+
+		if k8serrors.IsNotFound(err) {
+			// nobody holds the name, so it can simply be registered
+			return v.ValidateCreate(ctx, logger, namespace, newObj)
+		}
+
+		if k8serrors.IsInvalid(err) {
+			return duplicateError(newObj)
+		}
+
+		return unknownError()
+	}
+
+	return nil
+}
+
 func duplicateError(obj webhooks.UniqueClientObject) error {
 	return ValidationError{
 		Type:    DuplicateNameErrorType,
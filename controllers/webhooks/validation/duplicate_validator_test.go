@@ -301,4 +301,90 @@ var _ = Describe("DuplicateValidator", func() {
 			})
 		})
 	})
+
+	Describe("ValidateTransfer", func() {
+		var newUniqueClientObj *fake.UniqueClientObject
+
+		BeforeEach(func() {
+			newUniqueClientObj = new(fake.UniqueClientObject)
+			newUniqueClientObj.GetNameReturns("new-resource-name")
+			newUniqueClientObj.GetNamespaceReturns("test-resource-namespace")
+			newUniqueClientObj.UniqueNameReturns("unique-name")
+			newUniqueClientObj.UniqueValidationErrorMessageReturns("uniqueness-error")
+		})
+
+		JustBeforeEach(func() {
+			validationErr = duplicateValidator.ValidateTransfer(ctx, logger, "uniqueness-namespace", uniqueClientObj, newUniqueClientObj)
+		})
+
+		It("transfers the name to the new object", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+
+			Expect(nameRegistry.TransferNameOwnershipCallCount()).To(Equal(1))
+			_, namespace, name, ownerNamespace, ownerName, newOwnerNamespace, newOwnerName := nameRegistry.TransferNameOwnershipArgsForCall(0)
+			Expect(namespace).To(Equal("uniqueness-namespace"))
+			Expect(name).To(Equal("unique-name"))
+			Expect(ownerNamespace).To(Equal("test-resource-namespace"))
+			Expect(ownerName).To(Equal("test-resource-name"))
+			Expect(newOwnerNamespace).To(Equal("test-resource-namespace"))
+			Expect(newOwnerName).To(Equal("new-resource-name"))
+		})
+
+		When("the unique names differ", func() {
+			BeforeEach(func() {
+				newUniqueClientObj.UniqueNameReturns("another-unique-name")
+			})
+
+			It("fails without transferring the name", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.DuplicateNameErrorType,
+					Equal("uniqueness-error"),
+				))
+				Expect(nameRegistry.TransferNameOwnershipCallCount()).To(BeZero())
+			})
+		})
+
+		When("the name is not owned by the old object", func() {
+			BeforeEach(func() {
+				nameRegistry.TransferNameOwnershipReturns(k8serrors.NewInvalid(schema.GroupKind{}, "jim", nil))
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.DuplicateNameErrorType,
+					Equal("uniqueness-error"),
+				))
+			})
+		})
+
+		When("the name is not registered", func() {
+			BeforeEach(func() {
+				nameRegistry.TransferNameOwnershipReturns(k8serrors.NewNotFound(schema.GroupResource{}, "jim"))
+			})
+
+			It("registers the name for the new object", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+
+				Expect(nameRegistry.RegisterNameCallCount()).To(Equal(1))
+				_, namespace, name, ownerNamespace, ownerName := nameRegistry.RegisterNameArgsForCall(0)
+				Expect(namespace).To(Equal("uniqueness-namespace"))
+				Expect(name).To(Equal("unique-name"))
+				Expect(ownerNamespace).To(Equal("test-resource-namespace"))
+				Expect(ownerName).To(Equal("new-resource-name"))
+			})
+		})
+
+		When("transferring the name fails", func() {
+			BeforeEach(func() {
+				nameRegistry.TransferNameOwnershipReturns(errors.New("transfer-err"))
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.UnknownErrorType,
+					Equal(validation.UnknownErrorMessage),
+				))
+			})
+		})
+	})
 })
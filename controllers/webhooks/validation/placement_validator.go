@@ -3,6 +3,7 @@ package validation
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
@@ -15,6 +16,9 @@ const (
 	OrgPlacementErrorMessage   = "Organization '%s' must be placed in the root 'cf' namespace"
 	SpacePlacementErrorType    = "SpacePlacementError"
 	SpacePlacementErrorMessage = "Organization '%s' does not exist for Space '%s'"
+
+	IsolationSegmentNotEntitledErrorType    = "IsolationSegmentNotEntitledError"
+	IsolationSegmentNotEntitledErrorMessage = "Organization '%s' is not entitled to isolation segment '%s'"
 )
 
 type PlacementValidator struct {
@@ -50,3 +54,26 @@ func (v PlacementValidator) ValidateSpaceCreate(space korifiv1alpha1.CFSpace) er
 
 	return nil
 }
+
+// ValidateIsolationSegment ensures that the org is entitled to the isolation
+// segment its workloads are being assigned to
+func (v PlacementValidator) ValidateIsolationSegment(ctx context.Context, orgGUID, isolationSegmentGUID string) error {
+	if isolationSegmentGUID == "" {
+		return nil
+	}
+
+	isolationSegment := korifiv1alpha1.CFIsolationSegment{}
+	err := v.client.Get(ctx, types.NamespacedName{Name: isolationSegmentGUID, Namespace: v.rootNamespace}, &isolationSegment)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get isolation segment %q: %w", isolationSegmentGUID, err)
+	}
+
+	if err != nil || !slices.Contains(isolationSegment.Spec.EntitledOrgs, orgGUID) {
+		return ValidationError{
+			Type:    IsolationSegmentNotEntitledErrorType,
+			Message: fmt.Sprintf(IsolationSegmentNotEntitledErrorMessage, orgGUID, isolationSegmentGUID),
+		}.ExportJSONError()
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package validation_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -12,8 +13,11 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("CFPlacementValidation", func() {
@@ -108,4 +112,80 @@ var _ = Describe("CFPlacementValidation", func() {
 			})
 		})
 	})
+
+	Describe("ValidateIsolationSegment", func() {
+		var isolationSegmentGUID string
+
+		BeforeEach(func() {
+			isolationSegmentGUID = "my-segment"
+
+			fakeClient.GetStub = func(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+				Expect(key).To(Equal(client.ObjectKey{Namespace: rootNamespace, Name: "my-segment"}))
+				isolationSegment, ok := obj.(*korifiv1alpha1.CFIsolationSegment)
+				Expect(ok).To(BeTrue())
+				isolationSegment.Spec.EntitledOrgs = []string{"other-org", "my-org"}
+				return nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			validationErr = placementValidator.ValidateIsolationSegment(context.Background(), "my-org", isolationSegmentGUID)
+		})
+
+		It("succeeds", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+
+		When("no isolation segment is assigned", func() {
+			BeforeEach(func() {
+				isolationSegmentGUID = ""
+			})
+
+			It("succeeds without looking up a segment", func() {
+				Expect(validationErr).NotTo(HaveOccurred())
+				Expect(fakeClient.GetCallCount()).To(BeZero())
+			})
+		})
+
+		When("the org is not entitled to the isolation segment", func() {
+			BeforeEach(func() {
+				fakeClient.GetStub = func(_ context.Context, _ client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+					obj.(*korifiv1alpha1.CFIsolationSegment).Spec.EntitledOrgs = []string{"other-org"}
+					return nil
+				}
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.IsolationSegmentNotEntitledErrorType,
+					Equal(fmt.Sprintf(validation.IsolationSegmentNotEntitledErrorMessage, "my-org", "my-segment")),
+				))
+			})
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				fakeClient.GetStub = nil
+				fakeClient.GetReturns(k8serrors.NewNotFound(schema.GroupResource{}, "my-segment"))
+			})
+
+			It("fails", func() {
+				Expect(validationErr).To(matchers.BeValidationError(
+					validation.IsolationSegmentNotEntitledErrorType,
+					Equal(fmt.Sprintf(validation.IsolationSegmentNotEntitledErrorMessage, "my-org", "my-segment")),
+				))
+			})
+		})
+
+		When("getting the isolation segment fails", func() {
+			BeforeEach(func() {
+				fakeClient.GetStub = nil
+				fakeClient.GetReturns(errors.New("get-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(validationErr).To(MatchError(ContainSubstring("get-error")))
+			})
+		})
+	})
 })
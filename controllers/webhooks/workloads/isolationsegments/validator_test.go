@@ -0,0 +1,150 @@
+package isolationsegments_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/workloads/isolationsegments"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("CFIsolationSegmentValidatingWebhook", func() {
+	const (
+		defaultNamespace = "default"
+	)
+
+	var (
+		ctx                context.Context
+		duplicateValidator *fake.NameValidator
+		isolationSegment   *korifiv1alpha1.CFIsolationSegment
+		validatingWebhook  *isolationsegments.Validator
+		retErr             error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		err := korifiv1alpha1.AddToScheme(scheme)
+		Expect(err).ToNot(HaveOccurred())
+
+		isolationSegment = &korifiv1alpha1.CFIsolationSegment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: defaultNamespace,
+			},
+			Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+				DisplayName: uuid.NewString(),
+			},
+		}
+
+		duplicateValidator = new(fake.NameValidator)
+		validatingWebhook = isolationsegments.NewValidator(duplicateValidator, defaultNamespace)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, isolationSegment)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("invokes the validator correctly", func() {
+			Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+			actualContext, _, actualNamespace, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualNamespace).To(Equal(isolationSegment.Namespace))
+			Expect(actualResource).To(Equal(isolationSegment))
+			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Isolation Segment names are case insensitive and must be unique. The name '" + isolationSegment.Spec.DisplayName + "' is already taken."))
+		})
+
+		When("the isolation segment name is a duplicate", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedIsolationSegment *korifiv1alpha1.CFIsolationSegment
+
+		BeforeEach(func() {
+			updatedIsolationSegment = isolationSegment.DeepCopy()
+			updatedIsolationSegment.Spec.DisplayName = "the-new-name"
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, isolationSegment, updatedIsolationSegment)
+		})
+
+		It("invokes the validator correctly", func() {
+			Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(1))
+			actualContext, _, actualNamespace, oldResource, newResource := duplicateValidator.ValidateUpdateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualNamespace).To(Equal(isolationSegment.Namespace))
+			Expect(oldResource).To(Equal(isolationSegment))
+			Expect(newResource).To(Equal(updatedIsolationSegment))
+		})
+
+		When("the isolation segment is being deleted", func() {
+			BeforeEach(func() {
+				updatedIsolationSegment.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			It("does not return an error", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the new isolation segment name is a duplicate", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateUpdateReturns(errors.New("foo"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateDelete(ctx, isolationSegment)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("invokes the validator correctly", func() {
+			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+			actualContext, _, actualNamespace, actualResource := duplicateValidator.ValidateDeleteArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualNamespace).To(Equal(isolationSegment.Namespace))
+			Expect(actualResource).To(Equal(isolationSegment))
+		})
+
+		When("delete validation fails", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateDeleteReturns(errors.New("foo"))
+			})
+
+			It("disallows the request", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+})
@@ -62,6 +62,11 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, err
 	}
 
+	err = v.placementValidator.ValidateIsolationSegment(ctx, org.Name, org.Spec.DefaultIsolationSegmentGUID)
+	if err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfOrgLog, org.Namespace, org)
 }
 
@@ -80,6 +85,13 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFOrg but got a %T", obj))
 	}
 
+	if org.Spec.DefaultIsolationSegmentGUID != oldOrg.Spec.DefaultIsolationSegmentGUID {
+		err := v.placementValidator.ValidateIsolationSegment(ctx, org.Name, org.Spec.DefaultIsolationSegmentGUID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfOrgLog, org.Namespace, oldOrg, org)
 }
 
@@ -43,6 +43,42 @@ var _ = Describe("CFOrgValidatingWebhook", func() {
 			Expect(createErr).NotTo(HaveOccurred())
 		})
 
+		When("the org has a default isolation segment", func() {
+			var isolationSegment *korifiv1alpha1.CFIsolationSegment
+
+			BeforeEach(func() {
+				isolationSegment = &korifiv1alpha1.CFIsolationSegment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace,
+					},
+					Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+						DisplayName:  "segment-" + uuid.NewString(),
+						EntitledOrgs: []string{org.Name},
+					},
+				}
+				Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+
+				org.Spec.DefaultIsolationSegmentGUID = isolationSegment.Name
+			})
+
+			It("should succeed", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+			})
+
+			When("the org is not entitled to the isolation segment", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, isolationSegment, func() {
+						isolationSegment.Spec.EntitledOrgs = nil
+					})).To(Succeed())
+				})
+
+				It("should fail", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("is not entitled to isolation segment")))
+				})
+			})
+		})
+
 		When("CFOrg is requested outside of root namespace", func() {
 			BeforeEach(func() {
 				org.Namespace = "default"
@@ -181,6 +217,29 @@ var _ = Describe("CFOrgValidatingWebhook", func() {
 			})
 		})
 
+		When("assigning a default isolation segment the org is not entitled to", func() {
+			JustBeforeEach(func() {
+				isolationSegment := &korifiv1alpha1.CFIsolationSegment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace,
+					},
+					Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+						DisplayName: "segment-" + uuid.NewString(),
+					},
+				}
+				Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+
+				updateErr = k8s.Patch(ctx, adminClient, org, func() {
+					org.Spec.DefaultIsolationSegmentGUID = isolationSegment.Name
+				})
+			})
+
+			It("should fail", func() {
+				Expect(updateErr).To(MatchError(ContainSubstring("is not entitled to isolation segment")))
+			})
+		})
+
 		When("not changing the name", func() {
 			JustBeforeEach(func() {
 				updateErr = k8s.Patch(ctx, adminClient, org, func() {
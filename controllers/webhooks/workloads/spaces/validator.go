@@ -60,7 +60,12 @@ func (v *Validator) ValidateCreate(ctx context.Context, obj runtime.Object) (adm
 		return nil, err
 	}
 
-	return nil, v.placementValidator.ValidateSpaceCreate(*space)
+	err = v.placementValidator.ValidateSpaceCreate(*space)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, v.placementValidator.ValidateIsolationSegment(ctx, space.Namespace, space.Spec.IsolationSegmentGUID)
 }
 
 func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
@@ -78,6 +83,13 @@ func (v *Validator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Obje
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSpace but got a %T", obj))
 	}
 
+	if space.Spec.IsolationSegmentGUID != oldSpace.Spec.IsolationSegmentGUID {
+		err := v.placementValidator.ValidateIsolationSegment(ctx, space.Namespace, space.Spec.IsolationSegmentGUID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, spaceLogger, oldSpace.Namespace, oldSpace, space)
 }
 
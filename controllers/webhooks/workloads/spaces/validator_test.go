@@ -59,6 +59,42 @@ var _ = Describe("CFSpaceValidatingWebhook", func() {
 			Expect(createErr).To(Succeed())
 		})
 
+		When("the space is assigned to an isolation segment", func() {
+			var isolationSegment *korifiv1alpha1.CFIsolationSegment
+
+			BeforeEach(func() {
+				isolationSegment = &korifiv1alpha1.CFIsolationSegment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace,
+					},
+					Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+						DisplayName:  "segment-" + uuid.NewString(),
+						EntitledOrgs: []string{orgNamespace},
+					},
+				}
+				Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+
+				cfSpace.Spec.IsolationSegmentGUID = isolationSegment.Name
+			})
+
+			It("succeeds", func() {
+				Expect(createErr).To(Succeed())
+			})
+
+			When("the org is not entitled to the isolation segment", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, adminClient, isolationSegment, func() {
+						isolationSegment.Spec.EntitledOrgs = nil
+					})).To(Succeed())
+				})
+
+				It("fails", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("is not entitled to isolation segment")))
+				})
+			})
+		})
+
 		When("a corresponding CFOrg does not exist", func() {
 			BeforeEach(func() {
 				cfSpace.Namespace = "not-an-org"
@@ -153,6 +189,31 @@ var _ = Describe("CFSpaceValidatingWebhook", func() {
 		})
 	})
 
+	Describe("assigning an isolation segment the org is not entitled to", func() {
+		var updateErr error
+
+		JustBeforeEach(func() {
+			isolationSegment := &korifiv1alpha1.CFIsolationSegment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+					DisplayName: "segment-" + uuid.NewString(),
+				},
+			}
+			Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+
+			updateErr = k8s.Patch(ctx, adminClient, cfSpace, func() {
+				cfSpace.Spec.IsolationSegmentGUID = isolationSegment.Name
+			})
+		})
+
+		It("fails", func() {
+			Expect(updateErr).To(MatchError(ContainSubstring("is not entitled to isolation segment")))
+		})
+	})
+
 	Describe("deleting a space", func() {
 		It("can delete the space", func() {
 			Expect(adminNonSyncClient.Delete(ctx, cfSpace)).To(Succeed())
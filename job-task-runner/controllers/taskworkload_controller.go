@@ -19,6 +19,8 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"path/filepath"
+	"strconv"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -39,8 +41,9 @@ import (
 )
 
 const (
-	workloadContainerName = "workload"
-	ServiceAccountName    = "korifi-task"
+	workloadContainerName  = "workload"
+	ServiceAccountName     = "korifi-task"
+	instanceIdentityVolume = "cf-instance-credentials"
 )
 
 //counterfeiter:generate -o fake -fake-name TaskStatusGetter . TaskStatusGetter
@@ -199,6 +202,60 @@ func (r *TaskWorkloadReconciler) workloadToJob(taskWorkload *korifiv1alpha1.Task
 		},
 	}
 
+	for _, volumeMount := range taskWorkload.Spec.VolumeMounts {
+		job.Spec.Template.Spec.Containers[0].VolumeMounts = append(job.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      volumeMount.Name,
+			ReadOnly:  volumeMount.ReadOnly,
+			MountPath: volumeMount.ContainerDir,
+		})
+		job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: volumeMount.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: volumeMount.PersistentVolumeClaim,
+				CSI:                   volumeMount.CSI,
+			},
+		})
+	}
+
+	if secretName := taskWorkload.Spec.InstanceIdentitySecretName; secretName != "" {
+		job.Spec.Template.Spec.Containers[0].Env = append(job.Spec.Template.Spec.Containers[0].Env,
+			corev1.EnvVar{
+				Name:  "CF_INSTANCE_CERT",
+				Value: filepath.Join(korifiv1alpha1.InstanceIdentityMountPath, korifiv1alpha1.InstanceIdentityCertKey),
+			},
+			corev1.EnvVar{
+				Name:  "CF_INSTANCE_KEY",
+				Value: filepath.Join(korifiv1alpha1.InstanceIdentityMountPath, korifiv1alpha1.InstanceIdentityKeyKey),
+			},
+		)
+		job.Spec.Template.Spec.Containers[0].VolumeMounts = append(job.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      instanceIdentityVolume,
+			ReadOnly:  true,
+			MountPath: korifiv1alpha1.InstanceIdentityMountPath,
+		})
+		job.Spec.Template.Spec.Volumes = append(job.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: instanceIdentityVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  korifiv1alpha1.InstanceIdentityInstanceSecretName(secretName, "0"),
+					DefaultMode: tools.PtrTo[int32](0o644),
+				},
+			},
+		})
+	}
+
+	if limit := taskWorkload.Spec.LogRateLimitBytesPerSecond; limit != nil && *limit >= 0 {
+		job.Spec.Template.Annotations = map[string]string{
+			korifiv1alpha1.LogRateLimitAnnotation: strconv.FormatInt(*limit, 10),
+		}
+	}
+
+	if scheduling := taskWorkload.Spec.Scheduling; scheduling != nil {
+		job.Spec.Template.Spec.NodeSelector = scheduling.NodeSelector
+		job.Spec.Template.Spec.Tolerations = scheduling.Tolerations
+		job.Spec.Template.Spec.RuntimeClassName = scheduling.RuntimeClassName
+	}
+
 	err := controllerutil.SetControllerReference(taskWorkload, job, r.scheme)
 	if err != nil {
 		return nil, err
@@ -8,10 +8,13 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/job-task-runner/controllers"
 	"code.cloudfoundry.org/korifi/job-task-runner/controllers/fake"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -156,6 +159,129 @@ var _ = Describe("TaskworkloadController", func() {
 			Expect(job.Name).To(Equal(taskWorkload.Name))
 		})
 
+		When("the taskworkload has scheduling constraints", func() {
+			var jobPodSpec corev1.PodSpec
+
+			BeforeEach(func() {
+				fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					jobPodSpec = obj.(*batchv1.Job).Spec.Template.Spec
+					return nil
+				}
+
+				taskWorkload.Spec.Scheduling = &korifiv1alpha1.WorkloadScheduling{
+					NodeSelector: map[string]string{"pool": "pci"},
+					Tolerations: []corev1.Toleration{{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					}},
+					RuntimeClassName: tools.PtrTo("gvisor"),
+				}
+			})
+
+			It("sets them on the job pod template", func() {
+				Expect(jobPodSpec.NodeSelector).To(Equal(map[string]string{"pool": "pci"}))
+				Expect(jobPodSpec.Tolerations).To(ConsistOf(corev1.Toleration{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}))
+				Expect(jobPodSpec.RuntimeClassName).To(PointTo(Equal("gvisor")))
+			})
+		})
+
+		When("the taskworkload has volume mounts", func() {
+			var jobPodSpec corev1.PodSpec
+
+			BeforeEach(func() {
+				fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					jobPodSpec = obj.(*batchv1.Job).Spec.Template.Spec
+					return nil
+				}
+
+				taskWorkload.Spec.VolumeMounts = []korifiv1alpha1.VolumeMount{{
+					Name:         "nfs-volume",
+					ContainerDir: "/data/nfs",
+					ReadOnly:     true,
+					CSI: &corev1.CSIVolumeSource{
+						Driver:   "nfs.csi.k8s.io",
+						ReadOnly: tools.PtrTo(true),
+					},
+				}}
+			})
+
+			It("mounts the volumes onto the task container", func() {
+				Expect(jobPodSpec.Volumes).To(ConsistOf(corev1.Volume{
+					Name: "nfs-volume",
+					VolumeSource: corev1.VolumeSource{
+						CSI: &corev1.CSIVolumeSource{
+							Driver:   "nfs.csi.k8s.io",
+							ReadOnly: tools.PtrTo(true),
+						},
+					},
+				}))
+				Expect(jobPodSpec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+					Name:      "nfs-volume",
+					ReadOnly:  true,
+					MountPath: "/data/nfs",
+				}))
+			})
+		})
+
+		When("the taskworkload has instance identity credentials", func() {
+			var jobPodSpec corev1.PodSpec
+
+			BeforeEach(func() {
+				fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					jobPodSpec = obj.(*batchv1.Job).Spec.Template.Spec
+					return nil
+				}
+
+				taskWorkload.Spec.InstanceIdentitySecretName = "identity-secret"
+			})
+
+			It("mounts the credentials secret of instance 0 onto the task container", func() {
+				Expect(jobPodSpec.Volumes).To(ConsistOf(corev1.Volume{
+					Name: "cf-instance-credentials",
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{
+							SecretName:  "identity-secret-0",
+							DefaultMode: tools.PtrTo[int32](0o644),
+						},
+					},
+				}))
+				Expect(jobPodSpec.Containers[0].VolumeMounts).To(ConsistOf(corev1.VolumeMount{
+					Name:      "cf-instance-credentials",
+					ReadOnly:  true,
+					MountPath: "/etc/cf-instance-credentials",
+				}))
+			})
+
+			It("points the instance credentials env vars at the task instance files", func() {
+				Expect(jobPodSpec.Containers[0].Env).To(ContainElements(
+					corev1.EnvVar{Name: "CF_INSTANCE_CERT", Value: "/etc/cf-instance-credentials/tls.crt"},
+					corev1.EnvVar{Name: "CF_INSTANCE_KEY", Value: "/etc/cf-instance-credentials/tls.key"},
+				))
+			})
+		})
+
+		When("the taskworkload has a log rate limit", func() {
+			var jobPodTemplate corev1.PodTemplateSpec
+
+			BeforeEach(func() {
+				fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					jobPodTemplate = obj.(*batchv1.Job).Spec.Template
+					return nil
+				}
+
+				taskWorkload.Spec.LogRateLimitBytesPerSecond = tools.PtrTo[int64](2048)
+			})
+
+			It("annotates the task pod with it", func() {
+				Expect(jobPodTemplate.Annotations).To(HaveKeyWithValue("korifi.cloudfoundry.org/log-rate-limit-bytes-per-second", "2048"))
+			})
+		})
+
 		When("the taskworkload has the initialized true condition", func() {
 			BeforeEach(func() {
 				meta.SetStatusCondition(&taskWorkload.Status.Conditions, metav1.Condition{
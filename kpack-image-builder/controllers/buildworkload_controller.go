@@ -154,6 +154,8 @@ func filterBuildWorkloads(object client.Object) bool {
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads,verbs=get;list;watch;create;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=buildworkloads/status,verbs=get;patch
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfstacks,verbs=get;list;watch
+
 //+kubebuilder:rbac:groups=kpack.io,resources=images,verbs=get;list;watch;create;patch;delete
 //+kubebuilder:rbac:groups=kpack.io,resources=images/status,verbs=get;patch
 //+kubebuilder:rbac:groups=kpack.io,resources=builds,verbs=deletecollection
@@ -368,10 +370,21 @@ func (r *BuildWorkloadReconciler) getBuilderReadyCondition(ctx context.Context,
 	return condition, nil
 }
 
-func (r *BuildWorkloadReconciler) getDefaultClusterBuilder(ctx context.Context) (*buildv1alpha2.ClusterBuilder, error) {
-	var defaultBuilder buildv1alpha2.ClusterBuilder
-	err := r.k8sClient.Get(ctx, client.ObjectKey{Name: r.controllerConfig.ClusterBuilderName}, &defaultBuilder)
-	return &defaultBuilder, err
+func (r *BuildWorkloadReconciler) getClusterBuilder(ctx context.Context, clusterBuilderName string) (*buildv1alpha2.ClusterBuilder, error) {
+	var clusterBuilder buildv1alpha2.ClusterBuilder
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Name: clusterBuilderName}, &clusterBuilder)
+	return &clusterBuilder, err
+}
+
+// clusterBuilderNameFor returns the ClusterBuilder of the CFStack matching the
+// stack of the build workload
+func (r *BuildWorkloadReconciler) clusterBuilderNameFor(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload) (string, error) {
+	cfStacks := &korifiv1alpha1.CFStackList{}
+	if err := r.k8sClient.List(ctx, cfStacks, client.InNamespace(r.controllerConfig.CFRootNamespace)); err != nil {
+		return "", fmt.Errorf("failed to list CFStacks: %w", err)
+	}
+
+	return clusterBuilderNameForStackName(cfStacks.Items, buildWorkload.Spec.Stack, r.controllerConfig.ClusterBuilderName), nil
 }
 
 type doNotRetryError struct {
@@ -399,29 +412,29 @@ func ignoreDoNotRetryError(err error) error {
 	return err
 }
 
-func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) (string, error) {
+func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload, clusterBuilderName string) (string, error) {
 	var (
-		defaultBuilder *buildv1alpha2.ClusterBuilder
+		clusterBuilder *buildv1alpha2.ClusterBuilder
 		err            error
 	)
 
-	if defaultBuilder, err = r.getDefaultClusterBuilder(ctx); err != nil {
+	if clusterBuilder, err = r.getClusterBuilder(ctx, clusterBuilderName); err != nil {
 		if k8serrors.IsNotFound(err) {
 			meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
 				Type:               korifiv1alpha1.SucceededConditionType,
 				Status:             metav1.ConditionFalse,
 				Reason:             "BuilderNotReady",
-				Message:            "Default ClusterBuilder not found",
+				Message:            fmt.Sprintf("ClusterBuilder %q not found", clusterBuilderName),
 				ObservedGeneration: buildWorkload.Generation,
 			})
-			return "", newDoNotRetryError(fmt.Errorf("default ClusterBuilder not found: %w", err))
+			return "", newDoNotRetryError(fmt.Errorf("ClusterBuilder %q not found: %w", clusterBuilderName, err))
 		}
 
-		log.Info("error when fetching default ClusterBuilder", "reason", err)
+		log.Info("error when fetching ClusterBuilder", "reason", err)
 		return "", err
 	}
 
-	if err = r.checkBuildpacks(ctx, buildWorkload, defaultBuilder); err != nil {
+	if err = r.checkBuildpacks(ctx, buildWorkload, clusterBuilder); err != nil {
 		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
 			Type:               korifiv1alpha1.SucceededConditionType,
 			Status:             metav1.ConditionFalse,
@@ -454,8 +467,8 @@ func (r *BuildWorkloadReconciler) ensureKpackBuilderForBuildpacks(ctx context.Co
 		}
 
 		builder.Spec.Tag = builderRepo
-		builder.Spec.Stack = defaultBuilder.Spec.Stack
-		builder.Spec.Store = defaultBuilder.Spec.Store
+		builder.Spec.Stack = clusterBuilder.Spec.Stack
+		builder.Spec.Store = clusterBuilder.Spec.Store
 		builder.Spec.ServiceAccountName = r.controllerConfig.BuilderServiceAccount
 		builder.Spec.Order = nil
 		for _, bp := range buildWorkload.Spec.Buildpacks {
@@ -484,9 +497,9 @@ func ComputeBuilderName(bps []string) string {
 	return uuid.NewSHA1(uuid.Nil, []byte(strings.Join(bps, "\x00"))).String()
 }
 
-func (r *BuildWorkloadReconciler) checkBuildpacks(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload, defaultBuilder *buildv1alpha2.ClusterBuilder) error {
+func (r *BuildWorkloadReconciler) checkBuildpacks(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload, clusterBuilder *buildv1alpha2.ClusterBuilder) error {
 	validIDs := map[string]bool{}
-	for _, bp := range clusterBuilderToBuildpacks(defaultBuilder, metav1.Now()) {
+	for _, bp := range clusterBuilderToBuildpacks(clusterBuilder, metav1.Now()) {
 		validIDs[bp.Name] = true
 	}
 
@@ -604,10 +617,15 @@ func latestBuild(builds []buildv1alpha2.Build) (*buildv1alpha2.Build, error) {
 
 func (r *BuildWorkloadReconciler) beginImageBuild(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) (ctrl.Result, error) {
 	var builderName string
-	var err error
+
+	clusterBuilderName, err := r.clusterBuilderNameFor(ctx, buildWorkload)
+	if err != nil {
+		log.Info("failed resolving the stack ClusterBuilder", "reason", err)
+		return ctrl.Result{}, err
+	}
 
 	if len(buildWorkload.Spec.Buildpacks) > 0 {
-		builderName, err = r.ensureKpackBuilderForBuildpacks(ctx, log, buildWorkload)
+		builderName, err = r.ensureKpackBuilderForBuildpacks(ctx, log, buildWorkload, clusterBuilderName)
 		if err != nil {
 			log.Info("failed ensuring custom builder", "reason", err)
 			return ctrl.Result{}, ignoreDoNotRetryError(fmt.Errorf("failed ensuring custom builder: %w", err))
@@ -620,7 +638,7 @@ func (r *BuildWorkloadReconciler) beginImageBuild(ctx context.Context, log logr.
 		return ctrl.Result{}, err
 	}
 
-	return ctrl.Result{}, r.reconcileKpackImage(ctx, log, buildWorkload, builderName)
+	return ctrl.Result{}, r.reconcileKpackImage(ctx, log, buildWorkload, clusterBuilderName, builderName)
 }
 
 func (r *BuildWorkloadReconciler) ensureRegistryImagePullSecretsExist(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload) error {
@@ -638,6 +656,7 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 	ctx context.Context,
 	log logr.Logger,
 	buildWorkload *korifiv1alpha1.BuildWorkload,
+	clusterBuilderName string,
 	customBuilderName string,
 ) error {
 	appGUID := buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]
@@ -692,7 +711,7 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 			Tag: kpackImageTag,
 			Builder: corev1.ObjectReference{
 				Kind:       clusterBuilderKind,
-				Name:       r.controllerConfig.ClusterBuilderName,
+				Name:       clusterBuilderName,
 				APIVersion: clusterBuilderAPIVersion,
 			},
 			ServiceAccountName: r.controllerConfig.BuilderServiceAccount,
@@ -713,6 +732,11 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 				},
 			},
 		}
+		if scheduling := buildWorkload.Spec.Scheduling; scheduling != nil {
+			desiredKpackImage.Spec.Build.NodeSelector = scheduling.NodeSelector
+			desiredKpackImage.Spec.Build.Tolerations = scheduling.Tolerations
+			desiredKpackImage.Spec.Build.RuntimeClassName = scheduling.RuntimeClassName
+		}
 		if customBuilderName != "" {
 			desiredKpackImage.Spec.Builder.Kind = "Builder"
 			desiredKpackImage.Spec.Builder.Name = customBuilderName
@@ -45,6 +45,8 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 		services                  []corev1.ObjectReference
 		reconcilerName            string
 		buildpacks                []string
+		stack                     string
+		scheduling                *korifiv1alpha1.WorkloadScheduling
 		imageRepoCreatorCallCount int
 		expectedCacheVolumeSize   string
 	)
@@ -125,6 +127,8 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 		}
 
 		buildpacks = nil
+		stack = ""
+		scheduling = nil
 
 		fakeImageConfigGetter.ConfigReturns(image.Config{
 			Labels: map[string]string{
@@ -193,6 +197,8 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 	Describe("BuildWorkload initialization phase", func() {
 		JustBeforeEach(func() {
 			buildWorkload = buildWorkloadObject(buildWorkloadGUID, namespaceGUID, source, env, services, reconcilerName, buildpacks)
+			buildWorkload.Spec.Stack = stack
+			buildWorkload.Spec.Scheduling = scheduling
 			Expect(adminClient.Create(ctx, buildWorkload)).To(Succeed())
 		})
 
@@ -335,6 +341,129 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 			})
 		})
 
+		When("the workload has scheduling constraints", func() {
+			BeforeEach(func() {
+				scheduling = &korifiv1alpha1.WorkloadScheduling{
+					NodeSelector: map[string]string{"pool": "pci"},
+					Tolerations: []corev1.Toleration{{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					}},
+				}
+			})
+
+			It("sets them on the kpack image build", func() {
+				Eventually(func(g Gomega) {
+					kpackImage := new(buildv1alpha2.Image)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+					g.Expect(kpackImage.Spec.Build.NodeSelector).To(Equal(map[string]string{"pool": "pci"}))
+					g.Expect(kpackImage.Spec.Build.Tolerations).To(ConsistOf(corev1.Toleration{
+						Key:      "dedicated",
+						Operator: corev1.TolerationOpExists,
+						Effect:   corev1.TaintEffectNoSchedule,
+					}))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the workload stack is managed by a CFStack", func() {
+			var stackClusterBuilder *buildv1alpha2.ClusterBuilder
+
+			BeforeEach(func() {
+				stack = "cflinuxfs4"
+
+				stackClusterBuilder = &buildv1alpha2.ClusterBuilder{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: uuid.NewString(),
+					},
+					Spec: buildv1alpha2.ClusterBuilderSpec{
+						BuilderSpec: buildv1alpha2.BuilderSpec{
+							Stack: corev1.ObjectReference{Kind: "ClusterStack", Name: "cflinuxfs4-cluster-stack"},
+							Store: clusterBuilder.Spec.Store,
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, stackClusterBuilder)).To(Succeed())
+				DeferCleanup(func() {
+					Expect(adminClient.Delete(ctx, stackClusterBuilder)).To(Succeed())
+				})
+
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFStack{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: rootNamespace.Name,
+					},
+					Spec: korifiv1alpha1.CFStackSpec{
+						DisplayName:        "cflinuxfs4",
+						ClusterBuilderName: stackClusterBuilder.Name,
+					},
+				})).To(Succeed())
+			})
+
+			It("builds the image with the stack ClusterBuilder", func() {
+				Eventually(func(g Gomega) {
+					kpackImage := new(buildv1alpha2.Image)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+					g.Expect(kpackImage.Spec.Builder.Kind).To(Equal("ClusterBuilder"))
+					g.Expect(kpackImage.Spec.Builder.Name).To(Equal(stackClusterBuilder.Name))
+				}).Should(Succeed())
+			})
+
+			When("the workload stack is not managed by a CFStack", func() {
+				var defaultStackClusterBuilderName string
+
+				BeforeEach(func() {
+					stack = "unknown-stack"
+					defaultStackClusterBuilderName = uuid.NewString()
+
+					Expect(adminClient.Create(ctx, &korifiv1alpha1.CFStack{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      uuid.NewString(),
+							Namespace: rootNamespace.Name,
+						},
+						Spec: korifiv1alpha1.CFStackSpec{
+							DisplayName:        "default-stack",
+							Default:            true,
+							ClusterBuilderName: defaultStackClusterBuilderName,
+						},
+					})).To(Succeed())
+				})
+
+				It("builds the image with the default stack ClusterBuilder", func() {
+					Eventually(func(g Gomega) {
+						kpackImage := new(buildv1alpha2.Image)
+						g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: appGUID, Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+						g.Expect(kpackImage.Spec.Builder.Name).To(Equal(defaultStackClusterBuilderName))
+					}).Should(Succeed())
+				})
+			})
+
+			When("buildpacks are specified", func() {
+				BeforeEach(func() {
+					buildpacks = []string{"repo/my-buildpack"}
+					Expect(k8s.Patch(ctx, adminClient, stackClusterBuilder, func() {
+						stackClusterBuilder.Status.Order = []corev1alpha1.OrderEntry{
+							{Group: []corev1alpha1.BuildpackRef{{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "repo/my-buildpack"}}}},
+						}
+					})).To(Succeed())
+				})
+
+				It("creates the kpack Builder from the stack ClusterBuilder", func() {
+					builder := &buildv1alpha2.Builder{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      controllers.ComputeBuilderName(buildpacks),
+							Namespace: namespaceGUID,
+						},
+					}
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(builder), builder)).To(Succeed())
+						g.Expect(builder.Spec.Stack).To(Equal(stackClusterBuilder.Spec.Stack))
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("buildpacks are specified", func() {
 			BeforeEach(func() {
 				buildpacks = []string{"repo/my-buildpack"}
@@ -0,0 +1,432 @@
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/go-logr/logr"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ManagedBuildpackImagesAnnotation lists the ClusterStore sources that have been added for CFBuildpacks
+	ManagedBuildpackImagesAnnotation = "korifi.cloudfoundry.org/managed-buildpack-images"
+	// ManagedOrderEntriesAnnotation lists the ClusterBuilder order entries that have been added for
+	// CFBuildpacks, as `<buildpack id>@<buildpack version>`, so that operator defined entries are never touched
+	ManagedOrderEntriesAnnotation = "korifi.cloudfoundry.org/managed-order-entries"
+)
+
+func NewCFBuildpackReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	clusterBuilderName string,
+	rootNamespaceName string,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFBuildpack] {
+	buildpackReconciler := CFBuildpackReconciler{
+		k8sClient:          c,
+		scheme:             scheme,
+		log:                log,
+		clusterBuilderName: clusterBuilderName,
+		rootNamespaceName:  rootNamespaceName,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFBuildpack](log, c, &buildpackReconciler)
+}
+
+// CFBuildpackReconciler adds the images of the CFBuildpacks in the root
+// namespace to the ClusterStores of the ClusterBuilders staging apps on their
+// stack and maintains the order of these ClusterBuilders according to the
+// CFBuildpack positions. Buildpacks without a stack are added to every
+// ClusterBuilder
+type CFBuildpackReconciler struct {
+	k8sClient          client.Client
+	scheme             *runtime.Scheme
+	log                logr.Logger
+	clusterBuilderName string
+	rootNamespaceName  string
+}
+
+func (r *CFBuildpackReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFBuildpack{}).
+		Watches(
+			new(buildv1alpha2.ClusterBuilder),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFBuildpackRequests),
+		).
+		Watches(
+			new(buildv1alpha2.ClusterStore),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFBuildpackRequests),
+		).
+		Watches(
+			new(korifiv1alpha1.CFStack),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFBuildpackRequests),
+		).
+		WithEventFilter(predicate.NewPredicateFuncs(r.filterCFBuildpacks))
+}
+
+func (r *CFBuildpackReconciler) enqueueCFBuildpackRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	buildpacks := &korifiv1alpha1.CFBuildpackList{}
+	if err := r.k8sClient.List(ctx, buildpacks, client.InNamespace(r.rootNamespaceName)); err != nil {
+		r.log.Info("failed to list CFBuildpacks", "reason", err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, buildpack := range buildpacks.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&buildpack),
+		})
+	}
+	return requests
+}
+
+func (r *CFBuildpackReconciler) filterCFBuildpacks(object client.Object) bool {
+	if _, ok := object.(*korifiv1alpha1.CFBuildpack); !ok {
+		return true
+	}
+
+	return object.GetNamespace() == r.rootNamespaceName
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuildpacks,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfbuildpacks/status,verbs=get;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfstacks,verbs=get;list;watch
+
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterbuilders,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterstores,verbs=get;list;watch;patch
+
+func (r *CFBuildpackReconciler) ReconcileResource(ctx context.Context, cfBuildpack *korifiv1alpha1.CFBuildpack) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfBuildpack.Status.ObservedGeneration = cfBuildpack.Generation
+	log.V(1).Info("set observed generation", "generation", cfBuildpack.Status.ObservedGeneration)
+
+	if !cfBuildpack.GetDeletionTimestamp().IsZero() {
+		return r.finalize(ctx, log, cfBuildpack)
+	}
+
+	if controllerutil.AddFinalizer(cfBuildpack, korifiv1alpha1.CFBuildpackFinalizerName) {
+		log.V(1).Info("added finalizer")
+	}
+
+	if cfBuildpack.Spec.Image == "" {
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("AwaitingUpload").
+			WithMessage("The buildpack bits have not been uploaded yet").
+			WithNoRequeue()
+	}
+
+	builders, err := r.syncClusterStores(ctx, cfBuildpack)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	for _, builder := range builders {
+		if !builder.stages(cfBuildpack) {
+			continue
+		}
+
+		storeBuildpack, found := findStoreBuildpack(builder.clusterStore, cfBuildpack.Spec.Image)
+		if !found {
+			storeReadyCondition := builder.clusterStore.Status.GetCondition(corev1alpha1.ConditionReady)
+			if storeReadyCondition != nil && storeReadyCondition.Status == corev1.ConditionFalse {
+				return ctrl.Result{}, k8s.NewNotReadyError().
+					WithReason("ClusterStoreNotReady").
+					WithMessage(fmt.Sprintf("ClusterStore %q is not ready: %s", builder.clusterStore.Name, storeReadyCondition.Message)).
+					WithNoRequeue()
+			}
+
+			return ctrl.Result{}, k8s.NewNotReadyError().
+				WithReason("BuildpackNotInStore").
+				WithMessage(fmt.Sprintf("Waiting for ClusterStore %q to resolve image %q", builder.clusterStore.Name, cfBuildpack.Spec.Image)).
+				WithNoRequeue()
+		}
+
+		cfBuildpack.Status.BuildpackID = storeBuildpack.Id
+		cfBuildpack.Status.Version = storeBuildpack.Version
+	}
+
+	if err = r.syncClusterBuilderOrders(ctx, builders, cfBuildpack); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *CFBuildpackReconciler) finalize(ctx context.Context, log logr.Logger, cfBuildpack *korifiv1alpha1.CFBuildpack) (ctrl.Result, error) {
+	if !controllerutil.ContainsFinalizer(cfBuildpack, korifiv1alpha1.CFBuildpackFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	builders, err := r.syncClusterStores(ctx, cfBuildpack)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err = r.syncClusterBuilderOrders(ctx, builders, cfBuildpack); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if controllerutil.RemoveFinalizer(cfBuildpack, korifiv1alpha1.CFBuildpackFinalizerName) {
+		log.V(1).Info("finalizer removed")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// listCFBuildpacks returns the CFBuildpacks in the root namespace, replacing
+// the stored version of the buildpack being reconciled with the in-memory one
+func (r *CFBuildpackReconciler) listCFBuildpacks(ctx context.Context, current *korifiv1alpha1.CFBuildpack) ([]korifiv1alpha1.CFBuildpack, error) {
+	buildpackList := &korifiv1alpha1.CFBuildpackList{}
+	if err := r.k8sClient.List(ctx, buildpackList, client.InNamespace(r.rootNamespaceName)); err != nil {
+		return nil, fmt.Errorf("failed to list CFBuildpacks: %w", err)
+	}
+
+	buildpacks := slices.DeleteFunc(buildpackList.Items, func(b korifiv1alpha1.CFBuildpack) bool {
+		return b.Name == current.Name
+	})
+
+	return append(buildpacks, *current), nil
+}
+
+// stagingBuilder is a ClusterBuilder apps are staged with, along with its
+// ClusterStore and the stacks it stages apps on
+type stagingBuilder struct {
+	clusterBuilder *buildv1alpha2.ClusterBuilder
+	clusterStore   *buildv1alpha2.ClusterStore
+	cfStacks       []korifiv1alpha1.CFStack
+	defaultName    string
+}
+
+// stages tells whether the buildpack is used to stage apps with the builder
+func (b stagingBuilder) stages(buildpack *korifiv1alpha1.CFBuildpack) bool {
+	if buildpack.Spec.Stack == "" {
+		return true
+	}
+
+	return clusterBuilderNameForStackName(b.cfStacks, buildpack.Spec.Stack, b.defaultName) == b.clusterBuilder.Name
+}
+
+// getStagingBuilders returns the ClusterBuilders apps are staged with. Missing
+// builders (and builders with a missing store) are skipped, unless they stage
+// apps on the stack of the buildpack being reconciled (or on the default stack
+// for buildpacks without a stack)
+func (r *CFBuildpackReconciler) getStagingBuilders(ctx context.Context, current *korifiv1alpha1.CFBuildpack) ([]stagingBuilder, error) {
+	cfStacks := &korifiv1alpha1.CFStackList{}
+	if err := r.k8sClient.List(ctx, cfStacks, client.InNamespace(r.rootNamespaceName)); err != nil {
+		return nil, fmt.Errorf("failed to list CFStacks: %w", err)
+	}
+
+	builders := []stagingBuilder{}
+	for _, clusterBuilderName := range stagingClusterBuilderNames(cfStacks.Items, r.clusterBuilderName) {
+		builder := stagingBuilder{
+			clusterBuilder: &buildv1alpha2.ClusterBuilder{ObjectMeta: metav1.ObjectMeta{Name: clusterBuilderName}},
+			clusterStore:   new(buildv1alpha2.ClusterStore),
+			cfStacks:       cfStacks.Items,
+			defaultName:    r.clusterBuilderName,
+		}
+		required := current.GetDeletionTimestamp().IsZero() &&
+			clusterBuilderNameForStackName(cfStacks.Items, current.Spec.Stack, r.clusterBuilderName) == clusterBuilderName
+
+		if err := r.k8sClient.Get(ctx, types.NamespacedName{Name: clusterBuilderName}, builder.clusterBuilder); err != nil {
+			if !required {
+				continue
+			}
+
+			return nil, k8s.NewNotReadyError().
+				WithCause(err).
+				WithReason("ClusterBuilderMissing").
+				WithMessage(fmt.Sprintf("Error fetching ClusterBuilder %q", clusterBuilderName))
+		}
+
+		if err := r.k8sClient.Get(ctx, types.NamespacedName{Name: builder.clusterBuilder.Spec.Store.Name}, builder.clusterStore); err != nil {
+			if !required {
+				continue
+			}
+
+			return nil, k8s.NewNotReadyError().
+				WithCause(err).
+				WithReason("ClusterStoreMissing").
+				WithMessage(fmt.Sprintf("Error fetching ClusterStore %q", builder.clusterBuilder.Spec.Store.Name))
+		}
+
+		builders = append(builders, builder)
+	}
+
+	return builders, nil
+}
+
+// syncClusterStores sets the sources of the ClusterStores of the staging
+// builders to the images of the buildpacks staging apps with any of the
+// builders using the store
+func (r *CFBuildpackReconciler) syncClusterStores(ctx context.Context, current *korifiv1alpha1.CFBuildpack) ([]stagingBuilder, error) {
+	builders, err := r.getStagingBuilders(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	buildpacks, err := r.listCFBuildpacks(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredImagesByStore := map[string][]string{}
+	clusterStores := map[string]*buildv1alpha2.ClusterStore{}
+	for _, builder := range builders {
+		storeName := builder.clusterStore.Name
+		clusterStores[storeName] = builder.clusterStore
+
+		for _, buildpack := range buildpacks {
+			if buildpack.GetDeletionTimestamp().IsZero() && buildpack.Spec.Image != "" && builder.stages(&buildpack) {
+				desiredImagesByStore[storeName] = append(desiredImagesByStore[storeName], buildpack.Spec.Image)
+			}
+		}
+	}
+
+	for _, storeName := range slices.Sorted(maps.Keys(clusterStores)) {
+		clusterStore := clusterStores[storeName]
+		desiredImages := desiredImagesByStore[storeName]
+		slices.Sort(desiredImages)
+		desiredImages = slices.Compact(desiredImages)
+
+		err = k8s.PatchResource(ctx, r.k8sClient, clusterStore, func() {
+			managedImages := splitAnnotation(clusterStore.Annotations[ManagedBuildpackImagesAnnotation])
+			sources := slices.DeleteFunc(clusterStore.Spec.Sources, func(source corev1alpha1.ImageSource) bool {
+				return slices.Contains(managedImages, source.Image)
+			})
+			for _, image := range desiredImages {
+				sources = append(sources, corev1alpha1.ImageSource{Image: image})
+			}
+
+			clusterStore.Spec.Sources = sources
+			setAnnotation(clusterStore, ManagedBuildpackImagesAnnotation, strings.Join(desiredImages, ","))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to patch ClusterStore %q: %w", clusterStore.Name, err)
+		}
+	}
+
+	return builders, nil
+}
+
+func (r *CFBuildpackReconciler) syncClusterBuilderOrders(ctx context.Context, builders []stagingBuilder, current *korifiv1alpha1.CFBuildpack) error {
+	buildpacks, err := r.listCFBuildpacks(ctx, current)
+	if err != nil {
+		return err
+	}
+
+	orderedBuildpacks := slices.DeleteFunc(buildpacks, func(b korifiv1alpha1.CFBuildpack) bool {
+		return !b.GetDeletionTimestamp().IsZero() || !b.Spec.Enabled || b.Status.BuildpackID == ""
+	})
+	slices.SortStableFunc(orderedBuildpacks, func(b1, b2 korifiv1alpha1.CFBuildpack) int {
+		return cmp.Or(cmp.Compare(b1.Spec.Position, b2.Spec.Position), cmp.Compare(b1.Name, b2.Name))
+	})
+
+	for _, builder := range builders {
+		if err = r.syncClusterBuilderOrder(ctx, builder, orderedBuildpacks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *CFBuildpackReconciler) syncClusterBuilderOrder(ctx context.Context, builder stagingBuilder, orderedBuildpacks []korifiv1alpha1.CFBuildpack) error {
+	clusterBuilder := builder.clusterBuilder
+
+	err := k8s.PatchResource(ctx, r.k8sClient, clusterBuilder, func() {
+		managedEntries := splitAnnotation(clusterBuilder.Annotations[ManagedOrderEntriesAnnotation])
+		order := slices.DeleteFunc(clusterBuilder.Spec.Order, func(entry buildv1alpha2.BuilderOrderEntry) bool {
+			return len(entry.Group) == 1 && slices.Contains(managedEntries, orderEntryKey(entry.Group[0].BuildpackInfo))
+		})
+
+		desiredEntries := []string{}
+		for _, buildpack := range orderedBuildpacks {
+			if !builder.stages(&buildpack) {
+				continue
+			}
+
+			buildpackInfo := corev1alpha1.BuildpackInfo{
+				Id:      buildpack.Status.BuildpackID,
+				Version: buildpack.Status.Version,
+			}
+			entry := buildv1alpha2.BuilderOrderEntry{
+				Group: []buildv1alpha2.BuilderBuildpackRef{{
+					BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: buildpackInfo},
+				}},
+			}
+			order = slices.Insert(order, min(buildpack.Spec.Position-1, len(order)), entry)
+			desiredEntries = append(desiredEntries, orderEntryKey(buildpackInfo))
+		}
+
+		clusterBuilder.Spec.Order = order
+		setAnnotation(clusterBuilder, ManagedOrderEntriesAnnotation, strings.Join(desiredEntries, ","))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to patch ClusterBuilder %q: %w", clusterBuilder.Name, err)
+	}
+
+	return nil
+}
+
+func orderEntryKey(buildpackInfo corev1alpha1.BuildpackInfo) string {
+	return buildpackInfo.Id + "@" + buildpackInfo.Version
+}
+
+// findStoreBuildpack returns the top level buildpack the store resolved from
+// the image. Buildpackages contain a composite buildpack along with the
+// buildpacks it references, so the one with an order wins.
+func findStoreBuildpack(clusterStore *buildv1alpha2.ClusterStore, image string) (corev1alpha1.BuildpackStatus, bool) {
+	var (
+		result corev1alpha1.BuildpackStatus
+		found  bool
+	)
+
+	for _, storeBuildpack := range clusterStore.Status.Buildpacks {
+		if storeBuildpack.StoreImage.Image != image {
+			continue
+		}
+
+		if !found || len(storeBuildpack.Order) > 0 {
+			result = storeBuildpack
+			found = true
+		}
+	}
+
+	return result, found
+}
+
+func splitAnnotation(value string) []string {
+	if value == "" {
+		return nil
+	}
+
+	return strings.Split(value, ",")
+}
+
+func setAnnotation(obj client.Object, key, value string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
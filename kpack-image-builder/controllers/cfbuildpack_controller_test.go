@@ -0,0 +1,331 @@
+package controllers_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/kpack-image-builder/controllers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFBuildpackReconciler", func() {
+	const buildpackImage = "my.registry/custom-buildpack:1.0"
+
+	var (
+		clusterStore   *buildv1alpha2.ClusterStore
+		clusterBuilder *buildv1alpha2.ClusterBuilder
+		cfBuildpack    *korifiv1alpha1.CFBuildpack
+	)
+
+	builderOrderIDs := func(g Gomega, builder *buildv1alpha2.ClusterBuilder) []string {
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(builder), builder)).To(Succeed())
+		ids := []string{}
+		for _, entry := range builder.Spec.Order {
+			ids = append(ids, entry.Group[0].Id)
+		}
+		return ids
+	}
+
+	orderIDs := func(g Gomega) []string {
+		return builderOrderIDs(g, clusterBuilder)
+	}
+
+	createClusterStore := func() *buildv1alpha2.ClusterStore {
+		store := &buildv1alpha2.ClusterStore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: uuid.NewString(),
+			},
+			Spec: buildv1alpha2.ClusterStoreSpec{
+				Sources: []corev1alpha1.ImageSource{{Image: "paketo/go"}},
+			},
+		}
+		Expect(adminClient.Create(ctx, store)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, store, func() {
+			store.Status.Buildpacks = []corev1alpha1.BuildpackStatus{
+				{
+					BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "paketo/go", Version: "1.0.0"},
+					StoreImage:    corev1alpha1.ImageSource{Image: "paketo/go"},
+				},
+				{
+					BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "custom/dependency", Version: "0.1.0"},
+					StoreImage:    corev1alpha1.ImageSource{Image: buildpackImage},
+				},
+				{
+					BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "custom/buildpack", Version: "1.0.0"},
+					StoreImage:    corev1alpha1.ImageSource{Image: buildpackImage},
+					Order:         []corev1alpha1.OrderEntry{{Group: []corev1alpha1.BuildpackRef{{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "custom/dependency"}}}}},
+				},
+			}
+		})).To(Succeed())
+
+		return store
+	}
+
+	BeforeEach(func() {
+		clusterStore = createClusterStore()
+
+		clusterBuilder = &buildv1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: clusterBuilderName,
+			},
+			Spec: buildv1alpha2.ClusterBuilderSpec{
+				BuilderSpec: buildv1alpha2.BuilderSpec{
+					Store: corev1.ObjectReference{Kind: "ClusterStore", Name: clusterStore.Name},
+					Order: []buildv1alpha2.BuilderOrderEntry{
+						{Group: []buildv1alpha2.BuilderBuildpackRef{{BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "paketo/go"}}}}},
+						{Group: []buildv1alpha2.BuilderBuildpackRef{{BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "paketo/java"}}}}},
+					},
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, clusterBuilder)).To(Succeed())
+
+		cfBuildpack = &korifiv1alpha1.CFBuildpack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace.Name,
+			},
+			Spec: korifiv1alpha1.CFBuildpackSpec{
+				DisplayName: "custom",
+				Position:    2,
+				Enabled:     true,
+				Image:       buildpackImage,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfBuildpack)).To(Succeed())
+	})
+
+	It("adds the buildpack image to the cluster store", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterStore), clusterStore)).To(Succeed())
+			g.Expect(clusterStore.Spec.Sources).To(ConsistOf(
+				corev1alpha1.ImageSource{Image: "paketo/go"},
+				corev1alpha1.ImageSource{Image: buildpackImage},
+			))
+		}).Should(Succeed())
+	})
+
+	It("resolves the buildpack id from the store", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+			g.Expect(cfBuildpack.Status.BuildpackID).To(Equal("custom/buildpack"))
+			g.Expect(cfBuildpack.Status.Version).To(Equal("1.0.0"))
+			g.Expect(meta.IsStatusConditionTrue(cfBuildpack.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	It("inserts the buildpack in the builder order at its position", func() {
+		Eventually(orderIDs).Should(Equal([]string{"paketo/go", "custom/buildpack", "paketo/java"}))
+		Expect(clusterBuilder.Spec.Order[1].Group[0]).To(Equal(buildv1alpha2.BuilderBuildpackRef{
+			BuildpackRef: corev1alpha1.BuildpackRef{
+				BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "custom/buildpack", Version: "1.0.0"},
+			},
+		}))
+		Expect(clusterBuilder.Annotations).To(HaveKeyWithValue(controllers.ManagedOrderEntriesAnnotation, "custom/buildpack@1.0.0"))
+	})
+
+	When("a stack is staged with a ClusterBuilder of its own", func() {
+		var (
+			stackClusterStore   *buildv1alpha2.ClusterStore
+			stackClusterBuilder *buildv1alpha2.ClusterBuilder
+		)
+
+		BeforeEach(func() {
+			stackClusterStore = createClusterStore()
+			stackClusterBuilder = &buildv1alpha2.ClusterBuilder{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: uuid.NewString(),
+				},
+				Spec: buildv1alpha2.ClusterBuilderSpec{
+					BuilderSpec: buildv1alpha2.BuilderSpec{
+						Store: corev1.ObjectReference{Kind: "ClusterStore", Name: stackClusterStore.Name},
+						Order: []buildv1alpha2.BuilderOrderEntry{
+							{Group: []buildv1alpha2.BuilderBuildpackRef{{BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "paketo/go"}}}}},
+						},
+					},
+				},
+			}
+			Expect(adminClient.Create(ctx, stackClusterBuilder)).To(Succeed())
+
+			Expect(adminClient.Create(ctx, &korifiv1alpha1.CFStack{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      uuid.NewString(),
+					Namespace: rootNamespace.Name,
+				},
+				Spec: korifiv1alpha1.CFStackSpec{
+					DisplayName:        "cflinuxfs4",
+					ClusterBuilderName: stackClusterBuilder.Name,
+				},
+			})).To(Succeed())
+		})
+
+		It("adds the buildpack without a stack to every ClusterBuilder", func() {
+			Eventually(orderIDs).Should(Equal([]string{"paketo/go", "custom/buildpack", "paketo/java"}))
+			Eventually(func(g Gomega) []string {
+				return builderOrderIDs(g, stackClusterBuilder)
+			}).Should(Equal([]string{"paketo/go", "custom/buildpack"}))
+		})
+
+		When("the buildpack is restricted to the stack", func() {
+			BeforeEach(func() {
+				cfBuildpack.Spec.Stack = "cflinuxfs4"
+			})
+
+			It("only adds the buildpack to the ClusterBuilder of the stack", func() {
+				Eventually(func(g Gomega) []string {
+					return builderOrderIDs(g, stackClusterBuilder)
+				}).Should(Equal([]string{"paketo/go", "custom/buildpack"}))
+				Consistently(orderIDs).Should(Equal([]string{"paketo/go", "paketo/java"}))
+
+				Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(stackClusterStore), stackClusterStore)).To(Succeed())
+				Expect(stackClusterStore.Spec.Sources).To(ContainElement(corev1alpha1.ImageSource{Image: buildpackImage}))
+				Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterStore), clusterStore)).To(Succeed())
+				Expect(clusterStore.Spec.Sources).NotTo(ContainElement(corev1alpha1.ImageSource{Image: buildpackImage}))
+			})
+		})
+
+		When("the buildpack is restricted to a stack that is not managed by a CFStack", func() {
+			BeforeEach(func() {
+				cfBuildpack.Spec.Stack = "unknown-stack"
+			})
+
+			It("only adds the buildpack to the default ClusterBuilder", func() {
+				Eventually(orderIDs).Should(Equal([]string{"paketo/go", "custom/buildpack", "paketo/java"}))
+				Consistently(func(g Gomega) []string {
+					return builderOrderIDs(g, stackClusterBuilder)
+				}).Should(Equal([]string{"paketo/go"}))
+			})
+		})
+	})
+
+	When("the operator order already contains an entry with the buildpack id", func() {
+		BeforeEach(func() {
+			clusterBuilder.Spec.Order = append(clusterBuilder.Spec.Order, buildv1alpha2.BuilderOrderEntry{
+				Group: []buildv1alpha2.BuilderBuildpackRef{{BuildpackRef: corev1alpha1.BuildpackRef{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: "custom/buildpack"}}}},
+			})
+			Expect(adminClient.Update(ctx, clusterBuilder)).To(Succeed())
+		})
+
+		It("keeps the operator entry", func() {
+			Eventually(orderIDs).Should(Equal([]string{"paketo/go", "custom/buildpack", "paketo/java", "custom/buildpack"}))
+			Consistently(orderIDs).Should(Equal([]string{"paketo/go", "custom/buildpack", "paketo/java", "custom/buildpack"}))
+		})
+
+		When("the buildpack is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(orderIDs).Should(HaveLen(4))
+				Expect(adminClient.Delete(ctx, cfBuildpack)).To(Succeed())
+			})
+
+			It("only removes the managed entry", func() {
+				Eventually(orderIDs).Should(Equal([]string{"paketo/go", "paketo/java", "custom/buildpack"}))
+			})
+		})
+	})
+
+	It("sets the finalizer", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+			g.Expect(cfBuildpack.Finalizers).To(ContainElement(korifiv1alpha1.CFBuildpackFinalizerName))
+		}).Should(Succeed())
+	})
+
+	When("the position is beyond the end of the order", func() {
+		BeforeEach(func() {
+			cfBuildpack.Spec.Position = 42
+		})
+
+		It("appends the buildpack to the order", func() {
+			Eventually(orderIDs).Should(Equal([]string{"paketo/go", "paketo/java", "custom/buildpack"}))
+		})
+	})
+
+	When("the buildpack position changes", func() {
+		JustBeforeEach(func() {
+			Eventually(orderIDs).Should(ContainElement("custom/buildpack"))
+			Expect(k8s.PatchResource(ctx, adminClient, cfBuildpack, func() {
+				cfBuildpack.Spec.Position = 1
+			})).To(Succeed())
+		})
+
+		It("moves the buildpack in the order", func() {
+			Eventually(orderIDs).Should(Equal([]string{"custom/buildpack", "paketo/go", "paketo/java"}))
+		})
+	})
+
+	When("the buildpack is disabled", func() {
+		BeforeEach(func() {
+			cfBuildpack.Spec.Enabled = false
+		})
+
+		It("keeps the image in the store but not in the order", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterStore), clusterStore)).To(Succeed())
+				g.Expect(clusterStore.Spec.Sources).To(ContainElement(corev1alpha1.ImageSource{Image: buildpackImage}))
+			}).Should(Succeed())
+			Consistently(orderIDs).ShouldNot(ContainElement("custom/buildpack"))
+		})
+	})
+
+	When("the buildpack bits have not been uploaded", func() {
+		BeforeEach(func() {
+			cfBuildpack.Spec.Image = ""
+		})
+
+		It("is not ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(cfBuildpack.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+				g.Expect(readyCondition).NotTo(BeNil())
+				g.Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(readyCondition.Reason).To(Equal("AwaitingUpload"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the image is not resolved by the store", func() {
+		BeforeEach(func() {
+			cfBuildpack.Spec.Image = "my.registry/unknown"
+		})
+
+		It("is not ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(cfBuildpack.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+				g.Expect(readyCondition).NotTo(BeNil())
+				g.Expect(readyCondition.Reason).To(Equal("BuildpackNotInStore"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the buildpack is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(orderIDs).Should(ContainElement("custom/buildpack"))
+			Expect(adminClient.Delete(ctx, cfBuildpack)).To(Succeed())
+		})
+
+		It("removes the buildpack from the store and the order", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfBuildpack), cfBuildpack)
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+
+			Expect(orderIDs(Default)).To(Equal([]string{"paketo/go", "paketo/java"}))
+			Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(clusterStore), clusterStore)).To(Succeed())
+			Expect(clusterStore.Spec.Sources).To(ConsistOf(corev1alpha1.ImageSource{Image: "paketo/go"}))
+			Expect(clusterStore.Annotations).To(HaveKeyWithValue(controllers.ManagedBuildpackImagesAnnotation, ""))
+		})
+	})
+})
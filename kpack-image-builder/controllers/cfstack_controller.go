@@ -0,0 +1,197 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/go-logr/logr"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func NewCFStackReconciler(
+	c client.Client,
+	scheme *runtime.Scheme,
+	log logr.Logger,
+	clusterBuilderName string,
+	rootNamespaceName string,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFStack] {
+	stackReconciler := CFStackReconciler{
+		k8sClient:          c,
+		scheme:             scheme,
+		log:                log,
+		clusterBuilderName: clusterBuilderName,
+		rootNamespaceName:  rootNamespaceName,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFStack](log, c, &stackReconciler)
+}
+
+// CFStackReconciler resolves the ClusterBuilder and the ClusterStack images
+// backing the CFStacks in the root namespace
+type CFStackReconciler struct {
+	k8sClient          client.Client
+	scheme             *runtime.Scheme
+	log                logr.Logger
+	clusterBuilderName string
+	rootNamespaceName  string
+}
+
+func (r *CFStackReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFStack{}).
+		Watches(
+			new(buildv1alpha2.ClusterBuilder),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFStackRequests),
+		).
+		Watches(
+			new(buildv1alpha2.ClusterStack),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFStackRequests),
+		).
+		WithEventFilter(predicate.NewPredicateFuncs(r.filterCFStacks))
+}
+
+func (r *CFStackReconciler) enqueueCFStackRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	stacks := &korifiv1alpha1.CFStackList{}
+	if err := r.k8sClient.List(ctx, stacks, client.InNamespace(r.rootNamespaceName)); err != nil {
+		r.log.Info("failed to list CFStacks", "reason", err)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, stack := range stacks.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: client.ObjectKeyFromObject(&stack),
+		})
+	}
+	return requests
+}
+
+func (r *CFStackReconciler) filterCFStacks(object client.Object) bool {
+	if _, ok := object.(*korifiv1alpha1.CFStack); !ok {
+		return true
+	}
+
+	return object.GetNamespace() == r.rootNamespaceName
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfstacks,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfstacks/status,verbs=get;patch
+
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterbuilders,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterstacks,verbs=get;list;watch
+
+func (r *CFStackReconciler) ReconcileResource(ctx context.Context, cfStack *korifiv1alpha1.CFStack) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfStack.Status.ObservedGeneration = cfStack.Generation
+	log.V(1).Info("set observed generation", "generation", cfStack.Status.ObservedGeneration)
+
+	if !cfStack.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	cfStack.Status.ClusterBuilderName = ClusterBuilderNameForStack(*cfStack, r.clusterBuilderName)
+
+	clusterBuilder := new(buildv1alpha2.ClusterBuilder)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfStack.Status.ClusterBuilderName}, clusterBuilder)
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return ctrl.Result{}, k8s.NewNotReadyError().
+				WithCause(err).
+				WithReason("ClusterBuilderMissing").
+				WithMessage(fmt.Sprintf("ClusterBuilder %q not found", cfStack.Status.ClusterBuilderName))
+		}
+		return ctrl.Result{}, err
+	}
+
+	if clusterBuilder.Spec.Stack.Name != "" {
+		clusterStack := new(buildv1alpha2.ClusterStack)
+		if err = r.k8sClient.Get(ctx, types.NamespacedName{Name: clusterBuilder.Spec.Stack.Name}, clusterStack); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return ctrl.Result{}, k8s.NewNotReadyError().
+					WithCause(err).
+					WithReason("ClusterStackMissing").
+					WithMessage(fmt.Sprintf("ClusterStack %q not found", clusterBuilder.Spec.Stack.Name))
+			}
+			return ctrl.Result{}, err
+		}
+
+		cfStack.Status.BuildRootfsImage = clusterStack.Status.BuildImage.LatestImage
+		cfStack.Status.RunRootfsImage = clusterStack.Status.RunImage.LatestImage
+	}
+
+	builderReadyCondition := clusterBuilder.Status.GetCondition(corev1alpha1.ConditionReady)
+	if builderReadyCondition == nil || builderReadyCondition.Status != corev1.ConditionTrue {
+		var msg string
+		if builderReadyCondition != nil {
+			msg = builderReadyCondition.Message
+		}
+
+		return ctrl.Result{}, k8s.NewNotReadyError().
+			WithReason("ClusterBuilderNotReady").
+			WithMessage(fmt.Sprintf("ClusterBuilder %q is not ready: %s", clusterBuilder.Name, msg)).
+			WithNoRequeue()
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// ClusterBuilderNameForStack returns the ClusterBuilder that stages apps on
+// the stack, falling back to the default ClusterBuilder
+func ClusterBuilderNameForStack(cfStack korifiv1alpha1.CFStack, defaultClusterBuilderName string) string {
+	if cfStack.Spec.ClusterBuilderName != "" {
+		return cfStack.Spec.ClusterBuilderName
+	}
+
+	return defaultClusterBuilderName
+}
+
+// clusterBuilderNameForStackName returns the ClusterBuilder of the CFStack
+// with the given name. Stacks that are not managed by a CFStack are staged
+// with the builder of the default CFStack, if any.
+func clusterBuilderNameForStackName(cfStacks []korifiv1alpha1.CFStack, stackName string, defaultClusterBuilderName string) string {
+	clusterBuilderName := defaultClusterBuilderName
+	for _, cfStack := range cfStacks {
+		if cfStack.Spec.DisplayName == stackName {
+			return ClusterBuilderNameForStack(cfStack, defaultClusterBuilderName)
+		}
+
+		if cfStack.Spec.Default {
+			clusterBuilderName = ClusterBuilderNameForStack(cfStack, defaultClusterBuilderName)
+		}
+	}
+
+	return clusterBuilderName
+}
+
+// stagingClusterBuilderNames returns the names of all the ClusterBuilders apps
+// can be staged with, i.e. the builders of the CFStacks and, unless one of the
+// CFStacks is the default, the default ClusterBuilder
+func stagingClusterBuilderNames(cfStacks []korifiv1alpha1.CFStack, defaultClusterBuilderName string) []string {
+	names := []string{}
+	hasDefaultStack := false
+	for _, cfStack := range cfStacks {
+		names = append(names, ClusterBuilderNameForStack(cfStack, defaultClusterBuilderName))
+		hasDefaultStack = hasDefaultStack || cfStack.Spec.Default
+	}
+
+	if !hasDefaultStack {
+		names = append(names, defaultClusterBuilderName)
+	}
+
+	slices.Sort(names)
+	return slices.Compact(names)
+}
@@ -0,0 +1,141 @@
+package controllers_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"github.com/google/uuid"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
+	corev1alpha1 "github.com/pivotal/kpack/pkg/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFStackReconciler", func() {
+	var (
+		clusterStack   *buildv1alpha2.ClusterStack
+		clusterBuilder *buildv1alpha2.ClusterBuilder
+		cfStack        *korifiv1alpha1.CFStack
+	)
+
+	BeforeEach(func() {
+		clusterStack = &buildv1alpha2.ClusterStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: uuid.NewString(),
+			},
+			Spec: buildv1alpha2.ClusterStackSpec{
+				Id:         "io.buildpacks.stacks.jammy",
+				BuildImage: buildv1alpha2.ClusterStackSpecImage{Image: "my.registry/build:jammy"},
+				RunImage:   buildv1alpha2.ClusterStackSpecImage{Image: "my.registry/run:jammy"},
+			},
+		}
+		Expect(adminClient.Create(ctx, clusterStack)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, clusterStack, func() {
+			clusterStack.Status.BuildImage.LatestImage = "my.registry/build@sha256:build"
+			clusterStack.Status.RunImage.LatestImage = "my.registry/run@sha256:run"
+		})).To(Succeed())
+
+		clusterBuilder = &buildv1alpha2.ClusterBuilder{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: uuid.NewString(),
+			},
+			Spec: buildv1alpha2.ClusterBuilderSpec{
+				BuilderSpec: buildv1alpha2.BuilderSpec{
+					Stack: corev1.ObjectReference{Kind: "ClusterStack", Name: clusterStack.Name},
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, clusterBuilder)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, clusterBuilder, func() {
+			clusterBuilder.Status.Conditions = corev1alpha1.Conditions{{
+				Type:               corev1alpha1.ConditionReady,
+				Status:             corev1.ConditionTrue,
+				LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+			}}
+		})).To(Succeed())
+
+		cfStack = &korifiv1alpha1.CFStack{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: rootNamespace.Name,
+			},
+			Spec: korifiv1alpha1.CFStackSpec{
+				DisplayName:        "cflinuxfs4",
+				ClusterBuilderName: clusterBuilder.Name,
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfStack)).To(Succeed())
+	})
+
+	It("resolves the stack images from the ClusterBuilder stack", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(Succeed())
+			g.Expect(cfStack.Status.ClusterBuilderName).To(Equal(clusterBuilder.Name))
+			g.Expect(cfStack.Status.BuildRootfsImage).To(Equal("my.registry/build@sha256:build"))
+			g.Expect(cfStack.Status.RunRootfsImage).To(Equal("my.registry/run@sha256:run"))
+			g.Expect(meta.IsStatusConditionTrue(cfStack.Status.Conditions, korifiv1alpha1.StatusConditionReady)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	It("defaults the stack state to active", func() {
+		Expect(cfStack.Spec.State).To(Equal(korifiv1alpha1.StackStateActive))
+	})
+
+	When("the stack does not specify a ClusterBuilder", func() {
+		BeforeEach(func() {
+			cfStack.Spec.ClusterBuilderName = ""
+		})
+
+		It("uses the default ClusterBuilder", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(Succeed())
+				g.Expect(cfStack.Status.ClusterBuilderName).To(Equal(clusterBuilderName))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the ClusterBuilder does not exist", func() {
+		BeforeEach(func() {
+			cfStack.Spec.ClusterBuilderName = "i-do-not-exist"
+		})
+
+		It("is not ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(cfStack.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+				g.Expect(readyCondition).NotTo(BeNil())
+				g.Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(readyCondition.Reason).To(Equal("ClusterBuilderMissing"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the ClusterBuilder is not ready", func() {
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, adminClient, clusterBuilder, func() {
+				clusterBuilder.Status.Conditions = corev1alpha1.Conditions{{
+					Type:               corev1alpha1.ConditionReady,
+					Status:             corev1.ConditionFalse,
+					Message:            "not-ready",
+					LastTransitionTime: corev1alpha1.VolatileTime{Inner: metav1.Now()},
+				}}
+			})).To(Succeed())
+		})
+
+		It("is not ready", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfStack), cfStack)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(cfStack.Status.Conditions, korifiv1alpha1.StatusConditionReady)
+				g.Expect(readyCondition).NotTo(BeNil())
+				g.Expect(readyCondition.Reason).To(Equal("ClusterBuilderNotReady"))
+			}).Should(Succeed())
+		})
+	})
+})
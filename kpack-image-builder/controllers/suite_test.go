@@ -174,6 +174,26 @@ var _ = BeforeEach(func() {
 		).SetupWithManager(k8sManager),
 	).To(Succeed())
 
+	Expect(
+		controllers.NewCFBuildpackReconciler(
+			k8sManager.GetClient(),
+			k8sManager.GetScheme(),
+			ctrl.Log.WithName("kpack-image-builder").WithName("CFBuildpack"),
+			clusterBuilderName,
+			controllerConfig.CFRootNamespace,
+		).SetupWithManager(k8sManager),
+	).To(Succeed())
+
+	Expect(
+		controllers.NewCFStackReconciler(
+			k8sManager.GetClient(),
+			k8sManager.GetScheme(),
+			ctrl.Log.WithName("kpack-image-builder").WithName("CFStack"),
+			clusterBuilderName,
+			controllerConfig.CFRootNamespace,
+		).SetupWithManager(k8sManager),
+	).To(Succeed())
+
 	fakeImageDeleter = new(fake.ImageDeleter)
 	kpackBuildReconciler := controllers.NewKpackBuildController(
 		k8sManager.GetClient(),
@@ -140,6 +140,26 @@ func setupControllers(mgr manager.Manager, restConf *rest.Config, configPath str
 		return fmt.Errorf("unable to create BuilderInfo controller: %v", err)
 	}
 
+	if err = controllers.NewCFBuildpackReconciler(
+		controllersClient,
+		mgr.GetScheme(),
+		controllersLog,
+		controllerConfig.ClusterBuilderName,
+		controllerConfig.CFRootNamespace,
+	).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create CFBuildpack controller: %v", err)
+	}
+
+	if err = controllers.NewCFStackReconciler(
+		controllersClient,
+		mgr.GetScheme(),
+		controllersLog,
+		controllerConfig.ClusterBuilderName,
+		controllerConfig.CFRootNamespace,
+	).SetupWithManager(mgr); err != nil {
+		return fmt.Errorf("unable to create CFStack controller: %v", err)
+	}
+
 	if err = controllers.NewKpackBuildController(
 		controllersClient,
 		controllersLog,
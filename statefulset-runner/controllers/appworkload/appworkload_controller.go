@@ -49,6 +49,8 @@ const (
 	EnvCFInstanceInternalIP = "CF_INSTANCE_INTERNAL_IP"
 	EnvCFInstanceIndex      = "CF_INSTANCE_INDEX"
 	EnvServiceBindingRoot   = "SERVICE_BINDING_ROOT"
+	EnvCFInstanceCert       = "CF_INSTANCE_CERT"
+	EnvCFInstanceKey        = "CF_INSTANCE_KEY"
 
 	// StatefulSet Keys
 	AnnotationVersion     = "korifi.cloudfoundry.org/version"
@@ -61,6 +63,7 @@ const (
 	LabelProcessType     = "korifi.cloudfoundry.org/process-type"
 
 	ApplicationContainerName = "application"
+	InstanceIdentityVolume   = "cf-instance-credentials"
 	ServiceAccountName       = "korifi-app"
 
 	LivenessFailureThreshold  = 4
@@ -8,6 +8,7 @@ import (
 	"regexp"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -102,6 +103,11 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 			Value: bindingRootPath,
 		})
 	}
+
+	if appWorkload.Spec.InstanceIdentitySecretName != "" {
+		envs = append(envs, instanceIdentityEnvs()...)
+	}
+
 	// Sort env vars to guarantee idempotency
 	sort.SliceStable(envs, func(i, j int) bool {
 		return envs[i].Name < envs[j].Name
@@ -126,9 +132,10 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 					Type: corev1.SeccompProfileTypeRuntimeDefault,
 				},
 			},
-			Resources:     appWorkload.Spec.Resources,
-			StartupProbe:  appWorkload.Spec.StartupProbe,
-			LivenessProbe: appWorkload.Spec.LivenessProbe,
+			Resources:      appWorkload.Spec.Resources,
+			StartupProbe:   appWorkload.Spec.StartupProbe,
+			LivenessProbe:  appWorkload.Spec.LivenessProbe,
+			ReadinessProbe: appWorkload.Spec.ReadinessProbe,
 			VolumeMounts: slices.Collect(it.Map(slices.Values(appWorkload.Spec.Services), func(s korifiv1alpha1.ServiceBinding) corev1.VolumeMount {
 				return corev1.VolumeMount{
 					Name:      s.Name,
@@ -180,6 +187,44 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 	}
 
 	statefulSet.Spec.Template.Spec.AutomountServiceAccountToken = tools.PtrTo(false)
+	statefulSet.Spec.Template.Spec.TerminationGracePeriodSeconds = appWorkload.Spec.TerminationGracePeriodSeconds
+
+	for _, service := range appWorkload.Spec.Services {
+		for _, volumeMount := range service.VolumeMounts {
+			statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+				Name:      volumeMount.Name,
+				ReadOnly:  volumeMount.ReadOnly,
+				MountPath: volumeMount.ContainerDir,
+			})
+			statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+				Name: volumeMount.Name,
+				VolumeSource: corev1.VolumeSource{
+					PersistentVolumeClaim: volumeMount.PersistentVolumeClaim,
+					CSI:                   volumeMount.CSI,
+				},
+			})
+		}
+	}
+
+	if appWorkload.Spec.InstanceIdentitySecretName != "" {
+		// pods share the statefulset template, the instance identity webhook
+		// points the volume at the secret of the pod instance on pod creation
+		statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts = append(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts, corev1.VolumeMount{
+			Name:      InstanceIdentityVolume,
+			ReadOnly:  true,
+			MountPath: korifiv1alpha1.InstanceIdentityMountPath,
+		})
+		statefulSet.Spec.Template.Spec.Volumes = append(statefulSet.Spec.Template.Spec.Volumes, corev1.Volume{
+			Name: InstanceIdentityVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  appWorkload.Spec.InstanceIdentitySecretName,
+					DefaultMode: tools.PtrTo[int32](0o644),
+				},
+			},
+		})
+	}
+
 	statefulSet.Spec.Selector = statefulSetLabelSelector(appWorkload)
 
 	statefulSet.Spec.Template.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
@@ -203,6 +248,12 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		},
 	}
 
+	if scheduling := appWorkload.Spec.Scheduling; scheduling != nil {
+		statefulSet.Spec.Template.Spec.NodeSelector = scheduling.NodeSelector
+		statefulSet.Spec.Template.Spec.Tolerations = scheduling.Tolerations
+		statefulSet.Spec.Template.Spec.RuntimeClassName = scheduling.RuntimeClassName
+	}
+
 	labels := map[string]string{
 		controllers.LabelGUID: appWorkload.Spec.GUID,
 		LabelProcessType:      appWorkload.Spec.ProcessType,
@@ -220,12 +271,29 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 		AnnotationProcessGUID: fmt.Sprintf("%s-%s", appWorkload.Spec.GUID, appWorkload.Spec.Version),
 	}
 
+	if limit := appWorkload.Spec.LogRateLimitBytesPerSecond; limit != nil && *limit >= 0 {
+		annotations[korifiv1alpha1.LogRateLimitAnnotation] = strconv.FormatInt(*limit, 10)
+	}
+
 	statefulSet.Annotations = annotations
 	statefulSet.Spec.Template.Annotations = annotations
 
 	return statefulSet, nil
 }
 
+func instanceIdentityEnvs() []corev1.EnvVar {
+	return []corev1.EnvVar{
+		{
+			Name:  EnvCFInstanceCert,
+			Value: filepath.Join(korifiv1alpha1.InstanceIdentityMountPath, korifiv1alpha1.InstanceIdentityCertKey),
+		},
+		{
+			Name:  EnvCFInstanceKey,
+			Value: filepath.Join(korifiv1alpha1.InstanceIdentityMountPath, korifiv1alpha1.InstanceIdentityKeyKey),
+		},
+	}
+}
+
 func sanitizeName(name, fallback string) string {
 	const sanitizedNameMaxLen = 40
 	return sanitizeNameWithMaxStringLen(name, fallback, sanitizedNameMaxLen)
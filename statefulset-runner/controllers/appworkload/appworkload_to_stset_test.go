@@ -70,6 +70,16 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 					PeriodSeconds:    30,
 					FailureThreshold: 1,
 				},
+				ReadinessProbe: &corev1.Probe{
+					ProbeHandler: corev1.ProbeHandler{
+						HTTPGet: &corev1.HTTPGetAction{
+							Path: "/ready",
+							Port: intstr.IntOrString{Type: intstr.Int, IntVal: int32(8080)},
+						},
+					},
+					PeriodSeconds:    10,
+					FailureThreshold: 3,
+				},
 				Ports:      []int32{8888, 9999},
 				Instances:  1,
 				RunnerName: "statefulset-runner",
@@ -183,6 +193,10 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		Expect(statefulSet.Spec.Template.Spec.Containers[0].LivenessProbe).To(Equal(appWorkload.Spec.LivenessProbe))
 	})
 
+	It("should set the readiness probe", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].ReadinessProbe).To(Equal(appWorkload.Spec.ReadinessProbe))
+	})
+
 	It("should not automount service account token", func() {
 		Expect(statefulSet.Spec.Template.Spec.AutomountServiceAccountToken).To(Equal(tools.PtrTo(false)))
 	})
@@ -404,6 +418,169 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 				},
 			))
 		})
+		When("the services provide volume mounts", func() {
+			BeforeEach(func() {
+				appWorkload.Spec.Services[0].VolumeMounts = []korifiv1alpha1.VolumeMount{
+					{
+						Name:         "nfs-volume",
+						ContainerDir: "/data/nfs",
+						CSI: &corev1.CSIVolumeSource{
+							Driver:           "nfs.csi.k8s.io",
+							VolumeAttributes: map[string]string{"server": "nfs.example.com"},
+						},
+					},
+					{
+						Name:         "pvc-volume",
+						ContainerDir: "/data/pvc",
+						ReadOnly:     true,
+						PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+							ClaimName: "my-claim",
+							ReadOnly:  true,
+						},
+					},
+				}
+			})
+
+			It("adds the service volumes", func() {
+				Expect(statefulSet.Spec.Template.Spec.Volumes).To(ContainElements(
+					corev1.Volume{
+						Name: "nfs-volume",
+						VolumeSource: corev1.VolumeSource{
+							CSI: &corev1.CSIVolumeSource{
+								Driver:           "nfs.csi.k8s.io",
+								VolumeAttributes: map[string]string{"server": "nfs.example.com"},
+							},
+						},
+					},
+					corev1.Volume{
+						Name: "pvc-volume",
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+								ClaimName: "my-claim",
+								ReadOnly:  true,
+							},
+						},
+					},
+				))
+			})
+
+			It("mounts the service volumes at their container dir", func() {
+				Expect(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElements(
+					corev1.VolumeMount{
+						Name:      "nfs-volume",
+						MountPath: "/data/nfs",
+					},
+					corev1.VolumeMount{
+						Name:      "pvc-volume",
+						ReadOnly:  true,
+						MountPath: "/data/pvc",
+					},
+				))
+			})
+		})
+	})
+
+	It("should not constrain the pod scheduling", func() {
+		Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(BeEmpty())
+		Expect(statefulSet.Spec.Template.Spec.Tolerations).To(BeEmpty())
+		Expect(statefulSet.Spec.Template.Spec.RuntimeClassName).To(BeNil())
+	})
+
+	When("the app workload has scheduling constraints", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Scheduling = &korifiv1alpha1.WorkloadScheduling{
+				NodeSelector: map[string]string{"pool": "pci"},
+				Tolerations: []corev1.Toleration{{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "pci",
+					Effect:   corev1.TaintEffectNoSchedule,
+				}},
+				RuntimeClassName: tools.PtrTo("gvisor"),
+			}
+		})
+
+		It("sets them on the pod template", func() {
+			podSpec := statefulSet.Spec.Template.Spec
+			Expect(podSpec.NodeSelector).To(Equal(map[string]string{"pool": "pci"}))
+			Expect(podSpec.Tolerations).To(ConsistOf(corev1.Toleration{
+				Key:      "dedicated",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "pci",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}))
+			Expect(podSpec.RuntimeClassName).To(PointTo(Equal("gvisor")))
+		})
+	})
+
+	It("should not mount instance identity credentials", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers[0].Env).NotTo(ContainElement(HaveField("Name", "CF_INSTANCE_CERT")))
+		Expect(statefulSet.Spec.Template.Spec.Volumes).NotTo(ContainElement(HaveField("Name", "cf-instance-credentials")))
+	})
+
+	When("the app workload has instance identity credentials", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.InstanceIdentitySecretName = "identity-secret"
+		})
+
+		It("mounts the credentials secret", func() {
+			Expect(statefulSet.Spec.Template.Spec.Volumes).To(ContainElement(corev1.Volume{
+				Name: "cf-instance-credentials",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  "identity-secret",
+						DefaultMode: tools.PtrTo[int32](0o644),
+					},
+				},
+			}))
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+				Name:      "cf-instance-credentials",
+				ReadOnly:  true,
+				MountPath: "/etc/cf-instance-credentials",
+			}))
+		})
+
+		It("points the instance credentials env vars at the files of the instance", func() {
+			Expect(statefulSet.Spec.Template.Spec.Containers[0].Env).To(ContainElements(
+				corev1.EnvVar{Name: "CF_INSTANCE_CERT", Value: "/etc/cf-instance-credentials/tls.crt"},
+				corev1.EnvVar{Name: "CF_INSTANCE_KEY", Value: "/etc/cf-instance-credentials/tls.key"},
+			))
+		})
+	})
+
+	It("leaves the termination grace period and log rate limit unset", func() {
+		Expect(statefulSet.Spec.Template.Spec.TerminationGracePeriodSeconds).To(BeNil())
+		Expect(statefulSet.Spec.Template.Annotations).NotTo(HaveKey("korifi.cloudfoundry.org/log-rate-limit-bytes-per-second"))
+	})
+
+	When("the app workload has a termination grace period", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.TerminationGracePeriodSeconds = tools.PtrTo[int64](15)
+		})
+
+		It("sets it on the pod spec", func() {
+			Expect(statefulSet.Spec.Template.Spec.TerminationGracePeriodSeconds).To(PointTo(BeEquivalentTo(15)))
+		})
+	})
+
+	When("the app workload has a log rate limit", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.LogRateLimitBytesPerSecond = tools.PtrTo[int64](1024)
+		})
+
+		It("annotates the pods with it", func() {
+			Expect(statefulSet.Spec.Template.Annotations).To(HaveKeyWithValue("korifi.cloudfoundry.org/log-rate-limit-bytes-per-second", "1024"))
+		})
+
+		When("the log rate limit is unlimited", func() {
+			BeforeEach(func() {
+				appWorkload.Spec.LogRateLimitBytesPerSecond = tools.PtrTo[int64](-1)
+			})
+
+			It("does not annotate the pods", func() {
+				Expect(statefulSet.Spec.Template.Annotations).NotTo(HaveKey("korifi.cloudfoundry.org/log-rate-limit-bytes-per-second"))
+			})
+		})
 	})
 
 	It("should produce a stable statefulset regardless of labels iteration order", func() {
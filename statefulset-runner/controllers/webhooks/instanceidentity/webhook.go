@@ -0,0 +1,74 @@
+package instanceidentity
+
+//+kubebuilder:webhook:path=/mutate-v1-pod-instance-identity,mutating=true,failurePolicy=fail,sideEffects=None,groups="",resources=pods,verbs=create,versions=v1,name=mpodinstanceidentity.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/appworkload"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Webhook points the instance identity volume of app workload pods at the
+// secret of the pod instance. All pods of a statefulset share the same
+// template, so the secret of the instance can only be set on pod creation.
+type Webhook struct {
+	decoder admission.Decoder
+}
+
+var log = logf.Log.WithName("instance-identity-webhook")
+
+func NewWebhook() *Webhook {
+	return &Webhook{}
+}
+
+func (r *Webhook) SetupWebhookWithManager(mgr ctrl.Manager) {
+	mgr.GetWebhookServer().Register("/mutate-v1-pod-instance-identity", &admission.Webhook{
+		Handler: r,
+	})
+	r.decoder = admission.NewDecoder(mgr.GetScheme())
+}
+
+func (r *Webhook) Handle(ctx context.Context, req admission.Request) admission.Response {
+	var pod corev1.Pod
+	if err := r.decoder.Decode(req, &pod); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	volume := instanceIdentityVolume(&pod)
+	if volume == nil {
+		return admission.Allowed("pod has no instance identity volume")
+	}
+
+	index, ok := pod.Labels[korifiv1alpha1.PodIndexLabelKey]
+	if !ok {
+		return admission.Denied("pod has no instance index label")
+	}
+
+	volume.Secret.SecretName = korifiv1alpha1.InstanceIdentityInstanceSecretName(volume.Secret.SecretName, index)
+	log.V(1).Info("setting instance identity secret", "pod", pod.Name, "secretName", volume.Secret.SecretName)
+
+	rawUpdatedPod, err := json.Marshal(pod)
+	if err != nil {
+		log.Error(err, "failed to marshall pod")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, rawUpdatedPod)
+}
+
+func instanceIdentityVolume(pod *corev1.Pod) *corev1.Volume {
+	for i := range pod.Spec.Volumes {
+		if pod.Spec.Volumes[i].Name == appworkload.InstanceIdentityVolume && pod.Spec.Volumes[i].Secret != nil {
+			return &pod.Spec.Volumes[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package instanceidentity_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("InstanceIdentityWebhook", func() {
+	var (
+		pod       *corev1.Pod
+		createErr error
+	)
+
+	BeforeEach(func() {
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      uuid.NewString(),
+				Labels: map[string]string{
+					korifiv1alpha1.PodIndexLabelKey: "3",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "application",
+					Image: "some-image",
+				}},
+				Volumes: []corev1.Volume{
+					{
+						Name: "cf-instance-credentials",
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{
+								SecretName: "identity-secret",
+							},
+						},
+					},
+					{
+						Name: "other-volume",
+						VolumeSource: corev1.VolumeSource{
+							Secret: &corev1.SecretVolumeSource{
+								SecretName: "other-secret",
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		createErr = adminClient.Create(ctx, pod)
+	})
+
+	It("points the instance identity volume at the secret of the pod instance", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+		Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+		Expect(pod.Spec.Volumes).To(ConsistOf(
+			HaveField("Secret.SecretName", "identity-secret-3"),
+			HaveField("Secret.SecretName", "other-secret"),
+		))
+	})
+
+	When("the pod has no instance identity volume", func() {
+		BeforeEach(func() {
+			pod.Spec.Volumes = pod.Spec.Volumes[1:]
+		})
+
+		It("does not change the pod volumes", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(pod), pod)).To(Succeed())
+			Expect(pod.Spec.Volumes).To(ConsistOf(
+				HaveField("Secret.SecretName", "other-secret"),
+			))
+		})
+	})
+
+	When("the pod has no instance index label", func() {
+		BeforeEach(func() {
+			pod.Labels = nil
+		})
+
+		It("rejects the pod", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("pod has no instance index label")))
+		})
+	})
+})
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/appworkload/state"
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/runnerinfo"
 	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/webhooks/finalizer"
+	"code.cloudfoundry.org/korifi/statefulset-runner/controllers/webhooks/instanceidentity"
 	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/version"
 	"go.uber.org/zap/zapcore"
@@ -84,6 +85,7 @@ func main() {
 	}
 
 	finalizer.NewWebhook().SetupWebhookWithManager(mgr)
+	instanceidentity.NewWebhook().SetupWebhookWithManager(mgr)
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
@@ -1,11 +1,15 @@
 package image
 
 import (
+	"archive/tar"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/buildpacks/pack/pkg/archive"
@@ -13,7 +17,9 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
@@ -40,6 +46,7 @@ type Config struct {
 	Labels       map[string]string
 	User         string
 	ExposedPorts []int32
+	Digest       string
 }
 
 func NewClient(clietnset kubernetes.Interface) Client {
@@ -72,6 +79,92 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 		return "", fmt.Errorf("failed to append layer: %w", err)
 	}
 
+	return c.push(ctx, creds, repoRef, image, tags...)
+}
+
+// PushDroplet pushes a CF droplet tarball (a gzipped tar of the staged app)
+// as a single layer on top of the given base image, typically the run image
+// of the droplet stack. When baseImageRef is empty the droplet is pushed on
+// top of an empty image.
+func (c Client) PushDroplet(ctx context.Context, creds Creds, repoRef string, baseImageRef string, dropletReader io.Reader, tags ...string) (string, error) {
+	tmpFile, err := os.CreateTemp(os.TempDir(), "droplet-%s")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temp file for droplet: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	if _, err = io.Copy(tmpFile, dropletReader); err != nil {
+		return "", fmt.Errorf("failed to copy droplet into temp file '%s' %w", tmpFile.Name(), err)
+	}
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return os.Open(tmpFile.Name())
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create a layer out of '%s': %w", tmpFile.Name(), err)
+	}
+
+	baseImage, err := c.baseImage(ctx, creds, baseImageRef)
+	if err != nil {
+		return "", err
+	}
+
+	image, err := mutate.AppendLayers(baseImage, layer)
+	if err != nil {
+		return "", fmt.Errorf("failed to append layer: %w", err)
+	}
+
+	return c.push(ctx, creds, repoRef, image, tags...)
+}
+
+func (c Client) baseImage(ctx context.Context, creds Creds, baseImageRef string) (v1.Image, error) {
+	if baseImageRef == "" {
+		return empty.Image, nil
+	}
+
+	ref, err := name.ParseReference(baseImageRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing base image reference %s: %w", baseImageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	baseImage, err := remote.Image(ref, authOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get base image %s: %w", baseImageRef, err)
+	}
+
+	return baseImage, nil
+}
+
+// PushTarball pushes either a `docker save` style tarball or an OCI image
+// layout archive
+func (c Client) PushTarball(ctx context.Context, creds Creds, repoRef string, tarReader io.Reader, tags ...string) (string, error) {
+	tmpFile, err := os.CreateTemp(os.TempDir(), "imagetarball-%s")
+	if err != nil {
+		return "", fmt.Errorf("failed to create a temp file for image: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+	defer tmpFile.Close()
+
+	if _, err = io.Copy(tmpFile, tarReader); err != nil {
+		return "", fmt.Errorf("failed to copy image tarball into temp file '%s' %w", tmpFile.Name(), err)
+	}
+
+	image, cleanup, err := imageFromTarball(tmpFile.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read image tarball '%s': %w", tmpFile.Name(), err)
+	}
+	defer cleanup()
+
+	return c.push(ctx, creds, repoRef, image, tags...)
+}
+
+func (c Client) push(ctx context.Context, creds Creds, repoRef string, image v1.Image, tags ...string) (string, error) {
 	ref, err := name.ParseReference(repoRef)
 	if err != nil {
 		return "", fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
@@ -106,6 +199,156 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 	return refWithDigest.Name(), nil
 }
 
+// imageFromTarball loads either a `docker save` style tarball or an OCI image
+// layout archive, such as the buildpackages created by `pack buildpack package --format file`.
+// The returned cleanup func must be called once the image is no longer used.
+func imageFromTarball(tarPath string) (v1.Image, func(), error) {
+	noCleanup := func() {}
+
+	isLayout, err := isOCILayoutArchive(tarPath)
+	if err != nil {
+		return nil, noCleanup, err
+	}
+
+	if !isLayout {
+		image, err := tarball.ImageFromPath(tarPath, nil)
+		return image, noCleanup, err
+	}
+
+	layoutDir, err := os.MkdirTemp(os.TempDir(), "imagelayout-")
+	if err != nil {
+		return nil, noCleanup, fmt.Errorf("failed to create a temp dir for the image layout: %w", err)
+	}
+	cleanup := func() { _ = os.RemoveAll(layoutDir) }
+
+	if err = extractTar(tarPath, layoutDir); err != nil {
+		cleanup()
+		return nil, noCleanup, err
+	}
+
+	index, err := layout.ImageIndexFromPath(layoutDir)
+	if err != nil {
+		cleanup()
+		return nil, noCleanup, fmt.Errorf("failed to read image layout: %w", err)
+	}
+
+	indexManifest, err := index.IndexManifest()
+	if err != nil {
+		cleanup()
+		return nil, noCleanup, fmt.Errorf("failed to read image layout index: %w", err)
+	}
+
+	if len(indexManifest.Manifests) == 0 {
+		cleanup()
+		return nil, noCleanup, errors.New("the image layout does not contain any images")
+	}
+
+	image, err := index.Image(indexManifest.Manifests[0].Digest)
+	if err != nil {
+		cleanup()
+		return nil, noCleanup, fmt.Errorf("failed to read image from layout: %w", err)
+	}
+
+	return image, cleanup, nil
+}
+
+func isOCILayoutArchive(tarPath string) (bool, error) {
+	tarFile, err := os.Open(tarPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to open tarball: %w", err)
+	}
+	defer tarFile.Close()
+
+	tarReader := tar.NewReader(tarFile)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to read tarball: %w", err)
+		}
+
+		if path.Clean(header.Name) == "oci-layout" {
+			return true, nil
+		}
+	}
+}
+
+func extractTar(tarPath, destDir string) error {
+	tarFile, err := os.Open(tarPath)
+	if err != nil {
+		return fmt.Errorf("failed to open tarball: %w", err)
+	}
+	defer tarFile.Close()
+
+	tarReader := tar.NewReader(tarFile)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read tarball: %w", err)
+		}
+
+		target := filepath.Join(destDir, filepath.Clean("/"+header.Name))
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err = os.MkdirAll(target, 0o700); err != nil {
+				return fmt.Errorf("failed to create dir %q: %w", target, err)
+			}
+		case tar.TypeReg:
+			if err = extractFile(tarReader, target); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func extractFile(reader io.Reader, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return fmt.Errorf("failed to create dir for %q: %w", target, err)
+	}
+
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to create file %q: %w", target, err)
+	}
+	defer file.Close()
+
+	if _, err = io.Copy(file, reader); err != nil { // #nosec G110
+		return fmt.Errorf("failed to extract file %q: %w", target, err)
+	}
+
+	return nil
+}
+
+// Export streams the image as a `docker save` style tarball
+func (c Client) Export(ctx context.Context, creds Creds, imageRef string) (io.ReadCloser, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(tarball.Write(ref, img, writer))
+	}()
+
+	return reader, nil
+}
+
 func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Config, error) {
 	ref, err := name.ParseReference(imageRef)
 	if err != nil {
@@ -117,7 +360,12 @@ func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Confi
 		return Config{}, fmt.Errorf("error creating keychain: %w", err)
 	}
 
-	img, err := remote.Image(ref, authOpt)
+	desc, err := remote.Get(ref, authOpt)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	img, err := desc.Image()
 	if err != nil {
 		return Config{}, fmt.Errorf("failed to get image: %w", err)
 	}
@@ -140,6 +388,7 @@ func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Confi
 		Labels:       cfgFile.Config.Labels,
 		User:         cfgFile.Config.User,
 		ExposedPorts: ports,
+		Digest:       desc.Digest.String(),
 	}, nil
 }
 
@@ -1,11 +1,18 @@
 package image_test
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
 	"os"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/tests/helpers/oci"
 	"code.cloudfoundry.org/korifi/tools/image"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/random"
 	"github.com/google/uuid"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -129,6 +136,152 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("Export and PushTarball", func() {
+		var (
+			sourceRef  string
+			exportErr  error
+			tarballRef string
+		)
+
+		BeforeEach(func() {
+			var err error
+			sourceRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			var exported io.ReadCloser
+			exported, exportErr = imgClient.Export(ctx, creds, sourceRef)
+			if exportErr != nil {
+				return
+			}
+			defer exported.Close()
+
+			tarballRef, testErr = imgClient.PushTarball(ctx, creds, containerRegistry.ImageRef("foo/copy"), exported, "jim")
+		})
+
+		It("pushes the exported image unchanged", func() {
+			Expect(exportErr).NotTo(HaveOccurred())
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(tarballRef).To(HavePrefix(containerRegistry.ImageRef("foo/copy")))
+			Expect(strings.Split(tarballRef, "@")[1]).To(Equal(strings.Split(sourceRef, "@")[1]))
+
+			_, err := imgClient.Config(ctx, creds, containerRegistry.ImageRef("foo/copy")+":jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the image does not exist", func() {
+			BeforeEach(func() {
+				sourceRef = containerRegistry.ImageRef("not/there")
+			})
+
+			It("fails to export", func() {
+				Expect(exportErr).To(MatchError(ContainSubstring("failed to get image")))
+			})
+		})
+	})
+
+	Describe("PushTarball", func() {
+		When("the tarball is an OCI image layout archive", func() {
+			var (
+				layoutImage v1.Image
+				layoutTar   *os.File
+				pushedRef   string
+			)
+
+			BeforeEach(func() {
+				var err error
+				layoutImage, err = random.Image(64, 2)
+				Expect(err).NotTo(HaveOccurred())
+
+				layoutDir := GinkgoT().TempDir()
+				layoutPath, err := layout.Write(layoutDir, empty.Index)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(layoutPath.AppendImage(layoutImage)).To(Succeed())
+
+				layoutTar, err = os.CreateTemp(GinkgoT().TempDir(), "buildpackage-*.cnb")
+				Expect(err).NotTo(HaveOccurred())
+				tarWriter := tar.NewWriter(layoutTar)
+				Expect(tarWriter.AddFS(os.DirFS(layoutDir))).To(Succeed())
+				Expect(tarWriter.Close()).To(Succeed())
+				_, err = layoutTar.Seek(0, io.SeekStart)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			JustBeforeEach(func() {
+				pushedRef, testErr = imgClient.PushTarball(ctx, creds, containerRegistry.ImageRef("foo/buildpackage"), layoutTar)
+			})
+
+			It("pushes the image from the layout", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+
+				layoutDigest, err := layoutImage.Digest()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(pushedRef).To(Equal(containerRegistry.ImageRef("foo/buildpackage") + "@" + layoutDigest.String()))
+			})
+		})
+	})
+
+	Describe("PushDroplet", func() {
+		var (
+			dropletFile  *os.File
+			baseImageRef string
+			pushedRef    string
+		)
+
+		BeforeEach(func() {
+			var err error
+			baseImageRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			dropletFile, err = os.CreateTemp(GinkgoT().TempDir(), "droplet-*.tgz")
+			Expect(err).NotTo(HaveOccurred())
+			gzipWriter := gzip.NewWriter(dropletFile)
+			tarWriter := tar.NewWriter(gzipWriter)
+			Expect(tarWriter.WriteHeader(&tar.Header{Name: "app/run.sh", Mode: 0o755, Size: 4})).To(Succeed())
+			_, err = tarWriter.Write([]byte("echo"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tarWriter.Close()).To(Succeed())
+			Expect(gzipWriter.Close()).To(Succeed())
+			_, err = dropletFile.Seek(0, io.SeekStart)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			pushedRef, testErr = imgClient.PushDroplet(ctx, creds, containerRegistry.ImageRef("foo/droplet"), baseImageRef, dropletFile, "jim")
+		})
+
+		It("pushes the droplet on top of the base image", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(pushedRef).To(HavePrefix(containerRegistry.ImageRef("foo/droplet") + "@"))
+			Expect(strings.Split(pushedRef, "@")[1]).NotTo(Equal(strings.Split(baseImageRef, "@")[1]))
+
+			_, err := imgClient.Config(ctx, creds, containerRegistry.ImageRef("foo/droplet")+":jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the base image is not set", func() {
+			BeforeEach(func() {
+				baseImageRef = ""
+			})
+
+			It("pushes the droplet on top of an empty image", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+				Expect(pushedRef).To(HavePrefix(containerRegistry.ImageRef("foo/droplet") + "@"))
+			})
+		})
+
+		When("the base image does not exist", func() {
+			BeforeEach(func() {
+				baseImageRef = containerRegistry.ImageRef("not/there")
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to get base image")))
+			})
+		})
+	})
+
 	Describe("Config", func() {
 		var config image.Config
 
@@ -145,6 +298,7 @@ var _ = Describe("Client", func() {
 			Expect(config.Labels).To(Equal(map[string]string{"foo": "bar"}))
 			Expect(config.User).To(Equal("my-user"))
 			Expect(config.ExposedPorts).To(ConsistOf(int32(123), int32(456)))
+			Expect(config.Digest).To(HavePrefix("sha256:"))
 		})
 
 		When("the ref is invalid", func() {
@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const logRateLimitExceededMessage = "app instance exceeded log rate limit (%d bytes/sec)"
+
+// LogRateLimiter limits the log lines of an app instance to a number of bytes
+// per second. Once the limit is exceeded within a second, the rest of the
+// lines of that second are dropped and a single notice is emitted instead, the
+// way Cloud Foundry reports instances exceeding their log rate limit
+type LogRateLimiter struct {
+	bytesPerSecond int64
+	currentSecond  int64
+	bytesInSecond  int64
+	throttled      bool
+}
+
+// NewLogRateLimiter returns a limiter for the given number of bytes per
+// second, as held by the log rate limit annotation of workload pods. It
+// returns nil if the limit is not a valid non-negative integer. A nil
+// limiter lets every line through
+func NewLogRateLimiter(bytesPerSecond string) *LogRateLimiter {
+	limit, err := strconv.ParseInt(bytesPerSecond, 10, 64)
+	if err != nil || limit < 0 {
+		return nil
+	}
+
+	return &LogRateLimiter{bytesPerSecond: limit}
+}
+
+// Limit returns the line to emit for the given log line and whether anything
+// should be emitted at all. Lines are accounted to the second of their
+// timestamp, so the limiter expects them in chronological order
+func (l *LogRateLimiter) Limit(timestamp time.Time, line string) (string, bool) {
+	if l == nil {
+		return line, true
+	}
+
+	second := timestamp.Unix()
+	if second != l.currentSecond {
+		l.currentSecond = second
+		l.bytesInSecond = 0
+		l.throttled = false
+	}
+
+	if l.throttled {
+		return "", false
+	}
+
+	l.bytesInSecond += int64(len(line))
+	if l.bytesInSecond > l.bytesPerSecond {
+		l.throttled = true
+		return fmt.Sprintf(logRateLimitExceededMessage, l.bytesPerSecond), true
+	}
+
+	return line, true
+}
@@ -0,0 +1,65 @@
+package tools_test
+
+import (
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+var _ = Describe("LogRateLimiter", func() {
+	var (
+		limiter *tools.LogRateLimiter
+		second  time.Time
+	)
+
+	BeforeEach(func() {
+		limiter = tools.NewLogRateLimiter("15")
+		second = time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+	})
+
+	It("lets lines within the limit through", func() {
+		line, ok := limiter.Limit(second, "first line")
+		Expect(ok).To(BeTrue())
+		Expect(line).To(Equal("first line"))
+	})
+
+	When("the limit is exceeded within a second", func() {
+		BeforeEach(func() {
+			_, ok := limiter.Limit(second, "first line")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("replaces the first exceeding line with a notice and drops the rest of the second", func() {
+			line, ok := limiter.Limit(second.Add(time.Millisecond), "second line")
+			Expect(ok).To(BeTrue())
+			Expect(line).To(Equal("app instance exceeded log rate limit (15 bytes/sec)"))
+
+			_, ok = limiter.Limit(second.Add(2*time.Millisecond), "third line")
+			Expect(ok).To(BeFalse())
+
+			line, ok = limiter.Limit(second.Add(time.Second), "fourth line")
+			Expect(ok).To(BeTrue())
+			Expect(line).To(Equal("fourth line"))
+		})
+	})
+
+	When("the limit is invalid", func() {
+		BeforeEach(func() {
+			limiter = tools.NewLogRateLimiter("-1")
+		})
+
+		It("does not create a limiter", func() {
+			Expect(limiter).To(BeNil())
+		})
+
+		It("lets every line through", func() {
+			line, ok := limiter.Limit(second, strings.Repeat("a", 100))
+			Expect(ok).To(BeTrue())
+			Expect(line).To(HaveLen(100))
+		})
+	})
+})